@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"order-service/internal/bootstrap"
 	"order-service/internal/config"
+	"os"
+	"os/signal"
+	"syscall"
 
 	_ "order-service/docs" // Import swagger docs
 )
@@ -27,16 +31,21 @@ func main() {
 	port := flag.Int("port", 0, "Port to listen on (overrides config file)")
 	flag.Parse()
 
+	// Cancelled on SIGINT/SIGTERM to stop background jobs and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Initialize configuration and all required components
 	viperConfig := config.NewViper()
 	appConfig := config.NewAppConfig(viperConfig)
 	log := config.NewLogger(viperConfig)
 	db := config.NewDatabase(viperConfig, log)
-	validate := config.NewValidator(viperConfig)
+	validate := config.NewValidator(viperConfig, log)
 	app := config.NewFiber(viperConfig)
 
 	// Bootstrap the application
 	bootstrap.Bootstrap(&bootstrap.BootstrapConfig{
+		Context:  ctx,
 		DB:       db,
 		App:      app,
 		Log:      log,
@@ -51,6 +60,15 @@ func main() {
 		log.Infof("Overriding config port with command line port: %d", webPort)
 	}
 
+	// Shut down gracefully once a termination signal arrives
+	go func() {
+		<-ctx.Done()
+		log.Info("Shutting down server...")
+		if err := app.Shutdown(); err != nil {
+			log.Errorf("Failed to shut down server: %v", err)
+		}
+	}()
+
 	// Start the server
 	log.Infof("Starting server on port %d", webPort)
 	err := app.Listen(fmt.Sprintf("0.0.0.0:%d", webPort))
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reservation_release_outbox;
\ No newline at end of file
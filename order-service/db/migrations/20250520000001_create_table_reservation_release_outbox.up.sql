@@ -0,0 +1,17 @@
+CREATE TABLE reservation_release_outbox (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    order_id        BIGINT UNSIGNED NOT NULL,
+    product_id      BIGINT UNSIGNED NOT NULL,
+    warehouse_id    BIGINT UNSIGNED NOT NULL,
+    quantity        INT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts        INT NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_order_id (order_id),
+    INDEX idx_status (status),
+    INDEX idx_status_updated_at (status, updated_at),
+    CONSTRAINT fk_reservation_release_outbox_order_id FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
\ No newline at end of file
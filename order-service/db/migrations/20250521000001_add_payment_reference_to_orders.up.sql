@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN payment_reference VARCHAR(100) NULL AFTER payment_deadline;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    ADD COLUMN payment_attempts INT NOT NULL DEFAULT 0 AFTER payment_reference;
\ No newline at end of file
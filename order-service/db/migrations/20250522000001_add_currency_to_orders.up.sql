@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD' AFTER total_amount;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN reservation_reference VARCHAR(100) NULL AFTER payment_attempts;
\ No newline at end of file
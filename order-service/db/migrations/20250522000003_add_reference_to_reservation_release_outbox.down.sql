@@ -0,0 +1 @@
+ALTER TABLE reservation_release_outbox DROP COLUMN reference;
\ No newline at end of file
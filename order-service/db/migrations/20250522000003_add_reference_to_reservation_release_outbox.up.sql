@@ -0,0 +1 @@
+ALTER TABLE reservation_release_outbox ADD COLUMN reference VARCHAR(100) NULL AFTER quantity;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE coupons (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    code            VARCHAR(50) NOT NULL,
+    discount_type   ENUM('percentage', 'fixed') NOT NULL,
+    discount_value  DECIMAL(10, 2) NOT NULL,
+    currency        CHAR(3) NULL,
+    expires_at      TIMESTAMP NULL,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_code (code)
+) ENGINE = InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
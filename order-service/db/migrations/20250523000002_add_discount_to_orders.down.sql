@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP COLUMN discount_amount,
+    DROP COLUMN coupon_code;
\ No newline at end of file
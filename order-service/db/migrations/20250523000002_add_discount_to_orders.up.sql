@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN coupon_code VARCHAR(50) NULL AFTER currency,
+    ADD COLUMN discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER coupon_code;
\ No newline at end of file
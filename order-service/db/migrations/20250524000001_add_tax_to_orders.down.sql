@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP COLUMN tax_amount,
+    DROP COLUMN tax_rate,
+    DROP COLUMN subtotal;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE orders
+    ADD COLUMN subtotal DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER status,
+    ADD COLUMN tax_rate DECIMAL(6, 3) NOT NULL DEFAULT 0 AFTER discount_amount,
+    ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER tax_rate;
+
+-- Existing orders were untaxed, so their subtotal is the total before the discount
+UPDATE orders SET subtotal = total_amount + discount_amount;
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    DROP COLUMN shipping_cost;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    ADD COLUMN shipping_cost DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER tax_amount;
\ No newline at end of file
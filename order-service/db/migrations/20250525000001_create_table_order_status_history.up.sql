@@ -0,0 +1,11 @@
+CREATE TABLE order_status_history (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    order_id        BIGINT UNSIGNED NOT NULL,
+    from_status     VARCHAR(20) NOT NULL,
+    to_status       VARCHAR(20) NOT NULL,
+    actor           VARCHAR(100) NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_order_id (order_id),
+    CONSTRAINT fk_order_status_history_order_id FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
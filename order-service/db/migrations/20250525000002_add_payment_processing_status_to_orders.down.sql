@@ -0,0 +1,5 @@
+-- Charges still in flight are left for the customer to retry
+UPDATE orders SET status = 'pending' WHERE status = 'payment_processing';
+
+ALTER TABLE orders
+    MODIFY COLUMN status ENUM('pending', 'paid', 'cancelled', 'completed') NOT NULL DEFAULT 'pending';
\ No newline at end of file
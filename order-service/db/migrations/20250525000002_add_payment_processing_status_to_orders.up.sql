@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    MODIFY COLUMN status ENUM('pending', 'payment_processing', 'paid', 'cancelled', 'completed') NOT NULL DEFAULT 'pending';
\ No newline at end of file
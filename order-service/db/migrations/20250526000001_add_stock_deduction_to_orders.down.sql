@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP COLUMN stock_deduction_error,
+    DROP COLUMN stock_deduction_attempts,
+    DROP COLUMN stock_deducted_at;
\ No newline at end of file
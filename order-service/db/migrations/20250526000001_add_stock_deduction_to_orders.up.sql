@@ -0,0 +1,7 @@
+ALTER TABLE orders
+    ADD COLUMN stock_deducted_at DATETIME NULL AFTER payment_reference,
+    ADD COLUMN stock_deduction_attempts INT NOT NULL DEFAULT 0 AFTER stock_deducted_at,
+    ADD COLUMN stock_deduction_error TEXT NULL AFTER stock_deduction_attempts;
+
+-- Orders paid before stock deductions were tracked are assumed to have been deducted
+UPDATE orders SET stock_deducted_at = updated_at WHERE status IN ('paid', 'completed');
\ No newline at end of file
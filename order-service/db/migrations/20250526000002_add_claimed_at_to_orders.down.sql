@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    DROP COLUMN claimed_at;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    ADD COLUMN claimed_at DATETIME NULL AFTER stock_deduction_error;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE webhook_events (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    event_type      VARCHAR(50) NOT NULL,
+    order_id        BIGINT UNSIGNED NOT NULL,
+    payload         TEXT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT,
+    delivered_at    TIMESTAMP NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_order_id (order_id),
+    INDEX idx_status_next_attempt_at (status, next_attempt_at),
+    INDEX idx_status_updated_at (status, updated_at),
+    CONSTRAINT fk_webhook_events_order_id FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
\ No newline at end of file
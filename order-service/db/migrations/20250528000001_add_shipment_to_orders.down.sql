@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP COLUMN completed_at,
+    DROP COLUMN tracking_number,
+    DROP COLUMN carrier;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    ADD COLUMN carrier VARCHAR(50) NULL AFTER stock_deduction_error,
+    ADD COLUMN tracking_number VARCHAR(100) NULL AFTER carrier,
+    ADD COLUMN completed_at DATETIME NULL AFTER tracking_number;
\ No newline at end of file
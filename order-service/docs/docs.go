@@ -22,6 +22,63 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/carts/validate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Checks that every cart item is an active product at its current price with enough stock, without creating an order or reserving stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Validate a shopping cart",
+                "parameters": [
+                    {
+                        "description": "Cart items",
+                        "name": "cart",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ValidateCartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CartValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/config/warehouse": {
             "get": {
                 "security": [
@@ -114,6 +171,148 @@ const docTemplate = `{
                 }
             }
         },
+        "/health": {
+            "get": {
+                "description": "Reports that the process is up without checking any dependency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Pings the database and the warehouse and product services. Returns 503 with the status of each dependency when any is down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        },
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/availability": {
+            "post": {
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Check stock availability",
+                "description": "Reports the stock available for each item and whether the whole request can be fulfilled. Nothing is reserved or written; use this before placing an order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "description": "Items to check",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
         "/inventory/batch": {
             "post": {
                 "security": [
@@ -178,7 +377,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Confirms the deduction of reserved stock for an order (typically after payment). Calls the warehouse service endpoint at /api/v1/inventory/commit.",
+                "description": "Confirms the deduction of reserved stock for an order (typically after payment)",
                 "consumes": [
                     "application/json"
                 ],
@@ -235,7 +434,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Releases a previously created stock reservation, making the stock available again. Calls the warehouse service endpoint at /api/v1/inventory/cancel.",
+                "description": "Releases a previously created stock reservation, making the stock available again",
                 "consumes": [
                     "application/json"
                 ],
@@ -292,7 +491,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates stock reservations for items in an order. Uses either synchronous HTTP calls or asynchronous messaging based on configuration.",
+                "description": "Creates stock reservations for items in an order under one reference, returned as reservation_id. A reference is generated when none is given.",
                 "consumes": [
                     "application/json"
                 ],
@@ -417,7 +616,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns paginated list of orders for the specified user ID",
+                "description": "Returns paginated list of the authenticated user's orders. Only admins and internal services may name another user in user_id.",
                 "produces": [
                     "application/json"
                 ],
@@ -440,19 +639,34 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Items per page (defaults to 10, max 100)",
+                        "description": "Items per page (defaults to 10; larger values are clamped to the maximum, 100 by default)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by order status (pending, payment_processing, paid, cancelled, completed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or after this RFC3339 timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or before this RFC3339 timestamp",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.OrderResponse"
-                            }
+                            "$ref": "#/definitions/model.OrderListResponse"
                         }
                     },
                     "400": {
@@ -467,6 +681,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -481,7 +701,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new order with items",
+                "description": "Create a new order with items. An optional coupon_code discounts the order; the discount is computed from the item subtotal, and unknown, inactive or expired codes are rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -522,44 +742,67 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/orders/{id}": {
+        "/orders/export": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns order details for the specified ID",
+                "description": "Stream the orders created within a date range as CSV, oldest first. Requires the admin role. to defaults to now and from to the maximum range before to; a range longer than order.export_max_range (default 31 days) is rejected.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
                     "Orders"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Export orders as CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Only orders with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or after this time (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or before this time (RFC3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "CSV with columns order_id, user_id, status, total_amount, currency, created_at, item_count",
                         "schema": {
-                            "$ref": "#/definitions/model.OrderResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -574,8 +817,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -589,35 +832,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/payment": {
-            "post": {
+        "/orders/search": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Process payment for a pending order",
+                "description": "Returns a paginated list of the orders matching every given criterion, newest first. Requires the admin role. At least one of order_id, user_id, status, payment_method, from or to is required.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Orders"
                 ],
-                "summary": "Process payment for an order",
+                "summary": "Search orders",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the order owner",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order status (pending, payment_processing, paid, cancelled, completed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payment method",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or after this RFC3339 timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or before this RFC3339 timestamp",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (defaults to 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (defaults to 10; larger values are clamped to the maximum, 100 by default)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessResponse"
+                            "$ref": "#/definitions/model.OrderListResponse"
                         }
                     },
                     "400": {
@@ -632,8 +916,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -647,31 +931,594 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Update the status of an order",
-                "consumes": [
-                    "application/json"
+        "/orders/summary": {
+            "get": {
+                "tags": [
+                    "Orders"
                 ],
+                "summary": "Get an order summary for the authenticated user",
+                "description": "Returns the number of the authenticated user's orders in each status and the summed totals of their paid and completed orders per currency. A user without orders gets zero counts and an empty total_spent.",
                 "produces": [
                     "application/json"
                 ],
-                "tags": [
-                    "Orders"
-                ],
-                "summary": "Update order status",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderSummaryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns order details for the specified ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related data to include (reservations)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/complete": {
+            "post": {
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Complete a shipped order",
+                "description": "Confirm the shipment of a paid order and mark it completed, storing the carrier and tracking number. Requires the admin role. Only paid orders can be completed; other orders fail with ORDER_NOT_PAID, or ORDER_ALREADY_COMPLETED when completed before.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "description": "Order ID",
+                        "required": true,
+                        "type": "integer"
+                    },
+                    {
+                        "name": "shipment",
+                        "in": "body",
+                        "description": "Shipment details",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CompleteOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the status changes of an order, oldest first, with who made each change. Background jobs are recorded as the \"system\" actor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderStatusHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/items/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel specific line items of a pending order and release their stock reservations. Only the order owner or an admin may cancel items, and not while the order's payment is in progress.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel order items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Items to cancel, matched by product and warehouse",
+                        "name": "items",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CancelOrderItemsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payment": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Process payment for a pending order. Only the order owner or an admin may pay for the order. While the charge is in flight the order is in the payment_processing status, and other payments for it are refused.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Process payment for an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/reactivate": {
+            "post": {
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Reactivate a cancelled order",
+                "description": "Undo the cancellation of an order whose original payment deadline has not passed. Requires the admin role. The stock of its items is reserved again and the order goes back to pending. Fails with INSUFFICIENT_STOCK when an item no longer has enough stock.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "description": "Order ID",
+                        "required": true,
+                        "type": "integer"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/reconcile": {
+            "post": {
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Reconcile the stock deduction of a paid order",
+                "description": "Retry the stock deduction of a paid order whose deduction failed after payment. Requires the admin role. An order whose stock is already deducted is reported as already_reconciled without calling the warehouse.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "description": "Order ID",
+                        "required": true,
+                        "type": "integer"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconcileOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the status of an order. Requires the admin role. An order whose payment is in progress cannot be changed until the payment finishes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "description": "New order status",
@@ -702,12 +1549,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -724,7 +1583,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns all stock reservations for the specified order ID",
+                "description": "Returns all stock reservations for the specified order ID. Requires the admin role; order owners see their reservations with GET /orders/{id}?include=reservations.",
                 "produces": [
                     "application/json"
                 ],
@@ -763,6 +1622,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -936,39 +1801,221 @@ const docTemplate = `{
                 }
             }
         },
-        "/warehouse/health": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Checks the health of the connection to the warehouse service",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Warehouse"
-                ],
-                "summary": "Check warehouse service connection",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.SuccessResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
+        "/warehouse/health": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Checks the health of the connection to the warehouse service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Check warehouse service connection",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "errors.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "JSON path of the field, e.g. items[0].quantity",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Validator tag that failed, e.g. gt",
+                    "type": "string"
+                }
+            }
+        },
+        "errors.MinimumOrderShortfall": {
+            "type": "object",
+            "properties": {
+                "actual_amount": {
+                    "type": "number",
+                    "example": 42.5
+                },
+                "required_amount": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "errors.StockShortage": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "requested": {
+                    "type": "integer"
+                },
+                "shortfall": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AvailabilityItem": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AvailabilityRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItemRequest"
+                    }
+                }
+            }
+        },
+        "model.AvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "can_fulfil": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AvailabilityItem"
+                    }
+                }
+            }
+        },
+        "model.CancelOrderItemsRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItemRequest"
+                    }
+                }
+            }
+        },
+        "model.CartItemValidation": {
+            "type": "object",
+            "properties": {
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "current_price": {
+                    "type": "number"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "number"
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CartValidationResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CartItemValidation"
                     }
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "valid": {
+                    "type": "boolean"
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "model.CompleteOrderRequest": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "tracking_number"
+            ],
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
         "model.CreateOrderRequest": {
             "type": "object",
             "required": [
@@ -978,6 +2025,14 @@ const docTemplate = `{
                 "user_id"
             ],
             "properties": {
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "currency": {
+                    "description": "Defaults to the item currency, then USD",
+                    "type": "string"
+                },
                 "items": {
                     "type": "array",
                     "items": {
@@ -985,8 +2040,8 @@ const docTemplate = `{
                     }
                 },
                 "payment_method": {
-                    "type": "string",
-                    "maxLength": 50
+                    "description": "One of order.payment_methods; stored lowercased",
+                    "type": "string"
                 },
                 "shipping_address": {
                     "type": "string"
@@ -996,6 +2051,47 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CurrencyAmount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DependencyHealth": {
+            "description": "Status of a dependency such as the database or a downstream service",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "dial tcp: connection refused"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "model.HealthResponse": {
+            "description": "Service health with the status of each dependency",
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
         "model.InventoryBatchRequest": {
             "type": "object",
             "required": [
@@ -1060,11 +2156,13 @@ const docTemplate = `{
             "type": "object",
             "required": [
                 "product_id",
-                "quantity",
-                "unit_price",
                 "warehouse_id"
             ],
             "properties": {
+                "currency": {
+                    "description": "Must match the order currency when set",
+                    "type": "string"
+                },
                 "order_id": {
                     "description": "Added for compatibility with warehouse service",
                     "type": "integer"
@@ -1073,8 +2171,7 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "quantity": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
                 },
                 "unit_price": {
                     "type": "number",
@@ -1088,6 +2185,10 @@ const docTemplate = `{
         "model.OrderItemResponse": {
             "type": "object",
             "properties": {
+                "fulfillment_warehouse_id": {
+                    "description": "Warehouse the item ships from",
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -1097,6 +2198,10 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "reserved_warehouse_id": {
+                    "description": "Warehouse holding the stock reservation",
+                    "type": "integer"
+                },
                 "total_price": {
                     "type": "number"
                 },
@@ -1108,12 +2213,58 @@ const docTemplate = `{
                 }
             }
         },
+        "model.OrderListMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.OrderListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.OrderListMeta"
+                }
+            }
+        },
         "model.OrderResponse": {
             "type": "object",
             "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "currency": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -1129,15 +2280,43 @@ const docTemplate = `{
                 "payment_method": {
                     "type": "string"
                 },
+                "payment_reference": {
+                    "type": "string"
+                },
+                "reservation_summary": {
+                    "description": "ReservationSummary is only populated when requested with ?include=reservations",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReservationSummary"
+                        }
+                    ]
+                },
                 "shipping_address": {
                     "type": "string"
                 },
+                "shipping_cost": {
+                    "type": "number"
+                },
                 "status": {
                     "type": "string"
                 },
+                "subtotal": {
+                    "type": "number"
+                },
+                "tax_amount": {
+                    "type": "number"
+                },
+                "tax_rate": {
+                    "description": "Percentage",
+                    "type": "number"
+                },
                 "total_amount": {
+                    "description": "Subtotal less DiscountAmount plus TaxAmount and ShippingCost",
                     "type": "number"
                 },
+                "tracking_number": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -1146,6 +2325,90 @@ const docTemplate = `{
                 }
             }
         },
+        "model.OrderStatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "User ID of the caller, or \"system\" for background jobs",
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderStatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderStatusChangeResponse"
+                    }
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Current status",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "Owner of the order",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "status_counts": {
+                    "description": "Every status is present, zero when the user has no such orders",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_spent": {
+                    "description": "Summed total_amount of paid and completed orders, one entry per currency",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CurrencyAmount"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReconcileOrderResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Deduction attempts so far, including the one that succeeded",
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "result": {
+                    "description": "reconciled or already_reconciled",
+                    "type": "string"
+                },
+                "stock_deducted_at": {
+                    "type": "string"
+                }
+            }
+        },
         "model.ReservationRequest": {
             "type": "object",
             "required": [
@@ -1203,6 +2466,20 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ReservationSummary": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "committed": {
+                    "type": "integer"
+                },
+                "released": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.StockOperationRequest": {
             "type": "object",
             "required": [
@@ -1264,6 +2541,9 @@ const docTemplate = `{
                 "order_id": {
                     "type": "integer"
                 },
+                "reference": {
+                    "type": "string"
+                },
                 "reserve_until": {
                     "type": "string"
                 }
@@ -1353,14 +2633,51 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ValidateCartRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItemRequest"
+                    }
+                }
+            }
+        },
         "response.ErrorInfo": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string"
                 },
+                "fields": {
+                    "description": "Fields lists the request fields that failed validation, if any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.FieldError"
+                    }
+                },
+                "items": {
+                    "description": "Items lists the order items that lacked stock, if any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.StockShortage"
+                    }
+                },
                 "message": {
                     "type": "string"
+                },
+                "minimum_order": {
+                    "description": "MinimumOrder reports the required and actual amounts of an order below the minimum, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/errors.MinimumOrderShortfall"
+                        }
+                    ]
                 }
             }
         },
@@ -1376,6 +2693,18 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/response.ErrorInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
         "response.SuccessResponse": {
             "type": "object",
             "properties": {
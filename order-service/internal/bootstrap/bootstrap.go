@@ -1,13 +1,18 @@
 package bootstrap
 
 import (
+	"context"
+	"order-service/internal/auth"
 	"order-service/internal/config"
 	"order-service/internal/delivery/http/middleware"
 	"order-service/internal/delivery/http/route"
 	"order-service/internal/entity"
 	"order-service/internal/factory"
 	"order-service/internal/handler"
+	"order-service/internal/metrics"
+	"order-service/internal/scheduler"
 	"order-service/internal/usecase"
+	"slices"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -16,6 +21,8 @@ import (
 )
 
 type BootstrapConfig struct {
+	// Context is cancelled on shutdown to stop background jobs
+	Context  context.Context
 	DB       *gorm.DB
 	App      *fiber.App
 	Log      *logrus.Logger
@@ -35,23 +42,89 @@ func Bootstrap(config *BootstrapConfig) {
 	
 	config.Log.Info("Bootstrapping application...")
 
+	// Validate order configuration before wiring any dependencies
+	orderConfig := config.Config.GetOrderConfig()
+	if orderConfig.PaymentDeadline <= 0 {
+		config.Log.WithField("payment_deadline", orderConfig.PaymentDeadline.String()).Fatal("Order payment deadline must be positive")
+	}
+	if orderConfig.ExpiryScanInterval <= 0 {
+		config.Log.WithField("expiry_scan_interval", orderConfig.ExpiryScanInterval.String()).Fatal("Order expiry scan interval must be positive")
+	}
+	if orderConfig.ExportMaxRange <= 0 {
+		config.Log.WithField("export_max_range", orderConfig.ExportMaxRange.String()).Fatal("Order export max range must be positive")
+	}
+	if t := orderConfig.Timeouts; t.Read <= 0 || t.Write <= 0 || t.Commit <= 0 || t.Inventory <= 0 || t.Payment <= 0 {
+		config.Log.WithField("timeouts", orderConfig.Timeouts).Fatal("Order operation timeouts must be positive")
+	}
+	if orderConfig.TaxRate < 0 || orderConfig.TaxRate >= 100 {
+		config.Log.WithField("tax_rate", orderConfig.TaxRate).Fatal("Order tax rate must be at least 0 and below 100")
+	}
+	paginationConfig := config.Config.GetPaginationConfig()
+	if paginationConfig.DefaultLimit <= 0 || paginationConfig.MaxLimit <= 0 || paginationConfig.DefaultLimit > paginationConfig.MaxLimit {
+		config.Log.WithFields(logrus.Fields{
+			"default_limit": paginationConfig.DefaultLimit,
+			"max_limit":     paginationConfig.MaxLimit,
+		}).Fatal("Pagination limits must be positive and the default must not exceed the maximum")
+	}
+	shippingConfig := config.Config.GetShippingConfig()
+	if shippingConfig.BaseCost < 0 || shippingConfig.PerItemCost < 0 || shippingConfig.FreeThreshold < 0 {
+		config.Log.WithField("shipping", shippingConfig).Fatal("Shipping costs and free shipping threshold must not be negative")
+	}
+	webhookConfig := config.Config.GetWebhookConfig()
+	if webhookConfig.Enabled() {
+		if webhookConfig.Secret == "" {
+			config.Log.Fatal("webhook.secret must be configured to sign webhook deliveries")
+		}
+		if webhookConfig.Timeout <= 0 || webhookConfig.DispatchInterval <= 0 || webhookConfig.RetryBackoff <= 0 || webhookConfig.MaxRetryBackoff <= 0 {
+			config.Log.WithFields(logrus.Fields{
+				"timeout":           webhookConfig.Timeout.String(),
+				"dispatch_interval": webhookConfig.DispatchInterval.String(),
+				"retry_backoff":     webhookConfig.RetryBackoff.String(),
+				"max_retry_backoff": webhookConfig.MaxRetryBackoff.String(),
+			}).Fatal("Webhook timeout, dispatch interval and retry backoffs must be positive")
+		}
+		if webhookConfig.BatchSize <= 0 || webhookConfig.MaxAttempts < 0 {
+			config.Log.WithFields(logrus.Fields{
+				"batch_size":   webhookConfig.BatchSize,
+				"max_attempts": webhookConfig.MaxAttempts,
+			}).Fatal("Webhook batch size must be positive and max attempts must not be negative")
+		}
+	}
+
+	keyCleanupConfig := config.Config.GetKeyCleanupConfig()
+	if keyCleanupConfig.Retention <= 0 || keyCleanupConfig.Interval <= 0 || keyCleanupConfig.BatchSize <= 0 {
+		config.Log.WithFields(logrus.Fields{
+			"retention":  keyCleanupConfig.Retention.String(),
+			"interval":   keyCleanupConfig.Interval.String(),
+			"batch_size": keyCleanupConfig.BatchSize,
+		}).Fatal("Key cleanup retention, interval and batch size must be positive")
+	}
+
 	// Auto-migrate database if needed
 	if config.Config.Viper.GetBool("database.auto_migrate") {
 		config.Log.Info("Auto-migrating database tables...")
 		// Auto-migrate tables - removed Inventory entity as it's now handled by warehouse service
-		err := config.DB.AutoMigrate(&entity.Order{}, &entity.OrderItem{}, &entity.Reservation{})
+		err := config.DB.AutoMigrate(&entity.Order{}, &entity.OrderItem{}, &entity.Reservation{}, &entity.ReservationReleaseOutbox{}, &entity.Coupon{}, &entity.OrderStatusHistory{}, &entity.WebhookEvent{})
 		if err != nil {
 			config.Log.WithField("error", err.Error()).Fatal("Failed to migrate database")
 		}
 		config.Log.Info("Database migration completed")
 	}
 
+	// Register the Prometheus collectors only when metrics are enabled; a nil *Metrics records nothing
+	var appMetrics *metrics.Metrics
+	if config.Config.GetMetricsConfig().Enabled {
+		appMetrics = metrics.New()
+		config.Log.Info("Prometheus metrics enabled at /metrics")
+	}
+
 	// Create factory for dependency injection
 	appFactory := factory.NewFactory(
 		config.DB,
 		config.Config,
 		config.Log,
 		config.Validate,
+		appMetrics,
 	)
 
 	// Create repositories through factory
@@ -68,13 +141,105 @@ func Bootstrap(config *BootstrapConfig) {
 		orderRepository,
 	)
 
+	// Periodically cancel orders that were not paid in time
+	ctx := config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	scheduler.NewOrderExpiryScheduler(orderUseCase, orderConfig.ExpiryScanInterval, appMetrics, config.Log).Start(ctx)
+
+	// Periodically delete idempotency and operation keys past their retention so key tables stay bounded
+	keyCleanupUseCase := usecase.NewKeyCleanupUseCase(config.DB, config.Log, keyCleanupConfig.Retention, keyCleanupConfig.BatchSize,
+		usecase.KeyTable{Name: "reservation_releases", Purge: reservationRepository.DeleteDoneReservationReleases},
+		usecase.KeyTable{Name: "webhook_events", Purge: appFactory.CreateWebhookRepository().DeleteFinishedWebhookEvents},
+	)
+	scheduler.NewKeyPurgeScheduler(keyCleanupUseCase, keyCleanupConfig.Interval, config.Log).Start(ctx)
+
+	// Deliver queued order events to the webhook receiver
+	if webhookConfig.Enabled() {
+		scheduler.NewWebhookDispatcher(
+			config.DB,
+			appFactory.CreateWebhookRepository(),
+			appFactory.CreateWebhookClient(),
+			webhookConfig.DispatchInterval,
+			webhookConfig.BatchSize,
+			webhookConfig.MaxAttempts,
+			webhookConfig.RetryBackoff,
+			webhookConfig.MaxRetryBackoff,
+			config.Log,
+		).Start(ctx)
+		config.Log.WithField("url", webhookConfig.URL).Info("Order webhooks enabled")
+	}
+
 	// Setup handlers
 	orderHandler := handler.NewOrderHandler(orderUseCase, config.Log)
 	reservationHandler := handler.NewReservationHandler(reservationUseCase, config.Log)
-	warehouseHandler := handler.NewWarehouseHandler(config.Log, appFactory.CreateWarehouseGateway())
+	warehouseHandler := handler.NewWarehouseHandler(config.Log, appFactory.CreateWarehouseGateway(), appFactory.CreateInventoryUseCase())
+	cartHandler := handler.NewCartHandler(appFactory.CreateCartUseCase(), config.Log)
+	healthHandler := handler.NewHealthHandler(config.DB, map[string]handler.DependencyCheck{
+		"warehouse_service": appFactory.CreateWarehouseClient().Ping,
+		"product_service":   appFactory.CreateProductClient().Ping,
+	}, config.Log)
+
+	// Load tracing settings
+	tracingConfig := config.Config.GetTracingConfig()
+
+	// Create simple auth middleware; user access tokens are verified with the user service's JWT secret
+	// and internal callers' API keys against auth.api_keys
+	authConfig := config.Config.GetAuthConfig()
+	if authConfig.JWTSecret == "" {
+		config.Log.Fatal("jwt.secret must be configured to verify access tokens")
+	}
+	if err := authConfig.Validate(); err != nil {
+		config.Log.Fatal(err)
+	}
+	if authConfig.ActiveAPIKeys() == 0 {
+		config.Log.Warn("No active API keys configured in auth.api_keys; requests with X-API-Key will be rejected")
+	} else {
+		config.Log.WithField("active_keys", authConfig.ActiveAPIKeys()).Info("API keys loaded")
+	}
+	authMiddleware := middleware.NewSimpleAuthMiddleware(config.Log, auth.NewTokenVerifier(authConfig.JWTSecret),
+		auth.NewAPIKeyStore(authConfig.APIKeys))
 
-	// Create simple auth middleware
-	authMiddleware := middleware.NewSimpleAuthMiddleware(config.Log)
+	// Build one limiter per configured route group
+	rateLimiters := make(map[string]middleware.RateLimiter)
+	if rateLimitConfig := config.Config.GetRateLimitConfig(); rateLimitConfig.Enabled {
+		for group, limit := range rateLimitConfig.Groups {
+			if limit.Requests <= 0 || limit.Window <= 0 {
+				config.Log.WithField("group", group).Fatal("Rate limit requests and window must be positive")
+			}
+			rateLimiters[group] = middleware.NewMemoryRateLimiter(middleware.RateLimit{
+				Requests: limit.Requests,
+				Window:   limit.Window,
+			})
+		}
+	}
+
+	// Load access log settings
+	accessLogConfig := config.Config.GetAccessLogConfig()
+	accessLogLevel, err := logrus.ParseLevel(accessLogConfig.Level)
+	if err != nil {
+		config.Log.WithField("error", err.Error()).Fatal("Invalid access log level")
+	}
+	if accessLogConfig.SampleRate < 0 || accessLogConfig.SampleRate > 1 {
+		config.Log.WithField("sample_rate", accessLogConfig.SampleRate).Fatal("Access log sample rate must be between 0 and 1")
+	}
+
+	// Load CORS settings; methods and headers fall back to the middleware defaults
+	corsConfig := config.Config.GetCORSConfig()
+	if corsConfig.AllowCredentials && slices.Contains(corsConfig.AllowOrigins, "*") {
+		config.Log.Fatal("CORS allow_credentials cannot be combined with the * origin")
+	}
+	cors := middleware.DefaultCORSConfig()
+	cors.AllowOrigins = corsConfig.AllowOrigins
+	if len(corsConfig.AllowMethods) > 0 {
+		cors.AllowMethods = corsConfig.AllowMethods
+	}
+	if len(corsConfig.AllowHeaders) > 0 {
+		cors.AllowHeaders = corsConfig.AllowHeaders
+	}
+	cors.AllowCredentials = corsConfig.AllowCredentials
+	cors.MaxAge = corsConfig.MaxAge
 
 	// Configure routes
 	routeConfig := route.RouteConfig{
@@ -82,8 +247,23 @@ func Bootstrap(config *BootstrapConfig) {
 		OrderHandler:       orderHandler,
 		ReservationHandler: reservationHandler,
 		WarehouseHandler:   warehouseHandler,
+		CartHandler:        cartHandler,
+		HealthHandler:      healthHandler,
 		Log:                config.Log,
 		AuthMiddleware:     authMiddleware,
+		Tracing: middleware.TracingConfig{
+			SampleRatio:  tracingConfig.SampleRatio,
+			ReuseTraceID: tracingConfig.ReuseTraceID,
+		},
+		Metrics:      appMetrics,
+		RateLimiters: rateLimiters,
+		AccessLog: middleware.LoggerConfig{
+			Level:      accessLogLevel,
+			SampleRate: accessLogConfig.SampleRate,
+			LogHeaders: accessLogConfig.Headers,
+			LogBodies:  accessLogConfig.Bodies,
+		},
+		CORS: cors,
 	}
 	
 	// Setup routes
@@ -0,0 +1,35 @@
+package config
+
+// DefaultAccessLogLevel and DefaultAccessLogSampleRate are used when log.access is not configured
+const (
+	DefaultAccessLogLevel      = "info"
+	DefaultAccessLogSampleRate = 1.0
+)
+
+// AccessLogConfig holds configuration for the per-request access log
+type AccessLogConfig struct {
+	Level      string  `mapstructure:"level"`
+	SampleRate float64 `mapstructure:"sample_rate"`
+	Headers    bool    `mapstructure:"headers"`
+	Bodies     bool    `mapstructure:"bodies"`
+}
+
+// GetAccessLogConfig returns the access log configuration
+func (c *AppConfig) GetAccessLogConfig() *AccessLogConfig {
+	level := DefaultAccessLogLevel
+	if c.Viper.IsSet("log.access.level") {
+		level = c.Viper.GetString("log.access.level")
+	}
+
+	sampleRate := DefaultAccessLogSampleRate
+	if c.Viper.IsSet("log.access.sample_rate") {
+		sampleRate = c.Viper.GetFloat64("log.access.sample_rate")
+	}
+
+	return &AccessLogConfig{
+		Level:      level,
+		SampleRate: sampleRate,
+		Headers:    c.Viper.GetBool("log.access.headers"),
+		Bodies:     c.Viper.GetBool("log.access.bodies"),
+	}
+}
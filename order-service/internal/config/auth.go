@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"order-service/internal/auth"
+)
+
+// AuthConfig holds configuration for verifying user service access tokens and internal callers' API keys
+type AuthConfig struct {
+	// JWTSecret must match the secret the user service signs access tokens with
+	JWTSecret string `mapstructure:"secret"`
+	// APIKeys are accepted in the X-API-Key header; revoked keys stay listed so their use is reported as revoked
+	APIKeys []auth.APIKey `mapstructure:"api_keys"`
+
+	apiKeysErr error // Set when auth.api_keys could not be decoded; reported by Validate
+}
+
+// GetAuthConfig returns the access token verification and API key configuration
+func (c *AppConfig) GetAuthConfig() *AuthConfig {
+	var apiKeys []auth.APIKey
+	err := c.Viper.UnmarshalKey("auth.api_keys", &apiKeys)
+
+	return &AuthConfig{
+		JWTSecret:  c.Viper.GetString("jwt.secret"),
+		APIKeys:    apiKeys,
+		apiKeysErr: err,
+	}
+}
+
+// ActiveAPIKeys returns how many of the API keys are not revoked
+func (c *AuthConfig) ActiveAPIKeys() int {
+	active := 0
+	for _, key := range c.APIKeys {
+		if !key.Revoked {
+			active++
+		}
+	}
+	return active
+}
+
+// Validate reports a malformed auth.api_keys, API keys without a name or value and names or values listed more than once
+func (c *AuthConfig) Validate() error {
+	if c.apiKeysErr != nil {
+		return fmt.Errorf("invalid auth.api_keys: %w", c.apiKeysErr)
+	}
+
+	names := make(map[string]bool, len(c.APIKeys))
+	values := make(map[string]bool, len(c.APIKeys))
+	for _, key := range c.APIKeys {
+		if key.Name == "" || key.Key == "" {
+			return fmt.Errorf("every entry of auth.api_keys needs a name and a key")
+		}
+		if names[key.Name] || values[key.Key] {
+			return fmt.Errorf("auth.api_keys lists %q or its key more than once", key.Name)
+		}
+		names[key.Name] = true
+		values[key.Key] = true
+	}
+	return nil
+}
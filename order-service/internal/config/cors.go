@@ -0,0 +1,21 @@
+package config
+
+// CORSConfig holds the cross-origin settings; AllowMethods and AllowHeaders are empty unless configured
+type CORSConfig struct {
+	AllowOrigins     []string `mapstructure:"allow_origins"`
+	AllowMethods     []string `mapstructure:"allow_methods"`
+	AllowHeaders     []string `mapstructure:"allow_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"`
+	MaxAge           int      `mapstructure:"max_age"`
+}
+
+// GetCORSConfig returns the CORS configuration. Without cors.allow_origins every cross-origin request is denied.
+func (c *AppConfig) GetCORSConfig() *CORSConfig {
+	return &CORSConfig{
+		AllowOrigins:     c.Viper.GetStringSlice("cors.allow_origins"),
+		AllowMethods:     c.Viper.GetStringSlice("cors.allow_methods"),
+		AllowHeaders:     c.Viper.GetStringSlice("cors.allow_headers"),
+		AllowCredentials: c.Viper.GetBool("cors.allow_credentials"),
+		MaxAge:           c.Viper.GetInt("cors.max_age"),
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"order-service/internal/usecase"
+	"time"
+)
+
+const (
+	// DefaultKeyCleanupRetention is used when key_cleanup.retention is not configured. It covers
+	// retries of an operation well past the order payment deadline.
+	DefaultKeyCleanupRetention = 30 * 24 * time.Hour
+
+	// DefaultKeyCleanupInterval is used when key_cleanup.interval is not configured
+	DefaultKeyCleanupInterval = time.Hour
+)
+
+// KeyCleanupConfig holds configuration for purging idempotency and operation keys
+type KeyCleanupConfig struct {
+	Retention time.Duration `mapstructure:"retention"`
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+}
+
+// GetKeyCleanupConfig returns the key cleanup configuration
+func (c *AppConfig) GetKeyCleanupConfig() *KeyCleanupConfig {
+	keyCleanupConfig := &KeyCleanupConfig{
+		Retention: DefaultKeyCleanupRetention,
+		Interval:  DefaultKeyCleanupInterval,
+		BatchSize: usecase.DefaultKeyPurgeBatchSize,
+	}
+
+	if c.Viper.IsSet("key_cleanup.retention") {
+		keyCleanupConfig.Retention = c.Viper.GetDuration("key_cleanup.retention")
+	}
+	if c.Viper.IsSet("key_cleanup.interval") {
+		keyCleanupConfig.Interval = c.Viper.GetDuration("key_cleanup.interval")
+	}
+	if c.Viper.IsSet("key_cleanup.batch_size") {
+		keyCleanupConfig.BatchSize = c.Viper.GetInt("key_cleanup.batch_size")
+	}
+
+	return keyCleanupConfig
+}
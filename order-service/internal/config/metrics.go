@@ -0,0 +1,14 @@
+package config
+
+// MetricsConfig holds configuration for the Prometheus metrics endpoint
+type MetricsConfig struct {
+	// Enabled turns on request instrumentation and the /metrics endpoint; it is off unless configured
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// GetMetricsConfig returns the metrics configuration
+func (c *AppConfig) GetMetricsConfig() *MetricsConfig {
+	return &MetricsConfig{
+		Enabled: c.Viper.GetBool("metrics.enabled"),
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	appContext "order-service/internal/context"
+	"time"
+)
+
+const (
+	// DefaultPaymentDeadline is used when order.payment_deadline is not configured
+	DefaultPaymentDeadline = 24 * time.Hour
+
+	// DefaultExpiryScanInterval is used when order.expiry_scan_interval is not configured
+	DefaultExpiryScanInterval = time.Minute
+
+	// DefaultExportMaxRange is used when order.export_max_range is not configured
+	DefaultExportMaxRange = 31 * 24 * time.Hour
+
+	// DefaultDeadlockRetries is used when order.deadlock_retries is not configured
+	DefaultDeadlockRetries = 3
+
+	// DefaultDeadlockBackoff is used when order.deadlock_backoff is not configured
+	DefaultDeadlockBackoff = 50 * time.Millisecond
+)
+
+// OrderConfig holds configuration for order processing
+type OrderConfig struct {
+	PaymentDeadline    time.Duration `mapstructure:"payment_deadline"`
+	ExpiryScanInterval time.Duration `mapstructure:"expiry_scan_interval"`
+	ExportMaxRange     time.Duration `mapstructure:"export_max_range"`
+	TaxRate            float64       `mapstructure:"tax_rate"` // Flat tax percentage; 0 disables tax
+	// Timeouts bounds each class of operation; classes not set under order.timeouts use their defaults
+	Timeouts appContext.Timeouts `mapstructure:"timeouts"`
+	// Retries of an order transaction MySQL rolled back to break a deadlock; 0 disables them
+	DeadlockRetries int           `mapstructure:"deadlock_retries"`
+	DeadlockBackoff time.Duration `mapstructure:"deadlock_backoff"`
+	// Smallest subtotal after discounts an order may have; 0 disables the check
+	MinimumOrderAmount float64 `mapstructure:"minimum_order_amount"`
+}
+
+// GetOrderConfig returns the order processing configuration
+func (c *AppConfig) GetOrderConfig() *OrderConfig {
+	paymentDeadline := DefaultPaymentDeadline
+	if c.Viper.IsSet("order.payment_deadline") {
+		paymentDeadline = c.Viper.GetDuration("order.payment_deadline")
+	}
+
+	expiryScanInterval := DefaultExpiryScanInterval
+	if c.Viper.IsSet("order.expiry_scan_interval") {
+		expiryScanInterval = c.Viper.GetDuration("order.expiry_scan_interval")
+	}
+
+	exportMaxRange := DefaultExportMaxRange
+	if c.Viper.IsSet("order.export_max_range") {
+		exportMaxRange = c.Viper.GetDuration("order.export_max_range")
+	}
+
+	timeouts := appContext.DefaultTimeouts()
+	if c.Viper.IsSet("order.timeouts.read") {
+		timeouts.Read = c.Viper.GetDuration("order.timeouts.read")
+	}
+	if c.Viper.IsSet("order.timeouts.write") {
+		timeouts.Write = c.Viper.GetDuration("order.timeouts.write")
+	}
+	if c.Viper.IsSet("order.timeouts.commit") {
+		timeouts.Commit = c.Viper.GetDuration("order.timeouts.commit")
+	}
+	if c.Viper.IsSet("order.timeouts.inventory") {
+		timeouts.Inventory = c.Viper.GetDuration("order.timeouts.inventory")
+	}
+	if c.Viper.IsSet("order.timeouts.payment") {
+		timeouts.Payment = c.Viper.GetDuration("order.timeouts.payment")
+	}
+
+	deadlockRetries := DefaultDeadlockRetries
+	if c.Viper.IsSet("order.deadlock_retries") {
+		deadlockRetries = c.Viper.GetInt("order.deadlock_retries")
+	}
+	if deadlockRetries < 0 {
+		deadlockRetries = 0
+	}
+
+	deadlockBackoff := DefaultDeadlockBackoff
+	if c.Viper.IsSet("order.deadlock_backoff") {
+		deadlockBackoff = c.Viper.GetDuration("order.deadlock_backoff")
+	}
+
+	minimumOrderAmount := c.Viper.GetFloat64("order.minimum_order_amount")
+	if minimumOrderAmount < 0 {
+		minimumOrderAmount = 0
+	}
+
+	return &OrderConfig{
+		PaymentDeadline:    paymentDeadline,
+		ExpiryScanInterval: expiryScanInterval,
+		ExportMaxRange:     exportMaxRange,
+		TaxRate:            c.Viper.GetFloat64("order.tax_rate"),
+		Timeouts:           timeouts,
+		DeadlockRetries:    deadlockRetries,
+		DeadlockBackoff:    deadlockBackoff,
+		MinimumOrderAmount: minimumOrderAmount,
+	}
+}
@@ -0,0 +1,20 @@
+package config
+
+import "order-service/internal/model"
+
+// GetPaginationConfig returns the default and largest page size of list endpoints, read from
+// pagination.default_limit and pagination.max_limit. Without them model.DefaultPageLimit and
+// model.MaxPageLimit are used.
+func (c *AppConfig) GetPaginationConfig() model.Pagination {
+	pagination := model.Pagination{
+		DefaultLimit: model.DefaultPageLimit,
+		MaxLimit:     model.MaxPageLimit,
+	}
+	if c.Viper.IsSet("pagination.default_limit") {
+		pagination.DefaultLimit = c.Viper.GetInt("pagination.default_limit")
+	}
+	if c.Viper.IsSet("pagination.max_limit") {
+		pagination.MaxLimit = c.Viper.GetInt("pagination.max_limit")
+	}
+	return pagination
+}
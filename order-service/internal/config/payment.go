@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"order-service/internal/model"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// NewPaymentMethods reads order.payment_methods, the payment methods an order may be created with, falling back to
+// model.DefaultPaymentMethods when it is not set. Methods are compared lowercased, so they are normalized the same way.
+func NewPaymentMethods(viper *viper.Viper) ([]string, error) {
+	if !viper.IsSet("order.payment_methods") {
+		return model.DefaultPaymentMethods, nil
+	}
+
+	var methods []string
+	seen := make(map[string]bool)
+	for _, method := range viper.GetStringSlice("order.payment_methods") {
+		method = model.NormalizePaymentMethod(method)
+		if method == "" {
+			return nil, fmt.Errorf("order.payment_methods must not contain empty methods")
+		}
+		// The methods become a oneof parameter, which separates values by spaces
+		if strings.ContainsAny(method, " \t") {
+			return nil, fmt.Errorf("order.payment_methods must not contain whitespace, got %q", method)
+		}
+		if !seen[method] {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("order.payment_methods must list at least one method")
+	}
+	return methods, nil
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"order-service/internal/model"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaymentMethods_Defaults(t *testing.T) {
+	methods, err := NewPaymentMethods(viper.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, model.DefaultPaymentMethods, methods)
+}
+
+func TestNewPaymentMethods_Configured(t *testing.T) {
+	v := viper.New()
+	v.Set("order.payment_methods", []string{"credit_card", " Cash_On_Delivery ", "credit_card"})
+
+	methods, err := NewPaymentMethods(v)
+	require.NoError(t, err)
+
+	// Methods are normalized like the payment method of an order, and duplicates are dropped
+	assert.Equal(t, []string{"credit_card", "cash_on_delivery"}, methods)
+}
+
+func TestNewPaymentMethods_RejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []string
+	}{
+		{name: "empty list", methods: []string{}},
+		{name: "blank method", methods: []string{"credit_card", " "}},
+		{name: "method with a space", methods: []string{"cash on delivery"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set("order.payment_methods", tt.methods)
+
+			methods, err := NewPaymentMethods(v)
+			assert.Error(t, err)
+			assert.Nil(t, methods)
+		})
+	}
+}
+
+func TestNewValidator_PaymentMethodsFromConfig(t *testing.T) {
+	v := viper.New()
+	v.Set("order.payment_methods", []string{"credit_card", "cash_on_delivery"})
+	validate := NewValidator(v, logrus.New())
+
+	request := &model.CreateOrderRequest{
+		UserID:          "user-1",
+		ShippingAddress: "123 Test St",
+		PaymentMethod:   "cash_on_delivery",
+		Items:           []model.OrderItemRequest{{ProductID: 1, WarehouseID: 1, Quantity: 1, UnitPrice: 1000}},
+	}
+	assert.NoError(t, validate.Struct(request))
+
+	request.PaymentMethod = "e_wallet"
+	assert.Error(t, validate.Struct(request))
+}
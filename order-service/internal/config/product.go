@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+)
+
+// DefaultPriceTolerance is used when product.price_tolerance is not configured
+const DefaultPriceTolerance = 0.01
+
+// ProductConfig holds configuration for the product service integration
+type ProductConfig struct {
+	BaseURL        string        `mapstructure:"base_url"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+	ValidatePrices bool          `mapstructure:"validate_prices"`
+	PriceTolerance float64       `mapstructure:"price_tolerance"`
+}
+
+// GetProductConfig returns the product service configuration
+func (c *AppConfig) GetProductConfig() *ProductConfig {
+	priceTolerance := DefaultPriceTolerance
+	if c.Viper.IsSet("product.price_tolerance") {
+		priceTolerance = c.Viper.GetFloat64("product.price_tolerance")
+	}
+
+	return &ProductConfig{
+		BaseURL:        c.Viper.GetString("product.base_url"),
+		Timeout:        c.Viper.GetDuration("product.timeout"),
+		ValidatePrices: c.Viper.GetBool("product.validate_prices"),
+		PriceTolerance: priceTolerance,
+	}
+}
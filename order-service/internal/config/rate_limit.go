@@ -0,0 +1,35 @@
+package config
+
+import (
+	"time"
+)
+
+// RateLimitGroupConfig holds the limit applied to one route group
+type RateLimitGroupConfig struct {
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+}
+
+// RateLimitConfig holds configuration for per-route-group rate limiting
+type RateLimitConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Groups maps a route group name, such as default or orders, to its limit
+	Groups map[string]RateLimitGroupConfig `mapstructure:"groups"`
+}
+
+// GetRateLimitConfig returns the rate limiting configuration
+func (c *AppConfig) GetRateLimitConfig() *RateLimitConfig {
+	groups := make(map[string]RateLimitGroupConfig)
+	for name := range c.Viper.GetStringMap("rate_limit.groups") {
+		prefix := "rate_limit.groups." + name
+		groups[name] = RateLimitGroupConfig{
+			Requests: c.Viper.GetInt(prefix + ".requests"),
+			Window:   c.Viper.GetDuration(prefix + ".window"),
+		}
+	}
+
+	return &RateLimitConfig{
+		Enabled: c.Viper.GetBool("rate_limit.enabled"),
+		Groups:  groups,
+	}
+}
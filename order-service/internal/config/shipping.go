@@ -0,0 +1,18 @@
+package config
+
+// ShippingConfig holds configuration for the default shipping cost estimate
+type ShippingConfig struct {
+	BaseCost      float64 `mapstructure:"base_cost"`
+	PerItemCost   float64 `mapstructure:"per_item_cost"`
+	FreeThreshold float64 `mapstructure:"free_threshold"`
+}
+
+// GetShippingConfig returns the shipping cost configuration.
+// Every setting defaults to zero, so shipping is free unless configured.
+func (c *AppConfig) GetShippingConfig() *ShippingConfig {
+	return &ShippingConfig{
+		BaseCost:      c.Viper.GetFloat64("shipping.base_cost"),
+		PerItemCost:   c.Viper.GetFloat64("shipping.per_item_cost"),
+		FreeThreshold: c.Viper.GetFloat64("shipping.free_threshold"),
+	}
+}
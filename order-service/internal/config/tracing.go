@@ -0,0 +1,23 @@
+package config
+
+// DefaultTraceSampleRatio is used when tracing.sample_ratio is not configured
+const DefaultTraceSampleRatio = 0.1
+
+// TracingConfig holds configuration for request tracing
+type TracingConfig struct {
+	SampleRatio  float64 `mapstructure:"sample_ratio"`
+	ReuseTraceID bool    `mapstructure:"reuse_trace_id"`
+}
+
+// GetTracingConfig returns the tracing configuration
+func (c *AppConfig) GetTracingConfig() *TracingConfig {
+	sampleRatio := DefaultTraceSampleRatio
+	if c.Viper.IsSet("tracing.sample_ratio") {
+		sampleRatio = c.Viper.GetFloat64("tracing.sample_ratio")
+	}
+
+	return &TracingConfig{
+		SampleRatio:  sampleRatio,
+		ReuseTraceID: c.Viper.GetBool("tracing.reuse_trace_id"),
+	}
+}
@@ -1,10 +1,26 @@
 package config
 
 import (
+	appErrors "order-service/internal/errors"
+	"order-service/internal/model"
+
 	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func NewValidator(viper *viper.Viper) *validator.Validate {
-	return validator.New()
+func NewValidator(viper *viper.Viper, log *logrus.Logger) *validator.Validate {
+	validate := validator.New()
+	// Report failed fields by their JSON name so clients can match them to the request body
+	validate.RegisterTagNameFunc(appErrors.JSONFieldName)
+
+	// Orders may only use the configured payment methods
+	paymentMethods, err := NewPaymentMethods(viper)
+	if err != nil {
+		log.Fatalf("Invalid payment methods: %v", err)
+	}
+	model.RegisterPaymentMethods(validate, paymentMethods)
+	log.WithField("payment_methods", paymentMethods).Info("Accepting payment methods")
+
+	return validate
 }
@@ -0,0 +1,75 @@
+package config
+
+import "time"
+
+const (
+	// DefaultWebhookTimeout is used when webhook.timeout is not configured
+	DefaultWebhookTimeout = 10 * time.Second
+
+	// DefaultWebhookDispatchInterval is used when webhook.dispatch_interval is not configured
+	DefaultWebhookDispatchInterval = 5 * time.Second
+
+	// DefaultWebhookBatchSize is used when webhook.batch_size is not configured
+	DefaultWebhookBatchSize = 50
+
+	// DefaultWebhookMaxAttempts is used when webhook.max_attempts is not configured
+	DefaultWebhookMaxAttempts = 10
+
+	// DefaultWebhookRetryBackoff is used when webhook.retry_backoff is not configured
+	DefaultWebhookRetryBackoff = 10 * time.Second
+
+	// DefaultWebhookMaxRetryBackoff is used when webhook.max_retry_backoff is not configured
+	DefaultWebhookMaxRetryBackoff = time.Hour
+)
+
+// WebhookConfig holds configuration for outbound order event webhooks
+type WebhookConfig struct {
+	URL              string        `mapstructure:"url"` // Webhooks are disabled when empty
+	Secret           string        `mapstructure:"secret"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	DispatchInterval time.Duration `mapstructure:"dispatch_interval"`
+	BatchSize        int           `mapstructure:"batch_size"`
+	MaxAttempts      int           `mapstructure:"max_attempts"`
+	RetryBackoff     time.Duration `mapstructure:"retry_backoff"`     // Delay after the first failure; doubled after each further failure
+	MaxRetryBackoff  time.Duration `mapstructure:"max_retry_backoff"` // Upper bound on the delay between attempts
+}
+
+// Enabled reports whether a webhook URL is configured
+func (w *WebhookConfig) Enabled() bool {
+	return w.URL != ""
+}
+
+// GetWebhookConfig returns the webhook configuration
+func (c *AppConfig) GetWebhookConfig() *WebhookConfig {
+	webhookConfig := &WebhookConfig{
+		URL:              c.Viper.GetString("webhook.url"),
+		Secret:           c.Viper.GetString("webhook.secret"),
+		Timeout:          DefaultWebhookTimeout,
+		DispatchInterval: DefaultWebhookDispatchInterval,
+		BatchSize:        DefaultWebhookBatchSize,
+		MaxAttempts:      DefaultWebhookMaxAttempts,
+		RetryBackoff:     DefaultWebhookRetryBackoff,
+		MaxRetryBackoff:  DefaultWebhookMaxRetryBackoff,
+	}
+
+	if c.Viper.IsSet("webhook.timeout") {
+		webhookConfig.Timeout = c.Viper.GetDuration("webhook.timeout")
+	}
+	if c.Viper.IsSet("webhook.dispatch_interval") {
+		webhookConfig.DispatchInterval = c.Viper.GetDuration("webhook.dispatch_interval")
+	}
+	if c.Viper.IsSet("webhook.batch_size") {
+		webhookConfig.BatchSize = c.Viper.GetInt("webhook.batch_size")
+	}
+	if c.Viper.IsSet("webhook.max_attempts") {
+		webhookConfig.MaxAttempts = c.Viper.GetInt("webhook.max_attempts")
+	}
+	if c.Viper.IsSet("webhook.retry_backoff") {
+		webhookConfig.RetryBackoff = c.Viper.GetDuration("webhook.retry_backoff")
+	}
+	if c.Viper.IsSet("webhook.max_retry_backoff") {
+		webhookConfig.MaxRetryBackoff = c.Viper.GetDuration("webhook.max_retry_backoff")
+	}
+
+	return webhookConfig
+}
@@ -53,4 +53,78 @@ func WithTimeout(ctx context.Context, duration time.Duration) (context.Context,
 // WithDefaultTimeout returns a context with the default timeout of 5 seconds
 func WithDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return WithTimeout(ctx, 5*time.Second)
+}
+
+// Operation classifies work by how long it may take, so each class gets its own timeout
+type Operation string
+
+const (
+	// OperationRead covers database reads and lookups in other services
+	OperationRead Operation = "read"
+
+	// OperationWrite covers short database transactions such as status updates
+	OperationWrite Operation = "write"
+
+	// OperationCommit covers long database transactions such as creating an order
+	OperationCommit Operation = "commit"
+
+	// OperationInventory covers calls that reserve, commit or release stock
+	OperationInventory Operation = "inventory"
+
+	// OperationPayment covers charging an order through the payment gateway
+	OperationPayment Operation = "payment"
+)
+
+// Timeouts holds the timeout of each operation class. A zero field uses the default for its class.
+type Timeouts struct {
+	Read      time.Duration `mapstructure:"read"`
+	Write     time.Duration `mapstructure:"write"`
+	Commit    time.Duration `mapstructure:"commit"`
+	Inventory time.Duration `mapstructure:"inventory"`
+	Payment   time.Duration `mapstructure:"payment"`
+}
+
+// DefaultTimeouts returns the timeouts used when none are configured
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Read:      10 * time.Second,
+		Write:     15 * time.Second,
+		Commit:    30 * time.Second,
+		Inventory: 15 * time.Second,
+		Payment:   30 * time.Second,
+	}
+}
+
+// For returns the timeout of the operation class, falling back to its default
+func (t Timeouts) For(operation Operation) time.Duration {
+	defaults := DefaultTimeouts()
+	timeout, fallback := t.Read, defaults.Read
+	switch operation {
+	case OperationWrite:
+		timeout, fallback = t.Write, defaults.Write
+	case OperationCommit:
+		timeout, fallback = t.Commit, defaults.Commit
+	case OperationInventory:
+		timeout, fallback = t.Inventory, defaults.Inventory
+	case OperationPayment:
+		timeout, fallback = t.Payment, defaults.Payment
+	}
+	if timeout <= 0 {
+		return fallback
+	}
+	return timeout
+}
+
+// WithOperationTimeout derives a child of ctx bounded by the operation's timeout.
+// Cancelling ctx, for example when the client disconnects, also cancels the operation,
+// and an earlier deadline on ctx still applies.
+func WithOperationTimeout(ctx context.Context, timeouts Timeouts, operation Operation) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, timeouts.For(operation))
+}
+
+// WithDetachedTimeout is like WithOperationTimeout but ignores the cancellation and deadline of ctx,
+// while keeping its values such as the request ID. Use it for work that must finish once started,
+// such as releasing stock after a failed order or syncing inventory after a commit.
+func WithDetachedTimeout(ctx context.Context, timeouts Timeouts, operation Operation) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), timeouts.For(operation))
 }
\ No newline at end of file
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"order-service/internal/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics creates a middleware that records the count and latency of every request.
+// Requests are labeled by route template (e.g. /api/v1/orders/:id) rather than raw path to keep label cardinality bounded.
+func Metrics(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		// A returned error is only turned into a response by the error handler after the middleware chain unwinds,
+		// so derive the status the same way it will
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		// Fiber reuses the request buffers, so copy the method before the registry keeps it as a label value
+		m.ObserveHTTPRequest(strings.Clone(c.Method()), c.Route().Path, strconv.Itoa(status), time.Since(start).Seconds())
+		return err
+	}
+}
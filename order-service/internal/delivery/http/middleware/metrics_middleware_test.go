@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"order-service/internal/metrics"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetricsTestApp(m *metrics.Metrics) *fiber.App {
+	app := fiber.New()
+	app.Get("/metrics", adaptor.HTTPHandler(m.Handler()))
+	app.Use(Metrics(m))
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Post("/orders/:id/payment", func(c *fiber.Ctx) error {
+		return fiber.ErrPaymentRequired
+	})
+	return app
+}
+
+func TestMetrics_LabelsByRouteTemplateAndStatus(t *testing.T) {
+	m := metrics.New()
+	app := newMetricsTestApp(m)
+
+	for _, path := range []string{"/orders/1", "/orders/2"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+	}
+	_, err := app.Test(httptest.NewRequest("POST", "/orders/1/payment", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("GET", "/orders/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("POST", "/orders/:id/payment", "402")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.HTTPRequestDuration))
+}
+
+func TestMetrics_ServesPrometheusFormat(t *testing.T) {
+	m := metrics.New()
+	app := newMetricsTestApp(m)
+
+	_, err := app.Test(httptest.NewRequest("GET", "/orders/1", nil))
+	require.NoError(t, err)
+	m.RecordPayment(metrics.ResultDeclined)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.True(t, strings.Contains(string(body), `order_service_http_requests_total{method="GET",route="/orders/:id",status="200"} 1`))
+	assert.True(t, strings.Contains(string(body), `order_service_payments_total{result="declined"} 1`))
+	assert.True(t, strings.Contains(string(body), "go_goroutines"))
+
+	// Scrapes of /metrics are not themselves counted
+	assert.Equal(t, 1, testutil.CollectAndCount(m.HTTPRequests))
+}
+
+func TestMetrics_NilMetricsRecordNothing(t *testing.T) {
+	var m *metrics.Metrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveHTTPRequest("GET", "/", "200", 0.1)
+		m.RecordStockReservation(metrics.ResultSuccess)
+		m.RecordPayment(metrics.ResultSuccess)
+		m.RecordExpirySweep(1, 2)
+		m.RecordFailedExpirySweep()
+	})
+}
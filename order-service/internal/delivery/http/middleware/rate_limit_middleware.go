@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"order-service/internal/delivery/http/response"
+	appErrors "order-service/internal/errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimit is the number of requests a client may make per window.
+// Requests is also the burst size: a client that has been idle can send that many requests at once.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimiter decides whether the client identified by key may make another request.
+// The in-memory MemoryRateLimiter is the default; a shared store such as Redis can implement it to limit across instances.
+type RateLimiter interface {
+	// Allow consumes one request for key. When the limit is exceeded it returns false and how long until the next request is allowed.
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// tokenBucket is the state of one client's bucket
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryRateLimiter is a token bucket rate limiter that keeps one bucket per key in process memory.
+// Buckets refill continuously at Requests per Window, so limits are not shared between instances.
+type MemoryRateLimiter struct {
+	Limit RateLimit
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimiter creates an in-memory token bucket rate limiter
+func NewMemoryRateLimiter(limit RateLimit) *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		Limit:   limit,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the key's bucket if one is available
+func (l *MemoryRateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	capacity := float64(l.Limit.Requests)
+	refillRate := capacity / l.Limit.Window.Seconds()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		l.buckets[key] = bucket
+	} else {
+		bucket.tokens = math.Min(capacity, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*refillRate)
+		bucket.updatedAt = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+
+	retryAfter := time.Duration((1 - bucket.tokens) / refillRate * float64(time.Second))
+	return false, retryAfter, nil
+}
+
+// sweep drops buckets that have been idle for a whole window, since they have refilled and are the same as a new bucket.
+// It runs at most once per window; callers must hold mu.
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.Limit.Window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.updatedAt) >= l.Limit.Window {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimitKey identifies the client of a request: its API key when one is sent, otherwise its IP address.
+// API keys are hashed so that they are never kept, or later sent to a shared store, in clear text.
+func RateLimitKey(c *fiber.Ctx) string {
+	if apiKey := c.Get("X-API-Key"); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "api_key:" + hex.EncodeToString(sum[:8])
+	}
+	return "ip:" + c.IP()
+}
+
+// RateLimitMiddleware creates a middleware that rejects requests with 429 and a Retry-After header once the client exceeds its limit.
+// Requests are let through if the limiter itself fails, so an unavailable store does not take the API down.
+func RateLimitMiddleware(limiter RateLimiter, log *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := RateLimitKey(c)
+
+		allowed, retryAfter, err := limiter.Allow(c.UserContext(), key)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"request_id": c.Get("X-Request-ID"),
+				"error":      err.Error(),
+			}).Error("Rate limiter failed, allowing request")
+			return c.Next()
+		}
+
+		if !allowed {
+			retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+			if retryAfterSeconds < 1 {
+				retryAfterSeconds = 1
+			}
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfterSeconds))
+
+			log.WithFields(logrus.Fields{
+				"request_id":  c.Get("X-Request-ID"),
+				"path":        c.Path(),
+				"method":      c.Method(),
+				"retry_after": retryAfterSeconds,
+			}).Warn("Rate limit exceeded")
+
+			return response.JSONError(c, appErrors.ErrTooManyRequests, log)
+		}
+
+		return c.Next()
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"errors"
+	"order-service/internal/auth"
 	"order-service/internal/context"
 	"order-service/internal/delivery/http/response"
 	appErrors "order-service/internal/errors"
@@ -11,23 +13,34 @@ import (
 
 // SimpleAuthMiddleware provides basic authentication functionality
 type SimpleAuthMiddleware struct {
-	Log *logrus.Logger
+	Log           *logrus.Logger
+	TokenVerifier auth.TokenVerifierInterface
+	APIKeys       auth.APIKeyStoreInterface
 }
 
 // NewSimpleAuthMiddleware creates a new authentication middleware
-func NewSimpleAuthMiddleware(logger *logrus.Logger) *SimpleAuthMiddleware {
+func NewSimpleAuthMiddleware(logger *logrus.Logger, tokenVerifier auth.TokenVerifierInterface, apiKeys auth.APIKeyStoreInterface) *SimpleAuthMiddleware {
 	return &SimpleAuthMiddleware{
-		Log: logger,
+		Log:           logger,
+		TokenVerifier: tokenVerifier,
+		APIKeys:       apiKeys,
 	}
 }
 
 // RequireAuth middleware to validate API key from X-API-Key header
+// or the user's access token from the Authorization header
 func (m *SimpleAuthMiddleware) RequireAuth() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		requestID := c.Get("X-Request-ID")
 		
 		// Get API key from header
 		apiKey := c.Get("X-API-Key")
+		authHeader := c.Get("Authorization")
+		
+		// Users authenticate with a bearer token, internal services with the API key
+		if apiKey == "" && authHeader != "" {
+			return m.authenticateToken(c, requestID, authHeader)
+		}
 		
 		// Check if API key exists
 		if apiKey == "" {
@@ -38,37 +51,131 @@ func (m *SimpleAuthMiddleware) RequireAuth() fiber.Handler {
 			}).Warn("Missing API key")
 			
 			return response.JSONError(c, 
-				appErrors.WithMessage(appErrors.ErrUnauthorized, "Missing API key"), 
+				appErrors.WithMessage(appErrors.ErrUnauthorized, "Missing API key or authorization header"), 
 				m.Log)
 		}
 
-		// Simple API key validation - in a real app, this would check against a database
-		// For this example, we're using a hardcoded value
-		if apiKey != "order-service-api-key" {
+		key, err := m.APIKeys.Find(apiKey)
+		if err != nil {
 			m.Log.WithFields(logrus.Fields{
 				"request_id": requestID,
+				"error":      err.Error(),
 				"path":       c.Path(),
 				"method":     c.Method(),
 			}).Warn("Invalid API key")
 			
+			message := "Invalid API key"
+			if errors.Is(err, auth.ErrRevokedAPIKey) {
+				message = "API key has been revoked"
+			}
 			return response.JSONError(c, 
-				appErrors.WithMessage(appErrors.ErrUnauthorized, "Invalid API key"), 
+				appErrors.WithMessage(appErrors.ErrUnauthorized, message), 
 				m.Log)
 		}
 		
-		// Set a dummy user ID in locals - this would normally come from your auth service
-		c.Locals("userId", "service-account")
+		// The key's name identifies the calling service; its scopes are checked by RequireScope
+		c.Locals("userId", key.Name)
+		c.Locals("role", auth.RoleService)
+		c.Locals("apiKey", key)
 		
 		// Also set in the context
-		c.SetUserContext(context.WithUserID(c.UserContext(), "service-account"))
+		c.SetUserContext(context.WithUserID(c.UserContext(), key.Name))
 		
 		// Log successful authentication
 		m.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
+			"api_key":    key.Name,
 			"path":       c.Path(),
 		}).Info("API key authentication successful")
 		
 		// Call next handler
 		return c.Next()
 	}
+}
+
+// authenticateToken verifies a user service access token and stores the user ID and role in locals
+func (m *SimpleAuthMiddleware) authenticateToken(c *fiber.Ctx, requestID string, authHeader string) error {
+	// Extract token value - handle "Bearer " prefix if present
+	token := authHeader
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		token = authHeader[7:]
+	}
+
+	claims, err := m.TokenVerifier.Verify(token)
+	if err != nil {
+		m.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+			"path":       c.Path(),
+		}).Warn("Invalid token")
+
+		message := "Invalid token"
+		if errors.Is(err, auth.ErrExpiredToken) {
+			message = "Token has expired"
+		}
+		return response.JSONError(c,
+			appErrors.WithMessage(appErrors.ErrUnauthorized, message),
+			m.Log)
+	}
+
+	c.Locals("userId", claims.Subject)
+	c.Locals("role", claims.Role)
+	c.SetUserContext(context.WithUserID(c.UserContext(), claims.Subject))
+
+	m.Log.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"user_id":    claims.Subject,
+		"path":       c.Path(),
+	}).Info("User authenticated successfully")
+
+	return c.Next()
+}
+
+// RequireScope middleware rejects callers authenticated with an API key that was not granted scope.
+// Users authenticated with an access token are let through; their access is governed by RequireRole.
+// It must run after RequireAuth.
+func (m *SimpleAuthMiddleware) RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, ok := c.Locals("apiKey").(*auth.APIKey)
+		if !ok || key.HasScope(scope) {
+			return c.Next()
+		}
+
+		m.Log.WithFields(logrus.Fields{
+			"request_id": c.Get("X-Request-ID"),
+			"api_key":    key.Name,
+			"scope":      scope,
+			"path":       c.Path(),
+			"method":     c.Method(),
+		}).Warn("API key lacks scope")
+
+		return response.JSONError(c,
+			appErrors.WithMessage(appErrors.ErrForbidden, "API key is not allowed to access this resource"),
+			m.Log)
+	}
+}
+
+// RequireRole middleware rejects authenticated callers whose role is not one of roles.
+// It must run after RequireAuth.
+func (m *SimpleAuthMiddleware) RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+
+		m.Log.WithFields(logrus.Fields{
+			"request_id": c.Get("X-Request-ID"),
+			"user_id":    c.Locals("userId"),
+			"role":       role,
+			"path":       c.Path(),
+			"method":     c.Method(),
+		}).Warn("Insufficient role")
+
+		return response.JSONError(c,
+			appErrors.WithMessage(appErrors.ErrForbidden, "You do not have permission to perform this action"),
+			m.Log)
+	}
 }
\ No newline at end of file
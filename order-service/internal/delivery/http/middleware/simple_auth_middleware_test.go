@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"order-service/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, secret string, role string, expiresAt time.Time) string {
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "5df84b6f-8f5b-4a51-a106-e9a46b67c836",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: role,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func newTestAPIKeyStore() auth.APIKeyStoreInterface {
+	return auth.NewAPIKeyStore([]auth.APIKey{
+		{Name: "internal-services", Key: "order-service-api-key", Scopes: []string{auth.ScopeAll}},
+		{Name: "shop-service", Key: "shop-key", Scopes: []string{auth.ScopeCarts}},
+		{Name: "retired-service", Key: "revoked-key", Scopes: []string{auth.ScopeAll}, Revoked: true},
+	})
+}
+
+func TestSimpleAuthMiddleware_RequireRole(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	adminToken := signToken(t, testSecret, auth.RoleAdmin, expiresAt)
+	customerToken := signToken(t, testSecret, auth.RoleCustomer, expiresAt)
+
+	tests := []struct {
+		name          string
+		authorization string
+		apiKey        string
+		expectedCode  int
+	}{
+		{"admin changes status", "Bearer " + adminToken, "", fiber.StatusOK},
+		{"customer changes status", "Bearer " + customerToken, "", fiber.StatusForbidden},
+		{"service account changes status", "", "order-service-api-key", fiber.StatusForbidden},
+		{"expired admin token", "Bearer " + signToken(t, testSecret, auth.RoleAdmin, time.Now().Add(-time.Minute)), "", fiber.StatusUnauthorized},
+		{"admin token with wrong secret", "Bearer " + signToken(t, "other-secret", auth.RoleAdmin, expiresAt), "", fiber.StatusUnauthorized},
+		{"missing credentials", "", "", fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			middleware := NewSimpleAuthMiddleware(logger, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+
+			app := fiber.New()
+			app.Patch("/orders/:id/status", middleware.RequireAuth(), middleware.RequireRole(auth.RoleAdmin), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("PATCH", "/orders/1/status", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestSimpleAuthMiddleware_RequireAuth_SetsTokenClaims(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewSimpleAuthMiddleware(logger, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+
+	app := fiber.New()
+	app.Get("/orders", middleware.RequireAuth(), func(c *fiber.Ctx) error {
+		assert.Equal(t, "5df84b6f-8f5b-4a51-a106-e9a46b67c836", c.Locals("userId"))
+		assert.Equal(t, auth.RoleCustomer, c.Locals("role"))
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, testSecret, auth.RoleCustomer, time.Now().Add(time.Hour)))
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestSimpleAuthMiddleware_APIKey(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewSimpleAuthMiddleware(logger, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+
+	app := fiber.New()
+	identify := func(c *fiber.Ctx) error {
+		key := c.Locals("apiKey").(*auth.APIKey)
+		return c.SendString(c.Locals("userId").(string) + " " + key.Name)
+	}
+	app.Post("/carts/validate", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeCarts), identify)
+	app.Get("/orders", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeOrders), identify)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		apiKey       string
+		expectedCode int
+		expectedBody string
+	}{
+		{"valid key with scope", "POST", "/carts/validate", "shop-key", fiber.StatusOK, "shop-service shop-service"},
+		{"valid key granted every scope", "GET", "/orders", "order-service-api-key", fiber.StatusOK, "internal-services internal-services"},
+		{"valid key without scope", "GET", "/orders", "shop-key", fiber.StatusForbidden, ""},
+		{"missing key", "GET", "/orders", "", fiber.StatusUnauthorized, ""},
+		{"unknown key", "GET", "/orders", "not-a-key", fiber.StatusUnauthorized, ""},
+		{"revoked key", "GET", "/orders", "revoked-key", fiber.StatusUnauthorized, "API key has been revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			if tt.expectedBody != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Contains(t, string(body), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestSimpleAuthMiddleware_RequireScope_AllowsUsers(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewSimpleAuthMiddleware(logger, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+
+	app := fiber.New()
+	app.Get("/orders", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeOrders), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, testSecret, auth.RoleCustomer, time.Now().Add(time.Hour)))
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
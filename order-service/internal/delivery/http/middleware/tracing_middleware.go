@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// TraceParentHeader is the W3C trace context header
+	TraceParentHeader = "traceparent"
+
+	// TraceIDLocal and TraceSampledLocal are the ctx.Locals keys set by Tracing
+	TraceIDLocal      = "trace_id"
+	TraceSampledLocal = "trace_sampled"
+)
+
+// TracingConfig controls trace sampling and request ID correlation
+type TracingConfig struct {
+	// SampleRatio is the fraction of traces to sample, between 0 and 1
+	SampleRatio float64
+	// ReuseTraceID uses the inbound trace ID as the request ID when no X-Request-ID is sent
+	ReuseTraceID bool
+}
+
+// Sampler decides whether a trace is sampled based on its trace ID.
+// The decision is deterministic, so every service configured with the same
+// ratio makes the same decision for the same trace.
+type Sampler struct {
+	threshold uint64
+}
+
+// NewSampler creates a sampler for the given ratio, clamped to [0, 1]
+func NewSampler(ratio float64) *Sampler {
+	switch {
+	case ratio <= 0:
+		return &Sampler{threshold: 0}
+	case ratio >= 1:
+		return &Sampler{threshold: math.MaxUint64}
+	}
+	return &Sampler{threshold: uint64(ratio * math.MaxUint64)}
+}
+
+// ShouldSample reports whether the trace with the given ID is sampled
+func (s *Sampler) ShouldSample(traceID [16]byte) bool {
+	if s.threshold == math.MaxUint64 {
+		return true
+	}
+	return binary.BigEndian.Uint64(traceID[8:]) < s.threshold
+}
+
+// Tracing creates a middleware that continues or starts a trace for every request.
+// It must run before the request ID middleware so the trace ID can be reused as the request ID.
+func Tracing(config TracingConfig) fiber.Handler {
+	sampler := NewSampler(config.SampleRatio)
+
+	return func(c *fiber.Ctx) error {
+		traceID, ok := parseTraceParent(c.Get(TraceParentHeader))
+		if ok && config.ReuseTraceID && c.Get("X-Request-ID") == "" {
+			c.Request().Header.Set("X-Request-ID", hex.EncodeToString(traceID[:]))
+		}
+		if !ok {
+			rand.Read(traceID[:])
+		}
+
+		sampled := sampler.ShouldSample(traceID)
+		c.Locals(TraceIDLocal, hex.EncodeToString(traceID[:]))
+		c.Locals(TraceSampledLocal, sampled)
+
+		// Propagate the trace with a new span ID for this service
+		var spanID [8]byte
+		rand.Read(spanID[:])
+		flags := "00"
+		if sampled {
+			flags = "01"
+		}
+		c.Set(TraceParentHeader, fmt.Sprintf("00-%x-%x-%s", traceID, spanID, flags))
+
+		return c.Next()
+	}
+}
+
+// parseTraceParent extracts the trace ID from a W3C traceparent header
+// (version-traceid-parentid-flags). Invalid or all-zero trace IDs are rejected.
+func parseTraceParent(header string) ([16]byte, bool) {
+	var traceID [16]byte
+
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return traceID, false
+	}
+
+	if _, err := hex.Decode(traceID[:], []byte(strings.ToLower(parts[1]))); err != nil {
+		return traceID, false
+	}
+
+	if traceID == [16]byte{} {
+		return traceID, false
+	}
+
+	return traceID, true
+}
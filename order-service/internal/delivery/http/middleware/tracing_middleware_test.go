@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampler_HonorsRatio(t *testing.T) {
+	const requests = 20000
+
+	for _, ratio := range []float64{0.1, 0.25, 0.5} {
+		sampler := NewSampler(ratio)
+
+		sampled := 0
+		for i := 0; i < requests; i++ {
+			var traceID [16]byte
+			rand.Read(traceID[:])
+			if sampler.ShouldSample(traceID) {
+				sampled++
+			}
+		}
+
+		// Allow a few standard deviations of slack so the test is not flaky
+		assert.InDelta(t, ratio, float64(sampled)/requests, 0.02, "ratio %v", ratio)
+	}
+}
+
+func TestSampler_Bounds(t *testing.T) {
+	var traceID [16]byte
+	rand.Read(traceID[:])
+
+	assert.False(t, NewSampler(0).ShouldSample(traceID))
+	assert.True(t, NewSampler(1).ShouldSample(traceID))
+	assert.True(t, NewSampler(2).ShouldSample(traceID))
+}
+
+func TestTracing_ReuseTraceID(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	newApp := func(config TracingConfig) *fiber.App {
+		app := fiber.New()
+		app.Use(Tracing(config))
+		app.Get("/", func(c *fiber.Ctx) error {
+			return c.SendString(c.Get("X-Request-ID"))
+		})
+		return app
+	}
+
+	requestID := func(t *testing.T, app *fiber.App, headers map[string]string) string {
+		req := httptest.NewRequest("GET", "/", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+
+		body, _ := io.ReadAll(resp.Body)
+
+		// The trace is always propagated downstream
+		assert.Contains(t, resp.Header.Get(TraceParentHeader), traceID)
+		return string(body)
+	}
+
+	t.Run("Reused", func(t *testing.T) {
+		id := requestID(t, newApp(TracingConfig{SampleRatio: 1, ReuseTraceID: true}), map[string]string{
+			TraceParentHeader: traceParent,
+		})
+		assert.Equal(t, traceID, id)
+	})
+
+	t.Run("ExplicitRequestIDWins", func(t *testing.T) {
+		id := requestID(t, newApp(TracingConfig{SampleRatio: 1, ReuseTraceID: true}), map[string]string{
+			TraceParentHeader: traceParent,
+			"X-Request-ID":    "client-request-id",
+		})
+		assert.Equal(t, "client-request-id", id)
+	})
+
+	t.Run("NotReusedWhenDisabled", func(t *testing.T) {
+		id := requestID(t, newApp(TracingConfig{SampleRatio: 1}), map[string]string{
+			TraceParentHeader: traceParent,
+		})
+		assert.Empty(t, id)
+	})
+}
+
+func TestTracing_InvalidTraceParentStartsNewTrace(t *testing.T) {
+	app := fiber.New()
+	app.Use(Tracing(TracingConfig{SampleRatio: 0, ReuseTraceID: true}))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(c.Get("X-Request-ID"))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(TraceParentHeader, "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	traceParent := resp.Header.Get(TraceParentHeader)
+	assert.Len(t, traceParent, 55)
+	assert.NotContains(t, traceParent, "00000000000000000000000000000000")
+	assert.Equal(t, "00", traceParent[len(traceParent)-2:], "unsampled trace should carry 00 flags")
+}
@@ -19,6 +19,15 @@ type Response struct {
 type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+
+	// Fields lists the request fields that failed validation, if any
+	Fields []appErrors.FieldError `json:"fields,omitempty"`
+
+	// Items lists the order items that lacked stock, if any
+	Items []appErrors.StockShortage `json:"items,omitempty"`
+
+	// MinimumOrder reports the required and actual amounts of an order below the minimum, if any
+	MinimumOrder *appErrors.MinimumOrderShortfall `json:"minimum_order,omitempty"`
 }
 
 // JSONSuccess sends a successful JSON response
@@ -47,6 +56,9 @@ func JSONError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 	statusCode := fiber.StatusInternalServerError
 	errorCode := "INTERNAL_SERVER_ERROR"
 	message := "An unexpected error occurred"
+	var fields []appErrors.FieldError
+	var items []appErrors.StockShortage
+	var minimumOrder *appErrors.MinimumOrderShortfall
 
 	// Extract details from AppError if possible
 	var appErr *appErrors.AppError
@@ -54,6 +66,9 @@ func JSONError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 		statusCode = appErr.StatusCode
 		errorCode = appErr.Code
 		message = appErr.Message
+		fields = appErr.Fields
+		items = appErr.Items
+		minimumOrder = appErr.MinimumOrder
 		
 		// Log the error with context
 		fields := logrus.Fields{
@@ -80,8 +95,11 @@ func JSONError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 	response := Response{
 		Success: false,
 		Error: &ErrorInfo{
-			Code:    errorCode,
-			Message: message,
+			Code:         errorCode,
+			Message:      message,
+			Fields:       fields,
+			Items:        items,
+			MinimumOrder: minimumOrder,
 		},
 	}
 
@@ -1,13 +1,15 @@
 package route
 
 import (
-	"github.com/google/uuid"
+	"order-service/internal/auth"
 	"order-service/internal/delivery/http/middleware"
 	"order-service/internal/delivery/http/response"
 	"order-service/internal/errors"
 	"order-service/internal/handler"
+	"order-service/internal/metrics"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/swagger"
 	"github.com/sirupsen/logrus"
 )
@@ -17,22 +19,35 @@ type RouteConfig struct {
 	OrderHandler       *handler.OrderHandler
 	ReservationHandler *handler.ReservationHandler
 	WarehouseHandler   *handler.WarehouseHandler
+	CartHandler        *handler.CartHandler
+	HealthHandler      *handler.HealthHandler
 	Log                *logrus.Logger
 	AuthMiddleware     *middleware.SimpleAuthMiddleware
+	Tracing            middleware.TracingConfig
+	Metrics            *metrics.Metrics
+	RateLimiters       map[string]middleware.RateLimiter
+	AccessLog          middleware.LoggerConfig
+	CORS               middleware.CORSConfig
 }
 
 func (c *RouteConfig) Setup() {
-	// Add request ID middleware (must be first)
-	c.App.Use(func(ctx *fiber.Ctx) error {
-		requestID := ctx.Get("X-Request-ID")
-		if requestID == "" {
-			ctx.Set("X-Request-ID", uuid.New().String())
-		}
-		return ctx.Next()
-	})
+	// Metrics is nil unless enabled in config; when set, serve /metrics and instrument every other request
+	if c.Metrics != nil {
+		c.App.Get("/metrics", adaptor.HTTPHandler(c.Metrics.Handler()))
+		c.App.Use(middleware.Metrics(c.Metrics))
+	}
+
+	// Continue or start a trace (runs before the request ID middleware so the trace ID can become the request ID)
+	c.App.Use(middleware.Tracing(c.Tracing))
 
-	// Apply logger middleware
-	c.App.Use(middleware.Logger(c.Log))
+	// Add request ID middleware
+	c.App.Use(middleware.RequestID())
+
+	// Answer CORS preflight requests before rate limiting and authentication see them
+	c.App.Use(middleware.CORS(c.CORS))
+
+	// Apply access log middleware
+	c.App.Use(middleware.Logger(c.AccessLog, c.Log))
 
 	// Swagger documentation
 	c.App.Get("/swagger/*", swagger.HandlerDefault)
@@ -41,38 +56,70 @@ func (c *RouteConfig) Setup() {
 	api := c.App.Group("/api")
 	v1 := api.Group("/v1")
 
-	// Health check endpoint
-	v1.Get("/health", func(ctx *fiber.Ctx) error {
-		return response.JSONSuccess(ctx, map[string]string{"status": "ok"})
-	})
+	// Liveness stays a fast path; readiness checks the database and downstream services
+	v1.Get("/health", c.HealthHandler.Live)
+	v1.Get("/health/ready", c.HealthHandler.Ready)
+
+	// Health checks above are exempt; every other API route shares the default limit
+	v1.Use(c.rateLimit("default"))
+
+	// Every order, cart, reservation and inventory route requires authentication;
+	// callers using an API key also need the scope of the route group
+	requireAuth := c.AuthMiddleware.RequireAuth()
+	requireAdmin := c.AuthMiddleware.RequireRole(auth.RoleAdmin)
 
 	// Order endpoints
-	orders := v1.Group("/orders")
-	orders.Post("/", c.AuthMiddleware.RequireAuth(), c.OrderHandler.CreateOrder)
-	orders.Get("/", c.AuthMiddleware.RequireAuth(), c.OrderHandler.GetUserOrders)
-	orders.Get("/:id", c.AuthMiddleware.RequireAuth(), c.OrderHandler.GetOrder)
-	orders.Patch("/:id/status", c.AuthMiddleware.RequireAuth(), c.OrderHandler.UpdateOrderStatus)
-	orders.Post("/:id/payment", c.AuthMiddleware.RequireAuth(), c.OrderHandler.ProcessPayment)
+	orders := v1.Group("/orders", c.rateLimit("orders"), requireAuth, c.AuthMiddleware.RequireScope(auth.ScopeOrders))
+	orders.Post("/", c.OrderHandler.CreateOrder)
+	orders.Get("/", c.OrderHandler.GetUserOrders)
+	orders.Get("/export", requireAdmin, c.OrderHandler.ExportOrders)
+	orders.Get("/search", requireAdmin, c.OrderHandler.SearchOrders)
+	orders.Get("/summary", c.OrderHandler.GetOrderSummary)
+	orders.Get("/:id", c.OrderHandler.GetOrder)
+	orders.Get("/:id/history", c.OrderHandler.GetOrderStatusHistory)
+	orders.Patch("/:id/status", requireAdmin, c.OrderHandler.UpdateOrderStatus)
+	orders.Post("/:id/payment", c.OrderHandler.ProcessPayment)
+	orders.Post("/:id/reconcile", requireAdmin, c.OrderHandler.ReconcileOrder)
+	orders.Post("/:id/reactivate", requireAdmin, c.OrderHandler.ReactivateOrder)
+	orders.Post("/:id/complete", requireAdmin, c.OrderHandler.CompleteOrder)
+	orders.Post("/:id/items/cancel", c.OrderHandler.CancelOrderItems)
 
 	// Order reservation endpoints
-	orders.Get("/:order_id/reservations", c.AuthMiddleware.RequireAuth(), c.ReservationHandler.GetOrderReservations)
+	orders.Get("/:order_id/reservations", requireAdmin, c.ReservationHandler.GetOrderReservations)
+
+	// Cart endpoints
+	carts := v1.Group("/carts", requireAuth, c.AuthMiddleware.RequireScope(auth.ScopeCarts))
+	carts.Post("/validate", c.CartHandler.ValidateCart)
 
 	// Reservation endpoints
-	reservations := v1.Group("/reservations")
-	reservations.Post("/", c.AuthMiddleware.RequireAuth(), c.ReservationHandler.CreateReservation)
-	reservations.Post("/:id/deactivate", c.AuthMiddleware.RequireAuth(), c.ReservationHandler.DeactivateReservation)
-	reservations.Post("/cleanup", c.AuthMiddleware.RequireAuth(), c.ReservationHandler.CleanupExpiredReservations)
+	reservations := v1.Group("/reservations", requireAuth, c.AuthMiddleware.RequireScope(auth.ScopeReservations))
+	reservations.Post("/", c.ReservationHandler.CreateReservation)
+	reservations.Post("/:id/deactivate", c.ReservationHandler.DeactivateReservation)
+	reservations.Post("/cleanup", c.ReservationHandler.CleanupExpiredReservations)
 
 	// Inventory endpoints
-	inventory := v1.Group("/inventory")
-	inventory.Get("/:product_id/:warehouse_id", c.AuthMiddleware.RequireAuth(), c.WarehouseHandler.GetInventory)
-	inventory.Post("/batch", c.AuthMiddleware.RequireAuth(), c.WarehouseHandler.GetInventoryBatch)
-	inventory.Post("/reserve", c.AuthMiddleware.RequireAuth(), c.WarehouseHandler.ReserveStock)
-	inventory.Post("/confirm", c.AuthMiddleware.RequireAuth(), c.WarehouseHandler.ConfirmStockDeduction)
-	inventory.Post("/release", c.AuthMiddleware.RequireAuth(), c.WarehouseHandler.ReleaseReservation)
+	inventory := v1.Group("/inventory", requireAuth, c.AuthMiddleware.RequireScope(auth.ScopeInventory))
+	inventory.Get("/:product_id/:warehouse_id", c.WarehouseHandler.GetInventory)
+	inventory.Post("/batch", c.WarehouseHandler.GetInventoryBatch)
+	inventory.Post("/availability", c.WarehouseHandler.CheckAvailability)
+	inventory.Post("/reserve", c.WarehouseHandler.ReserveStock)
+	inventory.Post("/confirm", c.WarehouseHandler.ConfirmStockDeduction)
+	inventory.Post("/release", c.WarehouseHandler.ReleaseReservation)
 
 	// 404 Handler
 	c.App.Use(func(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, errors.ErrResourceNotFound, c.Log)
 	})
+}
+
+// rateLimit returns the rate limiting middleware for the named route group,
+// or a pass-through when rate limiting is disabled or the group has no limit configured
+func (c *RouteConfig) rateLimit(group string) fiber.Handler {
+	limiter, ok := c.RateLimiters[group]
+	if !ok {
+		return func(ctx *fiber.Ctx) error {
+			return ctx.Next()
+		}
+	}
+	return middleware.RateLimitMiddleware(limiter, c.Log)
 }
\ No newline at end of file
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+)
+
+// DiscountType is how a coupon reduces an order total
+type DiscountType string
+
+const (
+	// DiscountTypePercentage takes DiscountValue percent off the order subtotal
+	DiscountTypePercentage DiscountType = "percentage"
+	// DiscountTypeFixed takes DiscountValue off the order subtotal, in the coupon currency
+	DiscountTypeFixed DiscountType = "fixed"
+)
+
+// Coupon is a promo code that discounts an order
+type Coupon struct {
+	ID            uint         `gorm:"column:id;primaryKey;autoIncrement"`
+	Code          string       `gorm:"column:code;type:varchar(50);not null;uniqueIndex:idx_code"`
+	DiscountType  DiscountType `gorm:"column:discount_type;type:enum('percentage','fixed');not null"`
+	DiscountValue float64      `gorm:"column:discount_value;type:decimal(10,2);not null"` // A percentage, or an amount for fixed discounts
+	Currency      string       `gorm:"column:currency;type:char(3)"`                      // Required for fixed discounts, which only apply to orders in this currency
+	ExpiresAt     *time.Time   `gorm:"column:expires_at"`                                 // Never expires when nil
+	IsActive      bool         `gorm:"column:is_active;not null;default:true"`
+	CreatedAt     time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time    `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+}
+
+func (c *Coupon) TableName() string {
+	return "coupons"
+}
+
+// IsExpired reports whether the coupon's expiry has passed at the given time
+func (c *Coupon) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && !now.Before(*c.ExpiresAt)
+}
+
+// Discount returns the amount the coupon takes off a subtotal, rounded to cents and never more than the subtotal
+func (c *Coupon) Discount(subtotal Money) Money {
+	var discount Money
+	switch c.DiscountType {
+	case DiscountTypePercentage:
+		discount = subtotal.Percent(c.DiscountValue)
+	case DiscountTypeFixed:
+		discount = NewMoneyFromFloat(c.DiscountValue)
+	}
+
+	return max(0, min(discount, subtotal))
+}
@@ -1,8 +1,128 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	// ErrInsufficientStock is returned when there's not enough stock to fulfill a request
 	ErrInsufficientStock = errors.New("insufficient stock available")
-)
\ No newline at end of file
+
+	// ErrPriceMismatch is returned when a submitted unit price deviates from the authoritative product price
+	ErrPriceMismatch = errors.New("unit price does not match current product price")
+
+	// ErrProductNotFound is returned when a product cannot be found in the product catalogue
+	ErrProductNotFound = errors.New("product not found")
+
+	// ErrMixedCurrency is returned when the items of an order use different currencies
+	ErrMixedCurrency = errors.New("order items must share one currency")
+
+	// ErrPaymentDeclined is returned when the payment gateway declines a charge
+	ErrPaymentDeclined = errors.New("payment declined")
+
+	// ErrPaymentUnavailable is returned when the payment gateway cannot be reached or fails without deciding on a charge
+	ErrPaymentUnavailable = errors.New("payment gateway unavailable")
+
+	// ErrOrderAlreadyPaid is returned when paying an order that has already been paid or completed
+	ErrOrderAlreadyPaid = errors.New("order already paid")
+
+	// ErrPaymentInProgress is returned when changing an order, or cancelling its items, while its charge is still in flight
+	ErrPaymentInProgress = errors.New("payment in progress")
+
+	// ErrOrderOperationInProgress is returned when reconciling or reactivating an order that another reconciliation or reactivation is working on
+	ErrOrderOperationInProgress = errors.New("order operation in progress")
+
+	// ErrOrderCancelled is returned when paying an order that has been cancelled
+	ErrOrderCancelled = errors.New("order cancelled")
+
+	// ErrOrderNotCancelled is returned when reactivating an order that has not been cancelled
+	ErrOrderNotCancelled = errors.New("order not cancelled")
+
+	// ErrPaymentDeadlinePassed is returned when reactivating a cancelled order after its original payment deadline
+	ErrPaymentDeadlinePassed = errors.New("payment deadline has passed")
+
+	// ErrReservationReleasePending is returned when reactivating a cancelled order whose stock release has not reached the warehouse yet
+	ErrReservationReleasePending = errors.New("reservation release still pending")
+
+	// ErrOrderNotPaid is returned when reconciling the stock of an order, or completing an order, that has not been paid
+	ErrOrderNotPaid = errors.New("order not paid")
+
+	// ErrOrderAlreadyCompleted is returned when completing an order that has already been completed
+	ErrOrderAlreadyCompleted = errors.New("order already completed")
+
+	// ErrStockDeductionFailed is returned when the warehouse does not confirm the stock deduction of a paid order
+	ErrStockDeductionFailed = errors.New("stock deduction failed")
+
+	// ErrOrderContention is returned when placing an order keeps deadlocking with concurrent orders after every retry
+	ErrOrderContention = errors.New("order conflicted with concurrent orders")
+
+	// ErrBelowMinimumOrder is returned when the discounted subtotal of an order is below the configured minimum order amount
+	ErrBelowMinimumOrder = errors.New("order amount below minimum")
+
+	// ErrInvalidCoupon is returned when a coupon code does not exist, is inactive or cannot apply to the order
+	ErrInvalidCoupon = errors.New("invalid coupon code")
+
+	// ErrCouponExpired is returned when a coupon code is past its expiry
+	ErrCouponExpired = errors.New("coupon code expired")
+
+	// ErrExportRangeTooLarge is returned when an order export spans more than the configured maximum date range
+	ErrExportRangeTooLarge = errors.New("export date range too large")
+
+	// ErrSearchCriteriaRequired is returned when an order search sets no criteria, which would scan every order
+	ErrSearchCriteriaRequired = errors.New("at least one search criterion is required")
+)
+
+// StockShortage describes an item whose stock could not cover the requested quantity
+type StockShortage struct {
+	ProductID   uint
+	WarehouseID uint
+	Requested   int
+	Available   int
+}
+
+// Shortfall returns how many more units the item would have needed
+func (s StockShortage) Shortfall() int {
+	return s.Requested - s.Available
+}
+
+// InsufficientStockError lists the items that lacked stock. It wraps ErrInsufficientStock, so
+// errors.Is(err, ErrInsufficientStock) still holds. Items is empty when the shortages are not known.
+type InsufficientStockError struct {
+	Items []StockShortage
+}
+
+// Error names each short item and its shortfall
+func (e *InsufficientStockError) Error() string {
+	if len(e.Items) == 0 {
+		return ErrInsufficientStock.Error()
+	}
+	parts := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		parts[i] = fmt.Sprintf("product %d in warehouse %d short by %d", item.ProductID, item.WarehouseID, item.Shortfall())
+	}
+	return fmt.Sprintf("%s: %s", ErrInsufficientStock, strings.Join(parts, ", "))
+}
+
+// Unwrap returns ErrInsufficientStock
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
+}
+
+// BelowMinimumOrderError reports the minimum order amount an order fell short of. It wraps ErrBelowMinimumOrder,
+// so errors.Is(err, ErrBelowMinimumOrder) still holds.
+type BelowMinimumOrderError struct {
+	Required Money // Configured minimum order amount
+	Actual   Money // Subtotal of the order after discounts
+}
+
+// Error names the actual and required amounts
+func (e *BelowMinimumOrderError) Error() string {
+	return fmt.Sprintf("%s: %s is below the required %s", ErrBelowMinimumOrder, e.Actual, e.Required)
+}
+
+// Unwrap returns ErrBelowMinimumOrder
+func (e *BelowMinimumOrderError) Unwrap() error {
+	return ErrBelowMinimumOrder
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -14,17 +15,41 @@ const (
 	OrderStatusPaid      OrderStatus = "paid"
 	OrderStatusCancelled OrderStatus = "cancelled"
 	OrderStatusCompleted OrderStatus = "completed"
+
+	// OrderStatusPaymentProcessing marks a pending order whose charge is in flight with the payment gateway.
+	// It is only set by ProcessPayment, which moves the order on to paid or back to pending.
+	OrderStatusPaymentProcessing OrderStatus = "payment_processing"
 )
 
+// DefaultCurrency is the ISO-4217 currency used when an order does not specify one
+const DefaultCurrency = "USD"
+
 // Order represents an order entity
 type Order struct {
 	ID              uint         `gorm:"column:id;primaryKey;autoIncrement"`
 	UserID          string       `gorm:"column:user_id;type:char(36);not null;index:idx_user_id"`
-	Status          OrderStatus  `gorm:"column:status;type:enum('pending','paid','cancelled','completed');default:pending;index:idx_status"`
-	TotalAmount     float64      `gorm:"column:total_amount;type:decimal(10,2);not null"`
+	Status          OrderStatus  `gorm:"column:status;type:enum('pending','payment_processing','paid','cancelled','completed');default:pending;index:idx_status"`
+	Subtotal        Money        `gorm:"column:subtotal;type:decimal(10,2);not null;default:0"` // Sum of the item totals
+	TotalAmount     Money        `gorm:"column:total_amount;type:decimal(10,2);not null"`       // Grand total: Subtotal less DiscountAmount plus TaxAmount and ShippingCost
+	Currency        string       `gorm:"column:currency;type:char(3);not null;default:USD"`
+	CouponCode      string       `gorm:"column:coupon_code;type:varchar(50)"`
+	DiscountAmount  Money        `gorm:"column:discount_amount;type:decimal(10,2);not null;default:0"`
+	TaxRate         float64      `gorm:"column:tax_rate;type:decimal(6,3);not null;default:0"` // Percentage applied to the discounted subtotal
+	TaxAmount       Money        `gorm:"column:tax_amount;type:decimal(10,2);not null;default:0"`
+	ShippingCost    Money        `gorm:"column:shipping_cost;type:decimal(10,2);not null;default:0"` // Not taxed
 	ShippingAddress string       `gorm:"column:shipping_address;type:text;not null"`
 	PaymentMethod   string       `gorm:"column:payment_method;type:varchar(50);not null"`
 	PaymentDeadline time.Time    `gorm:"column:payment_deadline;not null"`
+	PaymentReference string      `gorm:"column:payment_reference;type:varchar(100)"`
+	PaymentAttempts int          `gorm:"column:payment_attempts;not null;default:0"` // Declined charges; each payment attempt is charged with its own idempotency key
+	ReservationReference string  `gorm:"column:reservation_reference;type:varchar(100)"` // Reference the warehouse holds the order's stock under
+	StockDeductedAt  *time.Time  `gorm:"column:stock_deducted_at"` // Set once the warehouse confirmed the deduction of a paid order
+	StockDeductionAttempts int   `gorm:"column:stock_deduction_attempts;not null;default:0"`
+	StockDeductionError string   `gorm:"column:stock_deduction_error;type:text"` // Last failed deduction, cleared once it succeeds
+	Carrier         string       `gorm:"column:carrier;type:varchar(50)"`          // Set when the order is completed
+	TrackingNumber  string       `gorm:"column:tracking_number;type:varchar(100)"` // Set when the order is completed
+	CompletedAt     *time.Time   `gorm:"column:completed_at"`
+	ClaimedAt       *time.Time   `gorm:"column:claimed_at"` // Set while a reconciliation or reactivation calls the warehouse for the order
 	CreatedAt       time.Time    `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt       time.Time    `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
 	OrderItems      []OrderItem  `gorm:"foreignKey:OrderID"`
@@ -35,6 +60,31 @@ func (o *Order) TableName() string {
 	return "orders"
 }
 
+// IsStockDeducted reports whether the stock reserved for the order has been confirmed as sold
+func (o *Order) IsStockDeducted() bool {
+	return o.StockDeductedAt != nil
+}
+
+// StockReference returns the reference the warehouse holds the order's stock under. Orders placed before
+// the reference was stored fall back to res_<id>.
+func (o *Order) StockReference() string {
+	if o.ReservationReference != "" {
+		return o.ReservationReference
+	}
+	return fmt.Sprintf("res_%d", o.ID)
+}
+
+// ApplyAmounts sets the order subtotal and discount, then derives the tax from TaxRate and the grand total.
+// The tax is rounded half-up to cents and the rest is exact, so TotalAmount is exactly
+// Subtotal - DiscountAmount + TaxAmount + ShippingCost.
+func (o *Order) ApplyAmounts(subtotal, discount Money) {
+	o.Subtotal = subtotal
+	o.DiscountAmount = discount
+	taxable := o.Subtotal - o.DiscountAmount
+	o.TaxAmount = taxable.Percent(o.TaxRate)
+	o.TotalAmount = taxable + o.TaxAmount + o.ShippingCost
+}
+
 func (o *Order) BeforeCreate(tx *gorm.DB) (err error) {
 	o.CreatedAt = time.Now()
 	o.UpdatedAt = time.Now()
@@ -13,8 +13,8 @@ type OrderItem struct {
 	ProductID   uint      `gorm:"column:product_id;not null;index:idx_product_id"`
 	WarehouseID uint      `gorm:"column:warehouse_id;not null;index:idx_warehouse_id"`
 	Quantity    int       `gorm:"column:quantity;not null"`
-	UnitPrice   float64   `gorm:"column:unit_price;type:decimal(10,2);not null"`
-	TotalPrice  float64   `gorm:"column:total_price;type:decimal(10,2);not null"`
+	UnitPrice   Money     `gorm:"column:unit_price;type:decimal(10,2);not null"`
+	TotalPrice  Money     `gorm:"column:total_price;type:decimal(10,2);not null"`
 	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
 	Order       *Order    `gorm:"foreignKey:OrderID"`
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// StatusActorSystem is recorded as the actor of status changes made by background jobs rather than a caller
+const StatusActorSystem = "system"
+
+// OrderStatusHistory records one status change of an order and who made it
+type OrderStatusHistory struct {
+	ID         uint        `gorm:"column:id;primaryKey;autoIncrement"`
+	OrderID    uint        `gorm:"column:order_id;not null;index:idx_order_id"`
+	FromStatus OrderStatus `gorm:"column:from_status;type:varchar(20);not null"`
+	ToStatus   OrderStatus `gorm:"column:to_status;type:varchar(20);not null"`
+	Actor      string      `gorm:"column:actor;type:varchar(100);not null"` // User ID of the caller, or StatusActorSystem
+	CreatedAt  time.Time   `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (h *OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReleaseOutboxStatus represents the processing state of a queued reservation release
+type ReleaseOutboxStatus string
+
+const (
+	ReleaseOutboxStatusPending ReleaseOutboxStatus = "pending"
+	ReleaseOutboxStatusDone    ReleaseOutboxStatus = "done"
+)
+
+// ReservationReleaseOutbox records an inventory release that must reach the warehouse service.
+// Rows stay pending until the release succeeds, so failed releases can be retried.
+type ReservationReleaseOutbox struct {
+	ID          uint                `gorm:"column:id;primaryKey;autoIncrement"`
+	OrderID     uint                `gorm:"column:order_id;not null;index:idx_order_id"`
+	ProductID   uint                `gorm:"column:product_id;not null"`
+	WarehouseID uint                `gorm:"column:warehouse_id;not null"`
+	Quantity    int                 `gorm:"column:quantity;not null"`
+	Reference   string              `gorm:"column:reference;type:varchar(100)"` // Reference the warehouse holds the stock under
+	Status      ReleaseOutboxStatus `gorm:"column:status;type:varchar(20);not null;default:'pending';index:idx_status;index:idx_status_updated_at,priority:1"`
+	Attempts    int                 `gorm:"column:attempts;not null;default:0"`
+	LastError   string              `gorm:"column:last_error;type:text"`
+	CreatedAt   time.Time           `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time           `gorm:"column:updated_at;autoCreateTime;autoUpdateTime;index:idx_status_updated_at,priority:2"`
+}
+
+func (r *ReservationReleaseOutbox) TableName() string {
+	return "reservation_release_outbox"
+}
+
+// StockReference returns the reference the warehouse holds the stock under. Entries queued before the
+// reference was stored fall back to res_<order id>, like Order.StockReference.
+func (r *ReservationReleaseOutbox) StockReference() string {
+	if r.Reference != "" {
+		return r.Reference
+	}
+	return fmt.Sprintf("res_%d", r.OrderID)
+}
+
+// ToOrderItem returns the order item whose reservation this entry releases
+func (r *ReservationReleaseOutbox) ToOrderItem() OrderItem {
+	return OrderItem{
+		OrderID:     r.OrderID,
+		ProductID:   r.ProductID,
+		WarehouseID: r.WarehouseID,
+		Quantity:    r.Quantity,
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+)
+
+// WebhookEventType names the kind of change a webhook event reports
+type WebhookEventType string
+
+const (
+	WebhookEventOrderStatusChanged WebhookEventType = "order.status_changed"
+)
+
+// WebhookEventStatus represents the delivery state of a queued webhook event
+type WebhookEventStatus string
+
+const (
+	WebhookEventStatusPending   WebhookEventStatus = "pending"
+	WebhookEventStatusDelivered WebhookEventStatus = "delivered"
+	WebhookEventStatusFailed    WebhookEventStatus = "failed" // Gave up after the configured number of attempts
+)
+
+// WebhookEvent is an order event queued for delivery to the configured webhook URL.
+// It is written in the same transaction as the change it reports and stays pending until
+// the receiver acknowledges it, so every committed change is delivered at least once.
+type WebhookEvent struct {
+	ID            uint               `gorm:"column:id;primaryKey;autoIncrement"`
+	EventType     WebhookEventType   `gorm:"column:event_type;type:varchar(50);not null"`
+	OrderID       uint               `gorm:"column:order_id;not null;index:idx_order_id"`
+	Payload       string             `gorm:"column:payload;type:text;not null"` // JSON sent as the event data
+	Status        WebhookEventStatus `gorm:"column:status;type:varchar(20);not null;default:'pending';index:idx_status_next_attempt_at,priority:1;index:idx_status_updated_at,priority:1"`
+	Attempts      int                `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt time.Time          `gorm:"column:next_attempt_at;not null;index:idx_status_next_attempt_at,priority:2"`
+	LastError     string             `gorm:"column:last_error;type:text"`
+	DeliveredAt   *time.Time         `gorm:"column:delivered_at"`
+	CreatedAt     time.Time          `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time          `gorm:"column:updated_at;autoCreateTime;autoUpdateTime;index:idx_status_updated_at,priority:2"`
+}
+
+func (e *WebhookEvent) TableName() string {
+	return "webhook_events"
+}
@@ -11,6 +11,15 @@ type AppError struct {
 	Message    string `json:"message"`
 	StatusCode int    `json:"-"` // HTTP status code
 	Err        error  `json:"-"` // Original error
+
+	// Fields lists the request fields that failed validation, if any
+	Fields []FieldError `json:"fields,omitempty"`
+
+	// Items lists the order items that lacked stock, if any
+	Items []StockShortage `json:"items,omitempty"`
+
+	// MinimumOrder reports the required and actual amounts of an order below the minimum, if any
+	MinimumOrder *MinimumOrderShortfall `json:"minimum_order,omitempty"`
 }
 
 // Error returns the error message
@@ -61,6 +70,13 @@ var (
 		nil,
 	)
 
+	ErrForbidden = NewAppError(
+		"FORBIDDEN",
+		"You do not have access to this resource",
+		http.StatusForbidden,
+		nil,
+	)
+
 	ErrInvalidCredentials = NewAppError(
 		"INVALID_CREDENTIALS",
 		"Invalid email or password",
@@ -95,24 +111,44 @@ var (
 		http.StatusRequestTimeout,
 		nil,
 	)
+
+	ErrServiceUnavailable = NewAppError(
+		"SERVICE_UNAVAILABLE",
+		"One or more dependencies are unavailable",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
+	ErrTooManyRequests = NewAppError(
+		"TOO_MANY_REQUESTS",
+		"Too many requests, try again later",
+		http.StatusTooManyRequests,
+		nil,
+	)
 )
 
 // WithError wraps the original error with AppError
 func WithError(appErr *AppError, err error) *AppError {
 	return &AppError{
-		Code:       appErr.Code,
-		Message:    appErr.Message,
-		StatusCode: appErr.StatusCode,
-		Err:        err,
+		Code:         appErr.Code,
+		Message:      appErr.Message,
+		StatusCode:   appErr.StatusCode,
+		Err:          err,
+		Fields:       appErr.Fields,
+		Items:        appErr.Items,
+		MinimumOrder: appErr.MinimumOrder,
 	}
 }
 
 // WithMessage creates a new error with a custom message
 func WithMessage(appErr *AppError, message string) *AppError {
 	return &AppError{
-		Code:       appErr.Code,
-		Message:    message,
-		StatusCode: appErr.StatusCode,
-		Err:        appErr.Err,
+		Code:         appErr.Code,
+		Message:      message,
+		StatusCode:   appErr.StatusCode,
+		Err:          appErr.Err,
+		Fields:       appErr.Fields,
+		Items:        appErr.Items,
+		MinimumOrder: appErr.MinimumOrder,
 	}
 }
\ No newline at end of file
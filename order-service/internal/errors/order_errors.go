@@ -2,6 +2,7 @@ package errors
 
 import (
 	"net/http"
+	"order-service/internal/entity"
 )
 
 // Order-specific error types
@@ -23,7 +24,21 @@ var (
 	ErrOrderAlreadyPaid = NewAppError(
 		"ORDER_ALREADY_PAID",
 		"Order has already been paid",
-		http.StatusBadRequest,
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrPaymentInProgress = NewAppError(
+		"PAYMENT_IN_PROGRESS",
+		"A payment for the order is already being processed, try again later",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrOrderOperationInProgress = NewAppError(
+		"ORDER_OPERATION_IN_PROGRESS",
+		"Another operation on the order is in progress, try again later",
+		http.StatusConflict,
 		nil,
 	)
 
@@ -34,6 +49,48 @@ var (
 		nil,
 	)
 
+	ErrOrderNotCancelled = NewAppError(
+		"ORDER_NOT_CANCELLED",
+		"Only cancelled orders can be reactivated",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrPaymentDeadlinePassed = NewAppError(
+		"PAYMENT_DEADLINE_PASSED",
+		"The payment deadline of the order has passed",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrReservationReleasePending = NewAppError(
+		"RESERVATION_RELEASE_PENDING",
+		"The stock of the cancelled order is still being released, try again later",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrOrderNotPaid = NewAppError(
+		"ORDER_NOT_PAID",
+		"Order has not been paid",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrOrderAlreadyCompleted = NewAppError(
+		"ORDER_ALREADY_COMPLETED",
+		"Order has already been completed",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrStockDeductionFailed = NewAppError(
+		"STOCK_DEDUCTION_FAILED",
+		"Warehouse did not confirm the stock deduction, try again later",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
 	ErrPaymentFailed = NewAppError(
 		"PAYMENT_FAILED",
 		"Failed to process payment",
@@ -41,6 +98,27 @@ var (
 		nil,
 	)
 
+	ErrPaymentDeclined = NewAppError(
+		"PAYMENT_DECLINED",
+		"Payment was declined",
+		http.StatusPaymentRequired,
+		nil,
+	)
+
+	ErrPaymentGatewayUnavailable = NewAppError(
+		"PAYMENT_GATEWAY_UNAVAILABLE",
+		"Payment gateway is unavailable, try again later",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
+	ErrOrderContention = NewAppError(
+		"ORDER_CONTENTION",
+		"Too many concurrent orders for the same stock, try again later",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
 	ErrInsufficientStock = NewAppError(
 		"INSUFFICIENT_STOCK",
 		"Insufficient stock to fulfill order",
@@ -55,6 +133,41 @@ var (
 		nil,
 	)
 
+	ErrMixedCurrency = NewAppError(
+		"MIXED_CURRENCY",
+		"All order items must use the same currency",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	ErrPriceMismatch = NewAppError(
+		"PRICE_MISMATCH",
+		"Unit price does not match current product price",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	ErrBelowMinimumOrder = NewAppError(
+		"BELOW_MINIMUM_ORDER",
+		"Order amount is below the minimum order amount",
+		http.StatusUnprocessableEntity,
+		nil,
+	)
+
+	ErrInvalidCoupon = NewAppError(
+		"INVALID_COUPON",
+		"Coupon code is invalid",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	ErrCouponExpired = NewAppError(
+		"COUPON_EXPIRED",
+		"Coupon code has expired",
+		http.StatusBadRequest,
+		nil,
+	)
+
 	ErrReservationFailed = NewAppError(
 		"RESERVATION_FAILED",
 		"Failed to reserve stock",
@@ -75,4 +188,36 @@ var (
 		http.StatusBadRequest,
 		nil,
 	)
-)
\ No newline at end of file
+)
+
+// StockShortage describes an order item whose stock could not cover the requested quantity
+type StockShortage struct {
+	ProductID   uint `json:"product_id"`
+	WarehouseID uint `json:"warehouse_id"`
+	Requested   int  `json:"requested"`
+	Available   int  `json:"available"`
+	Shortfall   int  `json:"shortfall"`
+}
+
+// NewInsufficientStockError returns ErrInsufficientStock listing the items that were short
+func NewInsufficientStockError(items []StockShortage, err error) *AppError {
+	appErr := WithError(ErrInsufficientStock, err)
+	appErr.Items = items
+	return appErr
+}
+
+// MinimumOrderShortfall describes how far an order fell short of the minimum order amount
+type MinimumOrderShortfall struct {
+	RequiredAmount entity.Money `json:"required_amount" swaggertype:"number" example:"50.00"`
+	ActualAmount   entity.Money `json:"actual_amount" swaggertype:"number" example:"42.50"`
+}
+
+// NewBelowMinimumOrderError returns ErrBelowMinimumOrder with the required and actual amounts
+func NewBelowMinimumOrderError(minimum *entity.BelowMinimumOrderError) *AppError {
+	appErr := WithError(ErrBelowMinimumOrder, minimum)
+	appErr.MinimumOrder = &MinimumOrderShortfall{
+		RequiredAmount: minimum.Required,
+		ActualAmount:   minimum.Actual,
+	}
+	return appErr
+}
@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single request field that failed validation
+type FieldError struct {
+	Field   string `json:"field"`   // JSON path of the field, e.g. items[0].quantity
+	Rule    string `json:"rule"`    // Validator tag that failed, e.g. gt
+	Message string `json:"message"`
+}
+
+// JSONFieldName names struct fields after their JSON tag in validation errors.
+// Register it with Validate.RegisterTagNameFunc so field errors match the request body.
+func JSONFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// NewValidationError converts the error returned by Validate.Struct into ErrInvalidInput
+// carrying one FieldError per failed field. Other errors are wrapped in ErrInvalidInput as is.
+func NewValidationError(err error) *AppError {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok || len(validationErrors) == 0 {
+		return WithError(ErrInvalidInput, err)
+	}
+
+	fields := make([]FieldError, len(validationErrors))
+	messages := make([]string, len(validationErrors))
+	for i, fieldErr := range validationErrors {
+		fields[i] = FieldError{
+			Field:   fieldPath(fieldErr),
+			Rule:    fieldErr.ActualTag(),
+			Message: fieldMessage(fieldPath(fieldErr), fieldErr),
+		}
+		messages[i] = fields[i].Message
+	}
+
+	return &AppError{
+		Code:       ErrInvalidInput.Code,
+		Message:    "Invalid input: " + strings.Join(messages, "; "),
+		StatusCode: ErrInvalidInput.StatusCode,
+		Err:        err,
+		Fields:     fields,
+	}
+}
+
+// fieldPath drops the struct name from the namespace, e.g. CreateOrderRequest.items[0].quantity becomes items[0].quantity
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// fieldMessage describes a failed validation rule in plain words
+func fieldMessage(field string, fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	isString := fieldErr.Kind() == reflect.String
+
+	switch fieldErr.ActualTag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, param)
+	case "gte", "min":
+		if isString {
+			return fmt.Sprintf("%s must be at least %s characters long", field, param)
+		}
+		return fmt.Sprintf("%s must be at least %s", field, param)
+	case "lte", "max":
+		if isString {
+			return fmt.Sprintf("%s must be at most %s characters long", field, param)
+		}
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(param, " ", ", "))
+	case "iso4217":
+		return fmt.Sprintf("%s must be an ISO 4217 currency code", field)
+	}
+	return fmt.Sprintf("%s failed the %s rule", field, fieldErr.ActualTag())
+}
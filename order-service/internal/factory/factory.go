@@ -3,8 +3,15 @@ package factory
 import (
 	"context"
 	"order-service/internal/config"
+	"order-service/internal/entity"
+	"order-service/internal/gateway/payment"
+	"order-service/internal/gateway/product"
+	"order-service/internal/gateway/shipping"
+	"order-service/internal/gateway/tax"
 	"order-service/internal/gateway/warehouse"
+	"order-service/internal/gateway/webhook"
 	"order-service/internal/messaging"
+	"order-service/internal/metrics"
 	"order-service/internal/repository"
 	"order-service/internal/usecase"
 
@@ -19,15 +26,20 @@ type Factory struct {
 	Config   *config.AppConfig
 	Log      *logrus.Logger
 	Validate *validator.Validate
+	// Metrics is nil when metrics are disabled
+	Metrics *metrics.Metrics
+
+	inventoryUseCase usecase.InventoryUseCaseInterface
 }
 
 // NewFactory creates a new Factory instance
-func NewFactory(db *gorm.DB, config *config.AppConfig, log *logrus.Logger, validate *validator.Validate) *Factory {
+func NewFactory(db *gorm.DB, config *config.AppConfig, log *logrus.Logger, validate *validator.Validate, metrics *metrics.Metrics) *Factory {
 	return &Factory{
 		DB:       db,
 		Config:   config,
 		Log:      log,
 		Validate: validate,
+		Metrics:  metrics,
 	}
 }
 
@@ -78,6 +90,51 @@ func (f *Factory) CreateWarehouseGateway() warehouse.WarehouseGatewayInterface {
 	return warehouse.NewWarehouseGateway(client, f.Log)
 }
 
+// CreateProductClient creates a new product client
+func (f *Factory) CreateProductClient() *product.Client {
+	productConfig := f.Config.GetProductConfig()
+	return product.NewClient(
+		productConfig.BaseURL,
+		productConfig.Timeout,
+		f.Log,
+	)
+}
+
+// CreateProductGateway creates a new product gateway
+func (f *Factory) CreateProductGateway() product.ProductPriceGatewayInterface {
+	client := f.CreateProductClient()
+	return product.NewProductPriceGateway(client, f.Log)
+}
+
+// CreateProductPriceGateway creates a product gateway for order price validation, or nil when it is disabled
+func (f *Factory) CreateProductPriceGateway() product.ProductPriceGatewayInterface {
+	if !f.Config.GetProductConfig().ValidatePrices {
+		return nil
+	}
+	return f.CreateProductGateway()
+}
+
+// CreatePaymentGateway creates a new payment gateway
+func (f *Factory) CreatePaymentGateway() payment.PaymentGatewayInterface {
+	return payment.NewApprovingPaymentGateway(f.Log)
+}
+
+// CreateTaxCalculator creates a new tax calculator
+func (f *Factory) CreateTaxCalculator() tax.TaxCalculatorInterface {
+	return tax.NewFlatRateTaxCalculator(f.Config.GetOrderConfig().TaxRate, f.Log)
+}
+
+// CreateShippingCalculator creates a new shipping calculator
+func (f *Factory) CreateShippingCalculator() shipping.ShippingCalculatorInterface {
+	shippingConfig := f.Config.GetShippingConfig()
+	return shipping.NewQuantityShippingCalculator(
+		shippingConfig.BaseCost,
+		shippingConfig.PerItemCost,
+		shippingConfig.FreeThreshold,
+		f.Log,
+	)
+}
+
 // CreateReservationRepository creates a new reservation repository
 func (f *Factory) CreateReservationRepository() repository.ReservationRepositoryInterface {
 	return repository.NewReservationRepository(f.Log, f.DB)
@@ -88,8 +145,46 @@ func (f *Factory) CreateOrderRepository() repository.OrderRepositoryInterface {
 	return repository.NewOrderRepository(f.Log, f.DB)
 }
 
-// CreateInventoryUseCase creates a new inventory usecase
+// CreateWebhookRepository creates a new webhook repository
+func (f *Factory) CreateWebhookRepository() repository.WebhookRepositoryInterface {
+	return repository.NewWebhookRepository(f.Log, f.DB)
+}
+
+// CreateOrderWebhookRepository returns the webhook repository status changes are queued in, or nil when webhooks are disabled
+func (f *Factory) CreateOrderWebhookRepository() repository.WebhookRepositoryInterface {
+	if !f.Config.GetWebhookConfig().Enabled() {
+		return nil
+	}
+	return f.CreateWebhookRepository()
+}
+
+// CreateWebhookClient creates a client that posts signed events to the configured webhook URL
+func (f *Factory) CreateWebhookClient() *webhook.Client {
+	webhookConfig := f.Config.GetWebhookConfig()
+	return webhook.NewClient(
+		webhookConfig.URL,
+		webhookConfig.Secret,
+		webhookConfig.Timeout,
+		f.Log,
+	)
+}
+
+// CreateCouponRepository creates a new coupon repository
+func (f *Factory) CreateCouponRepository() repository.CouponRepositoryInterface {
+	return repository.NewCouponRepository(f.Log, f.DB)
+}
+
+// CreateInventoryUseCase returns the inventory usecase, creating it on first use. It is shared
+// because in async mode it owns the consumer of inventory responses, which must only start once.
 func (f *Factory) CreateInventoryUseCase() usecase.InventoryUseCaseInterface {
+	if f.inventoryUseCase == nil {
+		f.inventoryUseCase = f.newInventoryUseCase()
+	}
+	return f.inventoryUseCase
+}
+
+// newInventoryUseCase creates the inventory usecase for the configured mode
+func (f *Factory) newInventoryUseCase() usecase.InventoryUseCaseInterface {
 	warehouseConfig := f.Config.GetWarehouseConfig()
 	
 	// Check the async mode config to determine which implementation to use
@@ -104,6 +199,7 @@ func (f *Factory) CreateInventoryUseCase() usecase.InventoryUseCaseInterface {
 				f.Log,
 				nil,
 				f.CreateWarehouseGateway(),
+				f.Config.GetOrderConfig().Timeouts,
 			)
 		}
 		
@@ -113,6 +209,8 @@ func (f *Factory) CreateInventoryUseCase() usecase.InventoryUseCaseInterface {
 			producer,
 			f.CreateOrderRepository(),
 			f.CreateReservationRepository(),
+			f.CreateWarehouseGateway(),
+			f.Config.GetOrderConfig().Timeouts,
 		)
 		
 		// Create and start the consumer with the async use case as the handler
@@ -125,6 +223,7 @@ func (f *Factory) CreateInventoryUseCase() usecase.InventoryUseCaseInterface {
 				f.Log,
 				nil,
 				f.CreateWarehouseGateway(),
+				f.Config.GetOrderConfig().Timeouts,
 			)
 		}
 		
@@ -137,6 +236,7 @@ func (f *Factory) CreateInventoryUseCase() usecase.InventoryUseCaseInterface {
 				f.Log,
 				nil,
 				f.CreateWarehouseGateway(),
+				f.Config.GetOrderConfig().Timeouts,
 			)
 		}
 		
@@ -149,17 +249,47 @@ func (f *Factory) CreateInventoryUseCase() usecase.InventoryUseCaseInterface {
 		f.Log,
 		nil,
 		f.CreateWarehouseGateway(),
+		f.Config.GetOrderConfig().Timeouts,
 	)
 }
 
 // CreateOrderUseCase creates a new order usecase
 func (f *Factory) CreateOrderUseCase() usecase.OrderUseCaseInterface {
-	return usecase.NewOrderUseCase(
-		f.DB,
+	return usecase.NewOrderUseCase(usecase.OrderUseCaseDeps{
+		DB:                    f.DB,
+		Log:                   f.Log,
+		Validate:              f.Validate,
+		OrderRepository:       f.CreateOrderRepository(),
+		ReservationRepository: f.CreateReservationRepository(),
+		InventoryUseCase:      f.CreateInventoryUseCase(),
+		PaymentGateway:        f.CreatePaymentGateway(),
+		PaymentDeadline:       f.Config.GetOrderConfig().PaymentDeadline,
+		ExportMaxRange:        f.Config.GetOrderConfig().ExportMaxRange,
+		Pagination:            f.Config.GetPaginationConfig(),
+		PriceGateway:          f.CreateProductPriceGateway(),
+		PriceTolerance:        entity.NewMoneyFromFloat(f.Config.GetProductConfig().PriceTolerance),
+		Metrics:               f.Metrics,
+		CouponRepository:      f.CreateCouponRepository(),
+		TaxCalculator:         f.CreateTaxCalculator(),
+		ShippingCalculator:    f.CreateShippingCalculator(),
+		WebhookRepository:     f.CreateOrderWebhookRepository(),
+		Timeouts:              f.Config.GetOrderConfig().Timeouts,
+		DeadlockRetry: usecase.DeadlockRetry{
+			MaxRetries: f.Config.GetOrderConfig().DeadlockRetries,
+			Backoff:    f.Config.GetOrderConfig().DeadlockBackoff,
+		},
+		MinimumOrderAmount: entity.NewMoneyFromFloat(f.Config.GetOrderConfig().MinimumOrderAmount),
+	})
+}
+
+// CreateCartUseCase creates a new cart usecase
+func (f *Factory) CreateCartUseCase() usecase.CartUseCaseInterface {
+	return usecase.NewCartUseCase(
 		f.Log,
 		f.Validate,
-		f.CreateOrderRepository(),
-		f.CreateReservationRepository(),
-		f.CreateInventoryUseCase(),
+		f.CreateProductGateway(),
+		f.CreateWarehouseGateway(),
+		entity.NewMoneyFromFloat(f.Config.GetProductConfig().PriceTolerance),
+		f.Config.GetOrderConfig().Timeouts,
 	)
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"order-service/internal/entity"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrPaymentDeclined is returned when the payment provider declines a charge
+	ErrPaymentDeclined = errors.New("payment declined")
+
+	// ErrGatewayUnavailable is returned when the payment provider cannot be reached
+	ErrGatewayUnavailable = errors.New("payment gateway unavailable")
+)
+
+// ApprovingPaymentGateway is a default PaymentGatewayInterface that approves every charge.
+// It is intended for development until a real payment provider is integrated.
+type ApprovingPaymentGateway struct {
+	Log *logrus.Logger
+
+	// Transaction IDs of approved charges by idempotency key
+	charges map[string]string
+	mu      sync.Mutex
+}
+
+// NewApprovingPaymentGateway creates a new always-approve payment gateway
+func NewApprovingPaymentGateway(log *logrus.Logger) *ApprovingPaymentGateway {
+	return &ApprovingPaymentGateway{
+		Log:     log,
+		charges: make(map[string]string),
+	}
+}
+
+// Charge approves the charge and returns a generated transaction ID.
+// A charge repeating an idempotency key returns the transaction ID of the original charge.
+func (g *ApprovingPaymentGateway) Charge(ctx context.Context, order *entity.Order, idempotencyKey string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if reference, ok := g.charges[idempotencyKey]; ok {
+		g.Log.Infof("Payment for order %d already approved with reference %s", order.ID, reference)
+		return reference, nil
+	}
+
+	reference := uuid.NewString()
+	g.charges[idempotencyKey] = reference
+	g.Log.Infof("Approved payment of %s for order %d with reference %s", order.TotalAmount, order.ID, reference)
+	return reference, nil
+}
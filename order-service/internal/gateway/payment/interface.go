@@ -0,0 +1,15 @@
+package payment
+
+import (
+	"context"
+	"order-service/internal/entity"
+)
+
+// PaymentGatewayInterface defines the contract for charging orders
+type PaymentGatewayInterface interface {
+	// Charge charges the order total and returns the gateway transaction ID.
+	// A declined charge returns ErrPaymentDeclined; a provider that cannot be reached returns ErrGatewayUnavailable.
+	// Any error other than ErrPaymentDeclined means the charge was not decided and may be retried.
+	// Charges with the same idempotency key are one charge: repeating it returns the original transaction ID.
+	Charge(ctx context.Context, order *entity.Order, idempotencyKey string) (string, error)
+}
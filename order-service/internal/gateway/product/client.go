@@ -0,0 +1,85 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HTTPClient defines the interface for HTTP operations
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client implements an HTTP client for the product service
+type Client struct {
+	BaseURL    string
+	HTTPClient HTTPClient
+	Timeout    time.Duration
+	Log        *logrus.Logger
+}
+
+// NewClient creates a new product service client
+func NewClient(baseURL string, timeout time.Duration, log *logrus.Logger) *Client {
+	return &Client{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: timeout,
+		},
+		Timeout: timeout,
+		Log:     log,
+	}
+}
+
+// doGet performs a GET request and unmarshals the response
+func (c *Client) doGet(ctx context.Context, path string, result interface{}) error {
+	// Create request URL
+	url := fmt.Sprintf("%s%s", c.BaseURL, path)
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	// Unmarshal response
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("error unmarshaling response body: %w", err)
+	}
+
+	return nil
+}
+
+// Ping checks that the product service is reachable and answers its liveness endpoint
+func (c *Client) Ping(ctx context.Context) error {
+	var health struct {
+		Success bool `json:"success"`
+	}
+	return c.doGet(ctx, "/api/v1/health", &health)
+}
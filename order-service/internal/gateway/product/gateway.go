@@ -0,0 +1,78 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"order-service/internal/entity"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrConnectionFailed is returned when we can't connect to the product service
+var ErrConnectionFailed = errors.New("failed to connect to product service")
+
+// ProductPriceGateway implements the ProductPriceGatewayInterface
+type ProductPriceGateway struct {
+	Client *Client
+	Log    *logrus.Logger
+}
+
+// NewProductPriceGateway creates a new product price gateway
+func NewProductPriceGateway(client *Client, log *logrus.Logger) *ProductPriceGateway {
+	return &ProductPriceGateway{
+		Client: client,
+		Log:    log,
+	}
+}
+
+// GetPrices gets the current price of each product using the product service batch endpoint
+func (g *ProductPriceGateway) GetPrices(ctx context.Context, productIDs []uint) (map[uint]entity.Money, error) {
+	products, err := g.GetProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[uint]entity.Money, len(products))
+	for productID, product := range products {
+		prices[productID] = product.Price
+	}
+
+	return prices, nil
+}
+
+// GetProducts gets the current details of each product using the product service batch endpoint
+func (g *ProductPriceGateway) GetProducts(ctx context.Context, productIDs []uint) (map[uint]*ProductResponse, error) {
+	products := make(map[uint]*ProductResponse, len(productIDs))
+	if len(productIDs) == 0 {
+		return products, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, productID := range productIDs {
+		ids[i] = strconv.FormatUint(uint64(productID), 10)
+	}
+
+	// Make API call
+	var response ProductListResponse
+	path := "/api/v1/products/batch?ids=" + url.QueryEscape(strings.Join(ids, ","))
+	if err := g.Client.doGet(ctx, path, &response); err != nil {
+		g.Log.Errorf("Failed to get products: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
+	}
+
+	for i := range response.Data.Products {
+		product := &response.Data.Products[i]
+		productID, err := strconv.ParseUint(product.ID, 10, 32)
+		if err != nil {
+			g.Log.Warnf("Skipping product with unexpected ID %q", product.ID)
+			continue
+		}
+		products[uint(productID)] = product
+	}
+
+	return products, nil
+}
@@ -0,0 +1,17 @@
+package product
+
+import (
+	"context"
+	"order-service/internal/entity"
+)
+
+// ProductPriceGatewayInterface defines the contract for looking up authoritative product data
+type ProductPriceGatewayInterface interface {
+	// GetPrices gets the current price of each product in a single lookup.
+	// Products that do not exist are absent from the returned map.
+	GetPrices(ctx context.Context, productIDs []uint) (map[uint]entity.Money, error)
+
+	// GetProducts gets the current details of each product in a single lookup.
+	// Products that do not exist are absent from the returned map.
+	GetProducts(ctx context.Context, productIDs []uint) (map[uint]*ProductResponse, error)
+}
@@ -0,0 +1,28 @@
+package product
+
+import "order-service/internal/entity"
+
+// ProductResponse represents the product data returned from the product service
+type ProductResponse struct {
+	ID     string       `json:"id"`
+	Name   string       `json:"name"`
+	Price  entity.Money `json:"price"`
+	Status string       `json:"status"`
+}
+
+// ProductStatusActive is the status of a product that can be sold
+const ProductStatusActive = "active"
+
+// IsActive reports whether the product can currently be sold.
+// Products without a status predate status tracking and are treated as active.
+func (p *ProductResponse) IsActive() bool {
+	return p.Status == "" || p.Status == ProductStatusActive
+}
+
+// ProductListResponse represents a list of products returned from the product service
+type ProductListResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Products []ProductResponse `json:"products"`
+	} `json:"data"`
+}
@@ -0,0 +1,47 @@
+package shipping
+
+import (
+	"context"
+	"order-service/internal/entity"
+	"order-service/internal/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// QuantityShippingCalculator is a default ShippingCalculatorInterface that charges a base cost plus a cost per unit.
+// Order items carry no weight, so the unit count stands in for it until a carrier integration is added.
+// Orders whose item subtotal reaches FreeThreshold ship for free.
+type QuantityShippingCalculator struct {
+	BaseCost      entity.Money
+	PerItemCost   entity.Money
+	FreeThreshold entity.Money // Zero disables free shipping
+	Log           *logrus.Logger
+}
+
+// NewQuantityShippingCalculator creates a new quantity-based shipping calculator.
+// The configured costs are rounded to cents.
+func NewQuantityShippingCalculator(baseCost, perItemCost, freeThreshold float64, log *logrus.Logger) *QuantityShippingCalculator {
+	return &QuantityShippingCalculator{
+		BaseCost:      entity.NewMoneyFromFloat(baseCost),
+		PerItemCost:   entity.NewMoneyFromFloat(perItemCost),
+		FreeThreshold: entity.NewMoneyFromFloat(freeThreshold),
+		Log:           log,
+	}
+}
+
+// ShippingCost returns the base cost plus the per-item cost for every unit, or zero once the free shipping threshold is met
+func (c *QuantityShippingCalculator) ShippingCost(ctx context.Context, items []model.OrderItemRequest, shippingAddress string) (entity.Money, error) {
+	var subtotal entity.Money
+	var units int
+	for _, item := range items {
+		subtotal += item.UnitPrice.Mul(item.Quantity)
+		units += item.Quantity
+	}
+
+	if c.FreeThreshold > 0 && subtotal >= c.FreeThreshold {
+		c.Log.Debugf("Free shipping for subtotal %s at threshold %s", subtotal, c.FreeThreshold)
+		return 0, nil
+	}
+
+	return c.BaseCost + c.PerItemCost.Mul(units), nil
+}
@@ -0,0 +1,14 @@
+package shipping
+
+import (
+	"context"
+	"order-service/internal/entity"
+	"order-service/internal/model"
+)
+
+// ShippingCalculatorInterface defines the contract for estimating the shipping cost of an order
+type ShippingCalculatorInterface interface {
+	// ShippingCost returns the cost of shipping the items to the given address, in the order currency.
+	// An error means no cost could be determined.
+	ShippingCost(ctx context.Context, items []model.OrderItemRequest, shippingAddress string) (entity.Money, error)
+}
@@ -0,0 +1,27 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FlatRateTaxCalculator is a default TaxCalculatorInterface that applies the same configured rate to every region.
+// It is intended until a tax provider with per-region rates is integrated.
+type FlatRateTaxCalculator struct {
+	Rate float64 // Percentage, e.g. 10 for 10%
+	Log  *logrus.Logger
+}
+
+// NewFlatRateTaxCalculator creates a new flat-rate tax calculator
+func NewFlatRateTaxCalculator(rate float64, log *logrus.Logger) *FlatRateTaxCalculator {
+	return &FlatRateTaxCalculator{
+		Rate: rate,
+		Log:  log,
+	}
+}
+
+// TaxRate returns the configured rate regardless of the shipping address
+func (c *FlatRateTaxCalculator) TaxRate(ctx context.Context, shippingAddress string) (float64, error) {
+	return c.Rate, nil
+}
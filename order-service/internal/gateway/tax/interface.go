@@ -0,0 +1,10 @@
+package tax
+
+import "context"
+
+// TaxCalculatorInterface defines the contract for looking up the tax rate of an order
+type TaxCalculatorInterface interface {
+	// TaxRate returns the tax rate, as a percentage, for orders shipped to the given address.
+	// Implementations may derive the region from the address; an error means no rate could be determined.
+	TaxRate(ctx context.Context, shippingAddress string) (float64, error)
+}
@@ -17,6 +17,16 @@ type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// StatusError is returned when the warehouse service answers with a status outside 2xx
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API request failed with status code %d: %s", e.StatusCode, e.Body)
+}
+
 // Client implements an HTTP client for the warehouse service
 type Client struct {
 	BaseURL    string
@@ -78,7 +88,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 	// Check status code
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(respBody))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	// Unmarshal response if result container provided
@@ -90,3 +100,8 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 	return nil
 }
+
+// Ping checks that the warehouse service is reachable and answers its liveness endpoint
+func (c *Client) Ping(ctx context.Context) error {
+	return c.doRequest(ctx, http.MethodGet, "/api/v1/health", nil, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"order-service/internal/entity"
 	"order-service/internal/model"
 
@@ -35,74 +36,77 @@ func NewWarehouseGateway(client *Client, log *logrus.Logger) *WarehouseGateway {
 	}
 }
 
-// CheckAndReserveStock checks and reserves stock for multiple items
-func (g *WarehouseGateway) CheckAndReserveStock(ctx context.Context, orderID uint, items []model.OrderItemRequest, reserveUntil string) (*ReservationResponse, error) {
-	// Parse reserveUntil string into time.Time if provided, otherwise use 24h from now
-	// var reserveUntilTime time.Time
-	var err error
-
-	// if reserveUntil != "" {
-	// 	reserveUntilTime, err = time.Parse(time.RFC3339, reserveUntil)
-	// 	if err != nil {
-	// 		g.Log.Warnf("Invalid reserveUntil format: %s, defaulting to 24h", reserveUntil)
-	// 		reserveUntilTime = time.Now().Add(24 * time.Hour)
-	// 	}
-	// } else {
-	// 	reserveUntilTime = time.Now().Add(24 * time.Hour)
-	// }
-
+// CheckAndReserveStock reserves stock for all items in one batch under the given reference, so later commits
+// and releases can name the reservation. Nothing is reserved when any item lacks stock.
+func (g *WarehouseGateway) CheckAndReserveStock(ctx context.Context, orderID uint, reference string, items []model.OrderItemRequest, reserveUntil string) (*ReservationResponse, error) {
 	// Prepare request
-	orderItems := make([]ReservationOrderItem, len(items))
+	request := ReserveStockBatchRequest{
+		Items: make([]ReserveStockRequest, len(items)),
+	}
 	for i, item := range items {
-		orderItems[i] = ReservationOrderItem{
-			ProductID:   item.ProductID,
+		request.Items[i] = ReserveStockRequest{
 			WarehouseID: item.WarehouseID,
+			ProductID:   item.ProductID,
 			Quantity:    item.Quantity,
-			UnitPrice:   item.UnitPrice,
+			Reference:   reference,
 		}
 	}
 
-	request := ReserveStockRequest{
-		WarehouseID: items[0].WarehouseID,
-		ProductID:   items[0].ProductID,
-		Quantity:    items[0].Quantity,
-	}
-
 	// Make API call
-	var response ReservationResponse
-	err = g.Client.doRequest(ctx, "POST", "/api/v1/inventory/reserve", request, &response)
+	var batchResponse ReserveStockBatchResponse
+	err := g.Client.doRequest(ctx, "POST", "/api/v1/inventory/reserve/batch", request, &batchResponse)
 	if err != nil {
+		// The warehouse answers 422 when any item lacks stock and rolls the whole batch back
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnprocessableEntity {
+			g.Log.Warnf("Stock reservation failed: %v", err)
+			return &ReservationResponse{ReservationID: reference, OrderID: orderID, Message: "insufficient stock"}, ErrInsufficientStock
+		}
 		g.Log.Errorf("Failed to reserve stock: %v", err)
 		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
 	}
 
+	response := &ReservationResponse{
+		ReservationID: reference,
+		OrderID:       orderID,
+		Items:         make([]ReservationResponseItem, len(batchResponse.Data.Results)),
+		Success:       batchResponse.Success && batchResponse.Data.Reserved,
+	}
+	for i, result := range batchResponse.Data.Results {
+		response.Items[i] = ReservationResponseItem{
+			ProductID:   result.ProductID,
+			WarehouseID: result.WarehouseID,
+			Quantity:    result.Quantity,
+			Available:   result.Status == "reserved",
+			Message:     result.Error,
+		}
+	}
+
 	if !response.Success {
-		g.Log.Warnf("Stock reservation failed: %s", response.Message)
-		return &response, ErrInsufficientStock
+		g.Log.Warnf("Stock reservation failed for reference %s", reference)
+		return response, ErrInsufficientStock
 	}
 
-	return &response, nil
+	return response, nil
 }
 
-// ConfirmStockDeduction commits reserved stock as sold (after payment)
-func (g *WarehouseGateway) ConfirmStockDeduction(ctx context.Context, orderID uint, reservationID string) (*StockOperationResponse, error) {
-	request := ReservationConfirmRequest{
-		ReservationID: reservationID,
-		OrderID:       orderID,
-	}
-
+// ConfirmStockDeduction commits reserved stock as sold (after payment). The warehouse ignores a repeated
+// commit of the same reference, so the call can be retried.
+func (g *WarehouseGateway) ConfirmStockDeduction(ctx context.Context, orderID uint, reservation ReservationCommitRequest) (*StockOperationResponse, error) {
 	var response StockOperationResponse
-	err := g.Client.doRequest(ctx, "POST", "/api/v1/inventory/commit", request, &response)
+	err := g.Client.doRequest(ctx, "POST", "/api/v1/inventory/reserve/commit", reservation, &response)
 	if err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			g.Log.Warnf("Reservation %s not found for order %d", reservation.Reference, orderID)
+			return nil, ErrReservationNotFound
+		}
 		g.Log.Errorf("Failed to confirm stock deduction: %v", err)
 		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
 	}
 
 	if !response.Success {
 		g.Log.Warnf("Stock confirmation failed: %s", response.Message)
-		if response.Message == "reservation not found" {
-			return &response, ErrReservationNotFound
-		}
 		return &response, errors.New(response.Message)
 	}
 
@@ -115,6 +119,11 @@ func (g *WarehouseGateway) ReleaseReservation(ctx context.Context, orderID uint,
 	var response StockOperationResponse
 	err := g.Client.doRequest(ctx, "POST", "/api/v1/inventory/reserve/cancel", reservation, &response)
 	if err != nil {
+		// The warehouse answers 404 when no active reservation has the reference
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return nil, ErrReservationNotFound
+		}
 		g.Log.Errorf("Failed to release stock reservation: %v", err)
 		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, err)
 	}
@@ -14,11 +14,11 @@ type InventoryQuery struct {
 
 // WarehouseGatewayInterface defines the contract for interacting with the warehouse service
 type WarehouseGatewayInterface interface {
-	// CheckAndReserveStock checks and reserves stock for multiple items
-	CheckAndReserveStock(ctx context.Context, orderID uint, items []model.OrderItemRequest, reserveUntil string) (*ReservationResponse, error)
+	// CheckAndReserveStock checks and reserves stock for multiple items under the given reference
+	CheckAndReserveStock(ctx context.Context, orderID uint, reference string, items []model.OrderItemRequest, reserveUntil string) (*ReservationResponse, error)
 
 	// ConfirmStockDeduction commits reserved stock as sold (after payment)
-	ConfirmStockDeduction(ctx context.Context, orderID uint, reservationID string) (*StockOperationResponse, error)
+	ConfirmStockDeduction(ctx context.Context, orderID uint, reservation ReservationCommitRequest) (*StockOperationResponse, error)
 
 	// ReleaseReservation releases stock back to available inventory (e.g., cancelled order)
 	ReleaseReservation(ctx context.Context, orderID uint, reservation ReservationReleaseRequest) (*StockOperationResponse, error)
@@ -1,6 +1,7 @@
 package warehouse
 
 import (
+	"order-service/internal/entity"
 	"time"
 )
 
@@ -27,18 +28,43 @@ type ReservationRequest struct {
 	ReserveUntil time.Time              `json:"reserve_until"`
 }
 
+// ReserveStockRequest represents a request to reserve stock of one product under the given reference
 type ReserveStockRequest struct {
-	WarehouseID uint `json:"warehouse_id" validate:"required"`
-	ProductID   uint `json:"product_id" validate:"required"`
-	Quantity    int  `json:"quantity" validate:"required,gt=0"`
+	WarehouseID uint   `json:"warehouse_id" validate:"required"`
+	ProductID   uint   `json:"product_id" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Reference   string `json:"reference,omitempty"`
+}
+
+// ReserveStockBatchRequest represents a request to reserve several items in one warehouse transaction
+type ReserveStockBatchRequest struct {
+	Items []ReserveStockRequest `json:"items"`
+}
+
+// ReserveStockBatchResponse represents the warehouse service response to a batch reservation
+type ReserveStockBatchResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Reserved bool                    `json:"reserved"`
+		Results  []ReserveStockBatchItem `json:"results"`
+	} `json:"data"`
+}
+
+// ReserveStockBatchItem represents the outcome of one item of a batch reservation
+type ReserveStockBatchItem struct {
+	WarehouseID uint   `json:"warehouse_id"`
+	ProductID   uint   `json:"product_id"`
+	Quantity    int    `json:"quantity"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
 }
 
 // ReservationOrderItem represents an item to be reserved in warehouse inventory
 type ReservationOrderItem struct {
-	ProductID   uint    `json:"product_id"`
-	WarehouseID uint    `json:"warehouse_id"`
-	Quantity    int     `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price,omitempty"`
+	ProductID   uint         `json:"product_id"`
+	WarehouseID uint         `json:"warehouse_id"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   entity.Money `json:"unit_price,omitempty"`
 }
 
 // ReservationResponse represents a response from the warehouse service for a reservation
@@ -68,10 +94,12 @@ type ReservationReleaseRequest struct {
 	Reference   string `json:"reference" validate:"required"`
 }
 
-// ReservationConfirmRequest represents a request to confirm (deduct) a reservation
-type ReservationConfirmRequest struct {
-	ReservationID string `json:"reservation_id,omitempty"`
-	OrderID       uint   `json:"order_id"`
+// ReservationCommitRequest represents a request to commit (deduct) a reservation
+type ReservationCommitRequest struct {
+	WarehouseID uint   `json:"warehouse_id" validate:"required"`
+	ProductID   uint   `json:"product_id" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Reference   string `json:"reference" validate:"required"`
 }
 
 // InventoryQueryRequest represents a request to query inventory
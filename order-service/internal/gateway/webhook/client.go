@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"order-service/internal/entity"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the request body
+	SignatureHeader = "X-Signature"
+	// EventTypeHeader carries the event type, e.g. order.status_changed
+	EventTypeHeader = "X-Webhook-Event"
+	// EventIDHeader carries the event ID, which stays the same across retries so receivers can drop duplicates
+	EventIDHeader = "X-Webhook-ID"
+)
+
+// HTTPClient defines the interface for HTTP operations
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Envelope is the JSON body posted for every event
+type Envelope struct {
+	ID        uint                    `json:"id"`
+	Type      entity.WebhookEventType `json:"type"`
+	CreatedAt string                  `json:"created_at"`
+	Data      json.RawMessage         `json:"data"`
+}
+
+// Client posts signed webhook events to a single URL
+type Client struct {
+	URL        string
+	Secret     string
+	HTTPClient HTTPClient
+	Log        *logrus.Logger
+}
+
+// NewClient creates a webhook client that signs request bodies with secret
+func NewClient(url, secret string, timeout time.Duration, log *logrus.Logger) *Client {
+	return &Client{
+		URL:    url,
+		Secret: secret,
+		HTTPClient: &http.Client{
+			Timeout: timeout,
+		},
+		Log: log,
+	}
+}
+
+// Sign returns the X-Signature value for body: "sha256=" followed by the hex HMAC-SHA256 of body keyed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the event wrapped in an Envelope and signs the exact bytes sent
+func (c *Client) Send(ctx context.Context, event *entity.WebhookEvent) error {
+	body, err := json.Marshal(Envelope{
+		ID:        event.ID,
+		Type:      event.EventType,
+		CreatedAt: event.CreatedAt.UTC().Format(time.RFC3339),
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling webhook event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(c.Secret, body))
+	req.Header.Set(EventTypeHeader, string(event.EventType))
+	req.Header.Set(EventIDHeader, strconv.FormatUint(uint64(event.ID), 10))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The start of the body is kept to explain a rejected delivery
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook receiver answered with status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import (
+	"context"
+	"order-service/internal/entity"
+)
+
+// WebhookSenderInterface defines the contract for delivering a queued event to the webhook receiver
+type WebhookSenderInterface interface {
+	// Send posts the event and returns nil only when the receiver answers with a 2xx status.
+	// Any error means the event was not acknowledged and should be sent again later.
+	Send(ctx context.Context, event *entity.WebhookEvent) error
+}
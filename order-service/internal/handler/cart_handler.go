@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"order-service/internal/context"
+	"order-service/internal/delivery/http/response"
+	appErrors "order-service/internal/errors"
+	"order-service/internal/model"
+	"order-service/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CartHandler struct {
+	Log         *logrus.Logger
+	CartUseCase usecase.CartUseCaseInterface
+}
+
+func NewCartHandler(cartUseCase usecase.CartUseCaseInterface, logger *logrus.Logger) *CartHandler {
+	return &CartHandler{
+		Log:         logger,
+		CartUseCase: cartUseCase,
+	}
+}
+
+// ValidateCart godoc
+// @Summary Validate a shopping cart
+// @Description Checks that every cart item is an active product at its current price with enough stock, without creating an order or reserving stock
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param cart body model.ValidateCartRequest true "Cart items"
+// @Success 200 {object} model.CartValidationResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /carts/validate [post]
+func (h *CartHandler) ValidateCart(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	request := new(model.ValidateCartRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), h.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	validation, err := h.CartUseCase.ValidateCart(timeoutCtx, request)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to validate cart")
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
+		}
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	return response.JSONSuccess(ctx, validation)
+}
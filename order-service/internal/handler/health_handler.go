@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"order-service/internal/delivery/http/response"
+	"order-service/internal/errors"
+	"order-service/internal/model"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// healthCheckTimeout bounds how long readiness waits for all dependency checks
+const healthCheckTimeout = 2 * time.Second
+
+// DependencyCheck reports whether a downstream dependency is reachable
+type DependencyCheck func(ctx context.Context) error
+
+// HealthHandler handles the liveness and readiness endpoints
+type HealthHandler struct {
+	DB         *gorm.DB
+	Downstream map[string]DependencyCheck
+	Log        *logrus.Logger
+}
+
+// NewHealthHandler creates a new health handler that checks the database and the given downstream services
+func NewHealthHandler(db *gorm.DB, downstream map[string]DependencyCheck, log *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		DB:         db,
+		Downstream: downstream,
+		Log:        log,
+	}
+}
+
+// Live godoc
+// @Summary Liveness check
+// @Description Reports that the process is up without checking any dependency
+// @Tags Health
+// @Produce json
+// @Success 200 {object} response.Response{data=model.HealthResponse}
+// @Router /health [get]
+func (h *HealthHandler) Live(ctx *fiber.Ctx) error {
+	return response.JSONSuccess(ctx, model.HealthResponse{Status: model.HealthStatusOK})
+}
+
+// Ready godoc
+// @Summary Readiness check
+// @Description Pings the database and the warehouse and product services. Returns 503 with the status of each dependency when any is down.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} response.Response{data=model.HealthResponse}
+// @Failure 503 {object} response.Response{data=model.HealthResponse,error=response.ErrorInfo}
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(ctx *fiber.Ctx) error {
+	checkCtx, cancel := context.WithTimeout(ctx.UserContext(), healthCheckTimeout)
+	defer cancel()
+
+	health := model.HealthResponse{
+		Status:       model.HealthStatusReady,
+		Dependencies: h.checkDependencies(checkCtx),
+	}
+
+	var down []string
+	for name, dependency := range health.Dependencies {
+		if dependency.Status != model.HealthStatusUp {
+			down = append(down, name)
+		}
+	}
+
+	if len(down) == 0 {
+		return response.JSONSuccess(ctx, health)
+	}
+
+	health.Status = model.HealthStatusNotReady
+	h.Log.WithField("dependencies", down).Warn("Readiness check failed")
+
+	return ctx.Status(fiber.StatusServiceUnavailable).JSON(response.Response{
+		Success: false,
+		Data:    health,
+		Error: &response.ErrorInfo{
+			Code:    errors.ErrServiceUnavailable.Code,
+			Message: errors.ErrServiceUnavailable.Message,
+		},
+	})
+}
+
+// checkDependencies runs the database and downstream checks concurrently,
+// so a slow service does not push the others past the timeout
+func (h *HealthHandler) checkDependencies(ctx context.Context) map[string]model.DependencyHealth {
+	checks := make(map[string]DependencyCheck, len(h.Downstream)+1)
+	checks["database"] = h.pingDatabase
+	for name, check := range h.Downstream {
+		checks[name] = check
+	}
+
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		dependencies = make(map[string]model.DependencyHealth, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check DependencyCheck) {
+			defer wg.Done()
+
+			dependency := model.DependencyHealth{Status: model.HealthStatusUp}
+			if err := check(ctx); err != nil {
+				dependency = model.DependencyHealth{Status: model.HealthStatusDown, Error: err.Error()}
+			}
+
+			mu.Lock()
+			dependencies[name] = dependency
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	return dependencies
+}
+
+// pingDatabase pings the underlying connection pool
+func (h *HealthHandler) pingDatabase(ctx context.Context) error {
+	sqlDB, err := h.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"order-service/internal/gateway/product"
+	"order-service/internal/gateway/warehouse"
+	"order-service/internal/model"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// newHealthServer starts a fake downstream service whose /api/v1/health answers with the given status
+func newHealthServer(t *testing.T, status int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/health", r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"success":true,"data":{"status":"ok"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func setupHealthHandlerTest(t *testing.T, warehouseStatus, productStatus int) (sqlmock.Sqlmock, *fiber.App) {
+	mockDb, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDb.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	warehouseClient := warehouse.NewClient(newHealthServer(t, warehouseStatus).URL, time.Second, logger)
+	productClient := product.NewClient(newHealthServer(t, productStatus).URL, time.Second, logger)
+
+	healthHandler := NewHealthHandler(db, map[string]DependencyCheck{
+		"warehouse_service": warehouseClient.Ping,
+		"product_service":   productClient.Ping,
+	}, logger)
+
+	app := fiber.New()
+	app.Get("/api/v1/health", healthHandler.Live)
+	app.Get("/api/v1/health/ready", healthHandler.Ready)
+
+	return mock, app
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	mock, app := setupHealthHandlerTest(t, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Liveness never pings the database, even when downstream services are failing
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name               string
+		pingErr            error
+		warehouseStatus    int
+		productStatus      int
+		expectedStatusCode int
+		expectedStatus     string
+		expectedDown       []string
+	}{
+		{
+			name:               "all dependencies up",
+			warehouseStatus:    http.StatusOK,
+			productStatus:      http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+			expectedStatus:     model.HealthStatusReady,
+		},
+		{
+			name:               "database down",
+			pingErr:            errors.New("connection refused"),
+			warehouseStatus:    http.StatusOK,
+			productStatus:      http.StatusOK,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     model.HealthStatusNotReady,
+			expectedDown:       []string{"database"},
+		},
+		{
+			name:               "warehouse service down",
+			warehouseStatus:    http.StatusServiceUnavailable,
+			productStatus:      http.StatusOK,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     model.HealthStatusNotReady,
+			expectedDown:       []string{"warehouse_service"},
+		},
+		{
+			name:               "product service down",
+			warehouseStatus:    http.StatusOK,
+			productStatus:      http.StatusInternalServerError,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     model.HealthStatusNotReady,
+			expectedDown:       []string{"product_service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, app := setupHealthHandlerTest(t, tt.warehouseStatus, tt.productStatus)
+			mock.ExpectPing().WillReturnError(tt.pingErr)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil), 5000)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+
+			var body struct {
+				Data model.HealthResponse `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expectedStatus, body.Data.Status)
+			require.Len(t, body.Data.Dependencies, 3)
+
+			for name, dependency := range body.Data.Dependencies {
+				if slices.Contains(tt.expectedDown, name) {
+					assert.Equal(t, model.HealthStatusDown, dependency.Status, name)
+					assert.NotEmpty(t, dependency.Error, name)
+				} else {
+					assert.Equal(t, model.HealthStatusUp, dependency.Status, name)
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
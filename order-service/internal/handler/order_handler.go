@@ -1,14 +1,22 @@
 package handler
 
 import (
+	"bufio"
+	"encoding/csv"
 	"errors"
+	"iter"
+	"order-service/internal/auth"
 	"order-service/internal/context"
 	"order-service/internal/delivery/http/response"
+	"order-service/internal/entity"
 	appErrors "order-service/internal/errors"
 	"order-service/internal/model"
 	"order-service/internal/usecase"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 )
@@ -27,7 +35,7 @@ func NewOrderHandler(orderUseCase usecase.OrderUseCaseInterface, logger *logrus.
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new order with items
+// @Description Create a new order with items. An optional coupon_code discounts the order; the discount is computed from the item subtotal, and unknown, inactive or expired codes are rejected.
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -35,7 +43,9 @@ func NewOrderHandler(orderUseCase usecase.OrderUseCaseInterface, logger *logrus.
 // @Success 201 {object} model.OrderResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Failure 503 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /orders [post]
 func (h *OrderHandler) CreateOrder(ctx *fiber.Ctx) error {
@@ -59,11 +69,8 @@ func (h *OrderHandler) CreateOrder(ctx *fiber.Ctx) error {
 		request.UserID = userId.(string)
 	}
 
-	// Add timeout to context
-	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
-	defer cancel()
-
-	orderResponse, err := h.OrderUseCase.CreateOrder(timeoutCtx, request)
+	// The use case bounds each step with its configured operation timeout
+	orderResponse, err := h.OrderUseCase.CreateOrder(userCtx, request)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -77,6 +84,48 @@ func (h *OrderHandler) CreateOrder(ctx *fiber.Ctx) error {
 			return response.JSONError(ctx, appErr, h.Log)
 		}
 
+		// Report each request field that failed validation
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return response.JSONError(ctx, appErrors.NewValidationError(validationErrs), h.Log)
+		}
+
+		// Map price validation errors to application errors
+		if errors.Is(err, entity.ErrMixedCurrency) {
+			return response.JSONError(ctx, appErrors.ErrMixedCurrency, h.Log)
+		}
+		if errors.Is(err, entity.ErrPriceMismatch) {
+			return response.JSONError(ctx, appErrors.ErrPriceMismatch, h.Log)
+		}
+		if errors.Is(err, entity.ErrProductNotFound) {
+			return response.JSONError(ctx, appErrors.ErrProductNotFound, h.Log)
+		}
+
+		// Name the items that lacked stock
+		var stockErr *entity.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			return response.JSONError(ctx, appErrors.NewInsufficientStockError(stockShortages(stockErr), err), h.Log)
+		}
+
+		// Map coupon errors to application errors
+		if errors.Is(err, entity.ErrInvalidCoupon) {
+			return response.JSONError(ctx, appErrors.ErrInvalidCoupon, h.Log)
+		}
+		if errors.Is(err, entity.ErrCouponExpired) {
+			return response.JSONError(ctx, appErrors.ErrCouponExpired, h.Log)
+		}
+
+		// Report the minimum order amount and how far the order fell short of it
+		var minimumErr *entity.BelowMinimumOrderError
+		if errors.As(err, &minimumErr) {
+			return response.JSONError(ctx, appErrors.NewBelowMinimumOrderError(minimumErr), h.Log)
+		}
+
+		// The order deadlocked with concurrent orders on every attempt
+		if errors.Is(err, entity.ErrOrderContention) {
+			return response.JSONError(ctx, appErrors.ErrOrderContention, h.Log)
+		}
+
 		// Convert Fiber errors to application errors
 		if err == fiber.ErrBadRequest {
 			return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
@@ -94,9 +143,11 @@ func (h *OrderHandler) CreateOrder(ctx *fiber.Ctx) error {
 // @Tags Orders
 // @Produce json
 // @Param id path int true "Order ID"
+// @Param include query string false "Comma-separated related data to include (reservations)"
 // @Success 200 {object} model.OrderResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
@@ -122,11 +173,21 @@ func (h *OrderHandler) GetOrder(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
 	}
 
-	// Add timeout to context
-	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
-	defer cancel()
+	// Parse optional related data to include
+	includeReservations := false
+	if include := ctx.Query("include"); include != "" {
+		for _, value := range strings.Split(include, ",") {
+			switch strings.TrimSpace(value) {
+			case "reservations":
+				includeReservations = true
+			default:
+				return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid include value"), h.Log)
+			}
+		}
+	}
 
-	orderResponse, err := h.OrderUseCase.GetOrderByID(timeoutCtx, uint(orderID))
+	// The use case bounds each step with its configured operation timeout
+	orderResponse, err := h.OrderUseCase.GetOrderByID(userCtx, uint(orderID), includeReservations)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -148,20 +209,152 @@ func (h *OrderHandler) GetOrder(ctx *fiber.Ctx) error {
 		}
 	}
 
+	// Only the order owner or an admin may view the order
+	if !canAccessOrder(ctx, orderResponse.UserID) {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"user_id":    ctx.Locals("userId"),
+		}).Warn("Forbidden order access")
+		return response.JSONError(ctx, appErrors.ErrForbidden, h.Log)
+	}
+
 	return response.JSONSuccess(ctx, orderResponse)
 }
 
+// stockShortages converts the short items of a failed reservation for the error response
+func stockShortages(stockErr *entity.InsufficientStockError) []appErrors.StockShortage {
+	shortages := make([]appErrors.StockShortage, len(stockErr.Items))
+	for i, item := range stockErr.Items {
+		shortages[i] = appErrors.StockShortage{
+			ProductID:   item.ProductID,
+			WarehouseID: item.WarehouseID,
+			Requested:   item.Requested,
+			Available:   item.Available,
+			Shortfall:   item.Shortfall(),
+		}
+	}
+	return shortages
+}
+
+// canAccessOrder reports whether the authenticated caller owns the order or has the admin role
+func canAccessOrder(ctx *fiber.Ctx, ownerID string) bool {
+	if role, _ := ctx.Locals("role").(string); role == auth.RoleAdmin {
+		return true
+	}
+	userID, _ := ctx.Locals("userId").(string)
+	return userID != "" && userID == ownerID
+}
+
+// authorizeOrder loads the order and checks that the caller owns it or has the admin role, before a handler
+// changes it. When the caller may not act on the order the error response is written and false is returned,
+// together with the result of writing it for the handler to return.
+func (h *OrderHandler) authorizeOrder(ctx *fiber.Ctx, orderID uint) (bool, error) {
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	order, err := h.OrderUseCase.GetOrderByID(userCtx, orderID, false)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"error":      err.Error(),
+		}).Warn("Failed to get order")
+
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return false, response.JSONError(ctx, appErr, h.Log)
+		}
+		if err == fiber.ErrNotFound {
+			return false, response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
+		}
+		return false, response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	if !canAccessOrder(ctx, order.UserID) {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"user_id":    ctx.Locals("userId"),
+		}).Warn("Forbidden order access")
+		return false, response.JSONError(ctx, appErrors.ErrForbidden, h.Log)
+	}
+
+	return true, nil
+}
+
+// GetOrderStatusHistory godoc
+// @Summary Get order status history
+// @Description Returns the status changes of an order, oldest first, with who made each change. Background jobs are recorded as the "system" actor.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} model.OrderStatusHistoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/{id}/history [get]
+func (h *OrderHandler) GetOrderStatusHistory(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	orderID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   ctx.Params("id"),
+			"error":      err.Error(),
+		}).Warn("Invalid order ID format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
+	}
+
+	// The use case bounds each step with its configured operation timeout
+	history, err := h.OrderUseCase.GetOrderStatusHistory(userCtx, uint(orderID))
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"error":      err.Error(),
+		}).Warn("Failed to get order status history")
+
+		if err == fiber.ErrNotFound {
+			return response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
+		}
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	// Only the order owner or an admin may view the order history
+	if !canAccessOrder(ctx, history.UserID) {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"user_id":    ctx.Locals("userId"),
+		}).Warn("Forbidden order access")
+		return response.JSONError(ctx, appErrors.ErrForbidden, h.Log)
+	}
+
+	return response.JSONSuccess(ctx, history)
+}
+
 // GetUserOrders godoc
 // @Summary Get orders for a user
-// @Description Returns paginated list of orders for the specified user ID
+// @Description Returns paginated list of the authenticated user's orders. Only admins and internal services may name another user in user_id.
 // @Tags Orders
 // @Produce json
 // @Param user_id query string false "User ID (defaults to authenticated user)"
 // @Param page query int false "Page number (defaults to 1)"
-// @Param limit query int false "Items per page (defaults to 10, max 100)"
-// @Success 200 {array} model.OrderResponse
+// @Param limit query int false "Items per page (defaults to 10; larger values are clamped to the maximum, 100 by default)"
+// @Param status query string false "Filter by order status (pending, payment_processing, paid, cancelled, completed)"
+// @Param from query string false "Only orders created at or after this RFC3339 timestamp"
+// @Param to query string false "Only orders created at or before this RFC3339 timestamp"
+// @Success 200 {object} model.OrderListResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /orders [get]
@@ -175,22 +368,50 @@ func (h *OrderHandler) GetUserOrders(ctx *fiber.Ctx) error {
 	
 	// Parse query parameters
 	userID := ctx.Query("user_id", authUserID) // Default to authenticated user
+	
+	// Callers may only list their own orders, unless they are an admin or an internal service
+	if role, _ := ctx.Locals("role").(string); userID != authUserID && role != auth.RoleAdmin && role != auth.RoleService {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"user_id":    authUserID,
+			"requested":  userID,
+		}).Warn("Forbidden order listing")
+		return response.JSONError(ctx, appErrors.ErrForbidden, h.Log)
+	}
+
 	page, _ := strconv.Atoi(ctx.Query("page", "1"))
-	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+	// The use case applies the configured default limit and clamps it to the maximum
+	limit, _ := strconv.Atoi(ctx.Query("limit", "0"))
 	
-	// Validate page and limit
+	// Validate page
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
-	}
 
-	// Add timeout to context
-	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
-	defer cancel()
+	// Parse optional filters
+	var err error
+	filter := model.OrderListFilter{
+		Status: ctx.Query("status"),
+	}
+	if filter.From, err = parseTimeQuery(ctx, "from"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"from":       ctx.Query("from"),
+			"error":      err.Error(),
+		}).Warn("Invalid from date format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid from date, expected RFC3339"), h.Log)
+	}
+	if filter.To, err = parseTimeQuery(ctx, "to"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"to":         ctx.Query("to"),
+			"error":      err.Error(),
+		}).Warn("Invalid to date format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid to date, expected RFC3339"), h.Log)
+	}
 
-	orders, total, err := h.OrderUseCase.GetOrdersByUserID(timeoutCtx, userID, page, limit)
+	// The use case bounds each step with its configured operation timeout
+	orders, err := h.OrderUseCase.GetOrdersByUserID(userCtx, userID, filter, page, limit)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -204,29 +425,287 @@ func (h *OrderHandler) GetUserOrders(ctx *fiber.Ctx) error {
 			return response.JSONError(ctx, appErr, h.Log)
 		}
 
+		// Convert Fiber errors to application errors
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order filter"), h.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	return response.JSONSuccess(ctx, orders)
+}
+
+// GetOrderSummary godoc
+// @Summary Get an order summary for the authenticated user
+// @Description Returns the number of the authenticated user's orders in each status and the summed totals of their paid and completed orders per currency. A user without orders gets zero counts and an empty total_spent.
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} model.OrderSummaryResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/summary [get]
+func (h *OrderHandler) GetOrderSummary(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// The summary always covers the authenticated user
+	userID := ctx.Locals("userId").(string)
+
+	summary, err := h.OrderUseCase.GetUserOrderSummary(userCtx, userID)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"user_id":    userID,
+			"error":      err.Error(),
+		}).Warn("Failed to get order summary")
 		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
 	}
 
-	// Create pagination metadata
-	meta := map[string]interface{}{
-		"total":     total,
-		"page":      page,
-		"limit":     limit,
-		"total_pages": (total + int64(limit) - 1) / int64(limit),
+	return response.JSONSuccess(ctx, summary)
+}
+
+// SearchOrders godoc
+// @Summary Search orders
+// @Description Returns a paginated list of the orders matching every given criterion, newest first. Requires the admin role. At least one of order_id, user_id, status, payment_method, from or to is required.
+// @Tags Orders
+// @Produce json
+// @Param order_id query int false "Order ID"
+// @Param user_id query string false "User ID of the order owner"
+// @Param status query string false "Order status (pending, payment_processing, paid, cancelled, completed)"
+// @Param payment_method query string false "Payment method"
+// @Param from query string false "Only orders created at or after this RFC3339 timestamp"
+// @Param to query string false "Only orders created at or before this RFC3339 timestamp"
+// @Param page query int false "Page number (defaults to 1)"
+// @Param limit query int false "Items per page (defaults to 10; larger values are clamped to the maximum, 100 by default)"
+// @Success 200 {object} model.OrderListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/search [get]
+func (h *OrderHandler) SearchOrders(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Query("limit", "0"))
+
+	criteria := model.OrderSearchCriteria{
+		UserID:        ctx.Query("user_id"),
+		Status:        ctx.Query("status"),
+		PaymentMethod: ctx.Query("payment_method"),
+		Page:          page,
+		Limit:         limit,
 	}
 
-	// Create response with data and metadata
-	result := map[string]interface{}{
-		"data": orders,
-		"meta": meta,
+	if orderIDStr := ctx.Query("order_id"); orderIDStr != "" {
+		orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
+		if err != nil || orderID == 0 {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"order_id":   orderIDStr,
+			}).Warn("Invalid order ID format")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
+		}
+		criteria.OrderID = uint(orderID)
 	}
 
-	return response.JSONSuccess(ctx, result)
+	var err error
+	if criteria.From, err = parseTimeQuery(ctx, "from"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"from":       ctx.Query("from"),
+			"error":      err.Error(),
+		}).Warn("Invalid from date format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid from date, expected RFC3339"), h.Log)
+	}
+	if criteria.To, err = parseTimeQuery(ctx, "to"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"to":         ctx.Query("to"),
+			"error":      err.Error(),
+		}).Warn("Invalid to date format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid to date, expected RFC3339"), h.Log)
+	}
+
+	// The use case bounds each step with its configured operation timeout
+	orders, err := h.OrderUseCase.SearchOrders(userCtx, criteria)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to search orders")
+
+		switch {
+		case errors.Is(err, entity.ErrSearchCriteriaRequired):
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "at least one search criterion is required"), h.Log)
+		case err == fiber.ErrBadRequest:
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order filter"), h.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, orders)
+}
+
+const (
+	// orderExportTimeout bounds how long streaming an order export may take
+	orderExportTimeout = 5 * time.Minute
+
+	// orderExportFlushRows is how many CSV rows are buffered before they are sent
+	orderExportFlushRows = 500
+)
+
+// orderExportHeader is the header row of an order export
+var orderExportHeader = []string{"order_id", "user_id", "status", "total_amount", "currency", "created_at", "item_count"}
+
+// ExportOrders godoc
+// @Summary Export orders as CSV
+// @Description Stream the orders created within a date range as CSV, oldest first. Requires the admin role. to defaults to now and from to the maximum range before to; a range longer than order.export_max_range (default 31 days) is rejected.
+// @Tags Orders
+// @Produce text/csv
+// @Param status query string false "Only orders with this status"
+// @Param from query string false "Only orders created at or after this time (RFC3339)"
+// @Param to query string false "Only orders created at or before this time (RFC3339)"
+// @Success 200 {string} string "CSV with columns order_id, user_id, status, total_amount, currency, created_at, item_count"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/export [get]
+func (h *OrderHandler) ExportOrders(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse optional filters
+	var err error
+	filter := model.OrderListFilter{
+		Status: ctx.Query("status"),
+	}
+	if filter.From, err = parseTimeQuery(ctx, "from"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"from":       ctx.Query("from"),
+			"error":      err.Error(),
+		}).Warn("Invalid from date format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid from date, expected RFC3339"), h.Log)
+	}
+	if filter.To, err = parseTimeQuery(ctx, "to"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"to":         ctx.Query("to"),
+			"error":      err.Error(),
+		}).Warn("Invalid to date format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid to date, expected RFC3339"), h.Log)
+	}
+
+	// The rows are read while the response is streamed, after this handler returns, so the timeout is released by the stream writer
+	exportCtx, cancel := context.WithTimeout(userCtx, orderExportTimeout)
+
+	rows, err := h.OrderUseCase.ExportOrders(exportCtx, filter)
+	if err != nil {
+		cancel()
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to export orders")
+
+		switch {
+		case errors.Is(err, entity.ErrExportRangeTooLarge):
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "export date range is too large, narrow from and to"), h.Log)
+		case err == fiber.ErrBadRequest:
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order filter"), h.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+		}
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="orders.csv"`)
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		// Headers are already sent, so a failure can only cut the CSV short
+		if err := writeOrdersCSV(w, rows); err != nil {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"error":      err.Error(),
+			}).Warn("Order export ended early")
+		}
+	})
+	return nil
+}
+
+// writeOrdersCSV writes the header and one line per order.
+// Rows are flushed every orderExportFlushRows so the client receives them as they are read.
+func writeOrdersCSV(w *bufio.Writer, rows iter.Seq2[*model.OrderExportRow, error]) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(orderExportHeader); err != nil {
+		return err
+	}
+
+	written := 0
+	for row, err := range rows {
+		if err != nil {
+			return err
+		}
+		record := []string{
+			strconv.FormatUint(uint64(row.OrderID), 10),
+			row.UserID,
+			row.Status,
+			row.TotalAmount.String(),
+			row.Currency,
+			row.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(row.ItemCount),
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+
+		written++
+		if written%orderExportFlushRows == 0 {
+			if err := flushCSV(csvWriter, w); err != nil {
+				return err
+			}
+		}
+	}
+	return flushCSV(csvWriter, w)
+}
+
+// flushCSV pushes buffered CSV rows through to the connection
+func flushCSV(csvWriter *csv.Writer, w *bufio.Writer) error {
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// parseTimeQuery parses an optional RFC3339 query parameter, returning nil when it is absent
+func parseTimeQuery(ctx *fiber.Ctx, name string) (*time.Time, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
 
 // UpdateOrderStatus godoc
 // @Summary Update order status
-// @Description Update the status of an order
+// @Description Update the status of an order. Requires the admin role. An order whose payment is in progress cannot be changed until the payment finishes.
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -235,7 +714,9 @@ func (h *OrderHandler) GetUserOrders(ctx *fiber.Ctx) error {
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /orders/{id}/status [patch]
@@ -270,11 +751,8 @@ func (h *OrderHandler) UpdateOrderStatus(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), h.Log)
 	}
 
-	// Add timeout to context
-	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
-	defer cancel()
-
-	err = h.OrderUseCase.UpdateOrderStatus(timeoutCtx, uint(orderID), request.Status)
+	// The use case bounds each step with its configured operation timeout
+	err = h.OrderUseCase.UpdateOrderStatus(userCtx, uint(orderID), request.Status)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -288,6 +766,9 @@ func (h *OrderHandler) UpdateOrderStatus(ctx *fiber.Ctx) error {
 		if errors.As(err, &appErr) {
 			return response.JSONError(ctx, appErr, h.Log)
 		}
+		if errors.Is(err, entity.ErrPaymentInProgress) {
+			return response.JSONError(ctx, appErrors.ErrPaymentInProgress, h.Log)
+		}
 
 		// Convert Fiber errors to application errors
 		if err == fiber.ErrNotFound {
@@ -304,17 +785,105 @@ func (h *OrderHandler) UpdateOrderStatus(ctx *fiber.Ctx) error {
 	})
 }
 
+// CancelOrderItems godoc
+// @Summary Cancel order items
+// @Description Cancel specific line items of a pending order and release their stock reservations. Only the order owner or an admin may cancel items, and not while the order's payment is in progress.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param items body model.CancelOrderItemsRequest true "Items to cancel, matched by product and warehouse"
+// @Success 200 {object} model.OrderResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/{id}/items/cancel [post]
+func (h *OrderHandler) CancelOrderItems(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse order ID from URL
+	orderIDStr := ctx.Params("id")
+	if orderIDStr == "" {
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "order id is required"), h.Log)
+	}
+
+	orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderIDStr,
+			"error":      err.Error(),
+		}).Warn("Invalid order ID format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
+	}
+
+	// Parse request body
+	request := new(model.CancelOrderItemsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), h.Log)
+	}
+
+	// Only the order owner or an admin may cancel its items
+	if ok, err := h.authorizeOrder(ctx, uint(orderID)); !ok {
+		return err
+	}
+
+	// The use case bounds each step with its configured operation timeout
+	orderResponse, err := h.OrderUseCase.CancelOrderItems(userCtx, uint(orderID), request.Items)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"error":      err.Error(),
+		}).Warn("Failed to cancel order items")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, h.Log)
+		}
+		if errors.Is(err, entity.ErrPaymentInProgress) {
+			return response.JSONError(ctx, appErrors.ErrPaymentInProgress, h.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		if err == fiber.ErrNotFound {
+			return response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
+		} else if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "items must belong to a pending order"), h.Log)
+		} else {
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, orderResponse)
+}
+
 // ProcessPayment godoc
 // @Summary Process payment for an order
-// @Description Process payment for a pending order
+// @Description Process payment for a pending order. Only the order owner or an admin may pay for the order. While the charge is in flight the order is in the payment_processing status, and other payments for it are refused.
 // @Tags Orders
 // @Produce json
 // @Param id path int true "Order ID"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 402 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
+// @Failure 503 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /orders/{id}/payment [post]
 func (h *OrderHandler) ProcessPayment(ctx *fiber.Ctx) error {
@@ -338,11 +907,13 @@ func (h *OrderHandler) ProcessPayment(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
 	}
 
-	// Add timeout to context
-	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
-	defer cancel()
+	// Only the order owner or an admin may pay for the order
+	if ok, err := h.authorizeOrder(ctx, uint(orderID)); !ok {
+		return err
+	}
 
-	err = h.OrderUseCase.ProcessPayment(timeoutCtx, uint(orderID))
+	// The use case bounds each step with its configured operation timeout
+	err = h.OrderUseCase.ProcessPayment(userCtx, uint(orderID))
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -356,11 +927,22 @@ func (h *OrderHandler) ProcessPayment(ctx *fiber.Ctx) error {
 			return response.JSONError(ctx, appErr, h.Log)
 		}
 
+		switch {
+		case errors.Is(err, entity.ErrOrderAlreadyPaid):
+			return response.JSONError(ctx, appErrors.ErrOrderAlreadyPaid, h.Log)
+		case errors.Is(err, entity.ErrOrderCancelled):
+			return response.JSONError(ctx, appErrors.ErrOrderCancelled, h.Log)
+		case errors.Is(err, entity.ErrPaymentDeclined):
+			return response.JSONError(ctx, appErrors.ErrPaymentDeclined, h.Log)
+		case errors.Is(err, entity.ErrPaymentUnavailable):
+			return response.JSONError(ctx, appErrors.ErrPaymentGatewayUnavailable, h.Log)
+		}
+
 		// Convert Fiber errors to application errors
 		if err == fiber.ErrNotFound {
 			return response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
 		} else if err == fiber.ErrBadRequest {
-			return response.JSONError(ctx, appErrors.ErrOrderAlreadyPaid, h.Log)
+			return response.JSONError(ctx, appErrors.ErrInvalidOrderStatus, h.Log)
 		} else {
 			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrPaymentFailed, err), h.Log)
 		}
@@ -369,4 +951,197 @@ func (h *OrderHandler) ProcessPayment(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, map[string]interface{}{
 		"message": "Payment processed successfully",
 	})
+}
+
+// ReactivateOrder godoc
+// @Summary Reactivate a cancelled order
+// @Description Undo the cancellation of an order whose original payment deadline has not passed. Requires the admin role. The stock of its items is reserved again and the order goes back to pending. Fails with INSUFFICIENT_STOCK when an item no longer has enough stock.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} model.OrderResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/{id}/reactivate [post]
+func (h *OrderHandler) ReactivateOrder(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	orderID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   ctx.Params("id"),
+			"error":      err.Error(),
+		}).Warn("Invalid order ID format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
+	}
+
+	orderResponse, err := h.OrderUseCase.ReactivateOrder(userCtx, uint(orderID))
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"error":      err.Error(),
+		}).Warn("Failed to reactivate order")
+
+		// Name the items that lacked stock
+		var stockErr *entity.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			return response.JSONError(ctx, appErrors.NewInsufficientStockError(stockShortages(stockErr), err), h.Log)
+		}
+
+		switch {
+		case errors.Is(err, entity.ErrInsufficientStock):
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInsufficientStock, err), h.Log)
+		case errors.Is(err, entity.ErrOrderNotCancelled):
+			return response.JSONError(ctx, appErrors.ErrOrderNotCancelled, h.Log)
+		case errors.Is(err, entity.ErrPaymentDeadlinePassed):
+			return response.JSONError(ctx, appErrors.ErrPaymentDeadlinePassed, h.Log)
+		case errors.Is(err, entity.ErrReservationReleasePending):
+			return response.JSONError(ctx, appErrors.ErrReservationReleasePending, h.Log)
+		case errors.Is(err, entity.ErrOrderOperationInProgress):
+			return response.JSONError(ctx, appErrors.ErrOrderOperationInProgress, h.Log)
+		case err == fiber.ErrNotFound:
+			return response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
+		case err == fiber.ErrBadRequest:
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "order has no items to reactivate"), h.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, orderResponse)
+}
+
+// CompleteOrder godoc
+// @Summary Complete a shipped order
+// @Description Confirm the shipment of a paid order and mark it completed, storing the carrier and tracking number. Requires the admin role. Only paid orders can be completed; other orders fail with ORDER_NOT_PAID, or ORDER_ALREADY_COMPLETED when completed before.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param shipment body model.CompleteOrderRequest true "Shipment details"
+// @Success 200 {object} model.OrderResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/{id}/complete [post]
+func (h *OrderHandler) CompleteOrder(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	orderID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   ctx.Params("id"),
+			"error":      err.Error(),
+		}).Warn("Invalid order ID format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
+	}
+
+	request := new(model.CompleteOrderRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), h.Log)
+	}
+
+	orderResponse, err := h.OrderUseCase.CompleteOrder(userCtx, uint(orderID), request)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"error":      err.Error(),
+		}).Warn("Failed to complete order")
+
+		// Report each request field that failed validation
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return response.JSONError(ctx, appErrors.NewValidationError(validationErrs), h.Log)
+		}
+
+		switch {
+		case errors.Is(err, entity.ErrOrderNotPaid):
+			return response.JSONError(ctx, appErrors.ErrOrderNotPaid, h.Log)
+		case errors.Is(err, entity.ErrOrderAlreadyCompleted):
+			return response.JSONError(ctx, appErrors.ErrOrderAlreadyCompleted, h.Log)
+		case err == fiber.ErrNotFound:
+			return response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, orderResponse)
+}
+
+// ReconcileOrder godoc
+// @Summary Reconcile the stock deduction of a paid order
+// @Description Retry the stock deduction of a paid order whose deduction failed after payment. Requires the admin role. An order whose stock is already deducted is reported as already_reconciled without calling the warehouse.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} model.ReconcileOrderResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 503 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /orders/{id}/reconcile [post]
+func (h *OrderHandler) ReconcileOrder(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	orderID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   ctx.Params("id"),
+			"error":      err.Error(),
+		}).Warn("Invalid order ID format")
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid order id"), h.Log)
+	}
+
+	result, err := h.OrderUseCase.ReconcilePaidOrder(userCtx, uint(orderID))
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"order_id":   orderID,
+			"error":      err.Error(),
+		}).Warn("Failed to reconcile order")
+
+		switch {
+		case errors.Is(err, entity.ErrOrderNotPaid):
+			return response.JSONError(ctx, appErrors.ErrOrderNotPaid, h.Log)
+		case errors.Is(err, entity.ErrStockDeductionFailed):
+			return response.JSONError(ctx, appErrors.ErrStockDeductionFailed, h.Log)
+		case errors.Is(err, entity.ErrOrderOperationInProgress):
+			return response.JSONError(ctx, appErrors.ErrOrderOperationInProgress, h.Log)
+		case err == fiber.ErrNotFound:
+			return response.JSONError(ctx, appErrors.ErrOrderNotFound, h.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, result)
 }
\ No newline at end of file
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
 	"net/http/httptest"
+	"order-service/internal/entity"
+	appErrors "order-service/internal/errors"
 	"order-service/internal/model"
 	usecase_mock "order-service/mocks/usecase"
 	"testing"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +48,7 @@ func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 				ProductID:   1,
 				WarehouseID: 1,
 				Quantity:    2,
-				UnitPrice:   10.0,
+				UnitPrice:   1000,
 			},
 		},
 	}
@@ -56,7 +61,7 @@ func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 		ID:              1,
 		UserID:          "test-user-id",
 		Status:          "pending",
-		TotalAmount:     20.0,
+		TotalAmount:     2000,
 		ShippingAddress: "123 Test St",
 		PaymentMethod:   "credit_card",
 		PaymentDeadline: time.Now().Add(24 * time.Hour).Format(time.RFC3339),
@@ -68,8 +73,8 @@ func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 				ProductID:   1,
 				WarehouseID: 1,
 				Quantity:    2,
-				UnitPrice:   10.0,
-				TotalPrice:  20.0,
+				UnitPrice:   1000,
+				TotalPrice:  2000,
 			},
 		},
 	}
@@ -129,7 +134,7 @@ func TestOrderHandler_CreateOrder_Error(t *testing.T) {
 				ProductID:   1,
 				WarehouseID: 1,
 				Quantity:    2,
-				UnitPrice:   10.0,
+				UnitPrice:   1000,
 			},
 		},
 	}
@@ -169,4 +174,968 @@ func TestOrderHandler_CreateOrder_BadRequest(t *testing.T) {
 	resp, err := app.Test(req)
 	assert.NoError(t, err)
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
-}
\ No newline at end of file
+}
+
+func TestOrderHandler_CreateOrder_ValidationErrors(t *testing.T) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(appErrors.JSONFieldName)
+	model.RegisterPaymentMethods(validate, model.DefaultPaymentMethods)
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedField   string
+		expectedMessage string
+	}{
+		{
+			"ZeroQuantity",
+			`{"shipping_address":"123 Test St","payment_method":"credit_card","items":[{"product_id":1,"warehouse_id":1,"quantity":0,"unit_price":10}]}`,
+			"items[0].quantity",
+			"items[0].quantity must be greater than 0",
+		},
+		{
+			"NegativePrice",
+			`{"shipping_address":"123 Test St","payment_method":"credit_card","items":[{"product_id":1,"warehouse_id":1,"quantity":1,"unit_price":-1}]}`,
+			"items[0].unit_price",
+			"items[0].unit_price must be at least 0",
+		},
+		{
+			"UnknownPaymentMethod",
+			`{"shipping_address":"123 Test St","payment_method":"cash","items":[{"product_id":1,"warehouse_id":1,"quantity":1,"unit_price":10}]}`,
+			"payment_method",
+			"payment_method must be one of: credit_card, bank_transfer, e_wallet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+			orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+			app := fiber.New()
+			app.Post("/orders", func(c *fiber.Ctx) error {
+				c.Locals("userId", "test-user-id")
+				return orderHandler.CreateOrder(c)
+			})
+
+			// The use case returns the validator errors of the request
+			mockOrderUseCase.EXPECT().
+				CreateOrder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, request *model.CreateOrderRequest) (*model.OrderResponse, error) {
+					return nil, validate.Struct(request)
+				})
+
+			req := httptest.NewRequest("POST", "/orders", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+			var body struct {
+				Error struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+					Fields  []struct {
+						Field   string `json:"field"`
+						Rule    string `json:"rule"`
+						Message string `json:"message"`
+					} `json:"fields"`
+				} `json:"error"`
+			}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "INVALID_INPUT", body.Error.Code)
+			assert.Contains(t, body.Error.Message, tt.expectedMessage)
+			if assert.Len(t, body.Error.Fields, 1) {
+				assert.Equal(t, tt.expectedField, body.Error.Fields[0].Field)
+				assert.Equal(t, tt.expectedMessage, body.Error.Fields[0].Message)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrder_CouponErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode string
+	}{
+		{"Invalid", entity.ErrInvalidCoupon, "INVALID_COUPON"},
+		{"Expired", entity.ErrCouponExpired, "COUPON_EXPIRED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+			orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+			app := fiber.New()
+			app.Post("/orders", orderHandler.CreateOrder)
+
+			mockOrderUseCase.EXPECT().
+				CreateOrder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, request *model.CreateOrderRequest) (*model.OrderResponse, error) {
+					assert.Equal(t, "PROMO", request.CouponCode)
+					return nil, tt.err
+				})
+
+			req := httptest.NewRequest("POST", "/orders", bytes.NewReader([]byte(`{"coupon_code":"PROMO"}`)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+			var body struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expectedCode, body.Error.Code)
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrder_Contention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+	app := fiber.New()
+	app.Post("/orders", orderHandler.CreateOrder)
+
+	mockOrderUseCase.EXPECT().
+		CreateOrder(gomock.Any(), gomock.Any()).
+		Return(nil, entity.ErrOrderContention)
+
+	req := httptest.NewRequest("POST", "/orders", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ORDER_CONTENTION", body.Error.Code)
+}
+
+func TestOrderHandler_CreateOrder_InsufficientStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+	app := fiber.New()
+	app.Post("/orders", orderHandler.CreateOrder)
+
+	mockOrderUseCase.EXPECT().
+		CreateOrder(gomock.Any(), gomock.Any()).
+		Return(nil, &entity.InsufficientStockError{Items: []entity.StockShortage{
+			{ProductID: 2, WarehouseID: 3, Requested: 5, Available: 3},
+		}})
+
+	req := httptest.NewRequest("POST", "/orders", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"success": false,
+		"error": {
+			"code": "INSUFFICIENT_STOCK",
+			"message": "Insufficient stock to fulfill order",
+			"items": [{"product_id": 2, "warehouse_id": 3, "requested": 5, "available": 3, "shortfall": 2}]
+		}
+	}`, string(body))
+}
+
+func TestOrderHandler_CreateOrder_BelowMinimumOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+	app := fiber.New()
+	app.Post("/orders", orderHandler.CreateOrder)
+
+	mockOrderUseCase.EXPECT().
+		CreateOrder(gomock.Any(), gomock.Any()).
+		Return(nil, &entity.BelowMinimumOrderError{Required: 5000, Actual: 4250})
+
+	req := httptest.NewRequest("POST", "/orders", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"success": false,
+		"error": {
+			"code": "BELOW_MINIMUM_ORDER",
+			"message": "Order amount is below the minimum order amount",
+			"minimum_order": {"required_amount": 50.00, "actual_amount": 42.50}
+		}
+	}`, string(body))
+}
+
+func TestOrderHandler_GetUserOrders_Filters(t *testing.T) {
+	// Initialize mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+
+	// Create handler with mock
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app
+	app := fiber.New()
+	app.Get("/orders", func(c *fiber.Ctx) error {
+		c.Locals("userId", "test-user-id")
+		return orderHandler.GetUserOrders(c)
+	})
+
+	t.Run("ValidFilters", func(t *testing.T) {
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+
+		mockOrderUseCase.EXPECT().
+			GetOrdersByUserID(gomock.Any(), "test-user-id", model.OrderListFilter{Status: "paid", From: &from, To: &to}, 1, 0).
+			Return(&model.OrderListResponse{
+				Orders: []model.OrderResponse{{ID: 1}},
+				Meta:   model.OrderListMeta{Total: 1, Page: 1, Limit: 10, TotalPages: 1},
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/orders?status=paid&from=2025-05-01T00:00:00Z&to=2025-05-31T00:00:00Z", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		// The list keeps the data/meta layout inside the response envelope
+		var body struct {
+			Data struct {
+				Data []model.OrderResponse `json:"data"`
+				Meta map[string]int64      `json:"meta"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data.Data, 1)
+		assert.Equal(t, map[string]int64{"total": 1, "page": 1, "limit": 10, "total_pages": 1}, body.Data.Meta)
+	})
+
+	t.Run("MalformedFromDate", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/orders?from=2025-05-01", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			GetOrdersByUserID(gomock.Any(), "test-user-id", model.OrderListFilter{Status: "shipped"}, 1, 0).
+			Return(nil, fiber.ErrBadRequest)
+
+		req := httptest.NewRequest("GET", "/orders?status=shipped", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("OwnUserID", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			GetOrdersByUserID(gomock.Any(), "test-user-id", model.OrderListFilter{}, 1, 0).
+			Return(&model.OrderListResponse{}, nil)
+
+		req := httptest.NewRequest("GET", "/orders?user_id=test-user-id", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("OtherUserID", func(t *testing.T) {
+		// Another user's orders are not listed to a customer
+		req := httptest.NewRequest("GET", "/orders?user_id=other-user-id", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("AdminOtherUserID", func(t *testing.T) {
+		adminApp := fiber.New()
+		adminApp.Get("/orders", func(c *fiber.Ctx) error {
+			c.Locals("userId", "admin-user-id")
+			c.Locals("role", "admin")
+			return orderHandler.GetUserOrders(c)
+		})
+
+		mockOrderUseCase.EXPECT().
+			GetOrdersByUserID(gomock.Any(), "other-user-id", model.OrderListFilter{}, 1, 0).
+			Return(&model.OrderListResponse{}, nil)
+
+		req := httptest.NewRequest("GET", "/orders?user_id=other-user-id", nil)
+		resp, err := adminApp.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ServiceOtherUserID", func(t *testing.T) {
+		serviceApp := fiber.New()
+		serviceApp.Get("/orders", func(c *fiber.Ctx) error {
+			c.Locals("userId", "service-account")
+			c.Locals("role", "service")
+			return orderHandler.GetUserOrders(c)
+		})
+
+		mockOrderUseCase.EXPECT().
+			GetOrdersByUserID(gomock.Any(), "other-user-id", model.OrderListFilter{}, 1, 0).
+			Return(&model.OrderListResponse{}, nil)
+
+		req := httptest.NewRequest("GET", "/orders?user_id=other-user-id", nil)
+		resp, err := serviceApp.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_ChangeOrder_Ownership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app that authenticates the caller from test headers
+	authenticate := func(handler fiber.Handler) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("userId", c.Get("X-Test-User"))
+			c.Locals("role", c.Get("X-Test-Role"))
+			return handler(c)
+		}
+	}
+	app := fiber.New()
+	app.Post("/orders/:id/payment", authenticate(orderHandler.ProcessPayment))
+	app.Post("/orders/:id/items/cancel", authenticate(orderHandler.CancelOrderItems))
+
+	mockOrderUseCase.EXPECT().
+		GetOrderByID(gomock.Any(), uint(1), false).
+		Return(&model.OrderResponse{ID: 1, UserID: "owner-id"}, nil).
+		AnyTimes()
+	mockOrderUseCase.EXPECT().
+		GetOrderByID(gomock.Any(), uint(999), false).
+		Return(nil, fiber.ErrNotFound).
+		AnyTimes()
+
+	send := func(path, userID, role string) int {
+		req := httptest.NewRequest("POST", path, bytes.NewReader([]byte(`{"items":[{"product_id":1,"warehouse_id":1}]}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID)
+		req.Header.Set("X-Test-Role", role)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	t.Run("OtherUserDenied", func(t *testing.T) {
+		// Neither use case method may be called for someone else's order
+		assert.Equal(t, fiber.StatusForbidden, send("/orders/1/payment", "other-user-id", ""))
+		assert.Equal(t, fiber.StatusForbidden, send("/orders/1/items/cancel", "other-user-id", ""))
+	})
+
+	t.Run("OwnerAllowed", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().ProcessPayment(gomock.Any(), uint(1)).Return(nil)
+		mockOrderUseCase.EXPECT().CancelOrderItems(gomock.Any(), uint(1), gomock.Len(1)).Return(&model.OrderResponse{ID: 1, UserID: "owner-id"}, nil)
+
+		assert.Equal(t, fiber.StatusOK, send("/orders/1/payment", "owner-id", ""))
+		assert.Equal(t, fiber.StatusOK, send("/orders/1/items/cancel", "owner-id", ""))
+	})
+
+	t.Run("AdminOverride", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().ProcessPayment(gomock.Any(), uint(1)).Return(nil)
+
+		assert.Equal(t, fiber.StatusOK, send("/orders/1/payment", "admin-user-id", "admin"))
+	})
+
+	t.Run("UnknownOrder", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusNotFound, send("/orders/999/payment", "owner-id", ""))
+	})
+}
+
+func TestOrderHandler_GetOrderSummary(t *testing.T) {
+	// Initialize mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create handler with mock
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app
+	app := fiber.New()
+	app.Get("/orders/summary", func(c *fiber.Ctx) error {
+		c.Locals("userId", "test-user-id")
+		return orderHandler.GetOrderSummary(c)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			GetUserOrderSummary(gomock.Any(), "test-user-id").
+			Return(&model.OrderSummaryResponse{
+				UserID:       "test-user-id",
+				TotalOrders:  3,
+				StatusCounts: map[string]int64{"pending": 1, "paid": 2, "cancelled": 0, "completed": 0},
+				TotalSpent:   []model.CurrencyAmount{{Currency: "USD", Amount: 4000}},
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/summary", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.JSONEq(t, `{"success": true, "data": {
+			"user_id": "test-user-id",
+			"total_orders": 3,
+			"status_counts": {"pending": 1, "paid": 2, "cancelled": 0, "completed": 0},
+			"total_spent": [{"currency": "USD", "amount": 40.00}]
+		}}`, string(body))
+	})
+
+	t.Run("UseCaseError", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			GetUserOrderSummary(gomock.Any(), "test-user-id").
+			Return(nil, fiber.ErrInternalServerError)
+
+		req := httptest.NewRequest("GET", "/orders/summary", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_SearchOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+
+	orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+	app := fiber.New()
+	app.Get("/orders/search", orderHandler.SearchOrders)
+
+	t.Run("ForwardsCriteria", func(t *testing.T) {
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+		mockOrderUseCase.EXPECT().
+			SearchOrders(gomock.Any(), model.OrderSearchCriteria{
+				OrderID:       7,
+				UserID:        "user-1",
+				Status:        "paid",
+				PaymentMethod: "credit_card",
+				From:          &from,
+				Page:          2,
+				Limit:         5,
+			}).
+			Return(&model.OrderListResponse{
+				Orders: []model.OrderResponse{{ID: 7}},
+				Meta:   model.OrderListMeta{Total: 6, Page: 2, Limit: 5, TotalPages: 2},
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/search?order_id=7&user_id=user-1&status=paid&payment_method=credit_card&from=2025-05-01T00:00:00Z&page=2&limit=5", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data struct {
+				Data []model.OrderResponse `json:"data"`
+				Meta map[string]int64      `json:"meta"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data.Data, 1)
+		assert.Equal(t, map[string]int64{"total": 6, "page": 2, "limit": 5, "total_pages": 2}, body.Data.Meta)
+	})
+
+	t.Run("NoCriteria", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			SearchOrders(gomock.Any(), model.OrderSearchCriteria{Page: 1}).
+			Return(nil, entity.ErrSearchCriteriaRequired)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/orders/search", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InvalidOrderID", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/orders/search?order_id=abc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("MalformedToDate", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/orders/search?user_id=user-1&to=yesterday", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_ExportOrders(t *testing.T) {
+	// Initialize mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+
+	// Create handler with mock
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app
+	app := fiber.New()
+	app.Get("/orders/export", orderHandler.ExportOrders)
+
+	t.Run("StreamsCSV", func(t *testing.T) {
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+		rows := []*model.OrderExportRow{
+			{OrderID: 1, UserID: "user-1", Status: "paid", TotalAmount: 15000, Currency: "USD", CreatedAt: from, ItemCount: 2},
+			{OrderID: 2, UserID: "user-2", Status: "paid", TotalAmount: 1950, Currency: "EUR", CreatedAt: from.Add(time.Hour), ItemCount: 1},
+		}
+
+		mockOrderUseCase.EXPECT().
+			ExportOrders(gomock.Any(), model.OrderListFilter{Status: "paid", From: &from, To: &to}).
+			Return(func(yield func(*model.OrderExportRow, error) bool) {
+				for _, row := range rows {
+					if !yield(row, nil) {
+						return
+					}
+				}
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/export?status=paid&from=2025-05-01T00:00:00Z&to=2025-05-31T00:00:00Z", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "order_id,user_id,status,total_amount,currency,created_at,item_count\n"+
+			"1,user-1,paid,150.00,USD,2025-05-01T00:00:00Z,2\n"+
+			"2,user-2,paid,19.50,EUR,2025-05-01T01:00:00Z,1\n", string(body))
+	})
+
+	t.Run("RangeTooLarge", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			ExportOrders(gomock.Any(), gomock.Any()).
+			Return(nil, entity.ErrExportRangeTooLarge)
+
+		req := httptest.NewRequest("GET", "/orders/export?from=2024-01-01T00:00:00Z", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("MalformedToDate", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/orders/export?to=yesterday", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_GetOrder_Include(t *testing.T) {
+	// Initialize mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+
+	// Create handler with mock
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app
+	app := fiber.New()
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		c.Locals("userId", "test-user-id")
+		return orderHandler.GetOrder(c)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			GetOrderByID(gomock.Any(), uint(1), false).
+			Return(&model.OrderResponse{ID: 1, UserID: "test-user-id"}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/1", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.NotContains(t, string(body), "reservation_summary")
+	})
+
+	t.Run("IncludeReservations", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			GetOrderByID(gomock.Any(), uint(1), true).
+			Return(&model.OrderResponse{ID: 1, UserID: "test-user-id", ReservationSummary: &model.ReservationSummary{Active: 1}}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/1?include=reservations", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), "reservation_summary")
+	})
+
+	t.Run("UnknownInclude", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/orders/1?include=payments", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_ProcessPayment_Declined(t *testing.T) {
+	// Initialize mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+
+	// Create handler with mock
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app that authenticates the order owner
+	app := fiber.New()
+	app.Post("/orders/:id/payment", func(c *fiber.Ctx) error {
+		c.Locals("userId", "owner-id")
+		return orderHandler.ProcessPayment(c)
+	})
+
+	mockOrderUseCase.EXPECT().
+		GetOrderByID(gomock.Any(), uint(1), false).
+		Return(&model.OrderResponse{ID: 1, UserID: "owner-id"}, nil)
+	mockOrderUseCase.EXPECT().
+		ProcessPayment(gomock.Any(), uint(1)).
+		Return(entity.ErrPaymentDeclined)
+
+	req := httptest.NewRequest("POST", "/orders/1/payment", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusPaymentRequired, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "PAYMENT_DECLINED")
+}
+
+func TestOrderHandler_ProcessPayment_StatusCodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"Success", nil, fiber.StatusOK, "Payment processed successfully"},
+		{"AlreadyPaid", entity.ErrOrderAlreadyPaid, fiber.StatusConflict, "ORDER_ALREADY_PAID"},
+		{"Cancelled", entity.ErrOrderCancelled, fiber.StatusBadRequest, "ORDER_CANCELLED"},
+		{"Declined", entity.ErrPaymentDeclined, fiber.StatusPaymentRequired, "PAYMENT_DECLINED"},
+		{"GatewayUnavailable", entity.ErrPaymentUnavailable, fiber.StatusServiceUnavailable, "PAYMENT_GATEWAY_UNAVAILABLE"},
+		{"NotFound", fiber.ErrNotFound, fiber.StatusNotFound, "ORDER_NOT_FOUND"},
+		{"UnexpectedError", fiber.ErrInternalServerError, fiber.StatusInternalServerError, "PAYMENT_FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+			app := fiber.New()
+			app.Post("/orders/:id/payment", func(c *fiber.Ctx) error {
+				c.Locals("userId", "owner-id")
+				return orderHandler.ProcessPayment(c)
+			})
+
+			mockOrderUseCase.EXPECT().
+				GetOrderByID(gomock.Any(), uint(1), false).
+				Return(&model.OrderResponse{ID: 1, UserID: "owner-id"}, nil)
+			mockOrderUseCase.EXPECT().
+				ProcessPayment(gomock.Any(), uint(1)).
+				Return(tt.useCaseErr)
+
+			req := httptest.NewRequest("POST", "/orders/1/payment", nil)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.expectedBody)
+		})
+	}
+}
+
+func TestOrderHandler_ReconcileOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       *model.ReconcileOrderResponse
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"Reconciled", &model.ReconcileOrderResponse{OrderID: 1, Result: model.ReconcileResultReconciled, Attempts: 2}, nil, fiber.StatusOK, `"result":"reconciled"`},
+		{"AlreadyReconciled", &model.ReconcileOrderResponse{OrderID: 1, Result: model.ReconcileResultAlreadyReconciled, Attempts: 1}, nil, fiber.StatusOK, `"result":"already_reconciled"`},
+		{"NotPaid", nil, entity.ErrOrderNotPaid, fiber.StatusConflict, "ORDER_NOT_PAID"},
+		{"DeductionFailed", nil, entity.ErrStockDeductionFailed, fiber.StatusServiceUnavailable, "STOCK_DEDUCTION_FAILED"},
+		{"InProgress", nil, entity.ErrOrderOperationInProgress, fiber.StatusConflict, "ORDER_OPERATION_IN_PROGRESS"},
+		{"NotFound", nil, fiber.ErrNotFound, fiber.StatusNotFound, "ORDER_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+			app := fiber.New()
+			app.Post("/orders/:id/reconcile", orderHandler.ReconcileOrder)
+
+			mockOrderUseCase.EXPECT().
+				ReconcilePaidOrder(gomock.Any(), uint(1)).
+				Return(tt.result, tt.useCaseErr)
+
+			req := httptest.NewRequest("POST", "/orders/1/reconcile", nil)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.expectedBody)
+		})
+	}
+}
+
+func TestOrderHandler_ReactivateOrder(t *testing.T) {
+	stockErr := &entity.InsufficientStockError{
+		Items: []entity.StockShortage{{ProductID: 1, WarehouseID: 2, Requested: 2, Available: 1}},
+	}
+
+	tests := []struct {
+		name         string
+		result       *model.OrderResponse
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"Reactivated", &model.OrderResponse{ID: 1, Status: "pending"}, nil, fiber.StatusOK, `"status":"pending"`},
+		{"StockUnavailable", nil, stockErr, fiber.StatusBadRequest, `"shortfall":1`},
+		{"DeadlinePassed", nil, entity.ErrPaymentDeadlinePassed, fiber.StatusConflict, "PAYMENT_DEADLINE_PASSED"},
+		{"NotCancelled", nil, entity.ErrOrderNotCancelled, fiber.StatusConflict, "ORDER_NOT_CANCELLED"},
+		{"ReleasePending", nil, entity.ErrReservationReleasePending, fiber.StatusConflict, "RESERVATION_RELEASE_PENDING"},
+		{"InProgress", nil, entity.ErrOrderOperationInProgress, fiber.StatusConflict, "ORDER_OPERATION_IN_PROGRESS"},
+		{"NotFound", nil, fiber.ErrNotFound, fiber.StatusNotFound, "ORDER_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+			app := fiber.New()
+			app.Post("/orders/:id/reactivate", orderHandler.ReactivateOrder)
+
+			mockOrderUseCase.EXPECT().
+				ReactivateOrder(gomock.Any(), uint(1)).
+				Return(tt.result, tt.useCaseErr)
+
+			req := httptest.NewRequest("POST", "/orders/1/reactivate", nil)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.expectedBody)
+		})
+	}
+}
+
+func TestOrderHandler_CompleteOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       *model.OrderResponse
+		useCaseErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"Completed", &model.OrderResponse{ID: 1, Status: "completed", Carrier: "JNE", TrackingNumber: "JNE123456789"}, nil, fiber.StatusOK, `"tracking_number":"JNE123456789"`},
+		{"NotPaid", nil, entity.ErrOrderNotPaid, fiber.StatusConflict, "ORDER_NOT_PAID"},
+		{"AlreadyCompleted", nil, entity.ErrOrderAlreadyCompleted, fiber.StatusConflict, "ORDER_ALREADY_COMPLETED"},
+		{"NotFound", nil, fiber.ErrNotFound, fiber.StatusNotFound, "ORDER_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+			app := fiber.New()
+			app.Post("/orders/:id/complete", orderHandler.CompleteOrder)
+
+			mockOrderUseCase.EXPECT().
+				CompleteOrder(gomock.Any(), uint(1), &model.CompleteOrderRequest{Carrier: "JNE", TrackingNumber: "JNE123456789"}).
+				Return(tt.result, tt.useCaseErr)
+
+			req := httptest.NewRequest("POST", "/orders/1/complete", bytes.NewReader([]byte(`{"carrier":"JNE","tracking_number":"JNE123456789"}`)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.expectedBody)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_Ownership(t *testing.T) {
+	// Initialize mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+	logger := logrus.New()
+
+	// Create handler with mock
+	orderHandler := NewOrderHandler(mockOrderUseCase, logger)
+
+	// Create test app that authenticates the caller from test headers
+	app := fiber.New()
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		c.Locals("userId", c.Get("X-Test-User"))
+		c.Locals("role", c.Get("X-Test-Role"))
+		return orderHandler.GetOrder(c)
+	})
+
+	mockOrderUseCase.EXPECT().
+		GetOrderByID(gomock.Any(), uint(1), false).
+		Return(&model.OrderResponse{ID: 1, UserID: "owner-id"}, nil).
+		Times(3)
+
+	getOrder := func(userID, role string) int {
+		req := httptest.NewRequest("GET", "/orders/1", nil)
+		req.Header.Set("X-Test-User", userID)
+		req.Header.Set("X-Test-Role", role)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	t.Run("OwnerAllowed", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, getOrder("owner-id", ""))
+	})
+
+	t.Run("OtherUserDenied", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusForbidden, getOrder("other-user-id", ""))
+	})
+
+	t.Run("AdminOverride", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, getOrder("admin-user-id", "admin"))
+	})
+}
+
+func TestOrderHandler_GetOrderStatusHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+
+	orderHandler := NewOrderHandler(mockOrderUseCase, logrus.New())
+
+	// Create test app that authenticates the caller from test headers
+	app := fiber.New()
+	app.Get("/orders/:id/history", func(c *fiber.Ctx) error {
+		c.Locals("userId", c.Get("X-Test-User"))
+		c.Locals("role", c.Get("X-Test-Role"))
+		return orderHandler.GetOrderStatusHistory(c)
+	})
+
+	mockOrderUseCase.EXPECT().
+		GetOrderStatusHistory(gomock.Any(), uint(1)).
+		Return(&model.OrderStatusHistoryResponse{
+			OrderID: 1,
+			UserID:  "owner-id",
+			Status:  "paid",
+			History: []model.OrderStatusChangeResponse{
+				{FromStatus: "pending", ToStatus: "paid", Actor: "owner-id", ChangedAt: "2025-05-20T10:00:00Z"},
+			},
+		}, nil).
+		AnyTimes()
+	mockOrderUseCase.EXPECT().
+		GetOrderStatusHistory(gomock.Any(), uint(999)).
+		Return(nil, fiber.ErrNotFound).
+		AnyTimes()
+
+	getHistory := func(orderID, userID, role string) *http.Response {
+		req := httptest.NewRequest("GET", "/orders/"+orderID+"/history", nil)
+		req.Header.Set("X-Test-User", userID)
+		req.Header.Set("X-Test-Role", role)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("OwnerAllowed", func(t *testing.T) {
+		resp := getHistory("1", "owner-id", "")
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data model.OrderStatusHistoryResponse `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data.History, 1)
+		assert.Equal(t, "paid", body.Data.History[0].ToStatus)
+	})
+
+	t.Run("OtherUserDenied", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusForbidden, getHistory("1", "other-user-id", "").StatusCode)
+	})
+
+	t.Run("AdminOverride", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, getHistory("1", "admin-user-id", "admin").StatusCode)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusNotFound, getHistory("999", "owner-id", "").StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, getHistory("abc", "owner-id", "").StatusCode)
+	})
+}
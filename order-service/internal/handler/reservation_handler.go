@@ -87,13 +87,14 @@ func (h *ReservationHandler) CreateReservation(ctx *fiber.Ctx) error {
 
 // GetOrderReservations godoc
 // @Summary Get reservations for an order
-// @Description Returns all stock reservations for the specified order ID
+// @Description Returns all stock reservations for the specified order ID. Requires the admin role; order owners see their reservations with GET /orders/{id}?include=reservations.
 // @Tags Reservations
 // @Produce json
 // @Param order_id path int true "Order ID"
 // @Success 200 {array} model.ReservationResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
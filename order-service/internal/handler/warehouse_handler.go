@@ -6,10 +6,12 @@ import (
 	"order-service/internal/errors"
 	"order-service/internal/gateway/warehouse"
 	"order-service/internal/model"
+	"order-service/internal/usecase"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,13 +19,15 @@ import (
 type WarehouseHandler struct {
 	log              *logrus.Logger
 	warehouseGateway warehouse.WarehouseGatewayInterface
+	inventoryUseCase usecase.InventoryUseCaseInterface
 }
 
 // NewWarehouseHandler creates a new handler for warehouse operations
-func NewWarehouseHandler(log *logrus.Logger, warehouseGateway warehouse.WarehouseGatewayInterface) *WarehouseHandler {
+func NewWarehouseHandler(log *logrus.Logger, warehouseGateway warehouse.WarehouseGatewayInterface, inventoryUseCase usecase.InventoryUseCaseInterface) *WarehouseHandler {
 	return &WarehouseHandler{
 		log:              log,
 		warehouseGateway: warehouseGateway,
+		inventoryUseCase: inventoryUseCase,
 	}
 }
 
@@ -132,9 +136,41 @@ func (h *WarehouseHandler) GetInventoryBatch(c *fiber.Ctx) error {
 	return response.JSONSuccess(c, result)
 }
 
+// CheckAvailability godoc
+// @Summary Check stock availability
+// @Description Reports the stock available for each item and whether the whole request can be fulfilled. Nothing is reserved or written; use this before placing an order.
+// @Tags Inventory
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body model.AvailabilityRequest true "Items to check"
+// @Success 200 {object} model.AvailabilityResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /inventory/availability [post]
+func (h *WarehouseHandler) CheckAvailability(c *fiber.Ctx) error {
+	var request model.AvailabilityRequest
+	if err := c.BodyParser(&request); err != nil {
+		h.log.Warnf("Invalid request body: %v", err)
+		return response.JSONError(c, errors.WithError(errors.ErrInvalidInput, err), h.log)
+	}
+
+	availability, err := h.inventoryUseCase.CheckAvailability(c.UserContext(), request.Items)
+	if err != nil {
+		h.log.Warnf("Failed to check availability: %v", err)
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(c, errors.WithMessage(errors.ErrInvalidInput, "items must name a product, a warehouse and a quantity greater than 0"), h.log)
+		}
+		return response.JSONError(c, errors.ErrInternalServer, h.log)
+	}
+
+	return response.JSONSuccess(c, availability)
+}
+
 // ReserveStock godoc
 // @Summary Reserve stock for an order
-// @Description Creates stock reservations for items in an order
+// @Description Creates stock reservations for items in an order under one reference, returned as reservation_id. A reference is generated when none is given.
 // @Tags Inventory
 // @Security ApiKeyAuth
 // @Accept json
@@ -158,8 +194,14 @@ func (h *WarehouseHandler) ReserveStock(c *fiber.Ctx) error {
 		reserveUntil = request.ReserveUntil
 	}
 
+	// Every item is reserved under one reference, which the response returns as reservation_id
+	reference := request.Reference
+	if reference == "" {
+		reference = "ord-" + uuid.NewString()
+	}
+
 	ctx := context.Background()
-	reservationResp, err := h.warehouseGateway.CheckAndReserveStock(ctx, request.OrderID, request.Items, reserveUntil)
+	reservationResp, err := h.warehouseGateway.CheckAndReserveStock(ctx, request.OrderID, reference, request.Items, reserveUntil)
 	if err != nil {
 		h.log.Warnf("Failed to reserve stock: %v", err)
 		return response.JSONError(c, errors.ErrInternalServer, h.log)
@@ -210,7 +252,16 @@ func (h *WarehouseHandler) ConfirmStockDeduction(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
-	opResponse, err := h.warehouseGateway.ConfirmStockDeduction(ctx, request.OrderID, request.ReservationID)
+
+	// Create the commit request with the data from the API request
+	commitRequest := warehouse.ReservationCommitRequest{
+		WarehouseID: request.WarehouseID,
+		ProductID:   request.ProductID,
+		Quantity:    request.Quantity,
+		Reference:   request.ReservationID,
+	}
+
+	opResponse, err := h.warehouseGateway.ConfirmStockDeduction(ctx, request.OrderID, commitRequest)
 	if err != nil {
 		h.log.Warnf("Failed to confirm stock deduction: %v", err)
 		return response.JSONError(c, errors.ErrInternalServer, h.log)
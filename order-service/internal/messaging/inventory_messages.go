@@ -1,6 +1,7 @@
 package messaging
 
 import (
+	"order-service/internal/entity"
 	"order-service/internal/model"
 	"time"
 )
@@ -32,10 +33,10 @@ type ReserveStockMessage struct {
 
 // ReserveStockItem represents an item to reserve stock for
 type ReserveStockItem struct {
-	ProductID   uint    `json:"product_id"`
-	WarehouseID uint    `json:"warehouse_id"`
-	Quantity    int     `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price,omitempty"`
+	ProductID   uint         `json:"product_id"`
+	WarehouseID uint         `json:"warehouse_id"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   entity.Money `json:"unit_price,omitempty"`
 }
 
 // NewReserveStockMessage creates a new ReserveStockMessage
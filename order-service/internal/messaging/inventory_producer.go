@@ -24,8 +24,8 @@ func NewInventoryProducer(mqClient *RabbitMQClient, log *logrus.Logger) *Invento
 	}
 }
 
-// PublishReserveStock publishes a stock reservation request
-func (p *InventoryProducer) PublishReserveStock(ctx context.Context, orderID uint, items []model.OrderItemRequest) (string, error) {
+// PublishReserveStock publishes a stock reservation request under the given reservation ID
+func (p *InventoryProducer) PublishReserveStock(ctx context.Context, orderID uint, reservationID string, items []model.OrderItemRequest) (string, error) {
 	// Set reservation expiry to 24 hours from now
 	reserveUntil := time.Now().Add(24 * time.Hour)
 	
@@ -37,6 +37,7 @@ func (p *InventoryProducer) PublishReserveStock(ctx context.Context, orderID uin
 	
 	// Create message
 	message := NewReserveStockMessage(orderID, items, reserveUntil)
+	message.ReservationID = reservationID
 	message.CorrelationID = correlationID
 	
 	// Publish message
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric exposed by the order service
+const namespace = "order_service"
+
+// Result label values for the domain event counters
+const (
+	ResultSuccess           = "success"
+	ResultFailure           = "failure"
+	ResultDeclined          = "declined"
+	ResultInsufficientStock = "insufficient_stock"
+)
+
+// Metrics holds the Prometheus collectors of the order service.
+// A nil *Metrics is valid and records nothing, so metrics can be switched off without nil checks at every call site.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	HTTPRequests                *prometheus.CounterVec
+	HTTPRequestDuration         *prometheus.HistogramVec
+	StockReservations           *prometheus.CounterVec
+	Payments                    *prometheus.CounterVec
+	ExpirySweeps                *prometheus.CounterVec
+	ExpiredOrdersCancelled      prometheus.Counter
+	ExpiredReservationsReleased prometheus.Counter
+}
+
+// New creates the collectors and registers them, together with the Go runtime and process collectors, on a new registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests handled, by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests, by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		StockReservations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stock_reservations_total",
+			Help:      "Number of stock reservation attempts made while creating orders, by result.",
+		}, []string{"result"}),
+		Payments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "payments_total",
+			Help:      "Number of payment attempts, by result.",
+		}, []string{"result"}),
+		ExpirySweeps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "expiry_sweeps_total",
+			Help:      "Number of expired-order sweeps run by the scheduler, by result.",
+		}, []string{"result"}),
+		ExpiredOrdersCancelled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "expired_orders_cancelled_total",
+			Help:      "Number of orders cancelled by expired-order sweeps.",
+		}),
+		ExpiredReservationsReleased: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "expired_reservations_released_total",
+			Help:      "Number of reservations released by expired-order sweeps.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.StockReservations,
+		m.Payments,
+		m.ExpirySweeps,
+		m.ExpiredOrdersCancelled,
+		m.ExpiredReservationsReleased,
+	)
+
+	return m
+}
+
+// Handler serves the registered metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records one handled HTTP request
+func (m *Metrics) ObserveHTTPRequest(method, route, status string, seconds float64) {
+	if m == nil {
+		return
+	}
+	m.HTTPRequests.WithLabelValues(method, route, status).Inc()
+	m.HTTPRequestDuration.WithLabelValues(method, route, status).Observe(seconds)
+}
+
+// RecordStockReservation records the result of reserving stock for an order
+func (m *Metrics) RecordStockReservation(result string) {
+	if m == nil {
+		return
+	}
+	m.StockReservations.WithLabelValues(result).Inc()
+}
+
+// RecordPayment records the result of charging an order
+func (m *Metrics) RecordPayment(result string) {
+	if m == nil {
+		return
+	}
+	m.Payments.WithLabelValues(result).Inc()
+}
+
+// RecordExpirySweep records a completed expired-order sweep and what it cleaned up
+func (m *Metrics) RecordExpirySweep(cancelledOrders, releasedReservations int) {
+	if m == nil {
+		return
+	}
+	m.ExpirySweeps.WithLabelValues(ResultSuccess).Inc()
+	m.ExpiredOrdersCancelled.Add(float64(cancelledOrders))
+	m.ExpiredReservationsReleased.Add(float64(releasedReservations))
+}
+
+// RecordFailedExpirySweep records an expired-order sweep that failed
+func (m *Metrics) RecordFailedExpirySweep() {
+	if m == nil {
+		return
+	}
+	m.ExpirySweeps.WithLabelValues(ResultFailure).Inc()
+}
@@ -0,0 +1,35 @@
+package model
+
+import "order-service/internal/entity"
+
+// ValidateCartRequest is used to validate a shopping cart before checkout
+type ValidateCartRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// Cart item problems reported by cart validation
+const (
+	CartIssueProductNotFound   = "product_not_found"
+	CartIssueProductInactive   = "product_inactive"
+	CartIssuePriceChanged      = "price_changed"
+	CartIssueInsufficientStock = "insufficient_stock"
+)
+
+// CartItemValidation describes whether a single cart item can be ordered
+type CartItemValidation struct {
+	ProductID         uint         `json:"product_id"`
+	WarehouseID       uint         `json:"warehouse_id"`
+	Quantity          int          `json:"quantity"`
+	UnitPrice         entity.Money `json:"unit_price" swaggertype:"number"`
+	CurrentPrice      entity.Money `json:"current_price" swaggertype:"number"`
+	AvailableQuantity int          `json:"available_quantity"`
+	Valid             bool         `json:"valid"`
+	Issues            []string     `json:"issues,omitempty"`
+}
+
+// CartValidationResponse is the result of validating a shopping cart
+type CartValidationResponse struct {
+	Valid       bool                 `json:"valid"`
+	Items       []CartItemValidation `json:"items"`
+	TotalAmount entity.Money         `json:"total_amount" swaggertype:"number"`
+}
@@ -11,24 +11,47 @@ func OrderToResponse(order *entity.Order) *model.OrderResponse {
 		ID:              order.ID,
 		UserID:          order.UserID,
 		Status:          string(order.Status),
+		Subtotal:        order.Subtotal,
 		TotalAmount:     order.TotalAmount,
+		Currency:        order.Currency,
+		CouponCode:      order.CouponCode,
+		DiscountAmount:  order.DiscountAmount,
+		TaxRate:         order.TaxRate,
+		TaxAmount:       order.TaxAmount,
+		ShippingCost:    order.ShippingCost,
 		ShippingAddress: order.ShippingAddress,
 		PaymentMethod:   order.PaymentMethod,
 		PaymentDeadline: order.PaymentDeadline.Format("2006-01-02T15:04:05Z07:00"),
+		PaymentReference: order.PaymentReference,
+		Carrier:         order.Carrier,
+		TrackingNumber:  order.TrackingNumber,
 		CreatedAt:       order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       order.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
+	if order.CompletedAt != nil {
+		response.CompletedAt = order.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
 	if len(order.OrderItems) > 0 {
+		reservedWarehouses := reservedWarehousesByProduct(order.Reservations)
+
 		response.Items = make([]model.OrderItemResponse, len(order.OrderItems))
 		for i, item := range order.OrderItems {
 			response.Items[i] = model.OrderItemResponse{
-				ID:          item.ID,
-				ProductID:   item.ProductID,
-				WarehouseID: item.WarehouseID,
-				Quantity:    item.Quantity,
-				UnitPrice:   item.UnitPrice,
-				TotalPrice:  item.TotalPrice,
+				ID:                     item.ID,
+				ProductID:              item.ProductID,
+				WarehouseID:            item.WarehouseID,
+				ReservedWarehouseID:    item.WarehouseID,
+				FulfillmentWarehouseID: item.WarehouseID,
+				Quantity:               item.Quantity,
+				UnitPrice:              item.UnitPrice,
+				TotalPrice:             item.TotalPrice,
+			}
+
+			// Prefer the warehouse recorded on the reservation when one exists
+			if warehouseID, ok := reservedWarehouses[item.ProductID]; ok {
+				response.Items[i].ReservedWarehouseID = warehouseID
 			}
 		}
 	}
@@ -36,6 +59,23 @@ func OrderToResponse(order *entity.Order) *model.OrderResponse {
 	return response
 }
 
+// reservedWarehousesByProduct maps product IDs to the warehouse holding their reservation.
+// Active reservations take precedence over inactive ones for the same product.
+func reservedWarehousesByProduct(reservations []entity.Reservation) map[uint]uint {
+	warehouses := make(map[uint]uint, len(reservations))
+	active := make(map[uint]bool, len(reservations))
+
+	for _, reservation := range reservations {
+		if _, seen := warehouses[reservation.ProductID]; seen && (active[reservation.ProductID] || !reservation.IsActive) {
+			continue
+		}
+		warehouses[reservation.ProductID] = reservation.WarehouseID
+		active[reservation.ProductID] = reservation.IsActive
+	}
+
+	return warehouses
+}
+
 // OrdersToResponse converts a slice of order entities to response models
 func OrdersToResponse(orders []entity.Order) []model.OrderResponse {
 	responses := make([]model.OrderResponse, len(orders))
@@ -46,6 +86,76 @@ func OrdersToResponse(orders []entity.Order) []model.OrderResponse {
 	return responses
 }
 
+// OrdersToListResponse converts a page of order entities to a list response with pagination metadata
+func OrdersToListResponse(orders []entity.Order, total int64, page, limit int) *model.OrderListResponse {
+	var totalPages int64
+	if limit > 0 {
+		totalPages = (total + int64(limit) - 1) / int64(limit)
+	}
+
+	return &model.OrderListResponse{
+		Orders: OrdersToResponse(orders),
+		Meta: model.OrderListMeta{
+			Total:      total,
+			Page:       page,
+			Limit:      limit,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+// OrderStatusHistoryToResponse converts an order and its status changes to a timeline response
+func OrderStatusHistoryToResponse(order *entity.Order, history []entity.OrderStatusHistory) *model.OrderStatusHistoryResponse {
+	response := &model.OrderStatusHistoryResponse{
+		OrderID: order.ID,
+		UserID:  order.UserID,
+		Status:  string(order.Status),
+		History: make([]model.OrderStatusChangeResponse, len(history)),
+	}
+
+	for i, change := range history {
+		response.History[i] = model.OrderStatusChangeResponse{
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			Actor:      change.Actor,
+			ChangedAt:  change.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	return response
+}
+
+// ReconcileOrderToResponse converts an order whose stock has been deducted to a reconciliation response
+func ReconcileOrderToResponse(order *entity.Order, result string) *model.ReconcileOrderResponse {
+	response := &model.ReconcileOrderResponse{
+		OrderID:  order.ID,
+		Result:   result,
+		Attempts: order.StockDeductionAttempts,
+	}
+	if order.StockDeductedAt != nil {
+		response.StockDeductedAt = order.StockDeductedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return response
+}
+
+// ReservationSummaryToResponse counts reservations by state for an order in the given status.
+// Reservations of paid or completed orders have been deducted from stock, so they count as
+// committed; otherwise a reservation is active until it is deactivated, then released.
+func ReservationSummaryToResponse(status entity.OrderStatus, reservations []entity.Reservation) *model.ReservationSummary {
+	summary := &model.ReservationSummary{}
+	for _, reservation := range reservations {
+		switch {
+		case status == entity.OrderStatusPaid || status == entity.OrderStatusCompleted:
+			summary.Committed++
+		case reservation.IsActive:
+			summary.Active++
+		default:
+			summary.Released++
+		}
+	}
+	return summary
+}
+
 // ReservationToResponse converts a reservation entity to response model
 func ReservationToResponse(reservation *entity.Reservation) *model.ReservationResponse {
 	return &model.ReservationResponse{
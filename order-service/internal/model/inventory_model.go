@@ -25,9 +25,31 @@ type InventoryBatchResponse struct {
 	Items map[string]InventoryResponse `json:"items"`
 }
 
+// AvailabilityRequest asks whether items could be ordered, without reserving them
+type AvailabilityRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// AvailabilityItem reports the stock available for one requested item
+type AvailabilityItem struct {
+	ProductID         uint   `json:"product_id"`
+	WarehouseID       uint   `json:"warehouse_id"`
+	Quantity          int    `json:"quantity"`
+	AvailableQuantity int    `json:"available_quantity"`
+	Available         bool   `json:"available"`
+	Message           string `json:"message,omitempty"`
+}
+
+// AvailabilityResponse tells whether every requested item can be fulfilled
+type AvailabilityResponse struct {
+	CanFulfil bool               `json:"can_fulfil"`
+	Items     []AvailabilityItem `json:"items"`
+}
+
 // StockReservationRequest represents a request to reserve stock for an order
 type StockReservationRequest struct {
 	OrderID      uint              `json:"order_id" validate:"required"`
+	Reference    string            `json:"reference,omitempty"` // Reference to commit or release the reservation with; generated when empty
 	Items        []OrderItemRequest `json:"items" validate:"required,dive"`
 	ReserveUntil string            `json:"reserve_until,omitempty"`
 }
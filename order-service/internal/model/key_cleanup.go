@@ -0,0 +1,6 @@
+package model
+
+// KeyPurgeResult reports how many expired keys a single purge deleted from each key table
+type KeyPurgeResult struct {
+	Deleted map[string]int64 `json:"deleted"`
+}
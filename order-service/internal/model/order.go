@@ -1,6 +1,7 @@
 package model
 
 import (
+	"order-service/internal/entity"
 	"time"
 )
 
@@ -8,7 +9,9 @@ import (
 type CreateOrderRequest struct {
 	UserID          string               `json:"user_id" validate:"required"`
 	ShippingAddress string               `json:"shipping_address" validate:"required"`
-	PaymentMethod   string               `json:"payment_method" validate:"required,max=50"`
+	PaymentMethod   string               `json:"payment_method" validate:"required,payment_method"` // One of order.payment_methods; stored lowercased
+	Currency        string               `json:"currency,omitempty" validate:"omitempty,iso4217"` // Defaults to the item currency, then USD
+	CouponCode      string               `json:"coupon_code,omitempty" validate:"omitempty,max=50"` // The discount is computed by the service, never taken from the client
 	Items           []OrderItemRequest   `json:"items" validate:"required,dive"`
 }
 
@@ -17,8 +20,9 @@ type OrderItemRequest struct {
 	OrderID     uint    `json:"order_id"`      // Added for compatibility with warehouse service
 	ProductID   uint    `json:"product_id" validate:"required"`
 	WarehouseID uint    `json:"warehouse_id" validate:"required"`
-	Quantity    int     `json:"quantity" validate:"required,min=1"`
-	UnitPrice   float64 `json:"unit_price" validate:"required,min=0"`
+	Quantity    int     `json:"quantity" validate:"gt=0"`
+	UnitPrice   entity.Money `json:"unit_price" validate:"gte=0" swaggertype:"number"`
+	Currency    string  `json:"currency,omitempty" validate:"omitempty,iso4217"` // Must match the order currency when set
 }
 
 // UpdateOrderStatusRequest is used to update an order's status
@@ -26,28 +30,125 @@ type UpdateOrderStatusRequest struct {
 	Status string `json:"status" validate:"required,oneof=pending paid cancelled completed"`
 }
 
+// CancelOrderItemsRequest is used to cancel specific line items of a pending order
+type CancelOrderItemsRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"required,min=1"`
+}
+
+// CompleteOrderRequest confirms the shipment of a paid order
+type CompleteOrderRequest struct {
+	Carrier        string `json:"carrier" validate:"required,max=50"`
+	TrackingNumber string `json:"tracking_number" validate:"required,max=100"`
+}
+
 // OrderResponse represents the response structure for an order
 type OrderResponse struct {
 	ID              uint                  `json:"id"`
 	UserID          string                `json:"user_id"`
 	Status          string                `json:"status"`
-	TotalAmount     float64               `json:"total_amount"`
+	Subtotal        entity.Money          `json:"subtotal" swaggertype:"number"`
+	TotalAmount     entity.Money          `json:"total_amount" swaggertype:"number"` // Subtotal less DiscountAmount plus TaxAmount and ShippingCost
+	Currency        string                `json:"currency"`
+	CouponCode      string                `json:"coupon_code,omitempty"`
+	DiscountAmount  entity.Money          `json:"discount_amount" swaggertype:"number"`
+	TaxRate         float64               `json:"tax_rate"` // Percentage
+	TaxAmount       entity.Money          `json:"tax_amount" swaggertype:"number"`
+	ShippingCost    entity.Money          `json:"shipping_cost" swaggertype:"number"`
 	ShippingAddress string                `json:"shipping_address"`
 	PaymentMethod   string                `json:"payment_method"`
 	PaymentDeadline string                `json:"payment_deadline"`
+	PaymentReference string               `json:"payment_reference,omitempty"`
+	Carrier         string                `json:"carrier,omitempty"`
+	TrackingNumber  string                `json:"tracking_number,omitempty"`
+	CompletedAt     string                `json:"completed_at,omitempty"`
 	CreatedAt       string                `json:"created_at"`
 	UpdatedAt       string                `json:"updated_at"`
 	Items           []OrderItemResponse   `json:"items,omitempty"`
+	// ReservationSummary is only populated when requested with ?include=reservations
+	ReservationSummary *ReservationSummary `json:"reservation_summary,omitempty"`
+}
+
+// OrderListResponse is a page of orders with its pagination metadata
+type OrderListResponse struct {
+	Orders []OrderResponse `json:"data"`
+	Meta   OrderListMeta   `json:"meta"`
+}
+
+// OrderListMeta describes the page returned in an OrderListResponse
+type OrderListMeta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalPages int64 `json:"total_pages"`
+}
+
+// ExpirySweepResult reports what a single expired-order sweep cleaned up
+type ExpirySweepResult struct {
+	CancelledOrders      int `json:"cancelled_orders"`
+	ReleasedReservations int `json:"released_reservations"`
+}
+
+// Results of reconciling the stock deduction of a paid order
+const (
+	ReconcileResultReconciled        = "reconciled"         // This call confirmed the deduction
+	ReconcileResultAlreadyReconciled = "already_reconciled" // The stock had already been deducted; nothing was done
+)
+
+// ReconcileOrderResponse reports the stock deduction state of a paid order after reconciliation
+type ReconcileOrderResponse struct {
+	OrderID         uint   `json:"order_id"`
+	Result          string `json:"result"` // reconciled or already_reconciled
+	StockDeductedAt string `json:"stock_deducted_at"`
+	Attempts        int    `json:"attempts"` // Deduction attempts so far, including the one that succeeded
+}
+
+// OrderSummaryResponse counts a user's orders by status and totals what they have spent
+type OrderSummaryResponse struct {
+	UserID       string           `json:"user_id"`
+	TotalOrders  int64            `json:"total_orders"`
+	StatusCounts map[string]int64 `json:"status_counts"` // Every status is present, zero when the user has no such orders
+	TotalSpent   []CurrencyAmount `json:"total_spent"`   // Summed total_amount of paid and completed orders, one entry per currency
+}
+
+// CurrencyAmount is an amount in a single currency
+type CurrencyAmount struct {
+	Currency string       `json:"currency"`
+	Amount   entity.Money `json:"amount" swaggertype:"number"`
+}
+
+// ReservationSummary counts an order's stock reservations by state
+type ReservationSummary struct {
+	Active    int `json:"active"`
+	Committed int `json:"committed"`
+	Released  int `json:"released"`
+}
+
+// OrderStatusHistoryResponse is the status timeline of an order, oldest change first
+type OrderStatusHistoryResponse struct {
+	OrderID uint                        `json:"order_id"`
+	UserID  string                      `json:"user_id"` // Owner of the order
+	Status  string                      `json:"status"`  // Current status
+	History []OrderStatusChangeResponse `json:"history"`
+}
+
+// OrderStatusChangeResponse is one status change of an order
+type OrderStatusChangeResponse struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Actor      string `json:"actor"` // User ID of the caller, or "system" for background jobs
+	ChangedAt  string `json:"changed_at"`
 }
 
 // OrderItemResponse represents an item in the order response
 type OrderItemResponse struct {
-	ID          uint    `json:"id"`
-	ProductID   uint    `json:"product_id"`
-	WarehouseID uint    `json:"warehouse_id"`
-	Quantity    int     `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price"`
-	TotalPrice  float64 `json:"total_price"`
+	ID                     uint    `json:"id"`
+	ProductID              uint    `json:"product_id"`
+	WarehouseID            uint    `json:"warehouse_id"`
+	ReservedWarehouseID    uint    `json:"reserved_warehouse_id,omitempty"`    // Warehouse holding the stock reservation
+	FulfillmentWarehouseID uint    `json:"fulfillment_warehouse_id,omitempty"` // Warehouse the item ships from
+	Quantity               int     `json:"quantity"`
+	UnitPrice              entity.Money `json:"unit_price" swaggertype:"number"`
+	TotalPrice             entity.Money `json:"total_price" swaggertype:"number"`
 }
 
 // OrderFilter represents query parameters for filtering orders
@@ -60,6 +161,37 @@ type OrderFilter struct {
 	Limit     int    `query:"limit"`
 }
 
+// OrderListFilter holds optional filters for listing a user's orders
+type OrderListFilter struct {
+	Status string
+	From   *time.Time
+	To     *time.Time
+}
+
+// OrderSearchCriteria holds the filters of an admin order search.
+// Empty fields are ignored, but at least one of them must be set.
+type OrderSearchCriteria struct {
+	OrderID       uint
+	UserID        string
+	Status        string
+	PaymentMethod string
+	From          *time.Time
+	To            *time.Time
+	Page          int
+	Limit         int
+}
+
+// OrderExportRow is one order of an order export
+type OrderExportRow struct {
+	OrderID     uint
+	UserID      string
+	Status      string
+	TotalAmount entity.Money
+	Currency    string
+	CreatedAt   time.Time
+	ItemCount   int
+}
+
 // ReservationRequest is used for creating stock reservations
 type ReservationRequest struct {
 	OrderID     uint      `json:"order_id" validate:"required"`
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// PaymentMethodTag is the validator tag that checks a payment method against the accepted ones
+const PaymentMethodTag = "payment_method"
+
+// DefaultPaymentMethods are accepted when order.payment_methods is not configured
+var DefaultPaymentMethods = []string{"credit_card", "bank_transfer", "e_wallet"}
+
+// RegisterPaymentMethods makes the payment_method tag accept exactly the given methods.
+// It is an alias of oneof, so a mismatch is reported with the oneof rule and lists the accepted methods.
+func RegisterPaymentMethods(validate *validator.Validate, methods []string) {
+	validate.RegisterAlias(PaymentMethodTag, "oneof="+strings.Join(methods, " "))
+}
+
+// NormalizePaymentMethod returns the form in which payment methods are validated and stored, so "E_Wallet " is e_wallet
+func NormalizePaymentMethod(method string) string {
+	return strings.ToLower(strings.TrimSpace(method))
+}
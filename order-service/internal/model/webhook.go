@@ -0,0 +1,10 @@
+package model
+
+// OrderStatusChangedEvent is the data of an order.status_changed webhook event
+type OrderStatusChangedEvent struct {
+	OrderID    uint   `json:"order_id"`
+	UserID     string `json:"user_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Actor      string `json:"actor"` // User ID of the caller, or "system" for background jobs
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"order-service/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CouponRepositoryInterface interface {
+	FindCouponByCode(tx *gorm.DB, code string) (*entity.Coupon, error)
+}
+
+type CouponRepository struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+}
+
+func NewCouponRepository(log *logrus.Logger, db *gorm.DB) CouponRepositoryInterface {
+	return &CouponRepository{
+		DB:  db,
+		Log: log,
+	}
+}
+
+// FindCouponByCode finds a coupon by its code, including inactive and expired coupons
+func (r *CouponRepository) FindCouponByCode(tx *gorm.DB, code string) (*entity.Coupon, error) {
+	coupon := new(entity.Coupon)
+	if err := tx.Where("code = ?", code).First(coupon).Error; err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDeadlock is the MySQL error number of a transaction rolled back to break a deadlock
+const mysqlErrDeadlock = 1213
+
+// IsDeadlock reports whether err is MySQL aborting the transaction to break a deadlock.
+// The whole transaction has been rolled back, so it can be retried from the start.
+func IsDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDeadlock
+}
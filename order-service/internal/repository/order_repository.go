@@ -6,16 +6,63 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepositoryInterface interface {
 	CreateOrder(tx *gorm.DB, order *entity.Order) error
 	CreateOrderItems(tx *gorm.DB, items []entity.OrderItem) error
 	FindOrderByID(tx *gorm.DB, orderID uint) (*entity.Order, error)
-	FindOrdersByUserID(tx *gorm.DB, userID string, page, limit int) ([]entity.Order, int64, error)
+	FindOrderByIDForUpdate(tx *gorm.DB, orderID uint) (*entity.Order, error)
+	FindOrdersByUserID(tx *gorm.DB, userID string, filter OrderFilter, page, limit int) ([]entity.Order, int64, error)
 	FindOrdersByStatus(tx *gorm.DB, status entity.OrderStatus, page, limit int) ([]entity.Order, int64, error)
+	SearchOrders(tx *gorm.DB, filter OrderFilter, page, limit int) ([]entity.Order, int64, error)
 	UpdateOrderStatus(tx *gorm.DB, orderID uint, status entity.OrderStatus) error
+	CreateOrderStatusHistory(tx *gorm.DB, history *entity.OrderStatusHistory) error
+	FindOrderStatusHistory(tx *gorm.DB, orderID uint) ([]entity.OrderStatusHistory, error)
 	FindExpiredOrders(tx *gorm.DB, deadline time.Time) ([]entity.Order, error)
+	DeleteOrderItems(tx *gorm.DB, itemIDs []uint) error
+	UpdateOrderAmounts(tx *gorm.DB, order *entity.Order) error
+	UpdatePaymentReference(tx *gorm.DB, orderID uint, reference string) error
+	IncrementPaymentAttempts(tx *gorm.DB, orderID uint) error
+	UpdateReservationReference(tx *gorm.DB, orderID uint, reference string) error
+	RecordStockDeduction(tx *gorm.DB, orderID uint, deductedAt time.Time) error
+	RecordStockDeductionFailure(tx *gorm.DB, orderID uint, lastError string) error
+	RecordShipment(tx *gorm.DB, orderID uint, carrier, trackingNumber string, completedAt time.Time) error
+	ClaimOrder(tx *gorm.DB, orderID uint, claimedAt time.Time) error
+	ReleaseOrderClaim(tx *gorm.DB, orderID uint) error
+	StreamOrdersForExport(tx *gorm.DB, filter OrderFilter, fn func(row *OrderExportRow) error) error
+	SummarizeOrdersByUserID(tx *gorm.DB, userID string) ([]OrderStatusTotal, error)
+}
+
+// OrderFilter holds optional criteria for narrowing order listings.
+// Zero values are ignored, so an empty filter matches every order.
+type OrderFilter struct {
+	OrderID       uint
+	UserID        string
+	Status        entity.OrderStatus
+	PaymentMethod string
+	From          *time.Time
+	To            *time.Time
+}
+
+// OrderExportRow is one order as read for an export, with the number of its line items
+type OrderExportRow struct {
+	ID          uint
+	UserID      string
+	Status      entity.OrderStatus
+	TotalAmount entity.Money
+	Currency    string
+	CreatedAt   time.Time
+	ItemCount   int
+}
+
+// OrderStatusTotal is the number and summed total of a user's orders sharing one status and currency
+type OrderStatusTotal struct {
+	Status      entity.OrderStatus
+	Currency    string
+	OrderCount  int64
+	TotalAmount entity.Money
 }
 
 type OrderRepository struct {
@@ -40,26 +87,36 @@ func (r *OrderRepository) CreateOrderItems(tx *gorm.DB, items []entity.OrderItem
 
 func (r *OrderRepository) FindOrderByID(tx *gorm.DB, orderID uint) (*entity.Order, error) {
 	order := new(entity.Order)
-	if err := tx.Preload("OrderItems").Where("id = ?", orderID).First(order).Error; err != nil {
+	if err := tx.Preload("OrderItems").Preload("Reservations").Where("id = ?", orderID).First(order).Error; err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// FindOrderByIDForUpdate loads an order like FindOrderByID and locks its row with SELECT ... FOR UPDATE.
+// Call it inside a transaction; concurrent callers wait until the transaction ends, then see its changes.
+func (r *OrderRepository) FindOrderByIDForUpdate(tx *gorm.DB, orderID uint) (*entity.Order, error) {
+	order := new(entity.Order)
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("OrderItems").Preload("Reservations").Where("id = ?", orderID).First(order).Error; err != nil {
 		return nil, err
 	}
 	return order, nil
 }
 
-func (r *OrderRepository) FindOrdersByUserID(tx *gorm.DB, userID string, page, limit int) ([]entity.Order, int64, error) {
+func (r *OrderRepository) FindOrdersByUserID(tx *gorm.DB, userID string, filter OrderFilter, page, limit int) ([]entity.Order, int64, error) {
 	var orders []entity.Order
 	var total int64
 	
 	offset := (page - 1) * limit
 	
 	// Count total matching records
-	err := tx.Model(&entity.Order{}).Where("user_id = ?", userID).Count(&total).Error
+	err := applyOrderFilter(tx.Model(&entity.Order{}).Where("user_id = ?", userID), filter).Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 	
 	// Get paginated data
-	err = tx.Preload("OrderItems").Where("user_id = ?", userID).
+	err = applyOrderFilter(tx.Preload("OrderItems").Preload("Reservations").Where("user_id = ?", userID), filter).
 		Offset(offset).Limit(limit).
 		Order("created_at DESC").
 		Find(&orders).Error
@@ -71,6 +128,29 @@ func (r *OrderRepository) FindOrdersByUserID(tx *gorm.DB, userID string, page, l
 	return orders, total, nil
 }
 
+// applyOrderFilter adds WHERE clauses for the criteria set on the filter
+func applyOrderFilter(query *gorm.DB, filter OrderFilter) *gorm.DB {
+	if filter.OrderID != 0 {
+		query = query.Where("id = ?", filter.OrderID)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.PaymentMethod != "" {
+		query = query.Where("payment_method = ?", filter.PaymentMethod)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
+
 func (r *OrderRepository) FindOrdersByStatus(tx *gorm.DB, status entity.OrderStatus, page, limit int) ([]entity.Order, int64, error) {
 	var orders []entity.Order
 	var total int64
@@ -96,10 +176,55 @@ func (r *OrderRepository) FindOrdersByStatus(tx *gorm.DB, status entity.OrderSta
 	return orders, total, nil
 }
 
+// SearchOrders returns a page of the orders matching the filter, newest first, with the total number of matches
+func (r *OrderRepository) SearchOrders(tx *gorm.DB, filter OrderFilter, page, limit int) ([]entity.Order, int64, error) {
+	var orders []entity.Order
+	var total int64
+
+	offset := (page - 1) * limit
+
+	// Count total matching records
+	err := applyOrderFilter(tx.Model(&entity.Order{}), filter).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated data
+	err = applyOrderFilter(tx.Preload("OrderItems").Preload("Reservations"), filter).
+		Offset(offset).Limit(limit).
+		Order("created_at DESC, id DESC").
+		Find(&orders).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
 func (r *OrderRepository) UpdateOrderStatus(tx *gorm.DB, orderID uint, status entity.OrderStatus) error {
 	return tx.Model(&entity.Order{}).Where("id = ?", orderID).Update("status", status).Error
 }
 
+func (r *OrderRepository) CreateOrderStatusHistory(tx *gorm.DB, history *entity.OrderStatusHistory) error {
+	return tx.Create(history).Error
+}
+
+// FindOrderStatusHistory returns the status changes of an order, oldest first
+func (r *OrderRepository) FindOrderStatusHistory(tx *gorm.DB, orderID uint) ([]entity.OrderStatusHistory, error) {
+	var history []entity.OrderStatusHistory
+
+	err := tx.Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Find(&history).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
 func (r *OrderRepository) FindExpiredOrders(tx *gorm.DB, deadline time.Time) ([]entity.Order, error) {
 	var orders []entity.Order
 	
@@ -112,4 +237,117 @@ func (r *OrderRepository) FindExpiredOrders(tx *gorm.DB, deadline time.Time) ([]
 	}
 	
 	return orders, nil
-}
\ No newline at end of file
+}
+
+func (r *OrderRepository) DeleteOrderItems(tx *gorm.DB, itemIDs []uint) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	return tx.Where("id IN ?", itemIDs).Delete(&entity.OrderItem{}).Error
+}
+
+// UpdateOrderAmounts saves an order's subtotal, discount, tax and grand total
+func (r *OrderRepository) UpdateOrderAmounts(tx *gorm.DB, order *entity.Order) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", order.ID).
+		Updates(map[string]interface{}{
+			"subtotal":        order.Subtotal,
+			"discount_amount": order.DiscountAmount,
+			"tax_amount":      order.TaxAmount,
+			"total_amount":    order.TotalAmount,
+		}).Error
+}
+
+func (r *OrderRepository) UpdatePaymentReference(tx *gorm.DB, orderID uint, reference string) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).Update("payment_reference", reference).Error
+}
+
+// IncrementPaymentAttempts ends the current payment attempt of the order, so the next charge gets a new idempotency key
+func (r *OrderRepository) IncrementPaymentAttempts(tx *gorm.DB, orderID uint) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).Update("payment_attempts", gorm.Expr("payment_attempts + 1")).Error
+}
+
+// UpdateReservationReference stores the reference the warehouse now holds the order's stock under
+func (r *OrderRepository) UpdateReservationReference(tx *gorm.DB, orderID uint, reference string) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).Update("reservation_reference", reference).Error
+}
+
+// RecordStockDeduction marks the order's stock as deducted and clears the last deduction error
+func (r *OrderRepository) RecordStockDeduction(tx *gorm.DB, orderID uint, deductedAt time.Time) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"stock_deducted_at":        deductedAt,
+			"stock_deduction_attempts": gorm.Expr("stock_deduction_attempts + 1"),
+			"stock_deduction_error":    nil,
+		}).Error
+}
+
+// RecordStockDeductionFailure counts a failed stock deduction and keeps its error for reconciliation
+func (r *OrderRepository) RecordStockDeductionFailure(tx *gorm.DB, orderID uint, lastError string) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"stock_deduction_attempts": gorm.Expr("stock_deduction_attempts + 1"),
+			"stock_deduction_error":    lastError,
+		}).Error
+}
+
+// RecordShipment stores the carrier and tracking number of a shipped order and when it was completed
+func (r *OrderRepository) RecordShipment(tx *gorm.DB, orderID uint, carrier, trackingNumber string, completedAt time.Time) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"carrier":         carrier,
+			"tracking_number": trackingNumber,
+			"completed_at":    completedAt,
+		}).Error
+}
+
+// ClaimOrder marks the order as being worked on by an operation that calls the warehouse without holding its row lock
+func (r *OrderRepository) ClaimOrder(tx *gorm.DB, orderID uint, claimedAt time.Time) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).Update("claimed_at", claimedAt).Error
+}
+
+// ReleaseOrderClaim clears the claim set by ClaimOrder
+func (r *OrderRepository) ReleaseOrderClaim(tx *gorm.DB, orderID uint) error {
+	return tx.Model(&entity.Order{}).Where("id = ?", orderID).Update("claimed_at", nil).Error
+}
+
+// StreamOrdersForExport calls fn for every order matching the filter, oldest first.
+// Rows are read from a cursor one at a time rather than loaded together, so memory stays flat for large exports.
+// Iteration stops at the first error returned by fn, which is passed back to the caller.
+func (r *OrderRepository) StreamOrdersForExport(tx *gorm.DB, filter OrderFilter, fn func(row *OrderExportRow) error) error {
+	query := tx.Model(&entity.Order{}).
+		Select("id, user_id, status, total_amount, currency, created_at, " +
+			"(SELECT COUNT(*) FROM order_items WHERE order_items.order_id = orders.id) AS item_count")
+
+	rows, err := applyOrderFilter(query, filter).Order("created_at ASC, id ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := new(OrderExportRow)
+		if err := tx.ScanRows(rows, row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// SummarizeOrdersByUserID counts and sums a user's orders grouped by status and currency in a single query.
+// A user without orders gets an empty slice.
+func (r *OrderRepository) SummarizeOrdersByUserID(tx *gorm.DB, userID string) ([]OrderStatusTotal, error) {
+	var totals []OrderStatusTotal
+	err := tx.Model(&entity.Order{}).
+		Select("status, currency, COUNT(*) AS order_count, COALESCE(SUM(total_amount), 0) AS total_amount").
+		Where("user_id = ?", userID).
+		Group("status, currency").
+		Order("status, currency").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -15,6 +15,13 @@ type ReservationRepositoryInterface interface {
 	UpdateReservationStatus(tx *gorm.DB, reservationID uint, isActive bool) error
 	DeactivateReservationsByOrderID(tx *gorm.DB, orderID uint) error
 	FindExpiredReservations(tx *gorm.DB, currentTime time.Time) ([]entity.Reservation, error)
+	DeactivateReservationsByOrderItems(tx *gorm.DB, orderID uint, items []entity.OrderItem) error
+	EnqueueReservationReleases(tx *gorm.DB, orderID uint, reference string, items []entity.OrderItem) ([]entity.ReservationReleaseOutbox, error)
+	FindPendingReservationReleases(tx *gorm.DB, limit int) ([]entity.ReservationReleaseOutbox, error)
+	CountPendingReservationReleases(tx *gorm.DB, orderID uint) (int64, error)
+	MarkReservationReleasesDone(tx *gorm.DB, ids []uint) error
+	RecordReservationReleaseFailure(tx *gorm.DB, id uint, lastError string) error
+	DeleteDoneReservationReleases(tx *gorm.DB, cutoff time.Time, limit int) (int64, error)
 }
 
 type ReservationRepository struct {
@@ -56,13 +63,112 @@ func (r *ReservationRepository) DeactivateReservationsByOrderID(tx *gorm.DB, ord
 	return tx.Model(&entity.Reservation{}).Where("order_id = ?", orderID).Update("is_active", false).Error
 }
 
+// FindExpiredReservations returns the active reservations past their expiry whose order is still pending.
+// Reservations of an order being charged or already paid are left alone, since that order's stock is about
+// to be or has been deducted.
 func (r *ReservationRepository) FindExpiredReservations(tx *gorm.DB, currentTime time.Time) ([]entity.Reservation, error) {
 	var reservations []entity.Reservation
 	
-	err := tx.Where("expires_at < ? AND is_active = true", currentTime).Find(&reservations).Error
+	// The order carries the reference the warehouse holds the reservations under
+	err := tx.Preload("Order").
+		Joins("JOIN orders ON orders.id = stock_reservations.order_id").
+		Where("stock_reservations.expires_at < ? AND stock_reservations.is_active = true AND orders.status = ?", currentTime, entity.OrderStatusPending).
+		Find(&reservations).Error
 	if err != nil {
 		return nil, err
 	}
 	
 	return reservations, nil
+}
+
+func (r *ReservationRepository) DeactivateReservationsByOrderItems(tx *gorm.DB, orderID uint, items []entity.OrderItem) error {
+	for _, item := range items {
+		err := tx.Model(&entity.Reservation{}).
+			Where("order_id = ? AND product_id = ? AND warehouse_id = ? AND is_active = true", orderID, item.ProductID, item.WarehouseID).
+			Update("is_active", false).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnqueueReservationReleases queues one pending outbox entry per item, released under the warehouse reference.
+// Call it in the same transaction that deactivates the reservations so the release is never lost.
+func (r *ReservationRepository) EnqueueReservationReleases(tx *gorm.DB, orderID uint, reference string, items []entity.OrderItem) ([]entity.ReservationReleaseOutbox, error) {
+	entries := make([]entity.ReservationReleaseOutbox, len(items))
+	for i, item := range items {
+		entries[i] = entity.ReservationReleaseOutbox{
+			OrderID:     orderID,
+			ProductID:   item.ProductID,
+			WarehouseID: item.WarehouseID,
+			Quantity:    item.Quantity,
+			Reference:   reference,
+			Status:      entity.ReleaseOutboxStatusPending,
+		}
+	}
+
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	if err := tx.Create(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *ReservationRepository) FindPendingReservationReleases(tx *gorm.DB, limit int) ([]entity.ReservationReleaseOutbox, error) {
+	var entries []entity.ReservationReleaseOutbox
+
+	err := tx.Where("status = ?", entity.ReleaseOutboxStatusPending).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CountPendingReservationReleases counts the queued releases of an order that have not reached the warehouse yet
+func (r *ReservationRepository) CountPendingReservationReleases(tx *gorm.DB, orderID uint) (int64, error) {
+	var count int64
+
+	err := tx.Model(&entity.ReservationReleaseOutbox{}).
+		Where("order_id = ? AND status = ?", orderID, entity.ReleaseOutboxStatusPending).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (r *ReservationRepository) MarkReservationReleasesDone(tx *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tx.Model(&entity.ReservationReleaseOutbox{}).
+		Where("id IN ?", ids).
+		Update("status", entity.ReleaseOutboxStatusDone).Error
+}
+
+func (r *ReservationRepository) RecordReservationReleaseFailure(tx *gorm.DB, id uint, lastError string) error {
+	return tx.Model(&entity.ReservationReleaseOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": lastError,
+		}).Error
+}
+
+// DeleteDoneReservationReleases deletes at most limit released outbox entries last updated before cutoff and
+// returns how many it deleted. Pending entries are never deleted. Bounding each delete keeps the locks it takes short.
+func (r *ReservationRepository) DeleteDoneReservationReleases(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	result := tx.Where("status = ? AND updated_at < ?", entity.ReleaseOutboxStatusDone, cutoff).
+		Limit(limit).
+		Delete(&entity.ReservationReleaseOutbox{})
+	return result.RowsAffected, result.Error
 }
\ No newline at end of file
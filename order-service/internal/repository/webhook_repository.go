@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"order-service/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoryInterface interface {
+	EnqueueWebhookEvent(tx *gorm.DB, event *entity.WebhookEvent) error
+	FindDueWebhookEvents(tx *gorm.DB, now time.Time, limit int) ([]entity.WebhookEvent, error)
+	MarkWebhookEventDelivered(tx *gorm.DB, id uint, deliveredAt time.Time) error
+	RecordWebhookEventFailure(tx *gorm.DB, id uint, lastError string, nextAttemptAt time.Time, giveUp bool) error
+	DeleteFinishedWebhookEvents(tx *gorm.DB, cutoff time.Time, limit int) (int64, error)
+}
+
+type WebhookRepository struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+}
+
+func NewWebhookRepository(log *logrus.Logger, db *gorm.DB) WebhookRepositoryInterface {
+	return &WebhookRepository{
+		DB:  db,
+		Log: log,
+	}
+}
+
+// EnqueueWebhookEvent queues a pending event that is due immediately. Call it in the same
+// transaction as the change the event reports so the event is never lost or sent for a rolled back change.
+func (r *WebhookRepository) EnqueueWebhookEvent(tx *gorm.DB, event *entity.WebhookEvent) error {
+	event.Status = entity.WebhookEventStatusPending
+	if event.NextAttemptAt.IsZero() {
+		event.NextAttemptAt = time.Now()
+	}
+	return tx.Create(event).Error
+}
+
+// FindDueWebhookEvents returns pending events whose next attempt is due, oldest first
+func (r *WebhookRepository) FindDueWebhookEvents(tx *gorm.DB, now time.Time, limit int) ([]entity.WebhookEvent, error) {
+	var events []entity.WebhookEvent
+
+	err := tx.Where("status = ? AND next_attempt_at <= ?", entity.WebhookEventStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// MarkWebhookEventDelivered counts the successful attempt and stops further deliveries of the event
+func (r *WebhookRepository) MarkWebhookEventDelivered(tx *gorm.DB, id uint, deliveredAt time.Time) error {
+	return tx.Model(&entity.WebhookEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       entity.WebhookEventStatusDelivered,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"delivered_at": deliveredAt,
+			"last_error":   nil,
+		}).Error
+}
+
+// RecordWebhookEventFailure counts a failed attempt and schedules the next one.
+// When giveUp is set the event is marked failed and is not attempted again.
+func (r *WebhookRepository) RecordWebhookEventFailure(tx *gorm.DB, id uint, lastError string, nextAttemptAt time.Time, giveUp bool) error {
+	updates := map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}
+	if giveUp {
+		updates["status"] = entity.WebhookEventStatusFailed
+	}
+
+	return tx.Model(&entity.WebhookEvent{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+}
+
+// DeleteFinishedWebhookEvents deletes at most limit delivered or failed events last updated before cutoff and
+// returns how many it deleted. Pending events are never deleted. Bounding each delete keeps the locks it takes short.
+func (r *WebhookRepository) DeleteFinishedWebhookEvents(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	result := tx.Where("status IN ? AND updated_at < ?", []entity.WebhookEventStatus{entity.WebhookEventStatusDelivered, entity.WebhookEventStatusFailed}, cutoff).
+		Limit(limit).
+		Delete(&entity.WebhookEvent{})
+	return result.RowsAffected, result.Error
+}
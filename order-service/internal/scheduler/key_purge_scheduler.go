@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"order-service/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// KeyPurgeScheduler periodically deletes idempotency and operation keys older than their retention
+type KeyPurgeScheduler struct {
+	KeyCleanupUseCase usecase.KeyCleanupUseCaseInterface
+	Log               *logrus.Logger
+
+	runner *periodicRunner
+}
+
+// NewKeyPurgeScheduler creates a new key purge scheduler
+func NewKeyPurgeScheduler(keyCleanupUseCase usecase.KeyCleanupUseCaseInterface, interval time.Duration, log *logrus.Logger) *KeyPurgeScheduler {
+	s := &KeyPurgeScheduler{
+		KeyCleanupUseCase: keyCleanupUseCase,
+		Log:               log,
+	}
+	s.runner = newPeriodicRunner("Key purge scheduler", interval, s.purge, log)
+	return s
+}
+
+// Start runs a purge every interval until ctx is cancelled
+func (s *KeyPurgeScheduler) Start(ctx context.Context) {
+	s.runner.Start(ctx)
+}
+
+// Purge deletes expired keys once. It returns false without purging when the previous purge is still running.
+func (s *KeyPurgeScheduler) Purge(ctx context.Context) bool {
+	return s.runner.RunOnce(ctx)
+}
+
+// purge deletes expired keys and logs how many were deleted from each table
+func (s *KeyPurgeScheduler) purge(ctx context.Context) {
+	start := time.Now()
+	result, err := s.KeyCleanupUseCase.PurgeExpiredKeys(ctx)
+
+	fields := logrus.Fields{
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if result != nil {
+		for table, deleted := range result.Deleted {
+			fields[table] = deleted
+		}
+	}
+	if err != nil {
+		s.Log.WithError(err).WithFields(fields).Error("Key purge failed")
+		return
+	}
+
+	s.Log.WithFields(fields).Info("Key purge completed")
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"order-service/internal/model"
+	usecase_mock "order-service/mocks/usecase"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKeyPurgeScheduler_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockKeyCleanupUseCase := usecase_mock.NewMockKeyCleanupUseCaseInterface(ctrl)
+
+	s := NewKeyPurgeScheduler(mockKeyCleanupUseCase, time.Hour, logrus.New())
+
+	t.Run("Success", func(t *testing.T) {
+		mockKeyCleanupUseCase.EXPECT().
+			PurgeExpiredKeys(gomock.Any()).
+			Return(&model.KeyPurgeResult{Deleted: map[string]int64{"test_keys": 3}}, nil)
+
+		assert.True(t, s.Purge(context.Background()))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockKeyCleanupUseCase.EXPECT().
+			PurgeExpiredKeys(gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		assert.True(t, s.Purge(context.Background()))
+	})
+}
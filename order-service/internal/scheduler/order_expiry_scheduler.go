@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"order-service/internal/metrics"
+	"order-service/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// OrderExpiryScheduler periodically cancels orders whose payment deadline has passed
+type OrderExpiryScheduler struct {
+	OrderUseCase usecase.OrderUseCaseInterface
+	Metrics      *metrics.Metrics
+	Log          *logrus.Logger
+
+	runner *periodicRunner
+}
+
+// NewOrderExpiryScheduler creates a new order expiry scheduler
+func NewOrderExpiryScheduler(orderUseCase usecase.OrderUseCaseInterface, interval time.Duration, metrics *metrics.Metrics, log *logrus.Logger) *OrderExpiryScheduler {
+	s := &OrderExpiryScheduler{
+		OrderUseCase: orderUseCase,
+		Metrics:      metrics,
+		Log:          log,
+	}
+	s.runner = newPeriodicRunner("Order expiry scheduler", interval, s.scan, log)
+	return s
+}
+
+// Start runs a scan every interval until ctx is cancelled
+func (s *OrderExpiryScheduler) Start(ctx context.Context) {
+	s.runner.Start(ctx)
+}
+
+// Scan cancels expired orders once. It returns false without scanning
+// when the previous scan is still running.
+func (s *OrderExpiryScheduler) Scan(ctx context.Context) bool {
+	return s.runner.RunOnce(ctx)
+}
+
+// scan cancels expired orders and records how many were cancelled
+func (s *OrderExpiryScheduler) scan(ctx context.Context) {
+	start := time.Now()
+	result, err := s.OrderUseCase.CancelExpiredOrders(ctx)
+	if err != nil {
+		s.Log.WithError(err).Error("Order expiry scan failed")
+		s.Metrics.RecordFailedExpirySweep()
+		return
+	}
+	s.Metrics.RecordExpirySweep(result.CancelledOrders, result.ReleasedReservations)
+
+	s.Log.WithFields(logrus.Fields{
+		"cancelled_orders":      result.CancelledOrders,
+		"released_reservations": result.ReleasedReservations,
+		"duration_ms":           time.Since(start).Milliseconds(),
+	}).Info("Order expiry scan completed")
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"order-service/internal/model"
+	usecase_mock "order-service/mocks/usecase"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderExpiryScheduler_Scan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockOrderUseCase := usecase_mock.NewMockOrderUseCaseInterface(ctrl)
+
+	s := NewOrderExpiryScheduler(mockOrderUseCase, time.Minute, nil, logrus.New())
+
+	t.Run("Success", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			CancelExpiredOrders(gomock.Any()).
+			Return(&model.ExpirySweepResult{CancelledOrders: 2, ReleasedReservations: 3}, nil)
+
+		assert.True(t, s.Scan(context.Background()))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockOrderUseCase.EXPECT().
+			CancelExpiredOrders(gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		assert.True(t, s.Scan(context.Background()))
+	})
+}
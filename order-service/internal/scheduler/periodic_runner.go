@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// periodicRunner runs a job every interval until its context is cancelled. A run that comes due while the
+// previous one is still going is skipped, so slow runs never pile up.
+type periodicRunner struct {
+	name     string
+	interval time.Duration
+	job      func(ctx context.Context)
+	log      *logrus.Logger
+
+	running atomic.Bool
+}
+
+// newPeriodicRunner creates a runner for job; name starts the log messages of the runner
+func newPeriodicRunner(name string, interval time.Duration, job func(ctx context.Context), log *logrus.Logger) *periodicRunner {
+	return &periodicRunner{
+		name:     name,
+		interval: interval,
+		job:      job,
+		log:      log,
+	}
+}
+
+// Start runs the job every interval in the background until ctx is cancelled
+func (r *periodicRunner) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		r.log.Infof("%s started with interval %s", r.name, r.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				r.log.Infof("%s stopped", r.name)
+				return
+			case <-ticker.C:
+				go r.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce runs the job once. It returns false without running when the previous run is still going.
+func (r *periodicRunner) RunOnce(ctx context.Context) bool {
+	if !r.running.CompareAndSwap(false, true) {
+		r.log.Warnf("%s skipped a run, previous run still going", r.name)
+		return false
+	}
+	defer r.running.Store(false)
+
+	r.job(ctx)
+	return true
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodicRunner_RunOnce(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	t.Run("RunsJob", func(t *testing.T) {
+		var runs atomic.Int32
+		r := newPeriodicRunner("Test job", time.Hour, func(ctx context.Context) { runs.Add(1) }, logger)
+
+		assert.True(t, r.RunOnce(context.Background()))
+		assert.True(t, r.RunOnce(context.Background()))
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("SkipsWhilePreviousRunGoes", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var runs atomic.Int32
+		r := newPeriodicRunner("Test job", time.Hour, func(ctx context.Context) {
+			runs.Add(1)
+			close(started)
+			<-release
+		}, logger)
+
+		done := make(chan bool)
+		go func() { done <- r.RunOnce(context.Background()) }()
+		<-started
+
+		// A second run while the first is still going is skipped
+		assert.False(t, r.RunOnce(context.Background()))
+
+		close(release)
+		assert.True(t, <-done)
+		assert.Equal(t, int32(1), runs.Load())
+	})
+}
+
+func TestPeriodicRunner_StartStopsOnCancel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	ran := make(chan struct{}, 1)
+	var runs atomic.Int32
+	r := newPeriodicRunner("Test job", 10*time.Millisecond, func(ctx context.Context) {
+		runs.Add(1)
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+	}, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Start(ctx)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run")
+	}
+	cancel()
+
+	// Let in-flight runs finish, then no further run is started
+	time.Sleep(50 * time.Millisecond)
+	stopped := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"context"
+	"order-service/internal/entity"
+	"order-service/internal/gateway/webhook"
+	"order-service/internal/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// WebhookDispatcher periodically posts queued webhook events and marks them delivered.
+// A failed delivery is retried with exponential backoff until MaxAttempts is reached.
+type WebhookDispatcher struct {
+	DB              *gorm.DB
+	Repository      repository.WebhookRepositoryInterface
+	Sender          webhook.WebhookSenderInterface
+	BatchSize       int
+	MaxAttempts     int           // Attempts before an event is marked failed; 0 retries forever
+	RetryBackoff    time.Duration // Delay after the first failure, doubled after each further failure
+	MaxRetryBackoff time.Duration
+	Log             *logrus.Logger
+
+	runner *periodicRunner
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(db *gorm.DB, repository repository.WebhookRepositoryInterface, sender webhook.WebhookSenderInterface, interval time.Duration, batchSize, maxAttempts int, retryBackoff, maxRetryBackoff time.Duration, log *logrus.Logger) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		DB:              db,
+		Repository:      repository,
+		Sender:          sender,
+		BatchSize:       batchSize,
+		MaxAttempts:     maxAttempts,
+		RetryBackoff:    retryBackoff,
+		MaxRetryBackoff: maxRetryBackoff,
+		Log:             log,
+	}
+	d.runner = newPeriodicRunner("Webhook dispatcher", interval, d.dispatch, log)
+	return d
+}
+
+// Start dispatches due events every interval until ctx is cancelled
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	d.runner.Start(ctx)
+}
+
+// Dispatch sends one batch of due events. It returns false without sending
+// when the previous dispatch is still running.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) bool {
+	return d.runner.RunOnce(ctx)
+}
+
+// dispatch sends one batch of due events and records the outcome of each
+func (d *WebhookDispatcher) dispatch(ctx context.Context) {
+	events, err := d.Repository.FindDueWebhookEvents(d.DB.WithContext(ctx), time.Now(), d.BatchSize)
+	if err != nil {
+		d.Log.WithError(err).Error("Failed to load due webhook events")
+		return
+	}
+
+	delivered := 0
+	for i := range events {
+		if d.deliver(ctx, &events[i]) {
+			delivered++
+		}
+	}
+
+	if len(events) > 0 {
+		d.Log.WithFields(logrus.Fields{
+			"due":       len(events),
+			"delivered": delivered,
+		}).Info("Webhook dispatch completed")
+	}
+}
+
+// deliver sends one event and records the outcome, reporting whether the receiver acknowledged it
+func (d *WebhookDispatcher) deliver(ctx context.Context, event *entity.WebhookEvent) bool {
+	sendErr := d.Sender.Send(ctx, event)
+	if sendErr == nil {
+		if err := d.Repository.MarkWebhookEventDelivered(d.DB.WithContext(ctx), event.ID, time.Now()); err != nil {
+			// The event stays pending and is sent again, which receivers must tolerate anyway
+			d.Log.WithError(err).WithField("event_id", event.ID).Error("Failed to mark webhook event delivered")
+		}
+		return true
+	}
+
+	attempts := event.Attempts + 1
+	giveUp := d.MaxAttempts > 0 && attempts >= d.MaxAttempts
+	nextAttemptAt := time.Now().Add(d.backoff(attempts))
+
+	fields := logrus.Fields{
+		"event_id":   event.ID,
+		"event_type": event.EventType,
+		"order_id":   event.OrderID,
+		"attempts":   attempts,
+		"error":      sendErr.Error(),
+	}
+	if giveUp {
+		d.Log.WithFields(fields).Error("Giving up on webhook event after the maximum number of attempts")
+	} else {
+		d.Log.WithFields(fields).WithField("next_attempt_at", nextAttemptAt).Warn("Webhook delivery failed, will retry")
+	}
+
+	if err := d.Repository.RecordWebhookEventFailure(d.DB.WithContext(ctx), event.ID, sendErr.Error(), nextAttemptAt, giveUp); err != nil {
+		d.Log.WithError(err).WithField("event_id", event.ID).Error("Failed to record webhook delivery failure")
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt after the given number of failed attempts:
+// RetryBackoff after the first, doubling each time, capped at MaxRetryBackoff
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		if d.MaxRetryBackoff > 0 && delay >= d.MaxRetryBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if d.MaxRetryBackoff > 0 && delay > d.MaxRetryBackoff {
+		delay = d.MaxRetryBackoff
+	}
+	return delay
+}
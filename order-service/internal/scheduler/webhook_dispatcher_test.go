@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"order-service/internal/entity"
+	"order-service/internal/gateway/webhook"
+	repository_mock "order-service/mocks/repository"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+func newWebhookTestDB(t *testing.T) *gorm.DB {
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+	return db
+}
+
+func newTestWebhookEvent(attempts int) entity.WebhookEvent {
+	return entity.WebhookEvent{
+		ID:        7,
+		EventType: entity.WebhookEventOrderStatusChanged,
+		OrderID:   1,
+		Payload:   `{"order_id":1,"user_id":"test-user-id","from_status":"pending","to_status":"paid","actor":"test-user-id"}`,
+		Status:    entity.WebhookEventStatusPending,
+		Attempts:  attempts,
+		CreatedAt: time.Date(2025, 5, 27, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookDispatcher_Dispatch(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	t.Run("DeliversSignedEvent", func(t *testing.T) {
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			body, _ := io.ReadAll(r.Body)
+
+			// The signature is the HMAC of the exact body received
+			assert.Equal(t, webhook.Sign(testWebhookSecret, body), r.Header.Get(webhook.SignatureHeader))
+			assert.Equal(t, "order.status_changed", r.Header.Get(webhook.EventTypeHeader))
+			assert.Equal(t, "7", r.Header.Get(webhook.EventIDHeader))
+			assert.JSONEq(t, `{
+				"id": 7,
+				"type": "order.status_changed",
+				"created_at": "2025-05-27T10:00:00Z",
+				"data": {"order_id": 1, "user_id": "test-user-id", "from_status": "pending", "to_status": "paid", "actor": "test-user-id"}
+			}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		mockRepo := new(repository_mock.WebhookRepositoryMock)
+		mockRepo.On("FindDueWebhookEvents", mock.Anything, mock.Anything, 50).
+			Return([]entity.WebhookEvent{newTestWebhookEvent(0)}, nil).Once()
+		mockRepo.On("MarkWebhookEventDelivered", mock.Anything, uint(7), mock.Anything).Return(nil).Once()
+
+		client := webhook.NewClient(server.URL, testWebhookSecret, time.Second, logger)
+		d := NewWebhookDispatcher(newWebhookTestDB(t), mockRepo, client, time.Minute, 50, 5, time.Minute, time.Hour, logger)
+
+		assert.True(t, d.Dispatch(context.Background()))
+		assert.Equal(t, int32(1), received.Load())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("RetriesAfterServerError", func(t *testing.T) {
+		var failing atomic.Bool
+		failing.Store(true)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		mockRepo := new(repository_mock.WebhookRepositoryMock)
+		client := webhook.NewClient(server.URL, testWebhookSecret, time.Second, logger)
+		d := NewWebhookDispatcher(newWebhookTestDB(t), mockRepo, client, time.Minute, 50, 5, time.Minute, time.Hour, logger)
+
+		// The first attempt fails and is scheduled again after the initial backoff
+		before := time.Now()
+		mockRepo.On("FindDueWebhookEvents", mock.Anything, mock.Anything, 50).
+			Return([]entity.WebhookEvent{newTestWebhookEvent(0)}, nil).Once()
+		mockRepo.On("RecordWebhookEventFailure", mock.Anything, uint(7), mock.MatchedBy(func(lastError string) bool {
+			return assert.Contains(t, lastError, "status code 500")
+		}), mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			return !nextAttemptAt.Before(before.Add(time.Minute))
+		}), false).Return(nil).Once()
+
+		assert.True(t, d.Dispatch(context.Background()))
+		mockRepo.AssertExpectations(t)
+
+		// Once the receiver recovers, the retried event is delivered
+		failing.Store(false)
+		mockRepo.On("FindDueWebhookEvents", mock.Anything, mock.Anything, 50).
+			Return([]entity.WebhookEvent{newTestWebhookEvent(1)}, nil).Once()
+		mockRepo.On("MarkWebhookEventDelivered", mock.Anything, uint(7), mock.Anything).Return(nil).Once()
+
+		assert.True(t, d.Dispatch(context.Background()))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		mockRepo := new(repository_mock.WebhookRepositoryMock)
+		mockRepo.On("FindDueWebhookEvents", mock.Anything, mock.Anything, 50).
+			Return([]entity.WebhookEvent{newTestWebhookEvent(4)}, nil).Once()
+		mockRepo.On("RecordWebhookEventFailure", mock.Anything, uint(7), mock.Anything, mock.Anything, true).Return(nil).Once()
+
+		client := webhook.NewClient(server.URL, testWebhookSecret, time.Second, logger)
+		d := NewWebhookDispatcher(newWebhookTestDB(t), mockRepo, client, time.Minute, 50, 5, time.Minute, time.Hour, logger)
+
+		assert.True(t, d.Dispatch(context.Background()))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookDispatcher_Backoff(t *testing.T) {
+	d := &WebhookDispatcher{RetryBackoff: 10 * time.Second, MaxRetryBackoff: time.Minute}
+
+	assert.Equal(t, 10*time.Second, d.backoff(1))
+	assert.Equal(t, 20*time.Second, d.backoff(2))
+	assert.Equal(t, 40*time.Second, d.backoff(3))
+	assert.Equal(t, time.Minute, d.backoff(4))
+	assert.Equal(t, time.Minute, d.backoff(100))
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	appContext "order-service/internal/context"
+	"order-service/internal/entity"
+	"order-service/internal/gateway/product"
+	"order-service/internal/gateway/warehouse"
+	"order-service/internal/model"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CartUseCaseInterface interface {
+	ValidateCart(ctx context.Context, request *model.ValidateCartRequest) (*model.CartValidationResponse, error)
+}
+
+type CartUseCase struct {
+	Log              *logrus.Logger
+	Validate         *validator.Validate
+	ProductGateway   product.ProductPriceGatewayInterface
+	WarehouseGateway warehouse.WarehouseGatewayInterface
+	PriceTolerance   entity.Money
+	Timeouts         appContext.Timeouts
+}
+
+func NewCartUseCase(
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	productGateway product.ProductPriceGatewayInterface,
+	warehouseGateway warehouse.WarehouseGatewayInterface,
+	priceTolerance entity.Money,
+	timeouts appContext.Timeouts,
+) CartUseCaseInterface {
+	return &CartUseCase{
+		Log:              logger,
+		Validate:         validate,
+		ProductGateway:   productGateway,
+		WarehouseGateway: warehouseGateway,
+		PriceTolerance:   priceTolerance,
+		Timeouts:         timeouts,
+	}
+}
+
+// ValidateCart checks that every cart item refers to an active product at its current price
+// with enough stock in the chosen warehouse. Products and stock are each fetched in one batched
+// call and nothing is reserved or created.
+func (c *CartUseCase) ValidateCart(ctx context.Context, request *model.ValidateCartRequest) (*model.CartValidationResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	productIDs := make([]uint, 0, len(request.Items))
+	seen := make(map[uint]bool, len(request.Items))
+	queries := make([]warehouse.InventoryQuery, len(request.Items))
+	for i, item := range request.Items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+		queries[i] = warehouse.InventoryQuery{
+			ProductID:   item.ProductID,
+			WarehouseID: item.WarehouseID,
+		}
+	}
+
+	// Follow the caller's context so an abandoned request stops the lookups
+	lookupCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer cancel()
+
+	products, err := c.ProductGateway.GetProducts(lookupCtx, productIDs)
+	if err != nil {
+		c.Log.Warnf("Failed to get products: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	inventories, err := c.WarehouseGateway.GetInventoryBatch(lookupCtx, queries)
+	if err != nil {
+		c.Log.Warnf("Failed to get inventory batch: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Index stock by product and warehouse rather than relying on the batch key format
+	available := make(map[warehouse.InventoryQuery]int, len(inventories))
+	for _, inventory := range inventories {
+		if inventory == nil {
+			continue
+		}
+		key := warehouse.InventoryQuery{ProductID: inventory.ProductID, WarehouseID: inventory.WarehouseID}
+		available[key] = inventory.AvailableQuantity()
+	}
+
+	response := &model.CartValidationResponse{
+		Valid: true,
+		Items: make([]model.CartItemValidation, len(request.Items)),
+	}
+
+	for i, item := range request.Items {
+		result := model.CartItemValidation{
+			ProductID:         item.ProductID,
+			WarehouseID:       item.WarehouseID,
+			Quantity:          item.Quantity,
+			UnitPrice:         item.UnitPrice,
+			CurrentPrice:      item.UnitPrice,
+			AvailableQuantity: available[queries[i]],
+		}
+
+		if p, ok := products[item.ProductID]; !ok {
+			result.Issues = append(result.Issues, model.CartIssueProductNotFound)
+		} else {
+			result.CurrentPrice = p.Price
+			if !p.IsActive() {
+				result.Issues = append(result.Issues, model.CartIssueProductInactive)
+			}
+			if (item.UnitPrice - p.Price).Abs() > c.PriceTolerance {
+				result.Issues = append(result.Issues, model.CartIssuePriceChanged)
+			}
+		}
+
+		if result.AvailableQuantity < item.Quantity {
+			result.Issues = append(result.Issues, model.CartIssueInsufficientStock)
+		}
+
+		result.Valid = len(result.Issues) == 0
+		if !result.Valid {
+			response.Valid = false
+		}
+
+		// The total reflects what the order would cost at current prices
+		response.TotalAmount += result.CurrentPrice.Mul(item.Quantity)
+		response.Items[i] = result
+	}
+
+	return response, nil
+}
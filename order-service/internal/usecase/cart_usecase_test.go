@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	appContext "order-service/internal/context"
+	"order-service/internal/entity"
+	"order-service/internal/gateway/product"
+	"order-service/internal/gateway/warehouse"
+	"order-service/internal/model"
+	product_mock "order-service/mocks/gateway/product"
+	warehouse_mock "order-service/mocks/gateway/warehouse"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCartUseCase_ValidateCart(t *testing.T) {
+	logger := logrus.New()
+	validate := validator.New()
+
+	request := &model.ValidateCartRequest{
+		Items: []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+			{ProductID: 2, WarehouseID: 1, Quantity: 1, UnitPrice: 2500},
+			{ProductID: 3, WarehouseID: 2, Quantity: 5, UnitPrice: 400},
+		},
+	}
+
+	queries := []warehouse.InventoryQuery{
+		{ProductID: 1, WarehouseID: 1},
+		{ProductID: 2, WarehouseID: 1},
+		{ProductID: 3, WarehouseID: 2},
+	}
+
+	t.Run("FullyValidCart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockProductGateway := product_mock.NewMockProductPriceGatewayInterface(ctrl)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		// Each lookup is made once for the whole cart
+		mockProductGateway.EXPECT().
+			GetProducts(gomock.Any(), []uint{1, 2, 3}).
+			Return(map[uint]*product.ProductResponse{
+				1: {ID: "1", Price: 1000, Status: product.ProductStatusActive},
+				2: {ID: "2", Price: 2500, Status: product.ProductStatusActive},
+				3: {ID: "3", Price: 400, Status: product.ProductStatusActive},
+			}, nil)
+		mockWarehouseGateway.EXPECT().
+			GetInventoryBatch(gomock.Any(), queries).
+			Return(map[string]*warehouse.InventoryResponse{
+				"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 10, ReservedQuantity: 2},
+				"2-1": {ProductID: 2, WarehouseID: 1, Quantity: 1},
+				"3-2": {ProductID: 3, WarehouseID: 2, Quantity: 5},
+			}, nil)
+
+		cartUseCase := NewCartUseCase(logger, validate, mockProductGateway, mockWarehouseGateway, 1, appContext.DefaultTimeouts())
+
+		response, err := cartUseCase.ValidateCart(context.Background(), request)
+
+		assert.NoError(t, err)
+		assert.True(t, response.Valid)
+		assert.Equal(t, entity.Money(6500), response.TotalAmount)
+		assert.Len(t, response.Items, 3)
+		for _, item := range response.Items {
+			assert.True(t, item.Valid)
+			assert.Empty(t, item.Issues)
+		}
+		assert.Equal(t, 8, response.Items[0].AvailableQuantity)
+	})
+
+	t.Run("DiscontinuedAndOutOfStock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockProductGateway := product_mock.NewMockProductPriceGatewayInterface(ctrl)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		mockProductGateway.EXPECT().
+			GetProducts(gomock.Any(), []uint{1, 2, 3}).
+			Return(map[uint]*product.ProductResponse{
+				1: {ID: "1", Price: 1000, Status: product.ProductStatusActive},
+				2: {ID: "2", Price: 2500, Status: "discontinued"},
+				3: {ID: "3", Price: 400, Status: product.ProductStatusActive},
+			}, nil)
+		mockWarehouseGateway.EXPECT().
+			GetInventoryBatch(gomock.Any(), queries).
+			Return(map[string]*warehouse.InventoryResponse{
+				"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 10},
+				"2-1": {ProductID: 2, WarehouseID: 1, Quantity: 3},
+				"3-2": {ProductID: 3, WarehouseID: 2, Quantity: 5, ReservedQuantity: 3},
+			}, nil)
+
+		cartUseCase := NewCartUseCase(logger, validate, mockProductGateway, mockWarehouseGateway, 1, appContext.DefaultTimeouts())
+
+		response, err := cartUseCase.ValidateCart(context.Background(), request)
+
+		assert.NoError(t, err)
+		assert.False(t, response.Valid)
+		assert.Equal(t, entity.Money(6500), response.TotalAmount)
+
+		assert.True(t, response.Items[0].Valid)
+
+		assert.False(t, response.Items[1].Valid)
+		assert.Equal(t, []string{model.CartIssueProductInactive}, response.Items[1].Issues)
+
+		assert.False(t, response.Items[2].Valid)
+		assert.Equal(t, []string{model.CartIssueInsufficientStock}, response.Items[2].Issues)
+		assert.Equal(t, 2, response.Items[2].AvailableQuantity)
+	})
+
+	t.Run("EmptyCart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockProductGateway := product_mock.NewMockProductPriceGatewayInterface(ctrl)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		cartUseCase := NewCartUseCase(logger, validate, mockProductGateway, mockWarehouseGateway, 1, appContext.DefaultTimeouts())
+
+		response, err := cartUseCase.ValidateCart(context.Background(), &model.ValidateCartRequest{})
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, response)
+	})
+}
@@ -8,14 +8,17 @@ import (
 
 // InventoryUseCaseInterface defines the contract for inventory business logic
 type InventoryUseCaseInterface interface {
-	// CheckAndReserveStock checks and reserves stock for multiple items in a transaction
-	CheckAndReserveStock(ctx context.Context, items []model.OrderItemRequest) error
+	// CheckAndReserveStock checks and reserves stock for multiple items in a transaction under the given reference
+	CheckAndReserveStock(ctx context.Context, reference string, items []model.OrderItemRequest) error
 
-	// ConfirmStockDeduction commits reserved stock as sold (after payment)
-	ConfirmStockDeduction(ctx context.Context, orderItems []entity.OrderItem) error
+	// CheckAvailability reports whether the items are in stock without reserving anything
+	CheckAvailability(ctx context.Context, items []model.OrderItemRequest) (*model.AvailabilityResponse, error)
 
-	// ReleaseReservation releases stock back to available inventory (e.g., cancelled order)
-	ReleaseReservation(ctx context.Context, orderItems []entity.OrderItem) error
+	// ConfirmStockDeduction commits the stock reserved under the reference as sold (after payment)
+	ConfirmStockDeduction(ctx context.Context, reference string, orderItems []entity.OrderItem) error
+
+	// ReleaseReservation releases the stock reserved under the reference back to available inventory (e.g., cancelled order)
+	ReleaseReservation(ctx context.Context, reference string, orderItems []entity.OrderItem) error
 
 	// GetInventory gets current inventory level for a product
 	GetInventory(ctx context.Context, productID, warehouseID uint) (*entity.Inventory, error)
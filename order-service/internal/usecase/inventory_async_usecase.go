@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	appContext "order-service/internal/context"
 	"order-service/internal/entity"
+	"order-service/internal/gateway/warehouse"
 	"order-service/internal/messaging"
 	"order-service/internal/model"
 	"order-service/internal/repository"
@@ -23,10 +25,9 @@ type InventoryAsyncUseCase struct {
 	InventoryProducer   *messaging.InventoryProducer
 	OrderRepository     repository.OrderRepositoryInterface
 	ReservationRepository repository.ReservationRepositoryInterface
-	
-	// For tracking correlations between requests and responses
-	reservations     map[uint]string // Map of order IDs to reservation IDs
-	reservationMutex sync.RWMutex
+	// WarehouseGateway serves read-only stock lookups, which are not sent over messaging
+	WarehouseGateway    warehouse.WarehouseGatewayInterface
+	Timeouts            appContext.Timeouts
 	
 	// Channel for response notifications
 	responses        map[string]chan bool // Map of correlation IDs to response channels
@@ -40,6 +41,8 @@ func NewInventoryAsyncUseCase(
 	producer *messaging.InventoryProducer,
 	orderRepository repository.OrderRepositoryInterface,
 	reservationRepository repository.ReservationRepositoryInterface,
+	warehouseGateway warehouse.WarehouseGatewayInterface,
+	timeouts appContext.Timeouts,
 ) *InventoryAsyncUseCase {
 	return &InventoryAsyncUseCase{
 		DB:                  db,
@@ -47,13 +50,14 @@ func NewInventoryAsyncUseCase(
 		InventoryProducer:   producer,
 		OrderRepository:     orderRepository,
 		ReservationRepository: reservationRepository,
-		reservations:        make(map[uint]string),
+		WarehouseGateway:    warehouseGateway,
+		Timeouts:            timeouts,
 		responses:           make(map[string]chan bool),
 	}
 }
 
-// CheckAndReserveStock checks and reserves stock for multiple items asynchronously
-func (uc *InventoryAsyncUseCase) CheckAndReserveStock(ctx context.Context, items []model.OrderItemRequest) error {
+// CheckAndReserveStock checks and reserves stock for multiple items asynchronously under the given reference
+func (uc *InventoryAsyncUseCase) CheckAndReserveStock(ctx context.Context, reference string, items []model.OrderItemRequest) error {
 	// Extract order ID if available
 	var orderID uint
 	for _, item := range items {
@@ -65,7 +69,7 @@ func (uc *InventoryAsyncUseCase) CheckAndReserveStock(ctx context.Context, items
 	
 	// Create a response channel and correlationID
 	responseChan := make(chan bool, 1)
-	correlationID, err := uc.InventoryProducer.PublishReserveStock(ctx, orderID, items)
+	correlationID, err := uc.InventoryProducer.PublishReserveStock(ctx, orderID, reference, items)
 	if err != nil {
 		return fmt.Errorf("failed to publish reserve stock message: %w", err)
 	}
@@ -84,7 +88,7 @@ func (uc *InventoryAsyncUseCase) CheckAndReserveStock(ctx context.Context, items
 	select {
 	case success := <-responseChan:
 		if !success {
-			return entity.ErrInsufficientStock
+			return insufficientStockError(ctx, uc.Log, uc.WarehouseGateway, uc.Timeouts, items)
 		}
 		return nil
 	case <-timer.C:
@@ -102,26 +106,22 @@ func (uc *InventoryAsyncUseCase) CheckAndReserveStock(ctx context.Context, items
 	}
 }
 
-// ConfirmStockDeduction commits reserved stock as sold (after payment)
-func (uc *InventoryAsyncUseCase) ConfirmStockDeduction(ctx context.Context, orderItems []entity.OrderItem) error {
+// CheckAvailability reports whether the items are in stock without reserving anything. The check is
+// a plain read, so it queries the warehouse service directly instead of going through messaging.
+func (uc *InventoryAsyncUseCase) CheckAvailability(ctx context.Context, items []model.OrderItemRequest) (*model.AvailabilityResponse, error) {
+	return checkAvailability(ctx, uc.Log, uc.WarehouseGateway, uc.Timeouts, items)
+}
+
+// ConfirmStockDeduction commits the stock reserved under the reference as sold (after payment)
+func (uc *InventoryAsyncUseCase) ConfirmStockDeduction(ctx context.Context, reference string, orderItems []entity.OrderItem) error {
 	if len(orderItems) == 0 {
 		return errors.New("no order items provided")
 	}
 	
 	orderID := orderItems[0].OrderID
 	
-	// Get the reservation ID
-	uc.reservationMutex.RLock()
-	reservationID, exists := uc.reservations[orderID]
-	uc.reservationMutex.RUnlock()
-	
-	if !exists {
-		// Use a default format if no reservation ID is found
-		reservationID = fmt.Sprintf("res_%d", orderID)
-	}
-	
 	// Publish the confirmation message
-	err := uc.InventoryProducer.PublishConfirmStock(ctx, orderID, reservationID)
+	err := uc.InventoryProducer.PublishConfirmStock(ctx, orderID, reference)
 	if err != nil {
 		return fmt.Errorf("failed to publish confirm stock message: %w", err)
 	}
@@ -132,23 +132,14 @@ func (uc *InventoryAsyncUseCase) ConfirmStockDeduction(ctx context.Context, orde
 	return nil
 }
 
-// ReleaseReservation releases stock back to available inventory (e.g., cancelled order)
-func (uc *InventoryAsyncUseCase) ReleaseReservation(ctx context.Context, orderItems []entity.OrderItem) error {
+// ReleaseReservation releases the stock reserved under the reference back to available inventory (e.g., cancelled order)
+func (uc *InventoryAsyncUseCase) ReleaseReservation(ctx context.Context, reference string, orderItems []entity.OrderItem) error {
 	if len(orderItems) == 0 {
 		return errors.New("no order items provided")
 	}
 	
 	orderID := orderItems[0].OrderID
-	
-	// Get the reservation ID
-	uc.reservationMutex.RLock()
-	reservationID, exists := uc.reservations[orderID]
-	uc.reservationMutex.RUnlock()
-	
-	if !exists {
-		// Use a default format if no reservation ID is found
-		reservationID = fmt.Sprintf("res_%d", orderID)
-	}
+	reservationID := reference
 	
 	// Get order item details
 	orderItem := orderItems[0]
@@ -177,11 +168,6 @@ func (uc *InventoryAsyncUseCase) ReleaseReservation(ctx context.Context, orderIt
 		return fmt.Errorf("failed to publish release stock message: %w", err)
 	}
 	
-	// Clean up the reservation
-	uc.reservationMutex.Lock()
-	delete(uc.reservations, orderID)
-	uc.reservationMutex.Unlock()
-	
 	return nil
 }
 
@@ -203,13 +189,6 @@ func (uc *InventoryAsyncUseCase) HandleReservationResponse(ctx context.Context,
 		correlationID, _ = ctxVal.(string)
 	}
 	
-	// Store the reservation ID for the order if successful
-	if message.Success && message.ReservationID != "" {
-		uc.reservationMutex.Lock()
-		uc.reservations[message.OrderID] = message.ReservationID
-		uc.reservationMutex.Unlock()
-	}
-	
 	// Find and notify the waiting response channel
 	if correlationID != "" {
 		uc.responsesMutex.RLock()
@@ -3,11 +3,12 @@ package usecase
 import (
 	"context"
 	"fmt"
+	appContext "order-service/internal/context"
 	"order-service/internal/entity"
 	"order-service/internal/gateway/warehouse"
 	"order-service/internal/model"
-	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -17,6 +18,7 @@ type InventoryWarehouseUseCase struct {
 	DB               *gorm.DB
 	Log              *logrus.Logger
 	WarehouseGateway warehouse.WarehouseGatewayInterface
+	Timeouts         appContext.Timeouts
 }
 
 // NewInventoryWarehouseUseCase creates a new InventoryWarehouseUseCase instance
@@ -25,19 +27,20 @@ func NewInventoryWarehouseUseCase(
 	log *logrus.Logger,
 	_ interface{}, // Kept for compatibility but not used
 	warehouseGateway warehouse.WarehouseGatewayInterface,
+	timeouts appContext.Timeouts,
 ) *InventoryWarehouseUseCase {
 	return &InventoryWarehouseUseCase{
 		DB:               db,
 		Log:              log,
 		WarehouseGateway: warehouseGateway,
+		Timeouts:         timeouts,
 	}
 }
 
-// CheckAndReserveStock checks and reserves stock for multiple items
-func (uc *InventoryWarehouseUseCase) CheckAndReserveStock(ctx context.Context, items []model.OrderItemRequest) error {
-	// Create a new context with a longer timeout for database operations
-	// This ensures that the database transaction can complete even if the original context deadline is close
-	dbCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// CheckAndReserveStock checks and reserves stock for multiple items under the given reference
+func (uc *InventoryWarehouseUseCase) CheckAndReserveStock(ctx context.Context, reference string, items []model.OrderItemRequest) error {
+	// Bound the transaction by the caller's context so an abandoned request stops it
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, uc.Timeouts, appContext.OperationCommit)
 	defer cancel()
 	
 	// Start a transaction to store our local reservation data
@@ -61,23 +64,23 @@ func (uc *InventoryWarehouseUseCase) CheckAndReserveStock(ctx context.Context, i
 		}
 	}
 
-	// Create a context with a timeout for the warehouse service call
-	// Using a separate context with a longer timeout that's not tied to the original request
-	warehouseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Bound the warehouse service call by the caller's context
+	warehouseCtx, warehouseCancel := appContext.WithOperationTimeout(ctx, uc.Timeouts, appContext.OperationInventory)
+	defer warehouseCancel()
 	
 	// Log the warehouse service call for debugging
 	uc.Log.WithFields(logrus.Fields{
-		"orderID": orderID,
-		"items":   items,
+		"orderID":   orderID,
+		"reference": reference,
+		"items":     items,
 	}).Info("Calling warehouse service to reserve stock")
 	
 	// Call warehouse service to reserve stock
-	reservationResp, err := uc.WarehouseGateway.CheckAndReserveStock(warehouseCtx, orderID, items, "")
+	reservationResp, err := uc.WarehouseGateway.CheckAndReserveStock(warehouseCtx, orderID, reference, items, "")
 	if err != nil {
 		tx.Rollback()
 		if err == warehouse.ErrInsufficientStock {
-			return entity.ErrInsufficientStock
+			return insufficientStockError(ctx, uc.Log, uc.WarehouseGateway, uc.Timeouts, items)
 		}
 		// Improve error logging to help diagnose the issue
 		uc.Log.WithError(err).WithFields(logrus.Fields{
@@ -90,7 +93,7 @@ func (uc *InventoryWarehouseUseCase) CheckAndReserveStock(ctx context.Context, i
 	for _, item := range reservationResp.Items {
 		if !item.Available {
 			tx.Rollback()
-			return entity.ErrInsufficientStock
+			return insufficientStockError(ctx, uc.Log, uc.WarehouseGateway, uc.Timeouts, items)
 		}
 	}
 
@@ -102,91 +105,204 @@ func (uc *InventoryWarehouseUseCase) CheckAndReserveStock(ctx context.Context, i
 	return nil
 }
 
-// ConfirmStockDeduction commits reserved stock as sold (after payment)
-func (uc *InventoryWarehouseUseCase) ConfirmStockDeduction(ctx context.Context, orderItems []entity.OrderItem) error {
-	// Get order ID from the first item
+// CheckAvailability reports whether the items are in stock without reserving anything
+func (uc *InventoryWarehouseUseCase) CheckAvailability(ctx context.Context, items []model.OrderItemRequest) (*model.AvailabilityResponse, error) {
+	return checkAvailability(ctx, uc.Log, uc.WarehouseGateway, uc.Timeouts, items)
+}
+
+// checkAvailability looks up the stock for all items in one read-only batch call. Items for the same
+// product and warehouse draw on the same stock, so each is checked against their combined quantity.
+func checkAvailability(ctx context.Context, log *logrus.Logger, warehouseGateway warehouse.WarehouseGatewayInterface, timeouts appContext.Timeouts, items []model.OrderItemRequest) (*model.AvailabilityResponse, error) {
+	if len(items) == 0 {
+		return nil, fiber.ErrBadRequest
+	}
+
+	queries := make([]warehouse.InventoryQuery, len(items))
+	requested := make(map[warehouse.InventoryQuery]int, len(items))
+	for i, item := range items {
+		if item.ProductID == 0 || item.WarehouseID == 0 || item.Quantity <= 0 {
+			return nil, fiber.ErrBadRequest
+		}
+		queries[i] = warehouse.InventoryQuery{
+			ProductID:   item.ProductID,
+			WarehouseID: item.WarehouseID,
+		}
+		requested[queries[i]] += item.Quantity
+	}
+
+	// Follow the caller's context so an abandoned request stops the lookup
+	warehouseCtx, cancel := appContext.WithOperationTimeout(ctx, timeouts, appContext.OperationRead)
+	defer cancel()
+
+	inventories, err := warehouseGateway.GetInventoryBatch(warehouseCtx, queries)
+	if err != nil {
+		log.WithError(err).WithField("items", len(items)).Error("Failed to check stock availability in warehouse")
+		return nil, fmt.Errorf("failed to check stock availability in warehouse: %w", err)
+	}
+
+	// Index stock by product and warehouse rather than relying on the batch key format
+	stock := make(map[warehouse.InventoryQuery]*warehouse.InventoryResponse, len(inventories))
+	for _, inventory := range inventories {
+		if inventory == nil {
+			continue
+		}
+		stock[warehouse.InventoryQuery{ProductID: inventory.ProductID, WarehouseID: inventory.WarehouseID}] = inventory
+	}
+
+	response := &model.AvailabilityResponse{
+		CanFulfil: true,
+		Items:     make([]model.AvailabilityItem, len(items)),
+	}
+	for i, item := range items {
+		result := model.AvailabilityItem{
+			ProductID:   item.ProductID,
+			WarehouseID: item.WarehouseID,
+			Quantity:    item.Quantity,
+		}
+
+		if inventory, ok := stock[queries[i]]; !ok {
+			result.Message = "product is not stocked in this warehouse"
+		} else {
+			result.AvailableQuantity = max(inventory.AvailableQuantity(), 0)
+			result.Available = requested[queries[i]] <= result.AvailableQuantity
+			if !result.Available {
+				result.Message = "insufficient stock"
+			}
+		}
+
+		if !result.Available {
+			response.CanFulfil = false
+		}
+		response.Items[i] = result
+	}
+
+	return response, nil
+}
+
+// insufficientStockError reads the current stock to report which items could not be reserved and by
+// how much. If the lookup fails the error names no items, but it still matches entity.ErrInsufficientStock.
+func insufficientStockError(ctx context.Context, log *logrus.Logger, warehouseGateway warehouse.WarehouseGatewayInterface, timeouts appContext.Timeouts, items []model.OrderItemRequest) error {
+	stockErr := &entity.InsufficientStockError{}
+
+	availability, err := checkAvailability(ctx, log, warehouseGateway, timeouts, items)
+	if err != nil {
+		log.WithError(err).Warn("Failed to look up which items are short of stock")
+		return stockErr
+	}
+
+	requested := make(map[warehouse.InventoryQuery]int, len(items))
+	for _, item := range items {
+		requested[warehouse.InventoryQuery{ProductID: item.ProductID, WarehouseID: item.WarehouseID}] += item.Quantity
+	}
+
+	// Report each short product and warehouse once, with the quantity requested across all its items
+	reported := make(map[warehouse.InventoryQuery]bool, len(availability.Items))
+	for _, item := range availability.Items {
+		key := warehouse.InventoryQuery{ProductID: item.ProductID, WarehouseID: item.WarehouseID}
+		if item.Available || reported[key] {
+			continue
+		}
+		reported[key] = true
+		stockErr.Items = append(stockErr.Items, entity.StockShortage{
+			ProductID:   item.ProductID,
+			WarehouseID: item.WarehouseID,
+			Requested:   requested[key],
+			Available:   item.AvailableQuantity,
+		})
+	}
+
+	return stockErr
+}
+
+// ConfirmStockDeduction commits the stock reserved under the reference as sold (after payment), one item at a
+// time. Items committed before a failure are skipped by the warehouse when the deduction is retried.
+func (uc *InventoryWarehouseUseCase) ConfirmStockDeduction(ctx context.Context, reference string, orderItems []entity.OrderItem) error {
 	if len(orderItems) == 0 {
 		return fmt.Errorf("no order items provided")
 	}
 
 	orderID := orderItems[0].OrderID
 
-	// Get reservation ID for the order
-	// In a real implementation, we would store the reservation ID from the warehouse service
-	// For now, we'll just use the order ID as a placeholder
-	reservationID := fmt.Sprintf("res_%d", orderID)
-
-	// Create a context with a timeout for the warehouse service call
-	warehouseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Bound the warehouse service calls by the caller's context
+	warehouseCtx, cancel := appContext.WithOperationTimeout(ctx, uc.Timeouts, appContext.OperationInventory)
 	defer cancel()
 	
 	// Log the warehouse service call for debugging
 	uc.Log.WithFields(logrus.Fields{
-		"orderID":       orderID,
-		"reservationID": reservationID,
+		"orderID":   orderID,
+		"reference": reference,
 	}).Info("Calling warehouse service to confirm stock deduction")
 	
-	// Call warehouse service to confirm stock deduction
-	_, err := uc.WarehouseGateway.ConfirmStockDeduction(warehouseCtx, orderID, reservationID)
-	if err != nil {
-		uc.Log.WithError(err).WithFields(logrus.Fields{
-			"orderID":       orderID,
-			"reservationID": reservationID,
-		}).Error("Failed to confirm stock deduction in warehouse")
-		return fmt.Errorf("failed to confirm stock deduction in warehouse: %w", err)
+	for _, item := range orderItems {
+		commitRequest := warehouse.ReservationCommitRequest{
+			WarehouseID: item.WarehouseID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Reference:   reference,
+		}
+
+		// Call warehouse service to confirm stock deduction
+		_, err := uc.WarehouseGateway.ConfirmStockDeduction(warehouseCtx, orderID, commitRequest)
+		if err != nil {
+			uc.Log.WithError(err).WithFields(logrus.Fields{
+				"orderID":     orderID,
+				"warehouseID": commitRequest.WarehouseID,
+				"productID":   commitRequest.ProductID,
+				"quantity":    commitRequest.Quantity,
+				"reference":   reference,
+			}).Error("Failed to confirm stock deduction in warehouse")
+			return fmt.Errorf("failed to confirm stock deduction in warehouse: %w", err)
+		}
 	}
 
 	return nil
 }
 
-// ReleaseReservation releases stock back to available inventory (e.g., cancelled order)
-func (uc *InventoryWarehouseUseCase) ReleaseReservation(ctx context.Context, orderItems []entity.OrderItem) error {
-	// Get order ID from the first item
+// ReleaseReservation releases the stock reserved under the reference back to available inventory
+// (e.g., cancelled order), one item at a time
+func (uc *InventoryWarehouseUseCase) ReleaseReservation(ctx context.Context, reference string, orderItems []entity.OrderItem) error {
 	if len(orderItems) == 0 {
 		return fmt.Errorf("no order items provided")
 	}
 
 	orderID := orderItems[0].OrderID
 
-	// Get reservation ID for the order
-	// In a real implementation, we would store the reservation ID from the warehouse service
-	// For now, we'll just use the order ID as a placeholder
-	reservationID := fmt.Sprintf("res_%d", orderID)
-
-	// Create a context with a timeout for the warehouse service call
-	warehouseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Bound the warehouse service calls by the caller's context
+	warehouseCtx, cancel := appContext.WithOperationTimeout(ctx, uc.Timeouts, appContext.OperationInventory)
 	defer cancel()
 	
 	// Log the warehouse service call for debugging
 	uc.Log.WithFields(logrus.Fields{
-		"orderID":       orderID,
-		"reservationID": reservationID,
+		"orderID":   orderID,
+		"reference": reference,
 	}).Info("Calling warehouse service to release reservation")
 	
-	// Create the request with order item details
-	releaseRequest := warehouse.ReservationReleaseRequest{
-		WarehouseID: orderItems[0].WarehouseID,
-		ProductID:   orderItems[0].ProductID,
-		Quantity:    orderItems[0].Quantity,
-		Reference:   reservationID,
-	}
-	
-	// Call warehouse service to release reservation
-	_, err := uc.WarehouseGateway.ReleaseReservation(warehouseCtx, orderID, releaseRequest)
-	if err != nil {
-		if err == warehouse.ErrReservationNotFound {
-			// If the reservation doesn't exist, consider it already released
-			uc.Log.Warnf("Reservation not found for order %d, considering it already released", orderID)
-			return nil
+	for _, item := range orderItems {
+		// Create the request with order item details
+		releaseRequest := warehouse.ReservationReleaseRequest{
+			WarehouseID: item.WarehouseID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Reference:   reference,
+		}
+		
+		// Call warehouse service to release reservation
+		_, err := uc.WarehouseGateway.ReleaseReservation(warehouseCtx, orderID, releaseRequest)
+		if err != nil {
+			if err == warehouse.ErrReservationNotFound {
+				// If the reservation doesn't exist, consider it already released
+				uc.Log.Warnf("Reservation %s not found for product %d, considering it already released", reference, item.ProductID)
+				continue
+			}
+			uc.Log.WithError(err).WithFields(logrus.Fields{
+				"orderID":     orderID,
+				"warehouseID": releaseRequest.WarehouseID,
+				"productID":   releaseRequest.ProductID,
+				"quantity":    releaseRequest.Quantity,
+				"reference":   releaseRequest.Reference,
+			}).Error("Failed to release reservation in warehouse")
+			return fmt.Errorf("failed to release reservation in warehouse: %w", err)
 		}
-		uc.Log.WithError(err).WithFields(logrus.Fields{
-			"orderID":     orderID,
-			"warehouseID": releaseRequest.WarehouseID,
-			"productID":   releaseRequest.ProductID,
-			"quantity":    releaseRequest.Quantity,
-			"reference":   releaseRequest.Reference,
-		}).Error("Failed to release reservation in warehouse")
-		return fmt.Errorf("failed to release reservation in warehouse: %w", err)
 	}
 
 	return nil
@@ -194,8 +310,8 @@ func (uc *InventoryWarehouseUseCase) ReleaseReservation(ctx context.Context, ord
 
 // GetInventory gets current inventory level for a product
 func (uc *InventoryWarehouseUseCase) GetInventory(ctx context.Context, productID, warehouseID uint) (*entity.Inventory, error) {
-	// Create a context with a timeout for the warehouse service call
-	warehouseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Bound the warehouse service call by the caller's context
+	warehouseCtx, cancel := appContext.WithOperationTimeout(ctx, uc.Timeouts, appContext.OperationRead)
 	defer cancel()
 	
 	// Log the warehouse service call for debugging
@@ -229,8 +345,8 @@ func (uc *InventoryWarehouseUseCase) GetInventory(ctx context.Context, productID
 
 // UpdateInventory updates inventory quantity
 func (uc *InventoryWarehouseUseCase) UpdateInventory(ctx context.Context, inventory *entity.Inventory) error {
-	// Create a context with a timeout for the warehouse service call
-	warehouseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Bound the warehouse service call by the caller's context
+	warehouseCtx, cancel := appContext.WithOperationTimeout(ctx, uc.Timeouts, appContext.OperationInventory)
 	defer cancel()
 	
 	// Log the warehouse service call for debugging
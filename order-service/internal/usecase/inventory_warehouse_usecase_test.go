@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	appContext "order-service/internal/context"
+	"order-service/internal/entity"
+	"order-service/internal/gateway/warehouse"
+	"order-service/internal/model"
+	warehouse_mock "order-service/mocks/gateway/warehouse"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestInventoryWarehouseUseCase_CheckAvailability(t *testing.T) {
+	logger := logrus.New()
+
+	items := []model.OrderItemRequest{
+		{ProductID: 1, WarehouseID: 1, Quantity: 2},
+		{ProductID: 2, WarehouseID: 1, Quantity: 3},
+	}
+	queries := []warehouse.InventoryQuery{
+		{ProductID: 1, WarehouseID: 1},
+		{ProductID: 2, WarehouseID: 1},
+	}
+
+	t.Run("FullyAvailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		// Only the batch lookup is made; nothing is reserved
+		mockWarehouseGateway.EXPECT().
+			GetInventoryBatch(gomock.Any(), queries).
+			Return(map[string]*warehouse.InventoryResponse{
+				"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 10, ReservedQuantity: 4},
+				"2-1": {ProductID: 2, WarehouseID: 1, Quantity: 3},
+			}, nil)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logger, nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		availability, err := inventoryUseCase.CheckAvailability(context.Background(), items)
+
+		assert.NoError(t, err)
+		assert.True(t, availability.CanFulfil)
+		assert.Equal(t, []model.AvailabilityItem{
+			{ProductID: 1, WarehouseID: 1, Quantity: 2, AvailableQuantity: 6, Available: true},
+			{ProductID: 2, WarehouseID: 1, Quantity: 3, AvailableQuantity: 3, Available: true},
+		}, availability.Items)
+	})
+
+	t.Run("PartiallyAvailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		mockWarehouseGateway.EXPECT().
+			GetInventoryBatch(gomock.Any(), queries).
+			Return(map[string]*warehouse.InventoryResponse{
+				"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 10},
+				"2-1": {ProductID: 2, WarehouseID: 1, Quantity: 5, ReservedQuantity: 4},
+			}, nil)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logger, nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		availability, err := inventoryUseCase.CheckAvailability(context.Background(), items)
+
+		assert.NoError(t, err)
+		assert.False(t, availability.CanFulfil)
+		assert.True(t, availability.Items[0].Available)
+		assert.False(t, availability.Items[1].Available)
+		assert.Equal(t, 1, availability.Items[1].AvailableQuantity)
+		assert.Equal(t, "insufficient stock", availability.Items[1].Message)
+	})
+
+	t.Run("RepeatedItemsShareStock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		// Each line fits on its own, but together they need 6 of the 5 in stock
+		repeated := []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1, Quantity: 3},
+			{ProductID: 1, WarehouseID: 1, Quantity: 3},
+		}
+		mockWarehouseGateway.EXPECT().
+			GetInventoryBatch(gomock.Any(), []warehouse.InventoryQuery{queries[0], queries[0]}).
+			Return(map[string]*warehouse.InventoryResponse{
+				"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 5},
+			}, nil)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logger, nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		availability, err := inventoryUseCase.CheckAvailability(context.Background(), repeated)
+
+		assert.NoError(t, err)
+		assert.False(t, availability.CanFulfil)
+	})
+
+	t.Run("UnknownProduct", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		// The warehouse has no stock record for product 2
+		mockWarehouseGateway.EXPECT().
+			GetInventoryBatch(gomock.Any(), queries).
+			Return(map[string]*warehouse.InventoryResponse{
+				"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 10},
+			}, nil)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logger, nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		availability, err := inventoryUseCase.CheckAvailability(context.Background(), items)
+
+		assert.NoError(t, err)
+		assert.False(t, availability.CanFulfil)
+		assert.True(t, availability.Items[0].Available)
+		assert.Equal(t, model.AvailabilityItem{
+			ProductID:   2,
+			WarehouseID: 1,
+			Quantity:    3,
+			Message:     "product is not stocked in this warehouse",
+		}, availability.Items[1])
+	})
+
+	t.Run("InvalidItem", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logger, nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		availability, err := inventoryUseCase.CheckAvailability(context.Background(), []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1, Quantity: 0},
+		})
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, availability)
+	})
+}
+
+func TestInventoryWarehouseUseCase_CheckAndReserveStock_ReportsShortItems(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+	items := []model.OrderItemRequest{
+		{ProductID: 1, WarehouseID: 1, Quantity: 2},
+		{ProductID: 2, WarehouseID: 3, Quantity: 5},
+	}
+
+	// The reservation is refused, then current stock is read to find the short item
+	mockWarehouseGateway.EXPECT().
+		CheckAndReserveStock(gomock.Any(), uint(0), "ord-1", items, "").
+		Return(nil, warehouse.ErrInsufficientStock)
+	mockWarehouseGateway.EXPECT().
+		GetInventoryBatch(gomock.Any(), []warehouse.InventoryQuery{
+			{ProductID: 1, WarehouseID: 1},
+			{ProductID: 2, WarehouseID: 3},
+		}).
+		Return(map[string]*warehouse.InventoryResponse{
+			"1-1": {ProductID: 1, WarehouseID: 1, Quantity: 10},
+			"2-3": {ProductID: 2, WarehouseID: 3, Quantity: 4, ReservedQuantity: 1},
+		}, nil)
+
+	inventoryUseCase := NewInventoryWarehouseUseCase(db, logrus.New(), nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+	err = inventoryUseCase.CheckAndReserveStock(context.Background(), "ord-1", items)
+
+	assert.ErrorIs(t, err, entity.ErrInsufficientStock)
+	var stockErr *entity.InsufficientStockError
+	if assert.True(t, errors.As(err, &stockErr)) {
+		assert.Equal(t, []entity.StockShortage{
+			{ProductID: 2, WarehouseID: 3, Requested: 5, Available: 3},
+		}, stockErr.Items)
+		assert.Equal(t, 2, stockErr.Items[0].Shortfall())
+	}
+	assert.EqualError(t, err, "insufficient stock available: product 2 in warehouse 3 short by 2")
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestInventoryWarehouseUseCase_FollowsCallerContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+	timeouts := appContext.DefaultTimeouts()
+	timeouts.Inventory = time.Minute
+
+	// An abandoned request cancels the warehouse call rather than leaving it to run on
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockWarehouseGateway.EXPECT().
+		ReleaseReservation(gomock.Any(), uint(1), gomock.Any()).
+		DoAndReturn(func(warehouseCtx context.Context, _ uint, _ warehouse.ReservationReleaseRequest) (*warehouse.StockOperationResponse, error) {
+			assert.ErrorIs(t, warehouseCtx.Err(), context.Canceled)
+			deadline, ok := warehouseCtx.Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+			return nil, warehouseCtx.Err()
+		})
+
+	inventoryUseCase := NewInventoryWarehouseUseCase(nil, logrus.New(), nil, mockWarehouseGateway, timeouts)
+
+	err := inventoryUseCase.ReleaseReservation(ctx, "ord-1", []entity.OrderItem{{OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 1}})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInventoryWarehouseUseCase_UsesReservationReference(t *testing.T) {
+	items := []entity.OrderItem{
+		{OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2},
+		{OrderID: 1, ProductID: 2, WarehouseID: 3, Quantity: 1},
+	}
+
+	t.Run("CommitsEachItem", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		mockWarehouseGateway.EXPECT().
+			ConfirmStockDeduction(gomock.Any(), uint(1), warehouse.ReservationCommitRequest{WarehouseID: 1, ProductID: 1, Quantity: 2, Reference: "ord-1"}).
+			Return(&warehouse.StockOperationResponse{Success: true}, nil)
+		mockWarehouseGateway.EXPECT().
+			ConfirmStockDeduction(gomock.Any(), uint(1), warehouse.ReservationCommitRequest{WarehouseID: 3, ProductID: 2, Quantity: 1, Reference: "ord-1"}).
+			Return(&warehouse.StockOperationResponse{Success: true}, nil)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logrus.New(), nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		assert.NoError(t, inventoryUseCase.ConfirmStockDeduction(context.Background(), "ord-1", items))
+	})
+
+	t.Run("FailedCommitStops", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		// The reservation expired in the warehouse, so nothing is left to commit
+		mockWarehouseGateway.EXPECT().
+			ConfirmStockDeduction(gomock.Any(), uint(1), gomock.Any()).
+			Return(nil, warehouse.ErrReservationNotFound)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logrus.New(), nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		err := inventoryUseCase.ConfirmStockDeduction(context.Background(), "ord-1", items)
+
+		assert.ErrorIs(t, err, warehouse.ErrReservationNotFound)
+	})
+
+	t.Run("ReleasesEachItem", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWarehouseGateway := warehouse_mock.NewMockWarehouseGatewayInterface(ctrl)
+
+		// A reservation the warehouse no longer holds counts as released
+		mockWarehouseGateway.EXPECT().
+			ReleaseReservation(gomock.Any(), uint(1), warehouse.ReservationReleaseRequest{WarehouseID: 1, ProductID: 1, Quantity: 2, Reference: "ord-1"}).
+			Return(nil, warehouse.ErrReservationNotFound)
+		mockWarehouseGateway.EXPECT().
+			ReleaseReservation(gomock.Any(), uint(1), warehouse.ReservationReleaseRequest{WarehouseID: 3, ProductID: 2, Quantity: 1, Reference: "ord-1"}).
+			Return(&warehouse.StockOperationResponse{Success: true}, nil)
+
+		inventoryUseCase := NewInventoryWarehouseUseCase(nil, logrus.New(), nil, mockWarehouseGateway, appContext.DefaultTimeouts())
+
+		assert.NoError(t, inventoryUseCase.ReleaseReservation(context.Background(), "ord-1", items))
+	})
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"order-service/internal/model"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultKeyPurgeBatchSize is used when the batch size of a key cleanup is not positive
+const DefaultKeyPurgeBatchSize = 1000
+
+// KeyPurgeFunc deletes up to limit keys of one table that were last used before cutoff and returns how many
+// it deleted. Keys whose operation may still be retried must be kept however old they are.
+type KeyPurgeFunc func(tx *gorm.DB, cutoff time.Time, limit int) (int64, error)
+
+// KeyTable is a table of idempotency or operation keys kept for Retention after their last use
+type KeyTable struct {
+	Name  string // Reported in the purge result and the logs
+	Purge KeyPurgeFunc
+}
+
+type KeyCleanupUseCaseInterface interface {
+	// PurgeExpiredKeys deletes the keys of every key table older than the retention
+	PurgeExpiredKeys(ctx context.Context) (*model.KeyPurgeResult, error)
+}
+
+type KeyCleanupUseCase struct {
+	DB        *gorm.DB
+	Log       *logrus.Logger
+	Tables    []KeyTable
+	Retention time.Duration // How long a key is kept for retries of its operation
+	BatchSize int           // Most rows deleted by a single statement
+}
+
+// NewKeyCleanupUseCase creates a key cleanup for tables. A feature that stores idempotency or operation keys
+// adds its table here so the keys do not grow without bound.
+func NewKeyCleanupUseCase(db *gorm.DB, logger *logrus.Logger, retention time.Duration, batchSize int, tables ...KeyTable) KeyCleanupUseCaseInterface {
+	if batchSize <= 0 {
+		batchSize = DefaultKeyPurgeBatchSize
+	}
+	return &KeyCleanupUseCase{
+		DB:        db,
+		Log:       logger,
+		Tables:    tables,
+		Retention: retention,
+		BatchSize: batchSize,
+	}
+}
+
+// PurgeExpiredKeys deletes the keys last used more than Retention before the start of the purge. Each round
+// deletes up to BatchSize rows from every table that still has expired keys, every delete its own statement so
+// none holds its locks for long, until all tables come back short or ctx is cancelled. On failure the counts
+// deleted so far are returned with the error.
+func (c *KeyCleanupUseCase) PurgeExpiredKeys(ctx context.Context) (*model.KeyPurgeResult, error) {
+	cutoff := time.Now().Add(-c.Retention)
+	result := &model.KeyPurgeResult{Deleted: make(map[string]int64, len(c.Tables))}
+	db := c.DB.WithContext(ctx)
+
+	remaining := c.Tables
+	for len(remaining) > 0 {
+		var full []KeyTable
+		for _, table := range remaining {
+			deleted, err := table.Purge(db, cutoff, c.BatchSize)
+			result.Deleted[table.Name] += deleted
+			if err != nil {
+				return result, fmt.Errorf("purge %s: %w", table.Name, err)
+			}
+			if deleted >= int64(c.BatchSize) {
+				full = append(full, table)
+			}
+		}
+
+		remaining = full
+		if len(remaining) == 0 {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// fakeKey is a row of a fakeKeyTable
+type fakeKey struct {
+	ID       int
+	Done     bool
+	LastUsed time.Time
+}
+
+// fakeKeyTable deletes from its rows the way a repository deletes from a key table
+type fakeKeyTable struct {
+	rows  []fakeKey
+	calls int
+}
+
+func (f *fakeKeyTable) purge(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	f.calls++
+	var deleted int64
+	var kept []fakeKey
+	for _, row := range f.rows {
+		if row.Done && row.LastUsed.Before(cutoff) && deleted < int64(limit) {
+			deleted++
+			continue
+		}
+		kept = append(kept, row)
+	}
+	f.rows = kept
+	return deleted, nil
+}
+
+func setupKeyCleanupUseCase(t *testing.T, retention time.Duration, batchSize int, tables ...KeyTable) *KeyCleanupUseCase {
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return NewKeyCleanupUseCase(db, logger, retention, batchSize, tables...).(*KeyCleanupUseCase)
+}
+
+func TestNewKeyCleanupUseCase_DefaultBatchSize(t *testing.T) {
+	uc := NewKeyCleanupUseCase(nil, logrus.New(), time.Hour, 0).(*KeyCleanupUseCase)
+	assert.Equal(t, DefaultKeyPurgeBatchSize, uc.BatchSize)
+}
+
+func TestKeyCleanupUseCase_PurgeExpiredKeys(t *testing.T) {
+	retention := 720 * time.Hour
+
+	t.Run("RemovesExpiredKeysAndKeepsRecentOnes", func(t *testing.T) {
+		recent := fakeKey{ID: 2, Done: true, LastUsed: time.Now().Add(-time.Hour)}
+		stalePending := fakeKey{ID: 3, Done: false, LastUsed: time.Now().Add(-2 * retention)}
+		table := &fakeKeyTable{rows: []fakeKey{
+			{ID: 1, Done: true, LastUsed: time.Now().Add(-retention - time.Hour)},
+			recent,
+			stalePending,
+		}}
+		uc := setupKeyCleanupUseCase(t, retention, 100, KeyTable{Name: "test_keys", Purge: table.purge})
+
+		result, err := uc.PurgeExpiredKeys(context.Background())
+
+		// Keys whose operation may still be retried are kept however old they are
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.Deleted["test_keys"])
+		assert.Equal(t, []fakeKey{recent, stalePending}, table.rows)
+	})
+
+	t.Run("DeletesInBatchesUntilEveryTableComesBackShort", func(t *testing.T) {
+		var rows []fakeKey
+		for i := 1; i <= 5; i++ {
+			rows = append(rows, fakeKey{ID: i, Done: true, LastUsed: time.Now().Add(-2 * retention)})
+		}
+		large := &fakeKeyTable{rows: rows}
+		small := &fakeKeyTable{rows: []fakeKey{{ID: 6, Done: true, LastUsed: time.Now().Add(-2 * retention)}}}
+		uc := setupKeyCleanupUseCase(t, retention, 2,
+			KeyTable{Name: "large_keys", Purge: large.purge},
+			KeyTable{Name: "small_keys", Purge: small.purge},
+		)
+
+		result, err := uc.PurgeExpiredKeys(context.Background())
+
+		// A table that came back short is not purged again in the same run
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), result.Deleted["large_keys"])
+		assert.Equal(t, int64(1), result.Deleted["small_keys"])
+		assert.Empty(t, large.rows)
+		assert.Equal(t, 3, large.calls)
+		assert.Equal(t, 1, small.calls)
+	})
+
+	t.Run("StopsAndReportsWhatWasDeletedWhenADeleteFails", func(t *testing.T) {
+		calls := 0
+		failing := func(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+			calls++
+			if calls == 2 {
+				return 0, errors.New("lock wait timeout")
+			}
+			return int64(limit), nil
+		}
+		uc := setupKeyCleanupUseCase(t, retention, 2, KeyTable{Name: "test_keys", Purge: failing})
+
+		result, err := uc.PurgeExpiredKeys(context.Background())
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(2), result.Deleted["test_keys"])
+	})
+
+	t.Run("StopsBetweenBatchesOnceTheContextIsCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		cancelling := func(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+			calls++
+			cancel()
+			return int64(limit), nil
+		}
+		uc := setupKeyCleanupUseCase(t, retention, 1, KeyTable{Name: "test_keys", Purge: cancelling})
+
+		result, err := uc.PurgeExpiredKeys(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int64(1), result.Deleted["test_keys"])
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("NoTables", func(t *testing.T) {
+		uc := setupKeyCleanupUseCase(t, retention, 2)
+
+		result, err := uc.PurgeExpiredKeys(context.Background())
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Deleted)
+	})
+}
@@ -2,28 +2,51 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"iter"
+	appContext "order-service/internal/context"
 	"order-service/internal/entity"
+	"order-service/internal/gateway/payment"
+	"order-service/internal/gateway/product"
+	"order-service/internal/gateway/shipping"
+	"order-service/internal/gateway/tax"
+	"order-service/internal/metrics"
 	"order-service/internal/model"
 	"order-service/internal/model/converter"
 	"order-service/internal/repository"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type OrderUseCaseInterface interface {
 	CreateOrder(ctx context.Context, request *model.CreateOrderRequest) (*model.OrderResponse, error)
-	GetOrderByID(ctx context.Context, orderID uint) (*model.OrderResponse, error)
-	GetOrdersByUserID(ctx context.Context, userID string, page, limit int) ([]model.OrderResponse, int64, error)
+	GetOrderByID(ctx context.Context, orderID uint, includeReservations bool) (*model.OrderResponse, error)
+	GetOrdersByUserID(ctx context.Context, userID string, filter model.OrderListFilter, page, limit int) (*model.OrderListResponse, error)
+	GetUserOrderSummary(ctx context.Context, userID string) (*model.OrderSummaryResponse, error)
+	SearchOrders(ctx context.Context, criteria model.OrderSearchCriteria) (*model.OrderListResponse, error)
+	ExportOrders(ctx context.Context, filter model.OrderListFilter) (iter.Seq2[*model.OrderExportRow, error], error)
 	UpdateOrderStatus(ctx context.Context, orderID uint, status string) error
+	GetOrderStatusHistory(ctx context.Context, orderID uint) (*model.OrderStatusHistoryResponse, error)
+	CancelOrderItems(ctx context.Context, orderID uint, items []model.OrderItemRequest) (*model.OrderResponse, error)
 	ProcessPayment(ctx context.Context, orderID uint) error
-	CancelExpiredOrders(ctx context.Context) error
+	ReconcilePaidOrder(ctx context.Context, orderID uint) (*model.ReconcileOrderResponse, error)
+	ReactivateOrder(ctx context.Context, orderID uint) (*model.OrderResponse, error)
+	CompleteOrder(ctx context.Context, orderID uint, tracking *model.CompleteOrderRequest) (*model.OrderResponse, error)
+	CancelExpiredOrders(ctx context.Context) (*model.ExpirySweepResult, error)
+	RetryPendingReleases(ctx context.Context) error
 }
 
+// pendingReleaseBatchSize bounds how many outbox entries RetryPendingReleases handles per run
+const pendingReleaseBatchSize = 100
+
 type OrderUseCase struct {
 	DB                    *gorm.DB
 	Log                   *logrus.Logger
@@ -31,32 +54,97 @@ type OrderUseCase struct {
 	OrderRepository       repository.OrderRepositoryInterface
 	ReservationRepository repository.ReservationRepositoryInterface
 	InventoryUseCase      InventoryUseCaseInterface
+	PaymentGateway        payment.PaymentGatewayInterface
+	PaymentDeadline       time.Duration
+	ExportMaxRange        time.Duration // Longest created-at range ExportOrders accepts
+	Pagination            model.Pagination
+	// PriceGateway is optional; when nil, submitted unit prices are trusted
+	PriceGateway   product.ProductPriceGatewayInterface
+	PriceTolerance entity.Money
+	// Metrics is optional; when nil, domain events are not counted
+	Metrics *metrics.Metrics
+	// CouponRepository is optional; when nil, orders with a coupon code are rejected
+	CouponRepository repository.CouponRepositoryInterface
+	// TaxCalculator is optional; when nil, orders are not taxed
+	TaxCalculator tax.TaxCalculatorInterface
+	// ShippingCalculator is optional; when nil, orders ship for free
+	ShippingCalculator shipping.ShippingCalculatorInterface
+	// WebhookRepository is optional; when nil, status changes are not queued for webhook delivery
+	WebhookRepository repository.WebhookRepositoryInterface
+	// Timeouts bounds each database, inventory and payment operation; zero fields use the defaults
+	Timeouts appContext.Timeouts
+	// DeadlockRetry controls how often CreateOrder retries a deadlocked transaction; the zero value never retries
+	DeadlockRetry DeadlockRetry
+	// MinimumOrderAmount is the smallest discounted subtotal an order may have; zero disables the check
+	MinimumOrderAmount entity.Money
+}
+
+// DeadlockRetry bounds the retries of an order transaction that MySQL aborted to break a deadlock
+type DeadlockRetry struct {
+	MaxRetries int           // Retries after the first attempt
+	Backoff    time.Duration // Wait before the first retry, growing linearly with each further retry
+}
+
+// OrderUseCaseDeps holds the dependencies and settings NewOrderUseCase wires into an OrderUseCase.
+// The fields match those of OrderUseCase, which documents the ones that may be left unset.
+type OrderUseCaseDeps struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	OrderRepository       repository.OrderRepositoryInterface
+	ReservationRepository repository.ReservationRepositoryInterface
+	InventoryUseCase      InventoryUseCaseInterface
+	PaymentGateway        payment.PaymentGatewayInterface
+	PaymentDeadline       time.Duration
+	ExportMaxRange        time.Duration
+	Pagination            model.Pagination
+	PriceGateway          product.ProductPriceGatewayInterface
+	PriceTolerance        entity.Money
+	Metrics               *metrics.Metrics
+	CouponRepository      repository.CouponRepositoryInterface
+	TaxCalculator         tax.TaxCalculatorInterface
+	ShippingCalculator    shipping.ShippingCalculatorInterface
+	WebhookRepository     repository.WebhookRepositoryInterface
+	Timeouts              appContext.Timeouts
+	DeadlockRetry         DeadlockRetry
+	MinimumOrderAmount    entity.Money
 }
 
-func NewOrderUseCase(
-	db *gorm.DB,
-	logger *logrus.Logger,
-	validate *validator.Validate,
-	orderRepository repository.OrderRepositoryInterface,
-	reservationRepository repository.ReservationRepositoryInterface,
-	inventoryUseCase InventoryUseCaseInterface,
-) OrderUseCaseInterface {
+func NewOrderUseCase(deps OrderUseCaseDeps) OrderUseCaseInterface {
 	return &OrderUseCase{
-		DB:                    db,
-		Log:                   logger,
-		Validate:              validate,
-		OrderRepository:       orderRepository,
-		ReservationRepository: reservationRepository,
-		InventoryUseCase:      inventoryUseCase,
+		DB:                    deps.DB,
+		Log:                   deps.Log,
+		Validate:              deps.Validate,
+		OrderRepository:       deps.OrderRepository,
+		ReservationRepository: deps.ReservationRepository,
+		InventoryUseCase:      deps.InventoryUseCase,
+		PaymentGateway:        deps.PaymentGateway,
+		PaymentDeadline:       deps.PaymentDeadline,
+		ExportMaxRange:        deps.ExportMaxRange,
+		Pagination:            deps.Pagination,
+		PriceGateway:          deps.PriceGateway,
+		PriceTolerance:        deps.PriceTolerance,
+		Metrics:               deps.Metrics,
+		CouponRepository:      deps.CouponRepository,
+		TaxCalculator:         deps.TaxCalculator,
+		ShippingCalculator:    deps.ShippingCalculator,
+		WebhookRepository:     deps.WebhookRepository,
+		Timeouts:              deps.Timeouts,
+		DeadlockRetry:         deps.DeadlockRetry,
+		MinimumOrderAmount:    deps.MinimumOrderAmount,
 	}
 }
 
 func (c *OrderUseCase) CreateOrder(ctx context.Context, request *model.CreateOrderRequest) (*model.OrderResponse, error) {
+	// Payment methods are validated and stored lowercased
+	request.PaymentMethod = model.NormalizePaymentMethod(request.PaymentMethod)
+
 	// Validate request first before attempting any resource reservation
+	// The validator errors are returned as is so the handler can report each failed field
 	err := c.Validate.Struct(request)
 	if err != nil {
 		c.Log.Warnf("Invalid request body: %+v", err)
-		return nil, fiber.ErrBadRequest
+		return nil, err
 	}
 
 	// Check if items exist
@@ -65,136 +153,389 @@ func (c *OrderUseCase) CreateOrder(ctx context.Context, request *model.CreateOrd
 		return nil, fiber.ErrBadRequest
 	}
 
-	// Create a separate context for inventory operations
-	inventoryCtx, inventoryCancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer inventoryCancel()
+	// All items must be priced in the order currency
+	currency, err := resolveOrderCurrency(request)
+	if err != nil {
+		c.Log.Warnf("Rejected mixed-currency order: %+v", err)
+		return nil, err
+	}
+
+	// The price, coupon, tax and shipping lookups share one read timeout
+	lookupCtx, lookupCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer lookupCancel()
+
+	// Reject the order before reserving anything if the submitted prices are stale
+	if err := c.validateUnitPrices(lookupCtx, request.Items); err != nil {
+		return nil, err
+	}
+
+	// Check the coupon before reserving anything; its discount is computed from the subtotal below
+	coupon, err := c.resolveCoupon(lookupCtx, request.CouponCode, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	taxRate, err := c.resolveTaxRate(lookupCtx, request.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	shippingCost, err := c.estimateShippingCost(lookupCtx, request.Items, request.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate the item subtotal in cents, so it is exact however many lines the order has
+	var subtotal entity.Money
+	for _, item := range request.Items {
+		subtotal += item.UnitPrice.Mul(item.Quantity)
+	}
+
+	// Take the coupon discount off the subtotal computed here, never a figure sent by the client
+	var couponCode string
+	var discountAmount entity.Money
+	if coupon != nil {
+		couponCode = coupon.Code
+		discountAmount = coupon.Discount(subtotal)
+	}
 
-	// Check and lock stock before starting the transaction
-	// This is a critical step to prevent overselling
-	if err := c.InventoryUseCase.CheckAndReserveStock(inventoryCtx, request.Items); err != nil {
+	// The minimum applies to what the customer pays for the items, so after the coupon but before tax and shipping
+	if c.MinimumOrderAmount > 0 && subtotal-discountAmount < c.MinimumOrderAmount {
+		c.Log.Warnf("Rejected order of %s below the minimum order amount of %s", subtotal-discountAmount, c.MinimumOrderAmount)
+		return nil, &entity.BelowMinimumOrderError{Required: c.MinimumOrderAmount, Actual: subtotal - discountAmount}
+	}
+
+	// A deadlock rolls back the whole transaction, so the stock is released and the order placed again from the reservation
+	var order *entity.Order
+	for attempt := 0; ; attempt++ {
+		reservationReference := newReservationReference()
+		if err := c.reserveOrderStock(ctx, reservationReference, request.Items); err != nil {
+			return nil, err
+		}
+
+		// Set payment deadline using the configured hold window
+		paymentDeadline := time.Now().Add(c.PaymentDeadline)
+
+		order = &entity.Order{
+			UserID:               request.UserID,
+			Status:               entity.OrderStatusPending,
+			Currency:             currency,
+			CouponCode:           couponCode,
+			TaxRate:              taxRate,
+			ShippingCost:         shippingCost,
+			ShippingAddress:      request.ShippingAddress,
+			PaymentMethod:        request.PaymentMethod,
+			PaymentDeadline:      paymentDeadline,
+			ReservationReference: reservationReference,
+		}
+		order.ApplyAmounts(subtotal, discountAmount)
+
+		err := c.persistOrder(ctx, order, request.Items)
+		if err == nil {
+			break
+		}
+
+		// Release the reserved stock since we're aborting the order
+		c.releaseStockForItems(ctx, reservationReference, request.Items)
+
+		if !repository.IsDeadlock(err) {
+			return nil, fiber.ErrInternalServerError
+		}
+		if attempt >= c.DeadlockRetry.MaxRetries {
+			c.Log.Warnf("Giving up on order after %d deadlocked attempts", attempt+1)
+			return nil, entity.ErrOrderContention
+		}
+
+		backoff := c.DeadlockRetry.Backoff * time.Duration(attempt+1)
+		c.Log.Infof("Order transaction deadlocked, retrying in %s (attempt %d of %d)", backoff, attempt+2, c.DeadlockRetry.MaxRetries+1)
+		if err := sleepContext(ctx, backoff); err != nil {
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	loadCtx, loadCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer loadCancel()
+
+	// Load the created order with its items
+	createdOrder, err := c.OrderRepository.FindOrderByID(c.DB.WithContext(loadCtx), order.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to load created order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.OrderToResponse(createdOrder), nil
+}
+
+// reserveOrderStock checks and locks the stock of the order items under the reference before the order
+// transaction starts. This is a critical step to prevent overselling.
+func (c *OrderUseCase) reserveOrderStock(ctx context.Context, reference string, items []model.OrderItemRequest) error {
+	inventoryCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationInventory)
+	defer cancel()
+
+	if err := c.InventoryUseCase.CheckAndReserveStock(inventoryCtx, reference, items); err != nil {
 		c.Log.Warnf("Failed to reserve stock: %+v", err)
 
 		// Check if it's a stock insufficiency error
 		if errors.Is(err, entity.ErrInsufficientStock) {
-			return nil, errors.New("insufficient stock available for one or more items")
+			c.Metrics.RecordStockReservation(metrics.ResultInsufficientStock)
+			return err
 		}
 
-		return nil, fiber.ErrInternalServerError
+		c.Metrics.RecordStockReservation(metrics.ResultFailure)
+		return fiber.ErrInternalServerError
 	}
+	c.Metrics.RecordStockReservation(metrics.ResultSuccess)
 
-	// Create a new context with a longer timeout for database operations
-	// This ensures that the database transaction can complete even if the original context deadline is close
-	dbCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	return nil
+}
+
+// persistOrder creates the order, its items and their reservations in one transaction.
+// The database error is returned as is, so the caller can tell deadlocks apart; the caller releases the reserved stock.
+func (c *OrderUseCase) persistOrder(ctx context.Context, order *entity.Order, items []model.OrderItemRequest) error {
+	// Creating the order gets the longer commit timeout, but is still cancelled with the request
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationCommit)
 	defer cancel()
 
-	// Start a transaction for the order creation with the new context
 	tx := c.DB.WithContext(dbCtx).Begin()
 	defer tx.Rollback()
 
-	// Calculate total amount
-	var totalAmount float64
-	orderItems := make([]entity.OrderItem, len(request.Items))
-
-	for i, item := range request.Items {
-		totalPrice := float64(item.Quantity) * item.UnitPrice
-		totalAmount += totalPrice
+	if err := c.OrderRepository.CreateOrder(tx, order); err != nil {
+		c.Log.Warnf("Failed to create order: %+v", err)
+		return err
+	}
 
+	orderItems := make([]entity.OrderItem, len(items))
+	for i, item := range items {
 		orderItems[i] = entity.OrderItem{
+			OrderID:     order.ID,
 			ProductID:   item.ProductID,
 			WarehouseID: item.WarehouseID,
 			Quantity:    item.Quantity,
 			UnitPrice:   item.UnitPrice,
-			TotalPrice:  totalPrice,
+			TotalPrice:  item.UnitPrice.Mul(item.Quantity),
 		}
 	}
 
-	// Set payment deadline to 24 hours from now
-	paymentDeadline := time.Now().Add(24 * time.Hour)
-
-	// Create order
-	order := &entity.Order{
-		UserID:          request.UserID,
-		Status:          entity.OrderStatusPending,
-		TotalAmount:     totalAmount,
-		ShippingAddress: request.ShippingAddress,
-		PaymentMethod:   request.PaymentMethod,
-		PaymentDeadline: paymentDeadline,
-	}
-
-	if err := c.OrderRepository.CreateOrder(tx, order); err != nil {
-		c.Log.Warnf("Failed to create order: %+v", err)
-
-		// Release the reserved stock since we're aborting the order
-		c.releaseStockForItems(ctx, request.Items)
-
-		return nil, fiber.ErrInternalServerError
-	}
-
-	// Set order ID for each item
-	for i := range orderItems {
-		orderItems[i].OrderID = order.ID
-	}
-
-	// Create order items
 	if err := c.OrderRepository.CreateOrderItems(tx, orderItems); err != nil {
 		c.Log.Warnf("Failed to create order items: %+v", err)
-
-		// Release the reserved stock since we're aborting the order
-		c.releaseStockForItems(ctx, request.Items)
-
-		return nil, fiber.ErrInternalServerError
+		return err
 	}
 
 	// Create stock reservations in the reservation tracking table
-	reservations := make([]entity.Reservation, len(request.Items))
-	for i, item := range request.Items {
+	reservations := make([]entity.Reservation, len(items))
+	for i, item := range items {
 		reservations[i] = entity.Reservation{
 			OrderID:     order.ID,
 			ProductID:   item.ProductID,
 			WarehouseID: item.WarehouseID,
 			Quantity:    item.Quantity,
-			ExpiresAt:   paymentDeadline,
+			ExpiresAt:   order.PaymentDeadline,
 			IsActive:    true,
 		}
 	}
 
 	if err := c.ReservationRepository.CreateReservationBatch(tx, reservations); err != nil {
 		c.Log.Warnf("Failed to create stock reservations: %+v", err)
-
-		// Release the reserved stock since we're aborting the order
-		c.releaseStockForItems(ctx, request.Items)
-
-		return nil, fiber.ErrInternalServerError
+		return err
 	}
 
-	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return err
+	}
 
-		// Release the reserved stock since we're aborting the order
-		c.releaseStockForItems(ctx, request.Items)
+	return nil
+}
 
-		return nil, fiber.ErrInternalServerError
+// newReservationReference returns a fresh reference to hold an order's stock under in the warehouse.
+// Each reservation gets its own, since the warehouse does not accept a resolved reference again.
+func newReservationReference() string {
+	return "ord-" + uuid.NewString()
+}
+
+// sleepContext waits for d, returning early with the context error if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	// Create a new context for loading the created order
-	loadCtx, loadCancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer loadCancel()
+// isValidOrderStatus reports whether status is one of the known order states
+func isValidOrderStatus(status entity.OrderStatus) bool {
+	switch status {
+	case entity.OrderStatusPending, entity.OrderStatusPaymentProcessing, entity.OrderStatusPaid, entity.OrderStatusCancelled, entity.OrderStatusCompleted:
+		return true
+	}
+	return false
+}
 
-	// Load the created order with its items
-	createdOrder, err := c.OrderRepository.FindOrderByID(c.DB.WithContext(loadCtx), order.ID)
+// resolveOrderCurrency returns the single currency shared by the order and its items.
+// When the order omits a currency, the first item currency is used, falling back to USD.
+func resolveOrderCurrency(request *model.CreateOrderRequest) (string, error) {
+	currency := request.Currency
+	for _, item := range request.Items {
+		if item.Currency == "" {
+			continue
+		}
+		if currency == "" {
+			currency = item.Currency
+		} else if item.Currency != currency {
+			return "", entity.ErrMixedCurrency
+		}
+	}
+
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	return currency, nil
+}
+
+// resolveCoupon looks up the coupon for an order in the given currency, returning nil when no code is given.
+// Unknown, inactive and other-currency fixed coupons return entity.ErrInvalidCoupon and expired ones entity.ErrCouponExpired.
+func (c *OrderUseCase) resolveCoupon(ctx context.Context, code string, currency string) (*entity.Coupon, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, nil
+	}
+
+	if c.CouponRepository == nil {
+		c.Log.Warnf("Rejected coupon %s: coupons are not configured", code)
+		return nil, entity.ErrInvalidCoupon
+	}
+
+	coupon, err := c.CouponRepository.FindCouponByCode(c.DB.WithContext(ctx), code)
 	if err != nil {
-		c.Log.Warnf("Failed to load created order: %+v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Rejected unknown coupon: %s", code)
+			return nil, entity.ErrInvalidCoupon
+		}
+		c.Log.Warnf("Failed to find coupon: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.OrderToResponse(createdOrder), nil
+	switch {
+	case !coupon.IsActive:
+		c.Log.Warnf("Rejected inactive coupon: %s", code)
+		return nil, entity.ErrInvalidCoupon
+	case coupon.IsExpired(time.Now()):
+		c.Log.Warnf("Rejected expired coupon: %s", code)
+		return nil, entity.ErrCouponExpired
+	case coupon.DiscountType == entity.DiscountTypeFixed && coupon.Currency != currency:
+		c.Log.Warnf("Rejected coupon %s: fixed discount in %s cannot apply to an order in %s", code, coupon.Currency, currency)
+		return nil, entity.ErrInvalidCoupon
+	}
+
+	return coupon, nil
+}
+
+// resolveTaxRate looks up the tax percentage for the shipping address.
+// Orders are untaxed when no tax calculator is configured.
+func (c *OrderUseCase) resolveTaxRate(ctx context.Context, shippingAddress string) (float64, error) {
+	if c.TaxCalculator == nil {
+		return 0, nil
+	}
+
+	rate, err := c.TaxCalculator.TaxRate(ctx, shippingAddress)
+	if err != nil {
+		c.Log.Warnf("Failed to determine tax rate: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	if rate < 0 || rate >= 100 {
+		c.Log.Warnf("Tax calculator returned an invalid rate: %v", rate)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	return rate, nil
+}
+
+// estimateShippingCost asks the shipping calculator what delivering the items costs.
+// Orders ship for free when no shipping calculator is configured.
+func (c *OrderUseCase) estimateShippingCost(ctx context.Context, items []model.OrderItemRequest, shippingAddress string) (entity.Money, error) {
+	if c.ShippingCalculator == nil {
+		return 0, nil
+	}
+
+	cost, err := c.ShippingCalculator.ShippingCost(ctx, items, shippingAddress)
+	if err != nil {
+		c.Log.Warnf("Failed to estimate shipping cost: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	if cost < 0 {
+		c.Log.Warnf("Shipping calculator returned a negative cost: %v", cost)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	return cost, nil
+}
+
+// recomputeDiscount returns the coupon discount for an order whose subtotal changed.
+// The coupon applied when the order was placed keeps counting even if it has since expired; if it can no longer be read,
+// the original discount is kept, capped at the new subtotal.
+func (c *OrderUseCase) recomputeDiscount(tx *gorm.DB, order *entity.Order, subtotal entity.Money) entity.Money {
+	if order.CouponCode == "" {
+		return 0
+	}
+
+	if c.CouponRepository != nil {
+		coupon, err := c.CouponRepository.FindCouponByCode(tx, order.CouponCode)
+		if err == nil {
+			return coupon.Discount(subtotal)
+		}
+		c.Log.Warnf("Failed to find coupon %s of order %d, keeping its discount: %+v", order.CouponCode, order.ID, err)
+	}
+
+	return min(order.DiscountAmount, subtotal)
+}
+
+// validateUnitPrices checks every submitted unit price against the product service
+// using a single batched lookup. It is a no-op when no price gateway is configured.
+func (c *OrderUseCase) validateUnitPrices(ctx context.Context, items []model.OrderItemRequest) error {
+	if c.PriceGateway == nil {
+		return nil
+	}
+
+	productIDs := make([]uint, 0, len(items))
+	seen := make(map[uint]bool, len(items))
+	for _, item := range items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	prices, err := c.PriceGateway.GetPrices(ctx, productIDs)
+	if err != nil {
+		c.Log.Warnf("Failed to get product prices: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	for _, item := range items {
+		price, ok := prices[item.ProductID]
+		if !ok {
+			c.Log.Warnf("Product %d not found in product service", item.ProductID)
+			return entity.ErrProductNotFound
+		}
+		if (item.UnitPrice - price).Abs() > c.PriceTolerance {
+			c.Log.Warnf("Unit price %s for product %d deviates from current price %s", item.UnitPrice, item.ProductID, price)
+			return entity.ErrPriceMismatch
+		}
+	}
+
+	return nil
 }
 
 // Helper method to release stock for items when an order fails
-func (c *OrderUseCase) releaseStockForItems(ctx context.Context, items []model.OrderItemRequest) {
-	// Create a new context for inventory operations
-	inventoryCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+func (c *OrderUseCase) releaseStockForItems(ctx context.Context, reference string, items []model.OrderItemRequest) {
+	// The release must run even when the failure was the request being cancelled
+	inventoryCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationInventory)
 	defer cancel()
 
 	// Convert to order items for the inventory usecase
@@ -208,14 +549,13 @@ func (c *OrderUseCase) releaseStockForItems(ctx context.Context, items []model.O
 	}
 
 	// Attempt to release the stock, but just log errors rather than returning them
-	if err := c.InventoryUseCase.ReleaseReservation(inventoryCtx, orderItems); err != nil {
+	if err := c.InventoryUseCase.ReleaseReservation(inventoryCtx, reference, orderItems); err != nil {
 		c.Log.Warnf("Failed to release stock reservations: %+v", err)
 	}
 }
 
-func (c *OrderUseCase) GetOrderByID(ctx context.Context, orderID uint) (*model.OrderResponse, error) {
-	// Create a new context with a timeout for database operations
-	dbCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (c *OrderUseCase) GetOrderByID(ctx context.Context, orderID uint, includeReservations bool) (*model.OrderResponse, error) {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
 	defer cancel()
 
 	order, err := c.OrderRepository.FindOrderByID(c.DB.WithContext(dbCtx), orderID)
@@ -228,54 +568,226 @@ func (c *OrderUseCase) GetOrderByID(ctx context.Context, orderID uint) (*model.O
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.OrderToResponse(order), nil
+	response := converter.OrderToResponse(order)
+
+	if includeReservations {
+		reservations, err := c.ReservationRepository.FindReservationsByOrderID(c.DB.WithContext(dbCtx), orderID)
+		if err != nil {
+			c.Log.Warnf("Failed to find reservations: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		response.ReservationSummary = converter.ReservationSummaryToResponse(order.Status, reservations)
+	}
+
+	return response, nil
 }
 
-func (c *OrderUseCase) GetOrdersByUserID(ctx context.Context, userID string, page, limit int) ([]model.OrderResponse, int64, error) {
+func (c *OrderUseCase) GetOrdersByUserID(ctx context.Context, userID string, filter model.OrderListFilter, page, limit int) (*model.OrderListResponse, error) {
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	limit = c.Pagination.Limit(limit)
+
+	// Validate optional filters
+	orderFilter := repository.OrderFilter{
+		From: filter.From,
+		To:   filter.To,
+	}
+	if filter.Status != "" {
+		orderStatus := entity.OrderStatus(filter.Status)
+		if !isValidOrderStatus(orderStatus) {
+			c.Log.Warnf("Invalid order status filter: %s", filter.Status)
+			return nil, fiber.ErrBadRequest
+		}
+		orderFilter.Status = orderStatus
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		c.Log.Warnf("Invalid date range: from %s is after to %s", filter.From, filter.To)
+		return nil, fiber.ErrBadRequest
 	}
 
-	// Create a new context with a timeout for database operations
-	dbCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
 	defer cancel()
 
-	orders, total, err := c.OrderRepository.FindOrdersByUserID(c.DB.WithContext(dbCtx), userID, page, limit)
+	orders, total, err := c.OrderRepository.FindOrdersByUserID(c.DB.WithContext(dbCtx), userID, orderFilter, page, limit)
 	if err != nil {
 		c.Log.Warnf("Failed to find orders by user ID: %+v", err)
-		return nil, 0, fiber.ErrInternalServerError
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.OrdersToListResponse(orders, total, page, limit), nil
+}
+
+// GetUserOrderSummary counts a user's orders per status and sums the totals of their paid and completed orders
+// per currency. The aggregation runs in the database, so no orders are loaded. A user without orders gets zeros.
+func (c *OrderUseCase) GetUserOrderSummary(ctx context.Context, userID string) (*model.OrderSummaryResponse, error) {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer cancel()
+
+	totals, err := c.OrderRepository.SummarizeOrdersByUserID(c.DB.WithContext(dbCtx), userID)
+	if err != nil {
+		c.Log.Warnf("Failed to summarize orders for user %s: %+v", userID, err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	summary := &model.OrderSummaryResponse{
+		UserID: userID,
+		StatusCounts: map[string]int64{
+			string(entity.OrderStatusPending):           0,
+			string(entity.OrderStatusPaymentProcessing): 0,
+			string(entity.OrderStatusPaid):              0,
+			string(entity.OrderStatusCancelled):         0,
+			string(entity.OrderStatusCompleted):         0,
+		},
+		TotalSpent: []model.CurrencyAmount{},
+	}
+
+	// Rows arrive ordered by status then currency, so spend is merged per currency in first-seen order
+	spent := make(map[string]int)
+	for _, total := range totals {
+		summary.TotalOrders += total.OrderCount
+		summary.StatusCounts[string(total.Status)] += total.OrderCount
+
+		if total.Status != entity.OrderStatusPaid && total.Status != entity.OrderStatusCompleted {
+			continue
+		}
+		if i, ok := spent[total.Currency]; ok {
+			summary.TotalSpent[i].Amount += total.TotalAmount
+			continue
+		}
+		spent[total.Currency] = len(summary.TotalSpent)
+		summary.TotalSpent = append(summary.TotalSpent, model.CurrencyAmount{
+			Currency: total.Currency,
+			Amount:   total.TotalAmount,
+		})
+	}
+
+	return summary, nil
+}
+
+// SearchOrders returns a page of the orders matching every set criterion, newest first.
+// At least one criterion is required so a search cannot page through the whole table.
+func (c *OrderUseCase) SearchOrders(ctx context.Context, criteria model.OrderSearchCriteria) (*model.OrderListResponse, error) {
+	if criteria.OrderID == 0 && criteria.UserID == "" && criteria.Status == "" && criteria.PaymentMethod == "" &&
+		criteria.From == nil && criteria.To == nil {
+		c.Log.Warn("Rejected order search without criteria")
+		return nil, entity.ErrSearchCriteriaRequired
+	}
+
+	page, limit := criteria.Page, criteria.Limit
+	if page < 1 {
+		page = 1
+	}
+	limit = c.Pagination.Limit(limit)
+
+	orderFilter := repository.OrderFilter{
+		OrderID:       criteria.OrderID,
+		UserID:        criteria.UserID,
+		PaymentMethod: criteria.PaymentMethod,
+		From:          criteria.From,
+		To:            criteria.To,
+	}
+	if criteria.Status != "" {
+		orderStatus := entity.OrderStatus(criteria.Status)
+		if !isValidOrderStatus(orderStatus) {
+			c.Log.Warnf("Invalid order status filter: %s", criteria.Status)
+			return nil, fiber.ErrBadRequest
+		}
+		orderFilter.Status = orderStatus
+	}
+	if criteria.From != nil && criteria.To != nil && criteria.From.After(*criteria.To) {
+		c.Log.Warnf("Invalid date range: from %s is after to %s", criteria.From, criteria.To)
+		return nil, fiber.ErrBadRequest
+	}
+
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer cancel()
+
+	orders, total, err := c.OrderRepository.SearchOrders(c.DB.WithContext(dbCtx), orderFilter, page, limit)
+	if err != nil {
+		c.Log.Warnf("Failed to search orders: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.OrdersToListResponse(orders, total, page, limit), nil
+}
+
+// errExportStopped ends an order export early when the consumer stops reading rows
+var errExportStopped = errors.New("order export stopped")
+
+// ExportOrders validates the filter and returns the matching orders, oldest first, as a sequence read lazily from the database.
+// To defaults to now and From to ExportMaxRange before To; a longer range returns entity.ErrExportRangeTooLarge.
+// Rows are only read while the sequence is ranged over, so it must be consumed before ctx is cancelled.
+func (c *OrderUseCase) ExportOrders(ctx context.Context, filter model.OrderListFilter) (iter.Seq2[*model.OrderExportRow, error], error) {
+	// Validate optional filters
+	orderFilter := repository.OrderFilter{}
+	if filter.Status != "" {
+		orderStatus := entity.OrderStatus(filter.Status)
+		if !isValidOrderStatus(orderStatus) {
+			c.Log.Warnf("Invalid order status filter: %s", filter.Status)
+			return nil, fiber.ErrBadRequest
+		}
+		orderFilter.Status = orderStatus
 	}
 
-	return converter.OrdersToResponse(orders), total, nil
+	// Bound the date range so a single export cannot scan the whole table
+	to := time.Now()
+	if filter.To != nil {
+		to = *filter.To
+	}
+	from := to.Add(-c.ExportMaxRange)
+	if filter.From != nil {
+		from = *filter.From
+	}
+	if from.After(to) {
+		c.Log.Warnf("Invalid date range: from %s is after to %s", from, to)
+		return nil, fiber.ErrBadRequest
+	}
+	if to.Sub(from) > c.ExportMaxRange {
+		c.Log.Warnf("Export date range from %s to %s exceeds %s", from, to, c.ExportMaxRange)
+		return nil, entity.ErrExportRangeTooLarge
+	}
+	orderFilter.From = &from
+	orderFilter.To = &to
+
+	return func(yield func(*model.OrderExportRow, error) bool) {
+		err := c.OrderRepository.StreamOrdersForExport(c.DB.WithContext(ctx), orderFilter, func(row *repository.OrderExportRow) error {
+			if !yield(&model.OrderExportRow{
+				OrderID:     row.ID,
+				UserID:      row.UserID,
+				Status:      string(row.Status),
+				TotalAmount: row.TotalAmount,
+				Currency:    row.Currency,
+				CreatedAt:   row.CreatedAt,
+				ItemCount:   row.ItemCount,
+			}, nil) {
+				return errExportStopped
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errExportStopped) {
+			c.Log.Warnf("Failed to export orders: %+v", err)
+			yield(nil, fiber.ErrInternalServerError)
+		}
+	}, nil
 }
 
 func (c *OrderUseCase) UpdateOrderStatus(ctx context.Context, orderID uint, status string) error {
-	// Create a new context with a timeout for database operations
-	dbCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationWrite)
 	defer cancel()
 
 	tx := c.DB.WithContext(dbCtx).Begin()
 	defer tx.Rollback()
 
-	// Validate status
+	// Validate status; only ProcessPayment claims an order for payment
 	orderStatus := entity.OrderStatus(status)
-	validStatuses := map[entity.OrderStatus]bool{
-		entity.OrderStatusPending:   true,
-		entity.OrderStatusPaid:      true,
-		entity.OrderStatusCancelled: true,
-		entity.OrderStatusCompleted: true,
-	}
-
-	if !validStatuses[orderStatus] {
+	if !isValidOrderStatus(orderStatus) || orderStatus == entity.OrderStatusPaymentProcessing {
 		c.Log.Warnf("Invalid order status: %s", status)
 		return fiber.ErrBadRequest
 	}
 
-	// Get current order to check current status
-	order, err := c.OrderRepository.FindOrderByID(tx, orderID)
+	// Get current order to check current status, locking it against a concurrent payment claim
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.Log.Warnf("Order not found: %d", orderID)
@@ -285,6 +797,12 @@ func (c *OrderUseCase) UpdateOrderStatus(ctx context.Context, orderID uint, stat
 		return fiber.ErrInternalServerError
 	}
 
+	// An order being charged is left to ProcessPayment to finish
+	if order.Status == entity.OrderStatusPaymentProcessing {
+		c.Log.Warnf("Cannot change status of order %d while its payment is in progress", orderID)
+		return entity.ErrPaymentInProgress
+	}
+
 	// Handle inventory and reservation updates based on status change
 	if orderStatus == entity.OrderStatusCancelled {
 		// For cancellations, release the inventory reservation
@@ -295,8 +813,15 @@ func (c *OrderUseCase) UpdateOrderStatus(ctx context.Context, orderID uint, stat
 				return fiber.ErrInternalServerError
 			}
 
+			// Queue the release in the same transaction so it is retried if the call below fails
+			releases, err := c.ReservationRepository.EnqueueReservationReleases(tx, orderID, order.StockReference(), order.OrderItems)
+			if err != nil {
+				c.Log.Warnf("Failed to enqueue reservation releases: %+v", err)
+				return fiber.ErrInternalServerError
+			}
+
 			// Update order status before external service calls
-			if err := c.OrderRepository.UpdateOrderStatus(tx, orderID, orderStatus); err != nil {
+			if err := c.changeOrderStatus(tx, order, orderStatus, actorFromContext(ctx)); err != nil {
 				c.Log.Warnf("Failed to update order status: %+v", err)
 				return fiber.ErrInternalServerError
 			}
@@ -307,52 +832,52 @@ func (c *OrderUseCase) UpdateOrderStatus(ctx context.Context, orderID uint, stat
 				return fiber.ErrInternalServerError
 			}
 
-			// Create a separate context for inventory operations
-			inventoryCtx, inventoryCancel := context.WithTimeout(context.Background(), 15*time.Second)
-			defer inventoryCancel()
-
 			// Release stock in inventory system - this is now outside the transaction
-			if err := c.InventoryUseCase.ReleaseReservation(inventoryCtx, order.OrderItems); err != nil {
-				c.Log.Warnf("Failed to release inventory reservation: %+v", err)
-				// The order is already marked as cancelled, so this is just a warning
-				// We don't want to fail the operation if just the inventory release fails
-				return nil
-			}
+			// The order is already marked as cancelled, so a failed release only stays
+			// pending for RetryPendingReleases instead of failing the operation
+			c.releaseQueuedReservations(ctx, releases)
 
 			// Early return since we've already committed the transaction
 			return nil
 		}
 	} else if orderStatus == entity.OrderStatusPaid && order.Status == entity.OrderStatusPending {
 		// For payment confirmation, update the database first
-		if err := c.OrderRepository.UpdateOrderStatus(tx, orderID, orderStatus); err != nil {
+		if err := c.changeOrderStatus(tx, order, orderStatus, actorFromContext(ctx)); err != nil {
 			c.Log.Warnf("Failed to update order status: %+v", err)
 			return fiber.ErrInternalServerError
 		}
 
+		// The reserved stock is deducted below, so the expiry sweep must not release it
+		if err := c.ReservationRepository.DeactivateReservationsByOrderID(tx, orderID); err != nil {
+			c.Log.Warnf("Failed to deactivate reservations: %+v", err)
+			return fiber.ErrInternalServerError
+		}
+
 		// Commit transaction before making external service call
 		if err := tx.Commit().Error; err != nil {
 			c.Log.Warnf("Failed to commit transaction: %+v", err)
 			return fiber.ErrInternalServerError
 		}
 
-		// Create a separate context for inventory operations
-		inventoryCtx, inventoryCancel := context.WithTimeout(context.Background(), 15*time.Second)
+		// The order is committed, so the deduction runs even if the request is cancelled
+		inventoryCtx, inventoryCancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationInventory)
 		defer inventoryCancel()
 
 		// Deduct stock permanently - this is now outside the transaction
-		if err := c.InventoryUseCase.ConfirmStockDeduction(inventoryCtx, order.OrderItems); err != nil {
+		err := c.InventoryUseCase.ConfirmStockDeduction(inventoryCtx, order.StockReference(), order.OrderItems)
+		if err != nil {
 			c.Log.Warnf("Failed to confirm stock deduction: %+v", err)
 			// The order is already marked as paid, so this is just a warning
-			// We'll need an operational process to reconcile these edge cases
-			return nil
+			// The failure is recorded so the order can be reconciled with ReconcilePaidOrder
 		}
+		c.recordStockDeduction(ctx, order.ID, err)
 
 		// Early return since we've already committed the transaction
 		return nil
 	}
 
 	// Update order status
-	if err := c.OrderRepository.UpdateOrderStatus(tx, orderID, orderStatus); err != nil {
+	if err := c.changeOrderStatus(tx, order, orderStatus, actorFromContext(ctx)); err != nil {
 		c.Log.Warnf("Failed to update order status: %+v", err)
 		return fiber.ErrInternalServerError
 	}
@@ -366,65 +891,793 @@ func (c *OrderUseCase) UpdateOrderStatus(ctx context.Context, orderID uint, stat
 	return nil
 }
 
-func (c *OrderUseCase) ProcessPayment(ctx context.Context, orderID uint) error {
-	// This is a simplified implementation
-	// In a real system, this would integrate with a payment gateway
-
-	// Create a new context with a timeout for database operations
-	dbCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+// GetOrderStatusHistory returns the status changes of an order, oldest first
+func (c *OrderUseCase) GetOrderStatusHistory(ctx context.Context, orderID uint) (*model.OrderStatusHistoryResponse, error) {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
 	defer cancel()
 
-	tx := c.DB.WithContext(dbCtx).Begin()
-	defer tx.Rollback()
+	db := c.DB.WithContext(dbCtx)
 
-	// Get order with its items
-	order, err := c.OrderRepository.FindOrderByID(tx, orderID)
+	order, err := c.OrderRepository.FindOrderByID(db, orderID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.Log.Warnf("Order not found: %d", orderID)
-			return fiber.ErrNotFound
+			return nil, fiber.ErrNotFound
 		}
 		c.Log.Warnf("Failed to find order: %+v", err)
-		return fiber.ErrInternalServerError
+		return nil, fiber.ErrInternalServerError
 	}
 
-	// Check if order is in pending status
-	if order.Status != entity.OrderStatusPending {
-		c.Log.Warnf("Cannot process payment for non-pending order: %d", orderID)
-		return fiber.ErrBadRequest
+	history, err := c.OrderRepository.FindOrderStatusHistory(db, orderID)
+	if err != nil {
+		c.Log.Warnf("Failed to find order status history: %+v", err)
+		return nil, fiber.ErrInternalServerError
 	}
 
-	// First update local database
-	// Update order status to paid
-	if err := c.OrderRepository.UpdateOrderStatus(tx, orderID, entity.OrderStatusPaid); err != nil {
+	return converter.OrderStatusHistoryToResponse(order, history), nil
+}
+
+// changeOrderStatus updates the order status and records the change in the status history within the same transaction.
+// When webhooks are enabled the change is also queued for delivery in that transaction.
+// Setting an order to the status it already has records nothing.
+func (c *OrderUseCase) changeOrderStatus(tx *gorm.DB, order *entity.Order, status entity.OrderStatus, actor string) error {
+	if err := c.OrderRepository.UpdateOrderStatus(tx, order.ID, status); err != nil {
+		return err
+	}
+
+	if order.Status == status {
+		return nil
+	}
+
+	err := c.OrderRepository.CreateOrderStatusHistory(tx, &entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: order.Status,
+		ToStatus:   status,
+		Actor:      actor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.WebhookRepository == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(model.OrderStatusChangedEvent{
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		FromStatus: string(order.Status),
+		ToStatus:   string(status),
+		Actor:      actor,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.WebhookRepository.EnqueueWebhookEvent(tx, &entity.WebhookEvent{
+		EventType: entity.WebhookEventOrderStatusChanged,
+		OrderID:   order.ID,
+		Payload:   string(payload),
+	})
+}
+
+// actorFromContext returns the authenticated caller recorded on the context, or the system actor when there is none
+func actorFromContext(ctx context.Context) string {
+	if userID := appContext.GetUserID(ctx); userID != "" {
+		return userID
+	}
+	return entity.StatusActorSystem
+}
+
+// CancelOrderItems cancels specific line items of a pending order and releases their reservations.
+// Items are matched by product and warehouse; the whole line is cancelled. When no lines remain,
+// the order itself is cancelled.
+func (c *OrderUseCase) CancelOrderItems(ctx context.Context, orderID uint, items []model.OrderItemRequest) (*model.OrderResponse, error) {
+	if len(items) == 0 {
+		c.Log.Warn("At least one item is required to cancel")
+		return nil, fiber.ErrBadRequest
+	}
+
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	// Lock the order so a payment cannot claim it while its items are cancelled
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Order not found: %d", orderID)
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Only pending orders still hold reservations that can be released
+	if order.Status == entity.OrderStatusPaymentProcessing {
+		c.Log.Warnf("Cannot cancel items of order %d while its payment is in progress", orderID)
+		return nil, entity.ErrPaymentInProgress
+	}
+	if order.Status != entity.OrderStatusPending {
+		c.Log.Warnf("Cannot cancel items of non-pending order: %d", orderID)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Resolve every requested item to a line of this order
+	cancelled := make(map[uint]bool, len(items))
+	var cancelledItems []entity.OrderItem
+	for _, item := range items {
+		found := false
+		for _, orderItem := range order.OrderItems {
+			if orderItem.ProductID == item.ProductID && orderItem.WarehouseID == item.WarehouseID {
+				found = true
+				if !cancelled[orderItem.ID] {
+					cancelled[orderItem.ID] = true
+					cancelledItems = append(cancelledItems, orderItem)
+				}
+				break
+			}
+		}
+
+		if !found {
+			c.Log.Warnf("Item with product %d and warehouse %d is not part of order %d", item.ProductID, item.WarehouseID, orderID)
+			return nil, fiber.ErrBadRequest
+		}
+	}
+
+	// Recompute the subtotal from the remaining lines
+	var remainingTotal entity.Money
+	cancelledIDs := make([]uint, 0, len(cancelledItems))
+	for _, orderItem := range order.OrderItems {
+		if cancelled[orderItem.ID] {
+			cancelledIDs = append(cancelledIDs, orderItem.ID)
+			continue
+		}
+		remainingTotal += orderItem.TotalPrice
+	}
+
+	if err := c.ReservationRepository.DeactivateReservationsByOrderItems(tx, orderID, cancelledItems); err != nil {
+		c.Log.Warnf("Failed to deactivate reservations: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Queue the release in the same transaction so it is retried if the call below fails
+	releases, err := c.ReservationRepository.EnqueueReservationReleases(tx, orderID, order.StockReference(), cancelledItems)
+	if err != nil {
+		c.Log.Warnf("Failed to enqueue reservation releases: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if len(cancelledIDs) == len(order.OrderItems) {
+		// Every line is cancelled, so the order as a whole is cancelled
+		if err := c.changeOrderStatus(tx, order, entity.OrderStatusCancelled, actorFromContext(ctx)); err != nil {
+			c.Log.Warnf("Failed to update order status: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	} else {
+		if err := c.OrderRepository.DeleteOrderItems(tx, cancelledIDs); err != nil {
+			c.Log.Warnf("Failed to delete order items: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		// The tax rate and shipping cost fixed when the order was placed apply to the remaining lines
+		order.ApplyAmounts(remainingTotal, c.recomputeDiscount(tx, order, remainingTotal))
+		if err := c.OrderRepository.UpdateOrderAmounts(tx, order); err != nil {
+			c.Log.Warnf("Failed to update order total amount: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	// Commit transaction before making external service call
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Release stock for the cancelled lines only - this is outside the transaction
+	// The items are already cancelled, so a failed release only stays queued for RetryPendingReleases
+	c.releaseQueuedReservations(ctx, releases)
+
+	loadCtx, loadCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer loadCancel()
+
+	updatedOrder, err := c.OrderRepository.FindOrderByID(c.DB.WithContext(loadCtx), orderID)
+	if err != nil {
+		c.Log.Warnf("Failed to load updated order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.OrderToResponse(updatedOrder), nil
+}
+
+func (c *OrderUseCase) ProcessPayment(ctx context.Context, orderID uint) error {
+	// Claim the order for this payment; its row is locked only while the claim is recorded
+	order, err := c.claimPayment(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	// Charge the order outside any transaction, so no connection or row lock is held during the call
+	// The charge is keyed by the payment attempt, so a retry after a failed commit below is not charged twice
+	paymentCtx, paymentCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationPayment)
+	defer paymentCancel()
+
+	reference, err := c.PaymentGateway.Charge(paymentCtx, order, paymentIdempotencyKey(order))
+	if err != nil {
+		// The order goes back to pending either way so the customer can retry
+		declined := errors.Is(err, payment.ErrPaymentDeclined)
+		c.releasePaymentClaim(ctx, orderID, declined)
+
+		if declined {
+			c.Log.Warnf("Payment declined for order: %d", orderID)
+			c.Metrics.RecordPayment(metrics.ResultDeclined)
+			return entity.ErrPaymentDeclined
+		}
+		// Anything but a decline leaves the charge undecided; a retry carries the same key
+		c.Log.Warnf("Failed to charge order: %+v", err)
+		c.Metrics.RecordPayment(metrics.ResultFailure)
+		return entity.ErrPaymentUnavailable
+	}
+	c.Metrics.RecordPayment(metrics.ResultSuccess)
+
+	// The customer has been charged, so the payment is recorded even if the request is cancelled
+	dbCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	// Lock the order again to mark it paid
+	order, err = c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		c.Log.Errorf("Failed to find order %d charged with reference %s: %+v", orderID, reference, err)
+		return fiber.ErrInternalServerError
+	}
+	if err := c.checkPayable(order); err != nil {
+		// A payment that took over a stale claim was charged with the same key, so it is the same charge
+		if !errors.Is(err, entity.ErrOrderAlreadyPaid) {
+			c.Log.Errorf("Order %d was charged with reference %s but can no longer be paid: %+v", orderID, reference, err)
+		}
+		return err
+	}
+
+	// First update local database
+	// Update order status to paid
+	if err := c.changeOrderStatus(tx, order, entity.OrderStatusPaid, actorFromContext(ctx)); err != nil {
 		c.Log.Warnf("Failed to update order status: %+v", err)
 		return fiber.ErrInternalServerError
 	}
 
+	// Store the gateway transaction ID for reconciliation
+	if err := c.OrderRepository.UpdatePaymentReference(tx, orderID, reference); err != nil {
+		c.Log.Warnf("Failed to store payment reference: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	// The reserved stock is deducted below, so the expiry sweep must not release it
+	if err := c.ReservationRepository.DeactivateReservationsByOrderID(tx, orderID); err != nil {
+		c.Log.Warnf("Failed to deactivate reservations: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
 	// Commit transaction
+	// If this fails the claim goes stale, and a retry gets the same charge back from the gateway
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return fiber.ErrInternalServerError
 	}
 
-	// Create a separate context for inventory operations
-	inventoryCtx, inventoryCancel := context.WithTimeout(context.Background(), 15*time.Second)
+	// The payment is committed, so the deduction runs even if the request is cancelled
+	inventoryCtx, inventoryCancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationInventory)
 	defer inventoryCancel()
 
 	// Now that the database transaction is committed, make the external service call
 	// Permanently deduct stock from inventory (converting reservation to actual sale)
-	if err := c.InventoryUseCase.ConfirmStockDeduction(inventoryCtx, order.OrderItems); err != nil {
+	err = c.InventoryUseCase.ConfirmStockDeduction(inventoryCtx, order.StockReference(), order.OrderItems)
+	if err != nil {
 		c.Log.Warnf("Failed to confirm stock deduction: %+v", err)
 		// The order is already marked as paid, so log but don't fail the operation
-		// This would typically trigger an alert for manual reconciliation
+		// The failure is recorded so the order can be reconciled with ReconcilePaidOrder
+	}
+	c.recordStockDeduction(ctx, orderID, err)
+
+	return nil
+}
+
+// claimPayment locks a pending order and moves it to payment_processing, so it cannot be paid,
+// cancelled or changed by another request while it is being charged. A claim older than
+// paymentClaimTimeout belongs to a payment that never finished and is taken over.
+func (c *OrderUseCase) claimPayment(ctx context.Context, orderID uint) (*entity.Order, error) {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	// A concurrent payment for the same order waits here and then finds it claimed
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Order not found: %d", orderID)
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if order.Status == entity.OrderStatusPaymentProcessing {
+		if time.Since(order.UpdatedAt) < c.paymentClaimTimeout() {
+			// The payment in flight pays the order, so this one is refused as already paid
+			c.Log.Warnf("Payment for order %d is already in progress", orderID)
+			return nil, entity.ErrOrderAlreadyPaid
+		}
+		// Charging again is safe because the gateway returns the original charge for the same key
+		c.Log.Warnf("Taking over stale payment of order %d claimed at %s", orderID, order.UpdatedAt)
+	} else if err := c.checkPayable(order); err != nil {
+		return nil, err
+	}
+
+	// Setting the status again on a stale claim renews it
+	if err := c.changeOrderStatus(tx, order, entity.OrderStatusPaymentProcessing, actorFromContext(ctx)); err != nil {
+		c.Log.Warnf("Failed to update order status: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	order.Status = entity.OrderStatusPaymentProcessing
+	return order, nil
+}
+
+// releasePaymentClaim moves an order whose charge failed back to pending. A declined charge ends its
+// payment attempt, so the next payment is charged with a new idempotency key; the gateway would
+// otherwise answer it with the stored decline. An undecided charge keeps its key, since it may have
+// gone through. If this fails the claim goes stale and is taken over by the next payment attempt.
+func (c *OrderUseCase) releasePaymentClaim(ctx context.Context, orderID uint, declined bool) {
+	dbCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		c.Log.Warnf("Failed to find order %d to release its payment claim: %+v", orderID, err)
+		return
+	}
+	if order.Status != entity.OrderStatusPaymentProcessing {
+		return
+	}
+
+	if err := c.changeOrderStatus(tx, order, entity.OrderStatusPending, actorFromContext(ctx)); err != nil {
+		c.Log.Warnf("Failed to release payment claim of order %d: %+v", orderID, err)
+		return
+	}
+	if declined {
+		if err := c.OrderRepository.IncrementPaymentAttempts(tx, orderID); err != nil {
+			c.Log.Warnf("Failed to end payment attempt of order %d: %+v", orderID, err)
+			return
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to release payment claim of order %d: %+v", orderID, err)
+	}
+}
+
+// paymentClaimTimeout is how long a payment claim is honoured: long enough to charge the order
+// and record the result
+func (c *OrderUseCase) paymentClaimTimeout() time.Duration {
+	return c.Timeouts.For(appContext.OperationPayment) + c.Timeouts.For(appContext.OperationWrite)
+}
+
+// checkPayable returns nil if the order is pending or claimed for payment, or the error reporting why it cannot be paid
+func (c *OrderUseCase) checkPayable(order *entity.Order) error {
+	switch order.Status {
+	case entity.OrderStatusPending, entity.OrderStatusPaymentProcessing:
+		return nil
+	case entity.OrderStatusPaid, entity.OrderStatusCompleted:
+		c.Log.Warnf("Cannot process payment for already paid order: %d", order.ID)
+		return entity.ErrOrderAlreadyPaid
+	case entity.OrderStatusCancelled:
+		c.Log.Warnf("Cannot process payment for cancelled order: %d", order.ID)
+		return entity.ErrOrderCancelled
+	default:
+		c.Log.Warnf("Cannot process payment for order %d in status %s", order.ID, order.Status)
+		return fiber.ErrBadRequest
+	}
+}
+
+// paymentIdempotencyKey identifies the current payment attempt of an order to the payment gateway, so
+// charging the same attempt again returns the original charge instead of taking the payment twice.
+// The first attempt is keyed by the order alone.
+func paymentIdempotencyKey(order *entity.Order) string {
+	if order.PaymentAttempts == 0 {
+		return fmt.Sprintf("order-%d", order.ID)
+	}
+	return fmt.Sprintf("order-%d-attempt-%d", order.ID, order.PaymentAttempts)
+}
+
+// errStockAlreadyDeducted stops a reconciliation of an order whose stock has already been deducted
+var errStockAlreadyDeducted = errors.New("stock already deducted")
+
+// ReconcilePaidOrder retries the stock deduction of a paid order whose deduction failed after payment.
+// The order is claimed while the warehouse is called, so concurrent reconciliations deduct once without
+// holding its row lock; an order whose stock is already deducted is reported as already reconciled
+// without calling the warehouse.
+func (c *OrderUseCase) ReconcilePaidOrder(ctx context.Context, orderID uint) (*model.ReconcileOrderResponse, error) {
+	order, err := c.claimOrder(ctx, orderID, func(_ *gorm.DB, order *entity.Order) error {
+		// Only paid orders have stock left to deduct
+		if order.Status != entity.OrderStatusPaid && order.Status != entity.OrderStatusCompleted {
+			c.Log.Warnf("Cannot reconcile stock for order %d in status %s", orderID, order.Status)
+			return entity.ErrOrderNotPaid
+		}
+		if order.IsStockDeducted() {
+			return errStockAlreadyDeducted
+		}
+		return nil
+	})
+	if errors.Is(err, errStockAlreadyDeducted) {
+		return converter.ReconcileOrderToResponse(order, model.ReconcileResultAlreadyReconciled), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryCtx, inventoryCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationInventory)
+	defer inventoryCancel()
+
+	deductionErr := c.InventoryUseCase.ConfirmStockDeduction(inventoryCtx, order.StockReference(), order.OrderItems)
+	if deductionErr != nil {
+		c.Log.Warnf("Failed to reconcile stock deduction for order %d: %+v", orderID, deductionErr)
+	}
+
+	// The warehouse has been called, so its outcome is recorded even if the request is cancelled
+	// If this fails the claim goes stale, and the next reconciliation commits the same reference again
+	dbCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	deductedAt := time.Now()
+	if deductionErr != nil {
+		// Keep the failed attempt even though the reconciliation failed
+		err = c.OrderRepository.RecordStockDeductionFailure(tx, orderID, deductionErr.Error())
+	} else {
+		err = c.OrderRepository.RecordStockDeduction(tx, orderID, deductedAt)
+	}
+	if err != nil {
+		c.Log.Warnf("Failed to record stock deduction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.OrderRepository.ReleaseOrderClaim(tx, orderID); err != nil {
+		c.Log.Warnf("Failed to release claim of order %d: %+v", orderID, err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if deductionErr != nil {
+		return nil, entity.ErrStockDeductionFailed
+	}
+
+	order.StockDeductedAt = &deductedAt
+	order.StockDeductionAttempts++
+	return converter.ReconcileOrderToResponse(order, model.ReconcileResultReconciled), nil
+}
+
+// ReactivateOrder undoes the cancellation of an order whose original payment deadline has not passed yet.
+// The stock of its items is reserved again and new reservation rows expiring at that deadline are created
+// before the order goes back to pending. The order is claimed while the warehouse is called, so concurrent
+// reactivations reserve once without holding its row lock. Orders whose release is still queued are refused
+// until it has gone through, otherwise the queued release would later free the new reservation.
+func (c *OrderUseCase) ReactivateOrder(ctx context.Context, orderID uint) (*model.OrderResponse, error) {
+	order, err := c.claimOrder(ctx, orderID, func(tx *gorm.DB, order *entity.Order) error {
+		return c.checkReactivatable(tx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.OrderItemRequest, len(order.OrderItems))
+	for i, orderItem := range order.OrderItems {
+		items[i] = model.OrderItemRequest{
+			OrderID:     order.ID,
+			ProductID:   orderItem.ProductID,
+			WarehouseID: orderItem.WarehouseID,
+			Quantity:    orderItem.Quantity,
+			UnitPrice:   orderItem.UnitPrice,
+		}
+	}
+
+	inventoryCtx, inventoryCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationInventory)
+	defer inventoryCancel()
+
+	// The released reference cannot be reserved again, so the stock is held under a new one
+	reservationReference := newReservationReference()
+	if err := c.InventoryUseCase.CheckAndReserveStock(inventoryCtx, reservationReference, items); err != nil {
+		c.Log.Warnf("Failed to reserve stock for reactivated order %d: %+v", orderID, err)
+		c.releaseOrderClaim(ctx, orderID)
+
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			c.Metrics.RecordStockReservation(metrics.ResultInsufficientStock)
+			return nil, err
+		}
+
+		c.Metrics.RecordStockReservation(metrics.ResultFailure)
+		return nil, fiber.ErrInternalServerError
+	}
+	c.Metrics.RecordStockReservation(metrics.ResultSuccess)
+
+	if err := c.commitReactivation(ctx, orderID, reservationReference); err != nil {
+		// Release the reserved stock since the order stays cancelled
+		c.releaseStockForItems(ctx, reservationReference, items)
+		c.releaseOrderClaim(ctx, orderID)
+		return nil, err
+	}
+
+	loadCtx, loadCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer loadCancel()
+
+	reactivatedOrder, err := c.OrderRepository.FindOrderByID(c.DB.WithContext(loadCtx), orderID)
+	if err != nil {
+		c.Log.Warnf("Failed to load reactivated order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.OrderToResponse(reactivatedOrder), nil
+}
+
+// checkReactivatable returns nil if the order can be reactivated, or the error reporting why it cannot
+func (c *OrderUseCase) checkReactivatable(tx *gorm.DB, order *entity.Order) error {
+	if order.Status != entity.OrderStatusCancelled {
+		c.Log.Warnf("Cannot reactivate order %d in status %s", order.ID, order.Status)
+		return entity.ErrOrderNotCancelled
+	}
+
+	if !time.Now().Before(order.PaymentDeadline) {
+		c.Log.Warnf("Cannot reactivate order %d after its payment deadline", order.ID)
+		return entity.ErrPaymentDeadlinePassed
+	}
+
+	if len(order.OrderItems) == 0 {
+		c.Log.Warnf("Cannot reactivate order %d without items", order.ID)
+		return fiber.ErrBadRequest
+	}
+
+	pending, err := c.ReservationRepository.CountPendingReservationReleases(tx, order.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to count pending reservation releases: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if pending > 0 {
+		c.Log.Warnf("Cannot reactivate order %d while %d reservation releases are pending", order.ID, pending)
+		return entity.ErrReservationReleasePending
 	}
 
 	return nil
 }
 
-func (c *OrderUseCase) CancelExpiredOrders(ctx context.Context) error {
-	// Create a new context with a timeout for database operations
-	dbCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// commitReactivation stores the reservations held under reference and moves the cancelled order back to
+// pending, releasing its claim. The order is locked again, and one that is no longer cancelled is left alone.
+func (c *OrderUseCase) commitReactivation(ctx context.Context, orderID uint, reference string) error {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		c.Log.Warnf("Failed to find order: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	// An admin may have changed the order while the stock was being reserved
+	if order.Status != entity.OrderStatusCancelled {
+		c.Log.Warnf("Order %d left cancelled as %s while it was being reactivated", orderID, order.Status)
+		return entity.ErrOrderNotCancelled
+	}
+
+	// The cancelled reservations stay inactive; the new ones keep the original deadline
+	reservations := make([]entity.Reservation, len(order.OrderItems))
+	for i, orderItem := range order.OrderItems {
+		reservations[i] = entity.Reservation{
+			OrderID:     order.ID,
+			ProductID:   orderItem.ProductID,
+			WarehouseID: orderItem.WarehouseID,
+			Quantity:    orderItem.Quantity,
+			ExpiresAt:   order.PaymentDeadline,
+			IsActive:    true,
+		}
+	}
+
+	if err := c.ReservationRepository.CreateReservationBatch(tx, reservations); err != nil {
+		c.Log.Warnf("Failed to create stock reservations: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.OrderRepository.UpdateReservationReference(tx, orderID, reference); err != nil {
+		c.Log.Warnf("Failed to store reservation reference: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.changeOrderStatus(tx, order, entity.OrderStatusPending, actorFromContext(ctx)); err != nil {
+		c.Log.Warnf("Failed to update order status: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.OrderRepository.ReleaseOrderClaim(tx, orderID); err != nil {
+		c.Log.Warnf("Failed to release claim of order %d: %+v", orderID, err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// claimOrder locks the order, runs check on it and marks it claimed, so a reconciliation or reactivation can
+// call the warehouse without holding the row lock while concurrent ones are refused. A claim older than
+// orderClaimTimeout belongs to an operation that never finished and is taken over. The order is returned
+// along with the error of check, so the caller can still report on it.
+func (c *OrderUseCase) claimOrder(ctx context.Context, orderID uint, check func(tx *gorm.DB, order *entity.Order) error) (*entity.Order, error) {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Order not found: %d", orderID)
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := check(tx, order); err != nil {
+		return order, err
+	}
+
+	if order.ClaimedAt != nil {
+		if time.Since(*order.ClaimedAt) < c.orderClaimTimeout() {
+			c.Log.Warnf("Another operation on order %d is in progress", orderID)
+			return nil, entity.ErrOrderOperationInProgress
+		}
+		c.Log.Warnf("Taking over stale claim of order %d claimed at %s", orderID, *order.ClaimedAt)
+	}
+
+	if err := c.OrderRepository.ClaimOrder(tx, orderID, time.Now()); err != nil {
+		c.Log.Warnf("Failed to claim order %d: %+v", orderID, err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return order, nil
+}
+
+// releaseOrderClaim clears the claim of an operation that gave up. If this fails the claim goes stale
+// and is taken over by the next operation.
+func (c *OrderUseCase) releaseOrderClaim(ctx context.Context, orderID uint) {
+	dbCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	if err := c.OrderRepository.ReleaseOrderClaim(c.DB.WithContext(dbCtx), orderID); err != nil {
+		c.Log.Warnf("Failed to release claim of order %d: %+v", orderID, err)
+	}
+}
+
+// orderClaimTimeout is how long an order claim is honoured: long enough to call the warehouse
+// and record the result
+func (c *OrderUseCase) orderClaimTimeout() time.Duration {
+	return c.Timeouts.For(appContext.OperationInventory) + c.Timeouts.For(appContext.OperationWrite)
+}
+
+// CompleteOrder marks a paid order as shipped and completed, storing its carrier and tracking number.
+// The status change is recorded in the status history in the same transaction.
+func (c *OrderUseCase) CompleteOrder(ctx context.Context, orderID uint, tracking *model.CompleteOrderRequest) (*model.OrderResponse, error) {
+	tracking.Carrier = strings.TrimSpace(tracking.Carrier)
+	tracking.TrackingNumber = strings.TrimSpace(tracking.TrackingNumber)
+
+	// The validator errors are returned as is so the handler can report each failed field
+	if err := c.Validate.Struct(tracking); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, err
+	}
+
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	tx := c.DB.WithContext(dbCtx).Begin()
+	defer tx.Rollback()
+
+	order, err := c.OrderRepository.FindOrderByIDForUpdate(tx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Order not found: %d", orderID)
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Only paid orders can ship; pending and cancelled orders have not been paid
+	if order.Status == entity.OrderStatusCompleted {
+		c.Log.Warnf("Order %d has already been completed", orderID)
+		return nil, entity.ErrOrderAlreadyCompleted
+	}
+	if order.Status != entity.OrderStatusPaid {
+		c.Log.Warnf("Cannot complete order %d in status %s", orderID, order.Status)
+		return nil, entity.ErrOrderNotPaid
+	}
+
+	if err := c.OrderRepository.RecordShipment(tx, orderID, tracking.Carrier, tracking.TrackingNumber, time.Now()); err != nil {
+		c.Log.Warnf("Failed to record shipment: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.changeOrderStatus(tx, order, entity.OrderStatusCompleted, actorFromContext(ctx)); err != nil {
+		c.Log.Warnf("Failed to update order status: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	loadCtx, loadCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationRead)
+	defer loadCancel()
+
+	completedOrder, err := c.OrderRepository.FindOrderByID(c.DB.WithContext(loadCtx), orderID)
+	if err != nil {
+		c.Log.Warnf("Failed to load completed order: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.OrderToResponse(completedOrder), nil
+}
+
+// recordStockDeduction stores the outcome of deducting a paid order's stock, so orders that were paid
+// but not deducted can be found and reconciled. A failure to record is only logged.
+func (c *OrderUseCase) recordStockDeduction(ctx context.Context, orderID uint, deductionErr error) {
+	// Runs after the payment was committed, so it is not cut short by the request being cancelled
+	dbCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	db := c.DB.WithContext(dbCtx)
+
+	var err error
+	if deductionErr != nil {
+		err = c.OrderRepository.RecordStockDeductionFailure(db, orderID, deductionErr.Error())
+	} else {
+		err = c.OrderRepository.RecordStockDeduction(db, orderID, time.Now())
+	}
+	if err != nil {
+		c.Log.Warnf("Failed to record stock deduction for order %d: %+v", orderID, err)
+	}
+}
+
+func (c *OrderUseCase) CancelExpiredOrders(ctx context.Context) (*model.ExpirySweepResult, error) {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationCommit)
 	defer cancel()
 
 	tx := c.DB.WithContext(dbCtx).Begin()
@@ -436,43 +1689,42 @@ func (c *OrderUseCase) CancelExpiredOrders(ctx context.Context) error {
 	expiredOrders, err := c.OrderRepository.FindExpiredOrders(tx, currentTime)
 	if err != nil {
 		c.Log.Warnf("Failed to find expired orders: %+v", err)
-		return fiber.ErrInternalServerError
+		return nil, fiber.ErrInternalServerError
 	}
 
+	// Releases are queued alongside the deactivations and sent once the transaction commits
+	var releases []entity.ReservationReleaseOutbox
+
 	for _, order := range expiredOrders {
 		// First update the database to mark the order as cancelled and deactivate reservations
 		// This ensures we don't leave the database in an inconsistent state if inventory release fails
 
 		// Update order status to cancelled
-		if err := c.OrderRepository.UpdateOrderStatus(tx, order.ID, entity.OrderStatusCancelled); err != nil {
+		if err := c.changeOrderStatus(tx, &order, entity.OrderStatusCancelled, entity.StatusActorSystem); err != nil {
 			c.Log.Warnf("Failed to update order status: %+v", err)
-			return fiber.ErrInternalServerError
+			return nil, fiber.ErrInternalServerError
 		}
 
 		// Deactivate reservations in tracking table
 		if err := c.ReservationRepository.DeactivateReservationsByOrderID(tx, order.ID); err != nil {
 			c.Log.Warnf("Failed to deactivate reservations: %+v", err)
-			return fiber.ErrInternalServerError
+			return nil, fiber.ErrInternalServerError
 		}
 
-		// Create a separate context for inventory operations
-		inventoryCtx, inventoryCancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer inventoryCancel()
-
-		// Release stock in inventory system (after database updates)
-		// If this fails, at least the database is consistent and we can retry inventory releases
-		if err := c.InventoryUseCase.ReleaseReservation(inventoryCtx, order.OrderItems); err != nil {
-			c.Log.Warnf("Failed to release inventory for expired order %d: %+v", order.ID, err)
-			// Continue with other orders rather than failing the entire process
-			// The warehouse service should have its own cleanup process for handling orphaned reservations
+		// Queue the stock release so it is retried if the call after the commit fails
+		queued, err := c.ReservationRepository.EnqueueReservationReleases(tx, order.ID, order.StockReference(), order.OrderItems)
+		if err != nil {
+			c.Log.Warnf("Failed to enqueue reservation releases: %+v", err)
+			return nil, fiber.ErrInternalServerError
 		}
+		releases = append(releases, queued...)
 	}
 
 	// Find expired reservations that are still active
 	expiredReservations, err := c.ReservationRepository.FindExpiredReservations(tx, currentTime)
 	if err != nil {
 		c.Log.Warnf("Failed to find expired reservations: %+v", err)
-		return fiber.ErrInternalServerError
+		return nil, fiber.ErrInternalServerError
 	}
 
 	// Process expired reservations in groups by order
@@ -481,13 +1733,13 @@ func (c *OrderUseCase) CancelExpiredOrders(ctx context.Context) error {
 		reservationsByOrder[reservation.OrderID] = append(reservationsByOrder[reservation.OrderID], reservation)
 	}
 
-	// For each order's reservations, update tracking table first, then release inventory
+	// For each order's reservations, update tracking table first, then queue the inventory release
 	for orderID, reservations := range reservationsByOrder {
 		// First update reservation status in tracking table
 		for _, res := range reservations {
 			if err := c.ReservationRepository.UpdateReservationStatus(tx, res.ID, false); err != nil {
 				c.Log.Warnf("Failed to deactivate reservation: %+v", err)
-				return fiber.ErrInternalServerError
+				return nil, fiber.ErrInternalServerError
 			}
 		}
 
@@ -501,23 +1753,110 @@ func (c *OrderUseCase) CancelExpiredOrders(ctx context.Context) error {
 			})
 		}
 
-		// Release inventory after database is updated
-		// Create a separate context for inventory operations
-		invCtx, invCancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer invCancel()
+		// The reservations are released under the reference of their order
+		order := reservations[0].Order
+		if order == nil {
+			order = &entity.Order{ID: orderID}
+		}
 
-		if err := c.InventoryUseCase.ReleaseReservation(invCtx, orderItems); err != nil {
-			c.Log.Warnf("Failed to release inventory for expired reservations of order %d: %+v", orderID, err)
-			// Continue with other reservations rather than failing the entire process
-			// The warehouse service should have its own cleanup process
+		queued, err := c.ReservationRepository.EnqueueReservationReleases(tx, orderID, order.StockReference(), orderItems)
+		if err != nil {
+			c.Log.Warnf("Failed to enqueue reservation releases: %+v", err)
+			return nil, fiber.ErrInternalServerError
 		}
+		releases = append(releases, queued...)
 	}
 
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Release stock in inventory system after the database is updated. A failed release
+	// stays queued for RetryPendingReleases rather than failing the sweep.
+	c.releaseQueuedReservations(ctx, releases)
+
+	return &model.ExpirySweepResult{
+		CancelledOrders:      len(expiredOrders),
+		ReleasedReservations: len(expiredReservations),
+	}, nil
+}
+
+// RetryPendingReleases drains the reservation release outbox, retrying each queued
+// release against the inventory system and marking it done once it succeeds
+func (c *OrderUseCase) RetryPendingReleases(ctx context.Context) error {
+	dbCtx, cancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationCommit)
+	defer cancel()
+
+	db := c.DB.WithContext(dbCtx)
+
+	pending, err := c.ReservationRepository.FindPendingReservationReleases(db, pendingReleaseBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find pending reservation releases: %+v", err)
 		return fiber.ErrInternalServerError
 	}
 
+	for _, entry := range pending {
+		// Each release gets its own inventory timeout
+		inventoryCtx, inventoryCancel := appContext.WithOperationTimeout(ctx, c.Timeouts, appContext.OperationInventory)
+		err := c.InventoryUseCase.ReleaseReservation(inventoryCtx, entry.StockReference(), []entity.OrderItem{entry.ToOrderItem()})
+		inventoryCancel()
+
+		if err != nil {
+			c.Log.Warnf("Failed to retry reservation release %d for order %d: %+v", entry.ID, entry.OrderID, err)
+			if err := c.ReservationRepository.RecordReservationReleaseFailure(db, entry.ID, err.Error()); err != nil {
+				c.Log.Warnf("Failed to record reservation release failure: %+v", err)
+			}
+			continue
+		}
+
+		if err := c.ReservationRepository.MarkReservationReleasesDone(db, []uint{entry.ID}); err != nil {
+			c.Log.Warnf("Failed to mark reservation release %d done: %+v", entry.ID, err)
+		}
+	}
+
 	return nil
 }
+
+// releaseQueuedReservations releases the reservation of each queued entry on its own, the way
+// RetryPendingReleases does, and marks only the entries whose release succeeded as done.
+// The others stay pending for RetryPendingReleases.
+func (c *OrderUseCase) releaseQueuedReservations(ctx context.Context, releases []entity.ReservationReleaseOutbox) {
+	released := make([]entity.ReservationReleaseOutbox, 0, len(releases))
+	for _, entry := range releases {
+		// The releases are committed, so they run even if the request is cancelled
+		inventoryCtx, inventoryCancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationInventory)
+		err := c.InventoryUseCase.ReleaseReservation(inventoryCtx, entry.StockReference(), []entity.OrderItem{entry.ToOrderItem()})
+		inventoryCancel()
+
+		if err != nil {
+			c.Log.Warnf("Failed to release reservation %d for order %d: %+v", entry.ID, entry.OrderID, err)
+			continue
+		}
+		released = append(released, entry)
+	}
+
+	c.markReleasesDone(ctx, released)
+}
+
+// markReleasesDone marks queued releases as done after the inventory release succeeded.
+// A failure here only means the release is retried, which the warehouse service tolerates.
+func (c *OrderUseCase) markReleasesDone(ctx context.Context, releases []entity.ReservationReleaseOutbox) {
+	if len(releases) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(releases))
+	for i, release := range releases {
+		ids[i] = release.ID
+	}
+
+	// Runs after the release succeeded, so it is not cut short by the request being cancelled
+	dbCtx, cancel := appContext.WithDetachedTimeout(ctx, c.Timeouts, appContext.OperationWrite)
+	defer cancel()
+
+	if err := c.ReservationRepository.MarkReservationReleasesDone(c.DB.WithContext(dbCtx), ids); err != nil {
+		c.Log.Warnf("Failed to mark reservation releases done: %+v", err)
+	}
+}
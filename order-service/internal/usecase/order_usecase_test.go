@@ -3,8 +3,20 @@ package usecase
 import (
 	"context"
 	"errors"
+	"io"
+	appContext "order-service/internal/context"
 	"order-service/internal/entity"
+	appErrors "order-service/internal/errors"
+	"order-service/internal/gateway/payment"
+	"order-service/internal/gateway/shipping"
+	"order-service/internal/gateway/tax"
+	"order-service/internal/metrics"
 	"order-service/internal/model"
+	"order-service/internal/repository"
+	payment_mock "order-service/mocks/gateway/payment"
+	product_mock "order-service/mocks/gateway/product"
+	shipping_mock "order-service/mocks/gateway/shipping"
+	tax_mock "order-service/mocks/gateway/tax"
 	repository_mock "order-service/mocks/repository"
 	usecase_mock "order-service/mocks/usecase"
 	"testing"
@@ -12,7 +24,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -21,6 +35,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// newTestValidator returns a validator that accepts the default payment methods, as config.NewValidator does without order.payment_methods
+func newTestValidator() *validator.Validate {
+	validate := validator.New()
+	model.RegisterPaymentMethods(validate, model.DefaultPaymentMethods)
+	return validate
+}
+
 func TestOrderUseCase_CreateOrder(t *testing.T) {
 	// Create SQL mock
 	sqlDB, sqlMock, err := sqlmock.New()
@@ -53,20 +74,30 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 	
 	// Setup inventory use case expectations - AnyTimes to prevent conflicts between subtests
 	mockInventoryUseCase.EXPECT().
-		CheckAndReserveStock(gomock.Any(), gomock.Any()).
+		CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil).
 		AnyTimes()
 		
 	// For error recovery in error case
 	mockInventoryUseCase.EXPECT().
-		ReleaseReservation(gomock.Any(), gomock.Any()).
+		ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil).
 		AnyTimes()
 	
 	// Create use case
 	logger := logrus.New()
-	validate := validator.New()
-	orderUseCase := NewOrderUseCase(db, logger, validate, mockOrderRepo, mockReservationRepo, mockInventoryUseCase)
+	validate := newTestValidator()
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
 	
 	// Test case 1: Successful order creation
 	t.Run("SuccessfulOrderCreation", func(t *testing.T) {
@@ -80,7 +111,7 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 					ProductID:   1,
 					WarehouseID: 1,
 					Quantity:    2,
-					UnitPrice:   10.0,
+					UnitPrice:   1000,
 				},
 			},
 		}
@@ -115,7 +146,7 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 			ID:              1,
 			UserID:          createRequest.UserID,
 			Status:          entity.OrderStatusPending,
-			TotalAmount:     20.0,
+			TotalAmount:     2000,
 			ShippingAddress: createRequest.ShippingAddress,
 			PaymentMethod:   createRequest.PaymentMethod,
 			PaymentDeadline: time.Now().Add(24 * time.Hour),
@@ -129,7 +160,7 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 					WarehouseID: createRequest.Items[0].WarehouseID,
 					Quantity:    createRequest.Items[0].Quantity,
 					UnitPrice:   createRequest.Items[0].UnitPrice,
-					TotalPrice:  20.0,
+					TotalPrice:  2000,
 				},
 			},
 		}
@@ -146,7 +177,7 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 		assert.Equal(t, uint(1), response.ID)
 		assert.Equal(t, createRequest.UserID, response.UserID)
 		assert.Equal(t, "pending", response.Status)
-		assert.Equal(t, 20.0, response.TotalAmount)
+		assert.Equal(t, entity.Money(2000), response.TotalAmount)
 		assert.Equal(t, 1, len(response.Items))
 		
 		// Verify mock expectations
@@ -186,7 +217,7 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 					ProductID:   1,
 					WarehouseID: 1,
 					Quantity:    2,
-					UnitPrice:   10.0,
+					UnitPrice:   1000,
 				},
 			},
 		}
@@ -210,6 +241,124 @@ func TestOrderUseCase_CreateOrder(t *testing.T) {
 	})
 }
 
+func TestOrderUseCase_CreateOrder_RetriesDeadlock(t *testing.T) {
+	deadlock := &mysqlDriver.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+	createRequest := &model.CreateOrderRequest{
+		UserID:          "test-user-id",
+		ShippingAddress: "123 Test St",
+		PaymentMethod:   "credit_card",
+		Items: []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+		},
+	}
+
+	newUseCase := func(t *testing.T, retry DeadlockRetry) (OrderUseCaseInterface, *repository_mock.OrderRepositoryMock, *repository_mock.ReservationRepositoryMock, *usecase_mock.MockInventoryUseCaseInterface) {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+		sqlMock.MatchExpectationsInOrder(false)
+		for i := 0; i <= retry.MaxRetries; i++ {
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectRollback()
+		}
+		sqlMock.ExpectCommit()
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		logger := logrus.New()
+		logger.SetOutput(io.Discard)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              newTestValidator(),
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+			DeadlockRetry:         retry,
+		})
+		return orderUseCase, mockOrderRepo, mockReservationRepo, mockInventoryUseCase
+	}
+
+	t.Run("SucceedsOnRetry", func(t *testing.T) {
+		orderUseCase, mockOrderRepo, mockReservationRepo, mockInventoryUseCase := newUseCase(t, DeadlockRetry{MaxRetries: 2, Backoff: time.Millisecond})
+
+		// The stock is reserved for each attempt and released once, after the deadlocked one
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), createRequest.Items).Return(nil).Times(2)
+		mockInventoryUseCase.EXPECT().ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.Order).ID = 1
+		}).Return(nil).Twice()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Twice()
+
+		// The first attempt deadlocks while reserving rows shared with another order
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(deadlock).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(&entity.Order{
+			ID:     1,
+			UserID: createRequest.UserID,
+			Status: entity.OrderStatusPending,
+		}, nil).Once()
+
+		response, err := orderUseCase.CreateOrder(context.Background(), createRequest)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, response) {
+			assert.Equal(t, uint(1), response.ID)
+		}
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("RetriesExhausted", func(t *testing.T) {
+		orderUseCase, mockOrderRepo, _, mockInventoryUseCase := newUseCase(t, DeadlockRetry{MaxRetries: 1, Backoff: time.Millisecond})
+
+		// Every attempt deadlocks, and each releases the stock it reserved
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), createRequest.Items).Return(nil).Times(2)
+		mockInventoryUseCase.EXPECT().ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(deadlock).Twice()
+
+		response, err := orderUseCase.CreateOrder(context.Background(), createRequest)
+
+		assert.ErrorIs(t, err, entity.ErrOrderContention)
+		assert.Nil(t, response)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherErrorsAreNotRetried", func(t *testing.T) {
+		orderUseCase, mockOrderRepo, _, mockInventoryUseCase := newUseCase(t, DeadlockRetry{MaxRetries: 3, Backoff: time.Millisecond})
+
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), createRequest.Items).Return(nil).Times(1)
+		mockInventoryUseCase.EXPECT().ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(&mysqlDriver.MySQLError{Number: 1062, Message: "Duplicate entry"}).Once()
+
+		response, err := orderUseCase.CreateOrder(context.Background(), createRequest)
+
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		assert.Nil(t, response)
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
 func TestOrderUseCase_GetOrderByID(t *testing.T) {
 	// Create SQL mock
 	sqlDB, _, err := sqlmock.New()
@@ -238,8 +387,18 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 	
 	// Create use case
 	logger := logrus.New()
-	validate := validator.New()
-	orderUseCase := NewOrderUseCase(db, logger, validate, mockOrderRepo, mockReservationRepo, mockInventoryUseCase)
+	validate := newTestValidator()
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
 	
 	// Test case 1: Order found
 	t.Run("OrderFound", func(t *testing.T) {
@@ -248,7 +407,7 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 			ID:              1,
 			UserID:          "test-user-id",
 			Status:          entity.OrderStatusPending,
-			TotalAmount:     20.0,
+			TotalAmount:     2000,
 			ShippingAddress: "123 Test St",
 			PaymentMethod:   "credit_card",
 			PaymentDeadline: time.Now().Add(24 * time.Hour),
@@ -261,8 +420,8 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 					ProductID:   1,
 					WarehouseID: 1,
 					Quantity:    2,
-					UnitPrice:   10.0,
-					TotalPrice:  20.0,
+					UnitPrice:   1000,
+					TotalPrice:  2000,
 				},
 			},
 		}
@@ -272,7 +431,7 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 		
 		// Call the method
 		ctx := context.Background()
-		response, err := orderUseCase.GetOrderByID(ctx, 1)
+		response, err := orderUseCase.GetOrderByID(ctx, 1, false)
 		
 		// Assertions
 		assert.NoError(t, err)
@@ -280,22 +439,85 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 		assert.Equal(t, uint(1), response.ID)
 		assert.Equal(t, "test-user-id", response.UserID)
 		assert.Equal(t, "pending", response.Status)
-		assert.Equal(t, 20.0, response.TotalAmount)
+		assert.Equal(t, entity.Money(2000), response.TotalAmount)
 		assert.Equal(t, 1, len(response.Items))
 		
 		// Verify mock expectations
 		mockOrderRepo.AssertExpectations(t)
 	})
 	
+	// Test case: Items expose reserved and fulfillment warehouses
+	t.Run("OrderItemsIncludeWarehouses", func(t *testing.T) {
+		order := &entity.Order{
+			ID:              2,
+			UserID:          "test-user-id",
+			Status:          entity.OrderStatusPending,
+			TotalAmount:     3500,
+			PaymentDeadline: time.Now().Add(24 * time.Hour),
+			OrderItems: []entity.OrderItem{
+				{ID: 1, OrderID: 2, ProductID: 1, WarehouseID: 3, Quantity: 2, UnitPrice: 1000, TotalPrice: 2000},
+				{ID: 2, OrderID: 2, ProductID: 2, WarehouseID: 5, Quantity: 1, UnitPrice: 1500, TotalPrice: 1500},
+			},
+			Reservations: []entity.Reservation{
+				{ID: 1, OrderID: 2, ProductID: 1, WarehouseID: 4, Quantity: 2, IsActive: true},
+				{ID: 2, OrderID: 2, ProductID: 2, WarehouseID: 5, Quantity: 1, IsActive: true},
+			},
+		}
+
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(2)).Return(order, nil).Once()
+
+		response, err := orderUseCase.GetOrderByID(context.Background(), 2, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Items, 2)
+		assert.Equal(t, uint(4), response.Items[0].ReservedWarehouseID)
+		assert.Equal(t, uint(3), response.Items[0].FulfillmentWarehouseID)
+		assert.Equal(t, uint(5), response.Items[1].ReservedWarehouseID)
+		assert.Equal(t, uint(5), response.Items[1].FulfillmentWarehouseID)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case: Reservation summary is only attached when requested
+	t.Run("ReservationSummaryOnlyWhenRequested", func(t *testing.T) {
+		order := &entity.Order{
+			ID:              3,
+			UserID:          "test-user-id",
+			Status:          entity.OrderStatusPending,
+			TotalAmount:     2000,
+			PaymentDeadline: time.Now().Add(24 * time.Hour),
+		}
+
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(3)).Return(order, nil).Twice()
+		mockReservationRepo.On("FindReservationsByOrderID", mock.Anything, uint(3)).Return([]entity.Reservation{
+			{ID: 1, OrderID: 3, ProductID: 1, WarehouseID: 1, Quantity: 2, IsActive: true},
+			{ID: 2, OrderID: 3, ProductID: 2, WarehouseID: 1, Quantity: 1, IsActive: true},
+			{ID: 3, OrderID: 3, ProductID: 3, WarehouseID: 2, Quantity: 1, IsActive: false},
+		}, nil).Once()
+
+		// Default response is unchanged
+		response, err := orderUseCase.GetOrderByID(context.Background(), 3, false)
+		assert.NoError(t, err)
+		assert.Nil(t, response.ReservationSummary)
+
+		// Summary is attached when requested
+		response, err = orderUseCase.GetOrderByID(context.Background(), 3, true)
+		assert.NoError(t, err)
+		assert.Equal(t, &model.ReservationSummary{Active: 2, Committed: 0, Released: 1}, response.ReservationSummary)
+
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertNumberOfCalls(t, "FindReservationsByOrderID", 1)
+	})
+
 	// Test case 2: Order not found
 	t.Run("OrderNotFound", func(t *testing.T) {
 		// Set up expectations for the mock
 		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(999)).
 			Return(nil, gorm.ErrRecordNotFound).Once()
-		
+
 		// Call the method
 		ctx := context.Background()
-		response, err := orderUseCase.GetOrderByID(ctx, 999)
+		response, err := orderUseCase.GetOrderByID(ctx, 999, false)
 		
 		// Assertions
 		assert.Error(t, err)
@@ -314,7 +536,7 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 		
 		// Call the method
 		ctx := context.Background()
-		response, err := orderUseCase.GetOrderByID(ctx, 1)
+		response, err := orderUseCase.GetOrderByID(ctx, 1, false)
 		
 		// Assertions
 		assert.Error(t, err)
@@ -324,6 +546,23 @@ func TestOrderUseCase_GetOrderByID(t *testing.T) {
 		// Verify mock expectations
 		mockOrderRepo.AssertExpectations(t)
 	})
+
+	// Test case 4: The query runs under a child of the caller's context
+	t.Run("CallerCancellationPropagates", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mockOrderRepo.On("FindOrderByID", mock.MatchedBy(func(db *gorm.DB) bool {
+			_, hasDeadline := db.Statement.Context.Deadline()
+			return hasDeadline && errors.Is(db.Statement.Context.Err(), context.Canceled)
+		}), uint(4)).Return(nil, context.Canceled).Once()
+
+		response, err := orderUseCase.GetOrderByID(ctx, 4, false)
+
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		assert.Nil(t, response)
+		mockOrderRepo.AssertExpectations(t)
+	})
 }
 
 func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
@@ -381,20 +620,30 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 		
 		// Setup inventory use case expectations
 		mockInventoryUseCase.EXPECT().
-			ConfirmStockDeduction(gomock.Any(), gomock.Any()).
+			ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil).
 			AnyTimes()
 		
 		// Create use case with first DB
 		logger := logrus.New()
-		validate := validator.New()
-		orderUseCase := NewOrderUseCase(db1, logger, validate, mockOrderRepo, mockReservationRepo, mockInventoryUseCase)
+		validate := newTestValidator()
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db1,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
 
 		order := &entity.Order{
 			ID:              1,
 			UserID:          "test-user-id",
 			Status:          entity.OrderStatusPending,
-			TotalAmount:     20.0,
+			TotalAmount:     2000,
 			ShippingAddress: "123 Test St",
 			PaymentMethod:   "credit_card",
 			OrderItems: []entity.OrderItem{
@@ -404,18 +653,21 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 					ProductID:   1,
 					WarehouseID: 1,
 					Quantity:    2,
-					UnitPrice:   10.0,
-					TotalPrice:  20.0,
+					UnitPrice:   1000,
+					TotalPrice:  2000,
 				},
 			},
 		}
 		
 		// Set up expectations for the mock
-		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
 		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPaid).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusPaid, Actor: "admin-user-id"}).Return(nil).Once()
+		mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Once()
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
 		
-		// Call the method
-		ctx := context.Background()
+		// Call the method as an authenticated admin, who is recorded as the actor
+		ctx := appContext.WithUserID(context.Background(), "admin-user-id")
 		err := orderUseCase.UpdateOrderStatus(ctx, 1, "paid")
 		
 		// Assertions
@@ -438,20 +690,30 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 		
 		// Setup inventory use case expectations
 		mockInventoryUseCase.EXPECT().
-			ReleaseReservation(gomock.Any(), gomock.Any()).
+			ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil).
 			AnyTimes()
 		
 		// Create use case with second DB
 		logger := logrus.New()
-		validate := validator.New()
-		orderUseCase := NewOrderUseCase(db2, logger, validate, mockOrderRepo, mockReservationRepo, mockInventoryUseCase)
+		validate := newTestValidator()
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db2,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
 
 		order := &entity.Order{
 			ID:              1,
 			UserID:          "test-user-id",
 			Status:          entity.OrderStatusPending,
-			TotalAmount:     20.0,
+			TotalAmount:     2000,
 			ShippingAddress: "123 Test St",
 			PaymentMethod:   "credit_card",
 			OrderItems: []entity.OrderItem{
@@ -461,16 +723,20 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 					ProductID:   1,
 					WarehouseID: 1,
 					Quantity:    2,
-					UnitPrice:   10.0,
-					TotalPrice:  20.0,
+					UnitPrice:   1000,
+					TotalPrice:  2000,
 				},
 			},
 		}
 		
 		// Set up expectations for the mock
-		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
 		mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Once()
+		mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", order.OrderItems).
+			Return([]entity.ReservationReleaseOutbox{{ID: 10, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2}}, nil).Once()
+		mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{10}).Return(nil).Once()
 		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusCancelled).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusCancelled, Actor: entity.StatusActorSystem}).Return(nil).Once()
 		
 		// Call the method
 		ctx := context.Background()
@@ -511,8 +777,18 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 	
 	// Create use case with third DB
 	logger := logrus.New()
-	validate := validator.New()
-	orderUseCase := NewOrderUseCase(db3, logger, validate, mockOrderRepo, mockReservationRepo, mockInventoryUseCase)
+	validate := newTestValidator()
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db3,
+		Log:                   logger,
+		Validate:              validate,
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
 	
 	// Test case 3: Invalid status
 	t.Run("InvalidStatus", func(t *testing.T) {
@@ -528,7 +804,7 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 	// Test case 4: Order not found
 	t.Run("OrderNotFound", func(t *testing.T) {
 		// Set up expectations for the mock
-		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(999)).
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(999)).
 			Return(nil, gorm.ErrRecordNotFound).Once()
 		
 		// Call the method
@@ -542,4 +818,4009 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 		// Verify mock expectations
 		mockOrderRepo.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+}
+
+func TestOrderUseCase_UpdateOrderStatus_CancelReleasesEachItem(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	order := &entity.Order{
+		ID:     1,
+		Status: entity.OrderStatusPending,
+		OrderItems: []entity.OrderItem{
+			{ID: 1, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2},
+			{ID: 2, OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1},
+		},
+	}
+	mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+	mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Once()
+	mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", order.OrderItems).
+		Return([]entity.ReservationReleaseOutbox{
+			{ID: 10, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2},
+			{ID: 11, OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1},
+		}, nil).Once()
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusCancelled).Return(nil).Once()
+	mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusCancelled, Actor: entity.StatusActorSystem}).Return(nil).Once()
+
+	// Only the second line is released, so only its outbox entry is marked done
+	mockInventoryUseCase.EXPECT().
+		ReleaseReservation(gomock.Any(), "res_1", []entity.OrderItem{{OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2}}).
+		Return(errors.New("warehouse unavailable"))
+	mockInventoryUseCase.EXPECT().
+		ReleaseReservation(gomock.Any(), "res_1", []entity.OrderItem{{OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1}}).
+		Return(nil)
+	mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{11}).Return(nil).Once()
+
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logrus.New(),
+		Validate:              validator.New(),
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	err = orderUseCase.UpdateOrderStatus(context.Background(), 1, "cancelled")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_PaymentInProgressBlocksChanges(t *testing.T) {
+	newDB := func(t *testing.T) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectRollback()
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	processing := func() *entity.Order {
+		return &entity.Order{
+			ID:         1,
+			Status:     entity.OrderStatusPaymentProcessing,
+			OrderItems: []entity.OrderItem{{ID: 1, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2}},
+		}
+	}
+
+	t.Run("UpdateOrderStatus", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processing(), nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t),
+			Log:                   logrus.New(),
+			Validate:              newTestValidator(),
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      usecase_mock.NewMockInventoryUseCaseInterface(ctrl),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.UpdateOrderStatus(context.Background(), 1, "cancelled")
+
+		assert.ErrorIs(t, err, entity.ErrPaymentInProgress)
+		mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockReservationRepo.AssertNotCalled(t, "DeactivateReservationsByOrderID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CancelOrderItems", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processing(), nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t),
+			Log:                   logrus.New(),
+			Validate:              newTestValidator(),
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      usecase_mock.NewMockInventoryUseCaseInterface(ctrl),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{{ProductID: 1, WarehouseID: 1, Quantity: 2}})
+
+		assert.ErrorIs(t, err, entity.ErrPaymentInProgress)
+	})
+
+	t.Run("StatusCannotBeSetByHand", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t),
+			Log:                   logrus.New(),
+			Validate:              newTestValidator(),
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      usecase_mock.NewMockInventoryUseCaseInterface(ctrl),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.UpdateOrderStatus(context.Background(), 1, "payment_processing")
+
+		assert.ErrorIs(t, err, fiber.ErrBadRequest)
+		mockOrderRepo.AssertNotCalled(t, "FindOrderByIDForUpdate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCase_UpdateOrderStatus_QueuesWebhookEvent(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// The event is queued in the status change transaction
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockWebhookRepo := new(repository_mock.WebhookRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+	mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              newTestValidator(),
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		WebhookRepository:     mockWebhookRepo,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	order := &entity.Order{ID: 1, UserID: "test-user-id", Status: entity.OrderStatusPending, TotalAmount: 2000}
+	mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPaid).Return(nil).Once()
+	mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+	mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+	mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Once()
+	mockWebhookRepo.On("EnqueueWebhookEvent", mock.Anything, &entity.WebhookEvent{
+		EventType: entity.WebhookEventOrderStatusChanged,
+		OrderID:   1,
+		Payload:   `{"order_id":1,"user_id":"test-user-id","from_status":"pending","to_status":"paid","actor":"admin-user-id"}`,
+	}).Return(nil).Once()
+
+	ctx := appContext.WithUserID(context.Background(), "admin-user-id")
+	err = orderUseCase.UpdateOrderStatus(ctx, 1, "paid")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockWebhookRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestOrderUseCase_GetOrderStatusHistory(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	t.Run("ReturnsTimeline", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:              db,
+			Log:             logger,
+			Validate:        validate,
+			OrderRepository: mockOrderRepo,
+			PaymentDeadline: 24 * time.Hour,
+			ExportMaxRange:  31 * 24 * time.Hour,
+			Timeouts:        appContext.DefaultTimeouts(),
+		})
+
+		paidAt := time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC)
+		completedAt := paidAt.Add(48 * time.Hour)
+
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).
+			Return(&entity.Order{ID: 1, UserID: "user-1", Status: entity.OrderStatusCompleted}, nil).Once()
+		mockOrderRepo.On("FindOrderStatusHistory", mock.Anything, uint(1)).Return([]entity.OrderStatusHistory{
+			{OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusPaid, Actor: "user-1", CreatedAt: paidAt},
+			{OrderID: 1, FromStatus: entity.OrderStatusPaid, ToStatus: entity.OrderStatusCompleted, Actor: "admin-1", CreatedAt: completedAt},
+		}, nil).Once()
+
+		history, err := orderUseCase.GetOrderStatusHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", history.UserID)
+		assert.Equal(t, "completed", history.Status)
+		assert.Equal(t, []model.OrderStatusChangeResponse{
+			{FromStatus: "pending", ToStatus: "paid", Actor: "user-1", ChangedAt: "2025-05-20T10:00:00Z"},
+			{FromStatus: "paid", ToStatus: "completed", Actor: "admin-1", ChangedAt: "2025-05-22T10:00:00Z"},
+		}, history.History)
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:              db,
+			Log:             logger,
+			Validate:        validate,
+			OrderRepository: mockOrderRepo,
+			PaymentDeadline: 24 * time.Hour,
+			ExportMaxRange:  31 * 24 * time.Hour,
+			Timeouts:        appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).
+			Return(&entity.Order{ID: 1, UserID: "user-1", Status: entity.OrderStatusPending}, nil).Once()
+		mockOrderRepo.On("FindOrderStatusHistory", mock.Anything, uint(1)).Return([]entity.OrderStatusHistory{}, nil).Once()
+
+		history, err := orderUseCase.GetOrderStatusHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, history.History)
+		assert.Empty(t, history.History)
+	})
+
+	t.Run("OrderNotFound", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:              db,
+			Log:             logger,
+			Validate:        validate,
+			OrderRepository: mockOrderRepo,
+			PaymentDeadline: 24 * time.Hour,
+			ExportMaxRange:  31 * 24 * time.Hour,
+			Timeouts:        appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		history, err := orderUseCase.GetOrderStatusHistory(context.Background(), 999)
+
+		assert.Nil(t, history)
+		assert.Equal(t, fiber.ErrNotFound, err)
+		mockOrderRepo.AssertNotCalled(t, "FindOrderStatusHistory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCase_CancelExpiredOrders_RecordsHistory(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	expired := []entity.Order{
+		{ID: 1, Status: entity.OrderStatusPending, OrderItems: []entity.OrderItem{{ProductID: 1, WarehouseID: 1, Quantity: 1}}},
+	}
+	mockOrderRepo.On("FindExpiredOrders", mock.Anything, mock.Anything).Return(expired, nil).Once()
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusCancelled).Return(nil).Once()
+	mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{
+		OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusCancelled, Actor: entity.StatusActorSystem,
+	}).Return(nil).Once()
+	mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Once()
+	mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", expired[0].OrderItems).
+		Return([]entity.ReservationReleaseOutbox{{ID: 5, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 1}}, nil).Once()
+	mockReservationRepo.On("FindExpiredReservations", mock.Anything, mock.Anything).Return([]entity.Reservation{}, nil).Once()
+	mockInventoryUseCase.EXPECT().ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Len(1)).Return(nil)
+	mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{5}).Return(nil).Once()
+
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logrus.New(),
+		Validate:              newTestValidator(),
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	// The sweep runs with a caller's context but is always recorded as the system
+	result, err := orderUseCase.CancelExpiredOrders(appContext.WithUserID(context.Background(), "service-account"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.CancelledOrders)
+	mockOrderRepo.AssertExpectations(t)
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CancelExpiredOrders_QueuesExpiredReservations(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	mockOrderRepo.On("FindExpiredOrders", mock.Anything, mock.Anything).Return([]entity.Order{}, nil).Once()
+	mockReservationRepo.On("FindExpiredReservations", mock.Anything, mock.Anything).Return([]entity.Reservation{
+		{ID: 1, OrderID: 7, ProductID: 1, WarehouseID: 1, Quantity: 2},
+		{ID: 2, OrderID: 7, ProductID: 2, WarehouseID: 1, Quantity: 1},
+	}, nil).Once()
+	mockReservationRepo.On("UpdateReservationStatus", mock.Anything, uint(1), false).Return(nil).Once()
+	mockReservationRepo.On("UpdateReservationStatus", mock.Anything, uint(2), false).Return(nil).Once()
+	mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(7), "res_7", []entity.OrderItem{
+		{ProductID: 1, WarehouseID: 1, Quantity: 2},
+		{ProductID: 2, WarehouseID: 1, Quantity: 1},
+	}).Return([]entity.ReservationReleaseOutbox{
+		{ID: 20, OrderID: 7, ProductID: 1, WarehouseID: 1, Quantity: 2},
+		{ID: 21, OrderID: 7, ProductID: 2, WarehouseID: 1, Quantity: 1},
+	}, nil).Once()
+
+	// The failed release stays queued for RetryPendingReleases
+	mockInventoryUseCase.EXPECT().
+		ReleaseReservation(gomock.Any(), "res_7", []entity.OrderItem{{OrderID: 7, ProductID: 1, WarehouseID: 1, Quantity: 2}}).
+		Return(nil)
+	mockInventoryUseCase.EXPECT().
+		ReleaseReservation(gomock.Any(), "res_7", []entity.OrderItem{{OrderID: 7, ProductID: 2, WarehouseID: 1, Quantity: 1}}).
+		Return(errors.New("warehouse unavailable"))
+	mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{20}).Return(nil).Once()
+
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logrus.New(),
+		Validate:              validator.New(),
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	result, err := orderUseCase.CancelExpiredOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ReleasedReservations)
+	mockReservationRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestOrderUseCase_CreateOrder_ConfiguredPaymentDeadline(t *testing.T) {
+	// Create SQL mock
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	// Configure GORM to use the mock
+	dialector := mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	// Create mocks
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	mockInventoryUseCase.EXPECT().
+		CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	// Create use case with a one hour payment window
+	logger := logrus.New()
+	validate := newTestValidator()
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	createRequest := &model.CreateOrderRequest{
+		UserID:          "test-user-id",
+		ShippingAddress: "123 Test St",
+		PaymentMethod:   "credit_card",
+		Items: []model.OrderItemRequest{
+			{
+				ProductID:   1,
+				WarehouseID: 1,
+				Quantity:    2,
+				UnitPrice:   1000,
+			},
+		},
+	}
+
+	before := time.Now()
+	var orderDeadline time.Time
+
+	mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+		order := args.Get(1).(*entity.Order)
+		order.ID = 1
+		orderDeadline = order.PaymentDeadline
+	}).Return(nil).Once()
+
+	mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+
+	mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.MatchedBy(func(reservations []entity.Reservation) bool {
+		return len(reservations) == 1 && reservations[0].ExpiresAt.Equal(orderDeadline)
+	})).Return(nil).Once()
+
+	mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(&entity.Order{
+		ID:              1,
+		UserID:          createRequest.UserID,
+		Status:          entity.OrderStatusPending,
+		PaymentDeadline: before.Add(time.Hour),
+	}, nil).Once()
+
+	response, err := orderUseCase.CreateOrder(context.Background(), createRequest)
+	after := time.Now()
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.False(t, orderDeadline.Before(before.Add(time.Hour)))
+	assert.False(t, orderDeadline.After(after.Add(time.Hour)))
+
+	// Verify mock expectations
+	mockOrderRepo.AssertExpectations(t)
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_ReservationReference(t *testing.T) {
+	// Create SQL mock
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	// Configure GORM to use the mock
+	dialector := mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	// Create mocks
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	// The reference the stock is reserved under must be stored on the order
+	var reservedReference, storedReference string
+	mockInventoryUseCase.EXPECT().
+		CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, reference string, _ []model.OrderItemRequest) error {
+			reservedReference = reference
+			return nil
+		})
+
+	logger := logrus.New()
+	validate := newTestValidator()
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	createRequest := &model.CreateOrderRequest{
+		UserID:          "test-user-id",
+		ShippingAddress: "123 Test St",
+		PaymentMethod:   "credit_card",
+		Items: []model.OrderItemRequest{
+			{
+				ProductID:   1,
+				WarehouseID: 1,
+				Quantity:    2,
+				UnitPrice:   10.0,
+			},
+		},
+	}
+
+	mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+		order := args.Get(1).(*entity.Order)
+		order.ID = 1
+		storedReference = order.ReservationReference
+	}).Return(nil).Once()
+
+	mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+	mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+
+	mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(&entity.Order{
+		ID:     1,
+		UserID: createRequest.UserID,
+		Status: entity.OrderStatusPending,
+	}, nil).Once()
+
+	_, err = orderUseCase.CreateOrder(context.Background(), createRequest)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Regexp(t, "^ord-", reservedReference)
+	assert.Equal(t, reservedReference, storedReference)
+
+	// Verify mock expectations
+	mockOrderRepo.AssertExpectations(t)
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CancelOrderItems(t *testing.T) {
+	// newDB creates a GORM DB backed by sqlmock, optionally expecting a committed transaction
+	newDB := func(t *testing.T, expectCommit bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		sqlMock.ExpectBegin()
+		if expectCommit {
+			sqlMock.ExpectCommit()
+		} else {
+			sqlMock.ExpectRollback()
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	// newOrder builds a pending order with two lines
+	newOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:          1,
+			UserID:      "test-user-id",
+			Status:      entity.OrderStatusPending,
+			TotalAmount: 3500,
+			OrderItems: []entity.OrderItem{
+				{ID: 1, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000, TotalPrice: 2000},
+				{ID: 2, OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1, UnitPrice: 1500, TotalPrice: 1500},
+			},
+		}
+	}
+
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	// Test case 1: Cancel a single line of a multi-line order
+	t.Run("CancelSingleItem", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		// Only the cancelled line is released
+		mockInventoryUseCase.EXPECT().
+			ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, items []entity.OrderItem) error {
+				assert.Len(t, items, 1)
+				assert.Equal(t, uint(2), items[0].ProductID)
+				return nil
+			})
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		remaining := newOrder()
+		remaining.OrderItems = remaining.OrderItems[:1]
+		remaining.TotalAmount = 2000
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(newOrder(), nil).Once()
+		mockReservationRepo.On("DeactivateReservationsByOrderItems", mock.Anything, uint(1), mock.MatchedBy(func(items []entity.OrderItem) bool {
+			return len(items) == 1 && items[0].ID == 2
+		})).Return(nil).Once()
+		mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", mock.Anything).
+			Return([]entity.ReservationReleaseOutbox{{ID: 10, OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1}}, nil).Once()
+		mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{10}).Return(nil).Once()
+		mockOrderRepo.On("DeleteOrderItems", mock.Anything, []uint{2}).Return(nil).Once()
+		mockOrderRepo.On("UpdateOrderAmounts", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+			return order.ID == 1 && order.Subtotal == 2000 && order.DiscountAmount == 0 && order.TotalAmount == 2000
+		})).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(remaining, nil).Once()
+
+		response, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{
+			{ProductID: 2, WarehouseID: 1},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", response.Status)
+		assert.Equal(t, entity.Money(2000), response.TotalAmount)
+		assert.Len(t, response.Items, 1)
+
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: The coupon discount is recomputed for the remaining lines
+	t.Run("RecomputesCouponDiscount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockCouponRepo := new(repository_mock.CouponRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockInventoryUseCase.EXPECT().ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Len(1)).Return(nil)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			CouponRepository:      mockCouponRepo,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		// 10% off the 35.00 subtotal was 3.50; 10% off the remaining 20.00 is 2.00
+		discounted := newOrder()
+		discounted.CouponCode = "SAVE10"
+		discounted.DiscountAmount = 350
+		discounted.TotalAmount = 3150
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(discounted, nil).Once()
+		mockCouponRepo.On("FindCouponByCode", mock.Anything, "SAVE10").
+			Return(&entity.Coupon{Code: "SAVE10", DiscountType: entity.DiscountTypePercentage, DiscountValue: 10}, nil).Once()
+		mockReservationRepo.On("DeactivateReservationsByOrderItems", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", mock.Anything).
+			Return([]entity.ReservationReleaseOutbox{{ID: 10}}, nil).Once()
+		mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{10}).Return(nil).Once()
+		mockOrderRepo.On("DeleteOrderItems", mock.Anything, []uint{2}).Return(nil).Once()
+		mockOrderRepo.On("UpdateOrderAmounts", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+			return order.Subtotal == 2000 && order.DiscountAmount == 200 && order.TotalAmount == 1800
+		})).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(newOrder(), nil).Once()
+
+		_, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{
+			{ProductID: 2, WarehouseID: 1},
+		})
+
+		assert.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+		mockCouponRepo.AssertExpectations(t)
+	})
+
+	// Test case 3: Cancelling every line cancels the order
+	t.Run("CancelAllItems", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		// Each cancelled line is released on its own
+		mockInventoryUseCase.EXPECT().
+			ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Len(1)).
+			Return(nil).
+			Times(2)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		cancelledOrder := newOrder()
+		cancelledOrder.Status = entity.OrderStatusCancelled
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(newOrder(), nil).Once()
+		mockReservationRepo.On("DeactivateReservationsByOrderItems", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", mock.Anything).
+			Return([]entity.ReservationReleaseOutbox{{ID: 10}, {ID: 11}}, nil).Once()
+		mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{10, 11}).Return(nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusCancelled).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusCancelled, Actor: entity.StatusActorSystem}).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(cancelledOrder, nil).Once()
+
+		response, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1},
+			{ProductID: 2, WarehouseID: 1},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cancelled", response.Status)
+
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+		mockOrderRepo.AssertNotCalled(t, "DeleteOrderItems", mock.Anything, mock.Anything)
+	})
+
+	// A line whose release fails stays queued while the other lines are marked done
+	t.Run("FailedReleaseStaysQueued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockInventoryUseCase.EXPECT().
+			ReleaseReservation(gomock.Any(), "res_1", []entity.OrderItem{{OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2}}).
+			Return(errors.New("warehouse unavailable"))
+		mockInventoryUseCase.EXPECT().
+			ReleaseReservation(gomock.Any(), "res_1", []entity.OrderItem{{OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1}}).
+			Return(nil)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		cancelledOrder := newOrder()
+		cancelledOrder.Status = entity.OrderStatusCancelled
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(newOrder(), nil).Once()
+		mockReservationRepo.On("DeactivateReservationsByOrderItems", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("EnqueueReservationReleases", mock.Anything, uint(1), "res_1", mock.Anything).
+			Return([]entity.ReservationReleaseOutbox{
+				{ID: 10, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2},
+				{ID: 11, OrderID: 1, ProductID: 2, WarehouseID: 1, Quantity: 1},
+			}, nil).Once()
+		mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{11}).Return(nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusCancelled).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(cancelledOrder, nil).Once()
+
+		_, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1},
+			{ProductID: 2, WarehouseID: 1},
+		})
+
+		assert.NoError(t, err)
+		mockReservationRepo.AssertExpectations(t)
+		mockReservationRepo.AssertNotCalled(t, "MarkReservationReleasesDone", mock.Anything, []uint{10, 11})
+	})
+
+	// Test case 3: Item that is not part of the order
+	t.Run("ItemNotInOrder", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(newOrder(), nil).Once()
+
+		response, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1},
+			{ProductID: 9, WarehouseID: 1},
+		})
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, response)
+
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertNotCalled(t, "DeactivateReservationsByOrderItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	// Test case 4: Order is not pending
+	t.Run("OrderNotPending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		paidOrder := newOrder()
+		paidOrder.Status = entity.OrderStatusPaid
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(paidOrder, nil).Once()
+
+		response, err := orderUseCase.CancelOrderItems(context.Background(), 1, []model.OrderItemRequest{
+			{ProductID: 1, WarehouseID: 1},
+		})
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, response)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderUseCase_GetOrdersByUserID(t *testing.T) {
+	// Create SQL mock
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Configure GORM to use the mock
+	dialector := mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	// Create mocks
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	logger := logrus.New()
+	validate := newTestValidator()
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		OrderRepository:       mockOrderRepo,
+		ReservationRepository: mockReservationRepo,
+		InventoryUseCase:      mockInventoryUseCase,
+		PaymentDeadline:       24 * time.Hour,
+		ExportMaxRange:        31 * 24 * time.Hour,
+		Timeouts:              appContext.DefaultTimeouts(),
+	})
+
+	orders := []entity.Order{
+		{ID: 1, UserID: "test-user-id", Status: entity.OrderStatusPaid, TotalAmount: 2000},
+	}
+
+	// Test case 1: Empty filter is passed through unchanged
+	t.Run("NoFilter", func(t *testing.T) {
+		mockOrderRepo.On("FindOrdersByUserID", mock.Anything, "test-user-id", repository.OrderFilter{}, 1, 10).
+			Return(orders, int64(1), nil).Once()
+
+		response, err := orderUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{}, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Orders, 1)
+		assert.Equal(t, model.OrderListMeta{Total: 1, Page: 1, Limit: 10, TotalPages: 1}, response.Meta)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Status and date range are forwarded to the repository
+	t.Run("StatusAndDateRange", func(t *testing.T) {
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 5, 31, 23, 59, 59, 0, time.UTC)
+
+		mockOrderRepo.On("FindOrdersByUserID", mock.Anything, "test-user-id", repository.OrderFilter{
+			Status: entity.OrderStatusPaid,
+			From:   &from,
+			To:     &to,
+		}, 1, 10).Return(orders, int64(1), nil).Once()
+
+		response, err := orderUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{
+			Status: "paid",
+			From:   &from,
+			To:     &to,
+		}, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), response.Meta.Total)
+		assert.Equal(t, "paid", response.Orders[0].Status)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 3: Unknown status is rejected
+	t.Run("InvalidStatus", func(t *testing.T) {
+		response, err := orderUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{
+			Status: "shipped",
+		}, 1, 10)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, response)
+	})
+
+	// Test case 4: Inverted date range is rejected
+	t.Run("FromAfterTo", func(t *testing.T) {
+		from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := orderUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{
+			From: &from,
+			To:   &to,
+		}, 1, 10)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	})
+
+	// Test case 5: Pagination metadata uses the normalised page and limit
+	t.Run("PaginationMeta", func(t *testing.T) {
+		mockOrderRepo.On("FindOrdersByUserID", mock.Anything, "test-user-id", repository.OrderFilter{}, 3, 10).
+			Return(orders, int64(21), nil).Once()
+
+		response, err := orderUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{}, 3, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.OrderListMeta{Total: 21, Page: 3, Limit: 10, TotalPages: 3}, response.Meta)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 6: A limit above the maximum is clamped rather than rejected
+	t.Run("OverMaxLimitIsClamped", func(t *testing.T) {
+		mockOrderRepo.On("FindOrdersByUserID", mock.Anything, "test-user-id", repository.OrderFilter{}, 1, 100).
+			Return(orders, int64(21), nil).Once()
+
+		response, err := orderUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{}, 1, 500)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.OrderListMeta{Total: 21, Page: 1, Limit: 100, TotalPages: 1}, response.Meta)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 7: Configured limits replace the defaults
+	t.Run("ConfiguredLimits", func(t *testing.T) {
+		configuredUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Pagination:            model.Pagination{DefaultLimit: 5, MaxLimit: 50},
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("FindOrdersByUserID", mock.Anything, "test-user-id", repository.OrderFilter{}, 1, 5).
+			Return(orders, int64(1), nil).Once()
+		mockOrderRepo.On("FindOrdersByUserID", mock.Anything, "test-user-id", repository.OrderFilter{}, 1, 50).
+			Return(orders, int64(1), nil).Once()
+
+		response, err := configuredUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{}, 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, response.Meta.Limit)
+
+		response, err = configuredUseCase.GetOrdersByUserID(context.Background(), "test-user-id", model.OrderListFilter{}, 1, 80)
+		assert.NoError(t, err)
+		assert.Equal(t, 50, response.Meta.Limit)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderUseCase_GetUserOrderSummary(t *testing.T) {
+	// Create SQL mock
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Configure GORM to use the mock
+	dialector := mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	validate := newTestValidator()
+
+	// Test case 1: Counts every status and sums only paid and completed orders per currency
+	t.Run("CountsAndSpend", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("SummarizeOrdersByUserID", mock.Anything, "test-user-id").
+			Return([]repository.OrderStatusTotal{
+				{Status: entity.OrderStatusCancelled, Currency: "USD", OrderCount: 1, TotalAmount: 900},
+				{Status: entity.OrderStatusCompleted, Currency: "USD", OrderCount: 2, TotalAmount: 5000},
+				{Status: entity.OrderStatusPaid, Currency: "EUR", OrderCount: 1, TotalAmount: 1250},
+				{Status: entity.OrderStatusPaid, Currency: "USD", OrderCount: 1, TotalAmount: 1999},
+				{Status: entity.OrderStatusPending, Currency: "USD", OrderCount: 3, TotalAmount: 3000},
+			}, nil).Once()
+
+		summary, err := orderUseCase.GetUserOrderSummary(context.Background(), "test-user-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-user-id", summary.UserID)
+		assert.Equal(t, int64(8), summary.TotalOrders)
+		assert.Equal(t, map[string]int64{"pending": 3, "payment_processing": 0, "paid": 2, "cancelled": 1, "completed": 2}, summary.StatusCounts)
+		assert.Equal(t, []model.CurrencyAmount{
+			{Currency: "USD", Amount: 6999},
+			{Currency: "EUR", Amount: 1250},
+		}, summary.TotalSpent)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: A user without orders gets zeros rather than an error
+	t.Run("NoOrders", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("SummarizeOrdersByUserID", mock.Anything, "new-user-id").
+			Return([]repository.OrderStatusTotal{}, nil).Once()
+
+		summary, err := orderUseCase.GetUserOrderSummary(context.Background(), "new-user-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), summary.TotalOrders)
+		assert.Equal(t, map[string]int64{"pending": 0, "payment_processing": 0, "paid": 0, "cancelled": 0, "completed": 0}, summary.StatusCounts)
+		assert.NotNil(t, summary.TotalSpent)
+		assert.Empty(t, summary.TotalSpent)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 3: Repository failure is reported as an internal error
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		mockOrderRepo.On("SummarizeOrdersByUserID", mock.Anything, "test-user-id").
+			Return(nil, errors.New("connection refused")).Once()
+
+		_, err := orderUseCase.GetUserOrderSummary(context.Background(), "test-user-id")
+
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+	})
+
+	// Test case 4: The repository aggregates in one GROUP BY query instead of loading orders
+	t.Run("SingleAggregateQuery", func(t *testing.T) {
+		orderRepo := repository.NewOrderRepository(logger, db)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       orderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		sqlMock.ExpectQuery("SELECT status, currency, COUNT\\(\\*\\) AS order_count, COALESCE\\(SUM\\(total_amount\\), 0\\) AS total_amount FROM `orders` WHERE user_id = \\? .*GROUP BY status, currency").
+			WithArgs("test-user-id").
+			WillReturnRows(sqlmock.NewRows([]string{"status", "currency", "order_count", "total_amount"}).
+				AddRow("paid", "USD", 2, []byte("40.00")).
+				AddRow("pending", "USD", 1, []byte("5.50")))
+
+		summary, err := orderUseCase.GetUserOrderSummary(context.Background(), "test-user-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), summary.TotalOrders)
+		assert.Equal(t, []model.CurrencyAmount{{Currency: "USD", Amount: 4000}}, summary.TotalSpent)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestOrderUseCase_SearchOrders(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+	orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+		DB:              db,
+		Log:             logrus.New(),
+		Validate:        newTestValidator(),
+		OrderRepository: mockOrderRepo,
+		PaymentDeadline: 24 * time.Hour,
+		ExportMaxRange:  31 * 24 * time.Hour,
+		Timeouts:        appContext.DefaultTimeouts(),
+	})
+
+	orders := []entity.Order{
+		{ID: 7, UserID: "user-1", Status: entity.OrderStatusPaid, PaymentMethod: "credit_card"},
+	}
+
+	// Test case 1: Every criterion is forwarded to the repository
+	t.Run("AllCriteria", func(t *testing.T) {
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+
+		mockOrderRepo.On("SearchOrders", mock.Anything, repository.OrderFilter{
+			OrderID:       7,
+			UserID:        "user-1",
+			Status:        entity.OrderStatusPaid,
+			PaymentMethod: "credit_card",
+			From:          &from,
+			To:            &to,
+		}, 2, 20).Return(orders, int64(21), nil).Once()
+
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{
+			OrderID:       7,
+			UserID:        "user-1",
+			Status:        "paid",
+			PaymentMethod: "credit_card",
+			From:          &from,
+			To:            &to,
+			Page:          2,
+			Limit:         20,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Orders, 1)
+		assert.Equal(t, model.OrderListMeta{Total: 21, Page: 2, Limit: 20, TotalPages: 2}, response.Meta)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Paging falls back to the defaults
+	t.Run("DefaultPaging", func(t *testing.T) {
+		mockOrderRepo.On("SearchOrders", mock.Anything, repository.OrderFilter{PaymentMethod: "paypal"}, 1, 10).
+			Return([]entity.Order{}, int64(0), nil).Once()
+
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{PaymentMethod: "paypal"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, response.Orders)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// A limit above the maximum is clamped rather than rejected
+	t.Run("OverMaxLimitIsClamped", func(t *testing.T) {
+		mockOrderRepo.On("SearchOrders", mock.Anything, repository.OrderFilter{PaymentMethod: "paypal"}, 1, 100).
+			Return([]entity.Order{}, int64(0), nil).Once()
+
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{PaymentMethod: "paypal", Limit: 500})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, response.Meta.Limit)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Test case 3: A search without criteria is rejected before querying
+	t.Run("NoCriteria", func(t *testing.T) {
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{Page: 1, Limit: 10})
+
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, entity.ErrSearchCriteriaRequired)
+	})
+
+	// Test case 4: Unknown status is rejected
+	t.Run("InvalidStatus", func(t *testing.T) {
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{Status: "shipped"})
+
+		assert.Nil(t, response)
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	})
+
+	// Test case 5: From after to is rejected
+	t.Run("InvertedDateRange", func(t *testing.T) {
+		from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{From: &from, To: &to})
+
+		assert.Nil(t, response)
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	})
+
+	// Test case 6: Repository failures are internal errors
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockOrderRepo.On("SearchOrders", mock.Anything, repository.OrderFilter{UserID: "user-2"}, 1, 10).
+			Return([]entity.Order(nil), int64(0), errors.New("database error")).Once()
+
+		response, err := orderUseCase.SearchOrders(context.Background(), model.OrderSearchCriteria{UserID: "user-2"})
+
+		assert.Nil(t, response)
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+	})
+
+	mockOrderRepo.AssertNotCalled(t, "SearchOrders", mock.Anything, repository.OrderFilter{}, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_ExportOrders(t *testing.T) {
+	// Create SQL mock
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Configure GORM to use the mock
+	dialector := mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	// Create mocks
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	newUseCase := func(mockOrderRepo *repository_mock.OrderRepositoryMock) OrderUseCaseInterface {
+		return NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+	}
+
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	// streamRows makes the repository mock pass each row to the export callback
+	streamRows := func(rows ...repository.OrderExportRow) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			fn := args.Get(2).(func(row *repository.OrderExportRow) error)
+			for i := range rows {
+				if fn(&rows[i]) != nil {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("StreamsMatchingOrders", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockOrderRepo.On("StreamOrdersForExport", mock.Anything, repository.OrderFilter{Status: entity.OrderStatusPaid, From: &from, To: &to}, mock.Anything).
+			Run(streamRows(
+				repository.OrderExportRow{ID: 1, UserID: "user-1", Status: entity.OrderStatusPaid, TotalAmount: 15000, Currency: "USD", CreatedAt: from, ItemCount: 2},
+				repository.OrderExportRow{ID: 2, UserID: "user-2", Status: entity.OrderStatusPaid, TotalAmount: 2000, Currency: "USD", CreatedAt: to, ItemCount: 1},
+			)).
+			Return(nil)
+
+		rows, err := newUseCase(mockOrderRepo).ExportOrders(context.Background(), model.OrderListFilter{Status: "paid", From: &from, To: &to})
+		assert.NoError(t, err)
+
+		var exported []*model.OrderExportRow
+		for row, err := range rows {
+			assert.NoError(t, err)
+			exported = append(exported, row)
+		}
+		assert.Equal(t, []*model.OrderExportRow{
+			{OrderID: 1, UserID: "user-1", Status: "paid", TotalAmount: 15000, Currency: "USD", CreatedAt: from, ItemCount: 2},
+			{OrderID: 2, UserID: "user-2", Status: "paid", TotalAmount: 2000, Currency: "USD", CreatedAt: to, ItemCount: 1},
+		}, exported)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("DefaultsToMaxRangeEndingNow", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockOrderRepo.On("StreamOrdersForExport", mock.Anything, mock.MatchedBy(func(filter repository.OrderFilter) bool {
+			return filter.To != nil && time.Since(*filter.To) < time.Minute && filter.To.Sub(*filter.From) == 31*24*time.Hour
+		}), mock.Anything).Return(nil)
+
+		rows, err := newUseCase(mockOrderRepo).ExportOrders(context.Background(), model.OrderListFilter{})
+		assert.NoError(t, err)
+		for range rows {
+		}
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("RangeTooLarge", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		earlier := to.Add(-32 * 24 * time.Hour)
+
+		rows, err := newUseCase(mockOrderRepo).ExportOrders(context.Background(), model.OrderListFilter{From: &earlier, To: &to})
+		assert.Nil(t, rows)
+		assert.ErrorIs(t, err, entity.ErrExportRangeTooLarge)
+		mockOrderRepo.AssertNotCalled(t, "StreamOrdersForExport", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		_, err := newUseCase(new(repository_mock.OrderRepositoryMock)).ExportOrders(context.Background(), model.OrderListFilter{Status: "shipped"})
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	})
+
+	t.Run("StopsWhenConsumerStops", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockOrderRepo.On("StreamOrdersForExport", mock.Anything, mock.Anything, mock.Anything).
+			Run(streamRows(repository.OrderExportRow{ID: 1}, repository.OrderExportRow{ID: 2})).
+			Return(nil)
+
+		rows, err := newUseCase(mockOrderRepo).ExportOrders(context.Background(), model.OrderListFilter{From: &from, To: &to})
+		assert.NoError(t, err)
+		count := 0
+		for range rows {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockOrderRepo.On("StreamOrdersForExport", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+
+		rows, err := newUseCase(mockOrderRepo).ExportOrders(context.Background(), model.OrderListFilter{From: &from, To: &to})
+		assert.NoError(t, err)
+		var errs []error
+		for _, err := range rows {
+			errs = append(errs, err)
+		}
+		assert.Equal(t, []error{fiber.ErrInternalServerError}, errs)
+	})
+}
+
+func TestOrderUseCase_RetryPendingReleases(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	// Test case 1: Successful retries are marked done, failed ones stay pending
+	t.Run("MarksSucceededAndRecordsFailed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		pending := []entity.ReservationReleaseOutbox{
+			{ID: 1, OrderID: 7, ProductID: 1, WarehouseID: 1, Quantity: 2, Status: entity.ReleaseOutboxStatusPending},
+			{ID: 2, OrderID: 8, ProductID: 3, WarehouseID: 2, Quantity: 1, Status: entity.ReleaseOutboxStatusPending},
+		}
+
+		mockReservationRepo.On("FindPendingReservationReleases", mock.Anything, pendingReleaseBatchSize).Return(pending, nil).Once()
+
+		// Each entry is released on its own, carrying the order it belongs to
+		mockInventoryUseCase.EXPECT().
+			ReleaseReservation(gomock.Any(), "res_7", []entity.OrderItem{{OrderID: 7, ProductID: 1, WarehouseID: 1, Quantity: 2}}).
+			Return(nil)
+		mockInventoryUseCase.EXPECT().
+			ReleaseReservation(gomock.Any(), "res_8", []entity.OrderItem{{OrderID: 8, ProductID: 3, WarehouseID: 2, Quantity: 1}}).
+			Return(errors.New("warehouse unavailable"))
+
+		mockReservationRepo.On("MarkReservationReleasesDone", mock.Anything, []uint{1}).Return(nil).Once()
+		mockReservationRepo.On("RecordReservationReleaseFailure", mock.Anything, uint(2), "warehouse unavailable").Return(nil).Once()
+
+		err := orderUseCase.RetryPendingReleases(context.Background())
+
+		assert.NoError(t, err)
+		mockReservationRepo.AssertExpectations(t)
+		mockReservationRepo.AssertNotCalled(t, "MarkReservationReleasesDone", mock.Anything, []uint{2})
+	})
+
+	// Test case 2: Outbox lookup failure
+	t.Run("FindPendingError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		mockReservationRepo.On("FindPendingReservationReleases", mock.Anything, pendingReleaseBatchSize).
+			Return([]entity.ReservationReleaseOutbox(nil), errors.New("database error")).Once()
+
+		err := orderUseCase.RetryPendingReleases(context.Background())
+
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		mockReservationRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderUseCase_CreateOrder_PriceValidation(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	newRequest := func(unitPrice entity.Money) *model.CreateOrderRequest {
+		return &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St",
+			PaymentMethod:   "credit_card",
+			Items: []model.OrderItemRequest{
+				{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: unitPrice},
+				{ProductID: 1, WarehouseID: 2, Quantity: 1, UnitPrice: unitPrice},
+			},
+		}
+	}
+
+	newDB := func(t *testing.T) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	expectOrderCreated := func(mockOrderRepo *repository_mock.OrderRepositoryMock, mockReservationRepo *repository_mock.ReservationRepositoryMock) {
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.Order).ID = 1
+		}).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(&entity.Order{
+			ID:     1,
+			Status: entity.OrderStatusPending,
+		}, nil).Once()
+	}
+
+	t.Run("MismatchRejectedBeforeReservingStock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPriceGateway := product_mock.NewMockProductPriceGatewayInterface(ctrl)
+
+		// Duplicate product IDs are looked up once
+		mockPriceGateway.EXPECT().
+			GetPrices(gomock.Any(), []uint{1}).
+			Return(map[uint]entity.Money{1: 1250}, nil)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			PriceGateway:          mockPriceGateway,
+			PriceTolerance:        1,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest(1000))
+
+		assert.ErrorIs(t, err, entity.ErrPriceMismatch)
+		assert.Nil(t, response)
+		mockOrderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownProductRejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPriceGateway := product_mock.NewMockProductPriceGatewayInterface(ctrl)
+
+		mockPriceGateway.EXPECT().
+			GetPrices(gomock.Any(), []uint{1}).
+			Return(map[uint]entity.Money{}, nil)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			PriceGateway:          mockPriceGateway,
+			PriceTolerance:        1,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest(1000))
+
+		assert.ErrorIs(t, err, entity.ErrProductNotFound)
+		assert.Nil(t, response)
+	})
+
+	t.Run("WithinToleranceAccepted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPriceGateway := product_mock.NewMockProductPriceGatewayInterface(ctrl)
+
+		mockPriceGateway.EXPECT().
+			GetPrices(gomock.Any(), []uint{1}).
+			Return(map[uint]entity.Money{1: 1001}, nil)
+		mockInventoryUseCase.EXPECT().
+			CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+		expectOrderCreated(mockOrderRepo, mockReservationRepo)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			PriceGateway:          mockPriceGateway,
+			PriceTolerance:        1,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest(1000))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("NilGatewaySkipsValidation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockInventoryUseCase.EXPECT().
+			CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+		expectOrderCreated(mockOrderRepo, mockReservationRepo)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest(99900))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderUseCase_ProcessPayment(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	// newDB expects one transaction per outcome, committed when true and rolled back otherwise
+	newDB := func(t *testing.T, commits ...bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+		t.Cleanup(func() { assert.NoError(t, sqlMock.ExpectationsWereMet()) })
+
+		for _, commit := range commits {
+			sqlMock.ExpectBegin()
+			if commit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	pendingOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:          1,
+			Status:      entity.OrderStatusPending,
+			TotalAmount: 2000,
+			OrderItems: []entity.OrderItem{
+				{ID: 1, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2},
+			},
+		}
+	}
+
+	processingOrder := func(claimedAt time.Time) *entity.Order {
+		order := pendingOrder()
+		order.Status = entity.OrderStatusPaymentProcessing
+		order.UpdatedAt = claimedAt
+		return order
+	}
+
+	// expectClaim expects the order to be locked and moved to payment_processing
+	expectClaim := func(mockOrderRepo *repository_mock.OrderRepositoryMock, order *entity.Order, actor string) {
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPaymentProcessing).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPending, ToStatus: entity.OrderStatusPaymentProcessing, Actor: actor}).Return(nil).Once()
+	}
+
+	// expectPaid expects the claimed order to be locked again and marked paid with the reference,
+	// and its reservations deactivated so the expiry sweep leaves them alone
+	expectPaid := func(mockOrderRepo *repository_mock.OrderRepositoryMock, mockReservationRepo *repository_mock.ReservationRepositoryMock, reference string, actor string) {
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now()), nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPaid).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPaymentProcessing, ToStatus: entity.OrderStatusPaid, Actor: actor}).Return(nil).Once()
+		mockOrderRepo.On("UpdatePaymentReference", mock.Anything, uint(1), reference).Return(nil).Once()
+		mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Once()
+	}
+
+	// expectReleased expects the claim of an order whose charge failed to be handed back
+	expectReleased := func(mockOrderRepo *repository_mock.OrderRepositoryMock) {
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now()), nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPending).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPaymentProcessing, ToStatus: entity.OrderStatusPending, Actor: entity.StatusActorSystem}).Return(nil).Once()
+	}
+
+	t.Run("ApprovedStoresReference", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		order := pendingOrder()
+		expectClaim(mockOrderRepo, order, "user-1")
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), order, "order-1").Return("txn-123", nil)
+		expectPaid(mockOrderRepo, mockReservationRepo, "txn-123", "user-1")
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), order.StockReference(), order.OrderItems).Return(nil)
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+
+		orderMetrics := metrics.New()
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Metrics:               orderMetrics,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(appContext.WithUserID(context.Background(), "user-1"), 1)
+
+		assert.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+		assert.Equal(t, 1.0, testutil.ToFloat64(orderMetrics.Payments.WithLabelValues(metrics.ResultSuccess)))
+	})
+
+	t.Run("StockDeductionOutlivesCancelledRequest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		ctx, cancel := context.WithCancel(appContext.WithRequestID(context.Background(), "req-1"))
+		defer cancel()
+
+		order := pendingOrder()
+		expectClaim(mockOrderRepo, order, entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), order, "order-1").Return("txn-123", nil)
+		expectPaid(mockOrderRepo, mockReservationRepo, "txn-123", entity.StatusActorSystem)
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), order.StockReference(), order.OrderItems).DoAndReturn(
+			func(inventoryCtx context.Context, _ string, items []entity.OrderItem) error {
+				// The client disconnects once the payment is committed
+				cancel()
+				assert.NoError(t, inventoryCtx.Err())
+				assert.Equal(t, "req-1", appContext.GetRequestID(inventoryCtx))
+				return nil
+			})
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(ctx, 1)
+
+		assert.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("FailedStockDeductionIsRecorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		order := pendingOrder()
+		expectClaim(mockOrderRepo, order, entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), order, "order-1").Return("txn-123", nil)
+		expectPaid(mockOrderRepo, mockReservationRepo, "txn-123", entity.StatusActorSystem)
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), order.StockReference(), order.OrderItems).Return(errors.New("warehouse unavailable"))
+		mockOrderRepo.On("RecordStockDeductionFailure", mock.Anything, uint(1), "warehouse unavailable").Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+		// The payment stands; the order is left for ReconcilePaidOrder
+		assert.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+		mockOrderRepo.AssertNotCalled(t, "RecordStockDeduction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeclinedReturnsOrderToPending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		expectClaim(mockOrderRepo, pendingOrder(), entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), gomock.Any(), "order-1").Return("", payment.ErrPaymentDeclined)
+		expectReleased(mockOrderRepo)
+		mockOrderRepo.On("IncrementPaymentAttempts", mock.Anything, uint(1)).Return(nil).Once()
+
+		orderMetrics := metrics.New()
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Metrics:               orderMetrics,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrPaymentDeclined)
+		assert.Equal(t, 1.0, testutil.ToFloat64(orderMetrics.Payments.WithLabelValues(metrics.ResultDeclined)))
+		assert.Equal(t, 0.0, testutil.ToFloat64(orderMetrics.Payments.WithLabelValues(metrics.ResultSuccess)))
+		mockOrderRepo.AssertExpectations(t)
+		mockOrderRepo.AssertNotCalled(t, "UpdatePaymentReference", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("GatewayErrorReturnsOrderToPending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		expectClaim(mockOrderRepo, pendingOrder(), entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), gomock.Any(), "order-1").Return("", errors.New("gateway timeout"))
+		expectReleased(mockOrderRepo)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrPaymentUnavailable)
+		mockOrderRepo.AssertExpectations(t)
+		mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, entity.OrderStatusPaid)
+		// The charge may have gone through, so a retry must carry the same key
+		mockOrderRepo.AssertNotCalled(t, "IncrementPaymentAttempts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("RetryAfterDeclineIsNewAttempt", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		// The first charge is declined and ends the attempt
+		expectClaim(mockOrderRepo, pendingOrder(), entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), gomock.Any(), "order-1").Return("", payment.ErrPaymentDeclined)
+		expectReleased(mockOrderRepo)
+		mockOrderRepo.On("IncrementPaymentAttempts", mock.Anything, uint(1)).Return(nil).Once()
+
+		// The retry is charged under a new key, so the gateway does not replay the decline
+		retried := pendingOrder()
+		retried.PaymentAttempts = 1
+		expectClaim(mockOrderRepo, retried, entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), gomock.Any(), "order-1-attempt-1").Return("txn-123", nil)
+		expectPaid(mockOrderRepo, mockReservationRepo, "txn-123", entity.StatusActorSystem)
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		assert.ErrorIs(t, orderUseCase.ProcessPayment(context.Background(), 1), entity.ErrPaymentDeclined)
+		assert.NoError(t, orderUseCase.ProcessPayment(context.Background(), 1))
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotPendingIsNotCharged", func(t *testing.T) {
+		tests := []struct {
+			status   entity.OrderStatus
+			expected error
+		}{
+			{entity.OrderStatusPaid, entity.ErrOrderAlreadyPaid},
+			{entity.OrderStatusCompleted, entity.ErrOrderAlreadyPaid},
+			{entity.OrderStatusCancelled, entity.ErrOrderCancelled},
+		}
+
+		for _, tt := range tests {
+			ctrl := gomock.NewController(t)
+			mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+			mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+			mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+			mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+			order := pendingOrder()
+			order.Status = tt.status
+			mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+
+			orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+				DB:                    newDB(t, false),
+				Log:                   logger,
+				Validate:              validate,
+				OrderRepository:       mockOrderRepo,
+				ReservationRepository: mockReservationRepo,
+				InventoryUseCase:      mockInventoryUseCase,
+				PaymentGateway:        mockPaymentGateway,
+				PaymentDeadline:       24 * time.Hour,
+				ExportMaxRange:        31 * 24 * time.Hour,
+				Timeouts:              appContext.DefaultTimeouts(),
+			})
+
+			err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+			assert.ErrorIs(t, err, tt.expected, "status %s", tt.status)
+		}
+	})
+
+	t.Run("PaymentInProgressIsNotCharged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		// Another request claimed the order moments ago and is still charging it
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now()), nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+		// The payment in flight pays the order, so this one is refused as already paid
+		assert.ErrorIs(t, err, entity.ErrOrderAlreadyPaid)
+		mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("StaleClaimIsTakenOver", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		// The claim outlived the payment and write timeouts, so its payment never finished
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now().Add(-time.Hour)), nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPaymentProcessing).Return(nil).Once()
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), gomock.Any(), "order-1").Return("txn-123", nil)
+		expectPaid(mockOrderRepo, mockReservationRepo, "txn-123", entity.StatusActorSystem)
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+		assert.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("FailedClaimIsNotCharged", func(t *testing.T) {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		defer sqlDB.Close()
+
+		// The charge only starts once the claim is committed and its row lock released
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit().WillReturnError(errors.New("connection lost"))
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+		expectClaim(mockOrderRepo, pendingOrder(), entity.StatusActorSystem)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      usecase_mock.NewMockInventoryUseCaseInterface(ctrl),
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err = orderUseCase.ProcessPayment(context.Background(), 1)
+
+		assert.ErrorIs(t, err, fiber.ErrInternalServerError)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("CancelledWhileChargingIsNotPaid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockPaymentGateway := payment_mock.NewMockPaymentGatewayInterface(ctrl)
+
+		cancelled := pendingOrder()
+		cancelled.Status = entity.OrderStatusCancelled
+		expectClaim(mockOrderRepo, pendingOrder(), entity.StatusActorSystem)
+		mockPaymentGateway.EXPECT().Charge(gomock.Any(), gomock.Any(), "order-1").Return("txn-123", nil)
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(cancelled, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        mockPaymentGateway,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		err := orderUseCase.ProcessPayment(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrOrderCancelled)
+		mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, entity.OrderStatusPaid)
+	})
+
+	t.Run("RetryAfterFailedCommitReusesCharge", func(t *testing.T) {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		defer sqlDB.Close()
+
+		// The first payment is charged but fails to record it; the retry takes over its claim
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit().WillReturnError(errors.New("connection lost"))
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		var references []string
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(pendingOrder(), nil).Once()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now()), nil).Once()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now().Add(-time.Hour)), nil).Once()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(time.Now()), nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), mock.Anything).Return(nil)
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, mock.Anything).Return(nil)
+		mockOrderRepo.On("UpdatePaymentReference", mock.Anything, uint(1), mock.Anything).
+			Run(func(args mock.Arguments) { references = append(references, args.String(2)) }).
+			Return(nil).Twice()
+		mockReservationRepo.On("DeactivateReservationsByOrderID", mock.Anything, uint(1)).Return(nil).Twice()
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    db,
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentGateway:        payment.NewApprovingPaymentGateway(logger),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		assert.ErrorIs(t, orderUseCase.ProcessPayment(context.Background(), 1), fiber.ErrInternalServerError)
+		assert.NoError(t, orderUseCase.ProcessPayment(context.Background(), 1))
+
+		// The gateway returned the original charge to the retry rather than charging again
+		if assert.Len(t, references, 2) {
+			assert.Equal(t, references[0], references[1])
+		}
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+// lockingOrderRepository holds a single order and stands in for its row lock. FindOrderByIDForUpdate
+// takes the lock and storing the payment reference, the last write before commit, releases it.
+type lockingOrderRepository struct {
+	repository.OrderRepositoryInterface
+	rowLock chan struct{}
+	order   entity.Order
+}
+
+func (r *lockingOrderRepository) FindOrderByIDForUpdate(tx *gorm.DB, orderID uint) (*entity.Order, error) {
+	r.rowLock <- struct{}{}
+	order := r.order
+	return &order, nil
+}
+
+func (r *lockingOrderRepository) UpdateOrderStatus(tx *gorm.DB, orderID uint, status entity.OrderStatus) error {
+	r.order.Status = status
+	return nil
+}
+
+func (r *lockingOrderRepository) CreateOrderStatusHistory(tx *gorm.DB, history *entity.OrderStatusHistory) error {
+	return nil
+}
+
+func (r *lockingOrderRepository) UpdatePaymentReference(tx *gorm.DB, orderID uint, reference string) error {
+	r.order.PaymentReference = reference
+	<-r.rowLock
+	return nil
+}
+
+func (r *lockingOrderRepository) RecordStockDeduction(tx *gorm.DB, orderID uint, deductedAt time.Time) error {
+	return nil
+}
+
+func TestOrderUseCase_ReconcilePaidOrder(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	// newDB expects one transaction per entry, committed or rolled back
+	newDB := func(t *testing.T, commits ...bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		for _, commit := range commits {
+			sqlMock.ExpectBegin()
+			if commit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	paidOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:                     1,
+			Status:                 entity.OrderStatusPaid,
+			TotalAmount:            2000,
+			StockDeductionAttempts: 1,
+			StockDeductionError:    "warehouse unavailable",
+			OrderItems: []entity.OrderItem{
+				{ID: 1, OrderID: 1, ProductID: 1, WarehouseID: 1, Quantity: 2},
+			},
+		}
+	}
+
+	t.Run("RetrySucceeds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		order := paidOrder()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockOrderRepo.On("ClaimOrder", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), order.StockReference(), order.OrderItems).Return(nil)
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("ReleaseOrderClaim", mock.Anything, uint(1)).Return(nil).Once()
+
+		// The claim and the outcome are committed separately, so no row lock is held while the warehouse is called
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		result, err := orderUseCase.ReconcilePaidOrder(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReconcileResultReconciled, result.Result)
+		assert.Equal(t, 2, result.Attempts)
+		assert.NotEmpty(t, result.StockDeductedAt)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyReconciled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		deductedAt := time.Date(2025, 5, 26, 10, 0, 0, 0, time.UTC)
+		order := paidOrder()
+		order.StockDeductedAt = &deductedAt
+		order.StockDeductionError = ""
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+
+		// The warehouse is not called again
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		result, err := orderUseCase.ReconcilePaidOrder(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &model.ReconcileOrderResponse{
+			OrderID:         1,
+			Result:          model.ReconcileResultAlreadyReconciled,
+			StockDeductedAt: "2025-05-26T10:00:00Z",
+			Attempts:        1,
+		}, result)
+		mockOrderRepo.AssertNotCalled(t, "RecordStockDeduction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RetryFailsIsRecorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		order := paidOrder()
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockOrderRepo.On("ClaimOrder", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), order.StockReference(), order.OrderItems).Return(errors.New("warehouse unavailable"))
+		mockOrderRepo.On("RecordStockDeductionFailure", mock.Anything, uint(1), "warehouse unavailable").Return(nil).Once()
+		mockOrderRepo.On("ReleaseOrderClaim", mock.Anything, uint(1)).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		result, err := orderUseCase.ReconcilePaidOrder(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrStockDeductionFailed)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotPaid", func(t *testing.T) {
+		for _, status := range []entity.OrderStatus{entity.OrderStatusPending, entity.OrderStatusCancelled} {
+			ctrl := gomock.NewController(t)
+			mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+			mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+			order := paidOrder()
+			order.Status = status
+			mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+
+			orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+				DB:                    newDB(t, false),
+				Log:                   logger,
+				Validate:              validate,
+				OrderRepository:       mockOrderRepo,
+				ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+				InventoryUseCase:      mockInventoryUseCase,
+				PaymentDeadline:       24 * time.Hour,
+				ExportMaxRange:        31 * 24 * time.Hour,
+				Timeouts:              appContext.DefaultTimeouts(),
+			})
+
+			_, err := orderUseCase.ReconcilePaidOrder(context.Background(), 1)
+
+			assert.ErrorIs(t, err, entity.ErrOrderNotPaid, "status %s", status)
+		}
+	})
+
+	t.Run("ClaimedByAnotherReconciliation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		claimedAt := time.Now()
+		order := paidOrder()
+		order.ClaimedAt = &claimedAt
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+
+		// The other reconciliation is calling the warehouse, so this one does not
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.ReconcilePaidOrder(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrOrderOperationInProgress)
+		mockOrderRepo.AssertNotCalled(t, "ClaimOrder", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("StaleClaimIsTakenOver", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		// The reconciliation that claimed the order never recorded its outcome
+		claimedAt := time.Now().Add(-time.Hour)
+		order := paidOrder()
+		order.ClaimedAt = &claimedAt
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockOrderRepo.On("ClaimOrder", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockInventoryUseCase.EXPECT().ConfirmStockDeduction(gomock.Any(), order.StockReference(), order.OrderItems).Return(nil)
+		mockOrderRepo.On("RecordStockDeduction", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("ReleaseOrderClaim", mock.Anything, uint(1)).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		result, err := orderUseCase.ReconcilePaidOrder(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReconcileResultReconciled, result.Result)
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderUseCase_ReactivateOrder(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	validate := newTestValidator()
+
+	// newDB expects one transaction per entry, committed or rolled back
+	newDB := func(t *testing.T, commits ...bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		for _, commit := range commits {
+			sqlMock.ExpectBegin()
+			if commit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	cancelledOrder := func(deadline time.Time) *entity.Order {
+		return &entity.Order{
+			ID:              1,
+			UserID:          "user-1",
+			Status:          entity.OrderStatusCancelled,
+			TotalAmount:     2000,
+			PaymentDeadline: deadline,
+			OrderItems: []entity.OrderItem{
+				{ID: 1, OrderID: 1, ProductID: 1, WarehouseID: 2, Quantity: 2, UnitPrice: 1000, TotalPrice: 2000},
+			},
+		}
+	}
+	reservedItems := []model.OrderItemRequest{
+		{OrderID: 1, ProductID: 1, WarehouseID: 2, Quantity: 2, UnitPrice: 1000},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		deadline := time.Now().Add(time.Hour)
+		order := cancelledOrder(deadline)
+		// Locked once to claim the order and again to reactivate it
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Twice()
+		mockReservationRepo.On("CountPendingReservationReleases", mock.Anything, uint(1)).Return(int64(0), nil).Once()
+		mockOrderRepo.On("ClaimOrder", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		var reference string
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), reservedItems).DoAndReturn(
+			func(_ context.Context, ref string, _ []model.OrderItemRequest) error {
+				reference = ref
+				return nil
+			})
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, []entity.Reservation{
+			{OrderID: 1, ProductID: 1, WarehouseID: 2, Quantity: 2, ExpiresAt: deadline, IsActive: true},
+		}).Return(nil).Once()
+		mockOrderRepo.On("UpdateReservationReference", mock.Anything, uint(1), mock.AnythingOfType("string")).Return(nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusPending).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusCancelled, ToStatus: entity.OrderStatusPending, Actor: entity.StatusActorSystem}).Return(nil).Once()
+		mockOrderRepo.On("ReleaseOrderClaim", mock.Anything, uint(1)).Return(nil).Once()
+
+		reactivated := cancelledOrder(deadline)
+		reactivated.Status = entity.OrderStatusPending
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(reactivated, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+		// The old reference was resolved by the cancel, so the order reserves under a new one
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", response.Status)
+		assert.NotEmpty(t, reference)
+		assert.NotEqual(t, order.StockReference(), reference)
+		mockOrderRepo.AssertCalled(t, "UpdateReservationReference", mock.Anything, uint(1), reference)
+		mockOrderRepo.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("StockUnavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(cancelledOrder(time.Now().Add(time.Hour)), nil).Once()
+		mockReservationRepo.On("CountPendingReservationReleases", mock.Anything, uint(1)).Return(int64(0), nil).Once()
+		mockOrderRepo.On("ClaimOrder", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), reservedItems).Return(&entity.InsufficientStockError{
+			Items: []entity.StockShortage{{ProductID: 1, WarehouseID: 2, Requested: 2, Available: 1}},
+		})
+		mockOrderRepo.On("ReleaseOrderClaim", mock.Anything, uint(1)).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+		// The order stays cancelled and no reservation rows are created
+		var stockErr *entity.InsufficientStockError
+		assert.ErrorAs(t, err, &stockErr)
+		assert.ErrorIs(t, err, entity.ErrInsufficientStock)
+		assert.Nil(t, response)
+		mockReservationRepo.AssertNotCalled(t, "CreateReservationBatch", mock.Anything, mock.Anything)
+		mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeadlinePassed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(cancelledOrder(time.Now().Add(-time.Minute)), nil).Once()
+
+		// No stock is reserved for an order that can no longer be paid
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrPaymentDeadlinePassed)
+	})
+
+	t.Run("NotCancelled", func(t *testing.T) {
+		for _, status := range []entity.OrderStatus{entity.OrderStatusPending, entity.OrderStatusPaid, entity.OrderStatusCompleted} {
+			ctrl := gomock.NewController(t)
+			mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+			mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+			order := cancelledOrder(time.Now().Add(time.Hour))
+			order.Status = status
+			mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+
+			orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+				DB:                    newDB(t, false),
+				Log:                   logger,
+				Validate:              validate,
+				OrderRepository:       mockOrderRepo,
+				ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+				InventoryUseCase:      mockInventoryUseCase,
+				PaymentDeadline:       24 * time.Hour,
+				ExportMaxRange:        31 * 24 * time.Hour,
+				Timeouts:              appContext.DefaultTimeouts(),
+			})
+
+			_, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+			assert.ErrorIs(t, err, entity.ErrOrderNotCancelled, "status %s", status)
+		}
+	})
+
+	t.Run("ReleasePending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(cancelledOrder(time.Now().Add(time.Hour)), nil).Once()
+		mockReservationRepo.On("CountPendingReservationReleases", mock.Anything, uint(1)).Return(int64(1), nil).Once()
+
+		// A queued release would free the new reservation once it is retried
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrReservationReleasePending)
+	})
+
+	t.Run("ClaimedByAnotherReactivation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		claimedAt := time.Now()
+		order := cancelledOrder(time.Now().Add(time.Hour))
+		order.ClaimedAt = &claimedAt
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Once()
+		mockReservationRepo.On("CountPendingReservationReleases", mock.Anything, uint(1)).Return(int64(0), nil).Once()
+
+		// The other reactivation is reserving the stock, so this one does not
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrOrderOperationInProgress)
+	})
+
+	t.Run("OrderChangedWhileReserving", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		deadline := time.Now().Add(time.Hour)
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(cancelledOrder(deadline), nil).Once()
+		mockReservationRepo.On("CountPendingReservationReleases", mock.Anything, uint(1)).Return(int64(0), nil).Once()
+		mockOrderRepo.On("ClaimOrder", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		var reference string
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), reservedItems).DoAndReturn(
+			func(_ context.Context, ref string, _ []model.OrderItemRequest) error {
+				reference = ref
+				return nil
+			})
+
+		// An admin moved the order on while its stock was being reserved
+		changed := cancelledOrder(deadline)
+		changed.Status = entity.OrderStatusPaid
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(changed, nil).Once()
+
+		mockInventoryUseCase.EXPECT().ReleaseReservation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, ref string, _ []entity.OrderItem) error {
+				assert.Equal(t, reference, ref)
+				return nil
+			})
+		mockOrderRepo.On("ReleaseOrderClaim", mock.Anything, uint(1)).Return(nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.ReactivateOrder(context.Background(), 1)
+
+		// The new reservation is released and the order is left as the admin set it
+		assert.ErrorIs(t, err, entity.ErrOrderNotCancelled)
+		mockReservationRepo.AssertNotCalled(t, "CreateReservationBatch", mock.Anything, mock.Anything)
+		mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderUseCase_CompleteOrder(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	validate := newTestValidator()
+
+	newDB := func(t *testing.T, commit bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		sqlMock.ExpectBegin()
+		if commit {
+			sqlMock.ExpectCommit()
+		} else {
+			sqlMock.ExpectRollback()
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	newTracking := func() *model.CompleteOrderRequest {
+		return &model.CompleteOrderRequest{Carrier: " JNE ", TrackingNumber: " JNE123456789 "}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(&entity.Order{ID: 1, UserID: "user-1", Status: entity.OrderStatusPaid}, nil).Once()
+		mockOrderRepo.On("RecordShipment", mock.Anything, uint(1), "JNE", "JNE123456789", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockOrderRepo.On("UpdateOrderStatus", mock.Anything, uint(1), entity.OrderStatusCompleted).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderStatusHistory", mock.Anything, &entity.OrderStatusHistory{OrderID: 1, FromStatus: entity.OrderStatusPaid, ToStatus: entity.OrderStatusCompleted, Actor: "admin-1"}).Return(nil).Once()
+
+		completedAt := time.Now()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(&entity.Order{
+			ID:             1,
+			UserID:         "user-1",
+			Status:         entity.OrderStatusCompleted,
+			Carrier:        "JNE",
+			TrackingNumber: "JNE123456789",
+			CompletedAt:    &completedAt,
+		}, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		ctx := appContext.WithUserID(context.Background(), "admin-1")
+		response, err := orderUseCase.CompleteOrder(ctx, 1, newTracking())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "completed", response.Status)
+		assert.Equal(t, "JNE", response.Carrier)
+		assert.Equal(t, "JNE123456789", response.TrackingNumber)
+		assert.NotEmpty(t, response.CompletedAt)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	// Orders that are not paid are rejected without recording a shipment or a status change
+	invalidSources := []struct {
+		status      entity.OrderStatus
+		expectedErr error
+	}{
+		{entity.OrderStatusPending, entity.ErrOrderNotPaid},
+		{entity.OrderStatusCancelled, entity.ErrOrderNotPaid},
+		{entity.OrderStatusCompleted, entity.ErrOrderAlreadyCompleted},
+	}
+	for _, tt := range invalidSources {
+		t.Run(string(tt.status), func(t *testing.T) {
+			mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+			mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(&entity.Order{ID: 1, Status: tt.status}, nil).Once()
+
+			orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+				DB:                    newDB(t, false),
+				Log:                   logger,
+				Validate:              validate,
+				OrderRepository:       mockOrderRepo,
+				ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+				PaymentDeadline:       24 * time.Hour,
+				ExportMaxRange:        31 * 24 * time.Hour,
+				Timeouts:              appContext.DefaultTimeouts(),
+			})
+
+			response, err := orderUseCase.CompleteOrder(context.Background(), 1, newTracking())
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, response)
+			mockOrderRepo.AssertNotCalled(t, "RecordShipment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+			mockOrderRepo.AssertNotCalled(t, "CreateOrderStatusHistory", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockOrderRepo.On("FindOrderByIDForUpdate", mock.Anything, uint(1)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CompleteOrder(context.Background(), 1, newTracking())
+
+		assert.Equal(t, fiber.ErrNotFound, err)
+		assert.Nil(t, response)
+	})
+
+	t.Run("MissingTrackingNumber", func(t *testing.T) {
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CompleteOrder(context.Background(), 1, &model.CompleteOrderRequest{Carrier: "JNE", TrackingNumber: "  "})
+
+		var validationErrs validator.ValidationErrors
+		assert.ErrorAs(t, err, &validationErrs)
+		assert.Nil(t, response)
+		mockOrderRepo.AssertNotCalled(t, "FindOrderByIDForUpdate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCase_CreateOrder_Currency(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	newDB := func(t *testing.T) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	newRequest := func(currency string, itemCurrencies ...string) *model.CreateOrderRequest {
+		request := &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St",
+			PaymentMethod:   "credit_card",
+			Currency:        currency,
+		}
+		for i, itemCurrency := range itemCurrencies {
+			request.Items = append(request.Items, model.OrderItemRequest{
+				ProductID:   uint(i + 1),
+				WarehouseID: 1,
+				Quantity:    1,
+				UnitPrice:   1000,
+				Currency:    itemCurrency,
+			})
+		}
+		return request
+	}
+
+	// createdCurrency runs a successful CreateOrder and returns the currency stored on the order
+	createdCurrency := func(t *testing.T, request *model.CreateOrderRequest) string {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockInventoryUseCase.EXPECT().
+			CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		var stored string
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+			order := args.Get(1).(*entity.Order)
+			order.ID = 1
+			stored = order.Currency
+		}).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(&entity.Order{ID: 1}, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.CreateOrder(context.Background(), request)
+		assert.NoError(t, err)
+		return stored
+	}
+
+	t.Run("DefaultsToUSD", func(t *testing.T) {
+		assert.Equal(t, "USD", createdCurrency(t, newRequest("", "")))
+	})
+
+	t.Run("OrderCurrency", func(t *testing.T) {
+		assert.Equal(t, "EUR", createdCurrency(t, newRequest("EUR", "EUR", "")))
+	})
+
+	t.Run("InheritedFromItems", func(t *testing.T) {
+		assert.Equal(t, "IDR", createdCurrency(t, newRequest("", "IDR", "IDR")))
+	})
+
+	t.Run("MixedCurrencyRejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       new(repository_mock.OrderRepositoryMock),
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest("", "USD", "EUR"))
+
+		assert.ErrorIs(t, err, entity.ErrMixedCurrency)
+		assert.Nil(t, response)
+	})
+
+	t.Run("InvalidCurrencyRejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       new(repository_mock.OrderRepositoryMock),
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest("DOLLARS", ""))
+
+		var validationErrs validator.ValidationErrors
+		assert.ErrorAs(t, err, &validationErrs)
+		assert.Nil(t, response)
+	})
+}
+
+func TestOrderUseCase_CreateOrder_Validation(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validate := newTestValidator()
+	validate.RegisterTagNameFunc(appErrors.JSONFieldName)
+
+	newRequest := func() *model.CreateOrderRequest {
+		return &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St",
+			PaymentMethod:   "credit_card",
+			Items: []model.OrderItemRequest{
+				{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(request *model.CreateOrderRequest)
+		field  string
+		rule   string
+	}{
+		{"ZeroQuantity", func(r *model.CreateOrderRequest) { r.Items[0].Quantity = 0 }, "items[0].quantity", "gt"},
+		{"NegativePrice", func(r *model.CreateOrderRequest) { r.Items[0].UnitPrice = -500 }, "items[0].unit_price", "gte"},
+		{"MissingShippingAddress", func(r *model.CreateOrderRequest) { r.ShippingAddress = "" }, "shipping_address", "required"},
+		{"UnknownPaymentMethod", func(r *model.CreateOrderRequest) { r.PaymentMethod = "cash" }, "payment_method", "oneof"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Nothing is reserved or stored for an invalid request
+			ctrl := gomock.NewController(t)
+			mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+			orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+				Log:                   logger,
+				Validate:              validate,
+				OrderRepository:       new(repository_mock.OrderRepositoryMock),
+				ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+				InventoryUseCase:      mockInventoryUseCase,
+				PaymentDeadline:       24 * time.Hour,
+				ExportMaxRange:        31 * 24 * time.Hour,
+				Timeouts:              appContext.DefaultTimeouts(),
+			})
+
+			request := newRequest()
+			tt.modify(request)
+
+			response, err := orderUseCase.CreateOrder(context.Background(), request)
+
+			assert.Nil(t, response)
+			var validationErrs validator.ValidationErrors
+			if assert.ErrorAs(t, err, &validationErrs) {
+				appErr := appErrors.NewValidationError(validationErrs)
+				assert.Equal(t, "INVALID_INPUT", appErr.Code)
+				if assert.Len(t, appErr.Fields, 1) {
+					assert.Equal(t, tt.field, appErr.Fields[0].Field)
+					assert.Equal(t, tt.rule, appErr.Fields[0].Rule)
+				}
+			}
+		})
+	}
+
+	t.Run("FreeItemAllowed", func(t *testing.T) {
+		request := newRequest()
+		request.Items[0].UnitPrice = 0
+
+		assert.NoError(t, validate.Struct(request))
+	})
+
+	t.Run("PaymentMethodNormalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       new(repository_mock.OrderRepositoryMock),
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      usecase_mock.NewMockInventoryUseCaseInterface(ctrl),
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		// The quantity stops the order after validation; the padded, mixed-case method itself is accepted
+		request := newRequest()
+		request.PaymentMethod = " Bank_Transfer "
+		request.Items[0].Quantity = 0
+
+		_, err := orderUseCase.CreateOrder(context.Background(), request)
+
+		assert.Equal(t, "bank_transfer", request.PaymentMethod)
+		var validationErrs validator.ValidationErrors
+		if assert.ErrorAs(t, err, &validationErrs) && assert.Len(t, validationErrs, 1) {
+			assert.Equal(t, "Quantity", validationErrs[0].StructField())
+		}
+	})
+}
+
+func TestOrderUseCase_CreateOrder_Coupon(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	// newDB creates a GORM DB backed by sqlmock, optionally expecting a committed transaction
+	newDB := func(t *testing.T, expectCommit bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		if expectCommit {
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectCommit()
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	// newRequest orders 2 x 10.00 and 1 x 15.00 in USD, a 35.00 subtotal
+	newRequest := func(couponCode string) *model.CreateOrderRequest {
+		return &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St",
+			PaymentMethod:   "credit_card",
+			CouponCode:      couponCode,
+			Items: []model.OrderItemRequest{
+				{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+				{ProductID: 2, WarehouseID: 1, Quantity: 1, UnitPrice: 1500},
+			},
+		}
+	}
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	// createdOrder runs a successful CreateOrder with the coupon and returns the order that was stored
+	createdOrder := func(t *testing.T, coupon *entity.Coupon, couponCode string) *entity.Order {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockCouponRepo := new(repository_mock.CouponRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockCouponRepo.On("FindCouponByCode", mock.Anything, coupon.Code).Return(coupon, nil).Once()
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		stored := new(entity.Order)
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+			order := args.Get(1).(*entity.Order)
+			order.ID = 1
+			*stored = *order
+		}).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(stored, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			CouponRepository:      mockCouponRepo,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest(couponCode))
+		assert.NoError(t, err)
+		assert.Equal(t, stored.DiscountAmount, response.DiscountAmount)
+		assert.Equal(t, stored.CouponCode, response.CouponCode)
+		return stored
+	}
+
+	// rejected runs CreateOrder with a coupon that must be refused before any stock is reserved
+	rejected := func(t *testing.T, coupon *entity.Coupon, findErr error) error {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockCouponRepo := new(repository_mock.CouponRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		if coupon != nil {
+			mockCouponRepo.On("FindCouponByCode", mock.Anything, "PROMO").Return(coupon, nil).Once()
+		} else {
+			mockCouponRepo.On("FindCouponByCode", mock.Anything, "PROMO").Return(nil, findErr).Once()
+		}
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			CouponRepository:      mockCouponRepo,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest("promo"))
+		assert.Nil(t, response)
+		mockOrderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+		return err
+	}
+
+	t.Run("NoCoupon", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		var stored entity.Order
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+			stored = *args.Get(1).(*entity.Order)
+		}).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, mock.Anything).Return(&entity.Order{}, nil).Once()
+
+		// No coupon repository is needed when the order has no code
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		_, err := orderUseCase.CreateOrder(context.Background(), newRequest(""))
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Money(3500), stored.TotalAmount)
+		assert.Zero(t, stored.DiscountAmount)
+		assert.Empty(t, stored.CouponCode)
+	})
+
+	t.Run("PercentageDiscount", func(t *testing.T) {
+		order := createdOrder(t, &entity.Coupon{Code: "SAVE10", DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, IsActive: true, ExpiresAt: &future}, " save10 ")
+
+		assert.Equal(t, "SAVE10", order.CouponCode)
+		assert.Equal(t, entity.Money(350), order.DiscountAmount)
+		assert.Equal(t, entity.Money(3150), order.TotalAmount)
+	})
+
+	t.Run("FixedDiscount", func(t *testing.T) {
+		order := createdOrder(t, &entity.Coupon{Code: "FIVEOFF", DiscountType: entity.DiscountTypeFixed, DiscountValue: 5, Currency: "USD", IsActive: true}, "FIVEOFF")
+
+		assert.Equal(t, entity.Money(500), order.DiscountAmount)
+		assert.Equal(t, entity.Money(3000), order.TotalAmount)
+	})
+
+	t.Run("FixedDiscountCappedAtSubtotal", func(t *testing.T) {
+		order := createdOrder(t, &entity.Coupon{Code: "BIG", DiscountType: entity.DiscountTypeFixed, DiscountValue: 100, Currency: "USD", IsActive: true}, "BIG")
+
+		assert.Equal(t, entity.Money(3500), order.DiscountAmount)
+		assert.Equal(t, entity.Money(0), order.TotalAmount)
+	})
+
+	t.Run("UnknownCode", func(t *testing.T) {
+		assert.ErrorIs(t, rejected(t, nil, gorm.ErrRecordNotFound), entity.ErrInvalidCoupon)
+	})
+
+	t.Run("InactiveCode", func(t *testing.T) {
+		err := rejected(t, &entity.Coupon{Code: "PROMO", DiscountType: entity.DiscountTypePercentage, DiscountValue: 10}, nil)
+		assert.ErrorIs(t, err, entity.ErrInvalidCoupon)
+	})
+
+	t.Run("ExpiredCode", func(t *testing.T) {
+		err := rejected(t, &entity.Coupon{Code: "PROMO", DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, IsActive: true, ExpiresAt: &past}, nil)
+		assert.ErrorIs(t, err, entity.ErrCouponExpired)
+	})
+
+	t.Run("FixedDiscountInOtherCurrency", func(t *testing.T) {
+		err := rejected(t, &entity.Coupon{Code: "PROMO", DiscountType: entity.DiscountTypeFixed, DiscountValue: 5, Currency: "EUR", IsActive: true}, nil)
+		assert.ErrorIs(t, err, entity.ErrInvalidCoupon)
+	})
+
+	t.Run("LookupFailure", func(t *testing.T) {
+		assert.Equal(t, fiber.ErrInternalServerError, rejected(t, nil, errors.New("connection refused")))
+	})
+}
+
+func TestOrderUseCase_CreateOrder_MinimumOrderAmount(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	// newDB creates a GORM DB backed by sqlmock, optionally expecting a committed transaction
+	newDB := func(t *testing.T, expectCommit bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		if expectCommit {
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectCommit()
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	// newRequest orders 2 x 10.00 and 1 x 15.00 in USD, a 35.00 subtotal
+	newRequest := func(couponCode string) *model.CreateOrderRequest {
+		return &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St",
+			PaymentMethod:   "credit_card",
+			CouponCode:      couponCode,
+			Items: []model.OrderItemRequest{
+				{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+				{ProductID: 2, WarehouseID: 1, Quantity: 1, UnitPrice: 1500},
+			},
+		}
+	}
+
+	// accepted runs CreateOrder with the minimum and expects the order to be placed
+	accepted := func(t *testing.T, minimum entity.Money) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, mock.Anything).Return(&entity.Order{}, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+			MinimumOrderAmount:    minimum,
+		})
+
+		_, err := orderUseCase.CreateOrder(context.Background(), newRequest(""))
+		assert.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		accepted(t, 0)
+	})
+
+	t.Run("AtMinimum", func(t *testing.T) {
+		accepted(t, 3500)
+	})
+
+	t.Run("AboveMinimum", func(t *testing.T) {
+		accepted(t, 3499)
+	})
+
+	t.Run("JustBelowMinimum", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		// No stock is reserved for an order below the minimum
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			Timeouts:              appContext.DefaultTimeouts(),
+			MinimumOrderAmount:    3501,
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest(""))
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, entity.ErrBelowMinimumOrder)
+
+		var minimumErr *entity.BelowMinimumOrderError
+		if assert.ErrorAs(t, err, &minimumErr) {
+			assert.Equal(t, entity.Money(3501), minimumErr.Required)
+			assert.Equal(t, entity.Money(3500), minimumErr.Actual)
+		}
+		mockOrderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CheckedAfterDiscount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockCouponRepo := new(repository_mock.CouponRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockCouponRepo.On("FindCouponByCode", mock.Anything, "SAVE10").
+			Return(&entity.Coupon{Code: "SAVE10", DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, IsActive: true}, nil).Once()
+
+		// The 35.00 subtotal clears the minimum, but not once the 3.50 discount is taken off
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			CouponRepository:      mockCouponRepo,
+			Timeouts:              appContext.DefaultTimeouts(),
+			MinimumOrderAmount:    3200,
+		})
+
+		_, err := orderUseCase.CreateOrder(context.Background(), newRequest("SAVE10"))
+
+		var minimumErr *entity.BelowMinimumOrderError
+		if assert.ErrorAs(t, err, &minimumErr) {
+			assert.Equal(t, entity.Money(3200), minimumErr.Required)
+			assert.Equal(t, entity.Money(3150), minimumErr.Actual)
+		}
+	})
+}
+
+func TestOrderUseCase_CreateOrder_Tax(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	newDB := func(t *testing.T, expectCommit bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		if expectCommit {
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectCommit()
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	newRequest := func(couponCode string, items ...model.OrderItemRequest) *model.CreateOrderRequest {
+		return &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St, Springfield",
+			PaymentMethod:   "credit_card",
+			CouponCode:      couponCode,
+			Items:           items,
+		}
+	}
+
+	// 2 x 10.00 and 1 x 15.00, a 35.00 subtotal
+	items := []model.OrderItemRequest{
+		{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+		{ProductID: 2, WarehouseID: 1, Quantity: 1, UnitPrice: 1500},
+	}
+
+	// createdOrder runs a successful CreateOrder taxed at the given rate and returns the order that was stored
+	createdOrder := func(t *testing.T, rate float64, request *model.CreateOrderRequest, couponRepo repository.CouponRepositoryInterface) *entity.Order {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockTaxCalculator := tax_mock.NewMockTaxCalculatorInterface(ctrl)
+
+		mockTaxCalculator.EXPECT().TaxRate(gomock.Any(), request.ShippingAddress).Return(rate, nil)
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		stored := new(entity.Order)
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+			order := args.Get(1).(*entity.Order)
+			order.ID = 1
+			*stored = *order
+		}).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(stored, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			CouponRepository:      couponRepo,
+			TaxCalculator:         mockTaxCalculator,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, stored.Subtotal, response.Subtotal)
+		assert.Equal(t, stored.TaxRate, response.TaxRate)
+		assert.Equal(t, stored.TaxAmount, response.TaxAmount)
+		assert.Equal(t, stored.TotalAmount, response.TotalAmount)
+		assert.Equal(t, stored.TotalAmount, stored.Subtotal-stored.DiscountAmount+stored.TaxAmount)
+		return stored
+	}
+
+	t.Run("ZeroRate", func(t *testing.T) {
+		order := createdOrder(t, 0, newRequest("", items...), nil)
+
+		assert.Equal(t, entity.Money(3500), order.Subtotal)
+		assert.Equal(t, 0.0, order.TaxRate)
+		assert.Equal(t, entity.Money(0), order.TaxAmount)
+		assert.Equal(t, entity.Money(3500), order.TotalAmount)
+	})
+
+	t.Run("TypicalRate", func(t *testing.T) {
+		// 8.25% of 35.00 is 2.8875
+		order := createdOrder(t, 8.25, newRequest("", items...), nil)
+
+		assert.Equal(t, entity.Money(3500), order.Subtotal)
+		assert.Equal(t, 8.25, order.TaxRate)
+		assert.Equal(t, entity.Money(289), order.TaxAmount)
+		assert.Equal(t, entity.Money(3789), order.TotalAmount)
+	})
+
+	t.Run("RoundsHalfUp", func(t *testing.T) {
+		// 10% of 10.05 is exactly half a cent over 1.00
+		order := createdOrder(t, 10, newRequest("", model.OrderItemRequest{ProductID: 1, WarehouseID: 1, Quantity: 1, UnitPrice: 1005}), nil)
+
+		assert.Equal(t, entity.Money(1005), order.Subtotal)
+		assert.Equal(t, entity.Money(101), order.TaxAmount)
+		assert.Equal(t, entity.Money(1106), order.TotalAmount)
+	})
+
+	t.Run("ManyLinesSumExactly", func(t *testing.T) {
+		// 300 lines of 0.10 and 0.20: summed as float64 the subtotal drifts away from 45.00
+		lines := make([]model.OrderItemRequest, 0, 300)
+		var floatSubtotal float64
+		for i := 0; i < 150; i++ {
+			lines = append(lines,
+				model.OrderItemRequest{ProductID: uint(2*i + 1), WarehouseID: 1, Quantity: 1, UnitPrice: 10},
+				model.OrderItemRequest{ProductID: uint(2*i + 2), WarehouseID: 1, Quantity: 1, UnitPrice: 20},
+			)
+			floatSubtotal += 0.1
+			floatSubtotal += 0.2
+		}
+		assert.NotEqual(t, 45.0, floatSubtotal)
+
+		order := createdOrder(t, 10, newRequest("", lines...), nil)
+
+		assert.Equal(t, entity.Money(4500), order.Subtotal)
+		assert.Equal(t, entity.Money(450), order.TaxAmount)
+		assert.Equal(t, entity.Money(4950), order.TotalAmount)
+		assert.Equal(t, "49.50", order.TotalAmount.String())
+	})
+
+	t.Run("TaxesDiscountedSubtotal", func(t *testing.T) {
+		mockCouponRepo := new(repository_mock.CouponRepositoryMock)
+		mockCouponRepo.On("FindCouponByCode", mock.Anything, "SAVE10").
+			Return(&entity.Coupon{Code: "SAVE10", DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, IsActive: true}, nil).Once()
+
+		// 10% off 35.00 leaves 31.50, taxed at 10%
+		order := createdOrder(t, 10, newRequest("SAVE10", items...), mockCouponRepo)
+
+		assert.Equal(t, entity.Money(3500), order.Subtotal)
+		assert.Equal(t, entity.Money(350), order.DiscountAmount)
+		assert.Equal(t, entity.Money(315), order.TaxAmount)
+		assert.Equal(t, entity.Money(3465), order.TotalAmount)
+	})
+
+	t.Run("CalculatorFailure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockTaxCalculator := tax_mock.NewMockTaxCalculatorInterface(ctrl)
+
+		mockTaxCalculator.EXPECT().TaxRate(gomock.Any(), gomock.Any()).Return(0.0, errors.New("region not supported"))
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			TaxCalculator:         mockTaxCalculator,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest("", items...))
+		assert.Nil(t, response)
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		mockOrderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCase_CreateOrder_Shipping(t *testing.T) {
+	logger := logrus.New()
+	validate := newTestValidator()
+
+	newDB := func(t *testing.T, expectCommit bool) *gorm.DB {
+		sqlDB, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create SQL mock: %v", err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		if expectCommit {
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectCommit()
+		}
+
+		db, err := gorm.Open(mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("Failed to open GORM DB: %v", err)
+		}
+		return db
+	}
+
+	// newRequest orders 2 x 10.00 and 1 x 15.00, three units and a 35.00 subtotal
+	newRequest := func() *model.CreateOrderRequest {
+		return &model.CreateOrderRequest{
+			UserID:          "test-user-id",
+			ShippingAddress: "123 Test St",
+			PaymentMethod:   "credit_card",
+			Items: []model.OrderItemRequest{
+				{ProductID: 1, WarehouseID: 1, Quantity: 2, UnitPrice: 1000},
+				{ProductID: 2, WarehouseID: 1, Quantity: 1, UnitPrice: 1500},
+			},
+		}
+	}
+
+	// createdOrder runs a successful CreateOrder with the shipping calculator and returns the order that was stored
+	createdOrder := func(t *testing.T, shippingCalculator shipping.ShippingCalculatorInterface, taxCalculator tax.TaxCalculatorInterface) *entity.Order {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockReservationRepo := new(repository_mock.ReservationRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+
+		mockInventoryUseCase.EXPECT().CheckAndReserveStock(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		stored := new(entity.Order)
+		mockOrderRepo.On("CreateOrder", mock.Anything, mock.AnythingOfType("*entity.Order")).Run(func(args mock.Arguments) {
+			order := args.Get(1).(*entity.Order)
+			order.ID = 1
+			*stored = *order
+		}).Return(nil).Once()
+		mockOrderRepo.On("CreateOrderItems", mock.Anything, mock.Anything).Return(nil).Once()
+		mockReservationRepo.On("CreateReservationBatch", mock.Anything, mock.Anything).Return(nil).Once()
+		mockOrderRepo.On("FindOrderByID", mock.Anything, uint(1)).Return(stored, nil).Once()
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, true),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: mockReservationRepo,
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			TaxCalculator:         taxCalculator,
+			ShippingCalculator:    shippingCalculator,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, stored.ShippingCost, response.ShippingCost)
+		assert.Equal(t, stored.TotalAmount, response.TotalAmount)
+		return stored
+	}
+
+	t.Run("BelowFreeThreshold", func(t *testing.T) {
+		// 5.00 base plus 1.50 for each of the three units
+		order := createdOrder(t, shipping.NewQuantityShippingCalculator(5, 1.5, 50, logger), nil)
+
+		assert.Equal(t, entity.Money(950), order.ShippingCost)
+		assert.Equal(t, entity.Money(4450), order.TotalAmount)
+	})
+
+	t.Run("AtFreeThreshold", func(t *testing.T) {
+		order := createdOrder(t, shipping.NewQuantityShippingCalculator(5, 1.5, 35, logger), nil)
+
+		assert.Equal(t, entity.Money(0), order.ShippingCost)
+		assert.Equal(t, entity.Money(3500), order.TotalAmount)
+	})
+
+	t.Run("AboveFreeThreshold", func(t *testing.T) {
+		order := createdOrder(t, shipping.NewQuantityShippingCalculator(5, 1.5, 30, logger), nil)
+
+		assert.Equal(t, entity.Money(0), order.ShippingCost)
+		assert.Equal(t, entity.Money(3500), order.TotalAmount)
+	})
+
+	t.Run("FreeThresholdDisabled", func(t *testing.T) {
+		order := createdOrder(t, shipping.NewQuantityShippingCalculator(5, 1.5, 0, logger), nil)
+
+		assert.Equal(t, entity.Money(950), order.ShippingCost)
+		assert.Equal(t, entity.Money(4450), order.TotalAmount)
+	})
+
+	t.Run("NotTaxed", func(t *testing.T) {
+		mockTaxCalculator := tax_mock.NewMockTaxCalculatorInterface(gomock.NewController(t))
+		mockTaxCalculator.EXPECT().TaxRate(gomock.Any(), gomock.Any()).Return(10.0, nil)
+
+		order := createdOrder(t, shipping.NewQuantityShippingCalculator(5, 0, 0, logger), mockTaxCalculator)
+
+		// 10% tax on the 35.00 subtotal only, then 5.00 shipping
+		assert.Equal(t, entity.Money(350), order.TaxAmount)
+		assert.Equal(t, entity.Money(500), order.ShippingCost)
+		assert.Equal(t, entity.Money(4350), order.TotalAmount)
+	})
+
+	t.Run("CalculatorFailure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrderRepo := new(repository_mock.OrderRepositoryMock)
+		mockInventoryUseCase := usecase_mock.NewMockInventoryUseCaseInterface(ctrl)
+		mockShippingCalculator := shipping_mock.NewMockShippingCalculatorInterface(ctrl)
+
+		mockShippingCalculator.EXPECT().ShippingCost(gomock.Any(), gomock.Len(2), "123 Test St").Return(entity.Money(0), errors.New("carrier unavailable"))
+
+		orderUseCase := NewOrderUseCase(OrderUseCaseDeps{
+			DB:                    newDB(t, false),
+			Log:                   logger,
+			Validate:              validate,
+			OrderRepository:       mockOrderRepo,
+			ReservationRepository: new(repository_mock.ReservationRepositoryMock),
+			InventoryUseCase:      mockInventoryUseCase,
+			PaymentDeadline:       24 * time.Hour,
+			ExportMaxRange:        31 * 24 * time.Hour,
+			ShippingCalculator:    mockShippingCalculator,
+			Timeouts:              appContext.DefaultTimeouts(),
+		})
+
+		response, err := orderUseCase.CreateOrder(context.Background(), newRequest())
+		assert.Nil(t, response)
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		mockOrderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	})
+}
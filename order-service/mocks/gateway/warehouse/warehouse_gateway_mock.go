@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: ./internal/gateway/warehouse/interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/gateway/warehouse/interface.go -destination=./mocks/gateway/warehouse/warehouse_gateway_mock.go -package=warehouse_mock
+//
 
 // Package warehouse_mock is a generated GoMock package.
 package warehouse_mock
@@ -18,6 +23,7 @@ import (
 type MockWarehouseGatewayInterface struct {
 	ctrl     *gomock.Controller
 	recorder *MockWarehouseGatewayInterfaceMockRecorder
+	isgomock struct{}
 }
 
 // MockWarehouseGatewayInterfaceMockRecorder is the mock recorder for MockWarehouseGatewayInterface.
@@ -38,33 +44,33 @@ func (m *MockWarehouseGatewayInterface) EXPECT() *MockWarehouseGatewayInterfaceM
 }
 
 // CheckAndReserveStock mocks base method.
-func (m *MockWarehouseGatewayInterface) CheckAndReserveStock(ctx context.Context, orderID uint, items []model.OrderItemRequest, reserveUntil string) (*warehouse.ReservationResponse, error) {
+func (m *MockWarehouseGatewayInterface) CheckAndReserveStock(ctx context.Context, orderID uint, reference string, items []model.OrderItemRequest, reserveUntil string) (*warehouse.ReservationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckAndReserveStock", ctx, orderID, items, reserveUntil)
+	ret := m.ctrl.Call(m, "CheckAndReserveStock", ctx, orderID, reference, items, reserveUntil)
 	ret0, _ := ret[0].(*warehouse.ReservationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CheckAndReserveStock indicates an expected call of CheckAndReserveStock.
-func (mr *MockWarehouseGatewayInterfaceMockRecorder) CheckAndReserveStock(ctx, orderID, items, reserveUntil interface{}) *gomock.Call {
+func (mr *MockWarehouseGatewayInterfaceMockRecorder) CheckAndReserveStock(ctx, orderID, reference, items, reserveUntil any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAndReserveStock", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).CheckAndReserveStock), ctx, orderID, items, reserveUntil)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAndReserveStock", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).CheckAndReserveStock), ctx, orderID, reference, items, reserveUntil)
 }
 
 // ConfirmStockDeduction mocks base method.
-func (m *MockWarehouseGatewayInterface) ConfirmStockDeduction(ctx context.Context, orderID uint, reservationID string) (*warehouse.StockOperationResponse, error) {
+func (m *MockWarehouseGatewayInterface) ConfirmStockDeduction(ctx context.Context, orderID uint, reservation warehouse.ReservationCommitRequest) (*warehouse.StockOperationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmStockDeduction", ctx, orderID, reservationID)
+	ret := m.ctrl.Call(m, "ConfirmStockDeduction", ctx, orderID, reservation)
 	ret0, _ := ret[0].(*warehouse.StockOperationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ConfirmStockDeduction indicates an expected call of ConfirmStockDeduction.
-func (mr *MockWarehouseGatewayInterfaceMockRecorder) ConfirmStockDeduction(ctx, orderID, reservationID interface{}) *gomock.Call {
+func (mr *MockWarehouseGatewayInterfaceMockRecorder) ConfirmStockDeduction(ctx, orderID, reservation any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmStockDeduction", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).ConfirmStockDeduction), ctx, orderID, reservationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmStockDeduction", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).ConfirmStockDeduction), ctx, orderID, reservation)
 }
 
 // GetInventory mocks base method.
@@ -77,7 +83,7 @@ func (m *MockWarehouseGatewayInterface) GetInventory(ctx context.Context, produc
 }
 
 // GetInventory indicates an expected call of GetInventory.
-func (mr *MockWarehouseGatewayInterfaceMockRecorder) GetInventory(ctx, productID, warehouseID interface{}) *gomock.Call {
+func (mr *MockWarehouseGatewayInterfaceMockRecorder) GetInventory(ctx, productID, warehouseID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).GetInventory), ctx, productID, warehouseID)
 }
@@ -92,24 +98,24 @@ func (m *MockWarehouseGatewayInterface) GetInventoryBatch(ctx context.Context, i
 }
 
 // GetInventoryBatch indicates an expected call of GetInventoryBatch.
-func (mr *MockWarehouseGatewayInterfaceMockRecorder) GetInventoryBatch(ctx, items interface{}) *gomock.Call {
+func (mr *MockWarehouseGatewayInterfaceMockRecorder) GetInventoryBatch(ctx, items any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryBatch", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).GetInventoryBatch), ctx, items)
 }
 
 // ReleaseReservation mocks base method.
-func (m *MockWarehouseGatewayInterface) ReleaseReservation(ctx context.Context, orderID uint, reservationID string) (*warehouse.StockOperationResponse, error) {
+func (m *MockWarehouseGatewayInterface) ReleaseReservation(ctx context.Context, orderID uint, reservation warehouse.ReservationReleaseRequest) (*warehouse.StockOperationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, orderID, reservationID)
+	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, orderID, reservation)
 	ret0, _ := ret[0].(*warehouse.StockOperationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReleaseReservation indicates an expected call of ReleaseReservation.
-func (mr *MockWarehouseGatewayInterfaceMockRecorder) ReleaseReservation(ctx, orderID, reservationID interface{}) *gomock.Call {
+func (mr *MockWarehouseGatewayInterfaceMockRecorder) ReleaseReservation(ctx, orderID, reservation any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).ReleaseReservation), ctx, orderID, reservationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).ReleaseReservation), ctx, orderID, reservation)
 }
 
 // UpdateInventory mocks base method.
@@ -122,7 +128,7 @@ func (m *MockWarehouseGatewayInterface) UpdateInventory(ctx context.Context, inv
 }
 
 // UpdateInventory indicates an expected call of UpdateInventory.
-func (mr *MockWarehouseGatewayInterfaceMockRecorder) UpdateInventory(ctx, inventory interface{}) *gomock.Call {
+func (mr *MockWarehouseGatewayInterfaceMockRecorder) UpdateInventory(ctx, inventory any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventory", reflect.TypeOf((*MockWarehouseGatewayInterface)(nil).UpdateInventory), ctx, inventory)
 }
@@ -0,0 +1,22 @@
+package repository_mock
+
+import (
+	"order-service/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// CouponRepositoryMock is a mock implementation of the CouponRepositoryInterface
+type CouponRepositoryMock struct {
+	mock.Mock
+}
+
+// FindCouponByCode mocks the FindCouponByCode method
+func (m *CouponRepositoryMock) FindCouponByCode(tx *gorm.DB, code string) (*entity.Coupon, error) {
+	args := m.Called(tx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Coupon), args.Error(1)
+}
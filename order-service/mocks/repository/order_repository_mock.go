@@ -2,6 +2,7 @@ package repository_mock
 
 import (
 	"order-service/internal/entity"
+	"order-service/internal/repository"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -36,9 +37,20 @@ func (m *OrderRepositoryMock) FindOrderByID(tx *gorm.DB, orderID uint) (*entity.
 	return args.Get(0).(*entity.Order), args.Error(1)
 }
 
+// FindOrderByIDForUpdate mocks the FindOrderByIDForUpdate method
+func (m *OrderRepositoryMock) FindOrderByIDForUpdate(tx *gorm.DB, orderID uint) (*entity.Order, error) {
+	args := m.Called(tx, orderID)
+	
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	
+	return args.Get(0).(*entity.Order), args.Error(1)
+}
+
 // FindOrdersByUserID mocks the FindOrdersByUserID method
-func (m *OrderRepositoryMock) FindOrdersByUserID(tx *gorm.DB, userID string, page, limit int) ([]entity.Order, int64, error) {
-	args := m.Called(tx, userID, page, limit)
+func (m *OrderRepositoryMock) FindOrdersByUserID(tx *gorm.DB, userID string, filter repository.OrderFilter, page, limit int) ([]entity.Order, int64, error) {
+	args := m.Called(tx, userID, filter, page, limit)
 	
 	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
 }
@@ -50,15 +62,110 @@ func (m *OrderRepositoryMock) FindOrdersByStatus(tx *gorm.DB, status entity.Orde
 	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
 }
 
+// SearchOrders mocks the SearchOrders method
+func (m *OrderRepositoryMock) SearchOrders(tx *gorm.DB, filter repository.OrderFilter, page, limit int) ([]entity.Order, int64, error) {
+	args := m.Called(tx, filter, page, limit)
+
+	return args.Get(0).([]entity.Order), args.Get(1).(int64), args.Error(2)
+}
+
 // UpdateOrderStatus mocks the UpdateOrderStatus method
 func (m *OrderRepositoryMock) UpdateOrderStatus(tx *gorm.DB, orderID uint, status entity.OrderStatus) error {
 	args := m.Called(tx, orderID, status)
 	return args.Error(0)
 }
 
+// CreateOrderStatusHistory mocks the CreateOrderStatusHistory method
+func (m *OrderRepositoryMock) CreateOrderStatusHistory(tx *gorm.DB, history *entity.OrderStatusHistory) error {
+	args := m.Called(tx, history)
+	return args.Error(0)
+}
+
+// FindOrderStatusHistory mocks the FindOrderStatusHistory method
+func (m *OrderRepositoryMock) FindOrderStatusHistory(tx *gorm.DB, orderID uint) ([]entity.OrderStatusHistory, error) {
+	args := m.Called(tx, orderID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.OrderStatusHistory), args.Error(1)
+}
+
 // FindExpiredOrders mocks the FindExpiredOrders method
 func (m *OrderRepositoryMock) FindExpiredOrders(tx *gorm.DB, deadline time.Time) ([]entity.Order, error) {
 	args := m.Called(tx, deadline)
 	
 	return args.Get(0).([]entity.Order), args.Error(1)
-}
\ No newline at end of file
+}
+
+// DeleteOrderItems mocks the DeleteOrderItems method
+func (m *OrderRepositoryMock) DeleteOrderItems(tx *gorm.DB, itemIDs []uint) error {
+	args := m.Called(tx, itemIDs)
+	return args.Error(0)
+}
+
+// UpdateOrderAmounts mocks the UpdateOrderAmounts method
+func (m *OrderRepositoryMock) UpdateOrderAmounts(tx *gorm.DB, order *entity.Order) error {
+	args := m.Called(tx, order)
+	return args.Error(0)
+}
+
+// UpdatePaymentReference mocks the UpdatePaymentReference method
+func (m *OrderRepositoryMock) UpdatePaymentReference(tx *gorm.DB, orderID uint, reference string) error {
+	args := m.Called(tx, orderID, reference)
+	return args.Error(0)
+}
+
+// IncrementPaymentAttempts mocks the IncrementPaymentAttempts method
+func (m *OrderRepositoryMock) IncrementPaymentAttempts(tx *gorm.DB, orderID uint) error {
+	args := m.Called(tx, orderID)
+	return args.Error(0)
+}
+
+// UpdateReservationReference mocks the UpdateReservationReference method
+func (m *OrderRepositoryMock) UpdateReservationReference(tx *gorm.DB, orderID uint, reference string) error {
+	args := m.Called(tx, orderID, reference)
+	return args.Error(0)
+}
+
+// RecordStockDeduction mocks the RecordStockDeduction method
+func (m *OrderRepositoryMock) RecordStockDeduction(tx *gorm.DB, orderID uint, deductedAt time.Time) error {
+	args := m.Called(tx, orderID, deductedAt)
+	return args.Error(0)
+}
+// RecordStockDeductionFailure mocks the RecordStockDeductionFailure method
+func (m *OrderRepositoryMock) RecordStockDeductionFailure(tx *gorm.DB, orderID uint, lastError string) error {
+	args := m.Called(tx, orderID, lastError)
+	return args.Error(0)
+}
+// RecordShipment mocks the RecordShipment method
+func (m *OrderRepositoryMock) RecordShipment(tx *gorm.DB, orderID uint, carrier, trackingNumber string, completedAt time.Time) error {
+	args := m.Called(tx, orderID, carrier, trackingNumber, completedAt)
+	return args.Error(0)
+}
+
+// ClaimOrder mocks the ClaimOrder method
+func (m *OrderRepositoryMock) ClaimOrder(tx *gorm.DB, orderID uint, claimedAt time.Time) error {
+	args := m.Called(tx, orderID, claimedAt)
+	return args.Error(0)
+}
+
+// ReleaseOrderClaim mocks the ReleaseOrderClaim method
+func (m *OrderRepositoryMock) ReleaseOrderClaim(tx *gorm.DB, orderID uint) error {
+	args := m.Called(tx, orderID)
+	return args.Error(0)
+}
+// StreamOrdersForExport mocks the StreamOrdersForExport method
+func (m *OrderRepositoryMock) StreamOrdersForExport(tx *gorm.DB, filter repository.OrderFilter, fn func(row *repository.OrderExportRow) error) error {
+	args := m.Called(tx, filter, fn)
+	return args.Error(0)
+}
+
+// SummarizeOrdersByUserID mocks the SummarizeOrdersByUserID method
+func (m *OrderRepositoryMock) SummarizeOrdersByUserID(tx *gorm.DB, userID string) ([]repository.OrderStatusTotal, error) {
+	args := m.Called(tx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.OrderStatusTotal), args.Error(1)
+}
@@ -49,4 +49,48 @@ func (m *ReservationRepositoryMock) FindExpiredReservations(tx *gorm.DB, current
 	args := m.Called(tx, currentTime)
 	
 	return args.Get(0).([]entity.Reservation), args.Error(1)
+}
+
+// DeactivateReservationsByOrderItems mocks the DeactivateReservationsByOrderItems method
+func (m *ReservationRepositoryMock) DeactivateReservationsByOrderItems(tx *gorm.DB, orderID uint, items []entity.OrderItem) error {
+	args := m.Called(tx, orderID, items)
+	return args.Error(0)
+}
+
+// EnqueueReservationReleases mocks the EnqueueReservationReleases method
+func (m *ReservationRepositoryMock) EnqueueReservationReleases(tx *gorm.DB, orderID uint, reference string, items []entity.OrderItem) ([]entity.ReservationReleaseOutbox, error) {
+	args := m.Called(tx, orderID, reference, items)
+
+	return args.Get(0).([]entity.ReservationReleaseOutbox), args.Error(1)
+}
+
+// FindPendingReservationReleases mocks the FindPendingReservationReleases method
+func (m *ReservationRepositoryMock) FindPendingReservationReleases(tx *gorm.DB, limit int) ([]entity.ReservationReleaseOutbox, error) {
+	args := m.Called(tx, limit)
+
+	return args.Get(0).([]entity.ReservationReleaseOutbox), args.Error(1)
+}
+
+// CountPendingReservationReleases mocks the CountPendingReservationReleases method
+func (m *ReservationRepositoryMock) CountPendingReservationReleases(tx *gorm.DB, orderID uint) (int64, error) {
+	args := m.Called(tx, orderID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MarkReservationReleasesDone mocks the MarkReservationReleasesDone method
+func (m *ReservationRepositoryMock) MarkReservationReleasesDone(tx *gorm.DB, ids []uint) error {
+	args := m.Called(tx, ids)
+	return args.Error(0)
+}
+
+// RecordReservationReleaseFailure mocks the RecordReservationReleaseFailure method
+func (m *ReservationRepositoryMock) RecordReservationReleaseFailure(tx *gorm.DB, id uint, lastError string) error {
+	args := m.Called(tx, id, lastError)
+	return args.Error(0)
+}
+
+// DeleteDoneReservationReleases mocks the DeleteDoneReservationReleases method
+func (m *ReservationRepositoryMock) DeleteDoneReservationReleases(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(tx, cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
 }
\ No newline at end of file
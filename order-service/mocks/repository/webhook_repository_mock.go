@@ -0,0 +1,47 @@
+package repository_mock
+
+import (
+	"order-service/internal/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// WebhookRepositoryMock is a mock implementation of the WebhookRepositoryInterface
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+// EnqueueWebhookEvent mocks the EnqueueWebhookEvent method
+func (m *WebhookRepositoryMock) EnqueueWebhookEvent(tx *gorm.DB, event *entity.WebhookEvent) error {
+	args := m.Called(tx, event)
+	return args.Error(0)
+}
+
+// FindDueWebhookEvents mocks the FindDueWebhookEvents method
+func (m *WebhookRepositoryMock) FindDueWebhookEvents(tx *gorm.DB, now time.Time, limit int) ([]entity.WebhookEvent, error) {
+	args := m.Called(tx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.WebhookEvent), args.Error(1)
+}
+
+// MarkWebhookEventDelivered mocks the MarkWebhookEventDelivered method
+func (m *WebhookRepositoryMock) MarkWebhookEventDelivered(tx *gorm.DB, id uint, deliveredAt time.Time) error {
+	args := m.Called(tx, id, deliveredAt)
+	return args.Error(0)
+}
+
+// RecordWebhookEventFailure mocks the RecordWebhookEventFailure method
+func (m *WebhookRepositoryMock) RecordWebhookEventFailure(tx *gorm.DB, id uint, lastError string, nextAttemptAt time.Time, giveUp bool) error {
+	args := m.Called(tx, id, lastError, nextAttemptAt, giveUp)
+	return args.Error(0)
+}
+
+// DeleteFinishedWebhookEvents mocks the DeleteFinishedWebhookEvents method
+func (m *WebhookRepositoryMock) DeleteFinishedWebhookEvents(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(tx, cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
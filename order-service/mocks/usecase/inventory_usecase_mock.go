@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: ./internal/usecase/interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/usecase/interface.go -destination=./mocks/usecase/inventory_usecase_mock.go -package=usecase_mock
+//
 
 // Package usecase_mock is a generated GoMock package.
 package usecase_mock
@@ -17,6 +22,7 @@ import (
 type MockInventoryUseCaseInterface struct {
 	ctrl     *gomock.Controller
 	recorder *MockInventoryUseCaseInterfaceMockRecorder
+	isgomock struct{}
 }
 
 // MockInventoryUseCaseInterfaceMockRecorder is the mock recorder for MockInventoryUseCaseInterface.
@@ -37,31 +43,46 @@ func (m *MockInventoryUseCaseInterface) EXPECT() *MockInventoryUseCaseInterfaceM
 }
 
 // CheckAndReserveStock mocks base method.
-func (m *MockInventoryUseCaseInterface) CheckAndReserveStock(ctx context.Context, items []model.OrderItemRequest) error {
+func (m *MockInventoryUseCaseInterface) CheckAndReserveStock(ctx context.Context, reference string, items []model.OrderItemRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckAndReserveStock", ctx, items)
+	ret := m.ctrl.Call(m, "CheckAndReserveStock", ctx, reference, items)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CheckAndReserveStock indicates an expected call of CheckAndReserveStock.
-func (mr *MockInventoryUseCaseInterfaceMockRecorder) CheckAndReserveStock(ctx, items interface{}) *gomock.Call {
+func (mr *MockInventoryUseCaseInterfaceMockRecorder) CheckAndReserveStock(ctx, reference, items any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAndReserveStock", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).CheckAndReserveStock), ctx, items)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAndReserveStock", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).CheckAndReserveStock), ctx, reference, items)
+}
+
+// CheckAvailability mocks base method.
+func (m *MockInventoryUseCaseInterface) CheckAvailability(ctx context.Context, items []model.OrderItemRequest) (*model.AvailabilityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAvailability", ctx, items)
+	ret0, _ := ret[0].(*model.AvailabilityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAvailability indicates an expected call of CheckAvailability.
+func (mr *MockInventoryUseCaseInterfaceMockRecorder) CheckAvailability(ctx, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAvailability", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).CheckAvailability), ctx, items)
 }
 
 // ConfirmStockDeduction mocks base method.
-func (m *MockInventoryUseCaseInterface) ConfirmStockDeduction(ctx context.Context, orderItems []entity.OrderItem) error {
+func (m *MockInventoryUseCaseInterface) ConfirmStockDeduction(ctx context.Context, reference string, orderItems []entity.OrderItem) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmStockDeduction", ctx, orderItems)
+	ret := m.ctrl.Call(m, "ConfirmStockDeduction", ctx, reference, orderItems)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ConfirmStockDeduction indicates an expected call of ConfirmStockDeduction.
-func (mr *MockInventoryUseCaseInterfaceMockRecorder) ConfirmStockDeduction(ctx, orderItems interface{}) *gomock.Call {
+func (mr *MockInventoryUseCaseInterfaceMockRecorder) ConfirmStockDeduction(ctx, reference, orderItems any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmStockDeduction", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).ConfirmStockDeduction), ctx, orderItems)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmStockDeduction", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).ConfirmStockDeduction), ctx, reference, orderItems)
 }
 
 // GetInventory mocks base method.
@@ -74,23 +95,23 @@ func (m *MockInventoryUseCaseInterface) GetInventory(ctx context.Context, produc
 }
 
 // GetInventory indicates an expected call of GetInventory.
-func (mr *MockInventoryUseCaseInterfaceMockRecorder) GetInventory(ctx, productID, warehouseID interface{}) *gomock.Call {
+func (mr *MockInventoryUseCaseInterfaceMockRecorder) GetInventory(ctx, productID, warehouseID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).GetInventory), ctx, productID, warehouseID)
 }
 
 // ReleaseReservation mocks base method.
-func (m *MockInventoryUseCaseInterface) ReleaseReservation(ctx context.Context, orderItems []entity.OrderItem) error {
+func (m *MockInventoryUseCaseInterface) ReleaseReservation(ctx context.Context, reference string, orderItems []entity.OrderItem) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, orderItems)
+	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, reference, orderItems)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ReleaseReservation indicates an expected call of ReleaseReservation.
-func (mr *MockInventoryUseCaseInterfaceMockRecorder) ReleaseReservation(ctx, orderItems interface{}) *gomock.Call {
+func (mr *MockInventoryUseCaseInterfaceMockRecorder) ReleaseReservation(ctx, reference, orderItems any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).ReleaseReservation), ctx, orderItems)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).ReleaseReservation), ctx, reference, orderItems)
 }
 
 // UpdateInventory mocks base method.
@@ -102,7 +123,7 @@ func (m *MockInventoryUseCaseInterface) UpdateInventory(ctx context.Context, inv
 }
 
 // UpdateInventory indicates an expected call of UpdateInventory.
-func (mr *MockInventoryUseCaseInterfaceMockRecorder) UpdateInventory(ctx, inventory interface{}) *gomock.Call {
+func (mr *MockInventoryUseCaseInterfaceMockRecorder) UpdateInventory(ctx, inventory any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventory", reflect.TypeOf((*MockInventoryUseCaseInterface)(nil).UpdateInventory), ctx, inventory)
 }
@@ -11,6 +11,7 @@ package usecase_mock
 
 import (
 	context "context"
+	iter "iter"
 	model "order-service/internal/model"
 	reflect "reflect"
 
@@ -42,11 +43,12 @@ func (m *MockOrderUseCaseInterface) EXPECT() *MockOrderUseCaseInterfaceMockRecor
 }
 
 // CancelExpiredOrders mocks base method.
-func (m *MockOrderUseCaseInterface) CancelExpiredOrders(ctx context.Context) error {
+func (m *MockOrderUseCaseInterface) CancelExpiredOrders(ctx context.Context) (*model.ExpirySweepResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CancelExpiredOrders", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*model.ExpirySweepResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CancelExpiredOrders indicates an expected call of CancelExpiredOrders.
@@ -55,6 +57,36 @@ func (mr *MockOrderUseCaseInterfaceMockRecorder) CancelExpiredOrders(ctx any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelExpiredOrders", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).CancelExpiredOrders), ctx)
 }
 
+// CancelOrderItems mocks base method.
+func (m *MockOrderUseCaseInterface) CancelOrderItems(ctx context.Context, orderID uint, items []model.OrderItemRequest) (*model.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrderItems", ctx, orderID, items)
+	ret0, _ := ret[0].(*model.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrderItems indicates an expected call of CancelOrderItems.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) CancelOrderItems(ctx, orderID, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrderItems", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).CancelOrderItems), ctx, orderID, items)
+}
+
+// CompleteOrder mocks base method.
+func (m *MockOrderUseCaseInterface) CompleteOrder(ctx context.Context, orderID uint, tracking *model.CompleteOrderRequest) (*model.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOrder", ctx, orderID, tracking)
+	ret0, _ := ret[0].(*model.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOrder indicates an expected call of CompleteOrder.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) CompleteOrder(ctx, orderID, tracking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrder", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).CompleteOrder), ctx, orderID, tracking)
+}
+
 // CreateOrder mocks base method.
 func (m *MockOrderUseCaseInterface) CreateOrder(ctx context.Context, request *model.CreateOrderRequest) (*model.OrderResponse, error) {
 	m.ctrl.T.Helper()
@@ -70,35 +102,79 @@ func (mr *MockOrderUseCaseInterfaceMockRecorder) CreateOrder(ctx, request any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).CreateOrder), ctx, request)
 }
 
+// ExportOrders mocks base method.
+func (m *MockOrderUseCaseInterface) ExportOrders(ctx context.Context, filter model.OrderListFilter) (iter.Seq2[*model.OrderExportRow, error], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportOrders", ctx, filter)
+	ret0, _ := ret[0].(iter.Seq2[*model.OrderExportRow, error])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportOrders indicates an expected call of ExportOrders.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) ExportOrders(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportOrders", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).ExportOrders), ctx, filter)
+}
+
 // GetOrderByID mocks base method.
-func (m *MockOrderUseCaseInterface) GetOrderByID(ctx context.Context, orderID uint) (*model.OrderResponse, error) {
+func (m *MockOrderUseCaseInterface) GetOrderByID(ctx context.Context, orderID uint, includeReservations bool) (*model.OrderResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderByID", ctx, orderID)
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, orderID, includeReservations)
 	ret0, _ := ret[0].(*model.OrderResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrderByID indicates an expected call of GetOrderByID.
-func (mr *MockOrderUseCaseInterfaceMockRecorder) GetOrderByID(ctx, orderID any) *gomock.Call {
+func (mr *MockOrderUseCaseInterfaceMockRecorder) GetOrderByID(ctx, orderID, includeReservations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).GetOrderByID), ctx, orderID, includeReservations)
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *MockOrderUseCaseInterface) GetOrderStatusHistory(ctx context.Context, orderID uint) (*model.OrderStatusHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, orderID)
+	ret0, _ := ret[0].(*model.OrderStatusHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) GetOrderStatusHistory(ctx, orderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).GetOrderByID), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).GetOrderStatusHistory), ctx, orderID)
 }
 
 // GetOrdersByUserID mocks base method.
-func (m *MockOrderUseCaseInterface) GetOrdersByUserID(ctx context.Context, userID string, page, limit int) ([]model.OrderResponse, int64, error) {
+func (m *MockOrderUseCaseInterface) GetOrdersByUserID(ctx context.Context, userID string, filter model.OrderListFilter, page, limit int) (*model.OrderListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersByUserID", ctx, userID, page, limit)
-	ret0, _ := ret[0].([]model.OrderResponse)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetOrdersByUserID", ctx, userID, filter, page, limit)
+	ret0, _ := ret[0].(*model.OrderListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetOrdersByUserID indicates an expected call of GetOrdersByUserID.
-func (mr *MockOrderUseCaseInterfaceMockRecorder) GetOrdersByUserID(ctx, userID, page, limit any) *gomock.Call {
+func (mr *MockOrderUseCaseInterfaceMockRecorder) GetOrdersByUserID(ctx, userID, filter, page, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserID", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).GetOrdersByUserID), ctx, userID, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserID", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).GetOrdersByUserID), ctx, userID, filter, page, limit)
+}
+
+// GetUserOrderSummary mocks base method.
+func (m *MockOrderUseCaseInterface) GetUserOrderSummary(ctx context.Context, userID string) (*model.OrderSummaryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrderSummary", ctx, userID)
+	ret0, _ := ret[0].(*model.OrderSummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrderSummary indicates an expected call of GetUserOrderSummary.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) GetUserOrderSummary(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrderSummary", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).GetUserOrderSummary), ctx, userID)
 }
 
 // ProcessPayment mocks base method.
@@ -115,6 +191,65 @@ func (mr *MockOrderUseCaseInterfaceMockRecorder) ProcessPayment(ctx, orderID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPayment", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).ProcessPayment), ctx, orderID)
 }
 
+// ReactivateOrder mocks base method.
+func (m *MockOrderUseCaseInterface) ReactivateOrder(ctx context.Context, orderID uint) (*model.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateOrder", ctx, orderID)
+	ret0, _ := ret[0].(*model.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateOrder indicates an expected call of ReactivateOrder.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) ReactivateOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateOrder", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).ReactivateOrder), ctx, orderID)
+}
+
+// ReconcilePaidOrder mocks base method.
+func (m *MockOrderUseCaseInterface) ReconcilePaidOrder(ctx context.Context, orderID uint) (*model.ReconcileOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcilePaidOrder", ctx, orderID)
+	ret0, _ := ret[0].(*model.ReconcileOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcilePaidOrder indicates an expected call of ReconcilePaidOrder.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) ReconcilePaidOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcilePaidOrder", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).ReconcilePaidOrder), ctx, orderID)
+}
+
+// RetryPendingReleases mocks base method.
+func (m *MockOrderUseCaseInterface) RetryPendingReleases(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPendingReleases", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryPendingReleases indicates an expected call of RetryPendingReleases.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) RetryPendingReleases(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPendingReleases", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).RetryPendingReleases), ctx)
+}
+
+// SearchOrders mocks base method.
+func (m *MockOrderUseCaseInterface) SearchOrders(ctx context.Context, criteria model.OrderSearchCriteria) (*model.OrderListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchOrders", ctx, criteria)
+	ret0, _ := ret[0].(*model.OrderListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchOrders indicates an expected call of SearchOrders.
+func (mr *MockOrderUseCaseInterfaceMockRecorder) SearchOrders(ctx, criteria any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchOrders", reflect.TypeOf((*MockOrderUseCaseInterface)(nil).SearchOrders), ctx, criteria)
+}
+
 // UpdateOrderStatus mocks base method.
 func (m *MockOrderUseCaseInterface) UpdateOrderStatus(ctx context.Context, orderID uint, status string) error {
 	m.ctrl.T.Helper()
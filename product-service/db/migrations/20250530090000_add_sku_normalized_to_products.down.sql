@@ -0,0 +1,3 @@
+ALTER TABLE products
+    DROP INDEX idx_products_sku_normalized,
+    DROP COLUMN sku_normalized;
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE products
+    ADD COLUMN sku_normalized VARCHAR(50) NULL AFTER sku;
+
+-- Existing SKUs that differ only in case must be renamed before this runs, or the unique index below fails
+UPDATE products
+SET sku_normalized = UPPER(TRIM(sku))
+WHERE sku IS NOT NULL AND TRIM(sku) <> '';
+
+ALTER TABLE products
+    ADD UNIQUE INDEX idx_products_sku_normalized (sku_normalized);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE products
+    ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER thumbnail_url;
\ No newline at end of file
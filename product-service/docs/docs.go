@@ -24,9 +24,94 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/health": {
+            "get": {
+                "description": "Reports that the process is up without checking any dependency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Pings the database. Returns 503 with the status of each dependency when any is down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        },
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/products": {
             "get": {
-                "description": "Get a list of products with pagination",
+                "description": "Get a list of products with pagination. Soft-deleted products are left out unless include_deleted is true. Pages are selected by offset, or by cursor when the next_cursor of a previous newest-first page is passed; the two cannot be combined.",
                 "consumes": [
                     "application/json"
                 ],
@@ -40,7 +125,7 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Limit",
+                        "description": "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)",
                         "name": "limit",
                         "in": "query"
                     },
@@ -49,6 +134,42 @@ const docTemplate = `{
                         "description": "Offset",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page; lists products newest first",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only products priced at or above this amount",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only products priced at or below this amount",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price_asc",
+                            "price_desc",
+                            "name_asc",
+                            "created_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products (admin listings)",
+                        "name": "include_deleted",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -117,6 +238,140 @@ const docTemplate = `{
                 }
             }
         },
+        "/products/batch": {
+            "get": {
+                "description": "Get up to 100 products in a single lookup. IDs that match no product, including deleted ones, are listed in missing_ids.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get multiple products by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated product IDs",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductsByIDsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create up to product.batch.max_size products (default 100) in one transaction. Each product is validated on its own: an invalid product, or one whose SKU repeats an earlier product of the batch or an existing product, is reported as failed and the others are still created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create several products",
+                "parameters": [
+                    {
+                        "description": "Products to create",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateProductsBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateProductsBatchResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/batch-get": {
+            "post": {
+                "description": "Get up to 100 products in a single lookup, passing the IDs in the body so long lists do not hit URL length limits. Every ID must be a UUID. IDs that match no product, including deleted ones, are listed in missing_ids.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Look up multiple products by ID",
+                "parameters": [
+                    {
+                        "description": "Product IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProductsByIDsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductsByIDsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/products/category/{category}": {
             "get": {
                 "description": "Get products filtered by category",
@@ -140,7 +395,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Limit",
+                        "description": "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)",
                         "name": "limit",
                         "in": "query"
                     },
@@ -179,9 +434,81 @@ const docTemplate = `{
                 }
             }
         },
+        "/products/low-stock": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List products whose stock available across all warehouses is below the threshold, newest first. Requires an admin access token.\nIf the warehouse service fails part way through, the products found so far are returned with partial set to true, the number of products that could not be checked and a warning.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get low stock products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Products with fewer units available than this are listed (defaults to 10)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LowStockProductsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/products/search": {
             "get": {
-                "description": "Search for products based on a query string",
+                "description": "Search for products matching any word of the query in their name, description, SKU, category or brand",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,9 +527,22 @@ const docTemplate = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "enum": [
+                            "relevance",
+                            "price_asc",
+                            "price_desc",
+                            "name_asc",
+                            "created_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Limit",
+                        "description": "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)",
                         "name": "limit",
                         "in": "query"
                     },
@@ -237,7 +577,7 @@ const docTemplate = `{
         },
         "/products/{id}": {
             "get": {
-                "description": "Get a single product by ID",
+                "description": "Get a single product by ID. With include_stock=true the stock available across all warehouses is fetched from the warehouse service; if it cannot be reached the product is returned without stock_updated_at. The response carries an ETag that changes with every update of the product; send it back in If-None-Match to get 304 Not Modified instead of the body while it is unchanged.",
                 "consumes": [
                     "application/json"
                 ],
@@ -255,6 +595,18 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include live warehouse stock",
+                        "name": "include_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier response; answered with 304 while it still matches",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -262,8 +614,17 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.ProductResponseWrapper"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Identifies this version of the product"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -285,7 +646,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing product",
+                "description": "Replace an existing product. Name and price are required, and optional fields left out of the body are cleared. Use PATCH to change only some fields. Pass the version from the last read, or an If-Unmodified-Since header, to get 409 CONCURRENT_MODIFICATION instead of overwriting a newer change.",
                 "consumes": [
                     "application/json"
                 ],
@@ -295,7 +656,7 @@ const docTemplate = `{
                 "tags": [
                     "products"
                 ],
-                "summary": "Update an existing product",
+                "summary": "Replace an existing product",
                 "parameters": [
                     {
                         "type": "string",
@@ -304,6 +665,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Reject the update if the product changed after this HTTP date",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    },
                     {
                         "description": "Product data",
                         "name": "product",
@@ -333,6 +700,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -342,7 +715,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a product",
+                "description": "Soft-delete a product. It disappears from listings but stays referenced by past orders and can be restored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -385,22 +758,191 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "model.CreateProductRequest": {
-            "type": "object",
-            "required": [
-                "name",
-                "price"
-            ],
-            "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "description": {
+            },
+            "patch": {
+                "description": "Change only the fields set in the body; empty or omitted fields keep their current values. Use PUT to replace the whole product. Pass the version from the last read, or an If-Unmodified-Since header, to get 409 CONCURRENT_MODIFICATION instead of overwriting a newer change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Partially update an existing product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reject the update if the product changed after this HTTP date",
+                        "name": "If-Unmodified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Product data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatchProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "description": "Undo the soft delete of a product. Restoring a product that is not deleted returns it unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Restore a deleted product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "errors.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "JSON path of the field, e.g. price",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Validator tag that failed, e.g. gt",
+                    "type": "string"
+                }
+            }
+        },
+        "model.BatchItemStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "BatchItemStatusCreated",
+                "BatchItemStatusFailed"
+            ]
+        },
+        "model.CreateProductBatchItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.BatchItemStatus"
+                }
+            }
+        },
+        "model.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "price"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "description": {
                     "type": "string"
                 },
                 "image_url": {
@@ -424,6 +966,59 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CreateProductsBatchRequest": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CreateProductRequest"
+                    }
+                }
+            }
+        },
+        "model.CreateProductsBatchResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CreateProductBatchItemResult"
+                    }
+                }
+            }
+        },
+        "model.CreateProductsBatchResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.CreateProductsBatchResponse"
+                },
+                "errors": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DependencyHealth": {
+            "description": "Status of a dependency such as the database",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "dial tcp: connection refused"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
         "model.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -432,15 +1027,166 @@ const docTemplate = `{
                 }
             }
         },
+        "model.GetProductsByIDsRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.HealthResponse": {
+            "description": "Service health with the status of each dependency",
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
+        "model.LowStockProductResponse": {
+            "type": "object",
+            "properties": {
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reserved_quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock_updated_at": {
+                    "description": "When the warehouse service reported these totals",
+                    "type": "string"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LowStockProductsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Low stock products found",
+                    "type": "integer"
+                },
+                "has_next": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "partial": {
+                    "type": "boolean"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LowStockProductResponse"
+                    }
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "unchecked_count": {
+                    "type": "integer"
+                },
+                "warning": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LowStockProductsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.LowStockProductsResponse"
+                },
+                "errors": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PatchProductRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "version": {
+                    "description": "Version the change is based on; a stale version is rejected, 0 skips the check",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
         "model.ProductListResponse": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
+                "has_next": {
+                    "description": "Whether products remain after this page",
+                    "type": "boolean"
+                },
                 "limit": {
                     "type": "integer"
                 },
+                "next_cursor": {
+                    "description": "Pass as cursor to fetch the next page of a newest-first listing",
+                    "type": "string"
+                },
                 "offset": {
                     "type": "integer"
                 },
@@ -449,6 +1195,10 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/model.ProductResponse"
                     }
+                },
+                "total_pages": {
+                    "description": "Pages of Limit products needed to cover Count",
+                    "type": "integer"
                 }
             }
         },
@@ -472,6 +1222,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -490,11 +1243,22 @@ const docTemplate = `{
                 "sku": {
                     "type": "string"
                 },
+                "status": {
+                    "type": "string"
+                },
                 "stock": {
                     "type": "integer"
                 },
+                "stock_updated_at": {
+                    "description": "Set when Stock holds live warehouse stock",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Pass back as version when updating to detect concurrent changes",
+                    "type": "integer"
                 }
             }
         },
@@ -509,8 +1273,40 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ProductsByIDsResponse": {
+            "type": "object",
+            "properties": {
+                "missing_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductResponse"
+                    }
+                }
+            }
+        },
+        "model.ProductsByIDsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ProductsByIDsResponse"
+                },
+                "errors": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UpdateProductRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "price"
+            ],
             "properties": {
                 "category": {
                     "type": "string",
@@ -537,8 +1333,51 @@ const docTemplate = `{
                 "stock": {
                     "type": "integer",
                     "minimum": 0
+                },
+                "version": {
+                    "description": "Version the change is based on; a stale version is rejected, 0 skips the check",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "response.ErrorInfo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Fields lists the request fields that failed validation, if any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
                 }
             }
+        },
+        "response.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/response.ErrorInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header",
+            "x-extension-openapi": "{\"example\": \"Accessible at http://localhost:8080/swagger/index.html or http://localhost:8080/api/v1/docs/index.html\"}"
         }
     }
 }`
@@ -72,7 +72,7 @@ var (
 	testProduct = model.CreateProductRequest{
 		Name:        "Test E2E Product",
 		Description: "Product created during E2E tests",
-		Price:       59.99,
+		Price:       5999,
 		Stock:       100,
 		Category:    "Test Category",
 		SKU:         fmt.Sprintf("TEST-SKU-%d", time.Now().UnixNano()), // Use nanoseconds for more unique SKUs
@@ -177,11 +177,11 @@ func (suite *ProductAPITestSuite) testGetProductByID(t *testing.T, productID str
 func (suite *ProductAPITestSuite) testUpdateProduct(t *testing.T, productID string) {
 	t.Log("Testing update product...")
 
-	// Create update request with modified data
-	updateData := model.UpdateProductRequest{
+	// Create a partial update request with modified data
+	updateData := model.PatchProductRequest{
 		Name:        "Updated E2E Product",
 		Description: "Updated description for E2E tests",
-		Price:       79.99,
+		Price:       7999,
 	}
 
 	// Create request body
@@ -190,7 +190,7 @@ func (suite *ProductAPITestSuite) testUpdateProduct(t *testing.T, productID stri
 
 	// Send update product request
 	url := fmt.Sprintf("%s/products/%s", suite.baseURL, productID)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(jsonBody))
 	assert.NoError(t, err)
 	req.Header.Set("Content-Type", "application/json")
 
@@ -221,7 +221,7 @@ func (suite *ProductAPITestSuite) testUpdateProduct(t *testing.T, productID stri
 	assert.Equal(t, updateData.Name, product.Name)
 	assert.Equal(t, updateData.Description, product.Description)
 	assert.Equal(t, updateData.Price, product.Price)
-	assert.Equal(t, testProduct.SKU, product.SKU) // SKU should remain unchanged
+	assert.Equal(t, testProduct.SKU, product.SKU) // SKU is omitted, so PATCH keeps it
 }
 
 func (suite *ProductAPITestSuite) testSearchProducts(t *testing.T) {
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"product-service/internal/delivery/http/middleware"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewAccessLogConfig reads log.access.level, log.access.sample_rate, log.access.headers and log.access.bodies.
+// Without them every request is logged at info level, without headers or bodies.
+func NewAccessLogConfig(config *viper.Viper, log *logrus.Logger) middleware.LoggerConfig {
+	accessLog := middleware.DefaultLoggerConfig()
+
+	if config.IsSet("log.access.level") {
+		level, err := logrus.ParseLevel(config.GetString("log.access.level"))
+		if err != nil {
+			log.WithField("error", err.Error()).Fatal("Invalid access log level")
+		}
+		accessLog.Level = level
+	}
+
+	if config.IsSet("log.access.sample_rate") {
+		accessLog.SampleRate = config.GetFloat64("log.access.sample_rate")
+		if accessLog.SampleRate < 0 || accessLog.SampleRate > 1 {
+			log.WithField("sample_rate", accessLog.SampleRate).Fatal("Access log sample rate must be between 0 and 1")
+		}
+	}
+
+	accessLog.LogHeaders = config.GetBool("log.access.headers")
+	accessLog.LogBodies = config.GetBool("log.access.bodies")
+
+	return accessLog
+}
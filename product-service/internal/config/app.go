@@ -1,9 +1,11 @@
 package config
 
 import (
+	"product-service/internal/config/services"
 	"product-service/internal/delivery/http/middleware"
 	"product-service/internal/delivery/http/route"
 	"product-service/internal/entity"
+	"product-service/internal/gateway"
 	"product-service/internal/handler"
 	"product-service/internal/repository"
 	"product-service/internal/usecase"
@@ -48,19 +50,30 @@ func Bootstrap(config *BootstrapConfig) {
 	// Setup repositories
 	productRepository := repository.NewProductRepository(config.Log, config.DB)
 
+	// Setup gateways
+	warehouseStockGateway := gateway.NewWarehouseStockGateway(config.Log, services.NewServicesConfig(config.Config))
+
 	// Setup use cases
-	productUseCase := usecase.NewProductUseCase(config.DB, config.Log, config.Validate, productRepository)
+	productUseCase := usecase.NewProductUseCase(config.DB, config.Log, config.Validate, productRepository, warehouseStockGateway, NewProductBatchSize(config.Config, config.Log), NewPagination(config.Config, config.Log))
 
 	// Setup handlers
 	productHandler := handler.NewProductHandler(productUseCase, config.Log)
+	healthHandler := handler.NewHealthHandler(config.DB, config.Log)
+
+	// Admin-only routes verify user access tokens with the user service's JWT secret
+	authMiddleware := middleware.NewAuthMiddleware(config.Log, NewTokenVerifier(config.Config, config.Log))
 
 	// Setup routes
 	routeConfig := route.RouteConfig{
 		App:            config.App,
 		ProductHandler: productHandler,
+		HealthHandler:  healthHandler,
+		AuthMiddleware: authMiddleware,
 		DB:             config.DB,
 		ProductRepo:    productRepository,
 		Logger:         config.Log,
+		AccessLog:      NewAccessLogConfig(config.Config, config.Log),
+		CORS:           NewCORSConfig(config.Config, config.Log),
 	}
 	routeConfig.Setup()
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"product-service/internal/auth"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewTokenVerifier creates the verifier for user service access tokens from jwt.secret,
+// which must match the secret the user service signs tokens with
+func NewTokenVerifier(config *viper.Viper, log *logrus.Logger) auth.TokenVerifierInterface {
+	secret := config.GetString("jwt.secret")
+	if secret == "" {
+		log.Fatal("jwt.secret must be configured to verify access tokens")
+	}
+
+	return auth.NewTokenVerifier(secret)
+}
+
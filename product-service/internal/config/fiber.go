@@ -4,7 +4,6 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/spf13/viper"
@@ -18,7 +17,6 @@ func NewFiber(config *viper.Viper) *fiber.App {
 	})
 	
 	// Middleware
-	app.Use(cors.New())
 	app.Use(logger.New())
 	app.Use(recover.New())
 	
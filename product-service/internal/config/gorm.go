@@ -10,6 +10,65 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// DefaultMaxOpenConns is used when database.pool.open is not configured
+	DefaultMaxOpenConns = 100
+	
+	// DefaultMaxIdleConns is used when database.pool.idle is not configured
+	DefaultMaxIdleConns = 10
+	
+	// DefaultConnMaxLifetime is used when database.pool.lifetime is not configured
+	DefaultConnMaxLifetime = 30 * time.Minute
+)
+
+// DatabasePoolConfig holds the connection pool settings applied to the underlying *sql.DB
+type DatabasePoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// NewDatabasePoolConfig reads database.pool.open, database.pool.idle and database.pool.lifetime (in minutes),
+// falling back to the defaults for keys that are not set, and rejects settings that would leave the pool unbounded or inconsistent
+func NewDatabasePoolConfig(config *viper.Viper) (*DatabasePoolConfig, error) {
+	pool := &DatabasePoolConfig{
+		MaxOpenConns:    DefaultMaxOpenConns,
+		MaxIdleConns:    DefaultMaxIdleConns,
+		ConnMaxLifetime: DefaultConnMaxLifetime,
+	}
+	if config.IsSet("database.pool.open") {
+		pool.MaxOpenConns = config.GetInt("database.pool.open")
+	}
+	if config.IsSet("database.pool.idle") {
+		pool.MaxIdleConns = config.GetInt("database.pool.idle")
+	}
+	if config.IsSet("database.pool.lifetime") {
+		pool.ConnMaxLifetime = time.Duration(config.GetInt("database.pool.lifetime")) * time.Minute
+	}
+	
+	if err := pool.Validate(); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// Validate reports the first pool setting that database/sql would silently treat as unlimited or clamp
+func (c *DatabasePoolConfig) Validate() error {
+	if c.MaxOpenConns <= 0 {
+		return fmt.Errorf("database.pool.open must be greater than 0, got %d", c.MaxOpenConns)
+	}
+	if c.MaxIdleConns < 0 {
+		return fmt.Errorf("database.pool.idle must not be negative, got %d", c.MaxIdleConns)
+	}
+	if c.MaxIdleConns > c.MaxOpenConns {
+		return fmt.Errorf("database.pool.idle (%d) must not exceed database.pool.open (%d)", c.MaxIdleConns, c.MaxOpenConns)
+	}
+	if c.ConnMaxLifetime <= 0 {
+		return fmt.Errorf("database.pool.lifetime must be greater than 0, got %s", c.ConnMaxLifetime)
+	}
+	return nil
+}
+
 func NewDatabase(config *viper.Viper, log *logrus.Logger) *gorm.DB {
 	username := config.GetString("database.username")
 	password := config.GetString("database.password")
@@ -40,7 +99,11 @@ func NewDatabase(config *viper.Viper, log *logrus.Logger) *gorm.DB {
 	// Complete the DSN
 	dsn = fmt.Sprintf("%s?%s", dsn, queryParams)
 	
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		// Report unique index violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+		Logger:         newGormLogger(log, NewSlowQueryThreshold(config)),
+	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -50,9 +113,20 @@ func NewDatabase(config *viper.Viper, log *logrus.Logger) *gorm.DB {
 		log.Fatalf("Failed to get database connection: %v", err)
 	}
 	
-	sqlDB.SetMaxIdleConns(config.GetInt("database.pool.idle"))
-	sqlDB.SetMaxOpenConns(config.GetInt("database.pool.open"))
-	sqlDB.SetConnMaxLifetime(time.Duration(config.GetInt("database.pool.lifetime")) * time.Minute)
+	pool, err := NewDatabasePoolConfig(config)
+	if err != nil {
+		log.Fatalf("Invalid database pool configuration: %v", err)
+	}
+	
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	
+	log.WithFields(logrus.Fields{
+		"max_open_conns":    pool.MaxOpenConns,
+		"max_idle_conns":    pool.MaxIdleConns,
+		"conn_max_lifetime": pool.ConnMaxLifetime.String(),
+	}).Info("Database connection pool configured")
 	
 	log.Info("Database connection established")
 	
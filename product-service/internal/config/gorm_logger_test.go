@@ -0,0 +1,74 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appContext "product-service/internal/context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlowQueryThreshold(t *testing.T) {
+	assert.Equal(t, DefaultSlowQueryThreshold, NewSlowQueryThreshold(viper.New()))
+
+	v := viper.New()
+	v.Set("database.slow_threshold", "1s")
+	assert.Equal(t, time.Second, NewSlowQueryThreshold(v))
+}
+
+func TestGormLogger_SlowQueryLoggedAtWarnWithRequestID(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.InfoLevel)
+	gormLog := newGormLogger(log, 200*time.Millisecond)
+
+	ctx := appContext.WithRequestID(context.Background(), "req-1")
+	gormLog.Trace(ctx, time.Now().Add(-300*time.Millisecond), func() (string, int64) {
+		return "SELECT * FROM items WHERE id = ?", 1
+	}, nil)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "Slow query", entry.Message)
+	assert.Equal(t, "req-1", entry.Data["request_id"])
+	assert.Equal(t, "SELECT * FROM items WHERE id = ?", entry.Data["sql"])
+	assert.Equal(t, int64(200), entry.Data["threshold_ms"])
+}
+
+func TestGormLogger_FastQueryNotLoggedAboveTraceLevel(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.InfoLevel)
+	gormLog := newGormLogger(log, 200*time.Millisecond)
+
+	called := false
+	gormLog.Trace(context.Background(), time.Now(), func() (string, int64) {
+		called = true
+		return "SELECT 1", 1
+	}, nil)
+
+	assert.Empty(t, hook.AllEntries())
+	assert.False(t, called, "SQL should not be rendered for queries that are not logged")
+}
+
+func TestGormLogger_QueryLoggedAtTraceLevel(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.TraceLevel)
+	gormLog := newGormLogger(log, 0)
+
+	gormLog.Trace(context.Background(), time.Now().Add(-time.Hour), func() (string, int64) {
+		return "SELECT 1", 0
+	}, errors.New("boom"))
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.TraceLevel, entry.Level, "a zero threshold disables slow query logging")
+	assert.NotContains(t, entry.Data, "request_id")
+	assert.EqualError(t, entry.Data[logrus.ErrorKey].(error), "boom")
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"product-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewProductBatchSize reads product.batch.max_size, the most products a batch create accepts.
+// Without it usecase.DefaultMaxCreateBatchSize is used.
+func NewProductBatchSize(config *viper.Viper, log *logrus.Logger) int {
+	if !config.IsSet("product.batch.max_size") {
+		return usecase.DefaultMaxCreateBatchSize
+	}
+
+	maxSize := config.GetInt("product.batch.max_size")
+	if maxSize <= 0 {
+		log.WithField("max_size", maxSize).Fatal("Product batch max size must be positive")
+	}
+	return maxSize
+}
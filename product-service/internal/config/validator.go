@@ -1,10 +1,15 @@
 package config
 
 import (
+	appErrors "product-service/internal/errors"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 )
 
 func NewValidator(config *viper.Viper) *validator.Validate {
-	return validator.New()
+	validate := validator.New()
+	// Report failed fields by their JSON name so clients can match them to the request body
+	validate.RegisterTagNameFunc(appErrors.JSONFieldName)
+	return validate
 }
\ No newline at end of file
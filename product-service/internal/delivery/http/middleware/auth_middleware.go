@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"errors"
+	"product-service/internal/auth"
+	"product-service/internal/delivery/http/response"
+	appErrors "product-service/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AuthMiddleware authenticates users with access tokens issued by the user service.
+// Catalog reads stay public; only routes that opt in use it.
+type AuthMiddleware struct {
+	Log           *logrus.Logger
+	TokenVerifier auth.TokenVerifierInterface
+}
+
+func NewAuthMiddleware(log *logrus.Logger, tokenVerifier auth.TokenVerifierInterface) *AuthMiddleware {
+	return &AuthMiddleware{
+		Log:           log,
+		TokenVerifier: tokenVerifier,
+	}
+}
+
+// RequireAuth middleware verifies the user's access token from the Authorization header
+// and stores the user ID and role in locals
+func (m *AuthMiddleware) RequireAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(RequestIDHeader)
+
+		authHeader := c.Get(fiber.HeaderAuthorization)
+		if authHeader == "" {
+			m.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"path":       c.Path(),
+				"method":     c.Method(),
+			}).Warn("Missing authorization header")
+
+			return response.JSONError(c,
+				appErrors.WithMessage(appErrors.ErrUnauthorized, "Missing authorization header"),
+				m.Log)
+		}
+
+		// Extract token value - handle "Bearer " prefix if present
+		token := authHeader
+		if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+			token = authHeader[7:]
+		}
+
+		claims, err := m.TokenVerifier.Verify(token)
+		if err != nil {
+			m.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"error":      err.Error(),
+				"path":       c.Path(),
+			}).Warn("Invalid token")
+
+			message := "Invalid token"
+			if errors.Is(err, auth.ErrExpiredToken) {
+				message = "Token has expired"
+			}
+			return response.JSONError(c,
+				appErrors.WithMessage(appErrors.ErrUnauthorized, message),
+				m.Log)
+		}
+
+		c.Locals("userId", claims.Subject)
+		c.Locals("role", claims.Role)
+
+		return c.Next()
+	}
+}
+
+// RequireRole middleware rejects authenticated callers whose role is not one of roles.
+// It must run after RequireAuth.
+func (m *AuthMiddleware) RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+
+		m.Log.WithFields(logrus.Fields{
+			"request_id": c.Get(RequestIDHeader),
+			"user_id":    c.Locals("userId"),
+			"role":       role,
+			"path":       c.Path(),
+			"method":     c.Method(),
+		}).Warn("Insufficient role")
+
+		return response.JSONError(c,
+			appErrors.WithMessage(appErrors.ErrForbidden, "You do not have permission to perform this action"),
+			m.Log)
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"product-service/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, secret string, role string, expiresAt time.Time) string {
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "5df84b6f-8f5b-4a51-a106-e9a46b67c836",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: role,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthMiddleware_RequireRole(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(logger, auth.NewTokenVerifier(testSecret))
+
+	app := fiber.New()
+	app.Get("/products/low-stock", middleware.RequireAuth(), middleware.RequireRole(auth.RoleAdmin), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	expiresAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name          string
+		authorization string
+		expectedCode  int
+	}{
+		{"admin", "Bearer " + signToken(t, testSecret, auth.RoleAdmin, expiresAt), fiber.StatusOK},
+		{"customer", "Bearer " + signToken(t, testSecret, auth.RoleCustomer, expiresAt), fiber.StatusForbidden},
+		{"expired admin token", "Bearer " + signToken(t, testSecret, auth.RoleAdmin, time.Now().Add(-time.Minute)), fiber.StatusUnauthorized},
+		{"admin token with wrong secret", "Bearer " + signToken(t, "other-secret", auth.RoleAdmin, expiresAt), fiber.StatusUnauthorized},
+		{"missing credentials", "", fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/products/low-stock", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+		})
+	}
+}
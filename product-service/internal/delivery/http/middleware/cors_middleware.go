@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// CORSConfig controls which browser origins may call the API
+type CORSConfig struct {
+	// AllowOrigins lists the origins allowed to make cross-origin requests. An entry matches exactly,
+	// "*" matches any origin and "https://*.example.com" matches any subdomain. When empty every origin is denied.
+	AllowOrigins []string
+	// AllowMethods are the methods a preflight request may ask for
+	AllowMethods []string
+	// AllowHeaders are the request headers a preflight request may ask for
+	AllowHeaders []string
+	// AllowCredentials lets browsers send cookies and Authorization headers cross-origin
+	AllowCredentials bool
+	// MaxAge is how long, in seconds, browsers may cache a preflight response
+	MaxAge int
+}
+
+// DefaultCORSConfig denies every origin; the methods and headers apply once origins are configured
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowMethods: []string{
+			fiber.MethodGet, fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete, fiber.MethodOptions,
+		},
+		AllowHeaders: []string{
+			fiber.HeaderOrigin, fiber.HeaderContentType, fiber.HeaderAccept, fiber.HeaderAuthorization, RequestIDHeader,
+		},
+	}
+}
+
+// AllowsOrigin reports whether the Origin header value matches one of the allowed origins
+func (config CORSConfig) AllowsOrigin(origin string) bool {
+	origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+	if origin == "" {
+		return false
+	}
+
+	for _, allowed := range config.AllowOrigins {
+		allowed = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(allowed), "/"))
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		// A wildcard must match at least one character, so https://*.example.com does not allow https://.example.com
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok {
+			if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CORS creates a middleware that answers preflight OPTIONS requests and adds the CORS headers to responses
+// for allowed origins. Requests from other origins get no Access-Control-Allow-Origin header, so browsers block them.
+func CORS(config CORSConfig) fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOriginsFunc: config.AllowsOrigin,
+		AllowMethods:     strings.Join(config.AllowMethods, ","),
+		AllowHeaders:     strings.Join(config.AllowHeaders, ","),
+		AllowCredentials: config.AllowCredentials,
+		ExposeHeaders:    RequestIDHeader + "," + fiber.HeaderETag,
+		MaxAge:           config.MaxAge,
+	})
+}
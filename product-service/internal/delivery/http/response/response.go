@@ -19,6 +19,8 @@ type Response struct {
 type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// Fields lists the request fields that failed validation, if any
+	Fields []appErrors.FieldError `json:"fields,omitempty"`
 }
 
 // JSONSuccess sends a successful JSON response
@@ -37,6 +39,7 @@ func JSONError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 	statusCode := fiber.StatusInternalServerError
 	errorCode := "INTERNAL_SERVER_ERROR"
 	message := "An unexpected error occurred"
+	var fields []appErrors.FieldError
 
 	// Extract details from AppError if possible
 	var appErr *appErrors.AppError
@@ -44,6 +47,7 @@ func JSONError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 		statusCode = appErr.StatusCode
 		errorCode = appErr.Code
 		message = appErr.Message
+		fields = appErr.Fields
 		
 		// Log the error with context
 		fields := logrus.Fields{
@@ -72,6 +76,7 @@ func JSONError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 		Error: &ErrorInfo{
 			Code:    errorCode,
 			Message: message,
+			Fields:  fields,
 		},
 	}
 
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"product-service/internal/auth"
 	"product-service/internal/delivery/http/middleware"
 	"product-service/internal/delivery/http/response"
 	"product-service/internal/errors"
@@ -16,26 +17,32 @@ import (
 type RouteConfig struct {
 	App            *fiber.App
 	ProductHandler *handler.ProductHandler
+	HealthHandler  *handler.HealthHandler
+	AuthMiddleware *middleware.AuthMiddleware
 	DB             *gorm.DB
 	ProductRepo    repository.ProductRepositoryInterface
 	Logger         *logrus.Logger
+	AccessLog      middleware.LoggerConfig
+	CORS           middleware.CORSConfig
 }
 
 func (c *RouteConfig) Setup() {
 	// Add the request ID middleware to all routes
 	c.App.Use(middleware.RequestID())
 	
-	// Add the logger middleware to all routes
-	c.App.Use(middleware.Logger(c.Logger))
+	// Answer CORS preflight requests before any route handler sees them
+	c.App.Use(middleware.CORS(c.CORS))
+	
+	// Add the access log middleware to all routes
+	c.App.Use(middleware.Logger(c.AccessLog, c.Logger))
 	
 	// Set up API routes
 	api := c.App.Group("/api")
 	v1 := api.Group("/v1")
 
-	// Health check endpoint with standardized response
-	v1.Get("/health", func(ctx *fiber.Ctx) error {
-		return response.JSONSuccess(ctx, fiber.Map{"status": "ok"})
-	})
+	// Liveness stays a fast path; readiness pings the database
+	v1.Get("/health", c.HealthHandler.Live)
+	v1.Get("/health/ready", c.HealthHandler.Ready)
 	
 	// Swagger documentation endpoint
 	v1.Get("/docs/*", swagger.FiberWrapHandler())
@@ -50,11 +57,20 @@ func (c *RouteConfig) Setup() {
 	// For example, "/search" must be defined before "/:id", otherwise "/search" will be matched as an ID
 	products.Get("/search", c.ProductHandler.SearchProducts)
 	products.Get("/category/:category", c.ProductHandler.GetProductsByCategory)
+	products.Get("/batch", c.ProductHandler.GetProductsByIDs)
+	products.Post("/batch", c.ProductHandler.CreateProductsBatch)
+	products.Post("/batch-get", c.ProductHandler.BatchGetProducts)
+	
+	// Catalog-wide stock report, restricted to admins
+	products.Get("/low-stock", c.AuthMiddleware.RequireAuth(), c.AuthMiddleware.RequireRole(auth.RoleAdmin),
+		c.ProductHandler.GetLowStockProducts)
 	
 	// Generic parameter routes come after specific routes
 	products.Get("/:id", c.ProductHandler.GetProductByID)
 	products.Put("/:id", c.ProductHandler.UpdateProduct)
+	products.Patch("/:id", c.ProductHandler.UpdateProductPartial)
 	products.Delete("/:id", c.ProductHandler.DeleteProduct)
+	products.Post("/:id/restore", c.ProductHandler.RestoreProduct)
 	
 	// 404 handler for undefined routes
 	c.App.Use(func(ctx *fiber.Ctx) error {
@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor units (cents). Amounts are added and multiplied as integers, so totals
+// never pick up the rounding drift of float64. It is written to JSON as a plain decimal number such as
+// 12.50 and stored in DECIMAL(…,2) columns.
+type Money int64
+
+// ErrInvalidMoney is returned when an amount is not a decimal number with at most two fraction digits
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// NewMoneyFromFloat converts a float amount, such as a configuration value, to Money, rounding halves away
+// from zero. The amount is first snapped to a millionth of a cent so that values such as 1.005,
+// which float64 stores as 1.00499..., still round up to 1.01.
+func NewMoneyFromFloat(amount float64) Money {
+	cents := math.Round(amount*100*1e6) / 1e6
+	return Money(math.Round(cents))
+}
+
+// ParseMoney parses a decimal amount such as "12", "12.5" or "-0.25" exactly, without going through float64.
+// Amounts with more than two fraction digits are rejected unless the extra digits are zeros.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	if len(fraction) > 2 {
+		if strings.Trim(fraction[2:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q has more than two decimal places", ErrInvalidMoney, s)
+		}
+		fraction = fraction[:2]
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	if whole == "" {
+		whole = "0"
+	}
+	for _, digits := range []string{whole, fraction} {
+		if strings.Trim(digits, "0123456789") != "" {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+		}
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+	cents, _ := strconv.ParseInt(fraction, 10, 64)
+
+	amount := Money(units*100 + cents)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// Mul returns the amount multiplied by a quantity
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Abs returns the absolute value of the amount
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Percent returns rate percent of the amount, rounded half away from zero to a cent.
+// The rate is used with up to three decimal places, matching the precision orders store it with.
+func (m Money) Percent(rate float64) Money {
+	thousandths := int64(math.Round(rate * 1000))
+	product := int64(m) * thousandths
+
+	// 100 for the percentage times 1000 for the rate scale
+	const scale = 100 * 1000
+	if product < 0 {
+		return -Money((-product + scale/2) / scale)
+	}
+	return Money((product + scale/2) / scale)
+}
+
+// Float64 returns the amount in major units, for display and metrics only
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount with two decimal places, e.g. "12.50"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or numeric string exactly; null leaves the amount unchanged
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	text = strings.Trim(text, `"`)
+
+	// Plain JSON numbers may use an exponent, which ParseMoney does not accept
+	if strings.ContainsAny(text, "eE") {
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidMoney, text)
+		}
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// Value stores the amount as an exact decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a DECIMAL column, which the MySQL driver returns as text
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		amount, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	case string:
+		amount, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	case int64:
+		*m = Money(v * 100)
+		return nil
+	case float64:
+		*m = NewMoneyFromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, value)
+	}
+}
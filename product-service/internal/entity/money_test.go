@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "Integer", input: `12`, want: 1200},
+		{name: "OneDecimal", input: `12.5`, want: 1250},
+		{name: "TwoDecimals", input: `0.07`, want: 7},
+		{name: "TrailingZeros", input: `19.990`, want: 1999},
+		{name: "QuotedString", input: `"4.20"`, want: 420},
+		{name: "Negative", input: `-0.25`, want: -25},
+		{name: "Exponent", input: `1.5e2`, want: 15000},
+		{name: "SubCent", input: `10.005`, wantErr: true},
+		{name: "NotANumber", input: `"ten"`, wantErr: true},
+		{name: "Empty", input: `""`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.input), &got)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMoney)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	body, err := json.Marshal(map[string]Money{"total": 4950, "refund": -5, "free": 0})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"total": 49.50, "refund": -0.05, "free": 0}`, string(body))
+}
+
+func TestMoney_Percent(t *testing.T) {
+	// 8.25% of 35.00 is 2.8875 and 10% of 10.05 is 1.005; both round half up
+	assert.Equal(t, Money(289), Money(3500).Percent(8.25))
+	assert.Equal(t, Money(101), Money(1005).Percent(10))
+	assert.Equal(t, Money(-101), Money(-1005).Percent(10))
+	assert.Equal(t, Money(0), Money(3500).Percent(0))
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var amount Money
+
+	assert.NoError(t, amount.Scan([]byte("1234.56")))
+	assert.Equal(t, Money(123456), amount)
+
+	value, err := amount.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1234.56", value)
+}
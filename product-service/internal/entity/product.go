@@ -1,34 +1,40 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-// Product is a struct that represents a product entity
+// Product is a struct that represents a product entity.
+// Deleting a product only sets DeletedAt, so orders can still reference it.
+// Soft-deleted products keep their SKU reserved until the row is removed for good.
 type Product struct {
-	ID              uuid.UUID `gorm:"column:uuid;primaryKey"`
-	Name            string    `gorm:"column:name;type:varchar(255);not null"`
-	Description     string    `gorm:"column:description;type:text"`
-	BasePrice       float64   `gorm:"column:base_price;type:decimal(15,2);not null"`
-	SKU             string    `gorm:"column:sku;type:varchar(50);uniqueIndex"`
-	Barcode         string    `gorm:"column:barcode;type:varchar(50);uniqueIndex"`
-	Weight          float64   `gorm:"column:weight;type:decimal(10,3)"`
-	Dimensions      string    `gorm:"column:dimensions;type:varchar(100)"`
-	Brand           string    `gorm:"column:brand;type:varchar(100)"`
-	Manufacturer    string    `gorm:"column:manufacturer;type:varchar(100)"`
-	Category        string    `gorm:"column:category;type:varchar(100)"`
-	Tags            string    `gorm:"column:tags;type:varchar(255)"`
-	Status          string    `gorm:"column:status;type:varchar(50);not null;default:active"`
-	ImageURLs       string    `gorm:"column:image_urls;type:text"` // Comma-separated list of image URLs
-	ThumbnailURL    string    `gorm:"column:thumbnail_url;type:varchar(255)"`
-	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt       time.Time `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
-	MetaTitle       string    `gorm:"column:meta_title;type:varchar(255)"`
-	MetaDescription string    `gorm:"column:meta_description;type:text"`
-	MetaKeywords    string    `gorm:"column:meta_keywords;type:varchar(255)"`
+	ID              uuid.UUID      `gorm:"column:uuid;primaryKey"`
+	Name            string         `gorm:"column:name;type:varchar(255);not null"`
+	Description     string         `gorm:"column:description;type:text"`
+	BasePrice       Money          `gorm:"column:base_price;type:decimal(15,2);not null"`
+	SKU             string         `gorm:"column:sku;type:varchar(50);uniqueIndex"`
+	SKUNormalized   *string        `gorm:"column:sku_normalized;type:varchar(50);uniqueIndex"` // Set from SKU on save, NULL when there is no SKU
+	Barcode         string         `gorm:"column:barcode;type:varchar(50);uniqueIndex"`
+	Weight          float64        `gorm:"column:weight;type:decimal(10,3)"`
+	Dimensions      string         `gorm:"column:dimensions;type:varchar(100)"`
+	Brand           string         `gorm:"column:brand;type:varchar(100)"`
+	Manufacturer    string         `gorm:"column:manufacturer;type:varchar(100)"`
+	Category        string         `gorm:"column:category;type:varchar(100)"`
+	Tags            string         `gorm:"column:tags;type:varchar(255)"`
+	Status          string         `gorm:"column:status;type:varchar(50);not null;default:active"`
+	ImageURLs       string         `gorm:"column:image_urls;type:text"` // Comma-separated list of image URLs
+	ThumbnailURL    string         `gorm:"column:thumbnail_url;type:varchar(255)"`
+	Version         int            `gorm:"column:version;not null;default:1"` // Bumped by every update so concurrent updates can be detected
+	CreatedAt       time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	MetaTitle       string         `gorm:"column:meta_title;type:varchar(255)"`
+	MetaDescription string         `gorm:"column:meta_description;type:text"`
+	MetaKeywords    string         `gorm:"column:meta_keywords;type:varchar(255)"`
 }
 
 // ProductVariant represents a specific variant of a product (e.g., size, color)
@@ -38,7 +44,7 @@ type ProductVariant struct {
 	SKU          string    `gorm:"column:sku;type:varchar(50);uniqueIndex"`
 	Name         string    `gorm:"column:name;type:varchar(255);not null"`
 	Attributes   string    `gorm:"column:attributes;type:text"` // JSON string of attributes
-	PriceDiff    float64   `gorm:"column:price_diff;type:decimal(15,2);default:0"`
+	PriceDiff    Money     `gorm:"column:price_diff;type:decimal(15,2);default:0"`
 	ThumbnailURL string    `gorm:"column:thumbnail_url;type:varchar(255)"`
 	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt    time.Time `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
@@ -76,11 +82,26 @@ func (p *Product) TableName() string {
 
 func (p *Product) BeforeCreate(tx *gorm.DB) (err error) {
 	p.ID = uuid.New()
+	p.Version = 1
 	p.CreatedAt = time.Now()
 	p.UpdatedAt = time.Now()
 	return
 }
 
+// BeforeSave keeps SKUNormalized in sync with SKU so the unique index rejects case variants
+func (p *Product) BeforeSave(tx *gorm.DB) (err error) {
+	p.SKUNormalized = nil
+	if normalized := NormalizeSKU(p.SKU); normalized != "" {
+		p.SKUNormalized = &normalized
+	}
+	return
+}
+
+// NormalizeSKU returns the form in which SKUs are compared, so "abc-1" and "ABC-1" are the same SKU
+func NormalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
+
 func (pv *ProductVariant) TableName() string {
 	return "product_variants"
 }
@@ -12,6 +12,8 @@ type AppError struct {
 	Message    string `json:"message"`
 	StatusCode int    `json:"-"` // HTTP status code
 	Err        error  `json:"-"` // Original error
+	// Fields lists the request fields that failed validation, if any
+	Fields []FieldError `json:"fields,omitempty"`
 }
 
 // Error returns the error message
@@ -62,6 +64,20 @@ var (
 		nil,
 	)
 
+	ErrUnauthorized = NewAppError(
+		"UNAUTHORIZED",
+		"Authentication required",
+		http.StatusUnauthorized,
+		nil,
+	)
+
+	ErrForbidden = NewAppError(
+		"FORBIDDEN",
+		"Access forbidden",
+		http.StatusForbidden,
+		nil,
+	)
+
 	ErrProductNotFound = NewAppError(
 		"PRODUCT_NOT_FOUND",
 		"Product not found",
@@ -76,6 +92,13 @@ var (
 		nil,
 	)
 
+	ErrConcurrentModification = NewAppError(
+		"CONCURRENT_MODIFICATION",
+		"Product was modified by another request; fetch it again and retry the update with the new version",
+		http.StatusConflict,
+		nil,
+	)
+
 	ErrInternalServer = NewAppError(
 		"INTERNAL_SERVER_ERROR",
 		"Internal server error",
@@ -96,6 +119,27 @@ var (
 		http.StatusBadRequest,
 		nil,
 	)
+
+	ErrExternalServiceUnavailable = NewAppError(
+		"EXTERNAL_SERVICE_UNAVAILABLE",
+		"External service is currently unavailable",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
+	ErrExternalServiceError = NewAppError(
+		"EXTERNAL_SERVICE_ERROR",
+		"External service returned an error",
+		http.StatusBadGateway,
+		nil,
+	)
+
+	ErrServiceUnavailable = NewAppError(
+		"SERVICE_UNAVAILABLE",
+		"One or more dependencies are unavailable",
+		http.StatusServiceUnavailable,
+		nil,
+	)
 )
 
 // WithError wraps the original error with AppError
@@ -105,6 +149,7 @@ func WithError(appErr *AppError, err error) *AppError {
 		Message:    appErr.Message,
 		StatusCode: appErr.StatusCode,
 		Err:        err,
+		Fields:     appErr.Fields,
 	}
 }
 
@@ -115,6 +160,7 @@ func WithMessage(appErr *AppError, message string) *AppError {
 		Message:    message,
 		StatusCode: appErr.StatusCode,
 		Err:        appErr.Err,
+		Fields:     appErr.Fields,
 	}
 }
 
@@ -0,0 +1,182 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"product-service/internal/config/services"
+	appContext "product-service/internal/context"
+	appErrors "product-service/internal/errors"
+	"product-service/internal/model"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WarehouseStockGatewayInterface defines the interface for warehouse service stock lookups
+type WarehouseStockGatewayInterface interface {
+	// GetProductStock retrieves the stock of a product summed across all active warehouses
+	GetProductStock(ctx context.Context, productID string) (*model.ProductStockResponse, error)
+}
+
+// cachedProductStock is a stock summary together with the time it stops being reused
+type cachedProductStock struct {
+	stock     model.ProductStockResponse
+	expiresAt time.Time
+}
+
+// WarehouseStockGateway implements WarehouseStockGatewayInterface.
+// Successful lookups are cached for the configured TTL so product pages do not hit the warehouse service on every view.
+type WarehouseStockGateway struct {
+	Log      *logrus.Logger
+	Services *services.ServicesConfig
+	Client   *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedProductStock
+}
+
+// NewWarehouseStockGateway creates a new warehouse stock gateway instance
+func NewWarehouseStockGateway(log *logrus.Logger, services *services.ServicesConfig) WarehouseStockGatewayInterface {
+	client := &http.Client{
+		Timeout: services.Warehouse.Timeout,
+	}
+
+	return &WarehouseStockGateway{
+		Log:      log,
+		Services: services,
+		Client:   client,
+		cache:    make(map[string]cachedProductStock),
+	}
+}
+
+// GetProductStock retrieves the stock of a product summed across all active warehouses
+func (g *WarehouseStockGateway) GetProductStock(ctx context.Context, productID string) (*model.ProductStockResponse, error) {
+	if stock, ok := g.cachedStock(productID); ok {
+		return stock, nil
+	}
+
+	stock, err := g.fetchProductStock(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	g.storeStock(productID, stock)
+	return stock, nil
+}
+
+// cachedStock returns a copy of the cached stock of a product if it has not expired
+func (g *WarehouseStockGateway) cachedStock(productID string) (*model.ProductStockResponse, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entry, ok := g.cache[productID]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(g.cache, productID)
+		return nil, false
+	}
+
+	stock := entry.stock
+	return &stock, true
+}
+
+// storeStock caches the stock of a product; a zero TTL disables caching
+func (g *WarehouseStockGateway) storeStock(productID string, stock *model.ProductStockResponse) {
+	ttl := g.Services.Warehouse.CacheTTL
+	if ttl <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cache[productID] = cachedProductStock{
+		stock:     *stock,
+		expiresAt: time.Now().Add(ttl),
+	}
+}
+
+// fetchProductStock calls the warehouse service's product stock summary endpoint
+func (g *WarehouseStockGateway) fetchProductStock(ctx context.Context, productID string) (*model.ProductStockResponse, error) {
+	// Create the endpoint URL
+	endpoint := fmt.Sprintf("inventory/products/%s/stock", url.PathEscape(productID))
+	requestURL := g.Services.Warehouse.GetEndpointURL(endpoint)
+
+	// Create the request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		g.Log.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to create request for warehouse service")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Set request headers
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-Key", g.Services.Warehouse.APIKey)
+	if requestID := appContext.GetRequestID(ctx); requestID != "" {
+		req.Header.Set("X-Request-ID", requestID)
+	}
+
+	// Execute the request
+	start := time.Now()
+	resp, err := g.Client.Do(req)
+	requestDuration := time.Since(start)
+
+	g.Log.WithFields(logrus.Fields{
+		"product_id":       productID,
+		"request_duration": requestDuration.Milliseconds(),
+		"method":           http.MethodGet,
+		"url":              requestURL,
+	}).Debug("Warehouse service request completed")
+
+	if err != nil {
+		g.Log.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to send request to warehouse service")
+		return nil, appErrors.WithError(appErrors.ErrExternalServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	// Handle non-successful status codes
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		g.Log.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"product_id":  productID,
+		}).Error("Warehouse service returned non-success status code")
+		return nil, appErrors.ErrExternalServiceError
+	}
+
+	// Parse the response
+	var response struct {
+		Success bool                       `json:"success"`
+		Data    model.ProductStockResponse `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		g.Log.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to parse warehouse service response")
+		return nil, appErrors.WithError(appErrors.ErrExternalServiceError, err)
+	}
+
+	// Check response success
+	if !response.Success {
+		g.Log.WithFields(logrus.Fields{
+			"product_id": productID,
+		}).Error("Warehouse service returned success=false")
+		return nil, appErrors.ErrExternalServiceError
+	}
+
+	response.Data.FetchedAt = start.UTC().Format(time.RFC3339)
+	return &response.Data, nil
+}
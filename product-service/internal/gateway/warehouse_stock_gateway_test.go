@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"product-service/internal/config/services"
+	appContext "product-service/internal/context"
+	appErrors "product-service/internal/errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProductID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func newTestGateway(url string, cacheTTL time.Duration) WarehouseStockGatewayInterface {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return NewWarehouseStockGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{
+			URL:      url,
+			APIKey:   "warehouse-service-api-key",
+			Timeout:  time.Second,
+			CacheTTL: cacheTTL,
+		},
+	})
+}
+
+func TestWarehouseStockGateway_GetProductStock(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, "/api/v1/inventory/products/"+testProductID+"/stock", r.URL.Path)
+		assert.Equal(t, "warehouse-service-api-key", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "req-1", r.Header.Get("X-Request-ID"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"product_id":1,"total_quantity":12,"total_reserved_quantity":5,"total_available_quantity":7,"warehouses":[]}}`))
+	}))
+	defer server.Close()
+
+	gateway := newTestGateway(server.URL+"/api/v1", time.Minute)
+	ctx := appContext.WithRequestID(context.Background(), "req-1")
+
+	stock, err := gateway.GetProductStock(ctx, testProductID)
+	require.NoError(t, err)
+	assert.Equal(t, 12, stock.TotalQuantity)
+	assert.Equal(t, 5, stock.TotalReservedQuantity)
+	assert.Equal(t, 7, stock.TotalAvailableQuantity)
+	assert.NotEmpty(t, stock.FetchedAt)
+
+	// The second lookup is served from the cache
+	cached, err := gateway.GetProductStock(ctx, testProductID)
+	require.NoError(t, err)
+	assert.Equal(t, stock, cached)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestWarehouseStockGateway_CacheDisabled(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"success":true,"data":{"total_available_quantity":3}}`))
+	}))
+	defer server.Close()
+
+	gateway := newTestGateway(server.URL, 0)
+
+	for i := 0; i < 2; i++ {
+		_, err := gateway.GetProductStock(context.Background(), testProductID)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestWarehouseStockGateway_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		expectedErr *appErrors.AppError
+	}{
+		{"non-success status", http.StatusBadRequest, `{"success":false}`, appErrors.ErrExternalServiceError},
+		{"success false", http.StatusOK, `{"success":false}`, appErrors.ErrExternalServiceError},
+		{"malformed body", http.StatusOK, `not json`, appErrors.ErrExternalServiceError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			gateway := newTestGateway(server.URL, time.Minute)
+
+			// Failures are not cached, so each lookup retries the warehouse service
+			for i := 0; i < 2; i++ {
+				stock, err := gateway.GetProductStock(context.Background(), testProductID)
+				assert.Nil(t, stock)
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestWarehouseStockGateway_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	stock, err := newTestGateway(url, time.Minute).GetProductStock(context.Background(), testProductID)
+	assert.Nil(t, stock)
+	assert.ErrorIs(t, err, appErrors.ErrExternalServiceUnavailable)
+}
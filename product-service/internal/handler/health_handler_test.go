@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"product-service/internal/model"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// setupHealthHandlerTest points the handler at a MySQL address nothing listens on,
+// so the readiness ping fails straight away without needing a database
+func setupHealthHandlerTest(t *testing.T) *fiber.App {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "root:root@tcp(127.0.0.1:1)/product_db?timeout=1s",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	healthHandler := NewHealthHandler(db, logger)
+
+	app := fiber.New()
+	app.Get("/api/v1/health", healthHandler.Live)
+	app.Get("/api/v1/health/ready", healthHandler.Ready)
+
+	return app
+}
+
+func TestHealthHandler_Live_DoesNotTouchDatabase(t *testing.T) {
+	app := setupHealthHandlerTest(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/health", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Success bool                 `json:"success"`
+		Data    model.HealthResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.True(t, body.Success)
+	assert.Equal(t, model.HealthStatusOK, body.Data.Status)
+}
+
+func TestHealthHandler_Ready_DatabaseDown(t *testing.T) {
+	app := setupHealthHandlerTest(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/health/ready", nil), 5000)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+
+	var body struct {
+		Success bool                 `json:"success"`
+		Data    model.HealthResponse `json:"data"`
+		Error   struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.False(t, body.Success)
+	assert.Equal(t, "SERVICE_UNAVAILABLE", body.Error.Code)
+	assert.Equal(t, model.HealthStatusNotReady, body.Data.Status)
+	assert.Equal(t, model.HealthStatusDown, body.Data.Dependencies["database"].Status)
+	assert.NotEmpty(t, body.Data.Dependencies["database"].Error)
+}
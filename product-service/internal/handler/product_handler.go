@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
 	"product-service/internal/context"
 	"product-service/internal/delivery/http/response"
+	"product-service/internal/entity"
 	"product-service/internal/errors"
 	"product-service/internal/model"
 	"product-service/internal/usecase"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -26,12 +33,17 @@ func NewProductHandler(useCase usecase.ProductUseCaseInterface, logger *logrus.L
 
 // GetProducts godoc
 // @Summary Get a list of products
-// @Description Get a list of products with pagination
+// @Description Get a list of products with pagination. Soft-deleted products are left out unless include_deleted is true. Pages are selected by offset, or by cursor when the next_cursor of a previous newest-first page is passed; the two cannot be combined.
 // @Tags products
 // @Accept json
 // @Produce json
-// @Param limit query int false "Limit"
+// @Param limit query int false "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)"
 // @Param offset query int false "Offset"
+// @Param cursor query string false "next_cursor of the previous page; lists products newest first"
+// @Param min_price query number false "Only products priced at or above this amount"
+// @Param max_price query number false "Only products priced at or below this amount"
+// @Param sort query string false "Sort order" Enums(price_asc, price_desc, name_asc, created_desc)
+// @Param include_deleted query bool false "Include soft-deleted products (admin listings)"
 // @Success 200 {object} model.ProductListResponseWrapper
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
@@ -41,7 +53,8 @@ func (h *ProductHandler) GetProducts(ctx *fiber.Ctx) error {
 	requestID := ctx.Get("X-Request-ID")
 
 	// Parse query parameters for pagination
-	limitStr := ctx.Query("limit", "10")
+	// The use case applies the configured default limit and clamps it to the maximum
+	limitStr := ctx.Query("limit", "0")
 	offsetStr := ctx.Query("offset", "0")
 	
 	limit, err := strconv.Atoi(limitStr)
@@ -53,8 +66,8 @@ func (h *ProductHandler) GetProducts(ctx *fiber.Ctx) error {
 			"error":      err.Error(),
 		}).Warn("Invalid limit parameter")
 		
-		// Default to 10 if invalid
-		limit = 10
+		// Fall back to the configured default if invalid
+		limit = 0
 	}
 	
 	offset, err := strconv.Atoi(offsetStr)
@@ -69,6 +82,40 @@ func (h *ProductHandler) GetProducts(ctx *fiber.Ctx) error {
 		// Default to 0 if invalid
 		offset = 0
 	}
+	
+	// Parse optional filters and ordering
+	filter := model.ProductListFilter{
+		Sort:           ctx.Query("sort"),
+		IncludeDeleted: ctx.QueryBool("include_deleted", false),
+		Cursor:         ctx.Query("cursor"),
+	}
+	if filter.Cursor != "" && ctx.Query("offset") != "" {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"cursor":     filter.Cursor,
+			"offset":     offsetStr,
+		}).Warn("Both cursor and offset given")
+		
+		return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, "cursor and offset cannot be combined"), h.Log)
+	}
+	if filter.MinPrice, err = parsePriceQuery(ctx, "min_price"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"param":      "min_price",
+			"value":      ctx.Query("min_price"),
+		}).Warn("Invalid min_price parameter")
+		
+		return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, "min_price must be a number with at most two decimal places"), h.Log)
+	}
+	if filter.MaxPrice, err = parsePriceQuery(ctx, "max_price"); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"param":      "max_price",
+			"value":      ctx.Query("max_price"),
+		}).Warn("Invalid max_price parameter")
+		
+		return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, "max_price must be a number with at most two decimal places"), h.Log)
+	}
 
 	// Create context with request ID and timeout
 	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
@@ -76,13 +123,15 @@ func (h *ProductHandler) GetProducts(ctx *fiber.Ctx) error {
 	defer cancel()
 	
 	// Get products from usecase
-	products, err := h.UseCase.GetProducts(ctxWithTimeout, limit, offset)
+	products, err := h.UseCase.GetProducts(ctxWithTimeout, filter, limit, offset)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
-			"request_id": requestID,
-			"limit":      limit,
-			"offset":     offset,
-			"error":      err.Error(),
+			"request_id":      requestID,
+			"limit":           limit,
+			"offset":          offset,
+			"sort":            filter.Sort,
+			"include_deleted": filter.IncludeDeleted,
+			"error":           err.Error(),
 		}).Warn("Failed to get products")
 		
 		return response.HandleError(ctx, err, h.Log)
@@ -91,14 +140,31 @@ func (h *ProductHandler) GetProducts(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, products)
 }
 
+// parsePriceQuery parses an optional price query parameter exactly to the cent, returning nil when it is absent
+func parsePriceQuery(ctx *fiber.Ctx, key string) (*entity.Money, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	price, err := entity.ParseMoney(value)
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
 // GetProductByID godoc
 // @Summary Get a single product by ID
-// @Description Get a single product by ID
+// @Description Get a single product by ID. With include_stock=true the stock available across all warehouses is fetched from the warehouse service; if it cannot be reached the product is returned without stock_updated_at. The response carries an ETag that changes with every update of the product; send it back in If-None-Match to get 304 Not Modified instead of the body while it is unchanged.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param include_stock query bool false "Include live warehouse stock"
+// @Param If-None-Match header string false "ETag from an earlier response; answered with 304 while it still matches"
 // @Success 200 {object} model.ProductResponseWrapper
+// @Header 200 {string} ETag "Identifies this version of the product"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
@@ -123,8 +189,14 @@ func (h *ProductHandler) GetProductByID(ctx *fiber.Ctx) error {
 	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
 	defer cancel()
 	
-	// Get product from usecase
-	product, err := h.UseCase.GetProductByID(ctxWithTimeout, id)
+	// Get product from usecase, with live stock if requested
+	var product *model.ProductResponse
+	var err error
+	if ctx.QueryBool("include_stock") {
+		product, err = h.UseCase.GetProductWithStock(ctxWithTimeout, id)
+	} else {
+		product, err = h.UseCase.GetProductByID(ctxWithTimeout, id)
+	}
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -140,9 +212,114 @@ func (h *ProductHandler) GetProductByID(ctx *fiber.Ctx) error {
 		return response.HandleError(ctx, err, h.Log)
 	}
 	
+	// Polling clients that already hold this version of the product get no body
+	etag := productETag(product)
+	ctx.Set(fiber.HeaderETag, etag)
+	if etagMatches(ctx.Get(fiber.HeaderIfNoneMatch), etag) {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+	
 	return response.JSONSuccess(ctx, product)
 }
 
+// GetProductsByIDs godoc
+// @Summary Get multiple products by ID
+// @Description Get up to 100 products in a single lookup. IDs that match no product, including deleted ones, are listed in missing_ids.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param ids query string true "Comma-separated product IDs"
+// @Success 200 {object} model.ProductsByIDsResponseWrapper
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /products/batch [get]
+func (h *ProductHandler) GetProductsByIDs(ctx *fiber.Ctx) error {
+	// Get request ID for logging context
+	requestID := ctx.Get("X-Request-ID")
+	
+	// Parse the comma-separated IDs
+	var ids []string
+	for _, id := range strings.Split(ctx.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	
+	if len(ids) == 0 {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      "missing product IDs",
+		}).Warn("Invalid request: missing product IDs")
+		
+		return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, "Product IDs are required"), h.Log)
+	}
+	
+	// Create context with request ID and timeout
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+	
+	// Get products from usecase
+	products, err := h.UseCase.GetProductsByIDs(ctxWithTimeout, ids)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"count":      len(ids),
+			"error":      err.Error(),
+		}).Warn("Failed to get products by IDs")
+		
+		return response.HandleError(ctx, err, h.Log)
+	}
+	
+	return response.JSONSuccess(ctx, products)
+}
+
+// BatchGetProducts godoc
+// @Summary Look up multiple products by ID
+// @Description Get up to 100 products in a single lookup, passing the IDs in the body so long lists do not hit URL length limits. Every ID must be a UUID. IDs that match no product, including deleted ones, are listed in missing_ids.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body model.GetProductsByIDsRequest true "Product IDs"
+// @Success 200 {object} model.ProductsByIDsResponseWrapper
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /products/batch-get [post]
+func (h *ProductHandler) BatchGetProducts(ctx *fiber.Ctx) error {
+	// Get request ID for logging context
+	requestID := ctx.Get("X-Request-ID")
+	
+	// Parse request body
+	request := new(model.GetProductsByIDsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		
+		return response.JSONError(ctx, errors.WithError(errors.ErrInvalidInput, err), h.Log)
+	}
+	
+	// Create context with request ID and timeout
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+	
+	// Get products from usecase
+	products, err := h.UseCase.GetProductsByIDs(ctxWithTimeout, request.IDs)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"count":      len(request.IDs),
+			"error":      err.Error(),
+		}).Warn("Failed to get products by IDs")
+		
+		return response.HandleError(ctx, err, h.Log)
+	}
+	
+	return response.JSONSuccess(ctx, products)
+}
+
 // CreateProduct godoc
 // @Summary Create a new product
 // @Description Create a new product
@@ -191,17 +368,65 @@ func (h *ProductHandler) CreateProduct(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, product)
 }
 
+// CreateProductsBatch godoc
+// @Summary Create several products
+// @Description Create up to product.batch.max_size products (default 100) in one transaction. Each product is validated on its own: an invalid product, or one whose SKU repeats an earlier product of the batch or an existing product, is reported as failed and the others are still created.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param products body model.CreateProductsBatchRequest true "Products to create"
+// @Success 200 {object} model.CreateProductsBatchResponseWrapper
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /products/batch [post]
+func (h *ProductHandler) CreateProductsBatch(ctx *fiber.Ctx) error {
+	// Get request ID for logging context
+	requestID := ctx.Get("X-Request-ID")
+	
+	// Parse request body
+	request := new(model.CreateProductsBatchRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		
+		return response.JSONError(ctx, errors.WithError(errors.ErrInvalidInput, err), h.Log)
+	}
+	
+	// Create context with request ID and timeout
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+	
+	// Create products using usecase
+	result, err := h.UseCase.CreateProductsBatch(ctxWithTimeout, request.Products)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"count":      len(request.Products),
+			"error":      err.Error(),
+		}).Warn("Failed to create product batch")
+		
+		return response.HandleError(ctx, err, h.Log)
+	}
+	
+	return response.JSONSuccess(ctx, result)
+}
+
 // UpdateProduct godoc
-// @Summary Update an existing product
-// @Description Update an existing product
+// @Summary Replace an existing product
+// @Description Replace an existing product. Name and price are required, and optional fields left out of the body are cleared. Use PATCH to change only some fields. Pass the version from the last read, or an If-Unmodified-Since header, to get 409 CONCURRENT_MODIFICATION instead of overwriting a newer change.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param If-Unmodified-Since header string false "Reject the update if the product changed after this HTTP date"
 // @Param product body model.UpdateProductRequest true "Product data"
 // @Success 200 {object} model.ProductResponseWrapper
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /products/{id} [put]
 func (h *ProductHandler) UpdateProduct(ctx *fiber.Ctx) error {
@@ -231,6 +456,18 @@ func (h *ProductHandler) UpdateProduct(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, errors.WithError(errors.ErrInvalidInput, err), h.Log)
 	}
 	
+	unmodifiedSince, err := parseUnmodifiedSince(ctx)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Invalid If-Unmodified-Since header")
+		
+		return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, "If-Unmodified-Since must be an HTTP date"), h.Log)
+	}
+	request.UnmodifiedSince = unmodifiedSince
+	
 	// Create context with request ID and timeout
 	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
 	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
@@ -251,9 +488,83 @@ func (h *ProductHandler) UpdateProduct(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, product)
 }
 
+// UpdateProductPartial godoc
+// @Summary Partially update an existing product
+// @Description Change only the fields set in the body; empty or omitted fields keep their current values. Use PUT to replace the whole product. Pass the version from the last read, or an If-Unmodified-Since header, to get 409 CONCURRENT_MODIFICATION instead of overwriting a newer change.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param If-Unmodified-Since header string false "Reject the update if the product changed after this HTTP date"
+// @Param product body model.PatchProductRequest true "Product data"
+// @Success 200 {object} model.ProductResponseWrapper
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /products/{id} [patch]
+func (h *ProductHandler) UpdateProductPartial(ctx *fiber.Ctx) error {
+	// Get request ID for logging context
+	requestID := ctx.Get("X-Request-ID")
+	
+	// Get ID from URL parameter
+	id := ctx.Params("id")
+	if id == "" {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      "missing product ID",
+		}).Warn("Invalid request: missing product ID")
+		
+		return response.JSONError(ctx, errors.ErrInvalidProductID, h.Log)
+	}
+	
+	// Parse request body
+	request := new(model.PatchProductRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		
+		return response.JSONError(ctx, errors.WithError(errors.ErrInvalidInput, err), h.Log)
+	}
+	
+	unmodifiedSince, err := parseUnmodifiedSince(ctx)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Invalid If-Unmodified-Since header")
+		
+		return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, "If-Unmodified-Since must be an HTTP date"), h.Log)
+	}
+	request.UnmodifiedSince = unmodifiedSince
+	
+	// Create context with request ID and timeout
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+	
+	// Partially update product using usecase
+	product, err := h.UseCase.UpdateProductPartial(ctxWithTimeout, id, request)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Failed to partially update product")
+		
+		return response.HandleError(ctx, err, h.Log)
+	}
+	
+	return response.JSONSuccess(ctx, product)
+}
+
 // DeleteProduct godoc
 // @Summary Delete a product
-// @Description Delete a product
+// @Description Soft-delete a product. It disappears from listings but stays referenced by past orders and can be restored.
 // @Tags products
 // @Accept json
 // @Produce json
@@ -299,14 +610,62 @@ func (h *ProductHandler) DeleteProduct(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusNoContent).Send(nil)
 }
 
+// RestoreProduct godoc
+// @Summary Restore a deleted product
+// @Description Undo the soft delete of a product. Restoring a product that is not deleted returns it unchanged.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} model.ProductResponseWrapper
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /products/{id}/restore [post]
+func (h *ProductHandler) RestoreProduct(ctx *fiber.Ctx) error {
+	// Get request ID for logging context
+	requestID := ctx.Get("X-Request-ID")
+	
+	// Get ID from URL parameter
+	id := ctx.Params("id")
+	if id == "" {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      "missing product ID",
+		}).Warn("Invalid request: missing product ID")
+		
+		return response.JSONError(ctx, errors.ErrInvalidProductID, h.Log)
+	}
+	
+	// Create context with request ID and timeout
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+	ctxWithTimeout, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+	
+	// Restore product using usecase
+	product, err := h.UseCase.RestoreProduct(ctxWithTimeout, id)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Failed to restore product")
+		
+		return response.HandleError(ctx, err, h.Log)
+	}
+	
+	return response.JSONSuccess(ctx, product)
+}
+
 // SearchProducts godoc
 // @Summary Search for products
-// @Description Search for products based on a query string
+// @Description Search for products matching any word of the query in their name, description, SKU, category or brand
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param q query string true "Search query"
-// @Param limit query int false "Limit"
+// @Param sort query string false "Sort order" Enums(relevance, price_asc, price_desc, name_asc, created_desc)
+// @Param limit query int false "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)"
 // @Param offset query int false "Offset"
 // @Success 200 {object} model.ProductListResponseWrapper
 // @Failure 400 {object} model.ErrorResponse
@@ -317,7 +676,8 @@ func (h *ProductHandler) SearchProducts(ctx *fiber.Ctx) error {
 	requestID := ctx.Get("X-Request-ID")
 	
 	// Get query parameters
-	query := ctx.Query("q")
+	query := strings.TrimSpace(ctx.Query("q"))
+	sort := ctx.Query("sort")
 	if query == "" {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -328,7 +688,8 @@ func (h *ProductHandler) SearchProducts(ctx *fiber.Ctx) error {
 	}
 	
 	// Parse pagination parameters
-	limitStr := ctx.Query("limit", "10")
+	// The use case applies the configured default limit and clamps it to the maximum
+	limitStr := ctx.Query("limit", "0")
 	offsetStr := ctx.Query("offset", "0")
 	
 	limit, err := strconv.Atoi(limitStr)
@@ -340,8 +701,8 @@ func (h *ProductHandler) SearchProducts(ctx *fiber.Ctx) error {
 			"error":      err.Error(),
 		}).Warn("Invalid limit parameter")
 		
-		// Default to 10 if invalid
-		limit = 10
+		// Fall back to the configured default if invalid
+		limit = 0
 	}
 	
 	offset, err := strconv.Atoi(offsetStr)
@@ -363,11 +724,12 @@ func (h *ProductHandler) SearchProducts(ctx *fiber.Ctx) error {
 	defer cancel()
 	
 	// Search products using usecase
-	products, err := h.UseCase.SearchProducts(ctxWithTimeout, query, limit, offset)
+	products, err := h.UseCase.SearchProducts(ctxWithTimeout, query, sort, limit, offset)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
 			"query":      query,
+			"sort":       sort,
 			"limit":      limit,
 			"offset":     offset,
 			"error":      err.Error(),
@@ -386,7 +748,7 @@ func (h *ProductHandler) SearchProducts(ctx *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param category path string true "Category"
-// @Param limit query int false "Limit"
+// @Param limit query int false "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)"
 // @Param offset query int false "Offset"
 // @Success 200 {object} model.ProductListResponseWrapper
 // @Failure 400 {object} model.ErrorResponse
@@ -409,7 +771,8 @@ func (h *ProductHandler) GetProductsByCategory(ctx *fiber.Ctx) error {
 	}
 	
 	// Parse pagination parameters
-	limitStr := ctx.Query("limit", "10")
+	// The use case applies the configured default limit and clamps it to the maximum
+	limitStr := ctx.Query("limit", "0")
 	offsetStr := ctx.Query("offset", "0")
 	
 	limit, err := strconv.Atoi(limitStr)
@@ -421,8 +784,8 @@ func (h *ProductHandler) GetProductsByCategory(ctx *fiber.Ctx) error {
 			"error":      err.Error(),
 		}).Warn("Invalid limit parameter")
 		
-		// Default to 10 if invalid
-		limit = 10
+		// Fall back to the configured default if invalid
+		limit = 0
 	}
 	
 	offset, err := strconv.Atoi(offsetStr)
@@ -458,4 +821,99 @@ func (h *ProductHandler) GetProductsByCategory(ctx *fiber.Ctx) error {
 	}
 	
 	return response.JSONSuccess(ctx, products)
+}
+
+// lowStockReportTimeout bounds the low stock report, which checks the stock of every product in the catalog.
+// Stock lookups still pending when it passes are reported as unchecked in a partial result.
+const lowStockReportTimeout = 30 * time.Second
+
+// GetLowStockProducts godoc
+// @Summary Get low stock products
+// @Description List products whose stock available across all warehouses is below the threshold, newest first. Requires an admin access token.
+// @Description If the warehouse service fails part way through, the products found so far are returned with partial set to true, the number of products that could not be checked and a warning.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param threshold query int false "Products with fewer units available than this are listed (defaults to 10)"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Limit (defaults to 10; larger values are clamped to the maximum, 100 by default)"
+// @Success 200 {object} model.LowStockProductsResponseWrapper
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /products/low-stock [get]
+func (h *ProductHandler) GetLowStockProducts(ctx *fiber.Ctx) error {
+	// Get request ID for logging context
+	requestID := ctx.Get("X-Request-ID")
+	
+	// Parse the threshold and pagination parameters; the use case applies the default limit and clamps it
+	params := map[string]int{"threshold": usecase.DefaultLowStockThreshold, "page": 1, "limit": 0}
+	for _, name := range []string{"threshold", "page", "limit"} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"param":      name,
+				"value":      value,
+				"error":      err.Error(),
+			}).Warn("Invalid low stock report parameter")
+			
+			return response.JSONError(ctx, errors.WithMessage(errors.ErrInvalidInput, name+" must be an integer"), h.Log)
+		}
+		params[name] = parsed
+	}
+	
+	// Create context with request ID and the report's timeout
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+	ctxWithTimeout, cancel := context.WithTimeout(userCtx, lowStockReportTimeout)
+	defer cancel()
+	
+	report, err := h.UseCase.GetLowStockProducts(ctxWithTimeout, params["threshold"], params["page"], params["limit"])
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"threshold":  params["threshold"],
+			"page":       params["page"],
+			"limit":      params["limit"],
+			"error":      err.Error(),
+		}).Warn("Failed to get low stock products")
+		
+		return response.HandleError(ctx, err, h.Log)
+	}
+	
+	return response.JSONSuccess(ctx, report)
+}
+
+// parseUnmodifiedSince reads the optional If-Unmodified-Since header of an update; a missing header yields the zero time
+func parseUnmodifiedSince(ctx *fiber.Ctx) (time.Time, error) {
+	header := ctx.Get(fiber.HeaderIfUnmodifiedSince)
+	if header == "" {
+		return time.Time{}, nil
+	}
+	return http.ParseTime(header)
+}
+
+// productETag identifies the state of a product detail response. The version is bumped by every update, so the tag
+// changes whenever the product does; live stock is part of the tag because it changes without the product changing.
+func productETag(product *model.ProductResponse) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s|%s|%d|%s", product.ID, product.Version, product.UpdatedAt, product.DeletedAt, product.Stock, product.StockUpdatedAt)))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists etag. Weak tags match their strong form, as RFC 9110
+// requires for GET, and "*" matches any tag.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
 }
\ No newline at end of file
@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net/http"
 	"net/http/httptest"
 	"product-service/internal/delivery/http/middleware"
 	"product-service/internal/delivery/http/response"
+	"product-service/internal/entity"
 	appErrors "product-service/internal/errors"
 	"product-service/internal/model"
 	mockUsecase "product-service/mocks/usecase"
 	"testing"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -54,10 +58,16 @@ func (suite *ProductHandlerTestSuite) SetupTest() {
 	// Special routes that could be matched by the :id parameter need to be defined first
 	products.Get("/search", suite.productHandler.SearchProducts)
 	products.Get("/category/:category", suite.productHandler.GetProductsByCategory)
+	products.Get("/batch", suite.productHandler.GetProductsByIDs)
+	products.Post("/batch", suite.productHandler.CreateProductsBatch)
+	products.Post("/batch-get", suite.productHandler.BatchGetProducts)
+	products.Get("/low-stock", suite.productHandler.GetLowStockProducts)
 	// Generic parameter routes come after specific routes
 	products.Get("/:id", suite.productHandler.GetProductByID)
 	products.Put("/:id", suite.productHandler.UpdateProduct)
+	products.Patch("/:id", suite.productHandler.UpdateProductPartial)
 	products.Delete("/:id", suite.productHandler.DeleteProduct)
+	products.Post("/:id/restore", suite.productHandler.RestoreProduct)
 }
 
 func (suite *ProductHandlerTestSuite) TestGetProducts() {
@@ -70,7 +80,7 @@ func (suite *ProductHandlerTestSuite) TestGetProducts() {
 				ID:          "f47ac10b-58cc-4372-a567-0e02b2c3d479",
 				Name:        "Test Product 1",
 				Description: "Test Description 1",
-				Price:       99.99,
+				Price:       9999,
 				Stock:       10,
 				Category:    "Test Category",
 				SKU:         "TEST-SKU-001",
@@ -79,7 +89,7 @@ func (suite *ProductHandlerTestSuite) TestGetProducts() {
 				ID:          "f47ac10b-58cc-4372-a567-0e02b2c3d480",
 				Name:        "Test Product 2",
 				Description: "Test Description 2",
-				Price:       149.99,
+				Price:       14999,
 				Stock:       20,
 				Category:    "Another Category",
 				SKU:         "TEST-SKU-002",
@@ -91,7 +101,7 @@ func (suite *ProductHandlerTestSuite) TestGetProducts() {
 	}
 	
 	// Setup expectations
-	suite.mockProductUseCase.On("GetProducts", mock.Anything, 10, 0).Return(mockProductResponse, nil)
+	suite.mockProductUseCase.On("GetProducts", mock.Anything, model.ProductListFilter{}, 0, 0).Return(mockProductResponse, nil)
 	
 	// Create request
 	req := httptest.NewRequest("GET", "/api/v1/products", nil)
@@ -124,12 +134,79 @@ func (suite *ProductHandlerTestSuite) TestGetProducts() {
 	
 	assert.Equal(t, 2, len(productListResp.Products))
 	assert.Equal(t, "Test Product 1", productListResp.Products[0].Name)
-	assert.Equal(t, float64(99.99), productListResp.Products[0].Price)
+	assert.Equal(t, entity.Money(9999), productListResp.Products[0].Price)
 	
 	// Verify expectations
 	suite.mockProductUseCase.AssertExpectations(t)
 }
 
+func (suite *ProductHandlerTestSuite) TestGetProducts_PriceRangeAndSort() {
+	t := suite.T()
+	minPrice, maxPrice := entity.Money(1050), entity.Money(20000)
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("GetProducts", mock.Anything, model.ProductListFilter{
+		MinPrice: &minPrice,
+		MaxPrice: &maxPrice,
+		Sort:     "price_desc",
+	}, 20, 0).Return(&model.ProductListResponse{Limit: 20}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products?limit=20&min_price=10.5&max_price=200&sort=price_desc", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetProducts_Cursor() {
+	t := suite.T()
+	
+	// Setup expectations; the cursor is passed through to the usecase
+	suite.mockProductUseCase.On("GetProducts", mock.Anything, model.ProductListFilter{Cursor: "abc123"}, 20, 0).
+		Return(&model.ProductListResponse{Limit: 20, HasNext: true, NextCursor: "def456"}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products?limit=20&cursor=abc123", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"next_cursor":"def456"`)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetProducts_CursorWithOffset() {
+	t := suite.T()
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products?cursor=abc123&offset=10", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	suite.mockProductUseCase.AssertNotCalled(t, "GetProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetProducts_InvalidPrice() {
+	t := suite.T()
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products?min_price=cheap", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	suite.mockProductUseCase.AssertNotCalled(t, "GetProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *ProductHandlerTestSuite) TestGetProductByID() {
 	t := suite.T()
 	
@@ -139,7 +216,7 @@ func (suite *ProductHandlerTestSuite) TestGetProductByID() {
 		ID:          mockProductID,
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       9999,
 		Stock:       10,
 		Category:    "Test Category",
 		SKU:         "TEST-SKU-001",
@@ -179,12 +256,268 @@ func (suite *ProductHandlerTestSuite) TestGetProductByID() {
 	
 	assert.Equal(t, mockProductID, productResp.ID)
 	assert.Equal(t, "Test Product", productResp.Name)
-	assert.Equal(t, float64(99.99), productResp.Price)
+	assert.Equal(t, entity.Money(9999), productResp.Price)
+	
+	// Verify expectations
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetProductsByIDs() {
+	t := suite.T()
+	
+	// Setup mock data
+	mockProductIDs := []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "f47ac10b-58cc-4372-a567-0e02b2c3d480"}
+	mockProductResponse := &model.ProductsByIDsResponse{
+		Products: []model.ProductResponse{
+			{ID: mockProductIDs[0], Name: "Test Product 1", Price: 9999},
+			{ID: mockProductIDs[1], Name: "Test Product 2", Price: 19999},
+		},
+		MissingIDs: []string{},
+	}
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("GetProductsByIDs", mock.Anything, mockProductIDs).Return(mockProductResponse, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/batch?ids="+mockProductIDs[0]+","+mockProductIDs[1], nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 	
 	// Verify expectations
 	suite.mockProductUseCase.AssertExpectations(t)
 }
 
+func (suite *ProductHandlerTestSuite) TestGetProductsByIDs_MissingIDs() {
+	t := suite.T()
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/batch", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	suite.mockProductUseCase.AssertNotCalled(t, "GetProductsByIDs", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchGetProducts() {
+	t := suite.T()
+	
+	// Setup mock data
+	mockProductIDs := []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "f47ac10b-58cc-4372-a567-0e02b2c3d480"}
+	mockProductResponse := &model.ProductsByIDsResponse{
+		Products: []model.ProductResponse{
+			{ID: mockProductIDs[0], Name: "Test Product 1", Price: 9999},
+		},
+		MissingIDs: []string{mockProductIDs[1]},
+	}
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("GetProductsByIDs", mock.Anything, mockProductIDs).Return(mockProductResponse, nil)
+	
+	// Create request
+	body, _ := json.Marshal(model.GetProductsByIDsRequest{IDs: mockProductIDs})
+	req := httptest.NewRequest("POST", "/api/v1/products/batch-get", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	var responseBody struct {
+		Data model.ProductsByIDsResponse `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+	assert.Len(t, responseBody.Data.Products, 1)
+	assert.Equal(t, []string{mockProductIDs[1]}, responseBody.Data.MissingIDs)
+	
+	// Verify expectations
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchGetProducts_InvalidID() {
+	t := suite.T()
+	
+	// Setup expectations; the usecase rejects IDs that are not UUIDs
+	ids := []string{"not-a-uuid"}
+	suite.mockProductUseCase.On("GetProductsByIDs", mock.Anything, ids).
+		Return(nil, appErrors.WithMessage(appErrors.ErrInvalidProductID, "Invalid product ID: not-a-uuid"))
+	
+	// Create request
+	body, _ := json.Marshal(model.GetProductsByIDsRequest{IDs: ids})
+	req := httptest.NewRequest("POST", "/api/v1/products/batch-get", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestCreateProduct_ValidationFields() {
+	t := suite.T()
+	
+	// Setup expectations; the usecase reports the fields failing validation
+	request := model.CreateProductRequest{Price: -500}
+	validate := validator.New()
+	validate.RegisterTagNameFunc(appErrors.JSONFieldName)
+	suite.mockProductUseCase.On("CreateProduct", mock.Anything, &request).
+		Return(nil, appErrors.NewValidationError(validate.Struct(request)))
+	
+	// Create request without a name and with a negative price
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/api/v1/products/", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	
+	var apiResponse response.Response
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&apiResponse))
+	assert.False(t, apiResponse.Success)
+	assert.Equal(t, "INVALID_INPUT", apiResponse.Error.Code)
+	assert.Equal(t, []appErrors.FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "price", Rule: "gt", Message: "price must be greater than 0"},
+	}, apiResponse.Error.Fields)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestCreateProductsBatch() {
+	t := suite.T()
+	
+	// Setup mock data
+	mockRequests := []model.CreateProductRequest{
+		{Name: "Product 1", Price: 1000, SKU: "SKU-1"},
+		{Name: "Product 2", Price: 2000, SKU: "SKU-1"},
+	}
+	mockResponse := &model.CreateProductsBatchResponse{
+		Created: 1,
+		Failed:  1,
+		Results: []model.CreateProductBatchItemResult{
+			{Index: 0, SKU: "SKU-1", Status: model.BatchItemStatusCreated, ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+			{Index: 1, SKU: "SKU-1", Status: model.BatchItemStatusFailed, ErrorCode: "DUPLICATE_SKU", Error: "SKU is already used by product 0 of the batch"},
+		},
+	}
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("CreateProductsBatch", mock.Anything, mockRequests).Return(mockResponse, nil)
+	
+	// Create request
+	reqBody, _ := json.Marshal(model.CreateProductsBatchRequest{Products: mockRequests})
+	req := httptest.NewRequest("POST", "/api/v1/products/batch", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	var responseBody struct {
+		Data model.CreateProductsBatchResponse `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&responseBody))
+	assert.Equal(t, *mockResponse, responseBody.Data)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestCreateProductsBatch_TooLarge() {
+	t := suite.T()
+	
+	// Setup expectations; the usecase rejects batches over the configured size
+	suite.mockProductUseCase.On("CreateProductsBatch", mock.Anything, mock.Anything).
+		Return(nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "Between 1 and 100 products are required"))
+	
+	// Create request
+	reqBody, _ := json.Marshal(model.CreateProductsBatchRequest{Products: make([]model.CreateProductRequest, 101)})
+	req := httptest.NewRequest("POST", "/api/v1/products/batch", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetProductByID_IncludeStock() {
+	t := suite.T()
+	
+	// Setup expectations
+	mockProductID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	suite.mockProductUseCase.On("GetProductWithStock", mock.Anything, mockProductID).Return(&model.ProductResponse{
+		ID:             mockProductID,
+		Stock:          7,
+		StockUpdatedAt: "2025-05-30T10:00:00Z",
+	}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/"+mockProductID+"?include_stock=true", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"stock":7`)
+	assert.Contains(t, string(body), `"stock_updated_at":"2025-05-30T10:00:00Z"`)
+	suite.mockProductUseCase.AssertNotCalled(t, "GetProductByID", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetProductByID_ConditionalGet() {
+	t := suite.T()
+	
+	mockProductID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	original := &model.ProductResponse{ID: mockProductID, Name: "Test Product", Version: 1, UpdatedAt: "2025-06-01T10:00:00Z"}
+	updated := &model.ProductResponse{ID: mockProductID, Name: "Renamed Product", Version: 2, UpdatedAt: "2025-06-01T10:05:00Z"}
+	
+	// Setup expectations; the product is read twice unchanged, then once after an update
+	suite.mockProductUseCase.On("GetProductByID", mock.Anything, mockProductID).Return(original, nil).Twice()
+	suite.mockProductUseCase.On("GetProductByID", mock.Anything, mockProductID).Return(updated, nil).Once()
+	
+	get := func(ifNoneMatch string) *http.Response {
+		req := httptest.NewRequest("GET", "/api/v1/products/"+mockProductID, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := suite.app.Test(req)
+		assert.NoError(t, err)
+		return resp
+	}
+	
+	// The first read returns the product with its ETag
+	first := get("")
+	assert.Equal(t, fiber.StatusOK, first.StatusCode)
+	etag := first.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+	
+	// Nothing changed, so the same tag gets 304 without a body
+	second := get(etag)
+	assert.Equal(t, fiber.StatusNotModified, second.StatusCode)
+	assert.Equal(t, etag, second.Header.Get("ETag"))
+	body, err := io.ReadAll(second.Body)
+	assert.NoError(t, err)
+	assert.Empty(t, body)
+	
+	// After an update the old tag no longer matches and the new product is returned
+	third := get(etag)
+	assert.Equal(t, fiber.StatusOK, third.StatusCode)
+	assert.NotEqual(t, etag, third.Header.Get("ETag"))
+	body, err = io.ReadAll(third.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"name":"Renamed Product"`)
+	
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
 func (suite *ProductHandlerTestSuite) TestGetProductByID_NotFound() {
 	t := suite.T()
 	
@@ -227,7 +560,7 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 	mockCreateRequest := model.CreateProductRequest{
 		Name:        "New Test Product",
 		Description: "New Test Description",
-		Price:       149.99,
+		Price:       14999,
 		Stock:       15,
 		Category:    "New Category",
 		SKU:         "NEW-SKU-001",
@@ -237,7 +570,7 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 		ID:          "f47ac10b-58cc-4372-a567-0e02b2c3d481",
 		Name:        "New Test Product",
 		Description: "New Test Description",
-		Price:       149.99,
+		Price:       14999,
 		Stock:       15,
 		Category:    "New Category",
 		SKU:         "NEW-SKU-001",
@@ -304,7 +637,7 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct_InvalidInput() {
 	// Mock behavior for invalid request - the handler should validate input before calling usecase
 	createReq := &model.CreateProductRequest{
 		Name:     "",
-		Price:    -10,
+		Price:    -1000,
 		Category: "Test Category",
 	}
 	suite.mockProductUseCase.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req *model.CreateProductRequest) bool {
@@ -349,14 +682,14 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 	mockUpdateRequest := model.UpdateProductRequest{
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       199.99,
+		Price:       19999,
 	}
 	
 	mockProductResponse := &model.ProductResponse{
 		ID:          mockProductID,
 		Name:        "Updated Product",
 		Description: "Updated Description",
-		Price:       199.99,
+		Price:       19999,
 		Stock:       10,
 		Category:    "Test Category",
 		SKU:         "TEST-SKU-001",
@@ -410,6 +743,83 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 	suite.mockProductUseCase.AssertExpectations(t)
 }
 
+func (suite *ProductHandlerTestSuite) TestUpdateProduct_Preconditions() {
+	t := suite.T()
+	
+	mockProductID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	unmodifiedSince := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	
+	// Setup expectations; the version and header date reach the usecase, which reports a newer change
+	suite.mockProductUseCase.On("UpdateProduct", mock.Anything, mockProductID, mock.MatchedBy(func(req *model.UpdateProductRequest) bool {
+		return req.Version == 2 && req.UnmodifiedSince.Equal(unmodifiedSince)
+	})).Return(nil, appErrors.ErrConcurrentModification)
+	
+	// Create request
+	reqBody, _ := json.Marshal(model.UpdateProductRequest{Name: "Updated Product", Price: 19999, Version: 2})
+	req := httptest.NewRequest("PUT", "/api/v1/products/"+mockProductID, bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Unmodified-Since", unmodifiedSince.Format(http.TimeFormat))
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	
+	var apiResponse response.Response
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&apiResponse))
+	assert.Equal(t, "CONCURRENT_MODIFICATION", apiResponse.Error.Code)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestUpdateProduct_InvalidUnmodifiedSince() {
+	t := suite.T()
+	
+	// Create request
+	reqBody, _ := json.Marshal(model.UpdateProductRequest{Name: "Updated Product", Price: 19999})
+	req := httptest.NewRequest("PUT", "/api/v1/products/f47ac10b-58cc-4372-a567-0e02b2c3d479", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Unmodified-Since", "yesterday")
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	suite.mockProductUseCase.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ProductHandlerTestSuite) TestUpdateProductPartial() {
+	t := suite.T()
+	
+	// Setup mock data
+	mockProductID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	mockProductResponse := &model.ProductResponse{
+		ID:          mockProductID,
+		Name:        "Test Product",
+		Description: "Patched Description",
+		Price:       9999,
+		Category:    "Test Category",
+		SKU:         "TEST-SKU-001",
+	}
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("UpdateProductPartial", mock.Anything, mockProductID, &model.PatchProductRequest{
+		Description: "Patched Description",
+	}).Return(mockProductResponse, nil)
+	
+	// Create request
+	req := httptest.NewRequest("PATCH", "/api/v1/products/"+mockProductID, bytes.NewBufferString(`{"description":"Patched Description"}`))
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Test the request
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	suite.mockProductUseCase.AssertExpectations(t)
+	suite.mockProductUseCase.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *ProductHandlerTestSuite) TestDeleteProduct() {
 	t := suite.T()
 	
@@ -431,6 +841,58 @@ func (suite *ProductHandlerTestSuite) TestDeleteProduct() {
 	suite.mockProductUseCase.AssertExpectations(t)
 }
 
+func (suite *ProductHandlerTestSuite) TestGetProducts_IncludeDeleted() {
+	t := suite.T()
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("GetProducts", mock.Anything, model.ProductListFilter{IncludeDeleted: true}, 0, 0).Return(&model.ProductListResponse{
+		Products: []model.ProductResponse{
+			{
+				ID:        "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Name:      "Deleted Product",
+				DeletedAt: "2025-05-29T09:00:00Z",
+			},
+		},
+		Count: 1,
+		Limit: 10,
+	}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products?include_deleted=true", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	// Verify expectations
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestRestoreProduct() {
+	t := suite.T()
+	
+	// Setup mock data
+	mockProductID := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("RestoreProduct", mock.Anything, mockProductID).Return(&model.ProductResponse{
+		ID:   mockProductID,
+		Name: "Restored Product",
+	}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("POST", "/api/v1/products/"+mockProductID+"/restore", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	// Verify expectations
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
 func (suite *ProductHandlerTestSuite) TestSearchProducts() {
 	t := suite.T()
 	
@@ -442,7 +904,7 @@ func (suite *ProductHandlerTestSuite) TestSearchProducts() {
 				ID:          "f47ac10b-58cc-4372-a567-0e02b2c3d479",
 				Name:        "Test Product 1",
 				Description: "Test Description 1",
-				Price:       99.99,
+				Price:       9999,
 				Stock:       10,
 				Category:    "Test Category",
 				SKU:         "TEST-SKU-001",
@@ -454,7 +916,7 @@ func (suite *ProductHandlerTestSuite) TestSearchProducts() {
 	}
 	
 	// Setup expectations
-	suite.mockProductUseCase.On("SearchProducts", mock.Anything, searchQuery, 10, 0).Return(mockProductResponse, nil)
+	suite.mockProductUseCase.On("SearchProducts", mock.Anything, searchQuery, "", 0, 0).Return(mockProductResponse, nil)
 	
 	// Create request
 	req := httptest.NewRequest("GET", "/api/v1/products/search?q="+searchQuery, nil)
@@ -492,6 +954,22 @@ func (suite *ProductHandlerTestSuite) TestSearchProducts() {
 	suite.mockProductUseCase.AssertExpectations(t)
 }
 
+func (suite *ProductHandlerTestSuite) TestSearchProducts_SortByRelevance() {
+	t := suite.T()
+	
+	// Setup expectations; the query is trimmed and the sort is passed through
+	suite.mockProductUseCase.On("SearchProducts", mock.Anything, "apple phone", "relevance", 0, 0).Return(&model.ProductListResponse{Limit: 10}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/search?q=%20apple%20phone%20&sort=relevance", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
 func (suite *ProductHandlerTestSuite) TestGetProductsByCategory() {
 	t := suite.T()
 	
@@ -503,7 +981,7 @@ func (suite *ProductHandlerTestSuite) TestGetProductsByCategory() {
 				ID:          "f47ac10b-58cc-4372-a567-0e02b2c3d479",
 				Name:        "iPhone",
 				Description: "Apple Smartphone",
-				Price:       999.99,
+				Price:       99999,
 				Stock:       10,
 				Category:    "electronics",
 				SKU:         "IPHONE-001",
@@ -512,7 +990,7 @@ func (suite *ProductHandlerTestSuite) TestGetProductsByCategory() {
 				ID:          "f47ac10b-58cc-4372-a567-0e02b2c3d480",
 				Name:        "Samsung Galaxy",
 				Description: "Android Smartphone",
-				Price:       899.99,
+				Price:       89999,
 				Stock:       15,
 				Category:    "electronics",
 				SKU:         "SAMSUNG-001",
@@ -524,7 +1002,7 @@ func (suite *ProductHandlerTestSuite) TestGetProductsByCategory() {
 	}
 	
 	// Setup expectations
-	suite.mockProductUseCase.On("GetProductsByCategory", mock.Anything, category, 10, 0).Return(mockProductResponse, nil)
+	suite.mockProductUseCase.On("GetProductsByCategory", mock.Anything, category, 0, 0).Return(mockProductResponse, nil)
 	
 	// Create request
 	req := httptest.NewRequest("GET", "/api/v1/products/category/"+category, nil)
@@ -563,6 +1041,81 @@ func (suite *ProductHandlerTestSuite) TestGetProductsByCategory() {
 	suite.mockProductUseCase.AssertExpectations(t)
 }
 
+func (suite *ProductHandlerTestSuite) TestGetLowStockProducts() {
+	t := suite.T()
+	
+	// Setup mock data; the warehouse service failed part way through
+	report := &model.LowStockProductsResponse{
+		Products: []model.LowStockProductResponse{
+			{ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479", Name: "iPhone", SKU: "IPHONE-001", AvailableQuantity: 0},
+		},
+		Threshold:      5,
+		Count:          1,
+		Page:           2,
+		Limit:          20,
+		TotalPages:     1,
+		Partial:        true,
+		UncheckedCount: 3,
+		Warning:        "warning",
+	}
+	
+	// Setup expectations
+	suite.mockProductUseCase.On("GetLowStockProducts", mock.Anything, 5, 2, 20).Return(report, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/low-stock?threshold=5&page=2&limit=20", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	var apiResponse struct {
+		Success bool                           `json:"success"`
+		Data    model.LowStockProductsResponse `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&apiResponse)
+	assert.NoError(t, err)
+	assert.True(t, apiResponse.Success)
+	assert.True(t, apiResponse.Data.Partial)
+	assert.Equal(t, 3, apiResponse.Data.UncheckedCount)
+	if assert.Len(t, apiResponse.Data.Products, 1) {
+		assert.Equal(t, 0, apiResponse.Data.Products[0].AvailableQuantity)
+	}
+	
+	// Verify expectations
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetLowStockProducts_DefaultThreshold() {
+	t := suite.T()
+	
+	// Setup expectations; omitted parameters use the default threshold and first page
+	suite.mockProductUseCase.On("GetLowStockProducts", mock.Anything, 10, 1, 0).Return(&model.LowStockProductsResponse{}, nil)
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/low-stock", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	suite.mockProductUseCase.AssertExpectations(t)
+}
+
+func (suite *ProductHandlerTestSuite) TestGetLowStockProducts_InvalidThreshold() {
+	t := suite.T()
+	
+	// Create request
+	req := httptest.NewRequest("GET", "/api/v1/products/low-stock?threshold=few", nil)
+	resp, err := suite.app.Test(req)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	suite.mockProductUseCase.AssertNotCalled(t, "GetLowStockProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestProductHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductHandlerTestSuite))
 }
\ No newline at end of file
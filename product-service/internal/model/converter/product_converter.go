@@ -8,7 +8,7 @@ import (
 
 // ProductToResponse converts a product entity to product response
 func ProductToResponse(product *entity.Product) *model.ProductResponse {
-	return &model.ProductResponse{
+	response := &model.ProductResponse{
 		ID:          product.ID.String(),
 		Name:        product.Name,
 		Description: product.Description,
@@ -17,9 +17,15 @@ func ProductToResponse(product *entity.Product) *model.ProductResponse {
 		Category:    product.Category,
 		SKU:         product.SKU,
 		ImageURL:    product.ThumbnailURL, // Using ThumbnailURL as main image for simplicity
+		Status:      product.Status,
+		Version:     product.Version,
 		CreatedAt:   product.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   product.UpdatedAt.Format(time.RFC3339),
 	}
+	if product.DeletedAt.Valid {
+		response.DeletedAt = product.DeletedAt.Time.Format(time.RFC3339)
+	}
+	return response
 }
 
 // ProductsToResponse converts a slice of product entities to product list response
@@ -36,16 +42,79 @@ func ProductsToResponse(products []entity.Product, count int64, limit, offset in
 			Category:    product.Category,
 			SKU:         product.SKU,
 			ImageURL:    product.ThumbnailURL, // Using ThumbnailURL as main image
+			Status:      product.Status,
+			Version:     product.Version,
 			CreatedAt:   product.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:   product.UpdatedAt.Format(time.RFC3339),
 		}
+		if product.DeletedAt.Valid {
+			productResponse.DeletedAt = product.DeletedAt.Time.Format(time.RFC3339)
+		}
 		productResponses = append(productResponses, productResponse)
 	}
 	
+	// Without a limit every product fits on a single page
+	var totalPages int64
+	hasNext := false
+	if limit > 0 {
+		totalPages = (count + int64(limit) - 1) / int64(limit)
+		hasNext = int64(offset)+int64(limit) < count
+	} else if count > 0 {
+		totalPages = 1
+	}
+	
 	return &model.ProductListResponse{
-		Products: productResponses,
-		Count:    count,
-		Limit:    limit,
-		Offset:   offset,
+		Products:   productResponses,
+		Count:      count,
+		Limit:      limit,
+		Offset:     offset,
+		TotalPages: totalPages,
+		HasNext:    hasNext,
+	}
+}
+// ProductsByIDsToResponse converts the products found by a batch lookup and the IDs that matched none
+func ProductsByIDsToResponse(products []entity.Product, missingIDs []string) *model.ProductsByIDsResponse {
+	response := &model.ProductsByIDsResponse{
+		Products:   make([]model.ProductResponse, len(products)),
+		MissingIDs: missingIDs,
+	}
+	for i := range products {
+		response.Products[i] = *ProductToResponse(&products[i])
+	}
+	if response.MissingIDs == nil {
+		response.MissingIDs = []string{}
+	}
+	return response
+}
+// LowStockProductToResponse converts a product and its live stock into a low stock report entry
+func LowStockProductToResponse(product *entity.Product, stock *model.ProductStockResponse) model.LowStockProductResponse {
+	return model.LowStockProductResponse{
+		ID:                product.ID.String(),
+		Name:              product.Name,
+		SKU:               product.SKU,
+		Category:          product.Category,
+		AvailableQuantity: stock.TotalAvailableQuantity,
+		ReservedQuantity:  stock.TotalReservedQuantity,
+		TotalQuantity:     stock.TotalQuantity,
+		StockUpdatedAt:    stock.FetchedAt,
+	}
+}
+
+// LowStockProductsToResponse returns the requested page of every low stock product found
+func LowStockProductsToResponse(products []model.LowStockProductResponse, threshold, page, limit int) *model.LowStockProductsResponse {
+	count := int64(len(products))
+	start := min((page-1)*limit, len(products))
+	end := min(start+limit, len(products))
+
+	response := &model.LowStockProductsResponse{
+		Products:   make([]model.LowStockProductResponse, end-start),
+		Threshold:  threshold,
+		Count:      count,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (count + int64(limit) - 1) / int64(limit),
+		HasNext:    end < len(products),
 	}
+	copy(response.Products, products[start:end])
+	return response
 }
\ No newline at end of file
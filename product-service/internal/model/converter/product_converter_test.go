@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"product-service/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductsToResponse_Pagination(t *testing.T) {
+	tests := []struct {
+		name          string
+		count         int64
+		limit         int
+		offset        int
+		expectedPages int64
+		expectedNext  bool
+	}{
+		{"no products", 0, 10, 0, 0, false},
+		{"single partial page", 5, 10, 0, 1, false},
+		{"exactly one page", 10, 10, 0, 1, false},
+		{"first of several pages", 25, 10, 0, 3, true},
+		{"middle page", 25, 10, 10, 3, true},
+		{"last partial page", 25, 10, 20, 3, false},
+		{"offset past count", 25, 10, 40, 3, false},
+		{"offset not on a page boundary", 25, 10, 14, 3, true},
+		{"no limit", 25, 0, 0, 1, false},
+		{"no limit and no products", 0, 0, 0, 0, false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := ProductsToResponse([]entity.Product{}, tt.count, tt.limit, tt.offset)
+			
+			assert.Equal(t, tt.expectedPages, response.TotalPages)
+			assert.Equal(t, tt.expectedNext, response.HasNext)
+			assert.Equal(t, tt.count, response.Count)
+			assert.Equal(t, tt.limit, response.Limit)
+			assert.Equal(t, tt.offset, response.Offset)
+		})
+	}
+}
@@ -1,41 +1,121 @@
 package model
 
+import (
+	"product-service/internal/entity"
+	"time"
+)
+
 type ProductResponse struct {
-	ID          string  `json:"id,omitempty"`
-	Name        string  `json:"name,omitempty"`
-	Description string  `json:"description,omitempty"`
-	Price       float64 `json:"price,omitempty"`
-	Stock       int     `json:"stock,omitempty"`
-	Category    string  `json:"category,omitempty"`
-	SKU         string  `json:"sku,omitempty"`
-	ImageURL    string  `json:"image_url,omitempty"`
-	CreatedAt   string  `json:"created_at,omitempty"`
-	UpdatedAt   string  `json:"updated_at,omitempty"`
+	ID             string  `json:"id,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Description    string  `json:"description,omitempty"`
+	Price          entity.Money `json:"price,omitempty" swaggertype:"number"`
+	Stock          int     `json:"stock,omitempty"`
+	StockUpdatedAt string  `json:"stock_updated_at,omitempty"` // Set when Stock holds live warehouse stock
+	Category       string  `json:"category,omitempty"`
+	SKU            string  `json:"sku,omitempty"`
+	ImageURL       string  `json:"image_url,omitempty"`
+	Status         string  `json:"status,omitempty"`
+	Version        int     `json:"version,omitempty"` // Pass back as version when updating to detect concurrent changes
+	CreatedAt      string  `json:"created_at,omitempty"`
+	UpdatedAt      string  `json:"updated_at,omitempty"`
+	DeletedAt      string  `json:"deleted_at,omitempty"`
 }
 
 type ProductListResponse struct {
-	Products []ProductResponse `json:"products"`
-	Count    int64            `json:"count"`
-	Limit    int              `json:"limit"`
-	Offset   int              `json:"offset"`
+	Products   []ProductResponse `json:"products"`
+	Count      int64             `json:"count"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	TotalPages int64             `json:"total_pages"` // Pages of Limit products needed to cover Count
+	HasNext    bool              `json:"has_next"`    // Whether products remain after this page
+	NextCursor string            `json:"next_cursor,omitempty"` // Pass as cursor to fetch the next page of a newest-first listing
+}
+
+// ProductListFilter holds optional filters and ordering for listing products
+type ProductListFilter struct {
+	MinPrice       *entity.Money
+	MaxPrice       *entity.Money
+	Sort           string // price_asc, price_desc, name_asc or created_desc
+	IncludeDeleted bool
+	Cursor         string // Opaque cursor from a previous page; when set, the page is found by keyset instead of offset
 }
 
 type CreateProductRequest struct {
 	Name        string  `json:"name" validate:"required,max=255"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,gt=0"`
+	Price       entity.Money `json:"price" validate:"required,gt=0" swaggertype:"number"`
 	Stock       int     `json:"stock" validate:"min=0"`
 	Category    string  `json:"category" validate:"max=100"`
 	SKU         string  `json:"sku" validate:"max=50"`
 	ImageURL    string  `json:"image_url" validate:"max=255"`
 }
 
+// CreateProductsBatchRequest creates several products in one request
+type CreateProductsBatchRequest struct {
+	Products []CreateProductRequest `json:"products"`
+}
+
+// GetProductsByIDsRequest looks up several products by ID in one request
+type GetProductsByIDsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// ProductsByIDsResponse holds the products found by a batch lookup and the requested IDs that matched no product.
+// Deleted products are reported as missing.
+type ProductsByIDsResponse struct {
+	Products   []ProductResponse `json:"products"`
+	MissingIDs []string          `json:"missing_ids"`
+}
+
+// BatchItemStatus represents the outcome of a single product in a batch create
+type BatchItemStatus string
+
+const (
+	BatchItemStatusCreated BatchItemStatus = "created"
+	BatchItemStatusFailed  BatchItemStatus = "failed"
+)
+
+// CreateProductBatchItemResult represents the result of creating one product of a batch.
+// Index is the position of the product in the request.
+type CreateProductBatchItemResult struct {
+	Index     int             `json:"index"`
+	SKU       string          `json:"sku,omitempty"`
+	Status    BatchItemStatus `json:"status"`
+	ID        string          `json:"id,omitempty"`
+	ErrorCode string          `json:"error_code,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// CreateProductsBatchResponse represents the per-product results of a batch create
+type CreateProductsBatchResponse struct {
+	Created int                            `json:"created"`
+	Failed  int                            `json:"failed"`
+	Results []CreateProductBatchItemResult `json:"results"`
+}
+
+// UpdateProductRequest replaces a product (PUT). Omitted optional fields are cleared.
 type UpdateProductRequest struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	Description string  `json:"description"`
+	Price       entity.Money `json:"price" validate:"required,gt=0" swaggertype:"number"`
+	Stock       int     `json:"stock" validate:"min=0"`
+	Category    string  `json:"category" validate:"max=100"`
+	SKU         string  `json:"sku" validate:"max=50"`
+	ImageURL    string  `json:"image_url" validate:"max=255"`
+	Version     int     `json:"version" validate:"min=0"` // Version the change is based on; a stale version is rejected, 0 skips the check
+	UnmodifiedSince time.Time `json:"-"` // From the If-Unmodified-Since header; a product changed later is rejected
+}
+
+// PatchProductRequest partially updates a product (PATCH). Only non-empty fields are applied.
+type PatchProductRequest struct {
 	Name        string  `json:"name" validate:"max=255"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"gt=0"`
+	Price       entity.Money `json:"price" validate:"omitempty,gt=0" swaggertype:"number"`
 	Stock       int     `json:"stock" validate:"min=0"`
 	Category    string  `json:"category" validate:"max=100"`
 	SKU         string  `json:"sku" validate:"max=50"`
 	ImageURL    string  `json:"image_url" validate:"max=255"`
+	Version     int     `json:"version" validate:"min=0"` // Version the change is based on; a stale version is rejected, 0 skips the check
+	UnmodifiedSince time.Time `json:"-"` // From the If-Unmodified-Since header; a product changed later is rejected
 }
\ No newline at end of file
@@ -0,0 +1,36 @@
+package model
+
+// ProductStockResponse is the stock of a product summed across all active warehouses
+type ProductStockResponse struct {
+	TotalQuantity          int    `json:"total_quantity"`
+	TotalReservedQuantity  int    `json:"total_reserved_quantity"`
+	TotalAvailableQuantity int    `json:"total_available_quantity"`
+	FetchedAt              string `json:"-"` // When the warehouse service reported these totals
+}
+
+// LowStockProductResponse is a product whose stock available across all warehouses is below the requested threshold
+type LowStockProductResponse struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	SKU               string `json:"sku"`
+	Category          string `json:"category"`
+	AvailableQuantity int    `json:"available_quantity"`
+	ReservedQuantity  int    `json:"reserved_quantity"`
+	TotalQuantity     int    `json:"total_quantity"`
+	StockUpdatedAt    string `json:"stock_updated_at"` // When the warehouse service reported these totals
+}
+
+// LowStockProductsResponse is a page of low stock products. When the warehouse service fails part way through,
+// Partial is set and the products whose stock could not be checked are counted in UncheckedCount instead of listed.
+type LowStockProductsResponse struct {
+	Products       []LowStockProductResponse `json:"products"`
+	Threshold      int                       `json:"threshold"`
+	Count          int64                     `json:"count"` // Low stock products found
+	Page           int                       `json:"page"`
+	Limit          int                       `json:"limit"`
+	TotalPages     int64                     `json:"total_pages"`
+	HasNext        bool                      `json:"has_next"`
+	Partial        bool                      `json:"partial"`
+	UncheckedCount int                       `json:"unchecked_count,omitempty"`
+	Warning        string                    `json:"warning,omitempty"`
+}
@@ -14,6 +14,24 @@ type ProductListResponseWrapper struct {
 	Errors string             `json:"errors,omitempty"`
 }
 
+// CreateProductsBatchResponseWrapper is a wrapper for WebResponse[CreateProductsBatchResponse]
+type CreateProductsBatchResponseWrapper struct {
+	Data   CreateProductsBatchResponse `json:"data,omitempty"`
+	Errors string                      `json:"errors,omitempty"`
+}
+
+// ProductsByIDsResponseWrapper is a wrapper for WebResponse[ProductsByIDsResponse]
+type ProductsByIDsResponseWrapper struct {
+	Data   ProductsByIDsResponse `json:"data,omitempty"`
+	Errors string                `json:"errors,omitempty"`
+}
+
+// LowStockProductsResponseWrapper is a wrapper for WebResponse[LowStockProductsResponse]
+type LowStockProductsResponseWrapper struct {
+	Data   LowStockProductsResponse `json:"data,omitempty"`
+	Errors string                   `json:"errors,omitempty"`
+}
+
 // ErrorResponse is a wrapper for WebResponse[string]
 type ErrorResponse struct {
 	Errors string `json:"errors,omitempty"`
@@ -1,25 +1,88 @@
 package repository
 
 import (
+	"errors"
 	"product-service/internal/entity"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrConcurrentModification is returned by Update when the product was changed after it was read
+var ErrConcurrentModification = errors.New("product was modified concurrently")
+
 type ProductRepositoryInterface interface {
 	Create(db *gorm.DB, product *entity.Product) error
-	FindAll(db *gorm.DB, limit, offset int) ([]entity.Product, int64, error)
+	FindAll(db *gorm.DB, filter ProductFilter, limit, offset int) ([]entity.Product, int64, error)
+	FindAfter(db *gorm.DB, filter ProductFilter, after *ProductCursor, limit int) ([]entity.Product, int64, *ProductCursor, error)
 	FindByID(db *gorm.DB, id string) (*entity.Product, error)
+	FindByIDWithDeleted(db *gorm.DB, id string) (*entity.Product, error)
+	FindByIDs(db *gorm.DB, ids []string) ([]entity.Product, error)
 	FindBySKU(db *gorm.DB, sku string) (*entity.Product, error)
 	Update(db *gorm.DB, product *entity.Product) error
 	Delete(db *gorm.DB, id string) error
-	Search(db *gorm.DB, query string, limit, offset int) ([]entity.Product, int64, error)
+	Restore(db *gorm.DB, id string) error
+	Search(db *gorm.DB, query string, sort string, limit, offset int) ([]entity.Product, int64, error)
 	FindByCategory(db *gorm.DB, category string, limit, offset int) ([]entity.Product, int64, error)
 	GetDB() *gorm.DB
 }
 
+// Sort keys accepted by FindAll
+const (
+	ProductSortPriceAsc    = "price_asc"
+	ProductSortPriceDesc   = "price_desc"
+	ProductSortNameAsc     = "name_asc"
+	ProductSortCreatedDesc = "created_desc"
+)
+
+// productSortOrders maps each sort key to its ORDER BY clause
+var productSortOrders = map[string]string{
+	ProductSortPriceAsc:    "base_price ASC",
+	ProductSortPriceDesc:   "base_price DESC",
+	ProductSortNameAsc:     "name ASC",
+	ProductSortCreatedDesc: "created_at DESC, uuid DESC", // uuid breaks ties so the order matches FindAfter
+}
+
+// ProductSortRelevance orders search results by the number of query tokens each product matches
+const ProductSortRelevance = "relevance"
+
+// maxSearchTokens caps how many query tokens Search matches so a long query cannot build an unbounded statement
+const maxSearchTokens = 10
+
+// searchColumns are the columns each search token is matched against
+var searchColumns = []string{"name", "description", "sku", "category", "brand"}
+
+// IsValidProductSort reports whether sort is a key FindAll understands
+func IsValidProductSort(sort string) bool {
+	_, ok := productSortOrders[sort]
+	return ok
+}
+
+// ProductFilter holds optional criteria for narrowing product listings.
+// Zero values are ignored, so an empty filter lists every product that is not deleted, newest first.
+type ProductFilter struct {
+	MinPrice       *entity.Money
+	MaxPrice       *entity.Money
+	Sort           string
+	IncludeDeleted bool
+}
+
+// ProductCursor marks the last product of a page in newest-first order.
+// FindAfter continues the listing with the products that sort after it.
+type ProductCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorOf returns the cursor pointing at product
+func CursorOf(product entity.Product) *ProductCursor {
+	return &ProductCursor{CreatedAt: product.CreatedAt, ID: product.ID}
+}
+
 type ProductRepository struct {
 	DB  *gorm.DB
 	Log *logrus.Logger
@@ -40,17 +103,36 @@ func (r *ProductRepository) Create(db *gorm.DB, product *entity.Product) error {
 	return db.Create(product).Error
 }
 
-func (r *ProductRepository) FindAll(db *gorm.DB, limit, offset int) ([]entity.Product, int64, error) {
+// priceRange restricts a query to the filter's price bounds
+func priceRange(filter ProductFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.MinPrice != nil {
+			db = db.Where("base_price >= ?", *filter.MinPrice)
+		}
+		if filter.MaxPrice != nil {
+			db = db.Where("base_price <= ?", *filter.MaxPrice)
+		}
+		return db
+	}
+}
+
+// FindAll returns a page of products matching the filter, along with the number of matching products
+func (r *ProductRepository) FindAll(db *gorm.DB, filter ProductFilter, limit, offset int) ([]entity.Product, int64, error) {
 	var products []entity.Product
 	var count int64
 	
+	if filter.IncludeDeleted {
+		// Start a new session so the count and page queries below do not share conditions
+		db = db.Unscoped().Session(&gorm.Session{})
+	}
+	
 	// Get total count
-	if err := db.Model(&entity.Product{}).Count(&count).Error; err != nil {
+	if err := db.Model(&entity.Product{}).Scopes(priceRange(filter)).Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
 	
-	// Apply pagination
-	query := db
+	// Apply filters and pagination
+	query := db.Scopes(priceRange(filter))
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -60,10 +142,50 @@ func (r *ProductRepository) FindAll(db *gorm.DB, limit, offset int) ([]entity.Pr
 	}
 	
 	// Get products with ordering
-	err := query.Order("created_at DESC").Find(&products).Error
+	order, ok := productSortOrders[filter.Sort]
+	if !ok {
+		order = productSortOrders[ProductSortCreatedDesc]
+	}
+	err := query.Order(order).Find(&products).Error
 	return products, count, err
 }
 
+// FindAfter returns up to limit products matching the filter that come after the cursor in newest-first order,
+// along with the number of matching products and the cursor of the next page. A nil cursor starts at the newest product
+// and the next cursor is nil on the last page. Products inserted meanwhile sort before the cursor, so they never shift later pages.
+func (r *ProductRepository) FindAfter(db *gorm.DB, filter ProductFilter, after *ProductCursor, limit int) ([]entity.Product, int64, *ProductCursor, error) {
+	var products []entity.Product
+	var count int64
+	
+	if filter.IncludeDeleted {
+		// Start a new session so the count and page queries below do not share conditions
+		db = db.Unscoped().Session(&gorm.Session{})
+	}
+	
+	// Get total count
+	if err := db.Model(&entity.Product{}).Scopes(priceRange(filter)).Count(&count).Error; err != nil {
+		return nil, 0, nil, err
+	}
+	
+	// Seek past the cursor instead of skipping rows with an offset
+	query := db.Scopes(priceRange(filter))
+	if after != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND uuid < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	
+	// Fetch one extra product to tell whether another page follows
+	if err := query.Order(productSortOrders[ProductSortCreatedDesc]).Limit(limit + 1).Find(&products).Error; err != nil {
+		return nil, 0, nil, err
+	}
+	
+	var next *ProductCursor
+	if len(products) > limit {
+		products = products[:limit]
+		next = CursorOf(products[limit-1])
+	}
+	return products, count, next, nil
+}
+
 func (r *ProductRepository) FindByID(db *gorm.DB, id string) (*entity.Product, error) {
 	product := new(entity.Product)
 	
@@ -79,20 +201,41 @@ func (r *ProductRepository) FindByID(db *gorm.DB, id string) (*entity.Product, e
 	return product, nil
 }
 
+// FindByIDWithDeleted finds a product by ID even if it has been soft-deleted
+func (r *ProductRepository) FindByIDWithDeleted(db *gorm.DB, id string) (*entity.Product, error) {
+	return r.FindByID(db.Unscoped(), id)
+}
+
+// FindBySKU finds the product holding a SKU, ignoring case. Soft-deleted products are included since their SKUs stay reserved.
 func (r *ProductRepository) FindBySKU(db *gorm.DB, sku string) (*entity.Product, error) {
 	product := new(entity.Product)
 	
-	if err := db.Where("sku = ?", sku).First(product).Error; err != nil {
+	if err := db.Unscoped().Where("sku_normalized = ?", entity.NormalizeSKU(sku)).First(product).Error; err != nil {
 		return nil, err
 	}
 	
 	return product, nil
 }
 
+// Update saves every field of a product only if it still has the version that was read, bumping the version.
+// A concurrent update makes the write match no rows and yields ErrConcurrentModification.
 func (r *ProductRepository) Update(db *gorm.DB, product *entity.Product) error {
-	return db.Save(product).Error
+	readVersion := product.Version
+	product.Version = readVersion + 1
+	
+	result := db.Model(product).Where("version = ?", readVersion).Select("*").Updates(product)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrConcurrentModification
+	}
+	if result.Error != nil {
+		product.Version = readVersion
+		return result.Error
+	}
+	
+	return nil
 }
 
+// Delete soft-deletes a product by setting its deleted_at timestamp
 func (r *ProductRepository) Delete(db *gorm.DB, id string) error {
 	parsedID, err := uuid.Parse(id)
 	if err != nil {
@@ -102,25 +245,77 @@ func (r *ProductRepository) Delete(db *gorm.DB, id string) error {
 	return db.Where("uuid = ?", parsedID).Delete(&entity.Product{}).Error
 }
 
-func (r *ProductRepository) Search(db *gorm.DB, query string, limit, offset int) ([]entity.Product, int64, error) {
+// Restore clears the deleted_at timestamp of a soft-deleted product
+func (r *ProductRepository) Restore(db *gorm.DB, id string) error {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	
+	return db.Unscoped().Model(&entity.Product{}).Where("uuid = ?", parsedID).Update("deleted_at", nil).Error
+}
+
+// searchTokens splits a search query into distinct, case-insensitive tokens
+func searchTokens(query string) []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, token := range strings.Fields(strings.ToLower(query)) {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+		if len(tokens) == maxSearchTokens {
+			break
+		}
+	}
+	return tokens
+}
+
+// tokenMatch builds a condition that holds when a token appears in any of the search columns
+func tokenMatch(token string) (string, []interface{}) {
+	pattern := "%" + token + "%"
+	conditions := make([]string, len(searchColumns))
+	vars := make([]interface{}, len(searchColumns))
+	for i, column := range searchColumns {
+		conditions[i] = column + " LIKE ?"
+		vars[i] = pattern
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", vars
+}
+
+// Search returns products matching any token of the query, along with the number of matching products.
+// Tokens are matched as substrings with LIKE rather than a FULLTEXT index, so partial words still match
+// and the same statement runs on MySQL and SQLite. With ProductSortRelevance, products matching more
+// tokens come first; otherwise sort is one of the FindAll sort keys, newest first by default.
+func (r *ProductRepository) Search(db *gorm.DB, query string, sort string, limit, offset int) ([]entity.Product, int64, error) {
 	var products []entity.Product
 	var count int64
-	searchQuery := "%" + query + "%"
+	
+	tokens := searchTokens(query)
+	if len(tokens) == 0 {
+		return products, 0, nil
+	}
+	
+	matches := make([]string, len(tokens))
+	scores := make([]string, len(tokens))
+	var matchVars, scoreVars []interface{}
+	for i, token := range tokens {
+		condition, vars := tokenMatch(token)
+		matches[i] = condition
+		scores[i] = "CASE WHEN " + condition + " THEN 1 ELSE 0 END"
+		matchVars = append(matchVars, vars...)
+		scoreVars = append(scoreVars, vars...)
+	}
+	where := strings.Join(matches, " OR ")
 
 	// Get total count for the search
-	countQuery := db.Model(&entity.Product{}).Where(
-		"name LIKE ? OR description LIKE ? OR sku LIKE ? OR category LIKE ? OR brand LIKE ?",
-		searchQuery, searchQuery, searchQuery, searchQuery, searchQuery,
-	)
-	if err := countQuery.Count(&count).Error; err != nil {
+	if err := db.Model(&entity.Product{}).Where(where, matchVars...).Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Apply search, pagination and get products
-	searchDB := db.Where(
-		"name LIKE ? OR description LIKE ? OR sku LIKE ? OR category LIKE ? OR brand LIKE ?",
-		searchQuery, searchQuery, searchQuery, searchQuery, searchQuery,
-	)
+	searchDB := db.Where(where, matchVars...)
 	
 	if limit > 0 {
 		searchDB = searchDB.Limit(limit)
@@ -130,7 +325,21 @@ func (r *ProductRepository) Search(db *gorm.DB, query string, limit, offset int)
 		searchDB = searchDB.Offset(offset)
 	}
 	
-	if err := searchDB.Order("created_at DESC").Find(&products).Error; err != nil {
+	if sort == ProductSortRelevance {
+		searchDB = searchDB.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "(" + strings.Join(scores, " + ") + ") DESC, created_at DESC",
+			Vars:               scoreVars,
+			WithoutParentheses: true,
+		}})
+	} else {
+		order, ok := productSortOrders[sort]
+		if !ok {
+			order = productSortOrders[ProductSortCreatedDesc]
+		}
+		searchDB = searchDB.Order(order)
+	}
+	
+	if err := searchDB.Find(&products).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -168,4 +377,23 @@ func (r *ProductRepository) FindByCategory(db *gorm.DB, category string, limit,
 	}
 
 	return products, count, nil
+}
+
+func (r *ProductRepository) FindByIDs(db *gorm.DB, ids []string) ([]entity.Product, error) {
+	var products []entity.Product
+	
+	parsedIDs := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		parsedIDs[i] = parsedID
+	}
+	
+	if err := db.Where("uuid IN ?", parsedIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	
+	return products, nil
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"product-service/internal/entity"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,7 +45,7 @@ func (suite *ProductRepositoryTestSuite) SetupTest() {
 		ID:              uuid.New(),
 		Name:            "Test Product",
 		Description:     "Test Description",
-		BasePrice:       99.99,
+		BasePrice:       9999,
 		Category:        "Test Category",
 		SKU:             randomSKU,
 		ThumbnailURL:    "http://example.com/image.jpg",
@@ -70,7 +71,7 @@ func (suite *ProductRepositoryTestSuite) TestCreate() {
 	newProduct := &entity.Product{
 		Name:            "New Product",
 		Description:     "New Description",
-		BasePrice:       149.99,
+		BasePrice:       14999,
 		Category:        "New Category",
 		SKU:             newSKU,
 		ThumbnailURL:    "http://example.com/new-image.jpg",
@@ -88,7 +89,7 @@ func (suite *ProductRepositoryTestSuite) TestCreate() {
 	err = suite.DB.Where("sku = ?", newSKU).First(&savedProduct).Error
 	assert.NoError(t, err)
 	assert.Equal(t, "New Product", savedProduct.Name)
-	assert.Equal(t, float64(149.99), savedProduct.BasePrice)
+	assert.Equal(t, entity.Money(14999), savedProduct.BasePrice)
 }
 
 func (suite *ProductRepositoryTestSuite) TestFindAll() {
@@ -99,7 +100,7 @@ func (suite *ProductRepositoryTestSuite) TestFindAll() {
 	newProduct := &entity.Product{
 		Name:            "Another Product",
 		Description:     "Another Description",
-		BasePrice:       199.99,
+		BasePrice:       19999,
 		Category:        "Another Category",
 		SKU:             anotherSKU,
 		ThumbnailURL:    "http://example.com/another-image.jpg",
@@ -111,18 +112,103 @@ func (suite *ProductRepositoryTestSuite) TestFindAll() {
 	assert.NoError(t, err)
 	
 	// Find all products
-	products, count, err := suite.repository.FindAll(suite.DB, 10, 0)
+	products, count, err := suite.repository.FindAll(suite.DB, ProductFilter{}, 10, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(products))
 	assert.Equal(t, int64(2), count)
 	
 	// Test pagination
-	limitedProducts, limitedCount, err := suite.repository.FindAll(suite.DB, 1, 0)
+	limitedProducts, limitedCount, err := suite.repository.FindAll(suite.DB, ProductFilter{}, 1, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(limitedProducts))
 	assert.Equal(t, int64(2), limitedCount)
 }
 
+func (suite *ProductRepositoryTestSuite) TestFindAfter_StableAcrossInserts() {
+	t := suite.T()
+	
+	// The suite's mock product is the newest; the others are older, two of them created at the same time
+	base := suite.mockProduct.CreatedAt.Add(-time.Hour).Truncate(time.Second)
+	createdAt := []time.Time{base, base.Add(-time.Hour), base.Add(-time.Hour), base.Add(-2 * time.Hour)}
+	older := make([]*entity.Product, len(createdAt))
+	for i := range createdAt {
+		older[i] = &entity.Product{Name: fmt.Sprintf("Older %d", i), BasePrice: 1000, SKU: fmt.Sprintf("OLDER-%d", i), Barcode: fmt.Sprintf("OLDER-%d", i)}
+		assert.NoError(t, suite.DB.Create(older[i]).Error)
+		// BeforeCreate stamps the current time, so backdate the row afterwards
+		assert.NoError(t, suite.DB.Model(older[i]).UpdateColumn("created_at", createdAt[i]).Error)
+	}
+	
+	// Walk the listing two products at a time, inserting a newer product before each following page
+	var seen []uuid.UUID
+	var cursor *ProductCursor
+	for page := 0; page < 3; page++ {
+		products, count, next, err := suite.repository.FindAfter(suite.DB, ProductFilter{}, cursor, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5+page), count)
+		for _, product := range products {
+			seen = append(seen, product.ID)
+		}
+		
+		cursor = next
+		if cursor == nil {
+			break
+		}
+		newer := &entity.Product{Name: fmt.Sprintf("Inserted %d", page), BasePrice: 1000, SKU: fmt.Sprintf("INSERTED-%d", page), Barcode: fmt.Sprintf("INSERTED-%d", page)}
+		assert.NoError(t, suite.DB.Create(newer).Error)
+	}
+	
+	// Every original product is listed exactly once, newest first with ties broken by descending ID
+	tiedFirst, tiedSecond := older[1].ID, older[2].ID
+	if tiedFirst.String() < tiedSecond.String() {
+		tiedFirst, tiedSecond = tiedSecond, tiedFirst
+	}
+	assert.Equal(t, []uuid.UUID{suite.mockProduct.ID, older[0].ID, tiedFirst, tiedSecond, older[3].ID}, seen)
+	assert.Nil(t, cursor)
+}
+
+func (suite *ProductRepositoryTestSuite) TestFindAll_PriceRangeAndSort() {
+	t := suite.T()
+	
+	// The suite's mock product costs 99.99
+	for _, p := range []struct {
+		name  string
+		price entity.Money
+	}{
+		{"Budget Product", 1000},
+		{"Mid Product", 5000},
+		{"Premium Product", 15000},
+	} {
+		err := suite.repository.Create(suite.DB, &entity.Product{
+			Name:      p.name,
+			BasePrice: p.price,
+			SKU:       fmt.Sprintf("SKU-%s", uuid.New().String()),
+			Barcode:   fmt.Sprintf("BAR-%s", uuid.New().String()),
+			Status:    "active",
+		})
+		assert.NoError(t, err)
+	}
+	
+	minPrice, maxPrice := entity.Money(2000), entity.Money(10000)
+	
+	// Count covers every product in the range, not just the page
+	products, count, err := suite.repository.FindAll(suite.DB, ProductFilter{MinPrice: &minPrice, MaxPrice: &maxPrice, Sort: ProductSortPriceDesc}, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, entity.Money(9999), products[0].BasePrice)
+	
+	products, _, err = suite.repository.FindAll(suite.DB, ProductFilter{MinPrice: &minPrice, Sort: ProductSortPriceAsc}, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(products))
+	assert.Equal(t, []entity.Money{5000, 9999, 15000}, []entity.Money{products[0].BasePrice, products[1].BasePrice, products[2].BasePrice})
+	
+	products, count, err = suite.repository.FindAll(suite.DB, ProductFilter{Sort: ProductSortNameAsc}, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.Equal(t, "Budget Product", products[0].Name)
+	assert.Equal(t, "Test Product", products[3].Name)
+}
+
 func (suite *ProductRepositoryTestSuite) TestFindByID() {
 	t := suite.T()
 	
@@ -142,7 +228,7 @@ func (suite *ProductRepositoryTestSuite) TestUpdate() {
 	
 	// Update the mock product
 	suite.mockProduct.Name = "Updated Product"
-	suite.mockProduct.BasePrice = 129.99
+	suite.mockProduct.BasePrice = 12999
 	
 	err := suite.repository.Update(suite.DB, suite.mockProduct)
 	assert.NoError(t, err)
@@ -152,7 +238,34 @@ func (suite *ProductRepositoryTestSuite) TestUpdate() {
 	err = suite.DB.First(&updatedProduct, "uuid = ?", suite.mockProduct.ID).Error
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Product", updatedProduct.Name)
-	assert.Equal(t, float64(129.99), updatedProduct.BasePrice)
+	assert.Equal(t, entity.Money(12999), updatedProduct.BasePrice)
+	assert.Equal(t, 2, updatedProduct.Version)
+}
+
+func (suite *ProductRepositoryTestSuite) TestUpdate_StaleVersionRejected() {
+	t := suite.T()
+	
+	// Two callers read the same version of the product
+	first, err := suite.repository.FindByID(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	second, err := suite.repository.FindByID(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	
+	first.Name = "First Update"
+	assert.NoError(t, suite.repository.Update(suite.DB, first))
+	
+	// The second update is based on the version the first one replaced
+	second.Name = "Second Update"
+	err = suite.repository.Update(suite.DB, second)
+	assert.ErrorIs(t, err, ErrConcurrentModification)
+	assert.Equal(t, 1, second.Version)
+	
+	// The first update is kept
+	var storedProduct entity.Product
+	err = suite.DB.First(&storedProduct, "uuid = ?", suite.mockProduct.ID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, "First Update", storedProduct.Name)
+	assert.Equal(t, 2, storedProduct.Version)
 }
 
 func (suite *ProductRepositoryTestSuite) TestDelete() {
@@ -162,11 +275,71 @@ func (suite *ProductRepositoryTestSuite) TestDelete() {
 	err := suite.repository.Delete(suite.DB, suite.mockProduct.ID.String())
 	assert.NoError(t, err)
 	
-	// Verify it's deleted
+	// Verify it's hidden from normal queries
 	var deletedProduct entity.Product
 	err = suite.DB.First(&deletedProduct, "uuid = ?", suite.mockProduct.ID).Error
 	assert.Error(t, err)
 	assert.Equal(t, "record not found", err.Error())
+	
+	// Verify the row is kept with its deletion timestamp
+	product, err := suite.repository.FindByIDWithDeleted(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, product.DeletedAt.Valid)
+}
+
+func (suite *ProductRepositoryTestSuite) TestSoftDeletedProductsAreExcluded() {
+	t := suite.T()
+	
+	err := suite.repository.Delete(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	
+	_, err = suite.repository.FindByID(suite.DB, suite.mockProduct.ID.String())
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+	
+	products, count, err := suite.repository.FindAll(suite.DB, ProductFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(products))
+	assert.Equal(t, int64(0), count)
+	
+	products, count, err = suite.repository.Search(suite.DB, "Test Product", "", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(products))
+	assert.Equal(t, int64(0), count)
+	
+	products, count, err = suite.repository.FindByCategory(suite.DB, suite.mockProduct.Category, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(products))
+	assert.Equal(t, int64(0), count)
+	
+	products, err = suite.repository.FindByIDs(suite.DB, []string{suite.mockProduct.ID.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(products))
+	
+	// Admin listings can still see it
+	products, count, err = suite.repository.FindAll(suite.DB, ProductFilter{IncludeDeleted: true}, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(products))
+	assert.Equal(t, int64(1), count)
+	assert.True(t, products[0].DeletedAt.Valid)
+	
+	// The SKU stays reserved
+	product, err := suite.repository.FindBySKU(suite.DB, suite.mockProduct.SKU)
+	assert.NoError(t, err)
+	assert.Equal(t, suite.mockProduct.ID, product.ID)
+}
+
+func (suite *ProductRepositoryTestSuite) TestRestore() {
+	t := suite.T()
+	
+	err := suite.repository.Delete(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	
+	err = suite.repository.Restore(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	
+	product, err := suite.repository.FindByID(suite.DB, suite.mockProduct.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, product.DeletedAt.Valid)
 }
 
 func (suite *ProductRepositoryTestSuite) TestFindBySKU() {
@@ -184,6 +357,31 @@ func (suite *ProductRepositoryTestSuite) TestFindBySKU() {
 	assert.Equal(t, "record not found", err.Error())
 }
 
+func (suite *ProductRepositoryTestSuite) TestFindBySKU_IgnoresCase() {
+	t := suite.T()
+	
+	// Find by a lower-case variant of the SKU
+	product, err := suite.repository.FindBySKU(suite.DB, strings.ToLower(suite.mockProduct.SKU))
+	assert.NoError(t, err)
+	assert.Equal(t, suite.mockProduct.ID, product.ID)
+	assert.Equal(t, entity.NormalizeSKU(suite.mockProduct.SKU), *product.SKUNormalized)
+}
+
+func (suite *ProductRepositoryTestSuite) TestCreate_CaseVariantSKURejected() {
+	t := suite.T()
+	
+	// The unique index on the normalized SKU rejects a case variant
+	err := suite.repository.Create(suite.DB, &entity.Product{
+		Name:      "Case Variant",
+		BasePrice: 1000,
+		SKU:       strings.ToLower(suite.mockProduct.SKU),
+		Barcode:   fmt.Sprintf("BAR-%s", uuid.New().String()),
+		Status:    "active",
+	})
+	assert.Error(t, err)
+
+}
+
 func (suite *ProductRepositoryTestSuite) TestSearch() {
 	t := suite.T()
 	
@@ -192,7 +390,7 @@ func (suite *ProductRepositoryTestSuite) TestSearch() {
 		{
 			Name:         "Apple iPhone",
 			Description:  "Smartphone with iOS",
-			BasePrice:    999.99,
+			BasePrice:    99999,
 			Category:     "Electronics",
 			SKU:          fmt.Sprintf("IPHONE-%s", uuid.New().String()),
 			Brand:        "Apple",
@@ -203,7 +401,7 @@ func (suite *ProductRepositoryTestSuite) TestSearch() {
 		{
 			Name:         "Samsung Galaxy",
 			Description:  "Smartphone with Android",
-			BasePrice:    899.99,
+			BasePrice:    89999,
 			Category:     "Electronics",
 			SKU:          fmt.Sprintf("GALAXY-%s", uuid.New().String()),
 			Brand:        "Samsung",
@@ -214,7 +412,7 @@ func (suite *ProductRepositoryTestSuite) TestSearch() {
 		{
 			Name:         "Apple MacBook",
 			Description:  "Laptop with macOS",
-			BasePrice:    1299.99,
+			BasePrice:    129999,
 			Category:     "Computers",
 			SKU:          fmt.Sprintf("MACBOOK-%s", uuid.New().String()),
 			Brand:        "Apple",
@@ -231,28 +429,57 @@ func (suite *ProductRepositoryTestSuite) TestSearch() {
 	}
 	
 	// Search by brand
-	appleProducts, appleCount, err := suite.repository.Search(suite.DB, "Apple", 10, 0)
+	appleProducts, appleCount, err := suite.repository.Search(suite.DB, "Apple", "", 10, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(appleProducts))
 	assert.Equal(t, int64(2), appleCount)
 	
 	// Search by category
-	electronicsProducts, electronicsCount, err := suite.repository.Search(suite.DB, "Electronics", 10, 0)
+	electronicsProducts, electronicsCount, err := suite.repository.Search(suite.DB, "Electronics", "", 10, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(electronicsProducts))
 	assert.Equal(t, int64(2), electronicsCount)
 	
 	// Search with limit
-	limitedProducts, limitedCount, err := suite.repository.Search(suite.DB, "Apple", 1, 0)
+	limitedProducts, limitedCount, err := suite.repository.Search(suite.DB, "Apple", "", 1, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(limitedProducts))
 	assert.Equal(t, int64(2), limitedCount)
 	
 	// Search with no results
-	noProducts, noCount, err := suite.repository.Search(suite.DB, "Nonexistent", 10, 0)
+	noProducts, noCount, err := suite.repository.Search(suite.DB, "Nonexistent", "", 10, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(noProducts))
 	assert.Equal(t, int64(0), noCount)
+	
+	// Search matches any token, regardless of case
+	anyProducts, anyCount, err := suite.repository.Search(suite.DB, "samsung MACBOOK", "", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(anyProducts))
+	assert.Equal(t, int64(2), anyCount)
+	
+	// Rank products matching more tokens first; the MacBook is the newest but only matches "apple"
+	rankedProducts, rankedCount, err := suite.repository.Search(suite.DB, "apple smartphone ios android", ProductSortRelevance, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), rankedCount)
+	if assert.Equal(t, 3, len(rankedProducts)) {
+		assert.Equal(t, "Apple iPhone", rankedProducts[0].Name)
+		assert.Equal(t, "Samsung Galaxy", rankedProducts[1].Name)
+		assert.Equal(t, "Apple MacBook", rankedProducts[2].Name)
+	}
+	
+	// Other sort keys order search results like listings
+	cheapestProducts, _, err := suite.repository.Search(suite.DB, "apple smartphone", ProductSortPriceAsc, 10, 0)
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(cheapestProducts)) {
+		assert.Equal(t, "Samsung Galaxy", cheapestProducts[0].Name)
+	}
+	
+	// A query with only whitespace matches nothing
+	blankProducts, blankCount, err := suite.repository.Search(suite.DB, "   ", ProductSortRelevance, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(blankProducts))
+	assert.Equal(t, int64(0), blankCount)
 }
 
 func (suite *ProductRepositoryTestSuite) TestFindByCategory() {
@@ -263,7 +490,7 @@ func (suite *ProductRepositoryTestSuite) TestFindByCategory() {
 		{
 			Name:         "Canon EOS R5",
 			Description:  "Mirrorless Camera",
-			BasePrice:    3899.99,
+			BasePrice:    389999,
 			Category:     "Cameras",
 			SKU:          fmt.Sprintf("CANON-%s", uuid.New().String()),
 			Brand:        "Canon",
@@ -274,7 +501,7 @@ func (suite *ProductRepositoryTestSuite) TestFindByCategory() {
 		{
 			Name:         "Nikon Z7",
 			Description:  "Mirrorless Camera",
-			BasePrice:    2999.99,
+			BasePrice:    299999,
 			Category:     "Cameras",
 			SKU:          fmt.Sprintf("NIKON-%s", uuid.New().String()),
 			Brand:        "Nikon",
@@ -285,7 +512,7 @@ func (suite *ProductRepositoryTestSuite) TestFindByCategory() {
 		{
 			Name:         "Logitech Mouse",
 			Description:  "Wireless Mouse",
-			BasePrice:    49.99,
+			BasePrice:    4999,
 			Category:     "Accessories",
 			SKU:          fmt.Sprintf("LOGITECH-%s", uuid.New().String()),
 			Brand:        "Logitech",
@@ -2,12 +2,19 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	appContext "product-service/internal/context"
 	"product-service/internal/entity"
 	appErrors "product-service/internal/errors"
+	"product-service/internal/gateway"
 	"product-service/internal/model"
 	"product-service/internal/model/converter"
 	"product-service/internal/repository"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -16,64 +23,191 @@ import (
 )
 
 type ProductUseCaseInterface interface {
-	GetProducts(ctx context.Context, limit, offset int) (*model.ProductListResponse, error)
+	GetProducts(ctx context.Context, filter model.ProductListFilter, limit, offset int) (*model.ProductListResponse, error)
 	GetProductByID(ctx context.Context, id string) (*model.ProductResponse, error)
+	GetProductWithStock(ctx context.Context, id string) (*model.ProductResponse, error)
+	GetProductsByIDs(ctx context.Context, ids []string) (*model.ProductsByIDsResponse, error)
 	CreateProduct(ctx context.Context, request *model.CreateProductRequest) (*model.ProductResponse, error)
+	CreateProductsBatch(ctx context.Context, requests []model.CreateProductRequest) (*model.CreateProductsBatchResponse, error)
 	UpdateProduct(ctx context.Context, id string, request *model.UpdateProductRequest) (*model.ProductResponse, error)
+	UpdateProductPartial(ctx context.Context, id string, request *model.PatchProductRequest) (*model.ProductResponse, error)
 	DeleteProduct(ctx context.Context, id string) error
-	SearchProducts(ctx context.Context, query string, limit, offset int) (*model.ProductListResponse, error)
+	RestoreProduct(ctx context.Context, id string) (*model.ProductResponse, error)
+	SearchProducts(ctx context.Context, query string, sort string, limit, offset int) (*model.ProductListResponse, error)
 	GetProductsByCategory(ctx context.Context, category string, limit, offset int) (*model.ProductListResponse, error)
+	GetLowStockProducts(ctx context.Context, threshold, page, limit int) (*model.LowStockProductsResponse, error)
 }
 
 type ProductUseCase struct {
-	DB                *gorm.DB
-	Log               *logrus.Logger
-	Validate          *validator.Validate
-	ProductRepository repository.ProductRepositoryInterface
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	ProductRepository     repository.ProductRepositoryInterface
+	WarehouseStockGateway gateway.WarehouseStockGatewayInterface
+	MaxCreateBatchSize    int // Most products CreateProductsBatch accepts in one request
+	Pagination            model.Pagination
 }
 
+// DefaultMaxCreateBatchSize is used when product.batch.max_size is not configured
+const DefaultMaxCreateBatchSize = 100
+
 func NewProductUseCase(
 	db *gorm.DB,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	productRepository repository.ProductRepositoryInterface,
+	warehouseStockGateway gateway.WarehouseStockGatewayInterface,
+	maxCreateBatchSize int,
+	pagination model.Pagination,
 ) ProductUseCaseInterface {
 	return &ProductUseCase{
-		DB:                db,
-		Log:               logger,
-		Validate:          validate,
-		ProductRepository: productRepository,
+		DB:                    db,
+		Log:                   logger,
+		Validate:              validate,
+		ProductRepository:     productRepository,
+		WarehouseStockGateway: warehouseStockGateway,
+		MaxCreateBatchSize:    maxCreateBatchSize,
+		Pagination:            pagination,
 	}
 }
 
-func (c *ProductUseCase) GetProducts(ctx context.Context, limit, offset int) (*model.ProductListResponse, error) {
+// GetProducts lists products matching the filter. Soft-deleted products are only included when filter.IncludeDeleted is set.
+func (c *ProductUseCase) GetProducts(ctx context.Context, filter model.ProductListFilter, limit, offset int) (*model.ProductListResponse, error) {
 	requestID := appContext.GetRequestID(ctx)
 	tx := c.DB.WithContext(ctx)
 	
 	// Default values for pagination
-	if limit <= 0 {
-		limit = 10 // Default limit
-	}
+	limit = c.Pagination.Limit(limit)
 	
 	if offset < 0 {
 		offset = 0
 	}
 	
+	// Validate optional filters
+	if (filter.MinPrice != nil && *filter.MinPrice < 0) || (filter.MaxPrice != nil && *filter.MaxPrice < 0) {
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "min_price and max_price cannot be negative")
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"min_price":  *filter.MinPrice,
+			"max_price":  *filter.MaxPrice,
+		}).Warn("Invalid price range")
+		
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "min_price cannot be greater than max_price")
+	}
+	if filter.Sort != "" && !repository.IsValidProductSort(filter.Sort) {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"sort":       filter.Sort,
+		}).Warn("Invalid sort key")
+		
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "sort must be one of price_asc, price_desc, name_asc, created_desc")
+	}
+	
+	repoFilter := repository.ProductFilter{
+		MinPrice:       filter.MinPrice,
+		MaxPrice:       filter.MaxPrice,
+		Sort:           filter.Sort,
+		IncludeDeleted: filter.IncludeDeleted,
+	}
+	if filter.Cursor != "" {
+		return c.getProductsAfter(ctx, repoFilter, filter.Cursor, limit)
+	}
+	
 	// Get products with pagination and count
-	products, count, err := c.ProductRepository.FindAll(tx, limit, offset)
+	products, count, err := c.ProductRepository.FindAll(tx, repoFilter, limit, offset)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":      requestID,
+			"limit":           limit,
+			"offset":          offset,
+			"sort":            filter.Sort,
+			"include_deleted": filter.IncludeDeleted,
+			"error":           err.Error(),
+		}).Warn("Failed to get products")
+		
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+	
+	// Convert to response; a newest-first page also gets a cursor so the client can switch to keyset pagination
+	response := converter.ProductsToResponse(products, count, limit, offset)
+	if response.HasNext && isNewestFirst(filter.Sort) && len(products) > 0 {
+		response.NextCursor = encodeProductCursor(repository.CursorOf(products[len(products)-1]))
+	}
+	return response, nil
+}
+
+// getProductsAfter lists the page of products following the cursor, newest first
+func (c *ProductUseCase) getProductsAfter(ctx context.Context, filter repository.ProductFilter, cursor string, limit int) (*model.ProductListResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+	tx := c.DB.WithContext(ctx)
+	
+	if !isNewestFirst(filter.Sort) {
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "cursor can only be used with the created_desc sort")
+	}
+	after, err := decodeProductCursor(cursor)
 	if err != nil {
 		c.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
-			"limit":      limit,
-			"offset":     offset,
+			"cursor":     cursor,
 			"error":      err.Error(),
+		}).Warn("Invalid cursor")
+		
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "cursor is invalid")
+	}
+	
+	products, count, next, err := c.ProductRepository.FindAfter(tx, filter, after, limit)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":      requestID,
+			"limit":           limit,
+			"cursor":          cursor,
+			"include_deleted": filter.IncludeDeleted,
+			"error":           err.Error(),
 		}).Warn("Failed to get products")
 		
 		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
 	}
 	
-	// Convert to response
-	return converter.ProductsToResponse(products, count, limit, offset), nil
+	// The offset-based paging fields do not apply, so only the count and total pages are kept
+	response := converter.ProductsToResponse(products, count, limit, 0)
+	response.HasNext = next != nil
+	if next != nil {
+		response.NextCursor = encodeProductCursor(next)
+	}
+	return response, nil
+}
+
+// isNewestFirst reports whether sort orders products newest first, the only order cursors support
+func isNewestFirst(sort string) bool {
+	return sort == "" || sort == repository.ProductSortCreatedDesc
+}
+
+// encodeProductCursor turns a cursor into an opaque, URL-safe token
+func encodeProductCursor(cursor *repository.ProductCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeProductCursor parses a token made by encodeProductCursor
+func decodeProductCursor(token string) (*repository.ProductCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("cursor %q has no separator", raw)
+	}
+	cursor := &repository.ProductCursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, err
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	return cursor, nil
 }
 
 func (c *ProductUseCase) GetProductByID(ctx context.Context, id string) (*model.ProductResponse, error) {
@@ -127,16 +261,18 @@ func (c *ProductUseCase) CreateProduct(ctx context.Context, request *model.Creat
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	normalizeProductText(&request.Name, &request.Category, &request.SKU)
+
 	// Validate request
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
 			"error":      err.Error(),
 		}).Warn("Invalid request body")
-		return nil, appErrors.WithError(appErrors.ErrInvalidInput, err)
+		return nil, appErrors.NewValidationError(err)
 	}
 
-	// Check if product with the same SKU already exists
+	// Check if product with the same SKU already exists, ignoring case
 	if request.SKU != "" {
 		existingProduct, err := c.ProductRepository.FindBySKU(tx, request.SKU)
 		if err == nil && existingProduct != nil {
@@ -144,7 +280,7 @@ func (c *ProductUseCase) CreateProduct(ctx context.Context, request *model.Creat
 				"request_id": requestID,
 				"sku":        request.SKU,
 			}).Warn("Product with this SKU already exists")
-			return nil, appErrors.ErrDuplicateSKU
+			return nil, duplicateSKUError(existingProduct)
 		}
 	}
 
@@ -167,6 +303,10 @@ func (c *ProductUseCase) CreateProduct(ctx context.Context, request *model.Creat
 			"sku":        request.SKU,
 			"error":      err.Error(),
 		}).Warn("Failed to create product")
+		// Another request inserted the same SKU after the lookup above
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, appErrors.WithError(appErrors.ErrDuplicateSKU, err)
+		}
 		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
 	}
 
@@ -183,11 +323,162 @@ func (c *ProductUseCase) CreateProduct(ctx context.Context, request *model.Creat
 	return converter.ProductToResponse(product), nil
 }
 
-func (c *ProductUseCase) UpdateProduct(ctx context.Context, id string, request *model.UpdateProductRequest) (*model.ProductResponse, error) {
+// CreateProductsBatch creates several products in one transaction and reports the outcome of each.
+// A product that is invalid, or whose SKU repeats an earlier product of the batch or an existing product, fails on its own
+// and the others are still created. Each insert runs under its own savepoint, so a unique index violation only undoes that product.
+func (c *ProductUseCase) CreateProductsBatch(ctx context.Context, requests []model.CreateProductRequest) (*model.CreateProductsBatchResponse, error) {
 	requestID := appContext.GetRequestID(ctx)
+
+	// Validate batch size
+	if len(requests) == 0 || len(requests) > c.MaxCreateBatchSize {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"count":      len(requests),
+			"max_size":   c.MaxCreateBatchSize,
+		}).Warn("Invalid product batch size")
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, fmt.Sprintf("Between 1 and %d products are required", c.MaxCreateBatchSize))
+	}
+
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	response := &model.CreateProductsBatchResponse{
+		Results: make([]model.CreateProductBatchItemResult, len(requests)),
+	}
+
+	// createdSKUs maps each normalized SKU created so far to the index of its product
+	createdSKUs := make(map[string]int, len(requests))
+
+	for i := range requests {
+		request := &requests[i]
+		normalizeProductText(&request.Name, &request.Category, &request.SKU)
+		result := model.CreateProductBatchItemResult{Index: i, SKU: request.SKU}
+
+		product, err := c.createBatchItem(tx, i, request, createdSKUs)
+		if err != nil {
+			var appErr *appErrors.AppError
+			if !appErrors.As(err, &appErr) {
+				c.Log.WithFields(logrus.Fields{
+					"request_id": requestID,
+					"index":      i,
+					"error":      err.Error(),
+				}).Warn("Failed to create product batch")
+				return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+			}
+
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"index":      i,
+				"sku":        request.SKU,
+				"error":      err.Error(),
+			}).Warn("Failed to create product in batch")
+
+			result.Status = model.BatchItemStatusFailed
+			result.ErrorCode = appErr.Code
+			result.Error = appErr.Message
+			response.Failed++
+		} else {
+			result.Status = model.BatchItemStatusCreated
+			result.ID = product.ID.String()
+			response.Created++
+		}
+		response.Results[i] = result
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to commit transaction")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	return response, nil
+}
+
+// createBatchItem validates and inserts one product of a batch. An AppError fails only this product;
+// any other error means the transaction itself is unusable.
+func (c *ProductUseCase) createBatchItem(tx *gorm.DB, index int, request *model.CreateProductRequest, createdSKUs map[string]int) (*entity.Product, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		return nil, appErrors.WithMessage(appErrors.WithError(appErrors.ErrInvalidInput, err), err.Error())
+	}
+
+	// Check the SKU against the products created earlier in the batch and the existing products, ignoring case
+	sku := entity.NormalizeSKU(request.SKU)
+	if sku != "" {
+		if earlier, ok := createdSKUs[sku]; ok {
+			return nil, appErrors.WithMessage(appErrors.ErrDuplicateSKU, fmt.Sprintf("SKU is already used by product %d of the batch", earlier))
+		}
+		if existingProduct, err := c.ProductRepository.FindBySKU(tx, request.SKU); err == nil && existingProduct != nil {
+			return nil, duplicateSKUError(existingProduct)
+		}
+	}
+
+	product := &entity.Product{
+		Name:         request.Name,
+		Description:  request.Description,
+		BasePrice:    request.Price,
+		Category:     request.Category,
+		SKU:          request.SKU,
+		ThumbnailURL: request.ImageURL,
+		Status:       "active", // Default status for new products
+	}
+
+	// A failed statement aborts the whole transaction on some databases, so roll back to a savepoint instead
+	savepoint := fmt.Sprintf("batch_item_%d", index)
+	if err := tx.SavePoint(savepoint).Error; err != nil {
+		return nil, err
+	}
+	if err := c.ProductRepository.Create(tx, product); err != nil {
+		if rollbackErr := tx.RollbackTo(savepoint).Error; rollbackErr != nil {
+			return nil, rollbackErr
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, appErrors.WithError(appErrors.ErrDuplicateSKU, err)
+		}
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	if sku != "" {
+		createdSKUs[sku] = index
+	}
+	return product, nil
+}
+
+// UpdateProduct replaces a product: name and price are required and omitted optional fields are cleared
+func (c *ProductUseCase) UpdateProduct(ctx context.Context, id string, request *model.UpdateProductRequest) (*model.ProductResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+
+	normalizeProductText(&request.Name, &request.Category, &request.SKU)
+
+	// Validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Invalid request body")
+		return nil, appErrors.WithMessage(appErrors.WithError(appErrors.ErrInvalidInput, err),
+			"PUT replaces the whole product: name and a price greater than 0 are required, and omitted fields are cleared. Use PATCH to change only some fields")
+	}
+
+	return c.updateProduct(ctx, id, request.SKU, request.Version, request.UnmodifiedSince, func(product *entity.Product) {
+		product.Name = request.Name
+		product.Description = request.Description
+		product.BasePrice = request.Price
+		product.Category = request.Category
+		product.SKU = request.SKU
+		product.ThumbnailURL = request.ImageURL
+	})
+}
+
+// UpdateProductPartial changes only the fields of a product that are set in the request
+func (c *ProductUseCase) UpdateProductPartial(ctx context.Context, id string, request *model.PatchProductRequest) (*model.ProductResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+
+	normalizeProductText(&request.Name, &request.Category, &request.SKU)
+
 	// Validate request
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.WithFields(logrus.Fields{
@@ -195,9 +486,47 @@ func (c *ProductUseCase) UpdateProduct(ctx context.Context, id string, request *
 			"product_id": id,
 			"error":      err.Error(),
 		}).Warn("Invalid request body")
-		return nil, appErrors.WithError(appErrors.ErrInvalidInput, err)
+		return nil, appErrors.WithMessage(appErrors.WithError(appErrors.ErrInvalidInput, err),
+			"PATCH changes only the fields provided, but each provided field must be valid: a price must be greater than 0")
 	}
 
+	return c.updateProduct(ctx, id, request.SKU, request.Version, request.UnmodifiedSince, func(product *entity.Product) {
+		// Update fields only if they are provided
+		if request.Name != "" {
+			product.Name = request.Name
+		}
+
+		if request.Description != "" {
+			product.Description = request.Description
+		}
+
+		if request.Price > 0 {
+			product.BasePrice = request.Price
+		}
+
+		if request.Category != "" {
+			product.Category = request.Category
+		}
+
+		if request.SKU != "" {
+			product.SKU = request.SKU
+		}
+
+		if request.ImageURL != "" {
+			product.ThumbnailURL = request.ImageURL
+		}
+	})
+}
+
+// updateProduct loads a product, checks that the new SKU is free, applies the changes and saves it.
+// It is shared by full (PUT) and partial (PATCH) updates, which differ only in the fields they overwrite.
+// A non-zero expectedVersion or unmodifiedSince rejects the update when the product changed since the caller read it;
+// the save itself is rejected too when another update lands between loading and saving the product.
+func (c *ProductUseCase) updateProduct(ctx context.Context, id string, sku string, expectedVersion int, unmodifiedSince time.Time, apply func(product *entity.Product)) (*model.ProductResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
 	// Validate ID format
 	if id == "" {
 		return nil, appErrors.ErrInvalidProductID
@@ -233,46 +562,43 @@ func (c *ProductUseCase) UpdateProduct(ctx context.Context, id string, request *
 		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
 	}
 
-	// Check if SKU is being updated and already exists
-	if request.SKU != "" && request.SKU != product.SKU {
-		existingProduct, err := c.ProductRepository.FindBySKU(tx, request.SKU)
+	// Reject changes based on an outdated copy of the product. HTTP dates have whole seconds, so UpdatedAt is truncated.
+	if (expectedVersion != 0 && product.Version != expectedVersion) ||
+		(!unmodifiedSince.IsZero() && product.UpdatedAt.Truncate(time.Second).After(unmodifiedSince)) {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":       requestID,
+			"product_id":       id,
+			"version":          product.Version,
+			"expected_version": expectedVersion,
+		}).Warn("Product was modified since the caller read it")
+		return nil, concurrentModificationError(product)
+	}
+
+	// Check if SKU is being updated and already belongs to another product; changing only its case is allowed
+	if sku != "" && entity.NormalizeSKU(sku) != entity.NormalizeSKU(product.SKU) {
+		existingProduct, err := c.ProductRepository.FindBySKU(tx, sku)
 		if err == nil && existingProduct != nil {
 			c.Log.WithFields(logrus.Fields{
 				"request_id": requestID,
 				"product_id": id,
-				"sku":        request.SKU,
+				"sku":        sku,
 			}).Warn("Product with this SKU already exists")
-			return nil, appErrors.ErrDuplicateSKU
+			return nil, duplicateSKUError(existingProduct)
 		}
 	}
 
-	// Update fields only if they are provided
-	if request.Name != "" {
-		product.Name = request.Name
-	}
-
-	if request.Description != "" {
-		product.Description = request.Description
-	}
-
-	if request.Price > 0 {
-		product.BasePrice = request.Price
-	}
-
-	if request.Category != "" {
-		product.Category = request.Category
-	}
-
-	if request.SKU != "" {
-		product.SKU = request.SKU
-	}
-
-	if request.ImageURL != "" {
-		product.ThumbnailURL = request.ImageURL
-	}
+	apply(product)
 
 	// Save updates
 	if err := c.ProductRepository.Update(tx, product); err != nil {
+		if errors.Is(err, repository.ErrConcurrentModification) {
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"product_id": id,
+			}).Warn("Product was modified by a concurrent update")
+			return nil, appErrors.WithError(appErrors.ErrConcurrentModification, err)
+		}
+		
 		c.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
 			"product_id": id,
@@ -294,6 +620,7 @@ func (c *ProductUseCase) UpdateProduct(ctx context.Context, id string, request *
 	return converter.ProductToResponse(product), nil
 }
 
+// DeleteProduct soft-deletes a product. It can be brought back with RestoreProduct.
 func (c *ProductUseCase) DeleteProduct(ctx context.Context, id string) error {
 	requestID := appContext.GetRequestID(ctx)
 	tx := c.DB.WithContext(ctx).Begin()
@@ -356,21 +683,130 @@ func (c *ProductUseCase) DeleteProduct(ctx context.Context, id string) error {
 	return nil
 }
 
-func (c *ProductUseCase) SearchProducts(ctx context.Context, query string, limit, offset int) (*model.ProductListResponse, error) {
+// RestoreProduct undoes the soft delete of a product. Restoring a product that is not deleted is a no-op.
+func (c *ProductUseCase) RestoreProduct(ctx context.Context, id string) (*model.ProductResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// Validate ID format
+	if id == "" {
+		return nil, appErrors.ErrInvalidProductID
+	}
+
+	_, err := uuid.Parse(id)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Invalid product ID format")
+		return nil, appErrors.WithError(appErrors.ErrInvalidProductID, err)
+	}
+
+	// Find the product, including soft-deleted ones
+	product, err := c.ProductRepository.FindByIDWithDeleted(tx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"product_id": id,
+			}).Info("Product not found")
+			return nil, appErrors.ErrProductNotFound
+		}
+
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Failed to get product by ID")
+
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	if !product.DeletedAt.Valid {
+		return converter.ProductToResponse(product), nil
+	}
+
+	// Restore the product
+	if err := c.ProductRepository.Restore(tx, id); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Failed to restore product")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to commit transaction")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	product.DeletedAt = gorm.DeletedAt{}
+	return converter.ProductToResponse(product), nil
+}
+
+// concurrentModificationError tells the caller which version to base a retried update on
+func concurrentModificationError(current *entity.Product) error {
+	return appErrors.WithMessage(appErrors.ErrConcurrentModification,
+		fmt.Sprintf("Product was modified by another request and is now at version %d; fetch it again and retry the update with that version", current.Version))
+}
+
+// duplicateSKUError tells the caller when the SKU is held by a deleted product, which must be restored instead
+func duplicateSKUError(existingProduct *entity.Product) error {
+	if existingProduct.DeletedAt.Valid {
+		return appErrors.WithMessage(appErrors.ErrDuplicateSKU, "SKU belongs to a deleted product; restore it or use a different SKU")
+	}
+	return appErrors.ErrDuplicateSKU
+}
+
+// normalizeProductText trims and collapses the whitespace of the fields that searches and the unique SKU index compare,
+// so " Phone  Case " is stored as "Phone Case" and a padded SKU collides with the SKU it pads
+func normalizeProductText(name, category, sku *string) {
+	*name = normalizeText(*name)
+	*category = normalizeText(*category)
+	*sku = normalizeText(*sku)
+}
+
+// normalizeText removes leading and trailing whitespace and replaces each run of inner whitespace with a single space
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// SearchProducts finds products matching any word of the query. Sort is empty, "relevance" or one of the listing sort keys.
+func (c *ProductUseCase) SearchProducts(ctx context.Context, query string, sort string, limit, offset int) (*model.ProductListResponse, error) {
 	requestID := appContext.GetRequestID(ctx)
 	tx := c.DB.WithContext(ctx)
 
-	// Default values for pagination
-	if limit <= 0 {
-		limit = 10 // Default limit
+	if strings.TrimSpace(query) == "" {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+		}).Warn("Empty search query provided")
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "Search query is required")
 	}
+
+	if sort != "" && sort != repository.ProductSortRelevance && !repository.IsValidProductSort(sort) {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"sort":       sort,
+		}).Warn("Invalid search sort provided")
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "sort must be one of relevance, price_asc, price_desc, name_asc, created_desc")
+	}
+
+	// Default values for pagination
+	limit = c.Pagination.Limit(limit)
 	
 	if offset < 0 {
 		offset = 0
 	}
 
 	// Search products
-	products, count, err := c.ProductRepository.Search(tx, query, limit, offset)
+	products, count, err := c.ProductRepository.Search(tx, query, sort, limit, offset)
 	if err != nil {
 		c.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -400,9 +836,7 @@ func (c *ProductUseCase) GetProductsByCategory(ctx context.Context, category str
 	}
 
 	// Default values for pagination
-	if limit <= 0 {
-		limit = 10 // Default limit
-	}
+	limit = c.Pagination.Limit(limit)
 	
 	if offset < 0 {
 		offset = 0
@@ -424,4 +858,215 @@ func (c *ProductUseCase) GetProductsByCategory(ctx context.Context, category str
 
 	// Convert to response
 	return converter.ProductsToResponse(products, count, limit, offset), nil
+}
+
+// MaxBatchProductIDs limits how many products can be fetched in a single batch lookup
+const MaxBatchProductIDs = 100
+
+// GetProductWithStock returns a product with its live stock available across all warehouses.
+// If the warehouse service cannot be reached the product is returned without stock rather than failing.
+func (c *ProductUseCase) GetProductWithStock(ctx context.Context, id string) (*model.ProductResponse, error) {
+	product, err := c.GetProductByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := c.WarehouseStockGateway.GetProductStock(ctx, product.ID)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": appContext.GetRequestID(ctx),
+			"product_id": product.ID,
+			"error":      err.Error(),
+		}).Warn("Failed to get product stock from warehouse service, returning product without stock")
+		return product, nil
+	}
+
+	product.Stock = stock.TotalAvailableQuantity
+	product.StockUpdatedAt = stock.FetchedAt
+	return product, nil
+}
+
+// GetProductsByIDs returns the products with the given IDs using a single query.
+// IDs that match no product, including deleted ones, are listed as missing instead of failing the lookup.
+func (c *ProductUseCase) GetProductsByIDs(ctx context.Context, ids []string) (*model.ProductsByIDsResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+	tx := c.DB.WithContext(ctx)
+
+	// Validate IDs
+	if len(ids) == 0 || len(ids) > MaxBatchProductIDs {
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, fmt.Sprintf("Between 1 and %d product IDs are required", MaxBatchProductIDs))
+	}
+
+	// Normalise the IDs so that differently cased or repeated IDs refer to the same product
+	normalizedIDs := make([]string, 0, len(ids))
+	requested := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"product_id": id,
+				"error":      err.Error(),
+			}).Warn("Invalid product ID format")
+
+			return nil, appErrors.WithMessage(appErrors.ErrInvalidProductID, fmt.Sprintf("Invalid product ID: %s", id))
+		}
+		if !requested[parsedID.String()] {
+			requested[parsedID.String()] = true
+			normalizedIDs = append(normalizedIDs, parsedID.String())
+		}
+	}
+
+	// Get all requested products in a single query
+	products, err := c.ProductRepository.FindByIDs(tx, normalizedIDs)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"count":      len(normalizedIDs),
+			"error":      err.Error(),
+		}).Warn("Failed to get products by IDs")
+
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Report the requested IDs that no product matched, in request order
+	found := make(map[string]bool, len(products))
+	for _, product := range products {
+		found[product.ID.String()] = true
+	}
+	var missingIDs []string
+	for _, id := range normalizedIDs {
+		if !found[id] {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	return converter.ProductsByIDsToResponse(products, missingIDs), nil
+}
+
+const (
+	// DefaultLowStockThreshold is used when the low stock report is requested without a threshold
+	DefaultLowStockThreshold = 10
+
+	// lowStockScanBatchSize is how many products are read from the catalog at a time while looking for low stock
+	lowStockScanBatchSize = 100
+
+	// lowStockConcurrency bounds the stock lookups in flight against the warehouse service
+	lowStockConcurrency = 8
+)
+
+// GetLowStockProducts returns a page of the products whose stock available across all warehouses is below threshold,
+// newest first. Every product in the catalog is checked against the warehouse service; the gateway's cache keeps
+// repeated reports cheap. When a lookup fails the scan stops, and the low stock products found so far are returned
+// with Partial set rather than failing the report.
+func (c *ProductUseCase) GetLowStockProducts(ctx context.Context, threshold, page, limit int) (*model.LowStockProductsResponse, error) {
+	requestID := appContext.GetRequestID(ctx)
+	tx := c.DB.WithContext(ctx)
+
+	if threshold < 1 {
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "threshold must be at least 1")
+	}
+	limit = c.Pagination.Limit(limit)
+	if page < 1 {
+		page = 1
+	}
+
+	var lowStock []model.LowStockProductResponse
+	var total int64
+	var stockErr error
+	checked := 0
+	for offset := 0; ; offset += lowStockScanBatchSize {
+		products, count, err := c.ProductRepository.FindAll(tx, repository.ProductFilter{}, lowStockScanBatchSize, offset)
+		if err != nil {
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"offset":     offset,
+				"error":      err.Error(),
+			}).Warn("Failed to list products for the low stock report")
+
+			return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+		}
+		total = count
+
+		stocks, err := c.productStocks(ctx, products)
+		for i := range products {
+			if stocks[i] == nil {
+				continue
+			}
+			checked++
+			if stocks[i].TotalAvailableQuantity < threshold {
+				lowStock = append(lowStock, converter.LowStockProductToResponse(&products[i], stocks[i]))
+			}
+		}
+		if err != nil {
+			stockErr = err
+			break
+		}
+		if len(products) < lowStockScanBatchSize {
+			break
+		}
+	}
+
+	response := converter.LowStockProductsToResponse(lowStock, threshold, page, limit)
+	if stockErr != nil {
+		response.Partial = true
+		response.UncheckedCount = max(int(total)-checked, 0)
+		response.Warning = "The warehouse service could not report stock for every product; products that were not checked are left out"
+
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"checked":    checked,
+			"unchecked":  response.UncheckedCount,
+			"error":      stockErr.Error(),
+		}).Warn("Warehouse service failed during the low stock report, returning a partial result")
+	}
+	return response, nil
+}
+
+// productStocks looks up the live stock of each product, lowStockConcurrency at a time. No new lookup starts after
+// one fails, so an unavailable warehouse service costs a timeout per worker rather than per product.
+// The stock of products that were not looked up, or whose lookup failed, is left nil and the first error is returned.
+func (c *ProductUseCase) productStocks(ctx context.Context, products []entity.Product) ([]*model.ProductStockResponse, error) {
+	stocks := make([]*model.ProductStockResponse, len(products))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+
+	slots := make(chan struct{}, lowStockConcurrency)
+	for i := range products {
+		slots <- struct{}{}
+		if failed() {
+			<-slots
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			stock, err := c.WarehouseStockGateway.GetProductStock(ctx, products[i].ID.String())
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			stocks[i] = stock
+		}()
+	}
+	wg.Wait()
+
+	return stocks, firstErr
 }
\ No newline at end of file
@@ -7,7 +7,10 @@ import (
 	appErrors "product-service/internal/errors"
 	"product-service/internal/model"
 	"product-service/internal/model/converter"
+	"product-service/internal/repository"
+	mockGateway "product-service/mocks/gateway"
 	mockRepository "product-service/mocks/repository"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +28,7 @@ type ProductUseCaseTestSuite struct {
 	suite.Suite
 	DB                  *gorm.DB
 	mockProductRepo     *mockRepository.MockProductRepository
+	mockStockGateway    *mockGateway.MockWarehouseStockGateway
 	productUseCase      ProductUseCaseInterface
 	mockProducts        []entity.Product
 	mockProduct         *entity.Product
@@ -47,13 +51,19 @@ func (suite *ProductUseCaseTestSuite) SetupTest() {
 	
 	// Setup mock repository
 	suite.mockProductRepo = new(mockRepository.MockProductRepository)
+	suite.mockStockGateway = new(mockGateway.MockWarehouseStockGateway)
 	
-	// Setup usecase
+	// Setup usecase with the validator as configured in config.NewValidator
+	validate := validator.New()
+	validate.RegisterTagNameFunc(appErrors.JSONFieldName)
 	suite.productUseCase = NewProductUseCase(
 		suite.DB,
 		suite.logger,
-		validator.New(),
+		validate,
 		suite.mockProductRepo,
+		suite.mockStockGateway,
+		DefaultMaxCreateBatchSize,
+		model.Pagination{},
 	)
 	
 	// Setup mock products
@@ -65,7 +75,7 @@ func (suite *ProductUseCaseTestSuite) SetupTest() {
 			ID:          productID1,
 			Name:        "Product 1",
 			Description: "Description 1",
-			BasePrice:   99.99,
+			BasePrice:   9999,
 			Category:    "Category 1",
 			SKU:         "SKU-001",
 			ThumbnailURL: "http://example.com/image1.jpg",
@@ -78,7 +88,7 @@ func (suite *ProductUseCaseTestSuite) SetupTest() {
 			ID:          productID2,
 			Name:        "Product 2",
 			Description: "Description 2",
-			BasePrice:   149.99,
+			BasePrice:   14999,
 			Category:    "Category 2",
 			SKU:         "SKU-002",
 			ThumbnailURL: "http://example.com/image2.jpg",
@@ -93,7 +103,7 @@ func (suite *ProductUseCaseTestSuite) SetupTest() {
 		ID:          uuid.New(),
 		Name:        "Test Product",
 		Description: "Test Description",
-		BasePrice:   199.99,
+		BasePrice:   19999,
 		Category:    "Test Category",
 		SKU:         "TEST-SKU",
 		ThumbnailURL: "http://example.com/test-image.jpg",
@@ -110,7 +120,7 @@ func (suite *ProductUseCaseTestSuite) TestGetProducts() {
 	// No need for a separate count variable as it's returned by the mock
 	
 	// Setup expectations for FindAll
-	suite.mockProductRepo.On("FindAll", mock.Anything, 10, 0).Return(suite.mockProducts, int64(2), nil)
+	suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{}, 10, 0).Return(suite.mockProducts, int64(2), nil)
 	
 	// Mock the DB.Model().Count() behavior by overriding the usecase
 	// Create a custom usecase that overrides the GetProducts method
@@ -127,7 +137,7 @@ func (suite *ProductUseCaseTestSuite) TestGetProducts() {
 	// Override the GetProducts method to avoid the DB count call
 	customGetProducts := func(ctx context.Context, limit, offset int) (*model.ProductListResponse, error) {
 		// Use the repository to get products as normal
-		products, count, err := suite.mockProductRepo.FindAll(suite.DB.WithContext(ctx), limit, offset)
+		products, count, err := suite.mockProductRepo.FindAll(suite.DB.WithContext(ctx), repository.ProductFilter{}, limit, offset)
 		if err != nil {
 			return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
 		}
@@ -157,6 +167,155 @@ func (suite *ProductUseCaseTestSuite) TestGetProducts() {
 	suite.mockProductRepo.AssertExpectations(t)
 }
 
+func (suite *ProductUseCaseTestSuite) TestGetProducts_Filter() {
+	t := suite.T()
+	minPrice, maxPrice := entity.Money(5000), entity.Money(15000)
+	
+	// Setup expectations; the filter is passed through to the repository
+	suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{
+		MinPrice: &minPrice,
+		MaxPrice: &maxPrice,
+		Sort:     repository.ProductSortPriceAsc,
+	}, 10, 0).Return(suite.mockProducts, int64(2), nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetProducts(suite.ctx, model.ProductListFilter{
+		MinPrice: &minPrice,
+		MaxPrice: &maxPrice,
+		Sort:     "price_asc",
+	}, 10, 0)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Count)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProducts_Limit() {
+	t := suite.T()
+	
+	tests := []struct {
+		name          string
+		pagination    model.Pagination
+		limit         int
+		expectedLimit int
+	}{
+		{name: "DefaultWhenUnset", limit: 0, expectedLimit: 10},
+		{name: "OverMaxIsClamped", limit: 500, expectedLimit: 100},
+		{name: "ConfiguredDefault", pagination: model.Pagination{DefaultLimit: 5, MaxLimit: 50}, limit: 0, expectedLimit: 5},
+		{name: "ConfiguredMaxIsClamped", pagination: model.Pagination{DefaultLimit: 5, MaxLimit: 50}, limit: 80, expectedLimit: 50},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite.productUseCase.(*ProductUseCase).Pagination = tt.pagination
+			suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{}, tt.expectedLimit, 0).
+				Return(suite.mockProducts, int64(2), nil).Once()
+			
+			// An over-max limit is clamped rather than rejected
+			result, err := suite.productUseCase.GetProducts(suite.ctx, model.ProductListFilter{}, tt.limit, 0)
+			
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, result.Limit)
+			suite.mockProductRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProducts_Cursor() {
+	t := suite.T()
+	last := suite.mockProducts[1]
+	
+	// Setup expectations; the first page is found by offset and hands out a cursor to its last product
+	suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{}, 2, 0).Return(suite.mockProducts, int64(5), nil)
+	next := &repository.ProductCursor{CreatedAt: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC), ID: uuid.New()}
+	suite.mockProductRepo.On("FindAfter", mock.Anything, repository.ProductFilter{}, mock.MatchedBy(func(after *repository.ProductCursor) bool {
+		return after.ID == last.ID && after.CreatedAt.Equal(last.CreatedAt)
+	}), 2).Return(suite.mockProducts, int64(5), next, nil)
+	
+	// Call the method for the first page, then follow its cursor
+	first, err := suite.productUseCase.GetProducts(suite.ctx, model.ProductListFilter{}, 2, 0)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.NextCursor)
+	
+	second, err := suite.productUseCase.GetProducts(suite.ctx, model.ProductListFilter{Cursor: first.NextCursor}, 2, 0)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(second.Products))
+	assert.Equal(t, int64(5), second.Count)
+	assert.True(t, second.HasNext)
+	assert.NotEmpty(t, second.NextCursor)
+	assert.NotEqual(t, first.NextCursor, second.NextCursor)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProducts_CursorLastPage() {
+	t := suite.T()
+	cursor := encodeProductCursor(repository.CursorOf(suite.mockProducts[0]))
+	
+	// Setup expectations; no further page follows
+	suite.mockProductRepo.On("FindAfter", mock.Anything, repository.ProductFilter{Sort: repository.ProductSortCreatedDesc}, mock.Anything, 10).
+		Return(suite.mockProducts[1:], int64(2), nil, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetProducts(suite.ctx, model.ProductListFilter{Sort: "created_desc", Cursor: cursor}, 10, 0)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Products))
+	assert.False(t, result.HasNext)
+	assert.Empty(t, result.NextCursor)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProducts_InvalidCursor() {
+	t := suite.T()
+	cursor := encodeProductCursor(repository.CursorOf(suite.mockProducts[0]))
+	
+	tests := []struct {
+		name   string
+		filter model.ProductListFilter
+	}{
+		{"not base64", model.ProductListFilter{Cursor: "not a cursor!"}},
+		{"no separator", model.ProductListFilter{Cursor: "YWJj"}},
+		{"other sort", model.ProductListFilter{Cursor: cursor, Sort: "price_asc"}},
+	}
+	
+	for _, tt := range tests {
+		result, err := suite.productUseCase.GetProducts(suite.ctx, tt.filter, 10, 0)
+		
+		assert.Nil(t, result, tt.name)
+		assert.ErrorIs(t, err, appErrors.ErrInvalidInput, tt.name)
+	}
+	suite.mockProductRepo.AssertNotCalled(t, "FindAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProducts_InvalidFilter() {
+	t := suite.T()
+	low, high, negative := entity.Money(1000), entity.Money(10000), entity.Money(-100)
+	
+	tests := []struct {
+		name   string
+		filter model.ProductListFilter
+	}{
+		{"min greater than max", model.ProductListFilter{MinPrice: &high, MaxPrice: &low}},
+		{"negative price", model.ProductListFilter{MinPrice: &negative}},
+		{"unknown sort", model.ProductListFilter{Sort: "popularity"}},
+	}
+	
+	for _, tt := range tests {
+		result, err := suite.productUseCase.GetProducts(suite.ctx, tt.filter, 10, 0)
+		
+		assert.Nil(t, result, tt.name)
+		var appErr *appErrors.AppError
+		assert.True(t, appErrors.As(err, &appErr), tt.name)
+		assert.Equal(t, 400, appErr.StatusCode, tt.name)
+	}
+	
+	// The repository is never queried with an invalid filter
+	suite.mockProductRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *ProductUseCaseTestSuite) TestGetProductByID() {
 	t := suite.T()
 	
@@ -178,6 +337,116 @@ func (suite *ProductUseCaseTestSuite) TestGetProductByID() {
 	suite.mockProductRepo.AssertExpectations(t)
 }
 
+func (suite *ProductUseCaseTestSuite) TestGetProductsByIDs_ReportsMissingIDs() {
+	t := suite.T()
+	
+	foundID := suite.mockProduct.ID.String()
+	missingID := uuid.New().String()
+	
+	// Setup expectations; repeated and upper-cased IDs are queried once
+	suite.mockProductRepo.On("FindByIDs", mock.Anything, []string{foundID, missingID}).
+		Return([]entity.Product{*suite.mockProduct}, nil).Once()
+	
+	// Call the method
+	result, err := suite.productUseCase.GetProductsByIDs(suite.ctx, []string{foundID, strings.ToUpper(missingID), foundID})
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Products, 1)
+	assert.Equal(t, foundID, result.Products[0].ID)
+	assert.Equal(t, []string{missingID}, result.MissingIDs)
+	
+	// Verify expectations
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProductsByIDs_InvalidInput() {
+	t := suite.T()
+	
+	tooMany := make([]string, MaxBatchProductIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New().String()
+	}
+	
+	tests := []struct {
+		name        string
+		ids         []string
+		expectedErr error
+	}{
+		{"no IDs", nil, appErrors.ErrInvalidInput},
+		{"too many IDs", tooMany, appErrors.ErrInvalidInput},
+		{"ID is not a UUID", []string{suite.mockProduct.ID.String(), "not-a-uuid"}, appErrors.ErrInvalidProductID},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := suite.productUseCase.GetProductsByIDs(suite.ctx, tt.ids)
+			
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+	suite.mockProductRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProductWithStock() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockStockGateway.On("GetProductStock", mock.Anything, id).Return(&model.ProductStockResponse{
+		TotalQuantity:          12,
+		TotalReservedQuantity:  5,
+		TotalAvailableQuantity: 7,
+		FetchedAt:              "2025-05-30T10:00:00Z",
+	}, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetProductWithStock(suite.ctx, id)
+	
+	// Assert; stock is what can still be ordered
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.Equal(t, 7, result.Stock)
+	assert.Equal(t, "2025-05-30T10:00:00Z", result.StockUpdatedAt)
+	suite.mockStockGateway.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProductWithStock_WarehouseUnavailable() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockStockGateway.On("GetProductStock", mock.Anything, id).Return(nil, appErrors.ErrExternalServiceUnavailable)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetProductWithStock(suite.ctx, id)
+	
+	// Assert; the product is still returned, just without stock
+	assert.NoError(t, err)
+	assert.Equal(t, suite.mockProduct.Name, result.Name)
+	assert.Zero(t, result.Stock)
+	assert.Empty(t, result.StockUpdatedAt)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetProductWithStock_NotFound() {
+	t := suite.T()
+	nonExistentID := "b964a671-5863-4094-8bca-10c8fad18501"
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByID", mock.Anything, nonExistentID).Return(nil, gorm.ErrRecordNotFound)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetProductWithStock(suite.ctx, nonExistentID)
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrProductNotFound)
+	suite.mockStockGateway.AssertNotCalled(t, "GetProductStock", mock.Anything, mock.Anything)
+}
+
 func (suite *ProductUseCaseTestSuite) TestGetProductByID_NotFound() {
 	t := suite.T()
 	
@@ -222,6 +491,587 @@ func (suite *ProductUseCaseTestSuite) TestGetProductByID_InvalidID() {
 	// We don't need to verify expectations as the method fails early
 }
 
+func (suite *ProductUseCaseTestSuite) TestDeleteProduct_SoftDeletes() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("Delete", mock.Anything, id).Return(nil)
+	
+	// Call the method
+	err := suite.productUseCase.DeleteProduct(suite.ctx, id)
+	
+	// Assert
+	assert.NoError(t, err)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestRestoreProduct() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	suite.mockProduct.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByIDWithDeleted", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("Restore", mock.Anything, id).Return(nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.RestoreProduct(suite.ctx, id)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.Empty(t, result.DeletedAt)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestRestoreProduct_NotDeleted() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations; Restore must not be called
+	suite.mockProductRepo.On("FindByIDWithDeleted", mock.Anything, id).Return(suite.mockProduct, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.RestoreProduct(suite.ctx, id)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	suite.mockProductRepo.AssertExpectations(t)
+	suite.mockProductRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestRestoreProduct_NotFound() {
+	t := suite.T()
+	id := "b964a671-5863-4094-8bca-10c8fad18501"
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByIDWithDeleted", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+	
+	// Call the method
+	result, err := suite.productUseCase.RestoreProduct(suite.ctx, id)
+	
+	// Assert
+	assert.Nil(t, result)
+	var appErr *appErrors.AppError
+	assert.True(t, appErrors.As(err, &appErr))
+	assert.Equal(t, "PRODUCT_NOT_FOUND", appErr.Code)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_SKUOfDeletedProduct() {
+	t := suite.T()
+	suite.mockProduct.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	
+	// Setup expectations; the deleted product still holds the SKU
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, suite.mockProduct.SKU).Return(suite.mockProduct, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.CreateProduct(suite.ctx, &model.CreateProductRequest{
+		Name:  "Replacement Product",
+		Price: 1000,
+		SKU:   suite.mockProduct.SKU,
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	var appErr *appErrors.AppError
+	assert.True(t, appErrors.As(err, &appErr))
+	assert.Equal(t, "DUPLICATE_SKU", appErr.Code)
+	assert.Contains(t, appErr.Message, "deleted product")
+	suite.mockProductRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_CaseVariantSKU() {
+	t := suite.T()
+	
+	// Setup expectations; "test-sku" collides with the existing "TEST-SKU"
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "test-sku").Return(suite.mockProduct, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.CreateProduct(suite.ctx, &model.CreateProductRequest{
+		Name:  "Case Variant",
+		Price: 1000,
+		SKU:   "test-sku",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateSKU)
+	suite.mockProductRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_PaddedSKU() {
+	t := suite.T()
+	
+	// Setup expectations; " TEST-SKU " is trimmed before it is checked against the existing "TEST-SKU"
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "TEST-SKU").Return(suite.mockProduct, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.CreateProduct(suite.ctx, &model.CreateProductRequest{
+		Name:  "Padded SKU",
+		Price: 1000,
+		SKU:   " TEST-SKU ",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateSKU)
+	suite.mockProductRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_SKUCreatedConcurrently() {
+	t := suite.T()
+	
+	// Setup expectations; another request inserts "NEW-SKU" after the lookup
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "NEW-SKU").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockProductRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Product")).Return(gorm.ErrDuplicatedKey)
+	
+	// Call the method
+	result, err := suite.productUseCase.CreateProduct(suite.ctx, &model.CreateProductRequest{
+		Name:  "Concurrent SKU",
+		Price: 1000,
+		SKU:   "NEW-SKU",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateSKU)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_NormalizesText() {
+	t := suite.T()
+	
+	// Setup expectations; the product is stored with trimmed and collapsed text fields
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "NEW SKU").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockProductRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Product) bool {
+		return p.Name == "Phone Case" && p.Category == "Mobile Accessories" && p.SKU == "NEW SKU"
+	})).Return(nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.CreateProduct(suite.ctx, &model.CreateProductRequest{
+		Name:     "  Phone   Case ",
+		Price:    1000,
+		Category: "\tMobile  Accessories\n",
+		SKU:      " NEW \t SKU ",
+	})
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Phone Case", result.Name)
+	assert.Equal(t, "Mobile Accessories", result.Category)
+	assert.Equal(t, "NEW SKU", result.SKU)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_PaddedSKUOfOtherProduct() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	other := &suite.mockProducts[0]
+	
+	// Setup expectations; "  SKU-001 " is trimmed to the SKU of another product
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "SKU-001").Return(other, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProductPartial(suite.ctx, id, &model.PatchProductRequest{
+		SKU: "  SKU-001 ",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateSKU)
+	suite.mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProduct_ValidationFields() {
+	t := suite.T()
+	
+	// Call the method without a name and with a negative price
+	result, err := suite.productUseCase.CreateProduct(suite.ctx, &model.CreateProductRequest{
+		Price: -500,
+		SKU:   "NEG-SKU",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	var appErr *appErrors.AppError
+	assert.True(t, appErrors.As(err, &appErr))
+	assert.Equal(t, []appErrors.FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "price", Rule: "gt", Message: "price must be greater than 0"},
+	}, appErr.Fields)
+	assert.Equal(t, "Invalid input: name is required; price must be greater than 0", appErr.Message)
+	suite.mockProductRepo.AssertNotCalled(t, "FindBySKU", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProductsBatch_ReportsEachProduct() {
+	t := suite.T()
+	createdID := uuid.New()
+	
+	// Setup expectations; SKU "EXISTING-SKU" already belongs to a product and "RACE-SKU" is taken by a concurrent insert
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "NEW-SKU").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "EXISTING-SKU").Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "RACE-SKU").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockProductRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Product) bool { return p.SKU == "NEW-SKU" })).
+		Run(func(args mock.Arguments) { args.Get(1).(*entity.Product).ID = createdID }).Return(nil)
+	suite.mockProductRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Product) bool { return p.SKU == "RACE-SKU" })).
+		Return(gorm.ErrDuplicatedKey)
+	
+	// Call the method
+	result, err := suite.productUseCase.CreateProductsBatch(suite.ctx, []model.CreateProductRequest{
+		{Name: "New Product", Price: 1000, SKU: "NEW-SKU"},
+		{Name: "Same SKU Other Case", Price: 1000, SKU: "new-sku"},
+		{Price: 1000, SKU: "NO-NAME"},
+		{Name: "Existing SKU", Price: 1000, SKU: "EXISTING-SKU"},
+		{Name: "Race SKU", Price: 1000, SKU: "RACE-SKU"},
+	})
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 4, result.Failed)
+	assert.Len(t, result.Results, 5)
+	
+	assert.Equal(t, model.BatchItemStatusCreated, result.Results[0].Status)
+	assert.Equal(t, createdID.String(), result.Results[0].ID)
+	
+	assert.Equal(t, model.BatchItemStatusFailed, result.Results[1].Status)
+	assert.Equal(t, "DUPLICATE_SKU", result.Results[1].ErrorCode)
+	assert.Contains(t, result.Results[1].Error, "product 0 of the batch")
+	
+	assert.Equal(t, "INVALID_INPUT", result.Results[2].ErrorCode)
+	assert.Equal(t, "DUPLICATE_SKU", result.Results[3].ErrorCode)
+	assert.Equal(t, "DUPLICATE_SKU", result.Results[4].ErrorCode)
+	for i, item := range result.Results {
+		assert.Equal(t, i, item.Index)
+	}
+	suite.mockProductRepo.AssertNotCalled(t, "FindBySKU", mock.Anything, "new-sku")
+}
+
+func (suite *ProductUseCaseTestSuite) TestCreateProductsBatch_InvalidSize() {
+	t := suite.T()
+	
+	for _, size := range []int{0, DefaultMaxCreateBatchSize + 1} {
+		result, err := suite.productUseCase.CreateProductsBatch(suite.ctx, make([]model.CreateProductRequest, size))
+		
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	}
+	suite.mockProductRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProductPartial_SameSKUDifferentCase() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations; changing only the case of the product's own SKU needs no duplicate check
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("Update", mock.Anything, suite.mockProduct).Return(nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProductPartial(suite.ctx, id, &model.PatchProductRequest{
+		SKU: "test-sku",
+	})
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "test-sku", result.SKU)
+	suite.mockProductRepo.AssertExpectations(t)
+	suite.mockProductRepo.AssertNotCalled(t, "FindBySKU", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_CaseVariantOfOtherSKU() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	other := &suite.mockProducts[0]
+	
+	// Setup expectations; "sku-001" belongs to another product as "SKU-001"
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("FindBySKU", mock.Anything, "sku-001").Return(other, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProduct(suite.ctx, id, &model.UpdateProductRequest{
+		Name:  "Test Product",
+		Price: 1000,
+		SKU:   "sku-001",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateSKU)
+	suite.mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_ClearsOmittedFields() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations; a PUT without SKU needs no duplicate check
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("Update", mock.Anything, suite.mockProduct).Return(nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProduct(suite.ctx, id, &model.UpdateProductRequest{
+		Name:  "Replaced Product",
+		Price: 1000,
+	})
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Replaced Product", result.Name)
+	assert.Equal(t, entity.Money(1000), result.Price)
+	assert.Empty(t, result.Description)
+	assert.Empty(t, result.Category)
+	assert.Empty(t, result.SKU)
+	assert.Empty(t, result.ImageURL)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_StaleVersion() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	suite.mockProduct.Version = 3
+	
+	// Setup expectations; the product moved on since the caller read version 2
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProduct(suite.ctx, id, &model.UpdateProductRequest{
+		Name:    "Replaced Product",
+		Price:   1000,
+		Version: 2,
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrConcurrentModification)
+	assert.Contains(t, err.Error(), "version 3")
+	suite.mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProductPartial_ModifiedSinceHeaderDate() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	suite.mockProduct.UpdatedAt = time.Date(2025, 6, 1, 10, 0, 30, 0, time.UTC)
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProductPartial(suite.ctx, id, &model.PatchProductRequest{
+		Name:            "Patched Product",
+		UnmodifiedSince: time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrConcurrentModification)
+	suite.mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_ConcurrentSave() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations; another update lands between loading and saving the product
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("Update", mock.Anything, suite.mockProduct).Return(repository.ErrConcurrentModification)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProduct(suite.ctx, id, &model.UpdateProductRequest{
+		Name:  "Replaced Product",
+		Price: 1000,
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrConcurrentModification)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProduct_MissingRequiredFields() {
+	t := suite.T()
+	
+	// Call the method with the partial body a PATCH would accept
+	result, err := suite.productUseCase.UpdateProduct(suite.ctx, suite.mockProduct.ID.String(), &model.UpdateProductRequest{
+		Description: "Only the description",
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "Use PATCH")
+	suite.mockProductRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProductPartial_KeepsOmittedFields() {
+	t := suite.T()
+	id := suite.mockProduct.ID.String()
+	
+	// Setup expectations
+	suite.mockProductRepo.On("FindByID", mock.Anything, id).Return(suite.mockProduct, nil)
+	suite.mockProductRepo.On("Update", mock.Anything, suite.mockProduct).Return(nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProductPartial(suite.ctx, id, &model.PatchProductRequest{
+		Description: "Only the description",
+	})
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Only the description", result.Description)
+	assert.Equal(t, "Test Product", result.Name)
+	assert.Equal(t, entity.Money(19999), result.Price)
+	assert.Equal(t, "Test Category", result.Category)
+	assert.Equal(t, "TEST-SKU", result.SKU)
+	assert.Equal(t, "http://example.com/test-image.jpg", result.ImageURL)
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestUpdateProductPartial_InvalidPrice() {
+	t := suite.T()
+	
+	// Call the method
+	result, err := suite.productUseCase.UpdateProductPartial(suite.ctx, suite.mockProduct.ID.String(), &model.PatchProductRequest{
+		Price: -100,
+	})
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "PATCH changes only the fields provided")
+	suite.mockProductRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestSearchProducts_Relevance() {
+	t := suite.T()
+	
+	// Setup expectations
+	suite.mockProductRepo.On("Search", mock.Anything, "apple phone", repository.ProductSortRelevance, 10, 0).Return(suite.mockProducts, int64(len(suite.mockProducts)), nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.SearchProducts(suite.ctx, "apple phone", repository.ProductSortRelevance, 10, 0)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, len(suite.mockProducts), len(result.Products))
+	suite.mockProductRepo.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestSearchProducts_InvalidInput() {
+	t := suite.T()
+	
+	tests := []struct {
+		name  string
+		query string
+		sort  string
+	}{
+		{"blank query", "   ", ""},
+		{"unknown sort", "apple", "popularity"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := suite.productUseCase.SearchProducts(suite.ctx, tt.query, tt.sort, 10, 0)
+			
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+		})
+	}
+	
+	suite.mockProductRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetLowStockProducts() {
+	t := suite.T()
+	lowID := suite.mockProducts[0].ID.String()
+	stockedID := suite.mockProducts[1].ID.String()
+	
+	// Setup expectations; the whole catalog fits in one scan batch
+	suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{}, 100, 0).Return(suite.mockProducts, int64(2), nil)
+	suite.mockStockGateway.On("GetProductStock", mock.Anything, lowID).Return(&model.ProductStockResponse{
+		TotalQuantity:          6,
+		TotalReservedQuantity:  4,
+		TotalAvailableQuantity: 2,
+		FetchedAt:              "2025-05-30T10:00:00Z",
+	}, nil)
+	suite.mockStockGateway.On("GetProductStock", mock.Anything, stockedID).Return(&model.ProductStockResponse{
+		TotalQuantity:          50,
+		TotalAvailableQuantity: 50,
+	}, nil)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetLowStockProducts(suite.ctx, 5, 1, 10)
+	
+	// Assert; only the product with fewer than 5 units available is listed
+	assert.NoError(t, err)
+	assert.False(t, result.Partial)
+	assert.Empty(t, result.Warning)
+	assert.Equal(t, 5, result.Threshold)
+	assert.Equal(t, int64(1), result.Count)
+	if assert.Len(t, result.Products, 1) {
+		assert.Equal(t, lowID, result.Products[0].ID)
+		assert.Equal(t, 2, result.Products[0].AvailableQuantity)
+		assert.Equal(t, 4, result.Products[0].ReservedQuantity)
+		assert.Equal(t, "2025-05-30T10:00:00Z", result.Products[0].StockUpdatedAt)
+	}
+	suite.mockStockGateway.AssertExpectations(t)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetLowStockProducts_Paginates() {
+	t := suite.T()
+	
+	// Setup expectations; both products are out of stock
+	suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{}, 100, 0).Return(suite.mockProducts, int64(2), nil)
+	suite.mockStockGateway.On("GetProductStock", mock.Anything, mock.Anything).Return(&model.ProductStockResponse{}, nil)
+	
+	// Call the method for the second page of one product
+	result, err := suite.productUseCase.GetLowStockProducts(suite.ctx, 1, 2, 1)
+	
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Count)
+	assert.Equal(t, int64(2), result.TotalPages)
+	assert.False(t, result.HasNext)
+	if assert.Len(t, result.Products, 1) {
+		assert.Equal(t, suite.mockProducts[1].ID.String(), result.Products[0].ID)
+	}
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetLowStockProducts_WarehouseUnavailable() {
+	t := suite.T()
+	
+	// Setup expectations; the warehouse service is down for every lookup
+	suite.mockProductRepo.On("FindAll", mock.Anything, repository.ProductFilter{}, 100, 0).Return(suite.mockProducts, int64(2), nil)
+	suite.mockStockGateway.On("GetProductStock", mock.Anything, mock.Anything).Return(nil, appErrors.ErrExternalServiceUnavailable)
+	
+	// Call the method
+	result, err := suite.productUseCase.GetLowStockProducts(suite.ctx, 5, 1, 10)
+	
+	// Assert; the report is partial rather than failed
+	assert.NoError(t, err)
+	assert.True(t, result.Partial)
+	assert.NotEmpty(t, result.Warning)
+	assert.Equal(t, 2, result.UncheckedCount)
+	assert.Empty(t, result.Products)
+}
+
+func (suite *ProductUseCaseTestSuite) TestGetLowStockProducts_InvalidThreshold() {
+	t := suite.T()
+	
+	// Call the method
+	result, err := suite.productUseCase.GetLowStockProducts(suite.ctx, 0, 1, 10)
+	
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	suite.mockProductRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestProductUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductUseCaseTestSuite))
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package gateway
+
+import (
+	"context"
+	"product-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWarehouseStockGateway is a mock for WarehouseStockGatewayInterface
+type MockWarehouseStockGateway struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseStockGateway) GetProductStock(ctx context.Context, productID string) (*model.ProductStockResponse, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProductStockResponse), args.Error(1)
+}
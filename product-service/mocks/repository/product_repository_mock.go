@@ -2,6 +2,7 @@ package repository
 
 import (
 	"product-service/internal/entity"
+	"product-service/internal/repository"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -16,14 +17,23 @@ func (m *MockProductRepository) Create(db *gorm.DB, product *entity.Product) err
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) FindAll(db *gorm.DB, limit, offset int) ([]entity.Product, int64, error) {
-	args := m.Called(db, limit, offset)
+func (m *MockProductRepository) FindAll(db *gorm.DB, filter repository.ProductFilter, limit, offset int) ([]entity.Product, int64, error) {
+	args := m.Called(db, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]entity.Product), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockProductRepository) FindAfter(db *gorm.DB, filter repository.ProductFilter, after *repository.ProductCursor, limit int) ([]entity.Product, int64, *repository.ProductCursor, error) {
+	args := m.Called(db, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, 0, nil, args.Error(3)
+	}
+	next, _ := args.Get(2).(*repository.ProductCursor)
+	return args.Get(0).([]entity.Product), args.Get(1).(int64), next, args.Error(3)
+}
+
 func (m *MockProductRepository) FindByID(db *gorm.DB, id string) (*entity.Product, error) {
 	args := m.Called(db, id)
 	if args.Get(0) == nil {
@@ -32,6 +42,14 @@ func (m *MockProductRepository) FindByID(db *gorm.DB, id string) (*entity.Produc
 	return args.Get(0).(*entity.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindByIDWithDeleted(db *gorm.DB, id string) (*entity.Product, error) {
+	args := m.Called(db, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) Update(db *gorm.DB, product *entity.Product) error {
 	args := m.Called(db, product)
 	return args.Error(0)
@@ -42,6 +60,11 @@ func (m *MockProductRepository) Delete(db *gorm.DB, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) Restore(db *gorm.DB, id string) error {
+	args := m.Called(db, id)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) GetDB() *gorm.DB {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -50,8 +73,8 @@ func (m *MockProductRepository) GetDB() *gorm.DB {
 	return args.Get(0).(*gorm.DB)
 }
 
-func (m *MockProductRepository) Search(db *gorm.DB, query string, limit, offset int) ([]entity.Product, int64, error) {
-	args := m.Called(db, query, limit, offset)
+func (m *MockProductRepository) Search(db *gorm.DB, query string, sort string, limit, offset int) ([]entity.Product, int64, error) {
+	args := m.Called(db, query, sort, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -72,4 +95,12 @@ func (m *MockProductRepository) FindBySKU(db *gorm.DB, sku string) (*entity.Prod
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) FindByIDs(db *gorm.DB, ids []string) ([]entity.Product, error) {
+	args := m.Called(db, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Product), args.Error(1)
 }
\ No newline at end of file
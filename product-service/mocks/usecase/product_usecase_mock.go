@@ -11,14 +11,22 @@ type MockProductUseCase struct {
 	mock.Mock
 }
 
-func (m *MockProductUseCase) GetProducts(ctx context.Context, limit, offset int) (*model.ProductListResponse, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockProductUseCase) GetProducts(ctx context.Context, filter model.ProductListFilter, limit, offset int) (*model.ProductListResponse, error) {
+	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ProductListResponse), args.Error(1)
 }
 
+func (m *MockProductUseCase) GetProductWithStock(ctx context.Context, id string) (*model.ProductResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProductResponse), args.Error(1)
+}
+
 func (m *MockProductUseCase) GetProductByID(ctx context.Context, id string) (*model.ProductResponse, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -35,6 +43,14 @@ func (m *MockProductUseCase) CreateProduct(ctx context.Context, request *model.C
 	return args.Get(0).(*model.ProductResponse), args.Error(1)
 }
 
+func (m *MockProductUseCase) CreateProductsBatch(ctx context.Context, requests []model.CreateProductRequest) (*model.CreateProductsBatchResponse, error) {
+	args := m.Called(ctx, requests)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CreateProductsBatchResponse), args.Error(1)
+}
+
 func (m *MockProductUseCase) UpdateProduct(ctx context.Context, id string, request *model.UpdateProductRequest) (*model.ProductResponse, error) {
 	args := m.Called(ctx, id, request)
 	if args.Get(0) == nil {
@@ -43,13 +59,29 @@ func (m *MockProductUseCase) UpdateProduct(ctx context.Context, id string, reque
 	return args.Get(0).(*model.ProductResponse), args.Error(1)
 }
 
+func (m *MockProductUseCase) UpdateProductPartial(ctx context.Context, id string, request *model.PatchProductRequest) (*model.ProductResponse, error) {
+	args := m.Called(ctx, id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProductResponse), args.Error(1)
+}
+
 func (m *MockProductUseCase) DeleteProduct(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockProductUseCase) SearchProducts(ctx context.Context, query string, limit, offset int) (*model.ProductListResponse, error) {
-	args := m.Called(ctx, query, limit, offset)
+func (m *MockProductUseCase) RestoreProduct(ctx context.Context, id string) (*model.ProductResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProductResponse), args.Error(1)
+}
+
+func (m *MockProductUseCase) SearchProducts(ctx context.Context, query string, sort string, limit, offset int) (*model.ProductListResponse, error) {
+	args := m.Called(ctx, query, sort, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -62,4 +94,20 @@ func (m *MockProductUseCase) GetProductsByCategory(ctx context.Context, category
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ProductListResponse), args.Error(1)
+}
+
+func (m *MockProductUseCase) GetProductsByIDs(ctx context.Context, ids []string) (*model.ProductsByIDsResponse, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProductsByIDsResponse), args.Error(1)
+}
+
+func (m *MockProductUseCase) GetLowStockProducts(ctx context.Context, threshold, page, limit int) (*model.LowStockProductsResponse, error) {
+	args := m.Called(ctx, threshold, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LowStockProductsResponse), args.Error(1)
 }
\ No newline at end of file
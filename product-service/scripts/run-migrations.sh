@@ -28,6 +28,8 @@ if mysql -h product-service-mysql-e2e --port=3306 -u root -ppassword --ssl=0 -e
 else
     echo "Creating tables..."
     mysql -h product-service-mysql-e2e --port=3306 -u root -ppassword --ssl=0 product_service_test < /app/db/migrations/20250517154713_create_table_products.up.sql
+    mysql -h product-service-mysql-e2e --port=3306 -u root -ppassword --ssl=0 product_service_test < /app/db/migrations/20250529100000_add_deleted_at_to_products.up.sql
+    mysql -h product-service-mysql-e2e --port=3306 -u root -ppassword --ssl=0 product_service_test < /app/db/migrations/20250530090000_add_sku_normalized_to_products.up.sql
 fi
 
 echo "Inserting test data..."
@@ -41,10 +43,10 @@ else
     
     # Insert fresh test data
     mysql -h product-service-mysql-e2e --port=3306 -u root -ppassword --ssl=0 product_service_test << EOF
-INSERT INTO products (uuid, name, description, base_price, category, sku, barcode, thumbnail_url, image_urls, status)
+INSERT INTO products (uuid, name, description, base_price, category, sku, sku_normalized, barcode, thumbnail_url, image_urls, status)
 VALUES 
-  ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'Test Product 1', 'Description for test product 1', 99.99, 'Test Category', 'TEST-SKU-001', 'BARCODE-001', 'http://example.com/image1.jpg', 'http://example.com/image1.jpg,http://example.com/image1-2.jpg', 'active'),
-  ('f47ac10b-58cc-4372-a567-0e02b2c3d480', 'Test Product 2', 'Description for test product 2', 149.99, 'Test Category', 'TEST-SKU-002', 'BARCODE-002', 'http://example.com/image2.jpg', 'http://example.com/image2.jpg,http://example.com/image2-2.jpg', 'active');
+  ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'Test Product 1', 'Description for test product 1', 99.99, 'Test Category', 'TEST-SKU-001', 'TEST-SKU-001', 'BARCODE-001', 'http://example.com/image1.jpg', 'http://example.com/image1.jpg,http://example.com/image1-2.jpg', 'active'),
+  ('f47ac10b-58cc-4372-a567-0e02b2c3d480', 'Test Product 2', 'Description for test product 2', 149.99, 'Test Category', 'TEST-SKU-002', 'TEST-SKU-002', 'BARCODE-002', 'http://example.com/image2.jpg', 'http://example.com/image2.jpg,http://example.com/image2-2.jpg', 'active');
 EOF
 fi
 
@@ -0,0 +1,5 @@
+-- Remove shop coordinates
+ALTER TABLE shops
+    DROP INDEX idx_shops_location,
+    DROP COLUMN longitude,
+    DROP COLUMN latitude;
\ No newline at end of file
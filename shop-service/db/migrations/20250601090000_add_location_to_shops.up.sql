@@ -0,0 +1,5 @@
+-- Add optional shop coordinates for nearby shop search
+ALTER TABLE shops
+    ADD COLUMN latitude DECIMAL(10,7) NULL AFTER address,
+    ADD COLUMN longitude DECIMAL(10,7) NULL AFTER latitude,
+    ADD INDEX idx_shops_location (latitude, longitude);
\ No newline at end of file
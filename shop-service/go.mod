@@ -10,6 +10,7 @@ require (
 	github.com/sirupsen/logrus v1.9.3
 	github.com/spf13/viper v1.20.1
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/sync v0.14.0
 	gorm.io/driver/mysql v1.5.7
 	gorm.io/gorm v1.26.1
 )
@@ -60,7 +60,18 @@ func Bootstrap(config *BootstrapConfig) {
 	shopWarehouseRepository := repository.NewShopWarehouseRepository(config.Log)
 	
 	// Setup gateways
-	warehouseGateway := gateway.NewWarehouseGateway(config.Log, config.Services)
+	warehouseBreaker := gateway.NewCircuitBreaker(
+		config.Services.Warehouse.Breaker.FailureThreshold,
+		config.Services.Warehouse.Breaker.ResetTimeout,
+	)
+	warehouseGateway := gateway.NewCircuitBreakerWarehouseGateway(
+		config.Log,
+		gateway.NewWarehouseGateway(config.Log, config.Services),
+		warehouseBreaker,
+	)
+	if ttl := config.Services.Warehouse.CacheTTL; ttl > 0 {
+		warehouseGateway = gateway.NewCachedWarehouseGateway(config.Log, warehouseGateway, ttl)
+	}
 	
 	// Setup usecases
 	shopUsecase := usecase.NewShopUsecase(
@@ -74,13 +85,17 @@ func Bootstrap(config *BootstrapConfig) {
 	
 	// Setup handlers
 	shopHandler := handler.NewShopHandler(shopUsecase, config.Log)
+	healthHandler := handler.NewHealthHandler(config.DB, warehouseBreaker, config.Log)
 	
 	// Configure routes
 	routeConfig := route.RouteConfig{
-		App:         config.App,
-		DB:          config.DB,
-		Log:         config.Log,
-		ShopHandler: shopHandler,
+		App:           config.App,
+		DB:            config.DB,
+		Log:           config.Log,
+		ShopHandler:   shopHandler,
+		HealthHandler: healthHandler,
+		AccessLog:     NewAccessLogConfig(config.Config, config.Log),
+		CORS:          NewCORSConfig(config.Config, config.Log),
 	}
 	
 	// Setup routes
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"slices"
+	"shop-service/internal/delivery/http/middleware"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewCORSConfig reads cors.allow_origins, cors.allow_methods, cors.allow_headers, cors.allow_credentials and cors.max_age.
+// Without cors.allow_origins every cross-origin request is denied.
+func NewCORSConfig(config *viper.Viper, log *logrus.Logger) middleware.CORSConfig {
+	cors := middleware.DefaultCORSConfig()
+	cors.AllowOrigins = config.GetStringSlice("cors.allow_origins")
+
+	if config.IsSet("cors.allow_methods") {
+		cors.AllowMethods = config.GetStringSlice("cors.allow_methods")
+	}
+	if config.IsSet("cors.allow_headers") {
+		cors.AllowHeaders = config.GetStringSlice("cors.allow_headers")
+	}
+	cors.AllowCredentials = config.GetBool("cors.allow_credentials")
+	cors.MaxAge = config.GetInt("cors.max_age")
+
+	// Credentials for any origin would let every site act on behalf of a signed-in user
+	if cors.AllowCredentials && slices.Contains(cors.AllowOrigins, "*") {
+		log.Fatal("CORS allow_credentials cannot be combined with the * origin")
+	}
+
+	return cors
+}
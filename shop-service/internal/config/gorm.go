@@ -11,18 +11,73 @@ import (
 	"github.com/spf13/viper"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
+const (
+	// DefaultMaxOpenConns is used when database.pool.max is not configured
+	DefaultMaxOpenConns = 100
+
+	// DefaultMaxIdleConns is used when database.pool.idle is not configured
+	DefaultMaxIdleConns = 10
+
+	// DefaultConnMaxLifetime is used when database.pool.lifetime is not configured
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// DatabasePoolConfig holds the connection pool settings applied to the underlying *sql.DB
+type DatabasePoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// NewDatabasePoolConfig reads database.pool.max, database.pool.idle and database.pool.lifetime (in seconds),
+// falling back to the defaults for keys that are not set, and rejects settings that would leave the pool unbounded or inconsistent
+func NewDatabasePoolConfig(viper *viper.Viper) (*DatabasePoolConfig, error) {
+	pool := &DatabasePoolConfig{
+		MaxOpenConns:    DefaultMaxOpenConns,
+		MaxIdleConns:    DefaultMaxIdleConns,
+		ConnMaxLifetime: DefaultConnMaxLifetime,
+	}
+	if viper.IsSet("database.pool.max") {
+		pool.MaxOpenConns = viper.GetInt("database.pool.max")
+	}
+	if viper.IsSet("database.pool.idle") {
+		pool.MaxIdleConns = viper.GetInt("database.pool.idle")
+	}
+	if viper.IsSet("database.pool.lifetime") {
+		pool.ConnMaxLifetime = time.Second * time.Duration(viper.GetInt("database.pool.lifetime"))
+	}
+
+	if err := pool.Validate(); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// Validate reports the first pool setting that database/sql would silently treat as unlimited or clamp
+func (c *DatabasePoolConfig) Validate() error {
+	if c.MaxOpenConns <= 0 {
+		return fmt.Errorf("database.pool.max must be greater than 0, got %d", c.MaxOpenConns)
+	}
+	if c.MaxIdleConns < 0 {
+		return fmt.Errorf("database.pool.idle must not be negative, got %d", c.MaxIdleConns)
+	}
+	if c.MaxIdleConns > c.MaxOpenConns {
+		return fmt.Errorf("database.pool.idle (%d) must not exceed database.pool.max (%d)", c.MaxIdleConns, c.MaxOpenConns)
+	}
+	if c.ConnMaxLifetime <= 0 {
+		return fmt.Errorf("database.pool.lifetime must be greater than 0, got %s", c.ConnMaxLifetime)
+	}
+	return nil
+}
+
 func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 	username := viper.GetString("database.username")
 	password := viper.GetString("database.password")
 	host := viper.GetString("database.host")
 	port := viper.GetInt("database.port")
 	database := viper.GetString("database.name")
-	idleConnection := viper.GetInt("database.pool.idle")
-	maxConnection := viper.GetInt("database.pool.max")
-	maxLifeTimeConnection := viper.GetInt("database.pool.lifetime")
 
 	sslMode := viper.GetString("database.ssl_mode")
 	sslConfig := ""
@@ -34,13 +89,9 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		username, password, host, port, database, sslConfig)
 
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.New(&logrusWriter{Logger: log}, logger.Config{
-			SlowThreshold:             time.Second * 5,
-			Colorful:                  false,
-			IgnoreRecordNotFoundError: true,
-			ParameterizedQueries:      true,
-			LogLevel:                  logger.Info,
-		}),
+		// Report unique index violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+		Logger: newGormLogger(log, NewSlowQueryThreshold(viper)),
 	})
 	if err != nil {
 		log.Fatalf("failed to connect database: %v", err)
@@ -51,9 +102,20 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		log.Fatalf("failed to connect database: %v", err)
 	}
 
-	connection.SetMaxIdleConns(idleConnection)
-	connection.SetMaxOpenConns(maxConnection)
-	connection.SetConnMaxLifetime(time.Second * time.Duration(maxLifeTimeConnection))
+	pool, err := NewDatabasePoolConfig(viper)
+	if err != nil {
+		log.Fatalf("invalid database pool configuration: %v", err)
+	}
+
+	connection.SetMaxIdleConns(pool.MaxIdleConns)
+	connection.SetMaxOpenConns(pool.MaxOpenConns)
+	connection.SetConnMaxLifetime(pool.ConnMaxLifetime)
+
+	log.WithFields(logrus.Fields{
+		"max_open_conns":    pool.MaxOpenConns,
+		"max_idle_conns":    pool.MaxIdleConns,
+		"conn_max_lifetime": pool.ConnMaxLifetime.String(),
+	}).Info("Database connection pool configured")
 
 	// Execute SQL migrations if configured
 	if viper.IsSet("database.migrations_dir") {
@@ -180,11 +242,3 @@ func executeMigrations(db *gorm.DB, migrationsDir string, log *logrus.Logger) er
 
 	return nil
 }
-
-type logrusWriter struct {
-	Logger *logrus.Logger
-}
-
-func (l *logrusWriter) Printf(message string, args ...interface{}) {
-	l.Logger.Tracef(message, args...)
-}
@@ -7,10 +7,27 @@ import (
 	"github.com/spf13/viper"
 )
 
+// defaultTimeout bounds requests to a service whose timeout is not configured, so they cannot hang
+const defaultTimeout = 5 * time.Second
+
+// Circuit breaker defaults used when the configuration leaves them unset
+const (
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerResetTimeout     = 30 * time.Second
+)
+
 // ServiceConfig holds the configuration for external service connections
 type ServiceConfig struct {
-	URL     string        // Base URL of the service
-	Timeout time.Duration // Timeout for requests in milliseconds
+	URL      string        // Base URL of the service
+	Timeout  time.Duration // Timeout for requests in milliseconds
+	CacheTTL time.Duration // How long responses are cached in milliseconds; zero disables caching
+	Breaker  BreakerConfig // Circuit breaker guarding calls to the service
+}
+
+// BreakerConfig holds the circuit breaker settings for an external service
+type BreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit
+	ResetTimeout     time.Duration // How long the circuit stays open before a probe is let through, in milliseconds
 }
 
 // ServicesConfig holds the configuration for all external services
@@ -22,12 +39,40 @@ type ServicesConfig struct {
 func NewServicesConfig(config *viper.Viper) *ServicesConfig {
 	return &ServicesConfig{
 		Warehouse: ServiceConfig{
-			URL:     config.GetString("services.warehouse.url"),
-			Timeout: time.Duration(config.GetInt("services.warehouse.timeout")) * time.Millisecond,
+			URL:      config.GetString("services.warehouse.url"),
+			Timeout:  newTimeout(config, "services.warehouse.timeout"),
+			CacheTTL: time.Duration(config.GetInt("services.warehouse.cache_ttl")) * time.Millisecond,
+			Breaker:  newBreakerConfig(config, "services.warehouse.breaker"),
 		},
 	}
 }
 
+// newBreakerConfig reads the circuit breaker settings under the given key, falling back to the defaults
+func newBreakerConfig(config *viper.Viper, key string) BreakerConfig {
+	breaker := BreakerConfig{
+		FailureThreshold: config.GetInt(key + ".failure_threshold"),
+		ResetTimeout:     time.Duration(config.GetInt(key+".reset_timeout")) * time.Millisecond,
+	}
+
+	if breaker.FailureThreshold <= 0 {
+		breaker.FailureThreshold = defaultBreakerFailureThreshold
+	}
+	if breaker.ResetTimeout <= 0 {
+		breaker.ResetTimeout = defaultBreakerResetTimeout
+	}
+
+	return breaker
+}
+
+// newTimeout reads a request timeout in milliseconds, using defaultTimeout when it is not positive
+func newTimeout(config *viper.Viper, key string) time.Duration {
+	timeout := time.Duration(config.GetInt(key)) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return timeout
+}
+
 // GetEndpointURL returns the full URL for a specific service endpoint
 func (s *ServiceConfig) GetEndpointURL(endpoint string) string {
 	// If endpoint already starts with '/', don't add another one
@@ -6,8 +6,6 @@ import (
 	"shop-service/internal/errors"
 	"shop-service/internal/handler"
 
-	"github.com/google/uuid"
-
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
@@ -15,24 +13,24 @@ import (
 )
 
 type RouteConfig struct {
-	App         *fiber.App
-	DB          *gorm.DB
-	Log         *logrus.Logger
-	ShopHandler *handler.ShopHandler
+	App           *fiber.App
+	DB            *gorm.DB
+	Log           *logrus.Logger
+	ShopHandler   *handler.ShopHandler
+	HealthHandler *handler.HealthHandler
+	AccessLog     middleware.LoggerConfig
+	CORS          middleware.CORSConfig
 }
 
 func (c *RouteConfig) Setup() {
 	// Add request ID middleware (must be first)
-	c.App.Use(func(ctx *fiber.Ctx) error {
-		requestID := ctx.Get("X-Request-ID")
-		if requestID == "" {
-			ctx.Set("X-Request-ID", uuid.New().String())
-		}
-		return ctx.Next()
-	})
+	c.App.Use(middleware.RequestID())
 
-	// Apply logger middleware
-	c.App.Use(middleware.Logger(c.Log))
+	// Answer CORS preflight requests before any route handler sees them
+	c.App.Use(middleware.CORS(c.CORS))
+
+	// Apply access log middleware
+	c.App.Use(middleware.Logger(c.AccessLog, c.Log))
 	
 	// Set up Swagger documentation endpoint
 	c.App.Get("/swagger/*", fiberSwagger.WrapHandler)
@@ -41,15 +39,17 @@ func (c *RouteConfig) Setup() {
 	api := c.App.Group("/api")
 	v1 := api.Group("/v1")
 
-	// Health check endpoint
-	v1.Get("/health", func(ctx *fiber.Ctx) error {
-		return response.JSONSuccess(ctx, map[string]string{"status": "ok"})
-	})
+	// Health check endpoints: liveness does no I/O, readiness checks dependencies
+	v1.Get("/health", c.HealthHandler.Live)
+	v1.Get("/health/ready", c.HealthHandler.Ready)
 
 	// Shop endpoints
 	v1.Get("/shops", c.ShopHandler.ListShops)
 	v1.Post("/shops", c.ShopHandler.CreateShop)
+	v1.Get("/shops/nearby", c.ShopHandler.ListShopsNearby)
 	v1.Get("/shops/:id", c.ShopHandler.GetShopByID)
+	v1.Put("/shops/:id", c.ShopHandler.UpdateShop)
+	v1.Delete("/shops/:id", c.ShopHandler.DeleteShop)
 	v1.Get("/shops/:id/warehouses", c.ShopHandler.GetShopWarehouses)
 
 	// 404 Handler
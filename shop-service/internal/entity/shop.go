@@ -10,12 +10,14 @@ type Shop struct {
 	Name         string         `gorm:"column:name;type:varchar(255);index:,unique;not null"`
 	Description  string         `gorm:"column:description;type:text"`
 	Address      string         `gorm:"column:address;type:text;not null"`
+	Latitude     *float64       `gorm:"column:latitude;type:decimal(10,7);index:idx_shops_location,priority:1"`
+	Longitude    *float64       `gorm:"column:longitude;type:decimal(10,7);index:idx_shops_location,priority:2"`
 	ContactEmail string         `gorm:"column:contact_email;type:varchar(255);not null"`
 	ContactPhone string         `gorm:"column:contact_phone;type:varchar(50);not null"`
 	IsActive     bool           `gorm:"column:is_active;default:true;not null;index"`
 	CreatedAt    time.Time      `gorm:"column:created_at;autoCreateTime;not null"`
 	UpdatedAt    time.Time      `gorm:"column:updated_at;autoUpdateTime;not null"`
-	Warehouses   []ShopWarehouse `gorm:"foreignKey:ShopID"`
+	Warehouses   []ShopWarehouse `gorm:"foreignKey:ShopID;constraint:OnDelete:CASCADE"`
 }
 
 // TableName returns the table name for the Shop entity
@@ -23,6 +25,12 @@ func (Shop) TableName() string {
 	return "shops"
 }
 
+// NearbyShop is a shop found by a nearby search together with its distance from the search point
+type NearbyShop struct {
+	Shop
+	DistanceKm float64 `gorm:"column:distance_km"`
+}
+
 // ShopWarehouse represents a junction table between Shop and Warehouse
 type ShopWarehouse struct {
 	ID          uint      `gorm:"primaryKey;column:id"`
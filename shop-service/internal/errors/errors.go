@@ -82,6 +82,13 @@ var (
 		nil,
 	)
 
+	ErrDuplicateShopName = NewAppError(
+		"DUPLICATE_SHOP_NAME",
+		"A shop with this name already exists",
+		http.StatusConflict,
+		nil,
+	)
+
 	ErrWarehouseNotFound = NewAppError(
 		"WAREHOUSE_NOT_FOUND",
 		"Warehouse not found",
@@ -117,6 +124,27 @@ var (
 		nil,
 	)
 
+	ErrExternalServiceTimeout = NewAppError(
+		"EXTERNAL_SERVICE_TIMEOUT",
+		"External service did not respond in time",
+		http.StatusGatewayTimeout,
+		nil,
+	)
+
+	ErrWarehouseUnavailable = NewAppError(
+		"WAREHOUSE_UNAVAILABLE",
+		"Warehouse service is temporarily unavailable, please try again later",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
+	ErrServiceUnavailable = NewAppError(
+		"SERVICE_UNAVAILABLE",
+		"One or more dependencies are unavailable",
+		http.StatusServiceUnavailable,
+		nil,
+	)
+
 	ErrTimeout = NewAppError(
 		"TIMEOUT",
 		"Operation timed out",
@@ -0,0 +1,128 @@
+package gateway
+
+import (
+	"context"
+	"shop-service/internal/model"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+)
+
+// cachedWarehouse is a warehouse together with the time it stops being reused
+type cachedWarehouse struct {
+	warehouse model.WarehouseResponse
+	expiresAt time.Time
+}
+
+// CachedWarehouseGateway keeps warehouse details in memory for a fixed TTL in front of another WarehouseGatewayInterface.
+// Entries are only dropped when their TTL expires. Concurrent lookups of the same uncached warehouse share one backend call.
+type CachedWarehouseGateway struct {
+	Log  *logrus.Logger
+	Next WarehouseGatewayInterface
+	TTL  time.Duration
+
+	mu    sync.Mutex
+	cache map[uint]cachedWarehouse
+	group singleflight.Group
+	now   func() time.Time
+}
+
+// NewCachedWarehouseGateway creates a warehouse gateway that caches lookups for the given TTL
+func NewCachedWarehouseGateway(log *logrus.Logger, next WarehouseGatewayInterface, ttl time.Duration) WarehouseGatewayInterface {
+	return &CachedWarehouseGateway{
+		Log:   log,
+		Next:  next,
+		TTL:   ttl,
+		cache: make(map[uint]cachedWarehouse),
+		now:   time.Now,
+	}
+}
+
+// GetWarehouseByID retrieves warehouse details by ID, from the cache when possible
+func (g *CachedWarehouseGateway) GetWarehouseByID(ctx context.Context, warehouseID uint) (*model.WarehouseResponse, error) {
+	if warehouse, ok := g.cached(warehouseID); ok {
+		return warehouse, nil
+	}
+
+	result, err, _ := g.group.Do(strconv.FormatUint(uint64(warehouseID), 10), func() (interface{}, error) {
+		warehouse, err := g.Next.GetWarehouseByID(ctx, warehouseID)
+		if err != nil {
+			return nil, err
+		}
+
+		g.store(*warehouse)
+		return *warehouse, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	warehouse := result.(model.WarehouseResponse)
+	return &warehouse, nil
+}
+
+// GetWarehousesByIDs retrieves the details of several warehouses, fetching only the ones not in the cache
+func (g *CachedWarehouseGateway) GetWarehousesByIDs(ctx context.Context, warehouseIDs []uint) ([]model.WarehouseResponse, error) {
+	warehouses := make([]model.WarehouseResponse, 0, len(warehouseIDs))
+	missing := make([]uint, 0, len(warehouseIDs))
+
+	for _, warehouseID := range warehouseIDs {
+		if warehouse, ok := g.cached(warehouseID); ok {
+			warehouses = append(warehouses, *warehouse)
+			continue
+		}
+		missing = append(missing, warehouseID)
+	}
+
+	if len(missing) == 0 {
+		return warehouses, nil
+	}
+
+	g.Log.WithFields(logrus.Fields{
+		"cached_count":  len(warehouses),
+		"missing_count": len(missing),
+	}).Debug("Fetching uncached warehouses from warehouse service")
+
+	fetched, err := g.Next.GetWarehousesByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, warehouse := range fetched {
+		g.store(warehouse)
+	}
+
+	return append(warehouses, fetched...), nil
+}
+
+// cached returns a copy of the cached warehouse if it has not expired
+func (g *CachedWarehouseGateway) cached(warehouseID uint) (*model.WarehouseResponse, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entry, ok := g.cache[warehouseID]
+	if !ok {
+		return nil, false
+	}
+	if !g.now().Before(entry.expiresAt) {
+		delete(g.cache, warehouseID)
+		return nil, false
+	}
+
+	warehouse := entry.warehouse
+	return &warehouse, true
+}
+
+// store caches a warehouse until the TTL expires
+func (g *CachedWarehouseGateway) store(warehouse model.WarehouseResponse) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cache[warehouse.ID] = cachedWarehouse{
+		warehouse: warehouse,
+		expiresAt: g.now().Add(g.TTL),
+	}
+}
@@ -0,0 +1,141 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"shop-service/internal/model"
+	"sync"
+	"testing"
+	"time"
+
+	gatewayMocks "shop-service/mocks/gateway"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCachedWarehouseGatewayTest(ttl time.Duration) (*gatewayMocks.WarehouseGatewayMock, *CachedWarehouseGateway, *fakeClock) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	mockGateway := new(gatewayMocks.WarehouseGatewayMock)
+	clock := &fakeClock{now: time.Now()}
+	gateway := NewCachedWarehouseGateway(logger, mockGateway, ttl).(*CachedWarehouseGateway)
+	gateway.now = clock.Now
+
+	return mockGateway, gateway, clock
+}
+
+func TestCachedWarehouseGateway_GetWarehouseByID_HitsCacheWithinTTL(t *testing.T) {
+	mockGateway, gateway, clock := setupCachedWarehouseGatewayTest(time.Minute)
+	ctx := context.Background()
+
+	mockGateway.On("GetWarehouseByID", mock.Anything, uint(1)).
+		Return(&model.WarehouseResponse{ID: 1, Name: "Main"}, nil).Once()
+
+	first, err := gateway.GetWarehouseByID(ctx, 1)
+	require.NoError(t, err)
+
+	clock.now = clock.now.Add(30 * time.Second)
+	second, err := gateway.GetWarehouseByID(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	mockGateway.AssertNumberOfCalls(t, "GetWarehouseByID", 1)
+}
+
+func TestCachedWarehouseGateway_GetWarehouseByID_RefetchesAfterTTL(t *testing.T) {
+	mockGateway, gateway, clock := setupCachedWarehouseGatewayTest(time.Minute)
+	ctx := context.Background()
+
+	mockGateway.On("GetWarehouseByID", mock.Anything, uint(1)).
+		Return(&model.WarehouseResponse{ID: 1, Name: "Main"}, nil).Once()
+	mockGateway.On("GetWarehouseByID", mock.Anything, uint(1)).
+		Return(&model.WarehouseResponse{ID: 1, Name: "Renamed"}, nil).Once()
+
+	_, err := gateway.GetWarehouseByID(ctx, 1)
+	require.NoError(t, err)
+
+	clock.now = clock.now.Add(time.Minute)
+	warehouse, err := gateway.GetWarehouseByID(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Renamed", warehouse.Name)
+	mockGateway.AssertNumberOfCalls(t, "GetWarehouseByID", 2)
+}
+
+func TestCachedWarehouseGateway_GetWarehouseByID_DoesNotCacheErrors(t *testing.T) {
+	mockGateway, gateway, _ := setupCachedWarehouseGatewayTest(time.Minute)
+	ctx := context.Background()
+
+	mockGateway.On("GetWarehouseByID", mock.Anything, uint(1)).
+		Return(nil, errors.New("warehouse service down")).Once()
+	mockGateway.On("GetWarehouseByID", mock.Anything, uint(1)).
+		Return(&model.WarehouseResponse{ID: 1, Name: "Main"}, nil).Once()
+
+	_, err := gateway.GetWarehouseByID(ctx, 1)
+	assert.Error(t, err)
+
+	warehouse, err := gateway.GetWarehouseByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Main", warehouse.Name)
+}
+
+func TestCachedWarehouseGateway_GetWarehouseByID_ConcurrentMissesShareOneCall(t *testing.T) {
+	mockGateway, gateway, _ := setupCachedWarehouseGatewayTest(time.Minute)
+	ctx := context.Background()
+
+	// Hold the backend call open until every caller is waiting on it
+	release := make(chan struct{})
+	mockGateway.On("GetWarehouseByID", mock.Anything, uint(1)).
+		Run(func(mock.Arguments) { <-release }).
+		Return(&model.WarehouseResponse{ID: 1, Name: "Main"}, nil).Once()
+
+	const callers = 10
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer done.Done()
+			started.Done()
+			warehouse, err := gateway.GetWarehouseByID(ctx, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "Main", warehouse.Name)
+		}()
+	}
+
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	mockGateway.AssertNumberOfCalls(t, "GetWarehouseByID", 1)
+}
+
+func TestCachedWarehouseGateway_GetWarehousesByIDs_FetchesOnlyMissing(t *testing.T) {
+	mockGateway, gateway, _ := setupCachedWarehouseGatewayTest(time.Minute)
+	ctx := context.Background()
+
+	mockGateway.On("GetWarehousesByIDs", mock.Anything, []uint{1, 2}).
+		Return([]model.WarehouseResponse{{ID: 1}, {ID: 2}}, nil).Once()
+	mockGateway.On("GetWarehousesByIDs", mock.Anything, []uint{3}).
+		Return([]model.WarehouseResponse{{ID: 3}}, nil).Once()
+
+	_, err := gateway.GetWarehousesByIDs(ctx, []uint{1, 2})
+	require.NoError(t, err)
+
+	warehouses, err := gateway.GetWarehousesByIDs(ctx, []uint{1, 2, 3})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []model.WarehouseResponse{{ID: 1}, {ID: 2}, {ID: 3}}, warehouses)
+
+	// Everything is cached now, so the gateway is not called again
+	_, err = gateway.GetWarehousesByIDs(ctx, []uint{3, 1})
+	require.NoError(t, err)
+
+	mockGateway.AssertExpectations(t)
+	mockGateway.AssertNumberOfCalls(t, "GetWarehousesByIDs", 2)
+}
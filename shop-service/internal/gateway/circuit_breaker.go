@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed lets every call through and counts consecutive failures
+	CircuitClosed CircuitState = "closed"
+
+	// CircuitOpen rejects every call until the reset timeout has passed
+	CircuitOpen CircuitState = "open"
+
+	// CircuitHalfOpen lets a single probe call through to decide whether to close or reopen
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreaker stops calls to a failing dependency once it has failed FailureThreshold times in a row.
+// After ResetTimeout it lets one probe call through; a successful probe closes the circuit again.
+type CircuitBreaker struct {
+	FailureThreshold int
+	ResetTimeout     time.Duration
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		ResetTimeout:     resetTimeout,
+		state:            CircuitClosed,
+		now:              time.Now,
+	}
+}
+
+// Allow reports whether a call may be made now.
+// When the reset timeout of an open circuit has passed, the first caller is let through as the probe.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	default:
+		return false
+	}
+}
+
+// RecordSuccess closes the circuit and resets the failure count
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// RecordFailure counts a failed call and opens the circuit once the threshold is reached.
+// A failed probe reopens the circuit straight away. It reports whether this failure opened the circuit.
+func (b *CircuitBreaker) RecordFailure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == CircuitOpen || !(b.probing || b.failures >= b.FailureThreshold) {
+		return false
+	}
+
+	b.state = CircuitOpen
+	b.openedAt = b.now()
+	b.probing = false
+	return true
+}
+
+// Release gives up a call's slot without judging the dependency, e.g. when the caller cancelled it.
+// A half-open circuit then lets the next caller probe instead.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State returns the current state of the circuit
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+// currentState reports an open circuit whose reset timeout has passed as half-open; callers must hold mu
+func (b *CircuitBreaker) currentState() CircuitState {
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.ResetTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
@@ -0,0 +1,189 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"shop-service/internal/config/services"
+	appErrors "shop-service/internal/errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock lets tests move a circuit breaker past its reset timeout without sleeping
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestBreaker(threshold int, resetTimeout time.Duration) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	breaker := NewCircuitBreaker(threshold, resetTimeout)
+	breaker.now = clock.Now
+	return breaker, clock
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		require.True(t, breaker.Allow())
+		assert.False(t, breaker.RecordFailure())
+		assert.Equal(t, CircuitClosed, breaker.State())
+	}
+
+	require.True(t, breaker.Allow())
+	assert.True(t, breaker.RecordFailure())
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_SuccessResetsFailureCount(t *testing.T) {
+	breaker, _ := newTestBreaker(3, time.Minute)
+
+	breaker.RecordFailure()
+	breaker.RecordFailure()
+	breaker.RecordSuccess()
+	breaker.RecordFailure()
+	breaker.RecordFailure()
+
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_RecoversAfterSuccessfulProbe(t *testing.T) {
+	breaker, clock := newTestBreaker(1, time.Minute)
+
+	breaker.RecordFailure()
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	clock.now = clock.now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+
+	// Only one probe is let through while half-open
+	require.True(t, breaker.Allow())
+	assert.False(t, breaker.Allow())
+
+	breaker.RecordSuccess()
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.True(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	breaker, clock := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		breaker.RecordFailure()
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	require.True(t, breaker.Allow())
+	assert.True(t, breaker.RecordFailure())
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	// The reset timeout starts again from the failed probe
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.False(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_ReleasedProbeLetsNextCallerProbe(t *testing.T) {
+	breaker, clock := newTestBreaker(1, time.Minute)
+
+	breaker.RecordFailure()
+	clock.now = clock.now.Add(time.Minute)
+
+	require.True(t, breaker.Allow())
+	breaker.Release()
+	assert.True(t, breaker.Allow())
+}
+
+func TestCircuitBreakerWarehouseGateway_FailsFastWhenOpen(t *testing.T) {
+	healthy := false
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"warehouses":[{"id":1,"name":"Main"}]}}`))
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	breaker, clock := newTestBreaker(2, time.Minute)
+	gateway := NewCircuitBreakerWarehouseGateway(logger, NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL + "/api/v1", Timeout: time.Second},
+	}), breaker)
+	ctx := context.Background()
+
+	// Two failing calls reach the warehouse service and open the circuit
+	for i := 0; i < 2; i++ {
+		_, err := gateway.GetWarehousesByIDs(ctx, []uint{1})
+		assert.ErrorIs(t, err, appErrors.ErrExternalServiceError)
+	}
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	// While open, calls fail fast without reaching the warehouse service
+	_, err := gateway.GetWarehousesByIDs(ctx, []uint{1})
+	assert.ErrorIs(t, err, appErrors.ErrWarehouseUnavailable)
+	_, err = gateway.GetWarehouseByID(ctx, 1)
+	assert.ErrorIs(t, err, appErrors.ErrWarehouseUnavailable)
+	assert.Equal(t, 2, requests)
+
+	// After the reset timeout a successful probe closes the circuit
+	healthy = true
+	clock.now = clock.now.Add(time.Minute)
+	warehouses, err := gateway.GetWarehousesByIDs(ctx, []uint{1})
+	require.NoError(t, err)
+	assert.Len(t, warehouses, 1)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.Equal(t, 3, requests)
+}
+
+func TestCircuitBreakerWarehouseGateway_NotFoundIsNotAFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	breaker, _ := newTestBreaker(1, time.Minute)
+	gateway := NewCircuitBreakerWarehouseGateway(logger, NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL + "/api/v1", Timeout: time.Second},
+	}), breaker)
+
+	_, err := gateway.GetWarehouseByID(context.Background(), 42)
+	assert.ErrorIs(t, err, appErrors.ErrWarehouseNotFound)
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerWarehouseGateway_ClientTimeoutIsAFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	breaker, _ := newTestBreaker(1, time.Minute)
+	gateway := NewCircuitBreakerWarehouseGateway(logger, NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL + "/api/v1", Timeout: 20 * time.Millisecond},
+	}), breaker)
+
+	_, err := gateway.GetWarehouseByID(context.Background(), 1)
+	assert.ErrorIs(t, err, appErrors.ErrExternalServiceTimeout)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	appErrors "shop-service/internal/errors"
+	"shop-service/internal/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CircuitBreakerWarehouseGateway wraps a WarehouseGatewayInterface in a circuit breaker,
+// so that while the warehouse service is down calls fail fast with ErrWarehouseUnavailable instead of waiting for the timeout.
+type CircuitBreakerWarehouseGateway struct {
+	Log     *logrus.Logger
+	Next    WarehouseGatewayInterface
+	Breaker *CircuitBreaker
+}
+
+// NewCircuitBreakerWarehouseGateway creates a warehouse gateway guarded by the given circuit breaker
+func NewCircuitBreakerWarehouseGateway(log *logrus.Logger, next WarehouseGatewayInterface, breaker *CircuitBreaker) WarehouseGatewayInterface {
+	return &CircuitBreakerWarehouseGateway{
+		Log:     log,
+		Next:    next,
+		Breaker: breaker,
+	}
+}
+
+// GetWarehouseByID retrieves warehouse details by ID
+func (g *CircuitBreakerWarehouseGateway) GetWarehouseByID(ctx context.Context, warehouseID uint) (*model.WarehouseResponse, error) {
+	if !g.Breaker.Allow() {
+		return nil, appErrors.ErrWarehouseUnavailable
+	}
+
+	warehouse, err := g.Next.GetWarehouseByID(ctx, warehouseID)
+	g.record(ctx, err)
+	return warehouse, err
+}
+
+// GetWarehousesByIDs retrieves the details of several warehouses at once
+func (g *CircuitBreakerWarehouseGateway) GetWarehousesByIDs(ctx context.Context, warehouseIDs []uint) ([]model.WarehouseResponse, error) {
+	if !g.Breaker.Allow() {
+		return nil, appErrors.ErrWarehouseUnavailable
+	}
+
+	warehouses, err := g.Next.GetWarehousesByIDs(ctx, warehouseIDs)
+	g.record(ctx, err)
+	return warehouses, err
+}
+
+// record reports the outcome of a call to the breaker.
+// Only unreachable, failing or timed out warehouse service responses count as failures; a not-found warehouse means the service answered.
+func (g *CircuitBreakerWarehouseGateway) record(ctx context.Context, err error) {
+	switch {
+	case err == nil, errors.Is(err, appErrors.ErrWarehouseNotFound):
+		g.Breaker.RecordSuccess()
+	case ctx.Err() != nil:
+		g.Breaker.Release()
+	case errors.Is(err, appErrors.ErrExternalServiceUnavailable), errors.Is(err, appErrors.ErrExternalServiceError),
+		errors.Is(err, appErrors.ErrExternalServiceTimeout):
+		if g.Breaker.RecordFailure() {
+			g.Log.WithFields(logrus.Fields{
+				"error":         err.Error(),
+				"reset_timeout": g.Breaker.ResetTimeout.Milliseconds(),
+			}).Warn("Warehouse service circuit breaker opened")
+		}
+	default:
+		g.Breaker.Release()
+	}
+}
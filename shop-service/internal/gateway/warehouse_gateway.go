@@ -3,13 +3,20 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"shop-service/internal/config/services"
+	appContext "shop-service/internal/context"
 	appErrors "shop-service/internal/errors"
 	"shop-service/internal/model"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,8 +24,20 @@ import (
 type WarehouseGatewayInterface interface {
 	// GetWarehouseByID retrieves warehouse details by ID
 	GetWarehouseByID(ctx context.Context, warehouseID uint) (*model.WarehouseResponse, error)
+
+	// GetWarehousesByIDs retrieves the details of several warehouses at once.
+	// Warehouses the warehouse service does not know are left out of the result.
+	GetWarehousesByIDs(ctx context.Context, warehouseIDs []uint) ([]model.WarehouseResponse, error)
 }
 
+const (
+	// maxWarehouseBatchSize is the most warehouse IDs the warehouse service accepts in one batch request
+	maxWarehouseBatchSize = 100
+
+	// requestIDHeader carries the request ID to the warehouse service so logs can be correlated across services
+	requestIDHeader = "X-Request-ID"
+)
+
 // WarehouseGateway implements WarehouseGatewayInterface
 type WarehouseGateway struct {
 	Log      *logrus.Logger
@@ -26,7 +45,9 @@ type WarehouseGateway struct {
 	Client   *http.Client
 }
 
-// NewWarehouseGateway creates a new warehouse gateway instance
+// NewWarehouseGateway creates a new warehouse gateway instance.
+// Every request is bounded by services.warehouse.timeout on top of the deadline of its context, so a slow
+// warehouse service cannot hold a call open for longer than that.
 func NewWarehouseGateway(log *logrus.Logger, services *services.ServicesConfig) WarehouseGatewayInterface {
 	client := &http.Client{
 		Timeout: services.Warehouse.Timeout,
@@ -58,6 +79,7 @@ func (g *WarehouseGateway) GetWarehouseByID(ctx context.Context, warehouseID uin
 	// Set request headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	setRequestID(req)
 
 	// Execute the request
 	start := time.Now()
@@ -76,7 +98,7 @@ func (g *WarehouseGateway) GetWarehouseByID(ctx context.Context, warehouseID uin
 			"error":        err.Error(),
 			"warehouse_id": warehouseID,
 		}).Error("Failed to send request to warehouse service")
-		return nil, appErrors.WithError(appErrors.ErrExternalServiceUnavailable, err)
+		return nil, requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -105,6 +127,9 @@ func (g *WarehouseGateway) GetWarehouseByID(ctx context.Context, warehouseID uin
 			"error":        err.Error(),
 			"warehouse_id": warehouseID,
 		}).Error("Failed to parse warehouse service response")
+		if isTimeout(err) {
+			return nil, appErrors.WithError(appErrors.ErrExternalServiceTimeout, err)
+		}
 		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
 	}
 
@@ -117,4 +142,136 @@ func (g *WarehouseGateway) GetWarehouseByID(ctx context.Context, warehouseID uin
 	}
 
 	return &response.Data, nil
-}
\ No newline at end of file
+}
+
+// GetWarehousesByIDs retrieves the details of several warehouses using the warehouse service batch endpoint.
+// IDs are sent in chunks of at most maxWarehouseBatchSize, so large lists still take only a few requests.
+func (g *WarehouseGateway) GetWarehousesByIDs(ctx context.Context, warehouseIDs []uint) ([]model.WarehouseResponse, error) {
+	warehouses := make([]model.WarehouseResponse, 0, len(warehouseIDs))
+
+	for start := 0; start < len(warehouseIDs); start += maxWarehouseBatchSize {
+		end := start + maxWarehouseBatchSize
+		if end > len(warehouseIDs) {
+			end = len(warehouseIDs)
+		}
+
+		batch, err := g.getWarehouseBatch(ctx, warehouseIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, batch...)
+	}
+
+	return warehouses, nil
+}
+
+// getWarehouseBatch fetches a single batch of warehouses
+func (g *WarehouseGateway) getWarehouseBatch(ctx context.Context, warehouseIDs []uint) ([]model.WarehouseResponse, error) {
+	ids := make([]string, len(warehouseIDs))
+	for i, warehouseID := range warehouseIDs {
+		ids[i] = strconv.FormatUint(uint64(warehouseID), 10)
+	}
+
+	// Create the endpoint URL
+	endpoint := "warehouses/batch?ids=" + url.QueryEscape(strings.Join(ids, ","))
+	requestURL := g.Services.Warehouse.GetEndpointURL(endpoint)
+
+	// Create the request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		g.Log.WithFields(logrus.Fields{
+			"error":         err.Error(),
+			"warehouse_ids": warehouseIDs,
+		}).Error("Failed to create request for warehouse service")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Set request headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	setRequestID(req)
+
+	// Execute the request
+	start := time.Now()
+	resp, err := g.Client.Do(req)
+	requestDuration := time.Since(start)
+
+	g.Log.WithFields(logrus.Fields{
+		"warehouse_count":  len(warehouseIDs),
+		"request_duration": requestDuration.Milliseconds(),
+		"method":           http.MethodGet,
+		"url":              requestURL,
+	}).Debug("Warehouse service request completed")
+
+	if err != nil {
+		g.Log.WithFields(logrus.Fields{
+			"error":         err.Error(),
+			"warehouse_ids": warehouseIDs,
+		}).Error("Failed to send request to warehouse service")
+		return nil, requestError(err)
+	}
+	defer resp.Body.Close()
+
+	// Handle non-successful status codes
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		g.Log.WithFields(logrus.Fields{
+			"status_code":   resp.StatusCode,
+			"warehouse_ids": warehouseIDs,
+		}).Error("Warehouse service returned non-success status code")
+		return nil, appErrors.ErrExternalServiceError
+	}
+
+	// Parse the response
+	var response struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Warehouses []model.WarehouseResponse `json:"warehouses"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		g.Log.WithFields(logrus.Fields{
+			"error":         err.Error(),
+			"warehouse_ids": warehouseIDs,
+		}).Error("Failed to parse warehouse service response")
+		if isTimeout(err) {
+			return nil, appErrors.WithError(appErrors.ErrExternalServiceTimeout, err)
+		}
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Check response success
+	if !response.Success {
+		g.Log.WithFields(logrus.Fields{
+			"warehouse_ids": warehouseIDs,
+		}).Error("Warehouse service returned success=false")
+		return nil, appErrors.ErrExternalServiceError
+	}
+
+	return response.Data.Warehouses, nil
+}
+
+// requestError maps a failed request to ErrExternalServiceTimeout when it ran out of time, either the client timeout
+// or the deadline of its context, and to ErrExternalServiceUnavailable otherwise
+func requestError(err error) error {
+	if isTimeout(err) {
+		return appErrors.WithError(appErrors.ErrExternalServiceTimeout, err)
+	}
+	return appErrors.WithError(appErrors.ErrExternalServiceUnavailable, err)
+}
+
+// isTimeout reports whether err is the client timeout or an expired context deadline; a cancelled context is not a timeout
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// setRequestID forwards the request ID carried by the request's context.
+// Calls that did not start from an HTTP request have none, so one is generated for them.
+func setRequestID(req *http.Request) {
+	requestID := appContext.GetRequestID(req.Context())
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	req.Header.Set(requestIDHeader, requestID)
+}
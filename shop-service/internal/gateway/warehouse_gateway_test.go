@@ -0,0 +1,155 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"shop-service/internal/config/services"
+	appContext "shop-service/internal/context"
+	appErrors "shop-service/internal/errors"
+	"shop-service/internal/model"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarehouseGateway_GetWarehousesByIDs_Chunks(t *testing.T) {
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/warehouses/batch", r.URL.Path)
+
+		// Echo back every requested warehouse except ID 7
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		batchSizes = append(batchSizes, len(ids))
+
+		var warehouses []model.WarehouseResponse
+		for _, idParam := range ids {
+			id, err := strconv.ParseUint(idParam, 10, 32)
+			require.NoError(t, err)
+			if id != 7 {
+				warehouses = append(warehouses, model.WarehouseResponse{ID: uint(id)})
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"warehouses": warehouses},
+		})
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	gateway := NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL + "/api/v1", Timeout: time.Second},
+	})
+
+	warehouseIDs := make([]uint, 150)
+	for i := range warehouseIDs {
+		warehouseIDs[i] = uint(i + 1)
+	}
+
+	warehouses, err := gateway.GetWarehousesByIDs(context.Background(), warehouseIDs)
+	require.NoError(t, err)
+	assert.Equal(t, []int{100, 50}, batchSizes)
+	assert.Len(t, warehouses, 149)
+}
+
+func TestWarehouseGateway_GetWarehousesByIDs_ServiceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	gateway := NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL, Timeout: time.Second},
+	})
+
+	warehouses, err := gateway.GetWarehousesByIDs(context.Background(), []uint{1, 2})
+	assert.Nil(t, warehouses)
+	assert.Error(t, err)
+}
+
+func TestWarehouseGateway_ForwardsRequestID(t *testing.T) {
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    model.WarehouseResponse{ID: 1},
+		})
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	gateway := NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL, Timeout: time.Second},
+	})
+
+	_, err := gateway.GetWarehouseByID(appContext.WithRequestID(context.Background(), "req-123"), 1)
+	require.NoError(t, err)
+
+	// Without a request ID in the context one is generated
+	_, err = gateway.GetWarehouseByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.Len(t, requestIDs, 2)
+	assert.Equal(t, "req-123", requestIDs[0])
+	assert.NotEmpty(t, requestIDs[1])
+}
+
+func TestWarehouseGateway_AbortsAtTimeout(t *testing.T) {
+	// The warehouse service answers long after the client timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	gateway := NewWarehouseGateway(logger, &services.ServicesConfig{
+		Warehouse: services.ServiceConfig{URL: server.URL, Timeout: 50 * time.Millisecond},
+	})
+
+	t.Run("ClientTimeout", func(t *testing.T) {
+		start := time.Now()
+		warehouse, err := gateway.GetWarehouseByID(context.Background(), 1)
+
+		assert.Nil(t, warehouse)
+		assert.ErrorIs(t, err, appErrors.ErrExternalServiceTimeout)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("ContextDeadline", func(t *testing.T) {
+		// A caller deadline shorter than the client timeout wins
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		warehouses, err := gateway.GetWarehousesByIDs(ctx, []uint{1, 2})
+
+		assert.Nil(t, warehouses)
+		assert.ErrorIs(t, err, appErrors.ErrExternalServiceTimeout)
+	})
+
+	t.Run("CancelledContextIsNotATimeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := gateway.GetWarehouseByID(ctx, 1)
+
+		assert.ErrorIs(t, err, appErrors.ErrExternalServiceUnavailable)
+	})
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"shop-service/internal/delivery/http/response"
+	appErrors "shop-service/internal/errors"
+	"shop-service/internal/gateway"
+	"shop-service/internal/model"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// healthCheckTimeout bounds how long readiness waits for all dependency checks
+const healthCheckTimeout = 2 * time.Second
+
+// HealthHandler handles the liveness and readiness endpoints
+type HealthHandler struct {
+	DB               *gorm.DB
+	WarehouseBreaker *gateway.CircuitBreaker
+	Log              *logrus.Logger
+}
+
+// NewHealthHandler creates a new health handler instance
+func NewHealthHandler(db *gorm.DB, warehouseBreaker *gateway.CircuitBreaker, log *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		DB:               db,
+		WarehouseBreaker: warehouseBreaker,
+		Log:              log,
+	}
+}
+
+// Live handles GET /health. It does no I/O so it stays fast for liveness probes.
+// @Summary Liveness check
+// @Description Reports that the process is up without checking any dependency
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.Response{data=model.HealthResponse}
+// @Router /health [get]
+func (h *HealthHandler) Live(c *fiber.Ctx) error {
+	return response.JSONSuccess(c, model.HealthResponse{Status: model.HealthStatusOK})
+}
+
+// Ready handles GET /health/ready by checking the database and the warehouse service
+// @Summary Readiness check
+// @Description Pings the database and checks the warehouse service circuit breaker. Returns 503 with the status of each dependency when any is down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.Response{data=model.HealthResponse}
+// @Failure 503 {object} response.Response{data=model.HealthResponse,error=response.ErrorInfo}
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), healthCheckTimeout)
+	defer cancel()
+
+	dependencies := map[string]model.DependencyHealth{
+		"database": h.checkDatabase(ctx),
+	}
+	if h.WarehouseBreaker != nil {
+		dependencies["warehouse_service"] = h.checkWarehouseService()
+	}
+
+	return writeReadiness(c, dependencies, h.Log)
+}
+
+// checkDatabase pings the underlying connection pool
+func (h *HealthHandler) checkDatabase(ctx context.Context) model.DependencyHealth {
+	sqlDB, err := h.DB.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		return model.DependencyHealth{Status: model.HealthStatusDown, Error: err.Error()}
+	}
+
+	return model.DependencyHealth{Status: model.HealthStatusUp}
+}
+
+// checkWarehouseService reports the warehouse service as down while its circuit breaker is open.
+// The breaker reflects recent calls, so this adds no request of its own.
+func (h *HealthHandler) checkWarehouseService() model.DependencyHealth {
+	state := h.WarehouseBreaker.State()
+	if state == gateway.CircuitOpen {
+		return model.DependencyHealth{
+			Status:         model.HealthStatusDown,
+			Error:          "circuit breaker is open after repeated warehouse service failures",
+			CircuitBreaker: string(state),
+		}
+	}
+
+	return model.DependencyHealth{Status: model.HealthStatusUp, CircuitBreaker: string(state)}
+}
+
+// writeReadiness responds 200 when every dependency is up and 503 otherwise
+func writeReadiness(c *fiber.Ctx, dependencies map[string]model.DependencyHealth, log *logrus.Logger) error {
+	health := model.HealthResponse{
+		Status:       model.HealthStatusReady,
+		Dependencies: dependencies,
+	}
+
+	var down []string
+	for name, dependency := range dependencies {
+		if dependency.Status != model.HealthStatusUp {
+			down = append(down, name)
+		}
+	}
+
+	if len(down) == 0 {
+		return response.JSONSuccess(c, health)
+	}
+
+	health.Status = model.HealthStatusNotReady
+	log.WithField("dependencies", down).Warn("Readiness check failed")
+
+	return c.Status(fiber.StatusServiceUnavailable).JSON(response.Response{
+		Success: false,
+		Data:    health,
+		Error: &response.ErrorInfo{
+			Code:    appErrors.ErrServiceUnavailable.Code,
+			Message: appErrors.ErrServiceUnavailable.Message,
+		},
+	})
+}
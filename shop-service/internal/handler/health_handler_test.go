@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"shop-service/internal/gateway"
+	"shop-service/internal/model"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupHealthHandlerTest(t *testing.T, breaker *gateway.CircuitBreaker) (sqlmock.Sqlmock, *fiber.App) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	handler := NewHealthHandler(db, breaker, logger)
+
+	app := fiber.New()
+	app.Get("/api/v1/health", handler.Live)
+	app.Get("/api/v1/health/ready", handler.Ready)
+
+	return mock, app
+}
+
+func decodeHealthResponse(t *testing.T, resp *http.Response) model.HealthResponse {
+	var body struct {
+		Data model.HealthResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body.Data
+}
+
+func TestHealthHandler_Live_DoesNotTouchDependencies(t *testing.T) {
+	mock, app := setupHealthHandlerTest(t, gateway.NewCircuitBreaker(1, time.Minute))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, model.HealthStatusOK, decodeHealthResponse(t, resp).Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthHandler_Ready_AllDependenciesUp(t *testing.T) {
+	mock, app := setupHealthHandlerTest(t, gateway.NewCircuitBreaker(1, time.Minute))
+	mock.ExpectPing()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	health := decodeHealthResponse(t, resp)
+	assert.Equal(t, model.HealthStatusReady, health.Status)
+	assert.Equal(t, model.HealthStatusUp, health.Dependencies["database"].Status)
+	assert.Equal(t, model.HealthStatusUp, health.Dependencies["warehouse_service"].Status)
+	assert.Equal(t, string(gateway.CircuitClosed), health.Dependencies["warehouse_service"].CircuitBreaker)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthHandler_Ready_DatabaseDown(t *testing.T) {
+	mock, app := setupHealthHandlerTest(t, gateway.NewCircuitBreaker(1, time.Minute))
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	health := decodeHealthResponse(t, resp)
+	assert.Equal(t, model.HealthStatusNotReady, health.Status)
+	assert.Equal(t, model.HealthStatusDown, health.Dependencies["database"].Status)
+	assert.Equal(t, "connection refused", health.Dependencies["database"].Error)
+	assert.Equal(t, model.HealthStatusUp, health.Dependencies["warehouse_service"].Status)
+}
+
+func TestHealthHandler_Ready_WarehouseCircuitOpen(t *testing.T) {
+	breaker := gateway.NewCircuitBreaker(1, time.Minute)
+	breaker.RecordFailure()
+
+	mock, app := setupHealthHandlerTest(t, breaker)
+	mock.ExpectPing()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	health := decodeHealthResponse(t, resp)
+	assert.Equal(t, model.HealthStatusUp, health.Dependencies["database"].Status)
+	assert.Equal(t, model.HealthStatusDown, health.Dependencies["warehouse_service"].Status)
+	assert.Equal(t, string(gateway.CircuitOpen), health.Dependencies["warehouse_service"].CircuitBreaker)
+}
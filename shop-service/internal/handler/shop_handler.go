@@ -3,6 +3,7 @@ package handler
 import (
 	"strconv"
 	"shop-service/internal/delivery/http/response"
+	appErrors "shop-service/internal/errors"
 	"shop-service/internal/model"
 	"shop-service/internal/model/converter"
 	"shop-service/internal/usecase"
@@ -72,6 +73,60 @@ func (h *ShopHandler) ListShops(c *fiber.Ctx) error {
 	return response.JSONSuccess(c, shopListResponse)
 }
 
+// ListShopsNearby handles GET /shops/nearby to find active shops within a radius of a point
+// @Summary List nearby shops
+// @Description Get a paginated list of active shops within radius_km of a point, nearest first, with the distance to each shop. Shops without coordinates are never returned.
+// @Tags shops
+// @Accept json
+// @Produce json
+// @Param lat query number true "Latitude of the search point (-90 to 90)"
+// @Param lng query number true "Longitude of the search point (-180 to 180)"
+// @Param radius_km query number true "Search radius in kilometers (greater than 0, max 500)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 10, max: 100)"
+// @Success 200 {object} response.Response{data=model.NearbyShopListResponse}
+// @Failure 400 {object} response.Response{error=response.ErrorInfo}
+// @Failure 500 {object} response.Response{error=response.ErrorInfo}
+// @Router /shops/nearby [get]
+func (h *ShopHandler) ListShopsNearby(c *fiber.Ctx) error {
+	// Extract the search point and radius
+	latitude, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		return response.JSONError(c, appErrors.WithMessage(appErrors.ErrInvalidInput, "lat query parameter must be a number"), h.Log)
+	}
+
+	longitude, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil {
+		return response.JSONError(c, appErrors.WithMessage(appErrors.ErrInvalidInput, "lng query parameter must be a number"), h.Log)
+	}
+
+	radiusKm, err := strconv.ParseFloat(c.Query("radius_km"), 64)
+	if err != nil {
+		return response.JSONError(c, appErrors.WithMessage(appErrors.ErrInvalidInput, "radius_km query parameter must be a number"), h.Log)
+	}
+
+	// Extract pagination parameters
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	// Get nearby shops from use case
+	shops, totalCount, err := h.ShopUsecase.ListShopsNearby(c.Context(), latitude, longitude, radiusKm, page, pageSize)
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to list nearby shops")
+		return response.JSONError(c, err, h.Log)
+	}
+
+	// Return JSON response
+	return response.JSONSuccess(c, converter.ToNearbyShopListResponse(shops, totalCount, page, pageSize))
+}
+
 // GetShopByID handles GET /shops/:id to retrieve a single shop by ID with its warehouse references
 // @Summary Get shop by ID
 // @Description Get detailed shop information by ID including warehouse references
@@ -118,6 +173,8 @@ func (h *ShopHandler) GetShopByID(c *fiber.Ctx) error {
 // @Failure 400 {object} response.Response{error=response.ErrorInfo}
 // @Failure 404 {object} response.Response{error=response.ErrorInfo}
 // @Failure 500 {object} response.Response{error=response.ErrorInfo}
+// @Failure 503 {object} response.Response{error=response.ErrorInfo}
+// @Failure 504 {object} response.Response{error=response.ErrorInfo}
 // @Router /shops/{id}/warehouses [get]
 func (h *ShopHandler) GetShopWarehouses(c *fiber.Ctx) error {
 	// Parse shop ID from URL parameter
@@ -176,4 +233,75 @@ func (h *ShopHandler) CreateShop(c *fiber.Ctx) error {
 		Success: true,
 		Data:    shopResponse,
 	})
-}
\ No newline at end of file
+}
+
+// UpdateShop handles PUT /shops/:id to update a shop
+// @Summary Update a shop
+// @Description Update a shop. Only the fields provided in the request are changed.
+// @Tags shops
+// @Accept json
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Param shop body model.UpdateShopRequest true "Shop fields to update"
+// @Success 200 {object} response.Response{data=model.ShopResponse}
+// @Failure 400 {object} response.Response{error=response.ErrorInfo}
+// @Failure 404 {object} response.Response{error=response.ErrorInfo}
+// @Failure 409 {object} response.Response{error=response.ErrorInfo}
+// @Failure 500 {object} response.Response{error=response.ErrorInfo}
+// @Router /shops/{id} [put]
+func (h *ShopHandler) UpdateShop(c *fiber.Ctx) error {
+	// Parse shop ID from URL parameter
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		h.Log.WithError(err).Error("Invalid shop ID format")
+		return response.HandleError(c, fiber.NewError(fiber.StatusBadRequest, "Invalid shop ID format"), h.Log)
+	}
+
+	// Parse request body
+	var req model.UpdateShopRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.Log.WithError(err).Error("Failed to parse shop update request")
+		return response.HandleError(c, fiber.NewError(fiber.StatusBadRequest, "Invalid request format"), h.Log)
+	}
+
+	// Update shop through use case
+	shop, err := h.ShopUsecase.UpdateShop(c.Context(), uint(id), &req)
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to update shop")
+		return response.JSONError(c, err, h.Log)
+	}
+
+	// Return JSON response
+	return response.JSONSuccess(c, converter.ToShopResponse(shop))
+}
+
+// DeleteShop handles DELETE /shops/:id to delete a shop
+// @Summary Delete a shop
+// @Description Delete a shop together with its warehouse assignments
+// @Tags shops
+// @Produce json
+// @Param id path int true "Shop ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.Response{error=response.ErrorInfo}
+// @Failure 404 {object} response.Response{error=response.ErrorInfo}
+// @Failure 500 {object} response.Response{error=response.ErrorInfo}
+// @Router /shops/{id} [delete]
+func (h *ShopHandler) DeleteShop(c *fiber.Ctx) error {
+	// Parse shop ID from URL parameter
+	idParam := c.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		h.Log.WithError(err).Error("Invalid shop ID format")
+		return response.HandleError(c, fiber.NewError(fiber.StatusBadRequest, "Invalid shop ID format"), h.Log)
+	}
+
+	// Delete shop through use case
+	if err := h.ShopUsecase.DeleteShop(c.Context(), uint(id)); err != nil {
+		h.Log.WithError(err).Error("Failed to delete shop")
+		return response.JSONError(c, err, h.Log)
+	}
+
+	// Return success with 204 status (No Content)
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
@@ -449,4 +449,411 @@ func TestShopHandler_CreateShop_UsecaseError(t *testing.T) {
 	
 	// Verify expectations
 	mockShopUsecase.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestShopHandler_UpdateShop_Success(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Put("/api/v1/shops/:id", handler.UpdateShop)
+	
+	// Mock request data
+	isActive := false
+	updateRequest := model.UpdateShopRequest{
+		Name:         "Updated Shop",
+		ContactEmail: "updated@example.com",
+		IsActive:     &isActive,
+	}
+	
+	requestBody, err := json.Marshal(updateRequest)
+	assert.NoError(t, err)
+	
+	// Mock data - the updated shop
+	now := time.Now()
+	mockShop := &entity.Shop{
+		ID:           1,
+		Name:         updateRequest.Name,
+		Description:  "A shop for testing",
+		Address:      "123 Test St",
+		ContactEmail: updateRequest.ContactEmail,
+		ContactPhone: "1234567890",
+		IsActive:     false,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	
+	// Set mock expectations
+	mockShopUsecase.On("UpdateShop", mock.Anything, uint(1), &updateRequest).Return(mockShop, nil)
+	
+	// Create a test request
+	req, err := http.NewRequest("PUT", "/api/v1/shops/1", bytes.NewBuffer(requestBody))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 200 OK
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	// Parse response body
+	var responseBody struct {
+		Success bool               `json:"success"`
+		Data    model.ShopResponse `json:"data"`
+	}
+	
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	assert.NoError(t, err)
+	
+	// Assert response content
+	assert.True(t, responseBody.Success)
+	assert.Equal(t, uint(1), responseBody.Data.ID)
+	assert.Equal(t, "Updated Shop", responseBody.Data.Name)
+	assert.Equal(t, "updated@example.com", responseBody.Data.ContactEmail)
+	assert.Equal(t, "123 Test St", responseBody.Data.Address)
+	assert.False(t, responseBody.Data.IsActive)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_UpdateShop_InvalidID(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Put("/api/v1/shops/:id", handler.UpdateShop)
+	
+	// Create a test request with invalid ID
+	req, err := http.NewRequest("PUT", "/api/v1/shops/invalid", bytes.NewBuffer([]byte(`{"name": "Updated Shop"}`)))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 400 Bad Request
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	
+	// Verify the usecase was not called
+	mockShopUsecase.AssertNotCalled(t, "UpdateShop", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestShopHandler_UpdateShop_InvalidRequest(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Put("/api/v1/shops/:id", handler.UpdateShop)
+	
+	// Invalid request - malformed email
+	invalidRequest := `{"contact_email": "not-an-email"}`
+	
+	// Mock validation error
+	mockShopUsecase.On("UpdateShop", mock.Anything, uint(1), mock.Anything).Return(nil, appErrors.WithError(appErrors.ErrInvalidInput, errors.New("validation error")))
+	
+	// Create a test request with invalid body
+	req, err := http.NewRequest("PUT", "/api/v1/shops/1", bytes.NewBuffer([]byte(invalidRequest)))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 400 Bad Request
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_UpdateShop_NotFound(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Put("/api/v1/shops/:id", handler.UpdateShop)
+	
+	// Set mock expectations
+	mockShopUsecase.On("UpdateShop", mock.Anything, uint(999), mock.Anything).Return(nil, appErrors.ErrShopNotFound)
+	
+	// Create a test request
+	req, err := http.NewRequest("PUT", "/api/v1/shops/999", bytes.NewBuffer([]byte(`{"name": "Updated Shop"}`)))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 404 Not Found
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_UpdateShop_DuplicateName(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Put("/api/v1/shops/:id", handler.UpdateShop)
+	
+	// Set mock expectations
+	mockShopUsecase.On("UpdateShop", mock.Anything, uint(1), mock.Anything).Return(nil, appErrors.ErrDuplicateShopName)
+	
+	// Create a test request
+	req, err := http.NewRequest("PUT", "/api/v1/shops/1", bytes.NewBuffer([]byte(`{"name": "Existing Shop"}`)))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 409 Conflict
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	
+	// Parse response body
+	var responseBody struct {
+		Success bool `json:"success"`
+		Error   struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	assert.NoError(t, err)
+	assert.False(t, responseBody.Success)
+	assert.Equal(t, "DUPLICATE_SHOP_NAME", responseBody.Error.Code)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_UpdateShop_UsecaseError(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Put("/api/v1/shops/:id", handler.UpdateShop)
+	
+	// Mock error
+	mockError := appErrors.WithError(appErrors.ErrInternalServer, errors.New("database error"))
+	
+	// Set mock expectations
+	mockShopUsecase.On("UpdateShop", mock.Anything, uint(1), mock.Anything).Return(nil, mockError)
+	
+	// Create a test request
+	req, err := http.NewRequest("PUT", "/api/v1/shops/1", bytes.NewBuffer([]byte(`{"name": "Updated Shop"}`)))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 500 Internal Server Error
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_DeleteShop_Success(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Delete("/api/v1/shops/:id", handler.DeleteShop)
+	
+	// Set mock expectations
+	mockShopUsecase.On("DeleteShop", mock.Anything, uint(1)).Return(nil)
+	
+	// Create a test request
+	req, err := http.NewRequest("DELETE", "/api/v1/shops/1", nil)
+	assert.NoError(t, err)
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 204 No Content
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_DeleteShop_InvalidID(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Delete("/api/v1/shops/:id", handler.DeleteShop)
+	
+	// Create a test request with invalid ID
+	req, err := http.NewRequest("DELETE", "/api/v1/shops/invalid", nil)
+	assert.NoError(t, err)
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 400 Bad Request
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	
+	// Verify the usecase was not called
+	mockShopUsecase.AssertNotCalled(t, "DeleteShop", mock.Anything, mock.Anything)
+}
+
+func TestShopHandler_DeleteShop_NotFound(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Delete("/api/v1/shops/:id", handler.DeleteShop)
+	
+	// Set mock expectations
+	mockShopUsecase.On("DeleteShop", mock.Anything, uint(999)).Return(appErrors.ErrShopNotFound)
+	
+	// Create a test request
+	req, err := http.NewRequest("DELETE", "/api/v1/shops/999", nil)
+	assert.NoError(t, err)
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 404 Not Found
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_ListShopsNearby_Success(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Get("/api/v1/shops/nearby", handler.ListShopsNearby)
+	
+	// Mock data
+	latitude, longitude := 40.7130, -74.0060
+	mockShops := []entity.NearbyShop{
+		{
+			Shop: entity.Shop{
+				ID:        1,
+				Name:      "Near Shop",
+				Latitude:  &latitude,
+				Longitude: &longitude,
+				IsActive:  true,
+			},
+			DistanceKm: 0.02234,
+		},
+	}
+	
+	// Set mock expectations
+	mockShopUsecase.On("ListShopsNearby", mock.Anything, 40.7128, -74.006, 5.0, 2, 20).
+		Return(mockShops, int64(21), nil)
+	
+	// Create a test request
+	req, err := http.NewRequest("GET", "/api/v1/shops/nearby?lat=40.7128&lng=-74.006&radius_km=5&page=2&page_size=20", nil)
+	assert.NoError(t, err)
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 200 OK
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	
+	// Parse response body
+	var responseBody struct {
+		Success bool                         `json:"success"`
+		Data    model.NearbyShopListResponse `json:"data"`
+	}
+	
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	assert.NoError(t, err)
+	
+	// Assert response content
+	assert.True(t, responseBody.Success)
+	assert.Equal(t, int64(21), responseBody.Data.TotalCount)
+	assert.Equal(t, 2, responseBody.Data.Page)
+	assert.Equal(t, 20, responseBody.Data.PageSize)
+	if assert.Len(t, responseBody.Data.Shops, 1) {
+		assert.Equal(t, "Near Shop", responseBody.Data.Shops[0].Name)
+		assert.Equal(t, 0.022, responseBody.Data.Shops[0].DistanceKm)
+		assert.Equal(t, latitude, *responseBody.Data.Shops[0].Latitude)
+	}
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
+
+func TestShopHandler_ListShopsNearby_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing latitude", "lng=-74.006&radius_km=5"},
+		{"non-numeric longitude", "lat=40.7128&lng=west&radius_km=5"},
+		{"missing radius", "lat=40.7128&lng=-74.006"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockShopUsecase, handler, app := setupShopHandlerTest(t)
+			
+			// Register the route
+			app.Get("/api/v1/shops/nearby", handler.ListShopsNearby)
+			
+			// Create a test request
+			req, err := http.NewRequest("GET", "/api/v1/shops/nearby?"+tt.query, nil)
+			assert.NoError(t, err)
+			
+			// Perform the request
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			
+			// Assert status code - should be 400 Bad Request
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			
+			// Verify the usecase was not called
+			mockShopUsecase.AssertNotCalled(t, "ListShopsNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShopHandler_ListShopsNearby_OutOfRange(t *testing.T) {
+	// Setup
+	mockShopUsecase, handler, app := setupShopHandlerTest(t)
+	
+	// Register the route
+	app.Get("/api/v1/shops/nearby", handler.ListShopsNearby)
+	
+	// Mock validation error
+	mockShopUsecase.On("ListShopsNearby", mock.Anything, 91.0, 0.0, 5.0, 1, 10).
+		Return(nil, int64(0), appErrors.WithMessage(appErrors.ErrInvalidInput, "Latitude must be between -90 and 90"))
+	
+	// Create a test request
+	req, err := http.NewRequest("GET", "/api/v1/shops/nearby?lat=91&lng=0&radius_km=5", nil)
+	assert.NoError(t, err)
+	
+	// Perform the request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	
+	// Assert status code - should be 400 Bad Request
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	
+	// Verify expectations
+	mockShopUsecase.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"math"
 	"shop-service/internal/entity"
 	"shop-service/internal/model"
 )
@@ -16,6 +17,8 @@ func ToShopResponse(shop *entity.Shop) *model.ShopResponse {
 		Name:         shop.Name,
 		Description:  shop.Description,
 		Address:      shop.Address,
+		Latitude:     shop.Latitude,
+		Longitude:    shop.Longitude,
 		ContactEmail: shop.ContactEmail,
 		ContactPhone: shop.ContactPhone,
 		IsActive:     shop.IsActive,
@@ -59,4 +62,24 @@ func ToShopListResponse(shops []entity.Shop, totalCount int64, page, pageSize in
 		Page:       page,
 		PageSize:   pageSize,
 	}
+}
+
+// ToNearbyShopListResponse converts shops found by a nearby search to a NearbyShopListResponse model.
+// Distances are rounded to the meter.
+func ToNearbyShopListResponse(shops []entity.NearbyShop, totalCount int64, page, pageSize int) *model.NearbyShopListResponse {
+	shopResponses := make([]model.NearbyShopResponse, 0, len(shops))
+
+	for _, shop := range shops {
+		shopResponses = append(shopResponses, model.NearbyShopResponse{
+			ShopResponse: *ToShopResponse(&shop.Shop),
+			DistanceKm:   math.Round(shop.DistanceKm*1000) / 1000,
+		})
+	}
+
+	return &model.NearbyShopListResponse{
+		Shops:      shopResponses,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+	}
 }
\ No newline at end of file
@@ -0,0 +1,25 @@
+package model
+
+// Health statuses reported by the health endpoints
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusReady    = "ready"
+	HealthStatusNotReady = "not_ready"
+	HealthStatusUp       = "up"
+	HealthStatusDown     = "down"
+)
+
+// HealthResponse holds the status of the service and, for readiness, of each dependency
+// @Description Service health with the status of each dependency
+type HealthResponse struct {
+	Status       string                      `json:"status" example:"ready"`
+	Dependencies map[string]DependencyHealth `json:"dependencies,omitempty"`
+}
+
+// DependencyHealth holds the status of a single dependency
+// @Description Status of a dependency such as the database or a downstream service
+type DependencyHealth struct {
+	Status         string `json:"status" example:"up"`
+	Error          string `json:"error,omitempty" example:"dial tcp: connection refused"`
+	CircuitBreaker string `json:"circuit_breaker,omitempty" example:"closed"`
+}
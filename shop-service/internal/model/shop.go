@@ -9,10 +9,12 @@ import (
 type CreateShopRequest struct {
 	Name         string `json:"name" validate:"required,min=3,max=255" example:"Downtown Bookstore"`
 	Description  string `json:"description" validate:"omitempty" example:"Our flagship bookstore location"`
-	Address      string `json:"address" validate:"required" example:"123 Main St, New York, NY 10001"`
-	ContactEmail string `json:"contact_email" validate:"required,email" example:"contact@example.com"`
-	ContactPhone string `json:"contact_phone" validate:"required" example:"+1-555-123-4567"`
-	IsActive     bool   `json:"is_active" example:"true"`
+	Address      string   `json:"address" validate:"required" example:"123 Main St, New York, NY 10001"`
+	Latitude     *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude" example:"40.7128"`
+	Longitude    *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude" example:"-74.006"`
+	ContactEmail string   `json:"contact_email" validate:"required,email" example:"contact@example.com"`
+	ContactPhone string   `json:"contact_phone" validate:"required" example:"+1-555-123-4567"`
+	IsActive     bool     `json:"is_active" example:"true"`
 }
 
 // UpdateShopRequest holds the data needed to update a shop
@@ -20,10 +22,12 @@ type CreateShopRequest struct {
 type UpdateShopRequest struct {
 	Name         string `json:"name" validate:"omitempty,min=3,max=255" example:"Updated Bookstore"`
 	Description  string `json:"description" validate:"omitempty" example:"Our rebranded bookstore location"`
-	Address      string `json:"address" validate:"omitempty" example:"456 Broadway, New York, NY 10012"`
-	ContactEmail string `json:"contact_email" validate:"omitempty,email" example:"new-contact@example.com"`
-	ContactPhone string `json:"contact_phone" validate:"omitempty" example:"+1-555-987-6543"`
-	IsActive     *bool  `json:"is_active" validate:"omitempty" example:"false"`
+	Address      string   `json:"address" validate:"omitempty" example:"456 Broadway, New York, NY 10012"`
+	Latitude     *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude" example:"40.7233"`
+	Longitude    *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude" example:"-73.9985"`
+	ContactEmail string   `json:"contact_email" validate:"omitempty,email" example:"new-contact@example.com"`
+	ContactPhone string   `json:"contact_phone" validate:"omitempty" example:"+1-555-987-6543"`
+	IsActive     *bool    `json:"is_active" validate:"omitempty" example:"false"`
 }
 
 // ShopResponse holds the data returned for a shop
@@ -33,6 +37,8 @@ type ShopResponse struct {
 	Name         string    `json:"name" example:"Downtown Bookstore"`
 	Description  string    `json:"description" example:"Our flagship bookstore location"`
 	Address      string    `json:"address" example:"123 Main St, New York, NY 10001"`
+	Latitude     *float64  `json:"latitude,omitempty" example:"40.7128"`
+	Longitude    *float64  `json:"longitude,omitempty" example:"-74.006"`
 	ContactEmail string    `json:"contact_email" example:"contact@example.com"`
 	ContactPhone string    `json:"contact_phone" example:"+1-555-123-4567"`
 	IsActive     bool      `json:"is_active" example:"true"`
@@ -66,4 +72,23 @@ type ShopListResponse struct {
 	TotalCount int64          `json:"total_count" example:"42"`
 	Page       int            `json:"page" example:"1"`
 	PageSize   int            `json:"page_size" example:"10"`
+}
+
+// MaxNearbyRadiusKm is the largest radius accepted by the nearby shop search
+const MaxNearbyRadiusKm = 500
+
+// NearbyShopResponse holds a shop together with its distance from the search point
+// @Description Shop found by a nearby search with its distance in kilometers
+type NearbyShopResponse struct {
+	ShopResponse
+	DistanceKm float64 `json:"distance_km" example:"1.254"`
+}
+
+// NearbyShopListResponse holds a page of shops ordered by distance
+// @Description Paginated list of shops within a radius, nearest first
+type NearbyShopListResponse struct {
+	Shops      []NearbyShopResponse `json:"shops"`
+	TotalCount int64                `json:"total_count" example:"3"`
+	Page       int                  `json:"page" example:"1"`
+	PageSize   int                  `json:"page_size" example:"10"`
 }
\ No newline at end of file
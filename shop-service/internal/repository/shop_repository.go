@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"math"
 	"shop-service/internal/entity"
 
 	"github.com/sirupsen/logrus"
@@ -12,6 +13,9 @@ type ShopRepositoryInterface interface {
 	// FindAll retrieves a paginated list of shops
 	FindAll(db *gorm.DB, page, pageSize int, searchTerm string, includeInactive bool) ([]entity.Shop, int64, error)
 	
+	// FindNearby retrieves a paginated list of active shops within radiusKm of a point, nearest first
+	FindNearby(db *gorm.DB, latitude, longitude, radiusKm float64, page, pageSize int) ([]entity.NearbyShop, int64, error)
+
 	// FindByID finds a shop by its ID
 	FindByID(db *gorm.DB, id uint) (*entity.Shop, error)
 
@@ -82,6 +86,94 @@ func (r *ShopRepository) FindAll(db *gorm.DB, page, pageSize int, searchTerm str
 	return shops, totalCount, nil
 }
 
+// earthRadiusKm is the mean radius of the Earth used for distance calculations
+const earthRadiusKm = 6371.0
+
+// haversineDistanceSQL computes the great-circle distance in kilometers between a shop and the point bound to
+// its three placeholders (latitude, longitude, latitude). LEAST guards ACOS against rounding just above 1.
+const haversineDistanceSQL = "6371 * ACOS(LEAST(1, COS(RADIANS(?)) * COS(RADIANS(latitude)) * COS(RADIANS(longitude) - RADIANS(?)) + SIN(RADIANS(?)) * SIN(RADIANS(latitude))))"
+
+// FindNearby retrieves a paginated list of active shops within radiusKm of a point, nearest first.
+// A bounding box on the indexed coordinate columns narrows the candidates before the exact haversine distance is applied.
+func (r *ShopRepository) FindNearby(db *gorm.DB, latitude, longitude, radiusKm float64, page, pageSize int) ([]entity.NearbyShop, int64, error) {
+	var shops []entity.NearbyShop
+	var totalCount int64
+
+	box := newBoundingBox(latitude, longitude, radiusKm)
+
+	query := db.Model(&entity.Shop{}).
+		Where("is_active = ?", true).
+		Where("latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude)
+
+	if box.LimitsLongitude {
+		query = query.Where("longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude)
+	} else {
+		query = query.Where("longitude IS NOT NULL")
+	}
+
+	query = query.Where(haversineDistanceSQL+" <= ?", latitude, longitude, latitude, radiusKm)
+
+	// Count total results
+	err := query.Count(&totalCount).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * pageSize
+
+	// Execute the query
+	err = query.
+		Select("shops.*, "+haversineDistanceSQL+" AS distance_km", latitude, longitude, latitude).
+		Order("distance_km ASC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&shops).
+		Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return shops, totalCount, nil
+}
+
+// boundingBox is the latitude/longitude range that contains every point within a radius of a center point
+type boundingBox struct {
+	MinLatitude     float64
+	MaxLatitude     float64
+	MinLongitude    float64
+	MaxLongitude    float64
+	LimitsLongitude bool // false when the box reaches a pole or crosses the antimeridian
+}
+
+// newBoundingBox computes the bounding box of a circle on the Earth's surface
+func newBoundingBox(latitude, longitude, radiusKm float64) boundingBox {
+	angularRadius := radiusKm / earthRadiusKm
+	latitudeDelta := angularRadius * 180 / math.Pi
+
+	box := boundingBox{
+		MinLatitude: math.Max(latitude-latitudeDelta, -90),
+		MaxLatitude: math.Min(latitude+latitudeDelta, 90),
+	}
+
+	// Near a pole every longitude is within the radius
+	if box.MinLatitude <= -90 || box.MaxLatitude >= 90 {
+		return box
+	}
+
+	latitudeRadians := latitude * math.Pi / 180
+	longitudeDelta := math.Asin(math.Sin(angularRadius)/math.Cos(latitudeRadians)) * 180 / math.Pi
+
+	box.MinLongitude = longitude - longitudeDelta
+	box.MaxLongitude = longitude + longitudeDelta
+
+	// A box crossing the antimeridian would need two ranges; the haversine condition alone filters it instead
+	box.LimitsLongitude = box.MinLongitude >= -180 && box.MaxLongitude <= 180
+
+	return box
+}
+
 // FindByID finds a shop by its ID
 func (r *ShopRepository) FindByID(db *gorm.DB, id uint) (*entity.Shop, error) {
 	var shop entity.Shop
@@ -237,4 +237,64 @@ func TestShopRepository_FindAll_WithInactive(t *testing.T) {
 	
 	assert.True(t, hasActive)
 	assert.True(t, hasInactive)
-}
\ No newline at end of file
+}
+func TestShopRepository_FindNearby(t *testing.T) {
+	// Setup
+	db, mock, repo := setupShopRepositoryTest(t)
+	
+	latitude, longitude, radiusKm := 40.7128, -74.006, 5.0
+	
+	// Mock the count query - filtered by bounding box and haversine distance
+	countRows := sqlmock.NewRows([]string{"count"}).AddRow(int64(2))
+	mock.ExpectQuery("^SELECT count.*FROM `shops` WHERE is_active = \\? AND \\(latitude BETWEEN \\? AND \\?\\) AND \\(longitude BETWEEN \\? AND \\?\\) AND 6371 \\* ACOS.* <= \\?").
+		WillReturnRows(countRows)
+	
+	// Mock the find query - nearest first
+	rows := sqlmock.NewRows([]string{"id", "name", "latitude", "longitude", "is_active", "distance_km"}).
+		AddRow(1, "Near Shop", 40.7130, -74.0060, true, 0.022).
+		AddRow(2, "Far Shop", 40.7500, -74.0000, true, 4.159)
+	mock.ExpectQuery("^SELECT shops\\.\\*, 6371 \\* ACOS.* AS distance_km FROM `shops` WHERE .* ORDER BY distance_km ASC LIMIT \\?").
+		WillReturnRows(rows)
+	
+	// Execute the method
+	shops, totalCount, err := repo.FindNearby(db, latitude, longitude, radiusKm, 1, 10)
+	
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), totalCount)
+	if !assert.Len(t, shops, 2) {
+		return
+	}
+	assert.Equal(t, uint(1), shops[0].ID)
+	assert.Equal(t, "Near Shop", shops[0].Name)
+	assert.InDelta(t, 40.7130, *shops[0].Latitude, 1e-9)
+	assert.InDelta(t, 0.022, shops[0].DistanceKm, 1e-9)
+	assert.InDelta(t, 4.159, shops[1].DistanceKm, 1e-9)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewBoundingBox(t *testing.T) {
+	// About 111 km per degree of latitude
+	box := newBoundingBox(0, 0, 111.195)
+	assert.InDelta(t, -1, box.MinLatitude, 1e-3)
+	assert.InDelta(t, 1, box.MaxLatitude, 1e-3)
+	assert.InDelta(t, -1, box.MinLongitude, 1e-3)
+	assert.InDelta(t, 1, box.MaxLongitude, 1e-3)
+	assert.True(t, box.LimitsLongitude)
+	
+	// Longitude degrees are shorter away from the equator, so the box is wider
+	box = newBoundingBox(60, 10, 111.195)
+	assert.InDelta(t, 59, box.MinLatitude, 1e-3)
+	assert.InDelta(t, 61, box.MaxLatitude, 1e-3)
+	assert.Greater(t, box.MaxLongitude-10, 1.9)
+	assert.True(t, box.LimitsLongitude)
+	
+	// A box crossing the antimeridian does not limit longitude
+	box = newBoundingBox(0, 179.9, 50)
+	assert.False(t, box.LimitsLongitude)
+	
+	// A box reaching a pole does not limit longitude
+	box = newBoundingBox(89.9, 0, 50)
+	assert.Equal(t, float64(90), box.MaxLatitude)
+	assert.False(t, box.LimitsLongitude)
+}
@@ -3,11 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"shop-service/internal/entity"
 	appErrors "shop-service/internal/errors"
 	"shop-service/internal/gateway"
 	"shop-service/internal/model"
 	"shop-service/internal/repository"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
@@ -19,6 +21,9 @@ type ShopUsecaseInterface interface {
 	// ListShops retrieves a paginated list of shops with optional filtering
 	ListShops(page, pageSize int, searchTerm string, includeInactive bool) ([]entity.Shop, int64, error)
 
+	// ListShopsNearby retrieves a paginated list of active shops within radiusKm of a point, nearest first
+	ListShopsNearby(ctx context.Context, latitude, longitude, radiusKm float64, page, pageSize int) ([]entity.NearbyShop, int64, error)
+
 	// GetShopByID retrieves a shop by its ID
 	GetShopByID(id uint) (*entity.Shop, error)
 
@@ -30,6 +35,12 @@ type ShopUsecaseInterface interface {
 	
 	// CreateShop creates a new shop
 	CreateShop(req *model.CreateShopRequest) (*entity.Shop, error)
+
+	// UpdateShop updates the fields of a shop that are set in the request
+	UpdateShop(ctx context.Context, id uint, req *model.UpdateShopRequest) (*entity.Shop, error)
+
+	// DeleteShop deletes a shop together with its warehouse assignments
+	DeleteShop(ctx context.Context, id uint) error
 }
 
 // ShopUsecase implements ShopUsecaseInterface
@@ -82,6 +93,38 @@ func (u *ShopUsecase) ListShops(page, pageSize int, searchTerm string, includeIn
 	return shops, totalCount, nil
 }
 
+// ListShopsNearby retrieves a paginated list of active shops within radiusKm of a point, nearest first
+func (u *ShopUsecase) ListShopsNearby(ctx context.Context, latitude, longitude, radiusKm float64, page, pageSize int) ([]entity.NearbyShop, int64, error) {
+	// Validate coordinates and radius; the negated ranges also reject NaN
+	if !(latitude >= -90 && latitude <= 90) {
+		return nil, 0, appErrors.WithMessage(appErrors.ErrInvalidInput, "Latitude must be between -90 and 90")
+	}
+	if !(longitude >= -180 && longitude <= 180) {
+		return nil, 0, appErrors.WithMessage(appErrors.ErrInvalidInput, "Longitude must be between -180 and 180")
+	}
+	if !(radiusKm > 0 && radiusKm <= model.MaxNearbyRadiusKm) {
+		return nil, 0, appErrors.WithMessage(appErrors.ErrInvalidInput, fmt.Sprintf("Radius must be greater than 0 and at most %d km", model.MaxNearbyRadiusKm))
+	}
+
+	// Validate page and pageSize
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	// Get the shops from repository
+	shops, totalCount, err := u.ShopRepo.FindNearby(u.DB.WithContext(ctx), latitude, longitude, radiusKm, page, pageSize)
+	if err != nil {
+		u.Log.WithError(err).Error("Failed to list nearby shops")
+		return nil, 0, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	return shops, totalCount, nil
+}
+
 // GetShopByID retrieves a shop by its ID
 func (u *ShopUsecase) GetShopByID(id uint) (*entity.Shop, error) {
 	if id == 0 {
@@ -153,30 +196,34 @@ func (u *ShopUsecase) GetShopWarehouses(ctx context.Context, shopID uint) (*mode
 		return response, nil
 	}
 
-	// Fetch detailed warehouse information for each warehouse ID
-	warehouses := make([]model.WarehouseResponse, 0, len(warehouseIDs))
+	// Fetch detailed warehouse information for all warehouse IDs in one batch
+	found, err := u.WarehouseGateway.GetWarehousesByIDs(ctx, warehouseIDs)
+	if err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"shop_id": shopID,
+		}).Warn("Failed to get warehouse information from warehouse service")
+		return nil, err
+	}
 
+	warehousesByID := make(map[uint]model.WarehouseResponse, len(found))
+	for _, warehouse := range found {
+		warehousesByID[warehouse.ID] = warehouse
+	}
+
+	// Keep the shop's warehouse order and skip warehouses the warehouse service did not return
+	warehouses := make([]model.WarehouseResponse, 0, len(warehouseIDs))
 	for _, warehouseID := range warehouseIDs {
-		warehouse, err := u.WarehouseGateway.GetWarehouseByID(ctx, warehouseID)
-		if err != nil {
-			// Log error but continue to fetch other warehouses if possible
+		warehouse, ok := warehousesByID[warehouseID]
+		if !ok {
 			u.Log.WithFields(logrus.Fields{
-				"error":        err.Error(),
 				"shop_id":      shopID,
 				"warehouse_id": warehouseID,
-			}).Warn("Failed to get warehouse information from warehouse service")
-
-			// Skip this warehouse and continue with others if it's a not found error
-			if errors.Is(err, appErrors.ErrWarehouseNotFound) {
-				continue
-			}
-
-			// For other errors, return the error
-			return nil, err
+			}).Warn("Warehouse not returned by warehouse service, omitting it")
+			continue
 		}
 
-		// Add warehouse to the list
-		warehouses = append(warehouses, *warehouse)
+		warehouses = append(warehouses, warehouse)
 	}
 
 	// Update response with warehouses
@@ -187,6 +234,9 @@ func (u *ShopUsecase) GetShopWarehouses(ctx context.Context, shopID uint) (*mode
 
 // CreateShop creates a new shop
 func (u *ShopUsecase) CreateShop(req *model.CreateShopRequest) (*entity.Shop, error) {
+	req.Name = normalizeText(req.Name)
+	req.ContactEmail = normalizeEmail(req.ContactEmail)
+
 	// Validate request
 	if err := u.Validate.Struct(req); err != nil {
 		u.Log.WithError(err).Error("Invalid shop creation request")
@@ -198,6 +248,8 @@ func (u *ShopUsecase) CreateShop(req *model.CreateShopRequest) (*entity.Shop, er
 		Name:         req.Name,
 		Description:  req.Description,
 		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
 		ContactEmail: req.ContactEmail,
 		ContactPhone: req.ContactPhone,
 		IsActive:     req.IsActive,
@@ -213,6 +265,9 @@ func (u *ShopUsecase) CreateShop(req *model.CreateShopRequest) (*entity.Shop, er
 	// Create shop in database
 	if err := u.ShopRepo.Create(tx, shop); err != nil {
 		tx.Rollback()
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, appErrors.ErrDuplicateShopName
+		}
 		u.Log.WithError(err).Error("Failed to create shop")
 		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
 	}
@@ -225,3 +280,109 @@ func (u *ShopUsecase) CreateShop(req *model.CreateShopRequest) (*entity.Shop, er
 
 	return shop, nil
 }
+
+// UpdateShop updates the fields of a shop that are set in the request
+func (u *ShopUsecase) UpdateShop(ctx context.Context, id uint, req *model.UpdateShopRequest) (*entity.Shop, error) {
+	if id == 0 {
+		return nil, appErrors.ErrInvalidInput
+	}
+
+	req.Name = normalizeText(req.Name)
+	req.ContactEmail = normalizeEmail(req.ContactEmail)
+
+	// Validate request
+	if err := u.Validate.Struct(req); err != nil {
+		u.Log.WithError(err).Error("Invalid shop update request")
+		return nil, appErrors.WithError(appErrors.ErrInvalidInput, err)
+	}
+
+	db := u.DB.WithContext(ctx)
+
+	// Get the existing shop
+	shop, err := u.ShopRepo.FindByID(db, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrShopNotFound
+		}
+		u.Log.WithError(err).Error("Failed to get shop for update")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Apply the fields that were provided
+	if req.Name != "" {
+		shop.Name = req.Name
+	}
+	if req.Description != "" {
+		shop.Description = req.Description
+	}
+	if req.Address != "" {
+		shop.Address = req.Address
+	}
+	if req.Latitude != nil && req.Longitude != nil {
+		shop.Latitude = req.Latitude
+		shop.Longitude = req.Longitude
+	}
+	if req.ContactEmail != "" {
+		shop.ContactEmail = req.ContactEmail
+	}
+	if req.ContactPhone != "" {
+		shop.ContactPhone = req.ContactPhone
+	}
+	if req.IsActive != nil {
+		shop.IsActive = *req.IsActive
+	}
+
+	// Save the shop; the unique name index rejects names used by another shop
+	if err := u.ShopRepo.Update(db, shop); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, appErrors.ErrDuplicateShopName
+		}
+		u.Log.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"shop_id": id,
+		}).Error("Failed to update shop")
+		return nil, appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	return shop, nil
+}
+
+// DeleteShop deletes a shop. Its shop_warehouses rows are removed by the ON DELETE CASCADE foreign key.
+func (u *ShopUsecase) DeleteShop(ctx context.Context, id uint) error {
+	if id == 0 {
+		return appErrors.ErrInvalidInput
+	}
+
+	db := u.DB.WithContext(ctx)
+
+	// Check that the shop exists
+	if _, err := u.ShopRepo.FindByID(db, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrShopNotFound
+		}
+		u.Log.WithError(err).Error("Failed to get shop for deletion")
+		return appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	// Delete the shop
+	if err := u.ShopRepo.Delete(db, id); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"shop_id": id,
+		}).Error("Failed to delete shop")
+		return appErrors.WithError(appErrors.ErrInternalServer, err)
+	}
+
+	return nil
+}
+
+// normalizeText removes leading and trailing whitespace and replaces each run of inner whitespace with a single space,
+// so " Electronics  Shop " is stored as "Electronics Shop" and collides with it on the unique name index
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// normalizeEmail trims an email and lowercases it, so differently typed forms of the same address compare equal
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
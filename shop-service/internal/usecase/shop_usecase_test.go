@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math"
 	"shop-service/internal/entity"
+	appErrors "shop-service/internal/errors"
 	"shop-service/internal/model"
 	"shop-service/mocks/gateway"
 	repoMocks "shop-service/mocks/repository_mocks"
@@ -319,8 +321,7 @@ func TestShopUsecase_GetShopWarehouses_Success(t *testing.T) {
 	// Set expectations
 	mockShopRepo.On("FindByID", db, shopID).Return(mockShop, nil)
 	mockShopWarehouseRepo.On("FindWarehouseIDsByShopID", db, shopID).Return(mockWarehouseIDs, nil)
-	mockWarehouseGateway.On("GetWarehouseByID", mock.Anything, uint(101)).Return(mockWarehouseResponse1, nil)
-	mockWarehouseGateway.On("GetWarehouseByID", mock.Anything, uint(102)).Return(mockWarehouseResponse2, nil)
+	mockWarehouseGateway.On("GetWarehousesByIDs", mock.Anything, mockWarehouseIDs).Return([]model.WarehouseResponse{*mockWarehouseResponse1, *mockWarehouseResponse2}, nil)
 	
 	// Execute
 	response, err := usecase.GetShopWarehouses(ctx, shopID)
@@ -341,6 +342,66 @@ func TestShopUsecase_GetShopWarehouses_Success(t *testing.T) {
 	mockWarehouseGateway.AssertExpectations(t)
 }
 
+func TestShopUsecase_GetShopWarehouses_SingleBatchCall(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	db, _, _, mockShopRepo, mockShopWarehouseRepo, mockWarehouseGateway, usecase := setupShopUsecaseTest(t)
+	
+	// Test parameters
+	shopID := uint(1)
+	mockWarehouseIDs := []uint{105, 101, 104, 102, 103}
+	
+	// The warehouse service returns warehouses in its own order and does not know warehouse 104
+	mockWarehouses := []model.WarehouseResponse{
+		{ID: 101, Name: "Warehouse 1"},
+		{ID: 102, Name: "Warehouse 2"},
+		{ID: 103, Name: "Warehouse 3"},
+		{ID: 105, Name: "Warehouse 5"},
+	}
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", db, shopID).Return(&entity.Shop{ID: shopID, Name: "Test Shop"}, nil)
+	mockShopWarehouseRepo.On("FindWarehouseIDsByShopID", db, shopID).Return(mockWarehouseIDs, nil)
+	mockWarehouseGateway.On("GetWarehousesByIDs", mock.Anything, mockWarehouseIDs).Return(mockWarehouses, nil).Once()
+	
+	// Execute
+	response, err := usecase.GetShopWarehouses(ctx, shopID)
+	
+	// Assertions; the shop's order is kept and the unknown warehouse is omitted
+	assert.NoError(t, err)
+	ids := make([]uint, len(response.Warehouses))
+	for i, warehouse := range response.Warehouses {
+		ids[i] = warehouse.ID
+	}
+	assert.Equal(t, []uint{105, 101, 102, 103}, ids)
+	
+	// Verify the warehouse service was called exactly once
+	mockWarehouseGateway.AssertNumberOfCalls(t, "GetWarehousesByIDs", 1)
+	mockWarehouseGateway.AssertNotCalled(t, "GetWarehouseByID", mock.Anything, mock.Anything)
+}
+
+func TestShopUsecase_GetShopWarehouses_GatewayError(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	db, _, _, mockShopRepo, mockShopWarehouseRepo, mockWarehouseGateway, usecase := setupShopUsecaseTest(t)
+	
+	// Test parameters
+	shopID := uint(1)
+	mockWarehouseIDs := []uint{101, 102}
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", db, shopID).Return(&entity.Shop{ID: shopID, Name: "Test Shop"}, nil)
+	mockShopWarehouseRepo.On("FindWarehouseIDsByShopID", db, shopID).Return(mockWarehouseIDs, nil)
+	mockWarehouseGateway.On("GetWarehousesByIDs", mock.Anything, mockWarehouseIDs).Return(nil, appErrors.ErrExternalServiceUnavailable)
+	
+	// Execute
+	response, err := usecase.GetShopWarehouses(ctx, shopID)
+	
+	// Assertions
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, appErrors.ErrExternalServiceUnavailable)
+}
+
 func TestShopUsecase_GetShopWarehouses_ShopNotFound(t *testing.T) {
 	// Setup
 	ctx := context.Background()
@@ -421,4 +482,308 @@ func TestShopUsecase_GetShopWarehouses_InvalidShopID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, response)
 	assert.Equal(t, "Invalid input data", err.Error())
-}
\ No newline at end of file
+}
+func TestShopUsecase_UpdateShop_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Mock data
+	mockShop := &entity.Shop{
+		ID:           1,
+		Name:         "Shop 1",
+		Description:  "Description 1",
+		Address:      "Address 1",
+		ContactEmail: "shop1@example.com",
+		ContactPhone: "1234567890",
+		IsActive:     true,
+	}
+	
+	// Only the name and active flag are updated
+	isActive := false
+	req := &model.UpdateShopRequest{
+		Name:     "Renamed Shop",
+		IsActive: &isActive,
+	}
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(mockShop, nil)
+	mockShopRepo.On("Update", mock.Anything, mock.MatchedBy(func(shop *entity.Shop) bool {
+		return shop.Name == "Renamed Shop" && !shop.IsActive && shop.Address == "Address 1"
+	})).Return(nil)
+	
+	// Execute
+	shop, err := usecase.UpdateShop(ctx, 1, req)
+	
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed Shop", shop.Name)
+	assert.Equal(t, "Description 1", shop.Description)
+	assert.Equal(t, "shop1@example.com", shop.ContactEmail)
+	assert.False(t, shop.IsActive)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_UpdateShop_InvalidInput(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Execute with a malformed email
+	shop, err := usecase.UpdateShop(ctx, 1, &model.UpdateShopRequest{ContactEmail: "not-an-email"})
+	
+	// Assertions
+	assert.Nil(t, shop)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	mockShopRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestShopUsecase_UpdateShop_NotFound(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+	
+	// Execute
+	shop, err := usecase.UpdateShop(ctx, 999, &model.UpdateShopRequest{Name: "Renamed Shop"})
+	
+	// Assertions
+	assert.Nil(t, shop)
+	assert.ErrorIs(t, err, appErrors.ErrShopNotFound)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_UpdateShop_DuplicateName(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations - the unique name index rejects the update
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(&entity.Shop{ID: 1, Name: "Shop 1"}, nil)
+	mockShopRepo.On("Update", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+	
+	// Execute
+	shop, err := usecase.UpdateShop(ctx, 1, &model.UpdateShopRequest{Name: "Shop 2"})
+	
+	// Assertions
+	assert.Nil(t, shop)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateShopName)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_UpdateShop_PaddedNameCollides(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations - the padded name reaches the unique name index trimmed, where another shop holds it
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(&entity.Shop{ID: 1, Name: "Shop 1"}, nil)
+	mockShopRepo.On("Update", mock.Anything, mock.MatchedBy(func(shop *entity.Shop) bool {
+		return shop.Name == "Electronics Shop"
+	})).Return(gorm.ErrDuplicatedKey)
+	
+	// Execute
+	shop, err := usecase.UpdateShop(ctx, 1, &model.UpdateShopRequest{Name: "  Electronics   Shop "})
+	
+	// Assertions
+	assert.Nil(t, shop)
+	assert.ErrorIs(t, err, appErrors.ErrDuplicateShopName)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_UpdateShop_NormalizesInput(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(&entity.Shop{ID: 1, Name: "Shop 1"}, nil)
+	mockShopRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	
+	// Execute
+	shop, err := usecase.UpdateShop(ctx, 1, &model.UpdateShopRequest{
+		Name:         "\tElectronics  Shop\n",
+		ContactEmail: " Sales@Example.COM ",
+	})
+	
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Electronics Shop", shop.Name)
+	assert.Equal(t, "sales@example.com", shop.ContactEmail)
+}
+
+func TestShopUsecase_UpdateShop_BlankNameIsIgnored(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations - a name of only whitespace counts as not provided
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(&entity.Shop{ID: 1, Name: "Shop 1"}, nil)
+	mockShopRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	
+	// Execute
+	shop, err := usecase.UpdateShop(ctx, 1, &model.UpdateShopRequest{Name: "   "})
+	
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Shop 1", shop.Name)
+}
+
+func TestShopUsecase_DeleteShop_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(&entity.Shop{ID: 1}, nil)
+	mockShopRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
+	
+	// Execute
+	err := usecase.DeleteShop(ctx, 1)
+	
+	// Assertions
+	assert.NoError(t, err)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_DeleteShop_NotFound(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+	
+	// Execute
+	err := usecase.DeleteShop(ctx, 999)
+	
+	// Assertions
+	assert.ErrorIs(t, err, appErrors.ErrShopNotFound)
+	mockShopRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestShopUsecase_DeleteShop_DatabaseError(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations
+	mockShopRepo.On("FindByID", mock.Anything, uint(1)).Return(&entity.Shop{ID: 1}, nil)
+	mockShopRepo.On("Delete", mock.Anything, uint(1)).Return(errors.New("database error"))
+	
+	// Execute
+	err := usecase.DeleteShop(ctx, 1)
+	
+	// Assertions
+	assert.ErrorIs(t, err, appErrors.ErrInternalServer)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_ListShopsNearby_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Mock data
+	mockShops := []entity.NearbyShop{
+		{Shop: entity.Shop{ID: 1, Name: "Near Shop"}, DistanceKm: 0.5},
+		{Shop: entity.Shop{ID: 2, Name: "Far Shop"}, DistanceKm: 4.2},
+	}
+	
+	// Invalid pagination falls back to the defaults
+	mockShopRepo.On("FindNearby", mock.Anything, 40.7128, -74.006, 5.0, 1, 10).
+		Return(mockShops, int64(2), nil)
+	
+	// Execute
+	shops, totalCount, err := usecase.ListShopsNearby(ctx, 40.7128, -74.006, 5, 0, 1000)
+	
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), totalCount)
+	assert.Equal(t, mockShops, shops)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_ListShopsNearby_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		radiusKm  float64
+	}{
+		{"latitude too low", -90.1, 0, 5},
+		{"latitude too high", 90.1, 0, 5},
+		{"latitude not a number", math.NaN(), 0, 5},
+		{"longitude too low", 0, -180.1, 5},
+		{"longitude too high", 0, 180.1, 5},
+		{"zero radius", 0, 0, 0},
+		{"negative radius", 0, 0, -1},
+		{"radius too large", 0, 0, model.MaxNearbyRadiusKm + 1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+			
+			// Execute
+			shops, _, err := usecase.ListShopsNearby(context.Background(), tt.latitude, tt.longitude, tt.radiusKm, 1, 10)
+			
+			// Assertions
+			assert.Nil(t, shops)
+			assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+			mockShopRepo.AssertNotCalled(t, "FindNearby", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShopUsecase_ListShopsNearby_DatabaseError(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Set expectations
+	mockShopRepo.On("FindNearby", mock.Anything, 0.0, 0.0, 5.0, 1, 10).
+		Return(nil, int64(0), errors.New("database error"))
+	
+	// Execute
+	shops, _, err := usecase.ListShopsNearby(ctx, 0, 0, 5, 1, 10)
+	
+	// Assertions
+	assert.Nil(t, shops)
+	assert.ErrorIs(t, err, appErrors.ErrInternalServer)
+	
+	// Verify expectations
+	mockShopRepo.AssertExpectations(t)
+}
+
+func TestShopUsecase_UpdateShop_LatitudeWithoutLongitude(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, _, _, mockShopRepo, _, _, usecase := setupShopUsecaseTest(t)
+	
+	// Coordinates must be provided together
+	latitude := 40.7128
+	shop, err := usecase.UpdateShop(ctx, 1, &model.UpdateShopRequest{Latitude: &latitude})
+	
+	// Assertions
+	assert.Nil(t, shop)
+	assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	mockShopRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
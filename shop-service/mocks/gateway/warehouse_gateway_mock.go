@@ -21,4 +21,15 @@ func (m *WarehouseGatewayMock) GetWarehouseByID(ctx context.Context, warehouseID
 	}
 	
 	return args.Get(0).(*model.WarehouseResponse), args.Error(1)
+}
+
+// GetWarehousesByIDs mocks the GetWarehousesByIDs method
+func (m *WarehouseGatewayMock) GetWarehousesByIDs(ctx context.Context, warehouseIDs []uint) ([]model.WarehouseResponse, error) {
+	args := m.Called(ctx, warehouseIDs)
+	
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	
+	return args.Get(0).([]model.WarehouseResponse), args.Error(1)
 }
\ No newline at end of file
@@ -122,6 +122,37 @@ func (_m *ShopRepositoryMock) FindByIDWithWarehouses(db *gorm.DB, id uint) (*ent
 	return r0, r1
 }
 
+// FindNearby provides a mock function with given fields: db, latitude, longitude, radiusKm, page, pageSize
+func (_m *ShopRepositoryMock) FindNearby(db *gorm.DB, latitude float64, longitude float64, radiusKm float64, page int, pageSize int) ([]entity.NearbyShop, int64, error) {
+	ret := _m.Called(db, latitude, longitude, radiusKm, page, pageSize)
+
+	var r0 []entity.NearbyShop
+	var r1 int64
+	var r2 error
+
+	if rf, ok := ret.Get(0).(func(*gorm.DB, float64, float64, float64, int, int) []entity.NearbyShop); ok {
+		r0 = rf(db, latitude, longitude, radiusKm, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.NearbyShop)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, float64, float64, float64, int, int) int64); ok {
+		r1 = rf(db, latitude, longitude, radiusKm, page, pageSize)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*gorm.DB, float64, float64, float64, int, int) error); ok {
+		r2 = rf(db, latitude, longitude, radiusKm, page, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: db, shop
 func (_m *ShopRepositoryMock) Update(db *gorm.DB, shop *entity.Shop) error {
 	ret := _m.Called(db, shop)
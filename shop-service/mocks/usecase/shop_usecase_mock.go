@@ -44,6 +44,37 @@ func (_m *ShopUsecaseMock) ListShops(page int, pageSize int, searchTerm string,
 	return r0, r1, r2
 }
 
+// ListShopsNearby provides a mock function
+func (_m *ShopUsecaseMock) ListShopsNearby(ctx context.Context, latitude float64, longitude float64, radiusKm float64, page int, pageSize int) ([]entity.NearbyShop, int64, error) {
+	ret := _m.Called(ctx, latitude, longitude, radiusKm, page, pageSize)
+
+	var r0 []entity.NearbyShop
+	var r1 int64
+	var r2 error
+
+	if rf, ok := ret.Get(0).(func(context.Context, float64, float64, float64, int, int) []entity.NearbyShop); ok {
+		r0 = rf(ctx, latitude, longitude, radiusKm, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.NearbyShop)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, float64, float64, float64, int, int) int64); ok {
+		r1 = rf(ctx, latitude, longitude, radiusKm, page, pageSize)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, float64, float64, float64, int, int) error); ok {
+		r2 = rf(ctx, latitude, longitude, radiusKm, page, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetShopByID provides a mock function
 func (_m *ShopUsecaseMock) GetShopByID(id uint) (*entity.Shop, error) {
 	ret := _m.Called(id)
@@ -138,4 +169,43 @@ func (_m *ShopUsecaseMock) CreateShop(req *model.CreateShopRequest) (*entity.Sho
 	}
 
 	return r0, r1
+}
+
+// UpdateShop provides a mock function
+func (_m *ShopUsecaseMock) UpdateShop(ctx context.Context, id uint, req *model.UpdateShopRequest) (*entity.Shop, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *entity.Shop
+	var r1 error
+
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *model.UpdateShopRequest) *entity.Shop); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Shop)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *model.UpdateShopRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteShop provides a mock function
+func (_m *ShopUsecaseMock) DeleteShop(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
\ No newline at end of file
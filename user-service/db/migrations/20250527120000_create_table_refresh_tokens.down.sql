@@ -0,0 +1 @@
+DROP Table refresh_tokens;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE refresh_tokens (
+    uuid       CHAR(36) NOT NULL,
+    user_uuid  CHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    INDEX idx_refresh_tokens_user_uuid (user_uuid),
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_uuid) REFERENCES users (uuid) ON DELETE CASCADE
+) ENGINE = InnoDB;
\ No newline at end of file
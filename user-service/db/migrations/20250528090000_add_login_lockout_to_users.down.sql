@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP COLUMN locked_until,
+    DROP COLUMN failed_login_attempts;
\ No newline at end of file
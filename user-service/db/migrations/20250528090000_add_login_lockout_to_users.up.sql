@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN failed_login_attempts INT NOT NULL DEFAULT 0 AFTER token,
+    ADD COLUMN locked_until TIMESTAMP NULL AFTER failed_login_attempts;
\ No newline at end of file
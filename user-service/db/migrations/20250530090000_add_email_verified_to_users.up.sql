@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE AFTER role;
+
+UPDATE users SET email_verified = TRUE;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP Table email_verification_tokens;
\ No newline at end of file
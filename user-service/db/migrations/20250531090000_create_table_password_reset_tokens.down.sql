@@ -0,0 +1 @@
+DROP Table password_reset_tokens;
\ No newline at end of file
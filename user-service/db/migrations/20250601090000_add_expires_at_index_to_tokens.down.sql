@@ -0,0 +1,6 @@
+ALTER TABLE password_reset_tokens
+    DROP INDEX idx_password_reset_tokens_expires_at;
+ALTER TABLE email_verification_tokens
+    DROP INDEX idx_email_verification_tokens_expires_at;
+ALTER TABLE refresh_tokens
+    DROP INDEX idx_refresh_tokens_expires_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE refresh_tokens
+    ADD INDEX idx_refresh_tokens_expires_at (expires_at);
+ALTER TABLE email_verification_tokens
+    ADD INDEX idx_email_verification_tokens_expires_at (expires_at);
+ALTER TABLE password_reset_tokens
+    ADD INDEX idx_password_reset_tokens_expires_at (expires_at);
\ No newline at end of file
@@ -17,13 +17,20 @@ const (
 	baseURL     = "http://app:3000"
 	registerURL = baseURL + "/api/v1/users"
 	loginURL    = baseURL + "/api/v1/users/login"
+	refreshURL  = baseURL + "/api/v1/users/refresh"
 	getUserURL  = baseURL + "/api/v1/users/" // Will be appended with user ID
 )
 
 // Response structures
 type WebResponse struct {
-	Data   json.RawMessage `json:"data"`
-	Errors string          `json:"errors,omitempty"`
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *ErrorInfo      `json:"error,omitempty"`
+}
+
+type ErrorInfo struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type UserResponse struct {
@@ -32,6 +39,9 @@ type UserResponse struct {
 	Email string `json:"email,omitempty"`
 	Phone string `json:"phone,omitempty"`
 	Token string `json:"token,omitempty"`
+
+	ExpiresAt    string `json:"expires_at,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 // Test user data
@@ -88,11 +98,15 @@ func TestUserRegistrationAndLogin(t *testing.T) {
 	require.NotEmpty(t, userID, "User ID should not be empty")
 
 	// 2. Login with the registered user
-	token := testLoginUser(t)
-	require.NotEmpty(t, token, "Token should not be empty after login")
+	login := testLoginUser(t)
+	require.NotEmpty(t, login.Token, "Token should not be empty after login")
 	
 	// 3. Get user details with authentication
-	testGetUserAuthenticated(t, userID, token)
+	testGetUserAuthenticated(t, userID, login.Token)
+
+	// 4. Exchange the refresh token for a new access token and use it
+	refreshedToken := testRefreshToken(t, login.RefreshToken)
+	testGetUserAuthenticated(t, userID, refreshedToken)
 }
 
 // testRegisterUser tests the user registration endpoint
@@ -146,7 +160,7 @@ func testRegisterUser(t *testing.T) string {
 }
 
 // testLoginUser tests the user login endpoint
-func testLoginUser(t *testing.T) string {
+func testLoginUser(t *testing.T) UserResponse {
 	t.Log("Testing user login...")
 
 	// Create login payload
@@ -189,10 +203,77 @@ func testLoginUser(t *testing.T) string {
 	err = json.Unmarshal(response.Data, &userResponse)
 	require.NoError(t, err, "Failed to parse user data")
 	require.NotEmpty(t, userResponse.Token, "Token should not be empty")
+	require.NotEmpty(t, userResponse.ExpiresAt, "Token expiry should not be empty")
+	require.NotEmpty(t, userResponse.RefreshToken, "Refresh token should not be empty")
+
+	return userResponse
+}
+
+// postRefreshToken sends a refresh token to the refresh endpoint
+func postRefreshToken(t *testing.T, refreshToken string) (int, []byte) {
+	jsonPayload, err := json.Marshal(map[string]interface{}{
+		"refresh_token": refreshToken,
+	})
+	require.NoError(t, err)
+
+	// Create a client with timeout
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	req, err := http.NewRequest("POST", refreshURL, bytes.NewBuffer(jsonPayload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, body
+}
+
+// requireErrorCode asserts that body is an error envelope carrying the given code
+func requireErrorCode(t *testing.T, body []byte, code string) {
+	var response WebResponse
+	require.NoError(t, json.Unmarshal(body, &response), "Failed to parse JSON response")
+	require.False(t, response.Success, "Expected an error response")
+	require.NotNil(t, response.Error, "Expected an error object")
+	require.Equal(t, code, response.Error.Code)
+}
+
+// testRefreshToken tests that a refresh token issued at login yields a new access token
+func testRefreshToken(t *testing.T, refreshToken string) string {
+	t.Log("Testing token refresh...")
+
+	statusCode, body := postRefreshToken(t, refreshToken)
+	t.Logf("Refresh Response: %s", string(body))
+	require.Equal(t, http.StatusOK, statusCode, "Expected status code 200")
+
+	var response WebResponse
+	err := json.Unmarshal(body, &response)
+	require.NoError(t, err, "Failed to parse JSON response")
+
+	var userResponse UserResponse
+	err = json.Unmarshal(response.Data, &userResponse)
+	require.NoError(t, err, "Failed to parse token data")
+	require.NotEmpty(t, userResponse.Token, "Token should not be empty")
+	require.NotEmpty(t, userResponse.ExpiresAt, "Token expiry should not be empty")
 
 	return userResponse.Token
 }
 
+// TestRefreshWithUnknownToken tests that refresh tokens that were never issued are rejected
+func TestRefreshWithUnknownToken(t *testing.T) {
+	t.Log("Testing refresh with an unknown token...")
+
+	statusCode, body := postRefreshToken(t, "never-issued-refresh-token")
+	t.Logf("Unknown Refresh Token Response (status %d): %s", statusCode, string(body))
+	require.Equal(t, http.StatusUnauthorized, statusCode, "Expected status code 401 for unknown refresh token")
+	requireErrorCode(t, body, "INVALID_REFRESH_TOKEN")
+}
+
 // TestInvalidLogin tests login with invalid credentials
 func TestInvalidLogin(t *testing.T) {
 	t.Log("Testing invalid login...")
@@ -396,4 +477,5 @@ func TestGetUserUnauthenticated(t *testing.T) {
 	body, err = io.ReadAll(resp.Body)
 	require.NoError(t, err)
 	t.Logf("Unauthenticated GetUser Response (status %d): %s", resp.StatusCode, string(body))
+	requireErrorCode(t, body, "UNAUTHORIZED")
 }
\ No newline at end of file
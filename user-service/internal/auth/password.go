@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+const (
+	// MinPasswordLength is the minimum number of characters a new password must have
+	MinPasswordLength = 8
+
+	// MaxPasswordBytes is the longest password bcrypt can hash without truncation
+	MaxPasswordBytes = 72
+)
+
+// ErrWeakPassword is returned for passwords that do not satisfy the strength policy
+var ErrWeakPassword = errors.New("password is too weak")
+
+// CheckPasswordStrength validates a new password against the strength policy: at least
+// MinPasswordLength characters, no more than MaxPasswordBytes bytes, and at least one letter and one digit
+func CheckPasswordStrength(password string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return fmt.Errorf("%w: must be at least %d characters long", ErrWeakPassword, MinPasswordLength)
+	}
+	if len(password) > MaxPasswordBytes {
+		return fmt.Errorf("%w: must be at most %d bytes long", ErrWeakPassword, MaxPasswordBytes)
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter {
+		return fmt.Errorf("%w: must contain at least one letter", ErrWeakPassword)
+	}
+	if !hasDigit {
+		return fmt.Errorf("%w: must contain at least one digit", ErrWeakPassword)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPasswordStrength(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{name: "strong", password: "correct4horse"},
+		{name: "minimum length", password: "abcdef12"},
+		{name: "too short", password: "abc12", wantErr: "at least 8 characters"},
+		{name: "too long for bcrypt", password: strings.Repeat("a1", 37), wantErr: "at most 72 bytes"},
+		{name: "no digit", password: "onlyletters", wantErr: "at least one digit"},
+		{name: "no letter", password: "1234567890", wantErr: "at least one letter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPasswordStrength(tt.password)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrWeakPassword)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
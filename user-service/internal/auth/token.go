@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// opaqueTokenBytes is the amount of randomness in refresh tokens and other opaque tokens
+const opaqueTokenBytes = 32
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed, signed with another key or algorithm, or carry no valid user ID
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrExpiredToken is returned for well-formed tokens whose expiry has passed
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Claims are the claims embedded in an access token. The user ID is stored in the subject.
+// Other services verify the same claims to authorize requests by role.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role,omitempty"`
+}
+
+// UserID returns the ID of the user the token was issued to
+func (c *Claims) UserID() (uuid.UUID, error) {
+	return uuid.Parse(c.Subject)
+}
+
+type TokenManagerInterface interface {
+	// Issue signs an access token for the user and role and returns it with its expiry
+	Issue(userID uuid.UUID, role string) (string, time.Time, error)
+
+	// IssueRefreshToken generates an opaque refresh token and returns it with its expiry
+	IssueRefreshToken() (string, time.Time, error)
+
+	// Verify checks the token's signature and expiry and returns its claims
+	Verify(token string) (*Claims, error)
+}
+
+// TokenManager issues and verifies HS256-signed JWT access tokens and generates refresh tokens
+type TokenManager struct {
+	Secret     []byte
+	TTL        time.Duration
+	RefreshTTL time.Duration
+}
+
+func NewTokenManager(secret string, ttl time.Duration, refreshTTL time.Duration) TokenManagerInterface {
+	return &TokenManager{
+		Secret:     []byte(secret),
+		TTL:        ttl,
+		RefreshTTL: refreshTTL,
+	}
+}
+
+// Issue signs an access token for the user and role and returns it with its expiry
+func (m *TokenManager) Issue(userID uuid.UUID, role string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.TTL)
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: role,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.Secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// IssueRefreshToken generates an opaque refresh token and returns it with its expiry.
+// Only the token's hash should be persisted, see HashRefreshToken.
+func (m *TokenManager) IssueRefreshToken() (string, time.Time, error) {
+	token, err := NewOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, time.Now().Add(m.RefreshTTL), nil
+}
+
+// HashRefreshToken returns the hex-encoded SHA-256 digest under which a refresh token is stored
+func HashRefreshToken(token string) string {
+	return HashOpaqueToken(token)
+}
+
+// NewOpaqueToken generates a random URL-safe token, such as a refresh or email verification token.
+// Only the token's hash should be persisted, see HashOpaqueToken.
+func NewOpaqueToken() (string, error) {
+	buf := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashOpaqueToken returns the hex-encoded SHA-256 digest under which an opaque token is stored
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks the token's signature and expiry and returns its claims
+func (m *TokenManager) Verify(token string) (*Claims, error) {
+	claims := new(Claims)
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return m.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if _, err := claims.UserID(); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func TestTokenManager_Verify_ValidToken(t *testing.T) {
+	manager := NewTokenManager(testSecret, time.Hour, time.Hour)
+	userID := uuid.New()
+
+	token, expiresAt, err := manager.Issue(userID, "admin")
+	require.NoError(t, err)
+
+	claims, err := manager.Verify(token)
+	require.NoError(t, err)
+
+	gotUserID, err := claims.UserID()
+	assert.NoError(t, err)
+	assert.Equal(t, userID, gotUserID)
+	assert.Equal(t, "admin", claims.Role)
+	assert.WithinDuration(t, time.Now(), claims.IssuedAt.Time, 2*time.Second)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, 2*time.Second)
+	assert.WithinDuration(t, expiresAt, claims.ExpiresAt.Time, time.Second)
+}
+
+func TestTokenManager_Verify_ExpiredToken(t *testing.T) {
+	// A negative TTL issues tokens that expired before they were handed out
+	manager := NewTokenManager(testSecret, -time.Minute, time.Hour)
+
+	token, _, err := manager.Issue(uuid.New(), "customer")
+	require.NoError(t, err)
+
+	claims, err := manager.Verify(token)
+	assert.ErrorIs(t, err, ErrExpiredToken)
+	assert.Nil(t, claims)
+}
+
+func TestTokenManager_Verify_TamperedSignature(t *testing.T) {
+	manager := NewTokenManager(testSecret, time.Hour, time.Hour)
+
+	token, _, err := manager.Issue(uuid.New(), "customer")
+	require.NoError(t, err)
+
+	// Flip the first character of the signature
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature := []byte(parts[2])
+	if signature[0] == 'A' {
+		signature[0] = 'B'
+	} else {
+		signature[0] = 'A'
+	}
+	tampered := strings.Join([]string{parts[0], parts[1], string(signature)}, ".")
+
+	claims, err := manager.Verify(tampered)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Nil(t, claims)
+}
+
+func TestTokenManager_Verify_RejectsForeignTokens(t *testing.T) {
+	manager := NewTokenManager(testSecret, time.Hour, time.Hour)
+	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	otherKeyToken, _, err := NewTokenManager("another-secret", time.Hour, time.Hour).Issue(uuid.New(), "customer")
+	require.NoError(t, err)
+
+	unsignedToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+		Subject:   uuid.New().String(),
+		ExpiresAt: expiresAt,
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	noSubjectToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: expiresAt,
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	noExpiryToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject: uuid.New().String(),
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"SignedWithAnotherKey": otherKeyToken,
+		"Unsigned":             unsignedToken,
+		"MissingUserID":        noSubjectToken,
+		"MissingExpiry":        noExpiryToken,
+		"Malformed":            "not-a-jwt",
+		"LegacyUUIDToken":      uuid.New().String(),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims, err := manager.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestTokenManager_IssueRefreshToken(t *testing.T) {
+	manager := NewTokenManager(testSecret, time.Hour, 30*24*time.Hour)
+
+	first, expiresAt, err := manager.IssueRefreshToken()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), expiresAt, 2*time.Second)
+
+	second, _, err := manager.IssueRefreshToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	// Refresh tokens are opaque and must not be accepted as access tokens
+	_, err = manager.Verify(first)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestHashRefreshToken(t *testing.T) {
+	hash := HashRefreshToken("refresh-token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashRefreshToken("refresh-token"))
+	assert.NotEqual(t, hash, HashRefreshToken("other-refresh-token"))
+	assert.NotContains(t, hash, "refresh-token")
+}
@@ -1,11 +1,14 @@
 package config
 
 import (
+	"context"
 	"user-service/internal/delivery/http/middleware"
 	"user-service/internal/delivery/http/route"
 	"user-service/internal/entity"
 	"user-service/internal/handler"
+	"user-service/internal/mail"
 	"user-service/internal/repository"
+	"user-service/internal/scheduler"
 	"user-service/internal/usecase"
 
 	"github.com/go-playground/validator/v10"
@@ -16,6 +19,8 @@ import (
 )
 
 type BootstrapConfig struct {
+	// Context is cancelled on shutdown to stop background jobs
+	Context  context.Context
 	DB       *gorm.DB
 	App      *fiber.App
 	Log      *logrus.Logger
@@ -38,8 +43,8 @@ func Bootstrap(config *BootstrapConfig) {
 	// Auto-migrate database if needed
 	if config.Config.GetBool("database.auto_migrate") {
 		config.Log.Info("Auto-migrating database tables...")
-		// Auto-migrate users table
-		err := config.DB.AutoMigrate(&entity.User{})
+		// Auto-migrate users, refresh tokens, email verification tokens and password reset tokens tables
+		err := config.DB.AutoMigrate(&entity.User{}, &entity.RefreshToken{}, &entity.EmailVerificationToken{}, &entity.PasswordResetToken{})
 		if err != nil {
 			config.Log.WithField("error", err.Error()).Fatal("Failed to migrate database")
 		}
@@ -48,24 +53,47 @@ func Bootstrap(config *BootstrapConfig) {
 
 	// setup repositories
 	userRepository := repository.NewUserRepository(config.Log, config.DB)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(config.Log, config.DB)
+	emailVerificationTokenRepository := repository.NewEmailVerificationTokenRepository(config.Log, config.DB)
+	passwordResetTokenRepository := repository.NewPasswordResetTokenRepository(config.Log, config.DB)
+
+	// setup JWT issuance and verification
+	tokenManager := NewTokenManager(config.Config, config.Log)
+
+	// No email provider is integrated yet, so emails are logged instead of delivered
+	mailer := mail.NewLogSender(config.Log)
 
 	// setup use cases
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository)
+	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository, refreshTokenRepository, emailVerificationTokenRepository, passwordResetTokenRepository, tokenManager, mailer, NewLoginLockoutPolicy(config.Config, config.Log), NewEmailVerificationPolicy(config.Config, config.Log), NewPasswordResetPolicy(config.Config, config.Log))
+
+	// Periodically delete expired tokens so the token tables do not grow without bound
+	tokenCleanupConfig := NewTokenCleanupConfig(config.Config, config.Log)
+	tokenCleanupUseCase := usecase.NewTokenCleanupUseCase(config.DB, config.Log, refreshTokenRepository, emailVerificationTokenRepository, passwordResetTokenRepository, tokenCleanupConfig.BatchSize)
+	ctx := config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	scheduler.NewTokenPurgeScheduler(tokenCleanupUseCase, tokenCleanupConfig.Interval, config.Log).Start(ctx)
 
 	// setup handler
 	userHandler := handler.NewUserHandler(userUseCase, config.Log)
+	healthHandler := handler.NewHealthHandler(config.DB, config.Log)
 
 	// Create auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(config.DB, userRepository)
+	authMiddleware := middleware.NewAuthMiddleware(tokenManager)
 	authMiddleware.SetLogger(config.Log)
 
 	// Configure routes
 	routeConfig := route.RouteConfig{
-		App:         config.App,
-		UserHandler: userHandler,
-		DB:          config.DB,
-		UserRepo:    userRepository,
-		Log:         config.Log,
+		App:            config.App,
+		UserHandler:    userHandler,
+		HealthHandler:  healthHandler,
+		AuthMiddleware: authMiddleware,
+		Security:       NewSecurityConfig(config.Config),
+		CORS:           NewCORSConfig(config.Config, config.Log),
+		RateLimiters:   NewRateLimiters(config.Config, config.Log),
+		AccessLog:      NewAccessLogConfig(config.Config, config.Log),
+		Log:            config.Log,
 	}
 	
 	// Setup routes
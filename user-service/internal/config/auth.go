@@ -0,0 +1,43 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/auth"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultAccessTokenTTL is used when jwt.access_token_ttl is not configured
+	DefaultAccessTokenTTL = 24 * time.Hour
+
+	// DefaultRefreshTokenTTL is used when jwt.refresh_token_ttl is not configured
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// NewTokenManager creates the JWT manager from jwt.secret, jwt.access_token_ttl and jwt.refresh_token_ttl
+func NewTokenManager(config *viper.Viper, log *logrus.Logger) auth.TokenManagerInterface {
+	secret := config.GetString("jwt.secret")
+	if secret == "" {
+		log.Fatal("jwt.secret must be configured to sign access tokens")
+	}
+
+	accessTokenTTL := DefaultAccessTokenTTL
+	if config.IsSet("jwt.access_token_ttl") {
+		accessTokenTTL = config.GetDuration("jwt.access_token_ttl")
+	}
+	if accessTokenTTL <= 0 {
+		log.WithField("access_token_ttl", accessTokenTTL.String()).Fatal("JWT access token TTL must be positive")
+	}
+
+	refreshTokenTTL := DefaultRefreshTokenTTL
+	if config.IsSet("jwt.refresh_token_ttl") {
+		refreshTokenTTL = config.GetDuration("jwt.refresh_token_ttl")
+	}
+	if refreshTokenTTL <= 0 {
+		log.WithField("refresh_token_ttl", refreshTokenTTL.String()).Fatal("JWT refresh token TTL must be positive")
+	}
+
+	return auth.NewTokenManager(secret, accessTokenTTL, refreshTokenTTL)
+}
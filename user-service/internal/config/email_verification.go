@@ -0,0 +1,40 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultEmailVerificationTokenTTL is used when email_verification.token_ttl is not configured
+	DefaultEmailVerificationTokenTTL = 24 * time.Hour
+
+	// DefaultEmailVerificationLinkURL is used when email_verification.link_url is not configured
+	DefaultEmailVerificationLinkURL = "http://localhost:3000/api/v1/users/verify"
+)
+
+// NewEmailVerificationPolicy reads email_verification.token_ttl, email_verification.link_url and
+// email_verification.required_to_login. Logins are allowed before verification unless required_to_login is true.
+func NewEmailVerificationPolicy(config *viper.Viper, log *logrus.Logger) usecase.EmailVerificationPolicy {
+	tokenTTL := DefaultEmailVerificationTokenTTL
+	if config.IsSet("email_verification.token_ttl") {
+		tokenTTL = config.GetDuration("email_verification.token_ttl")
+	}
+	if tokenTTL <= 0 {
+		log.WithField("token_ttl", tokenTTL.String()).Fatal("Email verification token TTL must be positive")
+	}
+
+	linkURL := DefaultEmailVerificationLinkURL
+	if config.IsSet("email_verification.link_url") {
+		linkURL = config.GetString("email_verification.link_url")
+	}
+
+	return usecase.EmailVerificationPolicy{
+		TokenTTL:        tokenTTL,
+		LinkURL:         linkURL,
+		RequiredToLogin: config.GetBool("email_verification.required_to_login"),
+	}
+}
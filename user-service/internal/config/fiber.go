@@ -1,29 +1,27 @@
 package config
 
 import (
-	"user-service/internal/model"
+	"user-service/internal/delivery/http/response"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func NewFiber(config *viper.Viper) *fiber.App {
+func NewFiber(config *viper.Viper, log *logrus.Logger) *fiber.App {
 	var app = fiber.New(fiber.Config{
 		AppName:      config.GetString("app.name"),
-		ErrorHandler: NewErrorHandler(),
+		ErrorHandler: NewErrorHandler(log),
 		Prefork:      config.GetBool("web.prefork"),
 	})
 
 	return app
 }
 
-func NewErrorHandler() fiber.ErrorHandler {
+// NewErrorHandler renders errors that escape the handlers, such as body
+// parsing failures, in the same envelope the handlers use.
+func NewErrorHandler(log *logrus.Logger) fiber.ErrorHandler {
 	return func(ctx *fiber.Ctx, err error) error {
-		code := fiber.StatusInternalServerError
-		if e, ok := err.(*fiber.Error); ok {
-			code = e.Code
-		}
-
-		return ctx.Status(code).JSON(model.WebResponse[interface{}]{Data: nil, Errors: err.Error()})
+		return response.HandleError(ctx, err, log)
 	}
 }
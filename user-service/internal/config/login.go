@@ -0,0 +1,39 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultMaxFailedLoginAttempts is used when login.max_failed_attempts is not configured
+	DefaultMaxFailedLoginAttempts = 5
+
+	// DefaultLoginLockoutDuration is used when login.lockout_duration is not configured
+	DefaultLoginLockoutDuration = 15 * time.Minute
+)
+
+// NewLoginLockoutPolicy reads login.max_failed_attempts and login.lockout_duration.
+// Setting login.max_failed_attempts to 0 disables the lockout.
+func NewLoginLockoutPolicy(config *viper.Viper, log *logrus.Logger) usecase.LoginLockoutPolicy {
+	maxFailedAttempts := DefaultMaxFailedLoginAttempts
+	if config.IsSet("login.max_failed_attempts") {
+		maxFailedAttempts = config.GetInt("login.max_failed_attempts")
+	}
+
+	lockoutDuration := DefaultLoginLockoutDuration
+	if config.IsSet("login.lockout_duration") {
+		lockoutDuration = config.GetDuration("login.lockout_duration")
+	}
+	if maxFailedAttempts > 0 && lockoutDuration <= 0 {
+		log.WithField("lockout_duration", lockoutDuration.String()).Fatal("Login lockout duration must be positive")
+	}
+
+	return usecase.LoginLockoutPolicy{
+		MaxFailedAttempts: maxFailedAttempts,
+		Duration:          lockoutDuration,
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultPasswordResetTokenTTL is used when password_reset.token_ttl is not configured
+	DefaultPasswordResetTokenTTL = 30 * time.Minute
+
+	// DefaultPasswordResetLinkURL is used when password_reset.link_url is not configured
+	DefaultPasswordResetLinkURL = "http://localhost:3000/reset-password"
+)
+
+// NewPasswordResetPolicy reads password_reset.token_ttl and password_reset.link_url.
+// The link should open a page that asks for the new password and posts it with the token to /users/password/reset.
+func NewPasswordResetPolicy(config *viper.Viper, log *logrus.Logger) usecase.PasswordResetPolicy {
+	tokenTTL := DefaultPasswordResetTokenTTL
+	if config.IsSet("password_reset.token_ttl") {
+		tokenTTL = config.GetDuration("password_reset.token_ttl")
+	}
+	if tokenTTL <= 0 {
+		log.WithField("token_ttl", tokenTTL.String()).Fatal("Password reset token TTL must be positive")
+	}
+
+	linkURL := DefaultPasswordResetLinkURL
+	if config.IsSet("password_reset.link_url") {
+		linkURL = config.GetString("password_reset.link_url")
+	}
+
+	return usecase.PasswordResetPolicy{
+		TokenTTL: tokenTTL,
+		LinkURL:  linkURL,
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"user-service/internal/delivery/http/middleware"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewRateLimiters builds one in-memory limiter per group under rate_limit.groups, such as default or login.
+// It returns no limiters when rate_limit.enabled is false, which leaves every route unlimited.
+func NewRateLimiters(config *viper.Viper, log *logrus.Logger) map[string]middleware.RateLimiter {
+	rateLimiters := make(map[string]middleware.RateLimiter)
+	if !config.GetBool("rate_limit.enabled") {
+		return rateLimiters
+	}
+
+	for group := range config.GetStringMap("rate_limit.groups") {
+		prefix := "rate_limit.groups." + group
+		limit := middleware.RateLimit{
+			Requests: config.GetInt(prefix + ".requests"),
+			Window:   config.GetDuration(prefix + ".window"),
+		}
+		if limit.Requests <= 0 || limit.Window <= 0 {
+			log.WithField("group", group).Fatal("Rate limit requests and window must be positive")
+		}
+		rateLimiters[group] = middleware.NewMemoryRateLimiter(limit)
+	}
+
+	return rateLimiters
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"user-service/internal/delivery/http/middleware"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultHSTSMaxAge is used when security.hsts_max_age is not configured (one year)
+const DefaultHSTSMaxAge = 31536000
+
+func NewSecurityConfig(config *viper.Viper) middleware.SecurityConfig {
+	hstsMaxAge := DefaultHSTSMaxAge
+	if config.IsSet("security.hsts_max_age") {
+		hstsMaxAge = config.GetInt("security.hsts_max_age")
+	}
+
+	return middleware.SecurityConfig{
+		HTTPSOnly:      config.GetBool("security.https_only"),
+		HSTSMaxAge:     hstsMaxAge,
+		CookieSameSite: config.GetString("security.cookie_same_site"),
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// DefaultTokenCleanupInterval is used when token_cleanup.interval is not configured
+const DefaultTokenCleanupInterval = time.Hour
+
+// TokenCleanupConfig holds configuration for purging expired refresh, email verification and password reset tokens
+type TokenCleanupConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewTokenCleanupConfig reads token_cleanup.interval and token_cleanup.batch_size
+func NewTokenCleanupConfig(config *viper.Viper, log *logrus.Logger) *TokenCleanupConfig {
+	interval := DefaultTokenCleanupInterval
+	if config.IsSet("token_cleanup.interval") {
+		interval = config.GetDuration("token_cleanup.interval")
+	}
+	if interval <= 0 {
+		log.WithField("interval", interval.String()).Fatal("Token cleanup interval must be positive")
+	}
+
+	batchSize := usecase.DefaultTokenPurgeBatchSize
+	if config.IsSet("token_cleanup.batch_size") {
+		batchSize = config.GetInt("token_cleanup.batch_size")
+	}
+	if batchSize <= 0 {
+		log.WithField("batch_size", batchSize).Fatal("Token cleanup batch size must be positive")
+	}
+
+	return &TokenCleanupConfig{
+		Interval:  interval,
+		BatchSize: batchSize,
+	}
+}
@@ -1,27 +1,25 @@
 package middleware
 
 import (
+	"errors"
+	"user-service/internal/auth"
 	"user-service/internal/context"
 	"user-service/internal/delivery/http/response"
 	appErrors "user-service/internal/errors"
-	"user-service/internal/repository"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 )
 
 type AuthMiddleware struct {
-	DB             *gorm.DB
-	UserRepository repository.UserRepositoryInterface
-	Log            *logrus.Logger
+	TokenManager auth.TokenManagerInterface
+	Log          *logrus.Logger
 }
 
-func NewAuthMiddleware(db *gorm.DB, userRepository repository.UserRepositoryInterface) *AuthMiddleware {
+func NewAuthMiddleware(tokenManager auth.TokenManagerInterface) *AuthMiddleware {
 	return &AuthMiddleware{
-		DB:             db,
-		UserRepository: userRepository,
-		Log:            logrus.New(),
+		TokenManager: tokenManager,
+		Log:          logrus.New(),
 	}
 }
 
@@ -30,7 +28,7 @@ func (m *AuthMiddleware) SetLogger(log *logrus.Logger) {
 	m.Log = log
 }
 
-// RequireAuth middleware to validate token from Authorization header
+// RequireAuth middleware to validate the JWT access token from the Authorization header
 func (m *AuthMiddleware) RequireAuth() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		requestID := c.Get("X-Request-ID")
@@ -57,51 +55,44 @@ func (m *AuthMiddleware) RequireAuth() fiber.Handler {
 			token = authHeader[7:]
 		}
 		
-		// Create a context with timeout for the token validation
+		// Create a context with timeout for the rest of the request
 		userCtx := context.WithRequestID(c.UserContext(), requestID)
 		timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
 		defer cancel()
 		
-		// Use a repository with context
 		c.SetUserContext(timeoutCtx)
 		
-		// Validate token
-		user, err := m.UserRepository.FindByToken(m.DB, token)
+		// Verify the JWT signature and expiry
+		claims, err := m.TokenManager.Verify(token)
 		if err != nil {
 			m.Log.WithFields(logrus.Fields{
 				"request_id": requestID,
-				"token":      token[:8] + "...", // Only log part of the token for security
 				"error":      err.Error(),
 				"path":       c.Path(),
 			}).Warn("Invalid token")
 			
+			message := "Invalid token"
+			if errors.Is(err, auth.ErrExpiredToken) {
+				message = "Token has expired"
+			}
 			return response.JSONError(c, 
-				appErrors.WithError(appErrors.ErrUnauthorized, err), 
+				appErrors.WithMessage(appErrors.ErrUnauthorized, message), 
 				m.Log)
 		}
 		
-		if user == nil {
-			m.Log.WithFields(logrus.Fields{
-				"request_id": requestID,
-				"token":      token[:8] + "...",
-				"path":       c.Path(),
-			}).Warn("User not found for token")
-			
-			return response.JSONError(c, 
-				appErrors.WithMessage(appErrors.ErrUnauthorized, "Invalid token"), 
-				m.Log)
-		}
+		userID, _ := claims.UserID()
 		
-		// Set user ID in locals to be used in handlers
-		c.Locals("userId", user.ID)
+		// Set user ID and role in locals to be used in handlers
+		c.Locals("userId", userID)
+		c.Locals("role", claims.Role)
 		
 		// Also set in the context
-		c.SetUserContext(context.WithUserID(c.UserContext(), user.ID.String()))
+		c.SetUserContext(context.WithUserID(c.UserContext(), userID.String()))
 		
 		// Log successful authentication
 		m.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
-			"user_id":    user.ID.String(),
+			"user_id":    userID.String(),
 			"path":       c.Path(),
 		}).Info("User authenticated successfully")
 		
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-service/internal/auth"
+	"user-service/internal/delivery/http/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware_RequireAuth(t *testing.T) {
+	tokenManager := auth.NewTokenManager("test-secret", time.Hour, time.Hour)
+	userID := uuid.New()
+
+	validToken, _, err := tokenManager.Issue(userID, "customer")
+	require.NoError(t, err)
+	expiredToken, _, err := auth.NewTokenManager("test-secret", -time.Minute, time.Hour).Issue(userID, "customer")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		expectedCode  int
+	}{
+		{"valid token", "Bearer " + validToken, fiber.StatusOK},
+		{"missing header", "", fiber.StatusUnauthorized},
+		{"expired token", "Bearer " + expiredToken, fiber.StatusUnauthorized},
+		{"legacy opaque token", "Bearer " + uuid.New().String(), fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			middleware := NewAuthMiddleware(tokenManager)
+			middleware.SetLogger(logger)
+
+			app := fiber.New()
+			app.Get("/me", middleware.RequireAuth(), func(c *fiber.Ctx) error {
+				assert.Equal(t, userID, c.Locals("userId"))
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/me", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == fiber.StatusUnauthorized {
+				var body response.Response
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.False(t, body.Success)
+				require.NotNil(t, body.Error)
+				assert.Equal(t, "UNAUTHORIZED", body.Error.Code)
+			}
+		})
+	}
+}
@@ -1,59 +1,205 @@
 package middleware
 
 import (
-	"fmt"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"strings"
 	"time"
 
+	"user-service/internal/context"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
-// Logger creates a middleware that logs requests and responses
-func Logger(log *logrus.Logger) fiber.Handler {
+const (
+	// RequestIDHeader carries the request ID on requests and responses
+	RequestIDHeader = "X-Request-ID"
+
+	// RequestIDLocal is the ctx.Locals key set by RequestID
+	RequestIDLocal = "requestID"
+
+	// redacted replaces sensitive values in the access log
+	redacted = "[REDACTED]"
+
+	// maxLoggedBodyBytes caps how much of a request body is logged
+	maxLoggedBodyBytes = 4096
+)
+
+// sensitiveHeaders are never written to the access log in clear text
+var sensitiveHeaders = map[string]bool{
+	fiber.HeaderAuthorization: true,
+	fiber.HeaderCookie:        true,
+	"X-Api-Key":               true,
+}
+
+// sensitiveFields are the substrings that mark a JSON body field as secret
+var sensitiveFields = []string{"password", "token", "secret"}
+
+// LoggerConfig controls the access log written for each request
+type LoggerConfig struct {
+	// Level is the level successful requests are logged at; 4xx responses are logged at warn and 5xx at error
+	Level logrus.Level
+	// SampleRate is the fraction of successful requests that are logged, between 0 and 1; failed requests are always logged
+	SampleRate float64
+	// LogHeaders adds the request headers to each entry, with credentials redacted
+	LogHeaders bool
+	// LogBodies adds the request body to each entry, with password and token fields redacted
+	LogBodies bool
+}
+
+// DefaultLoggerConfig logs every request at info level without headers or bodies
+func DefaultLoggerConfig() LoggerConfig {
+	return LoggerConfig{
+		Level:      logrus.InfoLevel,
+		SampleRate: 1,
+	}
+}
+
+// RequestID makes sure every request has an ID. An inbound X-Request-ID is kept, otherwise one is generated.
+// The ID is written back to the request header, so handlers reading X-Request-ID see it, and echoed on the response.
+// It is also put on the user context so repositories and gateways downstream, such as the slow query log, can report it.
+func RequestID() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Start timer
-		start := time.Now()
-		requestID := c.Get("X-Request-ID")
+		requestID := c.Get(RequestIDHeader)
 		if requestID == "" {
-			requestID = fmt.Sprintf("%d", time.Now().UnixNano())
-			c.Set("X-Request-ID", requestID)
+			requestID = uuid.New().String()
+			c.Request().Header.Set(RequestIDHeader, requestID)
 		}
+		c.Set(RequestIDHeader, requestID)
+		c.Locals(RequestIDLocal, requestID)
+		c.SetUserContext(context.WithRequestID(c.UserContext(), requestID))
 
-		// Log request
-		log.WithFields(logrus.Fields{
-			"request_id": requestID,
-			"method":     c.Method(),
-			"path":       c.Path(),
-			"ip":         c.IP(),
-			"user_agent": c.Get("User-Agent"),
-		}).Info("Incoming request")
+		return c.Next()
+	}
+}
 
-		// Process request
-		err := c.Next()
+// Logger creates a middleware that writes one structured access log entry per request.
+// It must run after RequestID so the entry carries the same request ID as the handler logs.
+func Logger(config LoggerConfig, log *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
 
-		// Get latency
-		latency := time.Since(start)
+		err := c.Next()
 
-		// Log response
+		// A returned error is only turned into a response by the error handler after the middleware chain unwinds,
+		// so derive the status the same way it will
 		statusCode := c.Response().StatusCode()
-		fields := logrus.Fields{
-			"request_id":  requestID,
-			"status_code": statusCode,
-			"latency_ms":  latency.Milliseconds(),
+		if err != nil {
+			statusCode = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				statusCode = fiberErr.Code
+			}
 		}
 
-		// Customize log level based on status code
+		level := config.Level
 		switch {
 		case statusCode >= 500:
-			log.WithFields(fields).Error("Server error response")
+			level = logrus.ErrorLevel
 		case statusCode >= 400:
-			log.WithFields(fields).Warn("Client error response")
-		case statusCode >= 300:
-			log.WithFields(fields).Info("Redirection response")
-		default:
-			log.WithFields(fields).Info("Success response")
+			level = logrus.WarnLevel
+		case config.SampleRate < 1 && rand.Float64() >= config.SampleRate:
+			return err
+		}
+		if !log.IsLevelEnabled(level) {
+			return err
+		}
+
+		requestID, _ := c.Locals(RequestIDLocal).(string)
+		if requestID == "" {
+			requestID = c.Get(RequestIDHeader)
 		}
 
+		fields := logrus.Fields{
+			"request_id":  requestID,
+			"method":      c.Method(),
+			"path":        c.Path(),
+			"status_code": statusCode,
+			"latency_ms":  time.Since(start).Milliseconds(),
+			"ip":          c.IP(),
+			"user_agent":  c.Get(fiber.HeaderUserAgent),
+		}
+		if userID := c.Locals("userId"); userID != nil {
+			fields["user_id"] = userID
+		}
+		if config.LogHeaders {
+			fields["headers"] = redactHeaders(c.GetReqHeaders())
+		}
+		if config.LogBodies && len(c.Body()) > 0 {
+			fields["body"] = redactBody(c.Body())
+		}
+
+		log.WithFields(fields).Log(level, "HTTP request")
 		return err
 	}
-}
\ No newline at end of file
+}
+
+// redactHeaders flattens the request headers and hides credentials
+func redactHeaders(headers map[string][]string) map[string]string {
+	logged := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if sensitiveHeaders[name] {
+			logged[name] = redacted
+			continue
+		}
+		logged[name] = strings.Join(values, ", ")
+	}
+	return logged
+}
+
+// redactBody returns the body with sensitive JSON fields hidden.
+// A body that is not JSON is dropped entirely if it mentions a password, since its fields cannot be picked out.
+func redactBody(body []byte) string {
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		if strings.Contains(strings.ToLower(string(body)), "password") {
+			return redacted
+		}
+		return truncateBody(string(body))
+	}
+
+	clean, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return redacted
+	}
+	return truncateBody(string(clean))
+}
+
+// redactValue walks a decoded JSON value and hides every field whose name looks sensitive
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveFields {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateBody(body string) string {
+	if len(body) > maxLoggedBodyBytes {
+		return body[:maxLoggedBodyBytes] + "...(truncated)"
+	}
+	return body
+}
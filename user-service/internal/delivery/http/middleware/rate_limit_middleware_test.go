@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimiter returns a limiter whose clock only moves when the test advances it
+func newTestRateLimiter(limit RateLimit) (*MemoryRateLimiter, *time.Time) {
+	now := time.Now()
+	limiter := NewMemoryRateLimiter(limit)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func newRateLimitTestApp(limiter RateLimiter) *fiber.App {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	app := fiber.New()
+	app.Use(RateLimitMiddleware(limiter, logger))
+	app.Post("/users/login", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func postLogin(t *testing.T, app *fiber.App) *http.Response {
+	resp, err := app.Test(httptest.NewRequest("POST", "/users/login", nil))
+	require.NoError(t, err)
+	return resp
+}
+
+func TestRateLimitMiddleware_RejectsRequestOverLimit(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{Requests: 3, Window: time.Minute})
+	app := newRateLimitTestApp(limiter)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, fiber.StatusOK, postLogin(t, app).StatusCode, "request %d", i+1)
+	}
+
+	resp := postLogin(t, app)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	// One token refills every 20 seconds at 3 requests per minute
+	assert.Equal(t, "20", resp.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestRateLimitMiddleware_AllowsWhenLimiterFails(t *testing.T) {
+	app := newRateLimitTestApp(failingRateLimiter{})
+
+	assert.Equal(t, fiber.StatusOK, postLogin(t, app).StatusCode)
+}
+
+func TestMemoryRateLimiter_RefillsOverTime(t *testing.T) {
+	limiter, now := newTestRateLimiter(RateLimit{Requests: 2, Window: time.Minute})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := limiter.Allow(ctx, "client")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	allowed, retryAfter, _ := limiter.Allow(ctx, "client")
+	assert.False(t, allowed)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	// Half a window refills one of the two tokens
+	*now = now.Add(30 * time.Second)
+	allowed, _, _ = limiter.Allow(ctx, "client")
+	assert.True(t, allowed)
+	allowed, _, _ = limiter.Allow(ctx, "client")
+	assert.False(t, allowed)
+}
+
+func TestMemoryRateLimiter_SeparateBucketPerKey(t *testing.T) {
+	limiter, _ := newTestRateLimiter(RateLimit{Requests: 1, Window: time.Minute})
+	ctx := context.Background()
+
+	allowed, _, _ := limiter.Allow(ctx, "ip:10.0.0.1")
+	assert.True(t, allowed)
+	allowed, _, _ = limiter.Allow(ctx, "ip:10.0.0.1")
+	assert.False(t, allowed)
+
+	allowed, _, _ = limiter.Allow(ctx, "ip:10.0.0.2")
+	assert.True(t, allowed)
+}
+
+func TestMemoryRateLimiter_SweepsIdleBuckets(t *testing.T) {
+	limiter, now := newTestRateLimiter(RateLimit{Requests: 1, Window: time.Minute})
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "idle")
+	*now = now.Add(time.Minute)
+	limiter.Allow(ctx, "active")
+
+	assert.NotContains(t, limiter.buckets, "idle")
+	assert.Contains(t, limiter.buckets, "active")
+}
+
+type failingRateLimiter struct{}
+
+func (failingRateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	return false, 0, errors.New("store unavailable")
+}
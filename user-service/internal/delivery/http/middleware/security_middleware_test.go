@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       SecurityConfig
+		expectedHSTS string
+	}{
+		{
+			name:         "http environment",
+			config:       SecurityConfig{HTTPSOnly: false, HSTSMaxAge: 31536000},
+			expectedHSTS: "",
+		},
+		{
+			name:         "https only environment",
+			config:       SecurityConfig{HTTPSOnly: true, HSTSMaxAge: 31536000},
+			expectedHSTS: "max-age=31536000; includeSubDomains",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(SecurityHeaders(tt.config))
+			app.Get("/health", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/health", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+			assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
+			assert.Equal(t, "no-referrer", resp.Header.Get("Referrer-Policy"))
+			assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+			assert.Equal(t, tt.expectedHSTS, resp.Header.Get("Strict-Transport-Security"))
+		})
+	}
+}
+
+func TestSecurityConfig_AuthCookie(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+
+	cookie := SecurityConfig{HTTPSOnly: true}.AuthCookie("token", "abc", expires)
+	assert.True(t, cookie.HTTPOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, fiber.CookieSameSiteStrictMode, cookie.SameSite)
+
+	cookie = SecurityConfig{HTTPSOnly: false, CookieSameSite: fiber.CookieSameSiteNoneMode}.AuthCookie("token", "abc", expires)
+	assert.True(t, cookie.HTTPOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, fiber.CookieSameSiteNoneMode, cookie.SameSite)
+}
@@ -87,10 +87,23 @@ func HandleError(c *fiber.Ctx, err error, logger *logrus.Logger) error {
 			err = appErrors.ErrInvalidInput
 		case fiber.StatusUnauthorized:
 			err = appErrors.ErrUnauthorized
+		case fiber.StatusForbidden:
+			err = appErrors.ErrForbidden
 		case fiber.StatusNotFound:
 			err = appErrors.ErrResourceNotFound
+		case fiber.StatusRequestTimeout:
+			err = appErrors.ErrTimeout
+		case fiber.StatusTooManyRequests:
+			err = appErrors.ErrTooManyRequests
+		case fiber.StatusServiceUnavailable:
+			err = appErrors.ErrServiceUnavailable
 		default:
-			err = appErrors.WithError(appErrors.ErrInternalServer, fiberErr)
+			if fiberErr.Code >= fiber.StatusBadRequest && fiberErr.Code < fiber.StatusInternalServerError {
+				// Keep the status of other client errors such as 405 or 413
+				err = appErrors.NewAppError(appErrors.ErrInvalidInput.Code, fiberErr.Message, fiberErr.Code, nil)
+			} else {
+				err = appErrors.WithError(appErrors.ErrInternalServer, fiberErr)
+			}
 		}
 	}
 	
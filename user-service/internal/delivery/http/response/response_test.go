@@ -0,0 +1,54 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"bad request", fiber.ErrBadRequest, fiber.StatusBadRequest, "INVALID_INPUT"},
+		{"unauthorized", fiber.ErrUnauthorized, fiber.StatusUnauthorized, "UNAUTHORIZED"},
+		{"forbidden", fiber.ErrForbidden, fiber.StatusForbidden, "FORBIDDEN"},
+		{"not found", fiber.ErrNotFound, fiber.StatusNotFound, "RESOURCE_NOT_FOUND"},
+		{"other client error keeps status", fiber.ErrRequestEntityTooLarge, fiber.StatusRequestEntityTooLarge, "INVALID_INPUT"},
+		{"too many requests", fiber.ErrTooManyRequests, fiber.StatusTooManyRequests, "TOO_MANY_REQUESTS"},
+		{"server error", fiber.ErrBadGateway, fiber.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+		{"plain error", errors.New("boom"), fiber.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				return HandleError(c, tt.err, logger)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var body Response
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.False(t, body.Success)
+			require.NotNil(t, body.Error)
+			assert.Equal(t, tt.expectedCode, body.Error.Code)
+		})
+	}
+}
@@ -1,43 +1,40 @@
 package route
 
 import (
-	"github.com/google/uuid"
 	"user-service/internal/delivery/http/middleware"
 	"user-service/internal/delivery/http/response"
 	"user-service/internal/errors"
 	"user-service/internal/handler"
-	"user-service/internal/repository"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 )
 
 type RouteConfig struct {
-	App         *fiber.App
-	UserHandler *handler.UserHandler
-	DB          *gorm.DB
-	UserRepo    repository.UserRepositoryInterface
-	Log         *logrus.Logger
+	App            *fiber.App
+	UserHandler    *handler.UserHandler
+	HealthHandler  *handler.HealthHandler
+	AuthMiddleware *middleware.AuthMiddleware
+	Security       middleware.SecurityConfig
+	CORS           middleware.CORSConfig
+	RateLimiters   map[string]middleware.RateLimiter
+	AccessLog      middleware.LoggerConfig
+	Log            *logrus.Logger
 }
 
 func (c *RouteConfig) Setup() {
 	// Add request ID middleware (must be first)
-	c.App.Use(func(ctx *fiber.Ctx) error {
-		requestID := ctx.Get("X-Request-ID")
-		if requestID == "" {
-			ctx.Set("X-Request-ID", uuid.New().String())
-		}
-		return ctx.Next()
-	})
+	c.App.Use(middleware.RequestID())
 
-	// Apply logger middleware
-	c.App.Use(middleware.Logger(c.Log))
+	// Answer CORS preflight requests before rate limiting and authentication see them
+	c.App.Use(middleware.CORS(c.CORS))
 
-	// Initialize auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(c.DB, c.UserRepo)
-	authMiddleware.SetLogger(c.Log)
+	// Apply security headers middleware
+	c.App.Use(middleware.SecurityHeaders(c.Security))
+
+	// Apply access log middleware
+	c.App.Use(middleware.Logger(c.AccessLog, c.Log))
 
 	// Swagger documentation
 	c.App.Get("/swagger/*", swagger.HandlerDefault)
@@ -46,20 +43,40 @@ func (c *RouteConfig) Setup() {
 	api := c.App.Group("/api")
 	v1 := api.Group("/v1")
 
-	// Health check endpoint
-	v1.Get("/health", func(ctx *fiber.Ctx) error {
-		return response.JSONSuccess(ctx, map[string]string{"status": "ok"})
-	})
+	// Liveness stays a fast path; readiness pings the database
+	v1.Get("/health", c.HealthHandler.Live)
+	v1.Get("/health/ready", c.HealthHandler.Ready)
+
+	// Health checks above are exempt; every other API route shares the default limit
+	v1.Use(c.rateLimit("default"))
 
 	// Public user endpoints
 	v1.Post("/users", c.UserHandler.Register)
-	v1.Post("/users/login", c.UserHandler.Login)
+	v1.Post("/users/login", c.rateLimit("login"), c.UserHandler.Login)
+	v1.Post("/users/refresh", c.UserHandler.Refresh)
+	v1.Get("/users/verify", c.UserHandler.VerifyEmail)
+	v1.Post("/users/password/forgot", c.rateLimit("login"), c.UserHandler.ForgotPassword)
+	v1.Post("/users/password/reset", c.UserHandler.ResetPassword)
 
 	// Protected user endpoints - require authentication
-	v1.Get("/users/:id", authMiddleware.RequireAuth(), c.UserHandler.GetUser)
+	v1.Get("/users/:id", c.AuthMiddleware.RequireAuth(), c.UserHandler.GetUser)
+	v1.Put("/users/:id", c.AuthMiddleware.RequireAuth(), c.UserHandler.Update)
+	v1.Patch("/users/:id/password", c.AuthMiddleware.RequireAuth(), c.UserHandler.ChangePassword)
 
 	// 404 Handler
 	c.App.Use(func(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, errors.ErrResourceNotFound, c.Log)
 	})
-}
\ No newline at end of file
+}
+
+// rateLimit returns the rate limiting middleware for the named route group,
+// or a pass-through when rate limiting is disabled or the group has no limit configured
+func (c *RouteConfig) rateLimit(group string) fiber.Handler {
+	limiter, ok := c.RateLimiters[group]
+	if !ok {
+		return func(ctx *fiber.Ctx) error {
+			return ctx.Next()
+		}
+	}
+	return middleware.RateLimitMiddleware(limiter, c.Log)
+}
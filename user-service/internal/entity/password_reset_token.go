@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PasswordResetToken is a struct that represents a token emailed to a user who forgot their password.
+// Like refresh tokens, only the SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uuid.UUID  `gorm:"column:uuid;primaryKey"`
+	UserID    uuid.UUID  `gorm:"column:user_uuid;type:char(36);not null;index"`
+	TokenHash string     `gorm:"column:token_hash;type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null;index"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (t *PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+func (t *PasswordResetToken) BeforeCreate(tx *gorm.DB) (err error) {
+	t.ID = uuid.New()
+	t.CreatedAt = time.Now()
+	return
+}
+
+// IsUsed reports whether the token has already reset the user's password
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired reports whether the token has expired at the given time
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
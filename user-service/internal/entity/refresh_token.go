@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a struct that represents a refresh token issued at login.
+// Only the SHA-256 hash of the token is stored so a database leak does not expose usable tokens.
+type RefreshToken struct {
+	ID        uuid.UUID  `gorm:"column:uuid;primaryKey"`
+	UserID    uuid.UUID  `gorm:"column:user_uuid;type:char(36);not null;index"`
+	TokenHash string     `gorm:"column:token_hash;type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null;index"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (r *RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+func (r *RefreshToken) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	r.CreatedAt = time.Now()
+	return
+}
+
+// IsRevoked reports whether the token has been revoked
+func (r *RefreshToken) IsRevoked() bool {
+	return r.RevokedAt != nil
+}
+
+// IsExpired reports whether the token has expired at the given time
+func (r *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
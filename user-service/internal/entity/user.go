@@ -7,16 +7,30 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// RoleCustomer is the role of every registered user
+	RoleCustomer = "customer"
+
+	// RoleAdmin may manage warehouses and change order statuses. It is granted directly in the database.
+	RoleAdmin = "admin"
+)
+
 // User is a struct that represents a user entity
 type User struct {
-	ID        uuid.UUID `gorm:"column:uuid;primaryKey"`
-	Name      string    `gorm:"column:name;type:varchar(255);not null"`
-	Email     string    `gorm:"column:email;type:varchar(255);uniqueIndex;not null"`
-	Phone     string    `gorm:"column:phone;type:varchar(50);uniqueIndex"`
-	Password  string    `gorm:"column:password;type:varchar(100);not null"`
-	Token     string    `gorm:"column:token;type:varchar(255)"`
-	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"` // Menggunakan time.Time untuk timestamp
-	UpdatedAt time.Time `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+	ID                  uuid.UUID  `gorm:"column:uuid;primaryKey"`
+	Name                string     `gorm:"column:name;type:varchar(255);not null"`
+	Email               string     `gorm:"column:email;type:varchar(255);uniqueIndex;not null"`
+	Phone               string     `gorm:"column:phone;type:varchar(50);uniqueIndex"`
+	Password            string     `gorm:"column:password;type:varchar(100);not null"`
+	Role                string     `gorm:"column:role;type:varchar(20);not null;default:customer"`
+	EmailVerified       bool       `gorm:"column:email_verified;not null;default:false"` // Set once the user follows the link of their verification email
+	FailedLoginAttempts int        `gorm:"column:failed_login_attempts;not null;default:0"` // Consecutive failed logins since the last success or lockout
+	LockedUntil         *time.Time `gorm:"column:locked_until"`
+	CreatedAt           time.Time  `gorm:"column:created_at;autoCreateTime"` // Menggunakan time.Time untuk timestamp
+	UpdatedAt           time.Time  `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+
+	// Deprecated: Token held the opaque login token. Logins now issue JWTs, so the column is no longer written or read.
+	Token string `gorm:"column:token;type:varchar(255)"`
 }
 
 func (u *User) TableName() string {
@@ -25,7 +39,15 @@ func (u *User) TableName() string {
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	u.ID = uuid.New()
+	if u.Role == "" {
+		u.Role = RoleCustomer
+	}
 	u.CreatedAt = time.Now()
 	u.UpdatedAt = time.Now()
 	return
 }
+
+// IsLocked reports whether logins are rejected at the given time because of repeated failures
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
@@ -68,6 +68,90 @@ var (
 		nil,
 	)
 
+	ErrInvalidRefreshToken = NewAppError(
+		"INVALID_REFRESH_TOKEN",
+		"Refresh token is invalid, expired or revoked",
+		http.StatusUnauthorized,
+		nil,
+	)
+
+	ErrInvalidVerificationToken = NewAppError(
+		"INVALID_VERIFICATION_TOKEN",
+		"Email verification token is invalid",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	ErrVerificationTokenExpired = NewAppError(
+		"VERIFICATION_TOKEN_EXPIRED",
+		"Email verification token has expired",
+		http.StatusGone,
+		nil,
+	)
+
+	ErrVerificationTokenUsed = NewAppError(
+		"VERIFICATION_TOKEN_USED",
+		"Email verification token has already been used",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrInvalidResetToken = NewAppError(
+		"INVALID_RESET_TOKEN",
+		"Password reset token is invalid",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	ErrResetTokenExpired = NewAppError(
+		"RESET_TOKEN_EXPIRED",
+		"Password reset token has expired",
+		http.StatusGone,
+		nil,
+	)
+
+	ErrResetTokenUsed = NewAppError(
+		"RESET_TOKEN_USED",
+		"Password reset token has already been used",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrEmailNotVerified = NewAppError(
+		"EMAIL_NOT_VERIFIED",
+		"Email address has not been verified",
+		http.StatusForbidden,
+		nil,
+	)
+
+	ErrAccountLocked = NewAppError(
+		"ACCOUNT_LOCKED",
+		"Too many failed login attempts, try again later",
+		http.StatusTooManyRequests,
+		nil,
+	)
+
+	ErrForbidden = NewAppError(
+		"FORBIDDEN",
+		"You are not allowed to access this resource",
+		http.StatusForbidden,
+		nil,
+	)
+
+	ErrIncorrectPassword = NewAppError(
+		"INCORRECT_PASSWORD",
+		"Current password is incorrect",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	ErrWeakPassword = NewAppError(
+		"WEAK_PASSWORD",
+		"New password does not meet the strength policy",
+		http.StatusUnprocessableEntity,
+		nil,
+	)
+
 	ErrResourceNotFound = NewAppError(
 		"RESOURCE_NOT_FOUND",
 		"Resource not found",
@@ -75,6 +159,13 @@ var (
 		nil,
 	)
 
+	ErrUserNotFound = NewAppError(
+		"USER_NOT_FOUND",
+		"User not found",
+		http.StatusNotFound,
+		nil,
+	)
+
 	ErrDuplicateEmail = NewAppError(
 		"DUPLICATE_EMAIL",
 		"Email already exists",
@@ -82,6 +173,13 @@ var (
 		nil,
 	)
 
+	ErrDuplicatePhone = NewAppError(
+		"DUPLICATE_PHONE",
+		"Phone number already exists",
+		http.StatusConflict,
+		nil,
+	)
+
 	ErrInternalServer = NewAppError(
 		"INTERNAL_SERVER_ERROR",
 		"Internal server error",
@@ -95,6 +193,20 @@ var (
 		http.StatusRequestTimeout,
 		nil,
 	)
+
+	ErrTooManyRequests = NewAppError(
+		"TOO_MANY_REQUESTS",
+		"Too many requests, try again later",
+		http.StatusTooManyRequests,
+		nil,
+	)
+
+	ErrServiceUnavailable = NewAppError(
+		"SERVICE_UNAVAILABLE",
+		"One or more dependencies are unavailable",
+		http.StatusServiceUnavailable,
+		nil,
+	)
 )
 
 // WithError wraps the original error with AppError
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"user-service/internal/delivery/http/response"
+	appErrors "user-service/internal/errors"
+	"user-service/internal/model"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// healthCheckTimeout bounds how long readiness waits for the database ping
+const healthCheckTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+}
+
+func NewHealthHandler(db *gorm.DB, logger *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		DB:  db,
+		Log: logger,
+	}
+}
+
+// Live godoc
+// @Summary Liveness check
+// @Description Reports that the process is up without checking any dependency
+// @Tags Health
+// @Produce json
+// @Success 200 {object} response.Response{data=model.HealthResponse}
+// @Router /health [get]
+func (c *HealthHandler) Live(ctx *fiber.Ctx) error {
+	return response.JSONSuccess(ctx, model.HealthResponse{Status: model.HealthStatusOK})
+}
+
+// Ready godoc
+// @Summary Readiness check
+// @Description Pings the database. Returns 503 with the status of each dependency when any is down.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} response.Response{data=model.HealthResponse}
+// @Failure 503 {object} response.Response{data=model.HealthResponse,error=response.ErrorInfo}
+// @Router /health/ready [get]
+func (c *HealthHandler) Ready(ctx *fiber.Ctx) error {
+	checkCtx, cancel := context.WithTimeout(ctx.UserContext(), healthCheckTimeout)
+	defer cancel()
+
+	health := model.HealthResponse{
+		Status: model.HealthStatusReady,
+		Dependencies: map[string]model.DependencyHealth{
+			"database": c.checkDatabase(checkCtx),
+		},
+	}
+
+	var down []string
+	for name, dependency := range health.Dependencies {
+		if dependency.Status != model.HealthStatusUp {
+			down = append(down, name)
+		}
+	}
+
+	if len(down) == 0 {
+		return response.JSONSuccess(ctx, health)
+	}
+
+	health.Status = model.HealthStatusNotReady
+	c.Log.WithField("dependencies", down).Warn("Readiness check failed")
+
+	return ctx.Status(fiber.StatusServiceUnavailable).JSON(response.Response{
+		Success: false,
+		Data:    health,
+		Error: &response.ErrorInfo{
+			Code:    appErrors.ErrServiceUnavailable.Code,
+			Message: appErrors.ErrServiceUnavailable.Message,
+		},
+	})
+}
+
+// checkDatabase pings the underlying connection pool
+func (c *HealthHandler) checkDatabase(ctx context.Context) model.DependencyHealth {
+	sqlDB, err := c.DB.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		return model.DependencyHealth{Status: model.HealthStatusDown, Error: err.Error()}
+	}
+
+	return model.DependencyHealth{Status: model.HealthStatusUp}
+}
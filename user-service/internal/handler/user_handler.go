@@ -9,6 +9,7 @@ import (
 	"user-service/internal/usecase"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,7 +27,7 @@ func NewUserHandler(useCase usecase.UserUseCaseInterface, logger *logrus.Logger)
 
 // Register godoc
 // @Summary Register a new user
-// @Description Create a new user account
+// @Description Create a new user account and send a verification email for its address
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -84,7 +85,7 @@ func (c *UserHandler) Register(ctx *fiber.Ctx) error {
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate a user and return a token
+// @Description Authenticate a user and return an access token and a refresh token
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -92,6 +93,8 @@ func (c *UserHandler) Register(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.UserResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /users/login [post]
 func (c *UserHandler) Login(ctx *fiber.Ctx) error {
@@ -139,6 +142,350 @@ func (c *UserHandler) Login(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, userResponse)
 }
 
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a new refresh token. The presented refresh token is revoked.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body model.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} model.UserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /users/refresh [post]
+func (c *UserHandler) Refresh(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	request := new(model.RefreshTokenRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	tokenResponse, err := c.UseCase.Refresh(timeoutCtx, request.RefreshToken)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to refresh access token")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		if err == fiber.ErrUnauthorized {
+			return response.JSONError(ctx, appErrors.ErrInvalidRefreshToken, c.Log)
+		} else if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		} else {
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, tokenResponse)
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirm the user's email address with the token from the verification email sent at registration. Each token works once and expires after email_verification.token_ttl.
+// @Tags Users
+// @Produce json
+// @Param token query string true "Email verification token"
+// @Success 200 {object} model.UserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 410 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /users/verify [get]
+func (c *UserHandler) VerifyEmail(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	userResponse, err := c.UseCase.VerifyEmail(timeoutCtx, ctx.Query("token"))
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to verify email")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "token is required"), c.Log)
+		}
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, userResponse)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a single-use password reset link to the account with this email. The response is the same whether or not the email has an account.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body model.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /users/password/forgot [post]
+func (c *UserHandler) ForgotPassword(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	request := new(model.ForgotPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	if err := c.UseCase.RequestPasswordReset(timeoutCtx, request.Email); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to request password reset")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "a valid email is required"), c.Log)
+		}
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, map[string]string{"message": "If an account exists for this email, a password reset link has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token from the password reset email. Each token works once and expires after password_reset.token_ttl. Refresh tokens issued before the reset are revoked.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body model.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 410 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /users/password/reset [post]
+func (c *UserHandler) ResetPassword(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	request := new(model.ResetPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	if err := c.UseCase.ResetPassword(timeoutCtx, request.Token, request.NewPassword); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to reset password")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "token and new_password are required"), c.Log)
+		}
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, map[string]string{"message": "Password reset successfully"})
+}
+
+// Update godoc
+// @Summary Update profile
+// @Description Update the authenticated user's name and phone. Empty fields are left unchanged; the email cannot be changed here.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param user body model.UpdateUserRequest true "Profile fields to update"
+// @Success 200 {object} model.UserResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /users/{id} [put]
+func (c *UserHandler) Update(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Users may only update their own profile
+	userID, appErr := c.ownUserID(ctx)
+	if appErr != nil {
+		return response.JSONError(ctx, appErr, c.Log)
+	}
+
+	request := new(model.UpdateUserRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	userResponse, err := c.UseCase.Update(timeoutCtx, userID, request)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"user_id":    userID.String(),
+			"error":      err.Error(),
+		}).Warn("Failed to update user")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		switch err {
+		case fiber.ErrBadRequest:
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		case fiber.ErrNotFound:
+			return response.JSONError(ctx, appErrors.ErrUserNotFound, c.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, userResponse)
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Replace the authenticated user's password after verifying the current one. Refresh tokens issued before the change are revoked.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body model.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /users/{id}/password [patch]
+func (c *UserHandler) ChangePassword(ctx *fiber.Ctx) error {
+	// Get request ID from context for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Users may only change their own password
+	userID, appErr := c.ownUserID(ctx)
+	if appErr != nil {
+		return response.JSONError(ctx, appErr, c.Log)
+	}
+
+	request := new(model.ChangePasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	if err := c.UseCase.ChangePassword(timeoutCtx, userID, request.CurrentPassword, request.NewPassword); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"user_id":    userID.String(),
+			"error":      err.Error(),
+		}).Warn("Failed to change password")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		// Convert Fiber errors to application errors
+		switch err {
+		case fiber.ErrBadRequest:
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		case fiber.ErrNotFound:
+			return response.JSONError(ctx, appErrors.ErrUserNotFound, c.Log)
+		default:
+			return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+		}
+	}
+
+	return response.JSONSuccess(ctx, map[string]string{"message": "Password changed successfully"})
+}
+
 // GetUser godoc
 // @Summary Get user by ID
 // @Description Returns user details for the specified ID
@@ -178,4 +525,24 @@ func (c *UserHandler) GetUser(ctx *fiber.Ctx) error {
 	}
 
 	return response.JSONSuccess(ctx, userData)
-}
\ No newline at end of file
+}
+
+// ownUserID parses the user ID from the path and checks that it belongs to the authenticated user
+func (c *UserHandler) ownUserID(ctx *fiber.Ctx) (uuid.UUID, *appErrors.AppError) {
+	userID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return uuid.Nil, appErrors.WithMessage(appErrors.ErrInvalidInput, "invalid user id")
+	}
+
+	if authenticatedID, ok := ctx.Locals("userId").(uuid.UUID); !ok || authenticatedID != userID {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":       ctx.Get("X-Request-ID"),
+			"user_id":          userID.String(),
+			"authenticated_as": ctx.Locals("userId"),
+			"path":             ctx.Path(),
+		}).Warn("Rejected access to another user's account")
+		return uuid.Nil, appErrors.ErrForbidden
+	}
+
+	return userID, nil
+}
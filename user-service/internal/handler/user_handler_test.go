@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	appErrors "user-service/internal/errors"
 	"user-service/internal/model"
 	usecase_mock "user-service/mocks/usecase"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
@@ -150,6 +152,17 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusUnauthorized,
 		},
+		{
+			name: "account locked",
+			requestBody: model.LoginUserRequest{
+				Email:    "testemail@mail.com",
+				Password: "password123",
+			},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, appErrors.ErrAccountLocked)
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+		},
 		{
 			name: "internal server error",
 			requestBody: model.LoginUserRequest{
@@ -220,4 +233,636 @@ func TestUserHandler_Login(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUserHandler_Refresh(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        any
+		mockExpectations   func(mockUseCase *usecase_mock.MockUserUseCaseInterface)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name: "success",
+			requestBody: model.RefreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Refresh(gomock.Any(), "refresh-token").Return(&model.UserResponse{
+					Token:     "sample-token",
+					ExpiresAt: "2025-05-28T12:00:00Z",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid request body",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:        "missing refresh token",
+			requestBody: model.RefreshTokenRequest{},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Refresh(gomock.Any(), "").Return(nil, fiber.ErrBadRequest)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name: "expired or revoked refresh token",
+			requestBody: model.RefreshTokenRequest{
+				RefreshToken: "revoked-token",
+			},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Refresh(gomock.Any(), "revoked-token").Return(nil, fiber.ErrUnauthorized)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErrorCode:  "INVALID_REFRESH_TOKEN",
+		},
+		{
+			name: "internal server error",
+			requestBody: model.RefreshTokenRequest{
+				RefreshToken: "refresh-token",
+			},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Refresh(gomock.Any(), "refresh-token").Return(nil, fiber.ErrInternalServerError)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorCode:  "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Disable logger output during tests
+			logger := logrus.New()
+			logger.SetOutput(&bytes.Buffer{})
+
+			app := fiber.New()
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+
+			mockUseCase := usecase_mock.NewMockUserUseCaseInterface(ctrl)
+			handler := NewUserHandler(mockUseCase, logger)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+
+			ctx.Request().SetRequestURI("/refresh")
+			ctx.Request().Header.SetMethod(http.MethodPost)
+			ctx.Request().Header.Set("Content-Type", "application/json")
+			ctx.Request().SetBody(jsonBody)
+
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockUseCase)
+			}
+
+			handler.Refresh(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, ctx.Response().StatusCode())
+
+			var response map[string]interface{}
+			err := json.Unmarshal(ctx.Response().Body(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.True(t, response["success"].(bool))
+				data := response["data"].(map[string]interface{})
+				assert.NotEmpty(t, data["token"])
+				assert.NotEmpty(t, data["expires_at"])
+			} else {
+				assert.False(t, response["success"].(bool))
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedErrorCode, errorBody["code"])
+			}
+		})
+	}
+}
+
+func TestUserHandler_VerifyEmail(t *testing.T) {
+	verified := true
+	tests := []struct {
+		name               string
+		token              string
+		mockExpectations   func(mockUseCase *usecase_mock.MockUserUseCaseInterface)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name:  "success",
+			token: "verification-token",
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().VerifyEmail(gomock.Any(), "verification-token").Return(&model.UserResponse{
+					ID:            uuid.New().String(),
+					Email:         "user@example.com",
+					EmailVerified: &verified,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "missing token",
+			token: "",
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().VerifyEmail(gomock.Any(), "").Return(nil, fiber.ErrBadRequest)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:  "unknown token",
+			token: "unknown-token",
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().VerifyEmail(gomock.Any(), "unknown-token").Return(nil, appErrors.ErrInvalidVerificationToken)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_VERIFICATION_TOKEN",
+		},
+		{
+			name:  "token already used",
+			token: "verification-token",
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().VerifyEmail(gomock.Any(), "verification-token").Return(nil, appErrors.ErrVerificationTokenUsed)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "VERIFICATION_TOKEN_USED",
+		},
+		{
+			name:  "token expired",
+			token: "verification-token",
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().VerifyEmail(gomock.Any(), "verification-token").Return(nil, appErrors.ErrVerificationTokenExpired)
+			},
+			expectedStatusCode: http.StatusGone,
+			expectedErrorCode:  "VERIFICATION_TOKEN_EXPIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Disable logger output during tests
+			logger := logrus.New()
+			logger.SetOutput(&bytes.Buffer{})
+
+			app := fiber.New()
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+
+			mockUseCase := usecase_mock.NewMockUserUseCaseInterface(ctrl)
+			handler := NewUserHandler(mockUseCase, logger)
+
+			ctx.Request().SetRequestURI("/verify?token=" + tt.token)
+			ctx.Request().Header.SetMethod(http.MethodGet)
+
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockUseCase)
+			}
+
+			handler.VerifyEmail(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, ctx.Response().StatusCode())
+
+			var response map[string]interface{}
+			err := json.Unmarshal(ctx.Response().Body(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.True(t, response["success"].(bool))
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, true, data["email_verified"])
+			} else {
+				assert.False(t, response["success"].(bool))
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedErrorCode, errorBody["code"])
+			}
+		})
+	}
+}
+
+func TestUserHandler_ForgotPassword(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        any
+		mockExpectations   func(mockUseCase *usecase_mock.MockUserUseCaseInterface)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name:        "success",
+			requestBody: model.ForgotPasswordRequest{Email: "user@example.com"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().RequestPasswordReset(gomock.Any(), "user@example.com").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid request body",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:        "invalid email",
+			requestBody: model.ForgotPasswordRequest{Email: "not-an-email"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().RequestPasswordReset(gomock.Any(), "not-an-email").Return(fiber.ErrBadRequest)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:        "internal server error",
+			requestBody: model.ForgotPasswordRequest{Email: "user@example.com"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().RequestPasswordReset(gomock.Any(), "user@example.com").Return(fiber.ErrInternalServerError)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorCode:  "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Disable logger output during tests
+			logger := logrus.New()
+			logger.SetOutput(&bytes.Buffer{})
+
+			app := fiber.New()
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+
+			mockUseCase := usecase_mock.NewMockUserUseCaseInterface(ctrl)
+			handler := NewUserHandler(mockUseCase, logger)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+
+			ctx.Request().SetRequestURI("/password/forgot")
+			ctx.Request().Header.SetMethod(http.MethodPost)
+			ctx.Request().Header.Set("Content-Type", "application/json")
+			ctx.Request().SetBody(jsonBody)
+
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockUseCase)
+			}
+
+			handler.ForgotPassword(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, ctx.Response().StatusCode())
+
+			var response map[string]interface{}
+			err := json.Unmarshal(ctx.Response().Body(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.True(t, response["success"].(bool))
+				data := response["data"].(map[string]interface{})
+				assert.NotEmpty(t, data["message"])
+			} else {
+				assert.False(t, response["success"].(bool))
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedErrorCode, errorBody["code"])
+			}
+		})
+	}
+}
+
+func TestUserHandler_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        any
+		mockExpectations   func(mockUseCase *usecase_mock.MockUserUseCaseInterface)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name:        "success",
+			requestBody: model.ResetPasswordRequest{Token: "reset-token", NewPassword: "newPassword456"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ResetPassword(gomock.Any(), "reset-token", "newPassword456").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid request body",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:        "token already used",
+			requestBody: model.ResetPasswordRequest{Token: "reset-token", NewPassword: "newPassword456"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ResetPassword(gomock.Any(), "reset-token", "newPassword456").Return(appErrors.ErrResetTokenUsed)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "RESET_TOKEN_USED",
+		},
+		{
+			name:        "token expired",
+			requestBody: model.ResetPasswordRequest{Token: "reset-token", NewPassword: "newPassword456"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ResetPassword(gomock.Any(), "reset-token", "newPassword456").Return(appErrors.ErrResetTokenExpired)
+			},
+			expectedStatusCode: http.StatusGone,
+			expectedErrorCode:  "RESET_TOKEN_EXPIRED",
+		},
+		{
+			name:        "weak new password",
+			requestBody: model.ResetPasswordRequest{Token: "reset-token", NewPassword: "short"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ResetPassword(gomock.Any(), "reset-token", "short").Return(appErrors.ErrWeakPassword)
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedErrorCode:  "WEAK_PASSWORD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Disable logger output during tests
+			logger := logrus.New()
+			logger.SetOutput(&bytes.Buffer{})
+
+			app := fiber.New()
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+
+			mockUseCase := usecase_mock.NewMockUserUseCaseInterface(ctrl)
+			handler := NewUserHandler(mockUseCase, logger)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+
+			ctx.Request().SetRequestURI("/password/reset")
+			ctx.Request().Header.SetMethod(http.MethodPost)
+			ctx.Request().Header.Set("Content-Type", "application/json")
+			ctx.Request().SetBody(jsonBody)
+
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockUseCase)
+			}
+
+			handler.ResetPassword(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, ctx.Response().StatusCode())
+
+			var response map[string]interface{}
+			err := json.Unmarshal(ctx.Response().Body(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.True(t, response["success"].(bool))
+			} else {
+				assert.False(t, response["success"].(bool))
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedErrorCode, errorBody["code"])
+			}
+		})
+	}
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	userID := uuid.New()
+	validBody := model.ChangePasswordRequest{
+		CurrentPassword: "password123",
+		NewPassword:     "newPassword456",
+	}
+
+	tests := []struct {
+		name               string
+		pathID             string
+		requestBody        any
+		mockExpectations   func(mockUseCase *usecase_mock.MockUserUseCaseInterface)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name:        "success",
+			pathID:      userID.String(),
+			requestBody: validBody,
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ChangePassword(gomock.Any(), userID, "password123", "newPassword456").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid user id",
+			pathID:             "not-a-uuid",
+			requestBody:        validBody,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:               "another user's password",
+			pathID:             uuid.New().String(),
+			requestBody:        validBody,
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorCode:  "FORBIDDEN",
+		},
+		{
+			name:               "invalid request body",
+			pathID:             userID.String(),
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:        "incorrect current password",
+			pathID:      userID.String(),
+			requestBody: validBody,
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ChangePassword(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(appErrors.ErrIncorrectPassword)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INCORRECT_PASSWORD",
+		},
+		{
+			name:        "weak new password",
+			pathID:      userID.String(),
+			requestBody: validBody,
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ChangePassword(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+					Return(appErrors.WithMessage(appErrors.ErrWeakPassword, "password is too weak: must contain at least one digit"))
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedErrorCode:  "WEAK_PASSWORD",
+		},
+		{
+			name:        "user not found",
+			pathID:      userID.String(),
+			requestBody: validBody,
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().ChangePassword(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(fiber.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  "USER_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Disable logger output during tests
+			logger := logrus.New()
+			logger.SetOutput(&bytes.Buffer{})
+
+			mockUseCase := usecase_mock.NewMockUserUseCaseInterface(ctrl)
+			handler := NewUserHandler(mockUseCase, logger)
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockUseCase)
+			}
+
+			// Stand in for the auth middleware, which stores the token's user ID in locals
+			app := fiber.New()
+			app.Patch("/users/:id/password", func(ctx *fiber.Ctx) error {
+				ctx.Locals("userId", userID)
+				return ctx.Next()
+			}, handler.ChangePassword)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPatch, "/users/"+tt.pathID+"/password", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+
+			var response map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.True(t, response["success"].(bool))
+			} else {
+				assert.False(t, response["success"].(bool))
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedErrorCode, errorBody["code"])
+			}
+		})
+	}
+}
+
+func TestUserHandler_Update(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		pathID             string
+		requestBody        any
+		mockExpectations   func(mockUseCase *usecase_mock.MockUserUseCaseInterface)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name:        "success",
+			pathID:      userID.String(),
+			requestBody: model.UpdateUserRequest{Name: "New Name"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Update(gomock.Any(), userID, &model.UpdateUserRequest{Name: "New Name"}).Return(&model.UserResponse{
+					ID:   userID.String(),
+					Name: "New Name",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "another user's profile",
+			pathID:             uuid.New().String(),
+			requestBody:        model.UpdateUserRequest{Name: "New Name"},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErrorCode:  "FORBIDDEN",
+		},
+		{
+			name:               "invalid request body",
+			pathID:             userID.String(),
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+		{
+			name:        "duplicate phone",
+			pathID:      userID.String(),
+			requestBody: model.UpdateUserRequest{Phone: "0987654321"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Update(gomock.Any(), userID, gomock.Any()).Return(nil, appErrors.ErrDuplicatePhone)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "DUPLICATE_PHONE",
+		},
+		{
+			name:        "validation fails",
+			pathID:      userID.String(),
+			requestBody: model.UpdateUserRequest{Name: "New Name"},
+			mockExpectations: func(mockUseCase *usecase_mock.MockUserUseCaseInterface) {
+				mockUseCase.EXPECT().Update(gomock.Any(), userID, gomock.Any()).Return(nil, fiber.ErrBadRequest)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "INVALID_INPUT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Disable logger output during tests
+			logger := logrus.New()
+			logger.SetOutput(&bytes.Buffer{})
+
+			mockUseCase := usecase_mock.NewMockUserUseCaseInterface(ctrl)
+			handler := NewUserHandler(mockUseCase, logger)
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockUseCase)
+			}
+
+			// Stand in for the auth middleware, which stores the token's user ID in locals
+			app := fiber.New()
+			app.Put("/users/:id", func(ctx *fiber.Ctx) error {
+				ctx.Locals("userId", userID)
+				return ctx.Next()
+			}, handler.Update)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPut, "/users/"+tt.pathID, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+
+			var response map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.True(t, response["success"].(bool))
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, "New Name", data["name"])
+			} else {
+				assert.False(t, response["success"].(bool))
+				errorBody := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedErrorCode, errorBody["code"])
+			}
+		})
+	}
+}
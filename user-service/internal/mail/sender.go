@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Message is a plain-text email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type SenderInterface interface {
+	// Send delivers the message or returns why it could not be delivered
+	Send(ctx context.Context, message Message) error
+}
+
+// LogSender stands in for an email provider: it logs each message instead of delivering it,
+// so the links it carries can be followed in development
+type LogSender struct {
+	Log *logrus.Logger
+}
+
+func NewLogSender(log *logrus.Logger) SenderInterface {
+	return &LogSender{
+		Log: log,
+	}
+}
+
+// Send logs the message
+func (s *LogSender) Send(ctx context.Context, message Message) error {
+	s.Log.WithFields(logrus.Fields{
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.Body,
+	}).Info("Email not delivered, no email provider is configured")
+	return nil
+}
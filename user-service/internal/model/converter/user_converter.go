@@ -1,23 +1,35 @@
 package converter
 
 import (
+	"time"
 	"user-service/internal/entity"
 	"user-service/internal/model"
 )
 
 func UserToResponse(user *entity.User) *model.UserResponse {
+	emailVerified := user.EmailVerified
 	return &model.UserResponse{
-		ID:        user.ID.String(),
-		Name:      user.Name,
-		Email:     user.Email,
-		Phone:     user.Phone,
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:            user.ID.String(),
+		Name:          user.Name,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Role:          user.Role,
+		EmailVerified: &emailVerified,
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
-func UserToTokenResponse(user *entity.User) *model.UserResponse {
+func UserToTokenResponse(token string, expiresAt time.Time) *model.UserResponse {
 	return &model.UserResponse{
-		Token: user.Token,
+		Token:     token,
+		ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
+
+func UserToLoginResponse(token string, expiresAt time.Time, refreshToken string, refreshTokenExpiresAt time.Time) *model.UserResponse {
+	response := UserToTokenResponse(token, expiresAt)
+	response.RefreshToken = refreshToken
+	response.RefreshTokenExpiresAt = refreshTokenExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+	return response
+}
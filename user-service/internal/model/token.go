@@ -0,0 +1,8 @@
+package model
+
+// TokenPurgeResult reports how many expired tokens of each kind a single purge deleted
+type TokenPurgeResult struct {
+	RefreshTokens           int64 `json:"refresh_tokens"`
+	EmailVerificationTokens int64 `json:"email_verification_tokens"`
+	PasswordResetTokens     int64 `json:"password_reset_tokens"`
+}
@@ -12,12 +12,42 @@ type UserResponse struct {
 	Name      string `json:"name,omitempty"`
 	Email     string `json:"email,omitempty"`
 	Phone     string `json:"phone,omitempty"`
+	Role      string `json:"role,omitempty"`
 	Token     string `json:"token,omitempty"`
-	CreatedAt string `json:"created_at,omitempty"`
-	UpdatedAt string `json:"updated_at,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// RefreshToken is returned at login and refresh and is exchanged once for new tokens via /users/refresh
+	RefreshToken          string `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at,omitempty"`
+	CreatedAt             string `json:"created_at,omitempty"`
+	UpdatedAt             string `json:"updated_at,omitempty"`
+	EmailVerified         *bool  `json:"email_verified,omitempty"` // Set on user details only, not on token responses
 }
 
 type LoginUserRequest struct {
 	Email    string `json:"email" validate:"required,max=100"`
 	Password string `json:"password" validate:"required,max=100"`
 }
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=255"`
+}
+
+// UpdateUserRequest holds the profile fields a user can change. Empty fields are left unchanged.
+type UpdateUserRequest struct {
+	Name  string `json:"name" validate:"max=255"`
+	Phone string `json:"phone" validate:"max=50"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required,max=100"`
+	NewPassword     string `json:"new_password" validate:"required,max=100"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required,max=255"`
+	NewPassword string `json:"new_password" validate:"required,max=100"`
+}
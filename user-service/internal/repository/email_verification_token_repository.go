@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"user-service/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrTokenAlreadyUsed is returned by MarkUsed of the single-use token repositories when another request used the token first
+var ErrTokenAlreadyUsed = errors.New("token has already been used")
+
+type EmailVerificationTokenRepositoryInterface interface {
+	Create(db *gorm.DB, token *entity.EmailVerificationToken) error
+	FindByHash(db *gorm.DB, tokenHash string) (*entity.EmailVerificationToken, error)
+	MarkUsed(db *gorm.DB, token *entity.EmailVerificationToken) error
+	DeleteExpired(db *gorm.DB, now time.Time, limit int) (int64, error)
+}
+
+type EmailVerificationTokenRepository struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+}
+
+func NewEmailVerificationTokenRepository(log *logrus.Logger, db *gorm.DB) EmailVerificationTokenRepositoryInterface {
+	return &EmailVerificationTokenRepository{
+		DB:  db,
+		Log: log,
+	}
+}
+
+func (r *EmailVerificationTokenRepository) Create(db *gorm.DB, token *entity.EmailVerificationToken) error {
+	return db.Create(token).Error
+}
+
+// FindByHash looks up a verification token by the hash of its value
+func (r *EmailVerificationTokenRepository) FindByHash(db *gorm.DB, tokenHash string) (*entity.EmailVerificationToken, error) {
+	token := new(entity.EmailVerificationToken)
+	if err := db.Where("token_hash = ?", tokenHash).Take(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// MarkUsed records that the token has been used so it cannot verify an email again.
+// Only an unused token is updated, so of two concurrent requests the second gets ErrTokenAlreadyUsed.
+func (r *EmailVerificationTokenRepository) MarkUsed(db *gorm.DB, token *entity.EmailVerificationToken) error {
+	now := time.Now()
+	usedAt := token.UsedAt
+	result := db.Model(token).Where("used_at IS NULL").Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Update assigns the value to the model even when no row matched
+		token.UsedAt = usedAt
+		return ErrTokenAlreadyUsed
+	}
+	token.UsedAt = &now
+	return nil
+}
+
+// DeleteExpired deletes at most limit verification tokens that expired at or before now and returns how many it deleted.
+// Bounding each delete keeps the locks it takes short.
+func (r *EmailVerificationTokenRepository) DeleteExpired(db *gorm.DB, now time.Time, limit int) (int64, error) {
+	result := db.Where("expires_at <= ?", now).Limit(limit).Delete(&entity.EmailVerificationToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+	"user-service/internal/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupEmailVerificationTokenRepository(t *testing.T) (*EmailVerificationTokenRepository, *gorm.DB, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating sqlmock: %v", err)
+	}
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	repo := &EmailVerificationTokenRepository{
+		DB:  db,
+		Log: logrus.New(),
+	}
+	return repo, db, mock
+}
+
+func TestEmailVerificationTokenRepository_FindByHash(t *testing.T) {
+	repo, db, mock := setupEmailVerificationTokenRepository(t)
+
+	tokenID := uuid.New()
+	userID := uuid.New()
+	tokenHash := "3f0a6c1f9e0d8c1b2a4f5e6d7c8b9a0f1e2d3c4b5a69788796a5b4c3d2e1f0a9"
+	expiresAt := time.Now().Add(time.Hour)
+
+	rows := sqlmock.NewRows([]string{"uuid", "user_uuid", "token_hash", "expires_at", "used_at", "created_at"}).
+		AddRow(tokenID.String(), userID.String(), tokenHash, expiresAt, nil, time.Now())
+	mock.ExpectQuery("SELECT \\* FROM `email_verification_tokens` WHERE token_hash = \\? LIMIT \\?").
+		WithArgs(tokenHash, 1).
+		WillReturnRows(rows)
+
+	got, err := repo.FindByHash(db, tokenHash)
+	if err != nil {
+		t.Fatalf("EmailVerificationTokenRepository.FindByHash() error = %v", err)
+	}
+	if got.UserID != userID {
+		t.Errorf("EmailVerificationTokenRepository.FindByHash() got user = %v, want %v", got.UserID, userID)
+	}
+	if got.IsUsed() {
+		t.Errorf("EmailVerificationTokenRepository.FindByHash() got used token, want unused")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEmailVerificationTokenRepository_MarkUsed(t *testing.T) {
+	repo, db, mock := setupEmailVerificationTokenRepository(t)
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success_mark_used",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "failed_mark_used_already_used",
+			rowsAffected: 0,
+			wantErr:      ErrTokenAlreadyUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &entity.EmailVerificationToken{
+				ID:        uuid.New(),
+				UserID:    uuid.New(),
+				TokenHash: "hash",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `email_verification_tokens` SET `used_at`=\\? WHERE used_at IS NULL AND `uuid` = \\?").
+				WithArgs(sqlmock.AnyArg(), token.ID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			err := repo.MarkUsed(db, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EmailVerificationTokenRepository.MarkUsed() error = %v, want %v", err, tt.wantErr)
+			}
+			if token.IsUsed() != (tt.wantErr == nil) {
+				t.Errorf("EmailVerificationTokenRepository.MarkUsed() used = %v, want %v", token.IsUsed(), tt.wantErr == nil)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestEmailVerificationTokenRepository_DeleteExpired(t *testing.T) {
+	repo, db, mock := setupEmailVerificationTokenRepository(t)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `email_verification_tokens` WHERE expires_at <= \\? LIMIT \\?").
+		WithArgs(now, 100).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteExpired(db, now, 100)
+	if err != nil {
+		t.Fatalf("EmailVerificationTokenRepository.DeleteExpired() error = %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("EmailVerificationTokenRepository.DeleteExpired() deleted = %d, want 0", deleted)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+	"user-service/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenRepositoryInterface interface {
+	Create(db *gorm.DB, token *entity.PasswordResetToken) error
+	FindByHash(db *gorm.DB, tokenHash string) (*entity.PasswordResetToken, error)
+	MarkUsed(db *gorm.DB, token *entity.PasswordResetToken) error
+	DeleteExpired(db *gorm.DB, now time.Time, limit int) (int64, error)
+}
+
+type PasswordResetTokenRepository struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+}
+
+func NewPasswordResetTokenRepository(log *logrus.Logger, db *gorm.DB) PasswordResetTokenRepositoryInterface {
+	return &PasswordResetTokenRepository{
+		DB:  db,
+		Log: log,
+	}
+}
+
+func (r *PasswordResetTokenRepository) Create(db *gorm.DB, token *entity.PasswordResetToken) error {
+	return db.Create(token).Error
+}
+
+// FindByHash looks up a password reset token by the hash of its value
+func (r *PasswordResetTokenRepository) FindByHash(db *gorm.DB, tokenHash string) (*entity.PasswordResetToken, error) {
+	token := new(entity.PasswordResetToken)
+	if err := db.Where("token_hash = ?", tokenHash).Take(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// MarkUsed records that the token has been used so it cannot reset the password again.
+// Only an unused token is updated, so of two concurrent requests the second gets ErrTokenAlreadyUsed.
+func (r *PasswordResetTokenRepository) MarkUsed(db *gorm.DB, token *entity.PasswordResetToken) error {
+	now := time.Now()
+	usedAt := token.UsedAt
+	result := db.Model(token).Where("used_at IS NULL").Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Update assigns the value to the model even when no row matched
+		token.UsedAt = usedAt
+		return ErrTokenAlreadyUsed
+	}
+	token.UsedAt = &now
+	return nil
+}
+
+// DeleteExpired deletes at most limit password reset tokens that expired at or before now and returns how many it deleted.
+// Bounding each delete keeps the locks it takes short.
+func (r *PasswordResetTokenRepository) DeleteExpired(db *gorm.DB, now time.Time, limit int) (int64, error) {
+	result := db.Where("expires_at <= ?", now).Limit(limit).Delete(&entity.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+	"user-service/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryInterface interface {
+	Create(db *gorm.DB, token *entity.RefreshToken) error
+	FindByHash(db *gorm.DB, tokenHash string) (*entity.RefreshToken, error)
+	Revoke(db *gorm.DB, token *entity.RefreshToken) error
+	RevokeAllForUser(db *gorm.DB, userID uuid.UUID) error
+	DeleteExpired(db *gorm.DB, now time.Time, limit int) (int64, error)
+}
+
+type RefreshTokenRepository struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+}
+
+func NewRefreshTokenRepository(log *logrus.Logger, db *gorm.DB) RefreshTokenRepositoryInterface {
+	return &RefreshTokenRepository{
+		DB:  db,
+		Log: log,
+	}
+}
+
+func (r *RefreshTokenRepository) Create(db *gorm.DB, token *entity.RefreshToken) error {
+	return db.Create(token).Error
+}
+
+// FindByHash looks up a refresh token by the hash of its value
+func (r *RefreshTokenRepository) FindByHash(db *gorm.DB, tokenHash string) (*entity.RefreshToken, error) {
+	token := new(entity.RefreshToken)
+	if err := db.Where("token_hash = ?", tokenHash).Take(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Revoke marks the token as revoked so it can no longer be used to refresh access tokens.
+// It returns gorm.ErrRecordNotFound if the token had already been revoked.
+func (r *RefreshTokenRepository) Revoke(db *gorm.DB, token *entity.RefreshToken) error {
+	now := time.Now()
+	result := db.Model(token).Where("revoked_at IS NULL").Update("revoked_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	token.RevokedAt = &now
+	return nil
+}
+
+// RevokeAllForUser revokes every refresh token of the user that is still active
+func (r *RefreshTokenRepository) RevokeAllForUser(db *gorm.DB, userID uuid.UUID) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("user_uuid = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// DeleteExpired deletes at most limit refresh tokens that expired at or before now and returns how many it deleted.
+// Bounding each delete keeps the locks it takes short.
+func (r *RefreshTokenRepository) DeleteExpired(db *gorm.DB, now time.Time, limit int) (int64, error) {
+	result := db.Where("expires_at <= ?", now).Limit(limit).Delete(&entity.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
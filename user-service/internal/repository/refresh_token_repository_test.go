@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+	"user-service/internal/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupRefreshTokenRepository(t *testing.T) (*RefreshTokenRepository, *gorm.DB, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating sqlmock: %v", err)
+	}
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	repo := &RefreshTokenRepository{
+		DB:  db,
+		Log: logrus.New(),
+	}
+	return repo, db, mock
+}
+
+func TestRefreshTokenRepository_FindByHash(t *testing.T) {
+	repo, db, mock := setupRefreshTokenRepository(t)
+
+	tokenID := uuid.New()
+	userID := uuid.New()
+	tokenHash := "3f0a6c1f9e0d8c1b2a4f5e6d7c8b9a0f1e2d3c4b5a69788796a5b4c3d2e1f0a9"
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		hash    string
+		mockFn  func()
+		wantErr bool
+	}{
+		{
+			name: "success_find_by_hash",
+			hash: tokenHash,
+			mockFn: func() {
+				rows := sqlmock.NewRows([]string{"uuid", "user_uuid", "token_hash", "expires_at", "revoked_at", "created_at"}).
+					AddRow(tokenID.String(), userID.String(), tokenHash, expiresAt, nil, time.Now())
+				mock.ExpectQuery("SELECT \\* FROM `refresh_tokens` WHERE token_hash = \\? LIMIT \\?").
+					WithArgs(tokenHash, 1).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed_find_by_hash_not_found",
+			hash: "unknown",
+			mockFn: func() {
+				mock.ExpectQuery("SELECT \\* FROM `refresh_tokens` WHERE token_hash = \\? LIMIT \\?").
+					WithArgs("unknown", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			got, err := repo.FindByHash(db, tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefreshTokenRepository.FindByHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				if got.UserID != userID {
+					t.Errorf("RefreshTokenRepository.FindByHash() got user = %v, want %v", got.UserID, userID)
+				}
+				if got.IsRevoked() {
+					t.Errorf("RefreshTokenRepository.FindByHash() got revoked token, want active")
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenRepository_Revoke(t *testing.T) {
+	repo, db, mock := setupRefreshTokenRepository(t)
+
+	token := &entity.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `revoked_at`=\\? WHERE revoked_at IS NULL AND `uuid` = \\?").
+		WithArgs(sqlmock.AnyArg(), token.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.Revoke(db, token); err != nil {
+		t.Fatalf("RefreshTokenRepository.Revoke() error = %v", err)
+	}
+	if !token.IsRevoked() {
+		t.Errorf("RefreshTokenRepository.Revoke() did not mark the token as revoked")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRefreshTokenRepository_Revoke_AlreadyRevoked(t *testing.T) {
+	repo, db, mock := setupRefreshTokenRepository(t)
+
+	token := &entity.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `revoked_at`=\\? WHERE revoked_at IS NULL AND `uuid` = \\?").
+		WithArgs(sqlmock.AnyArg(), token.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := repo.Revoke(db, token); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("RefreshTokenRepository.Revoke() error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRefreshTokenRepository_RevokeAllForUser(t *testing.T) {
+	repo, db, mock := setupRefreshTokenRepository(t)
+
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `revoked_at`=\\? WHERE user_uuid = \\? AND revoked_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	if err := repo.RevokeAllForUser(db, userID); err != nil {
+		t.Fatalf("RefreshTokenRepository.RevokeAllForUser() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRefreshTokenRepository_DeleteExpired(t *testing.T) {
+	repo, db, mock := setupRefreshTokenRepository(t)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `refresh_tokens` WHERE expires_at <= \\? LIMIT \\?").
+		WithArgs(now, 100).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteExpired(db, now, 100)
+	if err != nil {
+		t.Fatalf("RefreshTokenRepository.DeleteExpired() error = %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("RefreshTokenRepository.DeleteExpired() deleted = %d, want 3", deleted)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
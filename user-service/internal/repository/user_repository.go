@@ -3,13 +3,17 @@ package repository
 import (
 	"user-service/internal/entity"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepositoryInterface interface {
 	Create(db *gorm.DB, user *entity.User) error
 	FindByEmail(db *gorm.DB, user *entity.User, email string) error
+	FindByID(db *gorm.DB, user *entity.User, id uuid.UUID) error
+	FindByPhone(db *gorm.DB, user *entity.User, phone string) error
 	FindByToken(db *gorm.DB, token string) (*entity.User, error)
 	Update(db *gorm.DB, user *entity.User) error
 }
@@ -30,14 +34,25 @@ func (r *UserRepository) Create(db *gorm.DB, user *entity.User) error {
 	return r.DB.Create(user).Error
 }
 
+// FindByEmail locks the user row for update so concurrent logins record failed attempts one at a time
 func (r *UserRepository) FindByEmail(db *gorm.DB, user *entity.User, email string) error {
-	return r.DB.Where("email = ?", email).Take(user).Error
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", email).Take(user).Error
+}
+
+// FindByID locks the user row for update so concurrent changes to the same account are serialized
+func (r *UserRepository) FindByID(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", id).Take(user).Error
+}
+
+func (r *UserRepository) FindByPhone(db *gorm.DB, user *entity.User, phone string) error {
+	return db.Where("phone = ?", phone).Take(user).Error
 }
 
 func (r *UserRepository) Update(db *gorm.DB, user *entity.User) error {
-	return r.DB.Save(user).Error
+	return db.Save(user).Error
 }
 
+// Deprecated: FindByToken looks up users by the legacy opaque token. Access tokens are JWTs verified by auth.TokenManager.
 func (r *UserRepository) FindByToken(db *gorm.DB, token string) (*entity.User, error) {
 	user := new(entity.User)
 	if err := db.Where("token = ?", token).First(user).Error; err != nil {
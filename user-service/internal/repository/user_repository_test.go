@@ -441,3 +441,72 @@ func TestUserRepository_FindByToken(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_FindByID(t *testing.T) {
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		wantErr bool
+	}{
+		{
+			name: "success_find_by_id",
+			mockFn: func() {
+				rows := sqlmock.NewRows([]string{"uuid", "name", "email", "phone", "password", "token", "created_at", "updated_at"}).
+					AddRow(userID.String(), "Test User", "test@example.com", "1234567890", "hashedpassword", nil, time.Now(), time.Now())
+				mock.ExpectQuery("SELECT \\* FROM `users` WHERE uuid = \\? LIMIT \\? FOR UPDATE").
+					WithArgs(userID, 1).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed_find_by_id_not_found",
+			mockFn: func() {
+				mock.ExpectQuery("SELECT \\* FROM `users` WHERE uuid = \\? LIMIT \\? FOR UPDATE").
+					WithArgs(userID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			r := &UserRepository{
+				DB:  db,
+				Log: logrus.New(),
+			}
+			user := new(entity.User)
+			err := r.FindByID(db, user, userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && user.ID != userID {
+				t.Errorf("UserRepository.FindByID() got user id = %v, want %v", user.ID, userID)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestPeriodicRunner_RunOnce(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	t.Run("RunsJob", func(t *testing.T) {
+		var runs atomic.Int32
+		r := newPeriodicRunner("Test job", time.Hour, func(ctx context.Context) { runs.Add(1) }, logger)
+
+		if !r.RunOnce(context.Background()) || !r.RunOnce(context.Background()) {
+			t.Error("RunOnce() = false, want true")
+		}
+		if got := runs.Load(); got != 2 {
+			t.Errorf("job ran %d times, want 2", got)
+		}
+	})
+
+	t.Run("SkipsWhilePreviousRunGoes", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var runs atomic.Int32
+		r := newPeriodicRunner("Test job", time.Hour, func(ctx context.Context) {
+			runs.Add(1)
+			close(started)
+			<-release
+		}, logger)
+
+		done := make(chan bool)
+		go func() { done <- r.RunOnce(context.Background()) }()
+		<-started
+
+		// A second run while the first is still going is skipped
+		if r.RunOnce(context.Background()) {
+			t.Error("RunOnce() = true while the previous run goes, want false")
+		}
+
+		close(release)
+		if !<-done {
+			t.Error("RunOnce() = false, want true")
+		}
+		if got := runs.Load(); got != 1 {
+			t.Errorf("job ran %d times, want 1", got)
+		}
+	})
+}
+
+func TestPeriodicRunner_StartStopsOnCancel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	ran := make(chan struct{}, 1)
+	var runs atomic.Int32
+	r := newPeriodicRunner("Test job", 10*time.Millisecond, func(ctx context.Context) {
+		runs.Add(1)
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+	}, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Start(ctx)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run")
+	}
+	cancel()
+
+	// Let in-flight runs finish, then no further run is started
+	time.Sleep(50 * time.Millisecond)
+	stopped := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	if got := runs.Load(); got != stopped {
+		t.Errorf("job ran %d more times after cancel", got-stopped)
+	}
+}
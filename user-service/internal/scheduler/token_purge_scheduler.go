@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"user-service/internal/model"
+	"user-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TokenPurgeScheduler periodically deletes expired refresh, email verification and password reset tokens
+type TokenPurgeScheduler struct {
+	TokenCleanupUseCase usecase.TokenCleanupUseCaseInterface
+	Log                 *logrus.Logger
+
+	runner *periodicRunner
+}
+
+// NewTokenPurgeScheduler creates a new token purge scheduler
+func NewTokenPurgeScheduler(tokenCleanupUseCase usecase.TokenCleanupUseCaseInterface, interval time.Duration, log *logrus.Logger) *TokenPurgeScheduler {
+	s := &TokenPurgeScheduler{
+		TokenCleanupUseCase: tokenCleanupUseCase,
+		Log:                 log,
+	}
+	s.runner = newPeriodicRunner("Token purge scheduler", interval, s.purge, log)
+	return s
+}
+
+// Start runs a purge every interval until ctx is cancelled
+func (s *TokenPurgeScheduler) Start(ctx context.Context) {
+	s.runner.Start(ctx)
+}
+
+// Purge deletes expired tokens once. It returns false without purging when the previous purge is still running.
+func (s *TokenPurgeScheduler) Purge(ctx context.Context) bool {
+	return s.runner.RunOnce(ctx)
+}
+
+// purge deletes expired tokens and logs how many of each kind were deleted
+func (s *TokenPurgeScheduler) purge(ctx context.Context) {
+	start := time.Now()
+	result, err := s.TokenCleanupUseCase.PurgeExpiredTokens(ctx)
+	if result == nil {
+		result = new(model.TokenPurgeResult)
+	}
+
+	fields := logrus.Fields{
+		"refresh_tokens":            result.RefreshTokens,
+		"email_verification_tokens": result.EmailVerificationTokens,
+		"password_reset_tokens":     result.PasswordResetTokens,
+		"duration_ms":               time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		s.Log.WithError(err).WithFields(fields).Error("Token purge failed")
+		return
+	}
+
+	s.Log.WithFields(fields).Info("Token purge completed")
+}
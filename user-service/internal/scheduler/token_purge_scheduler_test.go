@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"user-service/internal/model"
+	usecase_mock "user-service/mocks/usecase"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTokenPurgeScheduler_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenCleanupUseCase := usecase_mock.NewMockTokenCleanupUseCaseInterface(ctrl)
+
+	s := NewTokenPurgeScheduler(mockTokenCleanupUseCase, time.Hour, logrus.New())
+
+	t.Run("Success", func(t *testing.T) {
+		mockTokenCleanupUseCase.EXPECT().
+			PurgeExpiredTokens(gomock.Any()).
+			Return(&model.TokenPurgeResult{RefreshTokens: 3, PasswordResetTokens: 1}, nil)
+
+		if !s.Purge(context.Background()) {
+			t.Error("Purge() = false, want true")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockTokenCleanupUseCase.EXPECT().
+			PurgeExpiredTokens(gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		if !s.Purge(context.Background()) {
+			t.Error("Purge() = false, want true")
+		}
+	})
+}
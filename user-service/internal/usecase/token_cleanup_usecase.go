@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-service/internal/model"
+	"user-service/internal/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultTokenPurgeBatchSize is used when the batch size of a token cleanup is not positive
+const DefaultTokenPurgeBatchSize = 1000
+
+type TokenCleanupUseCaseInterface interface {
+	// PurgeExpiredTokens deletes every expired refresh, email verification and password reset token
+	PurgeExpiredTokens(ctx context.Context) (*model.TokenPurgeResult, error)
+}
+
+type TokenCleanupUseCase struct {
+	DB                               *gorm.DB
+	Log                              *logrus.Logger
+	RefreshTokenRepository           repository.RefreshTokenRepositoryInterface
+	EmailVerificationTokenRepository repository.EmailVerificationTokenRepositoryInterface
+	PasswordResetTokenRepository     repository.PasswordResetTokenRepositoryInterface
+	BatchSize                        int // Most rows deleted by a single statement
+}
+
+func NewTokenCleanupUseCase(db *gorm.DB, logger *logrus.Logger, refreshTokenRepository repository.RefreshTokenRepositoryInterface, emailVerificationTokenRepository repository.EmailVerificationTokenRepositoryInterface, passwordResetTokenRepository repository.PasswordResetTokenRepositoryInterface, batchSize int) TokenCleanupUseCaseInterface {
+	if batchSize <= 0 {
+		batchSize = DefaultTokenPurgeBatchSize
+	}
+	return &TokenCleanupUseCase{
+		DB:                               db,
+		Log:                              logger,
+		RefreshTokenRepository:           refreshTokenRepository,
+		EmailVerificationTokenRepository: emailVerificationTokenRepository,
+		PasswordResetTokenRepository:     passwordResetTokenRepository,
+		BatchSize:                        batchSize,
+	}
+}
+
+// PurgeExpiredTokens deletes the tokens that expired by the start of the purge. Each table is emptied of them in
+// batches of BatchSize rows, every batch its own statement, so no delete holds its locks for long.
+// On failure the counts deleted so far are returned with the error.
+func (c *TokenCleanupUseCase) PurgeExpiredTokens(ctx context.Context) (*model.TokenPurgeResult, error) {
+	now := time.Now()
+	result := new(model.TokenPurgeResult)
+
+	var err error
+	if result.RefreshTokens, err = c.purge(ctx, c.RefreshTokenRepository.DeleteExpired, now); err != nil {
+		return result, fmt.Errorf("purge expired refresh tokens: %w", err)
+	}
+	if result.EmailVerificationTokens, err = c.purge(ctx, c.EmailVerificationTokenRepository.DeleteExpired, now); err != nil {
+		return result, fmt.Errorf("purge expired email verification tokens: %w", err)
+	}
+	if result.PasswordResetTokens, err = c.purge(ctx, c.PasswordResetTokenRepository.DeleteExpired, now); err != nil {
+		return result, fmt.Errorf("purge expired password reset tokens: %w", err)
+	}
+
+	return result, nil
+}
+
+// purge runs deleteExpired batch after batch until a batch comes back short or ctx is cancelled, and returns
+// how many rows were deleted in total
+func (c *TokenCleanupUseCase) purge(ctx context.Context, deleteExpired func(db *gorm.DB, now time.Time, limit int) (int64, error), now time.Time) (int64, error) {
+	var total int64
+	for {
+		deleted, err := deleteExpired(c.DB.WithContext(ctx), now, c.BatchSize)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < int64(c.BatchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
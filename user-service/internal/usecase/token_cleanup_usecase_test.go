@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+	"user-service/internal/entity"
+	repository_mock "user-service/mocks/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupTokenCleanupUseCase(t *testing.T, batchSize int) (*TokenCleanupUseCase, *repository_mock.MockRefreshTokenRepositoryInterface, *repository_mock.MockEmailVerificationTokenRepositoryInterface, *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+	ctrl := gomock.NewController(t)
+
+	mockDb, mock, _ := sqlmock.New()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	}), &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	refreshTokenRepository := repository_mock.NewMockRefreshTokenRepositoryInterface(ctrl)
+	emailVerificationTokenRepository := repository_mock.NewMockEmailVerificationTokenRepositoryInterface(ctrl)
+	passwordResetTokenRepository := repository_mock.NewMockPasswordResetTokenRepositoryInterface(ctrl)
+
+	uc := NewTokenCleanupUseCase(db, logrus.New(), refreshTokenRepository, emailVerificationTokenRepository, passwordResetTokenRepository, batchSize).(*TokenCleanupUseCase)
+	return uc, refreshTokenRepository, emailVerificationTokenRepository, passwordResetTokenRepository
+}
+
+func TestNewTokenCleanupUseCase_DefaultBatchSize(t *testing.T) {
+	uc := NewTokenCleanupUseCase(nil, logrus.New(), nil, nil, nil, 0).(*TokenCleanupUseCase)
+	if uc.BatchSize != DefaultTokenPurgeBatchSize {
+		t.Errorf("NewTokenCleanupUseCase() BatchSize = %d, want %d", uc.BatchSize, DefaultTokenPurgeBatchSize)
+	}
+}
+
+func TestTokenCleanupUseCase_PurgeExpiredTokens(t *testing.T) {
+	t.Run("removes expired tokens and keeps live ones", func(t *testing.T) {
+		uc, refreshTokenRepository, emailVerificationTokenRepository, passwordResetTokenRepository := setupTokenCleanupUseCase(t, 100)
+
+		expired := &entity.RefreshToken{ID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}
+		live := &entity.RefreshToken{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+		stored := []*entity.RefreshToken{expired, live}
+
+		// Deletes from stored the way the repository deletes from the table
+		refreshTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 100).
+			DoAndReturn(func(db *gorm.DB, now time.Time, limit int) (int64, error) {
+				var kept []*entity.RefreshToken
+				var deleted int64
+				for _, token := range stored {
+					if !token.ExpiresAt.After(now) && deleted < int64(limit) {
+						deleted++
+						continue
+					}
+					kept = append(kept, token)
+				}
+				stored = kept
+				return deleted, nil
+			})
+		emailVerificationTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 100).Return(int64(0), nil)
+		passwordResetTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 100).Return(int64(2), nil)
+
+		result, err := uc.PurgeExpiredTokens(context.Background())
+		if err != nil {
+			t.Fatalf("PurgeExpiredTokens() error = %v", err)
+		}
+		if result.RefreshTokens != 1 || result.EmailVerificationTokens != 0 || result.PasswordResetTokens != 2 {
+			t.Errorf("PurgeExpiredTokens() = %+v, want 1 refresh, 0 email verification and 2 password reset tokens", result)
+		}
+		if len(stored) != 1 || stored[0] != live {
+			t.Errorf("PurgeExpiredTokens() left %d refresh tokens, want only the live one", len(stored))
+		}
+	})
+
+	t.Run("deletes in batches until a batch comes back short", func(t *testing.T) {
+		uc, refreshTokenRepository, emailVerificationTokenRepository, passwordResetTokenRepository := setupTokenCleanupUseCase(t, 2)
+
+		gomock.InOrder(
+			refreshTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 2).Return(int64(2), nil),
+			refreshTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 2).Return(int64(2), nil),
+			refreshTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 2).Return(int64(1), nil),
+		)
+		emailVerificationTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 2).Return(int64(0), nil)
+		passwordResetTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 2).Return(int64(0), nil)
+
+		result, err := uc.PurgeExpiredTokens(context.Background())
+		if err != nil {
+			t.Fatalf("PurgeExpiredTokens() error = %v", err)
+		}
+		if result.RefreshTokens != 5 {
+			t.Errorf("PurgeExpiredTokens() RefreshTokens = %d, want 5", result.RefreshTokens)
+		}
+	})
+
+	t.Run("stops and reports what was deleted when a delete fails", func(t *testing.T) {
+		uc, refreshTokenRepository, emailVerificationTokenRepository, _ := setupTokenCleanupUseCase(t, 100)
+
+		refreshTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 100).Return(int64(3), nil)
+		emailVerificationTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 100).Return(int64(0), errors.New("lock wait timeout"))
+
+		result, err := uc.PurgeExpiredTokens(context.Background())
+		if err == nil {
+			t.Fatal("PurgeExpiredTokens() expected an error")
+		}
+		if result.RefreshTokens != 3 {
+			t.Errorf("PurgeExpiredTokens() RefreshTokens = %d, want 3", result.RefreshTokens)
+		}
+	})
+
+	t.Run("stops between batches once the context is cancelled", func(t *testing.T) {
+		uc, refreshTokenRepository, _, _ := setupTokenCleanupUseCase(t, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		refreshTokenRepository.EXPECT().DeleteExpired(gomock.Any(), gomock.Any(), 1).
+			DoAndReturn(func(db *gorm.DB, now time.Time, limit int) (int64, error) {
+				cancel()
+				return 1, nil
+			})
+
+		result, err := uc.PurgeExpiredTokens(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("PurgeExpiredTokens() error = %v, want %v", err, context.Canceled)
+		}
+		if result.RefreshTokens != 1 {
+			t.Errorf("PurgeExpiredTokens() RefreshTokens = %d, want 1", result.RefreshTokens)
+		}
+	})
+}
@@ -2,7 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"user-service/internal/auth"
 	"user-service/internal/entity"
+	appErrors "user-service/internal/errors"
+	"user-service/internal/mail"
 	"user-service/internal/model"
 	"user-service/internal/model/converter"
 	"user-service/internal/repository"
@@ -18,21 +26,64 @@ import (
 type UserUseCaseInterface interface {
 	Create(ctx context.Context, request *model.RegisterUserRequest) (*model.UserResponse, error)
 	Login(ctx context.Context, request *model.LoginUserRequest) (*model.UserResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.UserResponse, error)
+	Update(ctx context.Context, userID uuid.UUID, request *model.UpdateUserRequest) (*model.UserResponse, error)
+	ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword string, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) (*model.UserResponse, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string) error
+}
+
+// LoginLockoutPolicy controls how many consecutive failed logins lock an account and for how long.
+// A zero MaxFailedAttempts disables the lockout.
+type LoginLockoutPolicy struct {
+	MaxFailedAttempts int
+	Duration          time.Duration
+}
+
+// EmailVerificationPolicy controls the verification email sent on registration.
+// The token is appended to LinkURL as the token query parameter.
+type EmailVerificationPolicy struct {
+	TokenTTL        time.Duration
+	LinkURL         string
+	RequiredToLogin bool // Reject logins until the email is verified
+}
+
+// PasswordResetPolicy controls the password reset email. The token is appended to LinkURL as the token query parameter.
+type PasswordResetPolicy struct {
+	TokenTTL time.Duration
+	LinkURL  string
 }
 
 type UserUseCase struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	UserRepository repository.UserRepositoryInterface
+	DB                               *gorm.DB
+	Log                              *logrus.Logger
+	Validate                         *validator.Validate
+	UserRepository                   repository.UserRepositoryInterface
+	RefreshTokenRepository           repository.RefreshTokenRepositoryInterface
+	EmailVerificationTokenRepository repository.EmailVerificationTokenRepositoryInterface
+	PasswordResetTokenRepository     repository.PasswordResetTokenRepositoryInterface
+	TokenManager                     auth.TokenManagerInterface
+	Mailer                           mail.SenderInterface
+	LoginLockout                     LoginLockoutPolicy
+	EmailVerification                EmailVerificationPolicy
+	PasswordReset                    PasswordResetPolicy
 }
 
-func NewUserUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate, userRepository repository.UserRepositoryInterface) UserUseCaseInterface {
+func NewUserUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate, userRepository repository.UserRepositoryInterface, refreshTokenRepository repository.RefreshTokenRepositoryInterface, emailVerificationTokenRepository repository.EmailVerificationTokenRepositoryInterface, passwordResetTokenRepository repository.PasswordResetTokenRepositoryInterface, tokenManager auth.TokenManagerInterface, mailer mail.SenderInterface, loginLockout LoginLockoutPolicy, emailVerification EmailVerificationPolicy, passwordReset PasswordResetPolicy) UserUseCaseInterface {
 	return &UserUseCase{
-		DB:             db,
-		Log:            logger,
-		Validate:       validate,
-		UserRepository: userRepository,
+		DB:                               db,
+		Log:                              logger,
+		Validate:                         validate,
+		UserRepository:                   userRepository,
+		RefreshTokenRepository:           refreshTokenRepository,
+		EmailVerificationTokenRepository: emailVerificationTokenRepository,
+		PasswordResetTokenRepository:     passwordResetTokenRepository,
+		TokenManager:                     tokenManager,
+		Mailer:                           mailer,
+		LoginLockout:                     loginLockout,
+		EmailVerification:                emailVerification,
+		PasswordReset:                    passwordReset,
 	}
 }
 
@@ -40,12 +91,27 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	request.Name = normalizeText(request.Name)
+	request.Email = normalizeEmail(request.Email)
+
 	err := c.Validate.Struct(request)
 	if err != nil {
 		c.Log.Warnf("Invalid request body : %+v", err)
 		return nil, fiber.ErrBadRequest
 	}
 
+	// Emails are stored normalized, so a differently cased or padded copy of a registered email is found here
+	existingUser := new(entity.User)
+	err = c.UserRepository.FindByEmail(tx, existingUser, request.Email)
+	if err == nil {
+		c.Log.Warnf("Email is already used by user %s", existingUser.ID)
+		return nil, appErrors.ErrDuplicateEmail
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.Warnf("Failed find user by email : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	password, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
 		c.Log.Warnf("Failed to generate bcrype hash : %+v", err)
@@ -60,10 +126,110 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 	}
 
 	if err := c.UserRepository.Create(tx, user); err != nil {
+		// A concurrent registration took the email after it was checked
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			c.Log.Warnf("Email %s was registered concurrently", request.Email)
+			return nil, appErrors.ErrDuplicateEmail
+		}
 		c.Log.Warnf("Failed create user to database : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	// The account starts unverified; the token proving ownership of the email is stored with it
+	verificationToken, err := auth.NewOpaqueToken()
+	if err != nil {
+		c.Log.Warnf("Failed to generate email verification token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	storedVerificationToken := &entity.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashOpaqueToken(verificationToken),
+		ExpiresAt: time.Now().Add(c.EmailVerification.TokenTTL),
+	}
+	if err := c.EmailVerificationTokenRepository.Create(tx, storedVerificationToken); err != nil {
+		c.Log.Warnf("Failed create email verification token to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// The account exists now, so a failed email is logged rather than failing the registration
+	if err := c.Mailer.Send(ctx, c.verificationEmail(user, verificationToken)); err != nil {
+		c.Log.Warnf("Failed send verification email to user %s : %+v", user.ID, err)
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// verificationEmail builds the email asking the user to confirm their address
+func (c *UserUseCase) verificationEmail(user *entity.User, token string) mail.Message {
+	link := c.EmailVerification.LinkURL + "?token=" + url.QueryEscape(token)
+	return mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below. It expires in %s.\n\n%s\n",
+			user.Name, c.EmailVerification.TokenTTL, link),
+	}
+}
+
+// VerifyEmail marks the email of the token's user as verified. Each token can be used once, before it expires.
+func (c *UserUseCase) VerifyEmail(ctx context.Context, token string) (*model.UserResponse, error) {
+	if err := c.Validate.Var(token, "required,max=255"); err != nil {
+		c.Log.Warnf("Invalid email verification token : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	storedToken, err := c.EmailVerificationTokenRepository.FindByHash(tx, auth.HashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warn("Email verification token not found")
+			return nil, appErrors.ErrInvalidVerificationToken
+		}
+		c.Log.Warnf("Failed find email verification token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if storedToken.IsUsed() {
+		c.Log.Warnf("Email verification token %s has already been used", storedToken.ID)
+		return nil, appErrors.ErrVerificationTokenUsed
+	}
+
+	if storedToken.IsExpired(time.Now()) {
+		c.Log.Warnf("Email verification token %s has expired", storedToken.ID)
+		return nil, appErrors.ErrVerificationTokenExpired
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindByID(tx, user, storedToken.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("User %s of email verification token %s not found", storedToken.UserID, storedToken.ID)
+			return nil, appErrors.ErrInvalidVerificationToken
+		}
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.EmailVerificationTokenRepository.MarkUsed(tx, storedToken); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			c.Log.Warnf("Email verification token %s was used by a concurrent request", storedToken.ID)
+			return nil, appErrors.ErrVerificationTokenUsed
+		}
+		c.Log.Warnf("Failed mark email verification token as used : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user.EmailVerified = true
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed update user : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed commit transaction : %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -76,6 +242,8 @@ func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	request.Email = normalizeEmail(request.Email)
+
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Invalid request body  : %+v", err)
 		return nil, fiber.ErrBadRequest
@@ -87,14 +255,234 @@ func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest
 		return nil, fiber.ErrUnauthorized
 	}
 
+	now := time.Now()
+	if user.IsLocked(now) {
+		c.Log.Warnf("Rejected login for locked user %s", user.ID)
+		return nil, accountLockedError(*user.LockedUntil)
+	}
+
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
 		c.Log.Warnf("Failed to compare user password with bcrype hash : %+v", err)
+		return nil, c.recordFailedLogin(tx, user, now)
+	}
+
+	// Checked after the password so the verification status is not revealed to someone guessing
+	if c.EmailVerification.RequiredToLogin && !user.EmailVerified {
+		c.Log.Warnf("Rejected login for user %s with an unverified email", user.ID)
+		return nil, appErrors.ErrEmailNotVerified
+	}
+
+	// A successful login clears failures left over from earlier attempts or an expired lockout
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
+		if err := c.UserRepository.Update(tx, user); err != nil {
+			c.Log.Warnf("Failed reset failed login attempts : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	// Issue a signed JWT; the legacy token column is no longer written
+	token, expiresAt, err := c.TokenManager.Issue(user.ID, user.Role)
+	if err != nil {
+		c.Log.Warnf("Failed to issue access token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	refreshToken, refreshExpiresAt, err := c.TokenManager.IssueRefreshToken()
+	if err != nil {
+		c.Log.Warnf("Failed to issue refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Only the hash is stored so the token can be looked up and revoked without being recoverable
+	storedRefreshToken := &entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashRefreshToken(refreshToken),
+		ExpiresAt: refreshExpiresAt,
+	}
+	if err := c.RefreshTokenRepository.Create(tx, storedRefreshToken); err != nil {
+		c.Log.Warnf("Failed create refresh token to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToLoginResponse(token, expiresAt, refreshToken, refreshExpiresAt), nil
+}
+
+// recordFailedLogin counts a failed login and locks the account once the policy's threshold is reached.
+// The counter is committed even though the login fails, and the error to return to the caller is returned.
+func (c *UserUseCase) recordFailedLogin(tx *gorm.DB, user *entity.User, now time.Time) error {
+	if c.LoginLockout.MaxFailedAttempts <= 0 {
+		return fiber.ErrUnauthorized
+	}
+
+	user.FailedLoginAttempts++
+	locked := user.FailedLoginAttempts >= c.LoginLockout.MaxFailedAttempts
+	if locked {
+		lockedUntil := now.Add(c.LoginLockout.Duration)
+		user.LockedUntil = &lockedUntil
+		user.FailedLoginAttempts = 0
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed record failed login attempt : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if locked {
+		c.Log.Warnf("Locked user %s until %s after %d failed logins", user.ID, user.LockedUntil.Format(time.RFC3339), c.LoginLockout.MaxFailedAttempts)
+		return accountLockedError(*user.LockedUntil)
+	}
+	return fiber.ErrUnauthorized
+}
+
+// accountLockedError tells the caller when a locked account accepts logins again
+func accountLockedError(lockedUntil time.Time) error {
+	return appErrors.WithMessage(appErrors.ErrAccountLocked,
+		fmt.Sprintf("Too many failed login attempts, try again after %s", lockedUntil.UTC().Format(time.RFC3339)))
+}
+
+// Refresh exchanges a refresh token that is neither expired nor revoked for a new access token and a new
+// refresh token. The presented token is revoked in the same transaction, so each refresh token is used once.
+func (c *UserUseCase) Refresh(ctx context.Context, refreshToken string) (*model.UserResponse, error) {
+	if err := c.Validate.Var(refreshToken, "required,max=255"); err != nil {
+		c.Log.Warnf("Invalid refresh token : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	storedRefreshToken, err := c.RefreshTokenRepository.FindByHash(tx, auth.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warn("Refresh token not found")
+			return nil, fiber.ErrUnauthorized
+		}
+		c.Log.Warnf("Failed find refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if storedRefreshToken.IsRevoked() {
+		c.Log.Warnf("Refresh token %s has been revoked", storedRefreshToken.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if storedRefreshToken.IsExpired(now) {
+		c.Log.Warnf("Refresh token %s has expired", storedRefreshToken.ID)
 		return nil, fiber.ErrUnauthorized
 	}
 
-	user.Token = uuid.New().String()
+	// Look the user up again so role changes take effect on the next refresh
+	user := new(entity.User)
+	if err := c.UserRepository.FindByID(tx, user, storedRefreshToken.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("User %s of refresh token %s not found", storedRefreshToken.UserID, storedRefreshToken.ID)
+			return nil, fiber.ErrUnauthorized
+		}
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// A lockout stops refreshes as well as logins, so an attacker holding a refresh token is shut out too
+	if user.IsLocked(now) {
+		c.Log.Warnf("Rejected refresh for locked user %s", user.ID)
+		return nil, accountLockedError(*user.LockedUntil)
+	}
+
+	// Only one of two concurrent refreshes with the same token revokes it; the other is rejected
+	if err := c.RefreshTokenRepository.Revoke(tx, storedRefreshToken); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Refresh token %s has been revoked", storedRefreshToken.ID)
+			return nil, fiber.ErrUnauthorized
+		}
+		c.Log.Warnf("Failed revoke refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	token, expiresAt, err := c.TokenManager.Issue(user.ID, user.Role)
+	if err != nil {
+		c.Log.Warnf("Failed to issue access token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	newRefreshToken, refreshExpiresAt, err := c.TokenManager.IssueRefreshToken()
+	if err != nil {
+		c.Log.Warnf("Failed to issue refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.RefreshTokenRepository.Create(tx, &entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashRefreshToken(newRefreshToken),
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
+		c.Log.Warnf("Failed create refresh token to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToLoginResponse(token, expiresAt, newRefreshToken, refreshExpiresAt), nil
+}
+
+// Update applies the non-empty profile fields of the request to the user
+func (c *UserUseCase) Update(ctx context.Context, userID uuid.UUID, request *model.UpdateUserRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	request.Name = normalizeText(request.Name)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindByID(tx, user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("User %s not found", userID)
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Update fields only if they are provided
+	if request.Name != "" {
+		user.Name = request.Name
+	}
+
+	if request.Phone != "" && request.Phone != user.Phone {
+		existingUser := new(entity.User)
+		err := c.UserRepository.FindByPhone(tx, existingUser, request.Phone)
+		if err == nil {
+			c.Log.Warnf("Phone number is already used by user %s", existingUser.ID)
+			return nil, appErrors.ErrDuplicatePhone
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed find user by phone : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		user.Phone = request.Phone
+	}
+
 	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.Warnf("Failed save user : %+v", err)
+		c.Log.Warnf("Failed update user : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -103,5 +491,225 @@ func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.UserToTokenResponse(user), nil
+	return converter.UserToResponse(user), nil
+}
+
+// ChangePassword replaces the user's password after verifying the current one, and revokes
+// the user's refresh tokens so sessions started with the old password cannot be extended
+func (c *UserUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword string, newPassword string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Var(oldPassword, "required,max=100"); err != nil {
+		c.Log.Warnf("Invalid current password : %+v", err)
+		return fiber.ErrBadRequest
+	}
+	if err := c.Validate.Var(newPassword, "required,max=100"); err != nil {
+		c.Log.Warnf("Invalid new password : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindByID(tx, user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("User %s not found", userID)
+			return fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword)); err != nil {
+		c.Log.Warnf("Current password does not match for user %s", userID)
+		return appErrors.ErrIncorrectPassword
+	}
+
+	if err := auth.CheckPasswordStrength(newPassword); err != nil {
+		c.Log.Warnf("Rejected weak password for user %s : %+v", userID, err)
+		return appErrors.WithMessage(appErrors.ErrWeakPassword, err.Error())
+	}
+	if oldPassword == newPassword {
+		return appErrors.WithMessage(appErrors.ErrWeakPassword, "New password must differ from the current password")
+	}
+
+	password, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.Warnf("Failed to generate bcrype hash : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	user.Password = string(password)
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed update user password : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.RefreshTokenRepository.RevokeAllForUser(tx, userID); err != nil {
+		c.Log.Warnf("Failed revoke refresh tokens : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// RequestPasswordReset emails a single-use password reset link to the user with the given email.
+// An unknown email is not an error, so callers cannot use it to find out which emails have accounts.
+func (c *UserUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+	if err := c.Validate.Var(email, "required,email,max=255"); err != nil {
+		c.Log.Warnf("Invalid email : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindByEmail(tx, user, email); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Info("Password reset requested for an unknown email")
+			return nil
+		}
+		c.Log.Warnf("Failed find user by email : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resetToken, err := auth.NewOpaqueToken()
+	if err != nil {
+		c.Log.Warnf("Failed to generate password reset token : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	storedResetToken := &entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashOpaqueToken(resetToken),
+		ExpiresAt: time.Now().Add(c.PasswordReset.TokenTTL),
+	}
+	if err := c.PasswordResetTokenRepository.Create(tx, storedResetToken); err != nil {
+		c.Log.Warnf("Failed create password reset token to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	// Failing here would tell the caller the email has an account, so the error is only logged
+	if err := c.Mailer.Send(ctx, c.passwordResetEmail(user, resetToken)); err != nil {
+		c.Log.Warnf("Failed send password reset email to user %s : %+v", user.ID, err)
+	}
+
+	return nil
+}
+
+// passwordResetEmail builds the email with the link to choose a new password
+func (c *UserUseCase) passwordResetEmail(user *entity.User, token string) mail.Message {
+	link := c.PasswordReset.LinkURL + "?token=" + url.QueryEscape(token)
+	return mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to choose a new password. It expires in %s.\nIf you did not ask to reset your password, you can ignore this email.\n\n%s\n",
+			user.Name, c.PasswordReset.TokenTTL, link),
+	}
+}
+
+// ResetPassword replaces the password of the token's user. Each token can be used once, before it expires.
+// Like ChangePassword, it revokes the user's refresh tokens; it also lifts a login lockout.
+func (c *UserUseCase) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	if err := c.Validate.Var(token, "required,max=255"); err != nil {
+		c.Log.Warnf("Invalid password reset token : %+v", err)
+		return fiber.ErrBadRequest
+	}
+	if err := c.Validate.Var(newPassword, "required,max=100"); err != nil {
+		c.Log.Warnf("Invalid new password : %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	storedToken, err := c.PasswordResetTokenRepository.FindByHash(tx, auth.HashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warn("Password reset token not found")
+			return appErrors.ErrInvalidResetToken
+		}
+		c.Log.Warnf("Failed find password reset token : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if storedToken.IsUsed() {
+		c.Log.Warnf("Password reset token %s has already been used", storedToken.ID)
+		return appErrors.ErrResetTokenUsed
+	}
+
+	if storedToken.IsExpired(time.Now()) {
+		c.Log.Warnf("Password reset token %s has expired", storedToken.ID)
+		return appErrors.ErrResetTokenExpired
+	}
+
+	if err := auth.CheckPasswordStrength(newPassword); err != nil {
+		c.Log.Warnf("Rejected weak password for user %s : %+v", storedToken.UserID, err)
+		return appErrors.WithMessage(appErrors.ErrWeakPassword, err.Error())
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindByID(tx, user, storedToken.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("User %s of password reset token %s not found", storedToken.UserID, storedToken.ID)
+			return appErrors.ErrInvalidResetToken
+		}
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.PasswordResetTokenRepository.MarkUsed(tx, storedToken); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			c.Log.Warnf("Password reset token %s was used by a concurrent request", storedToken.ID)
+			return appErrors.ErrResetTokenUsed
+		}
+		c.Log.Warnf("Failed mark password reset token as used : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	password, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.Warnf("Failed to generate bcrype hash : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	user.Password = string(password)
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warnf("Failed update user password : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.RefreshTokenRepository.RevokeAllForUser(tx, user.ID); err != nil {
+		c.Log.Warnf("Failed revoke refresh tokens : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// normalizeText removes leading and trailing whitespace and replaces each run of inner whitespace with a single space
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// normalizeEmail trims an email and lowercases it, so " John@Example.com " is stored as, and collides with, "john@example.com"
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
@@ -6,14 +6,22 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+	"user-service/internal/auth"
 	"user-service/internal/entity"
+	appErrors "user-service/internal/errors"
+	"user-service/internal/mail"
 	"user-service/internal/model"
 	"user-service/internal/repository"
+	mail_mock "user-service/mocks/mail"
 	repository_mock "user-service/mocks/repository"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
@@ -25,10 +33,18 @@ func TestNewUserUseCase(t *testing.T) {
 	newLogrus := logrus.New()
 	newValidator := validator.New()
 	type args struct {
-		db             *gorm.DB
-		logger         *logrus.Logger
-		validate       *validator.Validate
-		userRepository repository.UserRepositoryInterface
+		db                               *gorm.DB
+		logger                           *logrus.Logger
+		validate                         *validator.Validate
+		userRepository                   repository.UserRepositoryInterface
+		refreshTokenRepository           repository.RefreshTokenRepositoryInterface
+		emailVerificationTokenRepository repository.EmailVerificationTokenRepositoryInterface
+		passwordResetTokenRepository     repository.PasswordResetTokenRepositoryInterface
+		tokenManager                     auth.TokenManagerInterface
+		mailer                           mail.SenderInterface
+		loginLockout                     LoginLockoutPolicy
+		emailVerification                EmailVerificationPolicy
+		passwordReset                    PasswordResetPolicy
 	}
 	tests := []struct {
 		name string
@@ -38,22 +54,38 @@ func TestNewUserUseCase(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				db:             nil,
-				logger:         newLogrus,
-				validate:       newValidator,
-				userRepository: nil,
+				db:                               nil,
+				logger:                           newLogrus,
+				validate:                         newValidator,
+				userRepository:                   nil,
+				refreshTokenRepository:           nil,
+				emailVerificationTokenRepository: nil,
+				passwordResetTokenRepository:     nil,
+				tokenManager:                     nil,
+				mailer:                           nil,
+				loginLockout:                     LoginLockoutPolicy{MaxFailedAttempts: 5, Duration: 15 * time.Minute},
+				emailVerification:                EmailVerificationPolicy{TokenTTL: 24 * time.Hour, LinkURL: "http://localhost:3000/api/v1/users/verify"},
+				passwordReset:                    PasswordResetPolicy{TokenTTL: 30 * time.Minute, LinkURL: "http://localhost:3000/reset-password"},
 			},
 			want: &UserUseCase{
-				DB:             nil,
-				Log:            newLogrus,
-				Validate:       newValidator,
-				UserRepository: nil,
+				DB:                               nil,
+				Log:                              newLogrus,
+				Validate:                         newValidator,
+				UserRepository:                   nil,
+				RefreshTokenRepository:           nil,
+				EmailVerificationTokenRepository: nil,
+				PasswordResetTokenRepository:     nil,
+				TokenManager:                     nil,
+				Mailer:                           nil,
+				LoginLockout:                     LoginLockoutPolicy{MaxFailedAttempts: 5, Duration: 15 * time.Minute},
+				EmailVerification:                EmailVerificationPolicy{TokenTTL: 24 * time.Hour, LinkURL: "http://localhost:3000/api/v1/users/verify"},
+				PasswordReset:                    PasswordResetPolicy{TokenTTL: 30 * time.Minute, LinkURL: "http://localhost:3000/reset-password"},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NewUserUseCase(tt.args.db, tt.args.logger, tt.args.validate, tt.args.userRepository)
+			got := NewUserUseCase(tt.args.db, tt.args.logger, tt.args.validate, tt.args.userRepository, tt.args.refreshTokenRepository, tt.args.emailVerificationTokenRepository, tt.args.passwordResetTokenRepository, tt.args.tokenManager, tt.args.mailer, tt.args.loginLockout, tt.args.emailVerification, tt.args.passwordReset)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserUseCase() = %v, want %v", got, tt.want)
 			}
@@ -89,11 +121,21 @@ func TestUserUseCase_Create(t *testing.T) {
 		log.Fatal("Error opening DB connection: ", err)
 	}
 
+	// sentVerificationEmail records the email delivered by the last successful registration
+	var sentVerificationEmail mail.Message
+	mailer := mail_mock.NewMockSenderInterface(ctrl)
+	mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, message mail.Message) error {
+			sentVerificationEmail = message
+			return nil
+		}).AnyTimes()
+
 	type fields struct {
-		DB             *gorm.DB
-		Log            *logrus.Logger
-		Validate       *validator.Validate
-		UserRepository repository.UserRepositoryInterface
+		DB                               *gorm.DB
+		Log                              *logrus.Logger
+		Validate                         *validator.Validate
+		UserRepository                   repository.UserRepositoryInterface
+		EmailVerificationTokenRepository repository.EmailVerificationTokenRepositoryInterface
 	}
 	type args struct {
 		ctx     context.Context
@@ -103,8 +145,9 @@ func TestUserUseCase_Create(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
-		want    *model.UserResponse
-		wantErr bool
+		want      *model.UserResponse
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "success",
@@ -114,6 +157,12 @@ func TestUserUseCase_Create(t *testing.T) {
 				Validate: validator.New(),
 				UserRepository: func() repository.UserRepositoryInterface {
 					r := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					r.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "fg1w2@example.com").Return(gorm.ErrRecordNotFound)
+					r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+					return r
+				}(),
+				EmailVerificationTokenRepository: func() repository.EmailVerificationTokenRepositoryInterface {
+					r := repository_mock.NewMockEmailVerificationTokenRepositoryInterface(ctrl)
 					r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 					return r
 				}(),
@@ -130,7 +179,7 @@ func TestUserUseCase_Create(t *testing.T) {
 			want: &model.UserResponse{
 				ID:    "generated-uuid",
 				Name:  "John Doe",
-				Email: "Fg1w2@example.com",
+				Email: "fg1w2@example.com",
 				Phone: "08123456789",
 			},
 			wantErr: false,
@@ -162,6 +211,7 @@ func TestUserUseCase_Create(t *testing.T) {
 				Validate: validator.New(),
 				UserRepository: func() repository.UserRepositoryInterface {
 					r := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					r.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "fg1w2@example.com").Return(gorm.ErrRecordNotFound)
 					r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("error create user"))
 					return r
 				}(),
@@ -178,6 +228,59 @@ func TestUserUseCase_Create(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "padded email collides with existing email",
+			fields: fields{
+				DB:       db,
+				Log:      logrus.New(),
+				Validate: validator.New(),
+				UserRepository: func() repository.UserRepositoryInterface {
+					r := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					// The account registered as fg1w2@example.com is found under the normalized email
+					r.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "fg1w2@example.com").Return(nil)
+					return r
+				}(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				request: &model.RegisterUserRequest{
+					Name:     "  John   Doe ",
+					Email:    " Fg1w2@Example.COM ",
+					Phone:    "08123456789",
+					Password: "password123",
+				},
+			},
+			want:      nil,
+			wantErr:   true,
+			wantErrIs: appErrors.ErrDuplicateEmail,
+		},
+		{
+			name: "email registered concurrently",
+			fields: fields{
+				DB:       db,
+				Log:      logrus.New(),
+				Validate: validator.New(),
+				UserRepository: func() repository.UserRepositoryInterface {
+					r := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					r.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "fg1w2@example.com").Return(gorm.ErrRecordNotFound)
+					// Another registration inserted the email after the lookup
+					r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(gorm.ErrDuplicatedKey)
+					return r
+				}(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				request: &model.RegisterUserRequest{
+					Name:     "John Doe",
+					Email:    "fg1w2@example.com",
+					Phone:    "08123456789",
+					Password: "password123",
+				},
+			},
+			want:      nil,
+			wantErr:   true,
+			wantErrIs: appErrors.ErrDuplicateEmail,
+		},
 		{
 			name: "error on commit",
 			fields: fields{
@@ -186,6 +289,12 @@ func TestUserUseCase_Create(t *testing.T) {
 				Validate: validator.New(),
 				UserRepository: func() repository.UserRepositoryInterface {
 					r := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					r.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "fg1w2@example.com").Return(gorm.ErrRecordNotFound)
+					r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+					return r
+				}(),
+				EmailVerificationTokenRepository: func() repository.EmailVerificationTokenRepositoryInterface {
+					r := repository_mock.NewMockEmailVerificationTokenRepositoryInterface(ctrl)
 					r.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 					return r
 				}(),
@@ -206,19 +315,33 @@ func TestUserUseCase_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &UserUseCase{
-				DB:             tt.fields.DB,
-				Log:            tt.fields.Log,
-				Validate:       tt.fields.Validate,
-				UserRepository: tt.fields.UserRepository,
+				DB:                               tt.fields.DB,
+				Log:                              tt.fields.Log,
+				Validate:                         tt.fields.Validate,
+				UserRepository:                   tt.fields.UserRepository,
+				EmailVerificationTokenRepository: tt.fields.EmailVerificationTokenRepository,
+				Mailer:                           mailer,
+				EmailVerification:                EmailVerificationPolicy{TokenTTL: time.Hour, LinkURL: "http://localhost:3000/api/v1/users/verify"},
 			}
 			got, err := c.Create(tt.args.ctx, tt.args.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UserUseCase.Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("UserUseCase.Create() error = %v, want %v", err, tt.wantErrIs)
+			}
 			if got != nil && !reflect.DeepEqual(got.Email, tt.want.Email) {
 				t.Errorf("UserUseCase.Create() = %v, want %v", got, tt.want)
 			}
+			if got != nil {
+				if got.EmailVerified == nil || *got.EmailVerified {
+					t.Errorf("UserUseCase.Create() expected an unverified user, got %v", got.EmailVerified)
+				}
+				if sentVerificationEmail.To != tt.args.request.Email || !strings.Contains(sentVerificationEmail.Body, "/users/verify?token=") {
+					t.Errorf("UserUseCase.Create() verification email = %+v", sentVerificationEmail)
+				}
+			}
 		})
 	}
 }
@@ -244,12 +367,32 @@ func TestUserUseCase_Login(t *testing.T) {
 	}
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	userID := uuid.New()
+
+	tokenManager := auth.NewTokenManager("test-secret", time.Hour, time.Hour)
+
+	// storedRefreshTokenHash records the hash persisted by the last successful login
+	var storedRefreshTokenHash string
+	refreshTokenRepository := func(createErr error) repository.RefreshTokenRepositoryInterface {
+		repo := repository_mock.NewMockRefreshTokenRepositoryInterface(ctrl)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, token *entity.RefreshToken) error {
+				if createErr == nil {
+					storedRefreshTokenHash = token.TokenHash
+				}
+				return createErr
+			})
+		return repo
+	}
 
 	type fields struct {
-		DB             *gorm.DB
-		Log            *logrus.Logger
-		Validate       *validator.Validate
-		UserRepository repository.UserRepositoryInterface
+		DB                     *gorm.DB
+		Log                    *logrus.Logger
+		Validate               *validator.Validate
+		UserRepository         repository.UserRepositoryInterface
+		RefreshTokenRepository repository.RefreshTokenRepositoryInterface
+		TokenManager           auth.TokenManagerInterface
+		EmailVerification      EmailVerificationPolicy
 	}
 	type args struct {
 		ctx     context.Context
@@ -274,13 +417,14 @@ func TestUserUseCase_Login(t *testing.T) {
 					repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
 					repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
 						func(db *gorm.DB, user *entity.User, email string) error {
+							user.ID = userID
 							user.Email = "user@example.com"
 							user.Password = string(hashedPassword)
 							return nil
 						})
-					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 					return repo
 				}(),
+				RefreshTokenRepository: refreshTokenRepository(nil),
 			},
 			args: args{
 				ctx: context.TODO(),
@@ -294,7 +438,7 @@ func TestUserUseCase_Login(t *testing.T) {
 				mock.ExpectCommit()
 			},
 			want: &model.UserResponse{
-				Token: "token-uuid", // We won't check the exact token value
+				Token: "signed-jwt", // We won't check the exact token value
 			},
 			wantErr: false,
 		},
@@ -346,6 +490,32 @@ func TestUserUseCase_Login(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "padded_mixed_case_email_is_normalized",
+			fields: fields{
+				DB:       db,
+				Log:      logrus.New(),
+				Validate: validator.New(),
+				UserRepository: func() repository.UserRepositoryInterface {
+					repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "nonexistent@example.com").Return(gorm.ErrRecordNotFound)
+					return repo
+				}(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				request: &model.LoginUserRequest{
+					Email:    " NonExistent@Example.com ",
+					Password: "password123",
+				},
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "incorrect_password",
 			fields: fields{
@@ -378,7 +548,73 @@ func TestUserUseCase_Login(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "update_user_fails",
+			name: "unverified_email_rejected_when_required",
+			fields: fields{
+				DB:       db,
+				Log:      logrus.New(),
+				Validate: validator.New(),
+				UserRepository: func() repository.UserRepositoryInterface {
+					repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
+						func(db *gorm.DB, user *entity.User, email string) error {
+							user.ID = userID
+							user.Email = "user@example.com"
+							user.Password = string(hashedPassword)
+							user.EmailVerified = false
+							return nil
+						})
+					return repo
+				}(),
+				EmailVerification: EmailVerificationPolicy{RequiredToLogin: true},
+			},
+			args: args{
+				ctx: context.TODO(),
+				request: &model.LoginUserRequest{
+					Email:    "user@example.com",
+					Password: "password123",
+				},
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "issue_token_fails",
+			fields: fields{
+				DB:       db,
+				Log:      logrus.New(),
+				Validate: validator.New(),
+				UserRepository: func() repository.UserRepositoryInterface {
+					repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+					repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
+						func(db *gorm.DB, user *entity.User, email string) error {
+							user.Email = "user@example.com"
+							user.Password = string(hashedPassword)
+							return nil
+						})
+					return repo
+				}(),
+				TokenManager: &failingTokenManager{},
+			},
+			args: args{
+				ctx: context.TODO(),
+				request: &model.LoginUserRequest{
+					Email:    "user@example.com",
+					Password: "password123",
+				},
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "create_refresh_token_fails",
 			fields: fields{
 				DB:       db,
 				Log:      logrus.New(),
@@ -387,13 +623,14 @@ func TestUserUseCase_Login(t *testing.T) {
 					repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
 					repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
 						func(db *gorm.DB, user *entity.User, email string) error {
+							user.ID = userID
 							user.Email = "user@example.com"
 							user.Password = string(hashedPassword)
 							return nil
 						})
-					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update failed"))
 					return repo
 				}(),
+				RefreshTokenRepository: refreshTokenRepository(errors.New("insert failed")),
 			},
 			args: args{
 				ctx: context.TODO(),
@@ -419,13 +656,14 @@ func TestUserUseCase_Login(t *testing.T) {
 					repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
 					repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
 						func(db *gorm.DB, user *entity.User, email string) error {
+							user.ID = userID
 							user.Email = "user@example.com"
 							user.Password = string(hashedPassword)
 							return nil
 						})
-					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 					return repo
 				}(),
+				RefreshTokenRepository: refreshTokenRepository(nil),
 			},
 			args: args{
 				ctx: context.TODO(),
@@ -450,10 +688,16 @@ func TestUserUseCase_Login(t *testing.T) {
 			}
 
 			c := &UserUseCase{
-				DB:             tt.fields.DB,
-				Log:            tt.fields.Log,
-				Validate:       tt.fields.Validate,
-				UserRepository: tt.fields.UserRepository,
+				DB:                     tt.fields.DB,
+				Log:                    tt.fields.Log,
+				Validate:               tt.fields.Validate,
+				UserRepository:         tt.fields.UserRepository,
+				RefreshTokenRepository: tt.fields.RefreshTokenRepository,
+				TokenManager:           tt.fields.TokenManager,
+				EmailVerification:      tt.fields.EmailVerification,
+			}
+			if c.TokenManager == nil {
+				c.TokenManager = tokenManager
 			}
 			got, err := c.Login(tt.args.ctx, tt.args.request)
 			if (err != nil) != tt.wantErr {
@@ -461,10 +705,19 @@ func TestUserUseCase_Login(t *testing.T) {
 				return
 			}
 
-			// For successful login, just check that a token exists
+			// For successful login, check that the token is a JWT issued to the user
 			if !tt.wantErr && got != nil {
-				if got.Token == "" {
-					t.Errorf("UserUseCase.Login() expected token to be set, but it was empty")
+				claims, err := tokenManager.Verify(got.Token)
+				if err != nil {
+					t.Errorf("UserUseCase.Login() returned a token that does not verify: %v", err)
+				} else if claims.Subject != userID.String() {
+					t.Errorf("UserUseCase.Login() token subject = %v, want %v", claims.Subject, userID)
+				}
+				if got.ExpiresAt == "" || got.RefreshTokenExpiresAt == "" {
+					t.Errorf("UserUseCase.Login() expiry timestamps missing: %+v", got)
+				}
+				if got.RefreshToken == "" || auth.HashRefreshToken(got.RefreshToken) != storedRefreshTokenHash {
+					t.Errorf("UserUseCase.Login() refresh token does not match the stored hash")
 				}
 			} else if !tt.wantErr && got == nil {
 				t.Errorf("UserUseCase.Login() got nil, expected non-nil response")
@@ -472,3 +725,1247 @@ func TestUserUseCase_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUseCase_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+	rawToken := "verification-token"
+	usedAt := time.Now().Add(-time.Minute)
+
+	storedToken := func(expiresAt time.Time, usedAt *time.Time) *entity.EmailVerificationToken {
+		return &entity.EmailVerificationToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			TokenHash: auth.HashOpaqueToken(rawToken),
+			ExpiresAt: expiresAt,
+			UsedAt:    usedAt,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		findToken func(repo *repository_mock.MockEmailVerificationTokenRepositoryInterface)
+		userRepo  func(repo *repository_mock.MockUserRepositoryInterface)
+		mockTx    func()
+		wantErr   error
+	}{
+		{
+			name:  "success",
+			token: rawToken,
+			findToken: func(repo *repository_mock.MockEmailVerificationTokenRepositoryInterface) {
+				token := storedToken(time.Now().Add(time.Hour), nil)
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(token, nil)
+				repo.EXPECT().MarkUsed(gomock.Any(), token).Return(nil)
+			},
+			userRepo: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).DoAndReturn(
+					func(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+						user.ID = userID
+						user.Email = "user@example.com"
+						return nil
+					})
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(db *gorm.DB, user *entity.User) error {
+						if !user.EmailVerified {
+							t.Errorf("expected the user to be marked as verified")
+						}
+						return nil
+					})
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "token_already_used",
+			token: rawToken,
+			findToken: func(repo *repository_mock.MockEmailVerificationTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(time.Hour), &usedAt), nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrVerificationTokenUsed,
+		},
+		{
+			name:  "token_used_by_concurrent_request",
+			token: rawToken,
+			findToken: func(repo *repository_mock.MockEmailVerificationTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+				repo.EXPECT().MarkUsed(gomock.Any(), gomock.Any()).Return(repository.ErrTokenAlreadyUsed)
+			},
+			userRepo: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).Return(nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrVerificationTokenUsed,
+		},
+		{
+			name:  "token_expired",
+			token: rawToken,
+			findToken: func(repo *repository_mock.MockEmailVerificationTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(-time.Second), nil), nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrVerificationTokenExpired,
+		},
+		{
+			name:  "token_unknown",
+			token: "unknown-token",
+			findToken: func(repo *repository_mock.MockEmailVerificationTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken("unknown-token")).Return(nil, gorm.ErrRecordNotFound)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrInvalidVerificationToken,
+		},
+		{
+			name:    "token_missing",
+			token:   "",
+			wantErr: fiber.ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockTx != nil {
+				tt.mockTx()
+			}
+
+			tokenRepo := repository_mock.NewMockEmailVerificationTokenRepositoryInterface(ctrl)
+			if tt.findToken != nil {
+				tt.findToken(tokenRepo)
+			}
+			userRepo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			if tt.userRepo != nil {
+				tt.userRepo(userRepo)
+			}
+
+			c := &UserUseCase{
+				DB:                               db,
+				Log:                              logrus.New(),
+				Validate:                         validator.New(),
+				UserRepository:                   userRepo,
+				EmailVerificationTokenRepository: tokenRepo,
+			}
+			got, err := c.VerifyEmail(context.TODO(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserUseCase.VerifyEmail() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got == nil || got.EmailVerified == nil || !*got.EmailVerified) {
+				t.Errorf("UserUseCase.VerifyEmail() expected a verified user, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_Login_Lockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	userID := uuid.New()
+	policy := LoginLockoutPolicy{MaxFailedAttempts: 3, Duration: 15 * time.Minute}
+	tokenManager := auth.NewTokenManager("test-secret", time.Hour, time.Hour)
+
+	lockedUntil := func(offset time.Duration) *time.Time {
+		t := time.Now().Add(offset)
+		return &t
+	}
+
+	tests := []struct {
+		name         string
+		password     string
+		attempts     int
+		lockedUntil  *time.Time
+		expectUpdate func(t *testing.T, user *entity.User)
+		mockTx       func()
+		wantErr      error
+	}{
+		{
+			name:     "failed_login_below_threshold_counts_attempt",
+			password: "wrong_password",
+			attempts: 1,
+			expectUpdate: func(t *testing.T, user *entity.User) {
+				if user.FailedLoginAttempts != 2 || user.LockedUntil != nil {
+					t.Errorf("expected 2 failed attempts and no lock, got %d and %v", user.FailedLoginAttempts, user.LockedUntil)
+				}
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: fiber.ErrUnauthorized,
+		},
+		{
+			name:     "failed_login_at_threshold_locks_account",
+			password: "wrong_password",
+			attempts: 2,
+			expectUpdate: func(t *testing.T, user *entity.User) {
+				if user.LockedUntil == nil {
+					t.Fatalf("expected the account to be locked")
+				}
+				if d := time.Until(*user.LockedUntil); d < 14*time.Minute || d > 15*time.Minute {
+					t.Errorf("expected a 15 minute lockout, got %v", d)
+				}
+				if user.FailedLoginAttempts != 0 {
+					t.Errorf("expected the counter to restart after locking, got %d", user.FailedLoginAttempts)
+				}
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: appErrors.ErrAccountLocked,
+		},
+		{
+			name:        "locked_account_rejects_correct_password",
+			password:    "password123",
+			lockedUntil: lockedUntil(10 * time.Minute),
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrAccountLocked,
+		},
+		{
+			name:        "expired_lockout_unlocks_account",
+			password:    "password123",
+			lockedUntil: lockedUntil(-time.Second),
+			expectUpdate: func(t *testing.T, user *entity.User) {
+				if user.LockedUntil != nil || user.FailedLoginAttempts != 0 {
+					t.Errorf("expected the lock to be cleared, got %v and %d attempts", user.LockedUntil, user.FailedLoginAttempts)
+				}
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "successful_login_resets_counter",
+			password: "password123",
+			attempts: 2,
+			expectUpdate: func(t *testing.T, user *entity.User) {
+				if user.FailedLoginAttempts != 0 {
+					t.Errorf("expected the counter to be reset, got %d", user.FailedLoginAttempts)
+				}
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			userRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
+				func(db *gorm.DB, user *entity.User, email string) error {
+					user.ID = userID
+					user.Email = email
+					user.Password = string(hashedPassword)
+					user.FailedLoginAttempts = tt.attempts
+					user.LockedUntil = tt.lockedUntil
+					return nil
+				})
+			if tt.expectUpdate != nil {
+				userRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(db *gorm.DB, user *entity.User) error {
+						tt.expectUpdate(t, user)
+						return nil
+					})
+			}
+
+			refreshRepo := repository_mock.NewMockRefreshTokenRepositoryInterface(ctrl)
+			if tt.wantErr == nil {
+				refreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			tt.mockTx()
+
+			c := &UserUseCase{
+				DB:                     db,
+				Log:                    logrus.New(),
+				Validate:               validator.New(),
+				UserRepository:         userRepo,
+				RefreshTokenRepository: refreshRepo,
+				TokenManager:           tokenManager,
+				LoginLockout:           policy,
+			}
+
+			got, err := c.Login(context.TODO(), &model.LoginUserRequest{Email: "user@example.com", Password: tt.password})
+			if tt.wantErr == nil {
+				if err != nil || got == nil || got.Token == "" {
+					t.Fatalf("UserUseCase.Login() = %v, %v, want a token", got, err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserUseCase.Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+	refreshToken := "opaque-refresh-token"
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tokenManager := auth.NewTokenManager("test-secret", time.Hour, time.Hour)
+
+	storedToken := func(expiresAt time.Time, revokedAt *time.Time) *entity.RefreshToken {
+		return &entity.RefreshToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			TokenHash: auth.HashRefreshToken(refreshToken),
+			ExpiresAt: expiresAt,
+			RevokedAt: revokedAt,
+		}
+	}
+
+	findUser := func(repo *repository_mock.MockUserRepositoryInterface) {
+		repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).DoAndReturn(
+			func(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+				user.ID = id
+				user.Role = entity.RoleAdmin
+				return nil
+			})
+	}
+
+	commitTx := func() {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+	}
+	rollbackTx := func() {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+
+	tests := []struct {
+		name           string
+		refreshToken   string
+		tokenManager   auth.TokenManagerInterface
+		mockExpect     func(repo *repository_mock.MockRefreshTokenRepositoryInterface)
+		userRepoExpect func(repo *repository_mock.MockUserRepositoryInterface)
+		mockTx         func()
+		wantErr        error
+	}{
+		{
+			name:         "success_refresh",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				stored := storedToken(time.Now().Add(time.Hour), nil)
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashRefreshToken(refreshToken)).Return(stored, nil)
+				// The presented token is revoked and a new one stored in its place
+				repo.EXPECT().Revoke(gomock.Any(), stored).Return(nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(db *gorm.DB, token *entity.RefreshToken) error {
+						if token.UserID != userID || token.TokenHash == auth.HashRefreshToken(refreshToken) {
+							t.Errorf("UserUseCase.Refresh() stored refresh token = %+v, want a new token of user %v", token, userID)
+						}
+						return nil
+					})
+			},
+			userRepoExpect: findUser,
+			mockTx:         commitTx,
+			wantErr:        nil,
+		},
+		{
+			name:         "empty_refresh_token",
+			refreshToken: "",
+			wantErr:      fiber.ErrBadRequest,
+		},
+		{
+			name:         "unknown_refresh_token",
+			refreshToken: "unknown",
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashRefreshToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+			},
+			mockTx:  rollbackTx,
+			wantErr: fiber.ErrUnauthorized,
+		},
+		{
+			name:         "find_refresh_token_fails",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset"))
+			},
+			mockTx:  rollbackTx,
+			wantErr: fiber.ErrInternalServerError,
+		},
+		{
+			name:         "expired_refresh_token",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(storedToken(time.Now().Add(-time.Second), nil), nil)
+			},
+			mockTx:  rollbackTx,
+			wantErr: fiber.ErrUnauthorized,
+		},
+		{
+			name:         "revoked_refresh_token",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(storedToken(time.Now().Add(time.Hour), &revokedAt), nil)
+			},
+			mockTx:  rollbackTx,
+			wantErr: fiber.ErrUnauthorized,
+		},
+		{
+			name:         "revoked_by_concurrent_refresh",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+				// Another refresh revoked the token after it was read
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			userRepoExpect: findUser,
+			mockTx:         rollbackTx,
+			wantErr:        fiber.ErrUnauthorized,
+		},
+		{
+			name:         "issue_token_fails",
+			refreshToken: refreshToken,
+			tokenManager: &failingTokenManager{},
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			userRepoExpect: findUser,
+			mockTx:         rollbackTx,
+			wantErr:        fiber.ErrInternalServerError,
+		},
+		{
+			name:         "user_deleted",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+			},
+			userRepoExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).Return(gorm.ErrRecordNotFound)
+			},
+			mockTx:  rollbackTx,
+			wantErr: fiber.ErrUnauthorized,
+		},
+		{
+			name:         "user_locked_out",
+			refreshToken: refreshToken,
+			mockExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+			},
+			userRepoExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).DoAndReturn(
+					func(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+						lockedUntil := time.Now().Add(15 * time.Minute)
+						user.ID = id
+						user.LockedUntil = &lockedUntil
+						return nil
+					})
+			},
+			mockTx:  rollbackTx,
+			wantErr: appErrors.ErrAccountLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository_mock.NewMockRefreshTokenRepositoryInterface(ctrl)
+			if tt.mockExpect != nil {
+				tt.mockExpect(repo)
+			}
+			userRepo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			if tt.userRepoExpect != nil {
+				tt.userRepoExpect(userRepo)
+			}
+
+			c := &UserUseCase{
+				DB:                     db,
+				Log:                    logrus.New(),
+				Validate:               validator.New(),
+				UserRepository:         userRepo,
+				RefreshTokenRepository: repo,
+				TokenManager:           tt.tokenManager,
+			}
+			if c.TokenManager == nil {
+				c.TokenManager = tokenManager
+			}
+			if tt.mockTx != nil {
+				tt.mockTx()
+			}
+
+			got, err := c.Refresh(context.TODO(), tt.refreshToken)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserUseCase.Refresh() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			claims, err := tokenManager.Verify(got.Token)
+			if err != nil {
+				t.Fatalf("UserUseCase.Refresh() returned a token that does not verify: %v", err)
+			}
+			if claims.Subject != userID.String() {
+				t.Errorf("UserUseCase.Refresh() token subject = %v, want %v", claims.Subject, userID)
+			}
+			if claims.Role != entity.RoleAdmin {
+				t.Errorf("UserUseCase.Refresh() token role = %v, want the user's current role %v", claims.Role, entity.RoleAdmin)
+			}
+			if got.ExpiresAt == "" {
+				t.Errorf("UserUseCase.Refresh() expires_at missing")
+			}
+			if got.RefreshToken == "" || got.RefreshToken == refreshToken {
+				t.Errorf("UserUseCase.Refresh() refresh token = %q, want a new refresh token", got.RefreshToken)
+			}
+			if got.RefreshTokenExpiresAt == "" {
+				t.Errorf("UserUseCase.Refresh() refresh_token_expires_at missing")
+			}
+		})
+	}
+}
+
+func TestUserUseCase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+	findUser := func(repo *repository_mock.MockUserRepositoryInterface) {
+		repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).DoAndReturn(
+			func(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+				user.ID = id
+				user.Name = "Old Name"
+				user.Email = "user@example.com"
+				user.Phone = "1234567890"
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.UpdateUserRequest
+		mockExpect func(repo *repository_mock.MockUserRepositoryInterface)
+		mockTx     func()
+		want       *model.UserResponse
+		wantErr    error
+	}{
+		{
+			name:    "success_update_name_only",
+			request: &model.UpdateUserRequest{Name: "New Name"},
+			mockExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			want: &model.UserResponse{Name: "New Name", Email: "user@example.com", Phone: "1234567890"},
+		},
+		{
+			name:    "success_update_phone",
+			request: &model.UpdateUserRequest{Phone: "0987654321"},
+			mockExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().FindByPhone(gomock.Any(), gomock.Any(), "0987654321").Return(gorm.ErrRecordNotFound)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			want: &model.UserResponse{Name: "Old Name", Email: "user@example.com", Phone: "0987654321"},
+		},
+		{
+			name:    "invalid_request_validation_fails",
+			request: &model.UpdateUserRequest{Phone: strings.Repeat("1", 51)},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrBadRequest,
+		},
+		{
+			name:    "user_not_found",
+			request: &model.UpdateUserRequest{Name: "New Name"},
+			mockExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).Return(gorm.ErrRecordNotFound)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrNotFound,
+		},
+		{
+			name:    "duplicate_phone",
+			request: &model.UpdateUserRequest{Phone: "0987654321"},
+			mockExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().FindByPhone(gomock.Any(), gomock.Any(), "0987654321").DoAndReturn(
+					func(db *gorm.DB, user *entity.User, phone string) error {
+						user.ID = uuid.New()
+						return nil
+					})
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrDuplicatePhone,
+		},
+		{
+			name:    "update_user_fails",
+			request: &model.UpdateUserRequest{Name: "New Name"},
+			mockExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update failed"))
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			if tt.mockExpect != nil {
+				tt.mockExpect(repo)
+			}
+			tt.mockTx()
+
+			c := &UserUseCase{
+				DB:             db,
+				Log:            logrus.New(),
+				Validate:       validator.New(),
+				UserRepository: repo,
+			}
+
+			got, err := c.Update(context.TODO(), userID, tt.request)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UserUseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("UserUseCase.Update() error = %v, want nil", err)
+				}
+				if got.ID != userID.String() || got.Name != tt.want.Name || got.Email != tt.want.Email || got.Phone != tt.want.Phone {
+					t.Errorf("UserUseCase.Update() = %+v, want %+v", got, tt.want)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+	currentPassword := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(currentPassword), bcrypt.MinCost)
+
+	findUser := func(repo *repository_mock.MockUserRepositoryInterface) {
+		repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).DoAndReturn(
+			func(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+				user.ID = id
+				user.Password = string(hashedPassword)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name              string
+		oldPassword       string
+		newPassword       string
+		userRepoExpect    func(repo *repository_mock.MockUserRepositoryInterface)
+		refreshRepoExpect func(repo *repository_mock.MockRefreshTokenRepositoryInterface)
+		mockTx            func()
+		wantErr           error
+	}{
+		{
+			name:        "success_change_password",
+			oldPassword: currentPassword,
+			newPassword: "newPassword456",
+			userRepoExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(db *gorm.DB, user *entity.User) error {
+						if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("newPassword456")); err != nil {
+							t.Errorf("UserUseCase.ChangePassword() stored hash does not match the new password")
+						}
+						return nil
+					})
+			},
+			refreshRepoExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().RevokeAllForUser(gomock.Any(), userID).Return(nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "missing_new_password",
+			oldPassword: currentPassword,
+			newPassword: "",
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrBadRequest,
+		},
+		{
+			name:        "user_not_found",
+			oldPassword: currentPassword,
+			newPassword: "newPassword456",
+			userRepoExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).Return(gorm.ErrRecordNotFound)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrNotFound,
+		},
+		{
+			name:           "incorrect_current_password",
+			oldPassword:    "wrong_password1",
+			newPassword:    "newPassword456",
+			userRepoExpect: findUser,
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrIncorrectPassword,
+		},
+		{
+			name:           "weak_new_password",
+			oldPassword:    currentPassword,
+			newPassword:    "short",
+			userRepoExpect: findUser,
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrWeakPassword,
+		},
+		{
+			name:           "new_password_same_as_current",
+			oldPassword:    currentPassword,
+			newPassword:    currentPassword,
+			userRepoExpect: findUser,
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrWeakPassword,
+		},
+		{
+			name:        "update_user_fails",
+			oldPassword: currentPassword,
+			newPassword: "newPassword456",
+			userRepoExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update failed"))
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrInternalServerError,
+		},
+		{
+			name:        "revoke_refresh_tokens_fails",
+			oldPassword: currentPassword,
+			newPassword: "newPassword456",
+			userRepoExpect: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			refreshRepoExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().RevokeAllForUser(gomock.Any(), userID).Return(errors.New("update failed"))
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: fiber.ErrInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			if tt.userRepoExpect != nil {
+				tt.userRepoExpect(userRepo)
+			}
+			refreshRepo := repository_mock.NewMockRefreshTokenRepositoryInterface(ctrl)
+			if tt.refreshRepoExpect != nil {
+				tt.refreshRepoExpect(refreshRepo)
+			}
+			tt.mockTx()
+
+			c := &UserUseCase{
+				DB:                     db,
+				Log:                    logrus.New(),
+				Validate:               validator.New(),
+				UserRepository:         userRepo,
+				RefreshTokenRepository: refreshRepo,
+			}
+
+			err := c.ChangePassword(context.TODO(), userID, tt.oldPassword, tt.newPassword)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("UserUseCase.ChangePassword() error = %v, want nil", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserUseCase.ChangePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		email      string
+		userRepo   func(repo *repository_mock.MockUserRepositoryInterface)
+		tokenRepo  func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface)
+		mockTx     func()
+		wantMailed bool
+		wantErr    error
+	}{
+		{
+			name:  "known_email_sends_reset_link",
+			email: "user@example.com",
+			userRepo: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "user@example.com").DoAndReturn(
+					func(db *gorm.DB, user *entity.User, email string) error {
+						user.ID = userID
+						user.Email = email
+						return nil
+					})
+			},
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(db *gorm.DB, token *entity.PasswordResetToken) error {
+						if token.UserID != userID {
+							t.Errorf("expected the token to belong to %s, got %s", userID, token.UserID)
+						}
+						if d := time.Until(token.ExpiresAt); d <= 0 || d > 30*time.Minute {
+							t.Errorf("expected the token to expire within 30 minutes, got %v", d)
+						}
+						return nil
+					})
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantMailed: true,
+			wantErr:    nil,
+		},
+		{
+			name:  "unknown_email_is_not_revealed",
+			email: "nobody@example.com",
+			userRepo: func(repo *repository_mock.MockUserRepositoryInterface) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "nobody@example.com").Return(gorm.ErrRecordNotFound)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantMailed: false,
+			wantErr:    nil,
+		},
+		{
+			name:    "invalid_email",
+			email:   "not-an-email",
+			wantErr: fiber.ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockTx != nil {
+				tt.mockTx()
+			}
+
+			userRepo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			if tt.userRepo != nil {
+				tt.userRepo(userRepo)
+			}
+			tokenRepo := repository_mock.NewMockPasswordResetTokenRepositoryInterface(ctrl)
+			if tt.tokenRepo != nil {
+				tt.tokenRepo(tokenRepo)
+			}
+			mailer := mail_mock.NewMockSenderInterface(ctrl)
+			if tt.wantMailed {
+				mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, message mail.Message) error {
+						if message.To != tt.email || !strings.Contains(message.Body, "/reset-password?token=") {
+							t.Errorf("UserUseCase.RequestPasswordReset() reset email = %+v", message)
+						}
+						return nil
+					})
+			}
+
+			c := &UserUseCase{
+				DB:                           db,
+				Log:                          logrus.New(),
+				Validate:                     validator.New(),
+				UserRepository:               userRepo,
+				PasswordResetTokenRepository: tokenRepo,
+				Mailer:                       mailer,
+				PasswordReset:                PasswordResetPolicy{TokenTTL: 30 * time.Minute, LinkURL: "http://localhost:3000/reset-password"},
+			}
+
+			err := c.RequestPasswordReset(context.TODO(), tt.email)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserUseCase.RequestPasswordReset() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Error opening DB connection: ", err)
+	}
+
+	userID := uuid.New()
+	rawToken := "reset-token"
+	newPassword := "newPassword456"
+	usedAt := time.Now().Add(-time.Minute)
+
+	storedToken := func(expiresAt time.Time, usedAt *time.Time) *entity.PasswordResetToken {
+		return &entity.PasswordResetToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			TokenHash: auth.HashOpaqueToken(rawToken),
+			ExpiresAt: expiresAt,
+			UsedAt:    usedAt,
+		}
+	}
+	findUser := func(repo *repository_mock.MockUserRepositoryInterface) {
+		repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), userID).DoAndReturn(
+			func(db *gorm.DB, user *entity.User, id uuid.UUID) error {
+				user.ID = id
+				user.FailedLoginAttempts = 3
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name              string
+		token             string
+		newPassword       string
+		tokenRepo         func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface)
+		userRepo          func(repo *repository_mock.MockUserRepositoryInterface)
+		refreshRepoExpect func(repo *repository_mock.MockRefreshTokenRepositoryInterface)
+		mockTx            func()
+		wantErr           error
+	}{
+		{
+			name:        "success_reset_password",
+			token:       rawToken,
+			newPassword: newPassword,
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				token := storedToken(time.Now().Add(time.Hour), nil)
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(token, nil)
+				repo.EXPECT().MarkUsed(gomock.Any(), token).Return(nil)
+			},
+			userRepo: func(repo *repository_mock.MockUserRepositoryInterface) {
+				findUser(repo)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(db *gorm.DB, user *entity.User) error {
+						if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(newPassword)); err != nil {
+							t.Errorf("UserUseCase.ResetPassword() stored hash does not match the new password")
+						}
+						if user.FailedLoginAttempts != 0 || user.LockedUntil != nil {
+							t.Errorf("UserUseCase.ResetPassword() expected the lockout to be cleared")
+						}
+						return nil
+					})
+			},
+			refreshRepoExpect: func(repo *repository_mock.MockRefreshTokenRepositoryInterface) {
+				repo.EXPECT().RevokeAllForUser(gomock.Any(), userID).Return(nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "token_already_used",
+			token:       rawToken,
+			newPassword: newPassword,
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(time.Hour), &usedAt), nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrResetTokenUsed,
+		},
+		{
+			name:        "token_used_by_concurrent_request",
+			token:       rawToken,
+			newPassword: newPassword,
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+				repo.EXPECT().MarkUsed(gomock.Any(), gomock.Any()).Return(repository.ErrTokenAlreadyUsed)
+			},
+			userRepo: findUser,
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrResetTokenUsed,
+		},
+		{
+			name:        "token_expired",
+			token:       rawToken,
+			newPassword: newPassword,
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(-time.Second), nil), nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrResetTokenExpired,
+		},
+		{
+			name:        "token_unknown",
+			token:       "unknown-token",
+			newPassword: newPassword,
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken("unknown-token")).Return(nil, gorm.ErrRecordNotFound)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrInvalidResetToken,
+		},
+		{
+			name:        "weak_new_password",
+			token:       rawToken,
+			newPassword: "short",
+			tokenRepo: func(repo *repository_mock.MockPasswordResetTokenRepositoryInterface) {
+				repo.EXPECT().FindByHash(gomock.Any(), auth.HashOpaqueToken(rawToken)).Return(storedToken(time.Now().Add(time.Hour), nil), nil)
+			},
+			mockTx: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: appErrors.ErrWeakPassword,
+		},
+		{
+			name:        "missing_token",
+			token:       "",
+			newPassword: newPassword,
+			wantErr:     fiber.ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockTx != nil {
+				tt.mockTx()
+			}
+
+			tokenRepo := repository_mock.NewMockPasswordResetTokenRepositoryInterface(ctrl)
+			if tt.tokenRepo != nil {
+				tt.tokenRepo(tokenRepo)
+			}
+			userRepo := repository_mock.NewMockUserRepositoryInterface(ctrl)
+			if tt.userRepo != nil {
+				tt.userRepo(userRepo)
+			}
+			refreshRepo := repository_mock.NewMockRefreshTokenRepositoryInterface(ctrl)
+			if tt.refreshRepoExpect != nil {
+				tt.refreshRepoExpect(refreshRepo)
+			}
+
+			c := &UserUseCase{
+				DB:                           db,
+				Log:                          logrus.New(),
+				Validate:                     validator.New(),
+				UserRepository:               userRepo,
+				RefreshTokenRepository:       refreshRepo,
+				PasswordResetTokenRepository: tokenRepo,
+			}
+
+			err := c.ResetPassword(context.TODO(), tt.token, tt.newPassword)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserUseCase.ResetPassword() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+// failingTokenManager fails to issue tokens, e.g. because of a signing error
+type failingTokenManager struct {
+	auth.TokenManagerInterface
+}
+
+func (m *failingTokenManager) Issue(userID uuid.UUID, role string) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("signing failed")
+}
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 	entity "user-service/internal/entity"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -69,18 +70,32 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) FindByEmail(db, user, email a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByEmail), db, user, email)
 }
 
-// Update mocks base method.
-func (m *MockUserRepositoryInterface) Update(db *gorm.DB, user *entity.User) error {
+// FindByID mocks base method.
+func (m *MockUserRepositoryInterface) FindByID(db *gorm.DB, user *entity.User, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", db, user)
+	ret := m.ctrl.Call(m, "FindByID", db, user, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockUserRepositoryInterfaceMockRecorder) Update(db, user any) *gomock.Call {
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByID(db, user, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Update), db, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByID), db, user, id)
+}
+
+// FindByPhone mocks base method.
+func (m *MockUserRepositoryInterface) FindByPhone(db *gorm.DB, user *entity.User, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPhone", db, user, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindByPhone indicates an expected call of FindByPhone.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByPhone(db, user, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhone", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByPhone), db, user, phone)
 }
 
 // FindByToken mocks base method.
@@ -97,3 +112,17 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) FindByToken(db, token any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByToken", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByToken), db, token)
 }
+
+// Update mocks base method.
+func (m *MockUserRepositoryInterface) Update(db *gorm.DB, user *entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", db, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserRepositoryInterfaceMockRecorder) Update(db, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Update), db, user)
+}
@@ -26,6 +26,7 @@ mysql -h mysql -u root <<SQL
 CREATE DATABASE IF NOT EXISTS user_service_test;
 USE user_service_test;
 
+DROP TABLE IF EXISTS refresh_tokens;
 DROP TABLE IF EXISTS users;
 CREATE TABLE users (
     uuid       CHAR(36) NOT NULL,           
@@ -33,11 +34,26 @@ CREATE TABLE users (
     email      VARCHAR(255) NOT NULL UNIQUE,
     phone      VARCHAR(50) UNIQUE,
     password   VARCHAR(100) NOT NULL,
+    role       VARCHAR(20) NOT NULL DEFAULT 'customer',
     token      VARCHAR(255),
+    failed_login_attempts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (uuid)
 ) ENGINE = InnoDB;
+
+CREATE TABLE refresh_tokens (
+    uuid       CHAR(36) NOT NULL,
+    user_uuid  CHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    INDEX idx_refresh_tokens_user_uuid (user_uuid),
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_uuid) REFERENCES users (uuid) ON DELETE CASCADE
+) ENGINE = InnoDB;
 SQL
 
 # Verify table was created
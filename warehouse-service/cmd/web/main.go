@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"warehouse-service/internal/config"
 
 	_ "warehouse-service/docs" // Import swagger docs
@@ -22,6 +26,10 @@ import (
 // @name X-API-Key
 // @description API key authentication
 func main() {
+	// Cancelled on SIGINT/SIGTERM to stop background jobs and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	viperConfig := config.NewViper()
 	log := config.NewLogger(viperConfig)
 	db := config.NewDatabase(viperConfig, log)
@@ -29,6 +37,7 @@ func main() {
 	app := config.NewFiber(viperConfig)
 
 	config.Bootstrap(&config.BootstrapConfig{
+		Context:  ctx,
 		DB:       db,
 		App:      app,
 		Log:      log,
@@ -37,6 +46,16 @@ func main() {
 	})
 
 	webPort := viperConfig.GetInt("web.port")
+
+	// Shut down gracefully once a termination signal arrives
+	go func() {
+		<-ctx.Done()
+		log.Info("Shutting down server...")
+		if err := app.Shutdown(); err != nil {
+			log.Errorf("Failed to shut down server: %v", err)
+		}
+	}()
+
 	err := app.Listen(fmt.Sprintf("0.0.0.0:%d", webPort))
 	if err != nil {
 		log.Fatalf("Failed to start server: %v", err)
@@ -0,0 +1,2 @@
+-- Remove the optimistic locking version column from warehouse_stock
+ALTER TABLE warehouse_stock DROP COLUMN version;
\ No newline at end of file
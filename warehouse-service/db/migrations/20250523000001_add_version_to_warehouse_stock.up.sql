@@ -0,0 +1,2 @@
+-- Add a version column used for optimistic locking of stock updates
+ALTER TABLE warehouse_stock ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 0 AFTER reserved_quantity;
\ No newline at end of file
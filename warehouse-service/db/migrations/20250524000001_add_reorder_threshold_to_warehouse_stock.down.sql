@@ -0,0 +1,2 @@
+-- Remove the reorder threshold from warehouse_stock
+ALTER TABLE warehouse_stock DROP COLUMN reorder_threshold;
\ No newline at end of file
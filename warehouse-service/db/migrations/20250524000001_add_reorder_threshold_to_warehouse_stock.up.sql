@@ -0,0 +1,2 @@
+-- Add a per-row reorder point used to raise low-stock alerts
+ALTER TABLE warehouse_stock ADD COLUMN reorder_threshold INT NOT NULL DEFAULT 0 AFTER reserved_quantity;
\ No newline at end of file
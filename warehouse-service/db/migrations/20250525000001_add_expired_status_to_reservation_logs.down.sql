@@ -0,0 +1,3 @@
+-- Drop the expired reservation status; expired logs are kept as cancelled
+UPDATE reservation_logs SET status = 'cancelled' WHERE status = 'expired';
+ALTER TABLE reservation_logs MODIFY COLUMN status ENUM('pending', 'committed', 'cancelled') NOT NULL DEFAULT 'pending';
\ No newline at end of file
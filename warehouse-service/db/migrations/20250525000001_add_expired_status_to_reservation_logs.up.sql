@@ -0,0 +1,2 @@
+-- Allow reservation logs to record reservations released by the expiry sweeper
+ALTER TABLE reservation_logs MODIFY COLUMN status ENUM('pending', 'committed', 'cancelled', 'expired') NOT NULL DEFAULT 'pending';
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Remove the non-negative stock checks
+ALTER TABLE warehouse_stock
+    DROP CHECK chk_warehouse_stock_quantity,
+    DROP CHECK chk_warehouse_stock_reserved_quantity;
\ No newline at end of file
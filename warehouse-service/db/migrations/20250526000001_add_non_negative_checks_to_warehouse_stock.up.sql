@@ -0,0 +1,4 @@
+-- Reject stock rows whose quantity or reserved quantity would become negative
+ALTER TABLE warehouse_stock
+    ADD CONSTRAINT chk_warehouse_stock_quantity CHECK (quantity >= 0),
+    ADD CONSTRAINT chk_warehouse_stock_reserved_quantity CHECK (reserved_quantity >= 0);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE warehouses
+    DROP INDEX idx_warehouses_deleted_at,
+    DROP COLUMN deleted_at;
\ No newline at end of file
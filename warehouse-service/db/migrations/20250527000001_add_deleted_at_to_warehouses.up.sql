@@ -0,0 +1,3 @@
+ALTER TABLE warehouses
+    ADD COLUMN deleted_at TIMESTAMP NULL AFTER updated_at,
+    ADD INDEX idx_warehouses_deleted_at (deleted_at);
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    warehouse_id INT UNSIGNED NOT NULL,
+    product_id INT UNSIGNED NOT NULL,
+    delta INT NOT NULL,
+    old_quantity INT NOT NULL,
+    new_quantity INT NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_stock_adjustments_warehouse_product (warehouse_id, product_id),
+    CONSTRAINT fk_stock_adjustments_warehouse FOREIGN KEY (warehouse_id) REFERENCES warehouses (id) ON DELETE CASCADE
+) ENGINE = InnoDB;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS committed_reservations;
\ No newline at end of file
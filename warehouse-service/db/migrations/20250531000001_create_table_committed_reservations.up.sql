@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS committed_reservations (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    warehouse_id INT UNSIGNED NOT NULL,
+    product_id INT UNSIGNED NOT NULL,
+    reference VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX uq_committed_reservations_reference (warehouse_id, product_id, reference),
+    INDEX idx_committed_reservations_created_at (created_at),
+    CONSTRAINT fk_committed_reservations_warehouse FOREIGN KEY (warehouse_id) REFERENCES warehouses (id) ON DELETE CASCADE
+) ENGINE = InnoDB;
\ No newline at end of file
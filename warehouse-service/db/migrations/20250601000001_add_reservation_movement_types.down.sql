@@ -0,0 +1,3 @@
+-- Drop the reservation and adjustment movement types along with the movements recorded with them
+DELETE FROM stock_movements WHERE movement_type IN ('reserve', 'release', 'commit', 'adjust');
+ALTER TABLE stock_movements MODIFY COLUMN movement_type ENUM('stock_in', 'stock_out', 'transfer_in', 'transfer_out') NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Allow stock movements to record reservations, their release and commit, and manual adjustments
+ALTER TABLE stock_movements MODIFY COLUMN movement_type ENUM('stock_in', 'stock_out', 'transfer_in', 'transfer_out', 'reserve', 'release', 'commit', 'adjust') NOT NULL;
\ No newline at end of file
@@ -22,14 +22,99 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/inventory/reserve": {
+        "/health": {
+            "get": {
+                "description": "Reports that the process is up without checking any dependency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Pings the database. Returns 503 with the status of each dependency when any is down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthResponse"
+                                        },
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/adjust": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Reserves stock for a product in a warehouse using database-level locking",
+                "description": "Corrects the on-hand quantity of a product at a warehouse by a signed delta, for example after a physical count. The adjustment is recorded with the caller, the old and new quantity and the reason. Restricted to admins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,17 +122,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Inventory"
+                    "Stock"
                 ],
-                "summary": "Reserve inventory stock",
+                "summary": "Adjust stock",
                 "parameters": [
                     {
-                        "description": "Reservation details",
-                        "name": "reservation",
+                        "description": "Stock adjustment",
+                        "name": "adjustment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ReserveStockRequest"
+                            "$ref": "#/definitions/model.AdjustStockRequest"
                         }
                     }
                 ],
@@ -55,7 +140,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ReservationResponse"
+                            "$ref": "#/definitions/model.StockResponse"
                         }
                     },
                     "400": {
@@ -97,40 +182,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/inventory/reserve/cancel": {
-            "post": {
+        "/inventory/products/{product_id}/stock": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Cancels a previously made stock reservation",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the quantities of a product at each active warehouse together with the totals across them. Warehouses without stock of the product are only listed when include_empty is true.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Inventory"
                 ],
-                "summary": "Cancel a stock reservation",
+                "summary": "Get stock of a product across warehouses",
                 "parameters": [
                     {
-                        "description": "Cancellation details",
-                        "name": "cancelation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CancelReservationRequest"
-                        }
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include warehouses with zero stock",
+                        "name": "include_empty",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessMessageResponse"
+                            "$ref": "#/definitions/model.ProductStockSummaryResponse"
                         }
                     },
                     "400": {
@@ -145,18 +231,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -166,40 +240,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/inventory/reserve/commit": {
-            "post": {
+        "/inventory/references/{reference}/reservations": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Commits a previously made stock reservation, reducing actual stock",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists every reservation logged with the reference across warehouses, products and statuses, oldest first, for reconciliation with the service that made them. A reference without reservations returns an empty list.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Inventory"
                 ],
-                "summary": "Commit a stock reservation",
+                "summary": "List reservations by reference",
                 "parameters": [
                     {
-                        "description": "Commit details",
-                        "name": "commit",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CommitReservationRequest"
-                        }
+                        "type": "string",
+                        "description": "Reservation reference, such as an order ID",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessMessageResponse"
+                            "$ref": "#/definitions/model.ReservationsByReferenceResponse"
                         }
                     },
                     "400": {
@@ -214,18 +283,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -235,35 +292,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/inventory/warehouses/{warehouse_id}/products/{product_id}/reservations": {
+        "/inventory/reservations/aged": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the reservation history for a product in a warehouse",
+                "description": "Lists active reservations held for longer than older_than without any commit, cancellation, expiry or other activity since, with totals per warehouse. Read-only diagnostics for stock still held for released orders; admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Inventory"
                 ],
-                "summary": "Get reservation history",
+                "summary": "Report aged reservations",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Warehouse ID",
-                        "name": "warehouse_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Minimum reservation age as a Go duration such as 2h or 90m (defaults to the reservation TTL)",
+                        "name": "older_than",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -273,7 +322,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Items per page (defaults to 20, max 100)",
+                        "description": "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)",
                         "name": "limit",
                         "in": "query"
                     }
@@ -282,7 +331,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ReservationHistoryResponse"
+                            "$ref": "#/definitions/model.AgedReservationReportResponse"
                         }
                     },
                     "400": {
@@ -312,14 +361,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/stock/transfer": {
+        "/inventory/reserve": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Transfers stock from one warehouse to another for a specific product",
+                "description": "Reserves stock for a product in a warehouse using optimistic locking; returns 409 if the stock keeps changing concurrently. The quantity must be positive and at most the configured maximum per reservation. An optional reference identifies the reservation for later commits and cancellations; one is generated when it is omitted",
                 "consumes": [
                     "application/json"
                 ],
@@ -327,17 +376,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Stock"
+                    "Inventory"
                 ],
-                "summary": "Transfer stock between warehouses",
+                "summary": "Reserve inventory stock",
                 "parameters": [
                     {
-                        "description": "Stock transfer details",
-                        "name": "transfer",
+                        "description": "Reservation details",
+                        "name": "reservation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.StockTransferRequest"
+                            "$ref": "#/definitions/model.ReserveStockRequest"
                         }
                     }
                 ],
@@ -345,7 +394,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.StockTransferResponse"
+                            "$ref": "#/definitions/model.ReservationResponse"
                         }
                     },
                     "400": {
@@ -372,6 +421,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -381,40 +436,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/warehouses": {
-            "get": {
+        "/inventory/reserve/batch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns a paginated list of warehouses",
+                "description": "Reserves stock for all items in a single transaction. If any item lacks stock, nothing is reserved and the per-item results show which items failed",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Warehouses"
+                    "Inventory"
                 ],
-                "summary": "List warehouses",
+                "summary": "Reserve inventory stock for several items",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (defaults to 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (defaults to 20, max 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Items to reserve",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReserveStockBatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.WarehouseListResponse"
+                            "$ref": "#/definitions/model.ReserveStockBatchResponse"
                         }
                     },
                     "400": {
@@ -429,12 +484,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReserveStockBatchResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -442,14 +509,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/inventory/reserve/cancel": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new warehouse",
+                "description": "Cancels the active stock reservation made under the reference. An unknown or already resolved reference returns 404, and a quantity above the reserved quantity returns 422",
                 "consumes": [
                     "application/json"
                 ],
@@ -457,25 +526,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Warehouses"
+                    "Inventory"
                 ],
-                "summary": "Create a new warehouse",
+                "summary": "Cancel a stock reservation",
                 "parameters": [
                     {
-                        "description": "Warehouse creation details",
-                        "name": "warehouse",
+                        "description": "Cancellation details",
+                        "name": "cancelation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateWarehouseRequest"
+                            "$ref": "#/definitions/model.CancelReservationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.WarehouseResponse"
+                            "$ref": "#/definitions/response.SuccessMessageResponse"
                         }
                     },
                     "400": {
@@ -496,6 +565,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -505,35 +592,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/warehouses/{id}": {
-            "get": {
+        "/inventory/reserve/commit": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns warehouse details for the specified ID with statistics",
+                "description": "Commits the active stock reservation made under the reference, reducing actual stock. Repeating a commit with the same reference succeeds without reducing stock again. A first commit of an unknown reference returns 404",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Warehouses"
+                    "Inventory"
                 ],
-                "summary": "Get warehouse by ID",
+                "summary": "Commit a stock reservation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Warehouse ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Commit details",
+                        "name": "commit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommitReservationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.WarehouseResponse"
+                            "$ref": "#/definitions/response.SuccessMessageResponse"
                         }
                     },
                     "400": {
@@ -560,6 +652,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -567,14 +671,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
+            }
+        },
+        "/inventory/reserve/relocate": {
+            "post": {
+                "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates an existing warehouse",
+                "description": "Moves the whole active reservation of a product from one warehouse to another, keeping its reference. The destination reserves the stock and the source releases it in one transaction; if the destination lacks stock nothing changes",
                 "consumes": [
                     "application/json"
                 ],
@@ -582,24 +688,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Warehouses"
+                    "Inventory"
                 ],
-                "summary": "Update an existing warehouse",
+                "summary": "Move a stock reservation to another warehouse",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Warehouse ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Warehouse update details",
-                        "name": "warehouse",
+                        "description": "Relocation details",
+                        "name": "relocation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateWarehouseRequest"
+                            "$ref": "#/definitions/model.RelocateReservationRequest"
                         }
                     }
                 ],
@@ -607,7 +706,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.WarehouseResponse"
+                            "$ref": "#/definitions/model.RelocateReservationResponse"
                         }
                     },
                     "400": {
@@ -634,6 +733,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -641,26 +752,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/inventory/warehouses/{warehouse_id}/products/{product_id}/availability": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes an existing warehouse by ID",
+                "description": "Returns the available, reserved and total quantity of a product at a warehouse. Quantities are zero when the product has no stock there.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Warehouses"
+                    "Inventory"
                 ],
-                "summary": "Delete a warehouse",
+                "summary": "Get product availability at a warehouse",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Warehouse ID",
-                        "name": "id",
+                        "name": "warehouse_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
                         "in": "path",
                         "required": true
                     }
@@ -669,7 +789,84 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SuccessMessageResponse"
+                            "$ref": "#/definitions/model.ProductAvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/warehouses/{warehouse_id}/products/{product_id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the stock movements of a product at a warehouse, oldest first: stock added, transfers in and out, reservations, their release and commit, and manual adjustments. Each movement carries the quantity on hand, reserved and available after it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get stock history of a product at a warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (defaults to 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockHistoryResponse"
                         }
                     },
                     "400": {
@@ -705,34 +902,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/warehouses/{warehouseId}/stock": {
+        "/inventory/warehouses/{warehouse_id}/products/{product_id}/reservations": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns a paginated list of stock items in a warehouse",
+                "description": "Returns the reservation history for a product in a warehouse",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stock"
+                    "Inventory"
                 ],
-                "summary": "Get warehouse stock",
+                "summary": "Get reservation history",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Warehouse ID",
-                        "name": "warehouseId",
+                        "name": "warehouse_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Product ID filter",
-                        "name": "productId",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -742,16 +940,112 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Items per page (defaults to 20, max 100)",
+                        "description": "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "reserved",
+                            "committed",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Only return logs with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return logs created at or after this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return logs created before this time (RFC3339), or on or before this day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.WarehouseStockListResponse"
+                            "$ref": "#/definitions/model.ReservationHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/warehouses/{warehouse_id}/products/{product_id}/reservations/{reference}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the reserved quantity, status and expiry of an active reservation. Committed, cancelled and expired reservations are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get a reservation by reference",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reservation reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReservationDetailResponse"
                         }
                     },
                     "400": {
@@ -785,14 +1079,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/stock/transfer": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds stock to a warehouse for a specific product",
+                "description": "Transfers stock from one warehouse to another for a specific product",
                 "consumes": [
                     "application/json"
                 ],
@@ -802,22 +1098,15 @@ const docTemplate = `{
                 "tags": [
                     "Stock"
                 ],
-                "summary": "Add stock to a warehouse",
+                "summary": "Transfer stock between warehouses",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Warehouse ID",
-                        "name": "warehouseId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Stock details to add",
-                        "name": "stock",
+                        "description": "Stock transfer details",
+                        "name": "transfer",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AddStockRequest"
+                            "$ref": "#/definitions/model.StockTransferRequest"
                         }
                     }
                 ],
@@ -825,7 +1114,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.StockResponse"
+                            "$ref": "#/definitions/model.StockTransferResponse"
                         }
                     },
                     "400": {
@@ -852,6 +1141,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -860,48 +1161,1046 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AddStockRequest": {
+        },
+        "/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of warehouses, optionally filtered by search term, location and active status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "List warehouses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (defaults to 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Matches part of the warehouse name or address",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return warehouses at this location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return active warehouses (defaults to false)",
+                        "name": "active_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Create a new warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse creation details",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateWarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/batch": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns up to 100 warehouses with statistics in one call, in the order requested. Unknown IDs are omitted from the result.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Get multiple warehouses by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated warehouse IDs",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns warehouse details for the specified ID with statistics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Get warehouse by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates an existing warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Update an existing warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse update details",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateWarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Soft-deletes an existing warehouse by ID. Its stock is kept and it can be restored. A warehouse that still holds stock is only deleted with force=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Delete a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the warehouse even if it still holds stock (defaults to false)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SuccessMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Undoes the soft delete of a warehouse. Restoring a warehouse that is not deleted returns it unchanged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Restore a deleted warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{warehouseId}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a paginated list of stock items in a warehouse",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "Get warehouse stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID filter",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (defaults to 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseStockListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds stock to a warehouse for a specific product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "Add stock to a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock details to add",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{warehouseId}/stock/{productId}/reorder-threshold": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets the quantity below which a low-stock alert is raised for a product at a warehouse. Zero disables alerts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock"
+                ],
+                "summary": "Update the reorder threshold of a stock record",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New reorder threshold",
+                        "name": "threshold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateReorderThresholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AddStockRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "product_sku",
+                "quantity",
+                "reference",
+                "warehouse_id"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_sku": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "reorder_threshold": {
+                    "description": "ReorderThreshold optionally sets the quantity below which a low-stock alert is raised",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AdjustStockRequest": {
+            "type": "object",
+            "required": [
+                "delta",
+                "product_id",
+                "reason",
+                "warehouse_id"
+            ],
+            "properties": {
+                "delta": {
+                    "description": "Signed change to the on-hand quantity",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AgedReservationReportResponse": {
+            "type": "object",
+            "properties": {
+                "cutoff": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "older_than": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "reservations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AgedReservationResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AgedReservationWarehouseTotal"
+                    }
+                }
+            }
+        },
+        "model.AgedReservationResponse": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "reserved_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AgedReservationWarehouseTotal": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "reservations": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BatchItemStatus": {
+            "type": "string",
+            "enum": [
+                "reserved",
+                "insufficient_stock",
+                "rolled_back"
+            ],
+            "x-enum-varnames": [
+                "BatchItemStatusReserved",
+                "BatchItemStatusInsufficientStock",
+                "BatchItemStatusRolledBack"
+            ]
+        },
+        "model.CancelReservationRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity",
+                "reference",
+                "warehouse_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CommitReservationRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity",
+                "reference",
+                "warehouse_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateWarehouseRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "location",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.DependencyHealth": {
+            "description": "Status of a dependency such as the database",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "dial tcp: connection refused"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "model.HealthResponse": {
+            "description": "Service health with the status of each dependency",
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.DependencyHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
+        "model.ProductAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reserved_quantity": {
+                    "type": "integer"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductStockSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "total_available_quantity": {
+                    "type": "integer"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_reserved_quantity": {
+                    "type": "integer"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WarehouseStockSummary"
+                    }
+                }
+            }
+        },
+        "model.ReferenceReservationResponse": {
             "type": "object",
-            "required": [
-                "product_id",
-                "product_sku",
-                "quantity",
-                "reference",
-                "warehouse_id"
-            ],
             "properties": {
-                "notes": {
+                "created_at": {
                     "type": "string"
                 },
-                "product_id": {
+                "id": {
                     "type": "integer"
                 },
-                "product_sku": {
-                    "type": "string"
+                "product_id": {
+                    "type": "integer"
                 },
                 "quantity": {
                     "type": "integer"
                 },
-                "reference": {
-                    "type": "string"
+                "status": {
+                    "$ref": "#/definitions/model.ReservationStatus"
                 },
                 "warehouse_id": {
                     "type": "integer"
                 }
             }
         },
-        "model.CancelReservationRequest": {
+        "model.RelocateReservationRequest": {
             "type": "object",
             "required": [
+                "from_warehouse_id",
                 "product_id",
                 "quantity",
                 "reference",
-                "warehouse_id"
+                "to_warehouse_id"
             ],
             "properties": {
+                "from_warehouse_id": {
+                    "type": "integer"
+                },
                 "product_id": {
                     "type": "integer"
                 },
@@ -911,56 +2210,45 @@ const docTemplate = `{
                 "reference": {
                     "type": "string"
                 },
-                "warehouse_id": {
+                "to_warehouse_id": {
                     "type": "integer"
                 }
             }
         },
-        "model.CommitReservationRequest": {
+        "model.RelocateReservationResponse": {
             "type": "object",
-            "required": [
-                "product_id",
-                "quantity",
-                "reference",
-                "warehouse_id"
-            ],
             "properties": {
-                "product_id": {
-                    "type": "integer"
-                },
-                "quantity": {
+                "from_warehouse_id": {
                     "type": "integer"
                 },
-                "reference": {
-                    "type": "string"
-                },
-                "warehouse_id": {
-                    "type": "integer"
+                "reservation": {
+                    "$ref": "#/definitions/model.ReservationResponse"
                 }
             }
         },
-        "model.CreateWarehouseRequest": {
+        "model.ReservationDetailResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "location",
-                "name"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "maxLength": 500
+                "expires_at": {
+                    "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "product_id": {
+                    "type": "integer"
                 },
-                "location": {
-                    "type": "string",
-                    "maxLength": 255
+                "reference": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255
+                "reserved_at": {
+                    "type": "string"
+                },
+                "reserved_quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ReservationStatus"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
@@ -1041,14 +2329,87 @@ const docTemplate = `{
             "enum": [
                 "pending",
                 "committed",
-                "cancelled"
+                "cancelled",
+                "expired",
+                "reserved"
             ],
             "x-enum-varnames": [
                 "ReservationStatusPending",
                 "ReservationStatusCommitted",
-                "ReservationStatusCancelled"
+                "ReservationStatusCancelled",
+                "ReservationStatusExpired",
+                "ReservationStatusReserved"
             ]
         },
+        "model.ReservationsByReferenceResponse": {
+            "type": "object",
+            "properties": {
+                "reference": {
+                    "type": "string"
+                },
+                "reservations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReferenceReservationResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReserveStockBatchItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reservation": {
+                    "$ref": "#/definitions/model.ReservationResponse"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.BatchItemStatus"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReserveStockBatchRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ReserveStockRequest"
+                    }
+                }
+            }
+        },
+        "model.ReserveStockBatchResponse": {
+            "type": "object",
+            "properties": {
+                "reserved": {
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReserveStockBatchItemResult"
+                    }
+                }
+            }
+        },
         "model.ReserveStockRequest": {
             "type": "object",
             "required": [
@@ -1063,6 +2424,52 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "reference": {
+                    "description": "Reference identifies the reservation in later commits and cancellations; a reference is generated when empty",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StockHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "current_quantity": {
+                    "type": "integer"
+                },
+                "current_reserved_quantity": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockMovementResponse"
+                    }
+                },
+                "opening_balance": {
+                    "type": "integer"
+                },
+                "opening_reserved_balance": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
                 "warehouse_id": {
                     "type": "integer"
                 }
@@ -1083,6 +2490,9 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "reorder_threshold": {
+                    "type": "integer"
+                },
                 "reserved_quantity": {
                     "type": "integer"
                 },
@@ -1097,6 +2507,53 @@ const docTemplate = `{
                 }
             }
         },
+        "model.StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "available_balance": {
+                    "description": "Quantity available after the movement",
+                    "type": "integer"
+                },
+                "balance": {
+                    "description": "Quantity on hand after the movement",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movement_type": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Units moved; the signed correction for adjustments",
+                    "type": "integer"
+                },
+                "quantity_change": {
+                    "description": "Change to the quantity on hand",
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "reference_type": {
+                    "type": "string"
+                },
+                "reserved_balance": {
+                    "description": "Reserved quantity after the movement",
+                    "type": "integer"
+                },
+                "reserved_change": {
+                    "description": "Change to the reserved quantity",
+                    "type": "integer"
+                }
+            }
+        },
         "model.StockResponse": {
             "type": "object",
             "properties": {
@@ -1112,6 +2569,9 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "reorder_threshold": {
+                    "type": "integer"
+                },
                 "reserved_quantity": {
                     "type": "integer"
                 },
@@ -1188,6 +2648,15 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateReorderThresholdRequest": {
+            "type": "object",
+            "properties": {
+                "reorder_threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
         "model.UpdateWarehouseRequest": {
             "type": "object",
             "required": [
@@ -1217,6 +2686,17 @@ const docTemplate = `{
                 }
             }
         },
+        "model.WarehouseBatchResponse": {
+            "type": "object",
+            "properties": {
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WarehouseResponse"
+                    }
+                }
+            }
+        },
         "model.WarehouseListResponse": {
             "type": "object",
             "properties": {
@@ -1269,11 +2749,19 @@ const docTemplate = `{
         "model.WarehouseStatsDTO": {
             "type": "object",
             "properties": {
+                "total_available": {
+                    "description": "Items neither reserved nor sold, TotalItems less TotalReserved",
+                    "type": "integer"
+                },
                 "total_items": {
                     "type": "integer"
                 },
                 "total_products": {
                     "type": "integer"
+                },
+                "total_reserved": {
+                    "description": "Items held by reservations",
+                    "type": "integer"
                 }
             }
         },
@@ -1303,6 +2791,26 @@ const docTemplate = `{
                 }
             }
         },
+        "model.WarehouseStockSummary": {
+            "type": "object",
+            "properties": {
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reserved_quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                },
+                "warehouse_name": {
+                    "type": "string"
+                }
+            }
+        },
         "response.ErrorInfo": {
             "type": "object",
             "properties": {
@@ -1326,6 +2834,18 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/response.ErrorInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
         "response.SuccessMessageResponse": {
             "type": "object",
             "properties": {
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,9 +18,25 @@ const (
 	baseURL         = "http://app:3000"
 	warehousesURL   = baseURL + "/api/v1/warehouses"
 	warehouseIDURL  = baseURL + "/api/v1/warehouses/" // Will be appended with warehouse ID
-	adminToken      = "admin_token_here"
+	jwtSecret       = "e2e-user-service-jwt-secret" // jwt.secret in config.e2e.json
 )
 
+// adminToken is an access token for an admin, signed like the user service would
+var adminToken = signAdminToken()
+
+func signAdminToken() string {
+	claims := jwt.MapClaims{
+		"sub":  "5df84b6f-8f5b-4a51-a106-e9a46b67c836",
+		"role": "admin",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(jwtSecret))
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
 // Response structures
 type WebResponse struct {
 	Success bool            `json:"success"`
@@ -44,8 +61,10 @@ type WarehouseResponse struct {
 }
 
 type WarehouseStatsDTO struct {
-	TotalProducts int64 `json:"total_products"`
-	TotalItems    int64 `json:"total_items"`
+	TotalProducts  int64 `json:"total_products"`
+	TotalItems     int64 `json:"total_items"`
+	TotalReserved  int64 `json:"total_reserved"`
+	TotalAvailable int64 `json:"total_available"`
 }
 
 // Test warehouse data
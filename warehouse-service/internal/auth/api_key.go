@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"errors"
+)
+
+const (
+	// ScopeAll grants an API key every scope
+	ScopeAll = "*"
+
+	// ScopeWarehouses lets an API key call the /warehouses routes
+	ScopeWarehouses = "warehouses"
+
+	// ScopeInventory lets an API key call the /inventory routes
+	ScopeInventory = "inventory"
+
+	// ScopeStock lets an API key call the /stock routes
+	ScopeStock = "stock"
+)
+
+var (
+	// ErrUnknownAPIKey is returned for keys that are not in the store
+	ErrUnknownAPIKey = errors.New("unknown API key")
+
+	// ErrRevokedAPIKey is returned for keys that are still listed but no longer accepted
+	ErrRevokedAPIKey = errors.New("API key has been revoked")
+)
+
+// APIKey is the credential an internal caller sends in the X-API-Key header
+type APIKey struct {
+	Name    string   `mapstructure:"name"` // Identifies the caller in logs and ctx.Locals
+	Key     string   `mapstructure:"key"`
+	Scopes  []string `mapstructure:"scopes"`
+	Revoked bool     `mapstructure:"revoked"`
+}
+
+// HasScope reports whether the key was granted scope, directly or through ScopeAll
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope || granted == ScopeAll {
+			return true
+		}
+	}
+	return false
+}
+
+type APIKeyStoreInterface interface {
+	// Find returns the API key matching key
+	Find(key string) (*APIKey, error)
+}
+
+// APIKeyStore holds a fixed set of API keys. Keys are indexed by their SHA-256 digest,
+// so a lookup takes the same time however much of a guessed key is right.
+type APIKeyStore struct {
+	keys map[[sha256.Size]byte]APIKey
+}
+
+func NewAPIKeyStore(keys []APIKey) APIKeyStoreInterface {
+	store := &APIKeyStore{
+		keys: make(map[[sha256.Size]byte]APIKey, len(keys)),
+	}
+	for _, key := range keys {
+		store.keys[sha256.Sum256([]byte(key.Key))] = key
+	}
+	return store
+}
+
+// Find returns the API key matching key, ErrUnknownAPIKey when none does and ErrRevokedAPIKey when it was revoked
+func (s *APIKeyStore) Find(key string) (*APIKey, error) {
+	apiKey, ok := s.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, ErrUnknownAPIKey
+	}
+	if apiKey.Revoked {
+		return nil, ErrRevokedAPIKey
+	}
+	return &apiKey, nil
+}
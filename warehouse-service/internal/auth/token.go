@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// RoleAdmin may create, update and delete warehouses
+	RoleAdmin = "admin"
+
+	// RoleCustomer is the role of every user registered with the user service
+	RoleCustomer = "customer"
+
+	// RoleService is assigned to internal callers authenticated with the service API key
+	RoleService = "service"
+)
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed, signed with another key or algorithm, or carry no subject
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrExpiredToken is returned for well-formed tokens whose expiry has passed
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Claims are the claims of an access token issued by the user service.
+// The user ID is stored in the subject.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role,omitempty"`
+}
+
+type TokenVerifierInterface interface {
+	// Verify checks the token's signature and expiry and returns its claims
+	Verify(token string) (*Claims, error)
+}
+
+// TokenVerifier verifies HS256-signed access tokens issued by the user service
+type TokenVerifier struct {
+	Secret []byte
+}
+
+func NewTokenVerifier(secret string) TokenVerifierInterface {
+	return &TokenVerifier{
+		Secret: []byte(secret),
+	}
+}
+
+// Verify checks the token's signature and expiry and returns its claims
+func (v *TokenVerifier) Verify(token string) (*Claims, error) {
+	claims := new(Claims)
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return v.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -1,12 +1,15 @@
 package config
 
 import (
+	"context"
 	"warehouse-service/internal/delivery/http/middleware"
 	"warehouse-service/internal/delivery/http/route"
 	"warehouse-service/internal/entity"
+	"warehouse-service/internal/gateway/notification"
 	"warehouse-service/internal/gateway/product"
 	"warehouse-service/internal/handler"
 	"warehouse-service/internal/repository"
+	"warehouse-service/internal/scheduler"
 	"warehouse-service/internal/usecase"
 
 	"github.com/go-playground/validator/v10"
@@ -17,6 +20,8 @@ import (
 )
 
 type BootstrapConfig struct {
+	// Context is cancelled on shutdown to stop background jobs
+	Context  context.Context
 	DB       *gorm.DB
 	App      *fiber.App
 	Log      *logrus.Logger
@@ -36,6 +41,25 @@ func Bootstrap(config *BootstrapConfig) {
 	
 	config.Log.Info("Bootstrapping application...")
 
+	// Validate reservation expiry configuration before wiring any dependencies
+	reservationConfig := NewReservationConfig(config.Config)
+	if reservationConfig.TTL <= 0 {
+		config.Log.WithField("reservation_ttl", reservationConfig.TTL.String()).Fatal("Reservation TTL must be positive")
+	}
+	if reservationConfig.SweepInterval <= 0 {
+		config.Log.WithField("reservation_sweep_interval", reservationConfig.SweepInterval.String()).Fatal("Reservation sweep interval must be positive")
+	}
+	if reservationConfig.CommitRetention <= 0 || reservationConfig.CommitPurgeInterval <= 0 || reservationConfig.CommitPurgeBatchSize <= 0 {
+		config.Log.WithFields(logrus.Fields{
+			"commit_retention":        reservationConfig.CommitRetention.String(),
+			"commit_purge_interval":   reservationConfig.CommitPurgeInterval.String(),
+			"commit_purge_batch_size": reservationConfig.CommitPurgeBatchSize,
+		}).Fatal("Reservation commit retention, purge interval and purge batch size must be positive")
+	}
+	if reservationConfig.MaxQuantity <= 0 {
+		config.Log.WithField("reservation_max_quantity", reservationConfig.MaxQuantity).Fatal("Reservation max quantity must be positive")
+	}
+
 	// Auto-migrate database if needed
 	if config.Config.GetBool("database.auto_migrate") {
 		config.Log.Info("Auto-migrating database tables...")
@@ -45,7 +69,9 @@ func Bootstrap(config *BootstrapConfig) {
 			&entity.WarehouseStock{},
 			&entity.StockTransfer{},
 			&entity.StockMovement{},
+			&entity.StockAdjustment{},
 			&entity.ReservationLog{},
+			&entity.CommittedReservation{},
 		)
 		if err != nil {
 			config.Log.WithField("error", err.Error()).Fatal("Failed to migrate database")
@@ -59,20 +85,38 @@ func Bootstrap(config *BootstrapConfig) {
 	stockRepository := repository.NewStockRepository(config.Log, config.DB)
 	
 	// setup product client
-	productClient := product.NewProductClient(config.Log)
+	productClient := product.NewProductClient(config.Log, NewProductTimeout(config.Config, config.Log), NewProductRetryPolicy(config.Config, config.Log))
+	
+	// setup low stock alerts; replace with a real notifier to send email or Slack alerts
+	stockAlertNotifier := notification.NewNoopStockAlertNotifier()
+
+	// setup use cases; list endpoints share one page size policy
+	pagination := NewPagination(config.Config, config.Log)
+	warehouseUseCase := usecase.NewWarehouseUseCase(config.DB, config.Log, config.Validate, warehouseRepository, pagination)
+	reservationUseCase := usecase.NewReservationUseCase(config.DB, config.Log, config.Validate, reservationRepository, warehouseRepository, stockAlertNotifier, reservationConfig.TTL, reservationConfig.MaxQuantity, pagination)
+	stockUseCase := usecase.NewStockUseCase(config.DB, config.Log, config.Validate, stockRepository, warehouseRepository, productClient, stockAlertNotifier, pagination)
+
+	// Periodically release reservations that were never committed or cancelled
+	ctx := config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	scheduler.NewReservationExpiryScheduler(reservationUseCase, reservationConfig.SweepInterval, config.Log).Start(ctx)
 
-	// setup use cases
-	warehouseUseCase := usecase.NewWarehouseUseCase(config.DB, config.Log, config.Validate, warehouseRepository)
-	reservationUseCase := usecase.NewReservationUseCase(config.DB, config.Log, config.Validate, reservationRepository, warehouseRepository)
-	stockUseCase := usecase.NewStockUseCase(config.DB, config.Log, config.Validate, stockRepository, warehouseRepository, productClient)
+	// Periodically delete commit records that are too old for their commit to be retried
+	scheduler.NewCommitPurgeScheduler(reservationUseCase, reservationConfig.CommitRetention, reservationConfig.CommitPurgeBatchSize,
+		reservationConfig.CommitPurgeInterval, config.Log).Start(ctx)
 
 	// setup handlers
 	warehouseHandler := handler.NewWarehouseHandler(warehouseUseCase, config.Log)
 	reservationHandler := handler.NewReservationHandler(reservationUseCase, config.Log)
 	stockHandler := handler.NewStockHandler(stockUseCase, config.Log)
+	healthHandler := handler.NewHealthHandler(config.DB, config.Log)
 
-	// Create auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(config.DB)
+	// Create auth middleware; user access tokens are verified with the user service's JWT secret
+	tokenVerifier := NewTokenVerifier(config.Config, config.Log)
+	apiKeys := NewAPIKeyStore(config.Config, config.Log)
+	authMiddleware := middleware.NewAuthMiddleware(config.DB, tokenVerifier, apiKeys)
 	authMiddleware.SetLogger(config.Log)
 
 	// Configure routes
@@ -81,9 +125,13 @@ func Bootstrap(config *BootstrapConfig) {
 		WarehouseHandler:   warehouseHandler,
 		ReservationHandler: reservationHandler,
 		StockHandler:       stockHandler,
+		HealthHandler:      healthHandler,
 		DB:                 config.DB,
 		WarehouseRepo:      warehouseRepository,
+		AuthMiddleware:     authMiddleware,
 		Log:                config.Log,
+		AccessLog:          NewAccessLogConfig(config.Config, config.Log),
+		CORS:               NewCORSConfig(config.Config, config.Log),
 	}
 	
 	// Setup routes
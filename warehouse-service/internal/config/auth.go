@@ -0,0 +1,53 @@
+package config
+
+import (
+	"warehouse-service/internal/auth"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewTokenVerifier creates the verifier for user service access tokens from jwt.secret,
+// which must match the secret the user service signs tokens with
+func NewTokenVerifier(config *viper.Viper, log *logrus.Logger) auth.TokenVerifierInterface {
+	secret := config.GetString("jwt.secret")
+	if secret == "" {
+		log.Fatal("jwt.secret must be configured to verify access tokens")
+	}
+
+	return auth.NewTokenVerifier(secret)
+}
+
+// NewAPIKeyStore loads the API keys internal callers may send in the X-API-Key header from auth.api_keys.
+// Every key needs a name and a value; revoked keys stay listed so their use is reported as revoked.
+func NewAPIKeyStore(config *viper.Viper, log *logrus.Logger) auth.APIKeyStoreInterface {
+	var keys []auth.APIKey
+	if err := config.UnmarshalKey("auth.api_keys", &keys); err != nil {
+		log.Fatalf("invalid auth.api_keys: %v", err)
+	}
+
+	names := make(map[string]bool, len(keys))
+	values := make(map[string]bool, len(keys))
+	active := 0
+	for _, key := range keys {
+		if key.Name == "" || key.Key == "" {
+			log.Fatal("every entry of auth.api_keys needs a name and a key")
+		}
+		if names[key.Name] || values[key.Key] {
+			log.Fatalf("auth.api_keys lists %q or its key more than once", key.Name)
+		}
+		names[key.Name] = true
+		values[key.Key] = true
+		if !key.Revoked {
+			active++
+		}
+	}
+
+	if active == 0 {
+		log.Warn("No active API keys configured in auth.api_keys; requests with X-API-Key will be rejected")
+	} else {
+		log.WithField("active_keys", active).Info("API keys loaded")
+	}
+
+	return auth.NewAPIKeyStore(keys)
+}
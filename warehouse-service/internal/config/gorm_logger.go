@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	appContext "warehouse-service/internal/context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// DefaultSlowQueryThreshold is used when database.slow_threshold is not configured
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// NewSlowQueryThreshold returns how long a query may run before it is logged as slow. Zero disables slow query logging.
+func NewSlowQueryThreshold(config *viper.Viper) time.Duration {
+	if config.IsSet("database.slow_threshold") {
+		return config.GetDuration("database.slow_threshold")
+	}
+	return DefaultSlowQueryThreshold
+}
+
+// gormLogger routes GORM's logs through logrus instead of stdout. Queries slower than slowThreshold are
+// logged at warn level, every other query at trace level. Both carry the request ID of the query's context when it has one.
+type gormLogger struct {
+	log           *logrus.Logger
+	slowThreshold time.Duration
+}
+
+func newGormLogger(log *logrus.Logger, slowThreshold time.Duration) *gormLogger {
+	return &gormLogger{log: log, slowThreshold: slowThreshold}
+}
+
+// LogMode returns the logger unchanged; verbosity follows the logrus level
+func (l *gormLogger) LogMode(logger.LogLevel) logger.Interface {
+	return l
+}
+
+func (l *gormLogger) Info(ctx context.Context, message string, args ...interface{}) {
+	l.entry(ctx).Infof(message, args...)
+}
+
+func (l *gormLogger) Warn(ctx context.Context, message string, args ...interface{}) {
+	l.entry(ctx).Warnf(message, args...)
+}
+
+func (l *gormLogger) Error(ctx context.Context, message string, args ...interface{}) {
+	l.entry(ctx).Errorf(message, args...)
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	if !slow && !l.log.IsLevelEnabled(logrus.TraceLevel) {
+		return
+	}
+
+	sql, rows := fc()
+	entry := l.entry(ctx).WithFields(logrus.Fields{
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": float64(elapsed.Microseconds()) / 1000,
+		"caller":      utils.FileWithLineNum(),
+	})
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+
+	if slow {
+		entry.WithField("threshold_ms", l.slowThreshold.Milliseconds()).Warn("Slow query")
+		return
+	}
+	entry.Trace("Query")
+}
+
+// ParamsFilter keeps bind values out of the logged SQL
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+func (l *gormLogger) entry(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(l.log)
+	if ctx == nil {
+		return entry
+	}
+	if requestID := appContext.GetRequestID(ctx); requestID != "" {
+		entry = entry.WithField("request_id", requestID)
+	}
+	return entry
+}
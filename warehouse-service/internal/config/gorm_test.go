@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDatabasePoolConfig_Defaults(t *testing.T) {
+	pool, err := NewDatabasePoolConfig(viper.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultMaxOpenConns, pool.MaxOpenConns)
+	assert.Equal(t, DefaultMaxIdleConns, pool.MaxIdleConns)
+	assert.Equal(t, DefaultConnMaxLifetime, pool.ConnMaxLifetime)
+}
+
+func TestNewDatabasePoolConfig_Configured(t *testing.T) {
+	v := viper.New()
+	v.Set("database.pool.max", 50)
+	v.Set("database.pool.idle", 5)
+	v.Set("database.pool.lifetime", 2)
+
+	pool, err := NewDatabasePoolConfig(v)
+	require.NoError(t, err)
+
+	assert.Equal(t, 50, pool.MaxOpenConns)
+	assert.Equal(t, 5, pool.MaxIdleConns)
+	assert.Equal(t, 2*time.Second, pool.ConnMaxLifetime)
+}
+
+func TestNewDatabasePoolConfig_RejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]int
+	}{
+		{name: "zero max open", settings: map[string]int{"max": 0}},
+		{name: "negative max open", settings: map[string]int{"max": -1}},
+		{name: "negative idle", settings: map[string]int{"idle": -1}},
+		{name: "idle above max open", settings: map[string]int{"max": 5, "idle": 10}},
+		{name: "zero lifetime", settings: map[string]int{"lifetime": 0}},
+		{name: "negative lifetime", settings: map[string]int{"lifetime": -30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			for key, value := range tt.settings {
+				v.Set("database.pool."+key, value)
+			}
+
+			pool, err := NewDatabasePoolConfig(v)
+			assert.Error(t, err)
+			assert.Nil(t, pool)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"warehouse-service/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewPagination reads pagination.default_limit and pagination.max_limit, the default and largest page size
+// of list endpoints. Without them model.DefaultPageLimit and model.MaxPageLimit are used.
+func NewPagination(config *viper.Viper, log *logrus.Logger) model.Pagination {
+	pagination := model.Pagination{
+		DefaultLimit: model.DefaultPageLimit,
+		MaxLimit:     model.MaxPageLimit,
+	}
+	if config.IsSet("pagination.default_limit") {
+		pagination.DefaultLimit = config.GetInt("pagination.default_limit")
+	}
+	if config.IsSet("pagination.max_limit") {
+		pagination.MaxLimit = config.GetInt("pagination.max_limit")
+	}
+
+	if pagination.DefaultLimit <= 0 || pagination.MaxLimit <= 0 || pagination.DefaultLimit > pagination.MaxLimit {
+		log.WithFields(logrus.Fields{
+			"default_limit": pagination.DefaultLimit,
+			"max_limit":     pagination.MaxLimit,
+		}).Fatal("Pagination limits must be positive and the default must not exceed the maximum")
+	}
+	return pagination
+}
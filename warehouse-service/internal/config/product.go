@@ -0,0 +1,50 @@
+package config
+
+import (
+	"time"
+	"warehouse-service/internal/gateway/product"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewProductRetryPolicy reads product.retry.max_attempts, product.retry.base_delay and product.retry.max_delay,
+// falling back to product.DefaultRetryPolicy for any that are not configured
+func NewProductRetryPolicy(config *viper.Viper, log *logrus.Logger) product.RetryPolicy {
+	retry := product.DefaultRetryPolicy()
+
+	if config.IsSet("product.retry.max_attempts") {
+		retry.MaxAttempts = config.GetInt("product.retry.max_attempts")
+	}
+	if config.IsSet("product.retry.base_delay") {
+		retry.BaseDelay = config.GetDuration("product.retry.base_delay")
+	}
+	if config.IsSet("product.retry.max_delay") {
+		retry.MaxDelay = config.GetDuration("product.retry.max_delay")
+	}
+
+	if retry.MaxAttempts < 1 {
+		log.WithField("max_attempts", retry.MaxAttempts).Fatal("Product retry max_attempts must be at least 1")
+	}
+	if retry.BaseDelay < 0 || retry.MaxDelay < retry.BaseDelay {
+		log.WithFields(logrus.Fields{
+			"base_delay": retry.BaseDelay.String(),
+			"max_delay":  retry.MaxDelay.String(),
+		}).Fatal("Product retry delays must not be negative and max_delay must not be below base_delay")
+	}
+
+	return retry
+}
+
+// NewProductTimeout reads product.timeout, the most a single product service request may take,
+// falling back to product.DefaultTimeout when it is not configured
+func NewProductTimeout(config *viper.Viper, log *logrus.Logger) time.Duration {
+	timeout := product.DefaultTimeout
+	if config.IsSet("product.timeout") {
+		timeout = config.GetDuration("product.timeout")
+	}
+	if timeout <= 0 {
+		log.WithField("timeout", timeout.String()).Fatal("Product timeout must be positive")
+	}
+	return timeout
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultReservationTTL is used when reservation.ttl is not configured. It outlasts the order
+	// service's default payment deadline so reservations of unpaid orders are not released early.
+	DefaultReservationTTL = 25 * time.Hour
+
+	// DefaultReservationSweepInterval is used when reservation.sweep_interval is not configured
+	DefaultReservationSweepInterval = time.Minute
+
+	// DefaultCommitRetention is used when reservation.commit_retention is not configured
+	DefaultCommitRetention = 720 * time.Hour
+
+	// DefaultCommitPurgeInterval is used when reservation.commit_purge_interval is not configured
+	DefaultCommitPurgeInterval = time.Hour
+
+	// DefaultCommitPurgeBatchSize is used when reservation.commit_purge_batch_size is not configured
+	DefaultCommitPurgeBatchSize = 1000
+
+	// DefaultReservationMaxQuantity is used when reservation.max_quantity is not configured
+	DefaultReservationMaxQuantity = 1000
+)
+
+// ReservationConfig holds configuration for limiting and expiring stock reservations and purging old commit records
+type ReservationConfig struct {
+	TTL                  time.Duration `mapstructure:"ttl"`
+	SweepInterval        time.Duration `mapstructure:"sweep_interval"`
+	CommitRetention      time.Duration `mapstructure:"commit_retention"`
+	CommitPurgeInterval  time.Duration `mapstructure:"commit_purge_interval"`
+	CommitPurgeBatchSize int           `mapstructure:"commit_purge_batch_size"`
+	// MaxQuantity caps the units a single reservation may hold
+	MaxQuantity int `mapstructure:"max_quantity"`
+}
+
+// NewReservationConfig returns the reservation expiry, commit purge and quantity limit configuration
+func NewReservationConfig(config *viper.Viper) *ReservationConfig {
+	ttl := DefaultReservationTTL
+	if config.IsSet("reservation.ttl") {
+		ttl = config.GetDuration("reservation.ttl")
+	}
+
+	sweepInterval := DefaultReservationSweepInterval
+	if config.IsSet("reservation.sweep_interval") {
+		sweepInterval = config.GetDuration("reservation.sweep_interval")
+	}
+
+	commitRetention := DefaultCommitRetention
+	if config.IsSet("reservation.commit_retention") {
+		commitRetention = config.GetDuration("reservation.commit_retention")
+	}
+
+	commitPurgeInterval := DefaultCommitPurgeInterval
+	if config.IsSet("reservation.commit_purge_interval") {
+		commitPurgeInterval = config.GetDuration("reservation.commit_purge_interval")
+	}
+
+	commitPurgeBatchSize := DefaultCommitPurgeBatchSize
+	if config.IsSet("reservation.commit_purge_batch_size") {
+		commitPurgeBatchSize = config.GetInt("reservation.commit_purge_batch_size")
+	}
+
+	maxQuantity := DefaultReservationMaxQuantity
+	if config.IsSet("reservation.max_quantity") {
+		maxQuantity = config.GetInt("reservation.max_quantity")
+	}
+
+	return &ReservationConfig{
+		TTL:                  ttl,
+		SweepInterval:        sweepInterval,
+		CommitRetention:      commitRetention,
+		CommitPurgeInterval:  commitPurgeInterval,
+		CommitPurgeBatchSize: commitPurgeBatchSize,
+		MaxQuantity:          maxQuantity,
+	}
+}
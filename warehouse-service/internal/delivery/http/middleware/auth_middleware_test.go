@@ -0,0 +1,273 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"warehouse-service/internal/auth"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, secret string, role string, expiresAt time.Time) string {
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "5df84b6f-8f5b-4a51-a106-e9a46b67c836",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: role,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func newTestAPIKeyStore() auth.APIKeyStoreInterface {
+	return auth.NewAPIKeyStore([]auth.APIKey{
+		{Name: "internal-services", Key: "warehouse-service-api-key", Scopes: []string{auth.ScopeAll}},
+		{Name: "order-service", Key: "order-key", Scopes: []string{auth.ScopeInventory}},
+		{Name: "retired-service", Key: "revoked-key", Scopes: []string{auth.ScopeAll}, Revoked: true},
+	})
+}
+
+func setupAuthMiddlewareTest() *fiber.App {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(nil, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+	middleware.SetLogger(logger)
+
+	app := fiber.New()
+	warehouses := app.Group("/warehouses", middleware.RequireAuth())
+	warehouses.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	warehouses.Post("/", middleware.RequireRole(auth.RoleAdmin), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	return app
+}
+
+func TestAuthMiddleware_RequireRole(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	adminToken := signToken(t, testSecret, auth.RoleAdmin, expiresAt)
+	customerToken := signToken(t, testSecret, auth.RoleCustomer, expiresAt)
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		apiKey        string
+		expectedCode  int
+	}{
+		{"admin creates warehouse", "POST", "Bearer " + adminToken, "", fiber.StatusCreated},
+		{"customer creates warehouse", "POST", "Bearer " + customerToken, "", fiber.StatusForbidden},
+		{"customer lists warehouses", "GET", "Bearer " + customerToken, "", fiber.StatusOK},
+		{"service account creates warehouse", "POST", "", "warehouse-service-api-key", fiber.StatusForbidden},
+		{"service account lists warehouses", "GET", "", "warehouse-service-api-key", fiber.StatusOK},
+		{"expired admin token", "POST", "Bearer " + signToken(t, testSecret, auth.RoleAdmin, time.Now().Add(-time.Minute)), "", fiber.StatusUnauthorized},
+		{"admin token with wrong secret", "POST", "Bearer " + signToken(t, "other-secret", auth.RoleAdmin, expiresAt), "", fiber.StatusUnauthorized},
+		{"missing credentials", "POST", "", "", fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupAuthMiddlewareTest()
+
+			req := httptest.NewRequest(tt.method, "/warehouses/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(nil, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+	middleware.SetLogger(logger)
+
+	app := fiber.New()
+	identify := func(c *fiber.Ctx) error {
+		key := c.Locals("apiKey").(*auth.APIKey)
+		return c.SendString(c.Locals("userId").(string) + " " + key.Name)
+	}
+	app.Get("/inventory", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeInventory), identify)
+	app.Get("/warehouses", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeWarehouses), identify)
+
+	tests := []struct {
+		name         string
+		path         string
+		apiKey       string
+		expectedCode int
+		expectedBody string
+	}{
+		{"valid key with scope", "/inventory", "order-key", fiber.StatusOK, "order-service order-service"},
+		{"valid key granted every scope", "/warehouses", "warehouse-service-api-key", fiber.StatusOK, "internal-services internal-services"},
+		{"valid key without scope", "/warehouses", "order-key", fiber.StatusForbidden, ""},
+		{"missing key", "/inventory", "", fiber.StatusUnauthorized, ""},
+		{"unknown key", "/inventory", "not-a-key", fiber.StatusUnauthorized, ""},
+		{"revoked key", "/inventory", "revoked-key", fiber.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			if tt.expectedBody != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RevokedKeyMessage(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(nil, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+	middleware.SetLogger(logger)
+
+	app := fiber.New()
+	app.Get("/inventory", middleware.RequireAuth(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/inventory", nil)
+	req.Header.Set("X-API-Key", "revoked-key")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "API key has been revoked")
+}
+
+func TestAuthMiddleware_RequireScope_AllowsUsers(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(nil, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+	middleware.SetLogger(logger)
+
+	app := fiber.New()
+	app.Get("/warehouses", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeWarehouses), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/warehouses", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, testSecret, auth.RoleCustomer, time.Now().Add(time.Hour)))
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestAuthMiddleware_RequireRole_AdminOrService(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(nil, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+	middleware.SetLogger(logger)
+
+	app := fiber.New()
+	app.Post("/stock/transfer", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeStock),
+		middleware.RequireRole(auth.RoleAdmin, auth.RoleService), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+	expiresAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name          string
+		authorization string
+		apiKey        string
+		expectedCode  int
+	}{
+		{"admin transfers stock", "Bearer " + signToken(t, testSecret, auth.RoleAdmin, expiresAt), "", fiber.StatusOK},
+		{"service transfers stock", "", "warehouse-service-api-key", fiber.StatusOK},
+		{"customer transfers stock", "Bearer " + signToken(t, testSecret, auth.RoleCustomer, expiresAt), "", fiber.StatusForbidden},
+		{"service without the stock scope", "", "order-key", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/stock/transfer", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireRole_ServiceOnly(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	middleware := NewAuthMiddleware(nil, auth.NewTokenVerifier(testSecret), newTestAPIKeyStore())
+	middleware.SetLogger(logger)
+
+	app := fiber.New()
+	app.Post("/inventory/reserve/cancel", middleware.RequireAuth(), middleware.RequireScope(auth.ScopeInventory),
+		middleware.RequireRole(auth.RoleService), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+	expiresAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name          string
+		authorization string
+		apiKey        string
+		expectedCode  int
+	}{
+		{"inventory-scoped key cancels a reservation", "", "order-key", fiber.StatusOK},
+		{"customer cancels a reservation", "Bearer " + signToken(t, testSecret, auth.RoleCustomer, expiresAt), "", fiber.StatusForbidden},
+		{"admin cancels a reservation", "Bearer " + signToken(t, testSecret, auth.RoleAdmin, expiresAt), "", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/inventory/reserve/cancel", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+		})
+	}
+}
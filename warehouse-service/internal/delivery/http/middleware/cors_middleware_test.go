@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowOrigins  []string
+		origin        string
+		expectedAllow string
+	}{
+		{
+			name:          "unconfigured denies every origin",
+			allowOrigins:  nil,
+			origin:        "https://shop.example.com",
+			expectedAllow: "",
+		},
+		{
+			name:          "exact match",
+			allowOrigins:  []string{"https://shop.example.com"},
+			origin:        "https://shop.example.com",
+			expectedAllow: "https://shop.example.com",
+		},
+		{
+			name:          "exact match rejects other origins",
+			allowOrigins:  []string{"https://shop.example.com"},
+			origin:        "https://evil.example.org",
+			expectedAllow: "",
+		},
+		{
+			name:          "subdomain wildcard",
+			allowOrigins:  []string{"https://*.example.com"},
+			origin:        "https://admin.example.com",
+			expectedAllow: "https://admin.example.com",
+		},
+		{
+			name:          "subdomain wildcard rejects the bare domain",
+			allowOrigins:  []string{"https://*.example.com"},
+			origin:        "https://example.com",
+			expectedAllow: "",
+		},
+		{
+			name:          "wildcard allows any origin",
+			allowOrigins:  []string{"*"},
+			origin:        "http://localhost:5173",
+			expectedAllow: "http://localhost:5173",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultCORSConfig()
+			config.AllowOrigins = tt.allowOrigins
+
+			app := fiber.New()
+			app.Use(CORS(config))
+			app.Get("/api/v1/health", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/api/v1/health", nil)
+			req.Header.Set("Origin", tt.origin)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.expectedAllow, resp.Header.Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	config := DefaultCORSConfig()
+	config.AllowOrigins = []string{"https://shop.example.com"}
+	config.AllowCredentials = true
+	config.MaxAge = 600
+
+	app := fiber.New()
+	app.Use(CORS(config))
+	// The preflight must be answered without reaching authentication
+	app.Put("/api/v1/warehouses/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	})
+
+	req := httptest.NewRequest("OPTIONS", "/api/v1/warehouses/1", nil)
+	req.Header.Set("Origin", "https://shop.example.com")
+	req.Header.Set("Access-Control-Request-Method", "PUT")
+	req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://shop.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), "PUT")
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "Authorization")
+	assert.Equal(t, "600", resp.Header.Get("Access-Control-Max-Age"))
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLoggerTestApp(config LoggerConfig) (*fiber.App, *test.Hook) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+
+	app := fiber.New()
+	app.Use(RequestID())
+	app.Use(Logger(config, logger))
+	app.Post("/warehouses", func(c *fiber.Ctx) error {
+		c.Locals("userId", "user-1")
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/warehouses/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNotFound)
+	})
+	return app, hook
+}
+
+func TestRequestID_ReusesInboundID(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestID())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(c.Get(RequestIDHeader))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "inbound-id")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "inbound-id", resp.Header.Get(RequestIDHeader))
+
+	// A generated ID is visible to handlers and echoed on the response
+	resp, err = app.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	generated := resp.Header.Get(RequestIDHeader)
+	assert.NotEmpty(t, generated)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, generated, string(body))
+}
+
+func TestLogger_WritesAccessLogEntry(t *testing.T) {
+	app, hook := newLoggerTestApp(DefaultLoggerConfig())
+
+	req := httptest.NewRequest("POST", "/warehouses", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	require.Len(t, hook.Entries, 1)
+	entry := hook.LastEntry()
+	assert.Equal(t, logrus.InfoLevel, entry.Level)
+	assert.Equal(t, "req-1", entry.Data["request_id"])
+	assert.Equal(t, "POST", entry.Data["method"])
+	assert.Equal(t, "/warehouses", entry.Data["path"])
+	assert.Equal(t, fiber.StatusOK, entry.Data["status_code"])
+	assert.Equal(t, "user-1", entry.Data["user_id"])
+	assert.Contains(t, entry.Data, "latency_ms")
+	assert.NotContains(t, entry.Data, "headers")
+	assert.NotContains(t, entry.Data, "body")
+}
+
+func TestLogger_RedactsCredentials(t *testing.T) {
+	config := DefaultLoggerConfig()
+	config.LogHeaders = true
+	config.LogBodies = true
+	app, hook := newLoggerTestApp(config)
+
+	req := httptest.NewRequest("POST", "/warehouses", strings.NewReader(`{"email":"jane@example.com","password":"s3cret-pass"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer access-token")
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	headers := entry.Data["headers"].(map[string]string)
+	assert.Equal(t, "[REDACTED]", headers["Authorization"])
+	assert.Equal(t, "application/json", headers["Content-Type"])
+
+	body := entry.Data["body"].(string)
+	assert.Contains(t, body, "jane@example.com")
+	assert.NotContains(t, body, "s3cret-pass")
+	assert.Contains(t, body, `"password":"[REDACTED]"`)
+}
+
+func TestLogger_RedactsNonJSONBodyWithPassword(t *testing.T) {
+	assert.Equal(t, "[REDACTED]", redactBody([]byte("email=jane@example.com&password=s3cret")))
+	assert.Equal(t, "name=jane", redactBody([]byte("name=jane")))
+}
+
+func TestLogger_SamplingKeepsFailedRequests(t *testing.T) {
+	config := DefaultLoggerConfig()
+	config.SampleRate = 0
+	app, hook := newLoggerTestApp(config)
+
+	_, err := app.Test(httptest.NewRequest("POST", "/warehouses", nil))
+	require.NoError(t, err)
+	assert.Empty(t, hook.Entries)
+
+	_, err = app.Test(httptest.NewRequest("GET", "/warehouses/1", nil))
+	require.NoError(t, err)
+	require.Len(t, hook.Entries, 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+}
+
+func TestLogger_SkipsDisabledLevel(t *testing.T) {
+	config := DefaultLoggerConfig()
+	config.Level = logrus.DebugLevel
+	app, hook := newLoggerTestApp(config)
+
+	_, err := app.Test(httptest.NewRequest("POST", "/warehouses", nil))
+	require.NoError(t, err)
+	assert.Empty(t, hook.Entries)
+}
@@ -1,7 +1,7 @@
 package route
 
 import (
-	"github.com/google/uuid"
+	"warehouse-service/internal/auth"
 	"warehouse-service/internal/delivery/http/middleware"
 	"warehouse-service/internal/delivery/http/response"
 	"warehouse-service/internal/errors"
@@ -19,27 +19,26 @@ type RouteConfig struct {
 	WarehouseHandler   *handler.WarehouseHandler
 	ReservationHandler *handler.ReservationHandler
 	StockHandler       *handler.StockHandler
+	HealthHandler      *handler.HealthHandler
 	DB                 *gorm.DB
 	WarehouseRepo      repository.WarehouseRepositoryInterface
+	AuthMiddleware     *middleware.AuthMiddleware
 	Log                *logrus.Logger
+	AccessLog          middleware.LoggerConfig
+	CORS               middleware.CORSConfig
 }
 
 func (c *RouteConfig) Setup() {
 	// Add request ID middleware (must be first)
-	c.App.Use(func(ctx *fiber.Ctx) error {
-		requestID := ctx.Get("X-Request-ID")
-		if requestID == "" {
-			ctx.Set("X-Request-ID", uuid.New().String())
-		}
-		return ctx.Next()
-	})
+	c.App.Use(middleware.RequestID())
+
+	// Answer CORS preflight requests before authentication sees them
+	c.App.Use(middleware.CORS(c.CORS))
 
-	// Apply logger middleware
-	c.App.Use(middleware.Logger(c.Log))
+	// Apply access log middleware
+	c.App.Use(middleware.Logger(c.AccessLog, c.Log))
 
-	// Initialize auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(c.DB)
-	authMiddleware.SetLogger(c.Log)
+	authMiddleware := c.AuthMiddleware
 
 	// Swagger documentation
 	c.App.Get("/swagger/*", swagger.HandlerDefault)
@@ -48,47 +47,80 @@ func (c *RouteConfig) Setup() {
 	api := c.App.Group("/api")
 	v1 := api.Group("/v1")
 
-	// Health check endpoint
-	v1.Get("/health", func(ctx *fiber.Ctx) error {
-		return response.JSONSuccess(ctx, map[string]string{"status": "ok"})
-	})
+	// Liveness stays a fast path; readiness pings the database
+	v1.Get("/health", c.HealthHandler.Live)
+	v1.Get("/health/ready", c.HealthHandler.Ready)
 
 	// Admin-only warehouse routes - all endpoints require authentication
 	warehouses := v1.Group("/warehouses")
 	
 	// Apply auth middleware to all warehouse routes
-	warehouses.Use(authMiddleware.RequireAuth())
+	warehouses.Use(authMiddleware.RequireAuth(), authMiddleware.RequireScope(auth.ScopeWarehouses))
 	
-	// Warehouse endpoints
+	// Warehouse endpoints; changing warehouses is restricted to admins
+	requireAdmin := authMiddleware.RequireRole(auth.RoleAdmin)
+
+	// Changing stock levels is restricted to admins and internal services holding the stock's scope
+	requireStockWriter := authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleService)
 	warehouses.Get("/", c.WarehouseHandler.ListWarehouses)
-	warehouses.Post("/", c.WarehouseHandler.CreateWarehouse)
+	warehouses.Post("/", requireAdmin, c.WarehouseHandler.CreateWarehouse)
+	warehouses.Get("/batch", c.WarehouseHandler.GetWarehousesByIDs)
 	warehouses.Get("/:id", c.WarehouseHandler.GetWarehouse)
-	warehouses.Put("/:id", c.WarehouseHandler.UpdateWarehouse)
-	warehouses.Delete("/:id", c.WarehouseHandler.DeleteWarehouse)
+	warehouses.Put("/:id", requireAdmin, c.WarehouseHandler.UpdateWarehouse)
+	warehouses.Delete("/:id", requireAdmin, c.WarehouseHandler.DeleteWarehouse)
+	warehouses.Post("/:id/restore", requireAdmin, c.WarehouseHandler.RestoreWarehouse)
 	
 	// Stock management endpoints for warehouses
 	warehouses.Get("/:warehouseId/stock", c.StockHandler.GetWarehouseStock)
-	warehouses.Post("/:warehouseId/stock", c.StockHandler.AddStock)
+	warehouses.Post("/:warehouseId/stock", requireStockWriter, c.StockHandler.AddStock)
+	warehouses.Put("/:warehouseId/stock/:productId/reorder-threshold", requireStockWriter, c.StockHandler.UpdateReorderThreshold)
 
 	// Inventory routes
 	inventory := v1.Group("/inventory")
 	
 	// Apply auth middleware to all inventory routes
-	inventory.Use(authMiddleware.RequireAuth())
+	inventory.Use(authMiddleware.RequireAuth(), authMiddleware.RequireScope(auth.ScopeInventory))
+	
+	// Reservation endpoints; reservations are made, cancelled and committed by internal services
+	// with an inventory-scoped API key, never by users, who could otherwise act on any order's reference
+	requireService := authMiddleware.RequireRole(auth.RoleService)
+	inventory.Post("/reserve", requireService, c.ReservationHandler.ReserveStock)
+	inventory.Post("/reserve/batch", requireService, c.ReservationHandler.ReserveStockBatch)
+	inventory.Post("/reserve/cancel", requireService, c.ReservationHandler.CancelReservation)
+	inventory.Post("/reserve/commit", requireService, c.ReservationHandler.CommitReservation)
 	
-	// Reservation endpoints
-	inventory.Post("/reserve", c.ReservationHandler.ReserveStock)
-	inventory.Post("/reserve/cancel", c.ReservationHandler.CancelReservation)
-	inventory.Post("/reserve/commit", c.ReservationHandler.CommitReservation)
+	// Moving a reservation between warehouses is an operations task, restricted to admins
+	inventory.Post("/reserve/relocate", requireAdmin, c.ReservationHandler.RelocateReservation)
 	
-	// Reservation history endpoint 
+	// Manual stock corrections are restricted to admins
+	inventory.Post("/adjust", requireAdmin, c.StockHandler.AdjustStock)
+	
+	// Reservation history and lookup by reference
 	inventory.Get("/warehouses/:warehouse_id/products/:product_id/reservations", 
 		c.ReservationHandler.GetReservationHistory)
+	inventory.Get("/warehouses/:warehouse_id/products/:product_id/reservations/:reference",
+		c.ReservationHandler.GetReservationByReference)
+	
+	// Every reservation made under a reference, such as an order, for cross-service reconciliation
+	inventory.Get("/references/:reference/reservations", c.ReservationHandler.GetReservationsByReference)
+	
+	// Diagnostic report of reservations held too long, restricted to admins
+	inventory.Get("/reservations/aged", requireAdmin, c.ReservationHandler.GetAgedReservations)
+	
+	// Product availability endpoint
+	inventory.Get("/warehouses/:warehouse_id/products/:product_id/availability",
+		c.StockHandler.GetProductAvailability)
+	
+	// Stock movement ledger of a product at a warehouse
+	inventory.Get("/warehouses/:warehouse_id/products/:product_id/movements", c.StockHandler.GetStockHistory)
+	
+	// Product stock across all warehouses
+	inventory.Get("/products/:product_id/stock", c.StockHandler.GetProductStockSummary)
 	
 	// Stock transfer endpoint (requires authentication)
 	stockGroup := v1.Group("/stock") 
-	stockGroup.Use(authMiddleware.RequireAuth())
-	stockGroup.Post("/transfer", c.StockHandler.TransferStock)
+	stockGroup.Use(authMiddleware.RequireAuth(), authMiddleware.RequireScope(auth.ScopeStock))
+	stockGroup.Post("/transfer", requireStockWriter, c.StockHandler.TransferStock)
 		
 	// 404 Handler
 	c.App.Use(func(ctx *fiber.Ctx) error {
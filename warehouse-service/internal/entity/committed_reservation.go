@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+)
+
+// CommittedReservation records that the reservation with a reference was committed for a product,
+// so a repeated commit of the same reference does not deduct the stock again
+type CommittedReservation struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	WarehouseID uint      `gorm:"column:warehouse_id;not null;uniqueIndex:uq_committed_reservations_reference"`
+	ProductID   uint      `gorm:"column:product_id;not null;uniqueIndex:uq_committed_reservations_reference"` // References external product service
+	Reference   string    `gorm:"column:reference;type:varchar(100);not null;uniqueIndex:uq_committed_reservations_reference"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime;index:idx_committed_reservations_created_at"`
+}
+
+func (cr *CommittedReservation) TableName() string {
+	return "committed_reservations"
+}
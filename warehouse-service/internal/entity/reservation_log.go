@@ -16,6 +16,9 @@ const (
 	
 	// ReservationStatusCancelled represents a cancelled reservation
 	ReservationStatusCancelled ReservationStatus = "cancelled"
+	
+	// ReservationStatusExpired represents a reservation released by the expiry sweeper
+	ReservationStatusExpired ReservationStatus = "expired"
 )
 
 // ReservationLog represents a log entry for stock reservations
@@ -24,7 +27,7 @@ type ReservationLog struct {
 	WarehouseID uint             `gorm:"column:warehouse_id;not null;index"`
 	ProductID   uint             `gorm:"column:product_id;not null;index"`
 	Quantity    int              `gorm:"column:quantity;not null"`
-	Status      string           `gorm:"column:status;type:enum('pending','committed','cancelled');default:pending;not null"`
+	Status      string           `gorm:"column:status;type:enum('pending','committed','cancelled','expired');default:pending;not null"`
 	Reference   string           `gorm:"column:reference;type:varchar(100)"`
 	CreatedAt   time.Time        `gorm:"column:created_at;autoCreateTime"`
 	
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// StockAdjustment is an audit record of a manual stock correction, such as one made after a physical count
+type StockAdjustment struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	WarehouseID uint      `gorm:"column:warehouse_id;not null;index:idx_stock_adjustments_warehouse_product"`
+	ProductID   uint      `gorm:"column:product_id;not null;index:idx_stock_adjustments_warehouse_product"` // References external product service
+	Delta       int       `gorm:"column:delta;not null"`
+	OldQuantity int       `gorm:"column:old_quantity;not null"`
+	NewQuantity int       `gorm:"column:new_quantity;not null"`
+	Reason      string    `gorm:"column:reason;type:varchar(255);not null"`
+	Actor       string    `gorm:"column:actor;type:varchar(100);not null"` // User ID of the operator who made the adjustment
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (sa *StockAdjustment) TableName() string {
+	return "stock_adjustments"
+}
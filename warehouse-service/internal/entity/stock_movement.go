@@ -12,15 +12,20 @@ const (
 	MovementTypeStockOut   MovementType = "stock_out"
 	MovementTypeTransferIn MovementType = "transfer_in"
 	MovementTypeTransferOut MovementType = "transfer_out"
+	MovementTypeReserve    MovementType = "reserve"
+	MovementTypeRelease    MovementType = "release"
+	MovementTypeCommit     MovementType = "commit"
+	MovementTypeAdjust     MovementType = "adjust"
 )
 
-// StockMovement represents a record of stock quantity changes
+// StockMovement represents a record of stock quantity changes.
+// Quantity is the number of units moved, except for adjustments, which store the signed correction.
 type StockMovement struct {
 	ID            uint         `gorm:"column:id;primaryKey;autoIncrement"`
 	WarehouseID   uint         `gorm:"column:warehouse_id;not null;index:idx_warehouse_product"`
 	ProductID     uint         `gorm:"column:product_id;not null;index:idx_warehouse_product"` // References external product service
 	ProductSKU    string       `gorm:"column:product_sku;type:varchar(100);not null"`         // Store SKU for reference
-	MovementType  MovementType `gorm:"column:movement_type;type:enum('stock_in','stock_out','transfer_in','transfer_out');not null"`
+	MovementType  MovementType `gorm:"column:movement_type;type:enum('stock_in','stock_out','transfer_in','transfer_out','reserve','release','commit','adjust');not null"`
 	Quantity      int          `gorm:"column:quantity;not null"`
 	ReferenceType string       `gorm:"column:reference_type;type:varchar(50)"`
 	ReferenceID   string       `gorm:"column:reference_id;type:varchar(100)"`
@@ -33,4 +38,26 @@ type StockMovement struct {
 
 func (sm *StockMovement) TableName() string {
 	return "stock_movements"
+}
+
+// QuantityChange returns how the movement changed the quantity on hand
+func (sm *StockMovement) QuantityChange() int {
+	switch sm.MovementType {
+	case MovementTypeStockOut, MovementTypeTransferOut, MovementTypeCommit:
+		return -sm.Quantity
+	case MovementTypeReserve, MovementTypeRelease:
+		return 0
+	}
+	return sm.Quantity
+}
+
+// ReservedChange returns how the movement changed the reserved quantity
+func (sm *StockMovement) ReservedChange() int {
+	switch sm.MovementType {
+	case MovementTypeReserve:
+		return sm.Quantity
+	case MovementTypeRelease, MovementTypeCommit:
+		return -sm.Quantity
+	}
+	return 0
 }
\ No newline at end of file
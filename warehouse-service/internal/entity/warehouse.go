@@ -2,9 +2,12 @@ package entity
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
-// Warehouse represents a warehouse entity
+// Warehouse represents a warehouse entity.
+// Deleting a warehouse only sets DeletedAt, so its stock and reservation history are kept.
 type Warehouse struct {
 	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
 	Name      string    `gorm:"column:name;type:varchar(255);not null"`
@@ -13,6 +16,7 @@ type Warehouse struct {
 	IsActive  bool      `gorm:"column:is_active;default:true;not null"`
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
 }
 
 func (w *Warehouse) TableName() string {
@@ -9,8 +9,10 @@ type WarehouseStock struct {
 	ID               uint      `gorm:"column:id;primaryKey;autoIncrement"`
 	WarehouseID      uint      `gorm:"column:warehouse_id;not null;index:idx_warehouse_product,unique"`
 	ProductID        uint      `gorm:"column:product_id;not null;index:idx_warehouse_product,unique"`
-	Quantity         int       `gorm:"column:quantity;default:0;not null"`
-	ReservedQuantity int       `gorm:"column:reserved_quantity;default:0;not null"`
+	Quantity         int       `gorm:"column:quantity;default:0;not null;check:chk_warehouse_stock_quantity,quantity >= 0"`
+	ReservedQuantity int       `gorm:"column:reserved_quantity;default:0;not null;check:chk_warehouse_stock_reserved_quantity,reserved_quantity >= 0"`
+	ReorderThreshold int       `gorm:"column:reorder_threshold;default:0;not null"`
+	Version          uint      `gorm:"column:version;default:0;not null"`
 	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime"`
 	
 	// Virtual field (not stored in database)
@@ -30,4 +32,13 @@ func (ws *WarehouseStock) CalculateAvailableQuantity() {
 	if ws.AvailableQuantity < 0 {
 		ws.AvailableQuantity = 0
 	}
+}
+
+// FellBelowReorderThreshold reports whether deducting the given quantity took Quantity from at or above
+// the reorder threshold to below it. A threshold of zero disables the check.
+func (ws *WarehouseStock) FellBelowReorderThreshold(deducted int) bool {
+	if ws.ReorderThreshold <= 0 || deducted <= 0 {
+		return false
+	}
+	return ws.Quantity < ws.ReorderThreshold && ws.Quantity+deducted >= ws.ReorderThreshold
 }
\ No newline at end of file
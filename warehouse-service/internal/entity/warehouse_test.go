@@ -53,4 +53,48 @@ func TestTransferStatus_Values(t *testing.T) {
 	assert.Equal(t, TransferStatus("pending"), StatusPending)
 	assert.Equal(t, TransferStatus("completed"), StatusCompleted)
 	assert.Equal(t, TransferStatus("failed"), StatusFailed)
+}
+
+func TestWarehouseStock_FellBelowReorderThreshold(t *testing.T) {
+	// Crossing from at the threshold to below it
+	stock := &WarehouseStock{Quantity: 7, ReorderThreshold: 10}
+	assert.True(t, stock.FellBelowReorderThreshold(3))
+	
+	// Still at the threshold
+	stock = &WarehouseStock{Quantity: 10, ReorderThreshold: 10}
+	assert.False(t, stock.FellBelowReorderThreshold(5))
+	
+	// Already below the threshold before the deduction
+	stock = &WarehouseStock{Quantity: 5, ReorderThreshold: 10}
+	assert.False(t, stock.FellBelowReorderThreshold(2))
+	
+	// Threshold disabled
+	stock = &WarehouseStock{Quantity: 0, ReorderThreshold: 0}
+	assert.False(t, stock.FellBelowReorderThreshold(5))
+}
+func TestStockMovement_Changes(t *testing.T) {
+	tests := []struct {
+		movementType    MovementType
+		quantity        int
+		expectedOnHand  int
+		expectedReserve int
+	}{
+		{MovementTypeStockIn, 5, 5, 0},
+		{MovementTypeTransferIn, 5, 5, 0},
+		{MovementTypeStockOut, 5, -5, 0},
+		{MovementTypeTransferOut, 5, -5, 0},
+		{MovementTypeReserve, 5, 0, 5},
+		{MovementTypeRelease, 5, 0, -5},
+		{MovementTypeCommit, 5, -5, -5},
+		{MovementTypeAdjust, -3, -3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.movementType), func(t *testing.T) {
+			movement := StockMovement{MovementType: tt.movementType, Quantity: tt.quantity}
+
+			assert.Equal(t, tt.expectedOnHand, movement.QuantityChange())
+			assert.Equal(t, tt.expectedReserve, movement.ReservedChange())
+		})
+	}
 }
\ No newline at end of file
@@ -109,6 +109,34 @@ var (
 		http.StatusUnprocessableEntity,
 		nil,
 	)
+
+	ErrStockConflict = NewAppError(
+		"STOCK_CONFLICT",
+		"Stock was modified concurrently, please retry",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrInsufficientStock = NewAppError(
+		"INSUFFICIENT_STOCK",
+		"Insufficient stock for this operation",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrWarehouseNotEmpty = NewAppError(
+		"WAREHOUSE_NOT_EMPTY",
+		"Warehouse still holds stock; move it out or delete with force",
+		http.StatusConflict,
+		nil,
+	)
+
+	ErrServiceUnavailable = NewAppError(
+		"SERVICE_UNAVAILABLE",
+		"One or more dependencies are unavailable",
+		http.StatusServiceUnavailable,
+		nil,
+	)
 )
 
 // WithError wraps the original error with AppError
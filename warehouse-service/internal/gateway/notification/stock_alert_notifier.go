@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"context"
+)
+
+// StockAlertNotifier is told when a product's stock at a warehouse falls below its reorder threshold
+type StockAlertNotifier interface {
+	NotifyLowStock(ctx context.Context, warehouseID, productID uint, remainingQuantity int) error
+}
+
+// NoopStockAlertNotifier discards low-stock alerts until a real channel such as email or Slack is wired in
+type NoopStockAlertNotifier struct{}
+
+// NewNoopStockAlertNotifier creates a notifier that does nothing
+func NewNoopStockAlertNotifier() *NoopStockAlertNotifier {
+	return &NoopStockAlertNotifier{}
+}
+
+// NotifyLowStock does nothing
+func (n *NoopStockAlertNotifier) NotifyLowStock(ctx context.Context, warehouseID, productID uint, remainingQuantity int) error {
+	return nil
+}
@@ -1,16 +1,47 @@
 package product
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
+	appContext "warehouse-service/internal/context"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// requestIDHeader carries the request ID to the product service so logs can be correlated across services
+const requestIDHeader = "X-Request-ID"
+
+// errProductNotFound is returned by getProduct when the product service answers 404
+var errProductNotFound = errors.New("product not found")
+
+// DefaultTimeout bounds a single product service request when product.timeout is not configured
+const DefaultTimeout = 5 * time.Second
+
+// TimeoutError is returned when the product service did not answer in time, either within the client timeout
+// or before the deadline of the caller's context. A cancelled context is not a timeout.
+type TimeoutError struct {
+	Timeout time.Duration // Client timeout of each request
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("product service did not respond within %s: %v", e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
 // ProductInfo represents product information from the external product service
 type ProductInfo struct {
 	ID          uint   `json:"id"`
@@ -23,18 +54,69 @@ type ProductInfo struct {
 type ProductClientInterface interface {
 	GetProductByID(ctx context.Context, productID uint) (*ProductInfo, error)
 	GetProductBySKU(ctx context.Context, sku string) (*ProductInfo, error)
+	GetProductsByIDs(ctx context.Context, productIDs []uint) (map[uint]*ProductInfo, error)
 	ValidateProduct(ctx context.Context, productID uint) (bool, error)
 }
 
+// batchProductInfo is a product as returned by the product service batch lookup, which reports IDs as strings
+type batchProductInfo struct {
+	ID          string `json:"id"`
+	SKU         string `json:"sku"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// batchProductsResponse is the envelope of the product service batch lookup
+type batchProductsResponse struct {
+	Data struct {
+		Products   []batchProductInfo `json:"products"`
+		MissingIDs []string           `json:"missing_ids"`
+	} `json:"data"`
+}
+
+// RetryPolicy controls how often a failed product service request is retried.
+// Only network errors, 429 and 5xx responses are retried; a missing product is never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first; 1 disables retries
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles for every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between two attempts
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy makes up to three attempts, waiting around 100ms and then 200ms in between
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+	}
+}
+
+// backoff returns the wait before the given retry, counting from 1.
+// The exponential delay is jittered between half and all of its value so clients that failed together do not retry together.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
 // ProductClient implements ProductClientInterface for the external product service
 type ProductClient struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	Retry      RetryPolicy
 	Log        *logrus.Logger
 }
 
-// NewProductClient creates a new ProductClient with the given configuration
-func NewProductClient(log *logrus.Logger) *ProductClient {
+// NewProductClient creates a new ProductClient with the given configuration.
+// Every request is bounded by timeout on top of the deadline of its context, so a slow product service
+// cannot hold a call open for longer than that.
+func NewProductClient(log *logrus.Logger, timeout time.Duration, retry RetryPolicy) *ProductClient {
 	baseURL := os.Getenv("PRODUCT_SERVICE_URL")
 	if baseURL == "" {
 		baseURL = "http://product-service:8080/api/v1" // Default URL
@@ -43,78 +125,196 @@ func NewProductClient(log *logrus.Logger) *ProductClient {
 	return &ProductClient{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: 5 * time.Second,
+			Timeout: timeout,
 		},
-		Log: log,
+		Retry: retry,
+		Log:   log,
 	}
 }
 
 // GetProductByID fetches product information by ID from the product service
 func (c *ProductClient) GetProductByID(ctx context.Context, productID uint) (*ProductInfo, error) {
 	url := fmt.Sprintf("%s/products/%d", c.BaseURL, productID)
-	
+
+	product, err := c.getProduct(ctx, url)
+	if errors.Is(err, errProductNotFound) {
+		return nil, fmt.Errorf("product with ID %d not found", productID)
+	}
+	return product, err
+}
+
+// GetProductBySKU fetches product information by SKU from the product service
+func (c *ProductClient) GetProductBySKU(ctx context.Context, sku string) (*ProductInfo, error) {
+	url := fmt.Sprintf("%s/products/sku/%s", c.BaseURL, sku)
+
+	product, err := c.getProduct(ctx, url)
+	if errors.Is(err, errProductNotFound) {
+		return nil, fmt.Errorf("product with SKU %s not found", sku)
+	}
+	return product, err
+}
+
+// getProduct fetches a product, retrying transient failures according to the retry policy
+func (c *ProductClient) getProduct(ctx context.Context, url string) (*ProductInfo, error) {
+	var product *ProductInfo
+	err := c.withRetry(ctx, func() (bool, error) {
+		var retryable bool
+		var err error
+		product, retryable, err = c.fetchProduct(ctx, url)
+		return retryable, err
+	})
+	return product, err
+}
+
+// withRetry runs attempt until it succeeds or fails with an error that is not worth retrying.
+// Retrying is safe because every product service call made here only reads. It gives up early when the
+// context is cancelled or its deadline would pass before the next attempt.
+func (c *ProductClient) withRetry(ctx context.Context, attempt func() (bool, error)) error {
+	attempts := 1
+	for {
+		retryable, err := attempt()
+		if err == nil || !retryable {
+			return err
+		}
+
+		if attempts >= c.Retry.MaxAttempts {
+			c.Log.WithError(err).WithField("attempts", attempts).Error("Failed to fetch product from product service")
+			return err
+		}
+
+		delay := c.Retry.backoff(attempts)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			c.Log.WithError(err).WithField("attempts", attempts).Error("Failed to fetch product from product service, no time left to retry")
+			return err
+		}
+
+		c.Log.WithError(err).WithFields(logrus.Fields{
+			"attempt":     attempts,
+			"retry_delay": delay.String(),
+		}).Warn("Product service request failed, retrying")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		attempts++
+	}
+}
+
+// fetchProduct makes a single request for a product and reports whether a failure is worth retrying
+func (c *ProductClient) fetchProduct(ctx context.Context, url string) (*ProductInfo, bool, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		c.Log.WithError(err).Error("Failed to create request for product service")
-		return nil, err
+		return nil, false, err
 	}
-	
+	setRequestID(req)
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		c.Log.WithError(err).Error("Failed to fetch product from product service")
-		return nil, err
+		// A cancelled or expired context fails every further attempt too
+		return nil, ctx.Err() == nil, c.requestError(err)
 	}
 	defer resp.Body.Close()
-	
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("product with ID %d not found", productID)
-	}
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code from product service: %d", resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, errProductNotFound
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, true, fmt.Errorf("unexpected status code from product service: %d", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, false, fmt.Errorf("unexpected status code from product service: %d", resp.StatusCode)
 	}
-	
+
 	var product ProductInfo
 	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
 		c.Log.WithError(err).Error("Failed to decode product response")
+		return nil, false, c.requestError(err)
+	}
+
+	return &product, false, nil
+}
+
+// GetProductsByIDs fetches several products with a single call to the product service batch lookup.
+// Products the product service does not know are absent from the returned map.
+func (c *ProductClient) GetProductsByIDs(ctx context.Context, productIDs []uint) (map[uint]*ProductInfo, error) {
+	products := make(map[uint]*ProductInfo, len(productIDs))
+	if len(productIDs) == 0 {
+		return products, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, productID := range productIDs {
+		ids[i] = strconv.FormatUint(uint64(productID), 10)
+	}
+	body, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
 		return nil, err
 	}
-	
-	return &product, nil
+
+	var response *batchProductsResponse
+	url := fmt.Sprintf("%s/products/batch-get", c.BaseURL)
+	err = c.withRetry(ctx, func() (bool, error) {
+		var retryable bool
+		var err error
+		response, retryable, err = c.fetchProducts(ctx, url, body)
+		return retryable, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range response.Data.Products {
+		productID, err := strconv.ParseUint(product.ID, 10, 32)
+		if err != nil {
+			c.Log.WithField("product_id", product.ID).Warn("Skipping product with unexpected ID from product service")
+			continue
+		}
+		products[uint(productID)] = &ProductInfo{
+			ID:          uint(productID),
+			SKU:         product.SKU,
+			Name:        product.Name,
+			Description: product.Description,
+		}
+	}
+
+	return products, nil
 }
 
-// GetProductBySKU fetches product information by SKU from the product service
-func (c *ProductClient) GetProductBySKU(ctx context.Context, sku string) (*ProductInfo, error) {
-	url := fmt.Sprintf("%s/products/sku/%s", c.BaseURL, sku)
-	
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// fetchProducts makes a single batch lookup request and reports whether a failure is worth retrying
+func (c *ProductClient) fetchProducts(ctx context.Context, url string, body []byte) (*batchProductsResponse, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		c.Log.WithError(err).Error("Failed to create request for product service")
-		return nil, err
+		return nil, false, err
 	}
-	
+	req.Header.Set("Content-Type", "application/json")
+	setRequestID(req)
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		c.Log.WithError(err).Error("Failed to fetch product from product service")
-		return nil, err
+		// A cancelled or expired context fails every further attempt too
+		return nil, ctx.Err() == nil, c.requestError(err)
 	}
 	defer resp.Body.Close()
-	
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("product with SKU %s not found", sku)
-	}
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code from product service: %d", resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, true, fmt.Errorf("unexpected status code from product service: %d", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, false, fmt.Errorf("unexpected status code from product service: %d", resp.StatusCode)
 	}
-	
-	var product ProductInfo
-	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
-		c.Log.WithError(err).Error("Failed to decode product response")
-		return nil, err
+
+	var response batchProductsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		c.Log.WithError(err).Error("Failed to decode product batch response")
+		return nil, false, c.requestError(err)
 	}
-	
-	return &product, nil
+
+	return &response, false, nil
 }
 
 // ValidateProduct checks if a product exists and is valid
@@ -125,4 +325,23 @@ func (c *ProductClient) ValidateProduct(ctx context.Context, productID uint) (bo
 	}
 	
 	return product != nil, nil
-}
\ No newline at end of file
+}
+
+// requestError wraps err in a TimeoutError when the request ran out of time
+func (c *ProductClient) requestError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{Timeout: c.HTTPClient.Timeout, Err: err}
+	}
+	return err
+}
+
+// setRequestID forwards the request ID carried by the request's context.
+// Calls that did not start from an HTTP request, such as background jobs, have none, so one is generated for them.
+func setRequestID(req *http.Request) {
+	requestID := appContext.GetRequestID(req.Context())
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	req.Header.Set(requestIDHeader, requestID)
+}
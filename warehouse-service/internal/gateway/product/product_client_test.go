@@ -0,0 +1,217 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	appContext "warehouse-service/internal/context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProductClient(baseURL string) *ProductClient {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client := NewProductClient(logger, time.Second, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestProductClient_ForwardsRequestID(t *testing.T) {
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		_ = json.NewEncoder(w).Encode(ProductInfo{ID: 1, SKU: "SKU-1", Name: "Product 1"})
+	}))
+	defer server.Close()
+
+	client := newTestProductClient(server.URL)
+
+	_, err := client.GetProductByID(appContext.WithRequestID(context.Background(), "req-123"), 1)
+	require.NoError(t, err)
+	_, err = client.GetProductBySKU(appContext.WithRequestID(context.Background(), "req-456"), "SKU-1")
+	require.NoError(t, err)
+
+	// Without a request ID in the context one is generated
+	_, err = client.GetProductByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.Len(t, requestIDs, 3)
+	assert.Equal(t, "req-123", requestIDs[0])
+	assert.Equal(t, "req-456", requestIDs[1])
+	assert.NotEmpty(t, requestIDs[2])
+}
+
+func TestProductClient_RetriesTransientFailure(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ProductInfo{ID: 1, SKU: "SKU-1", Name: "Product 1"})
+	}))
+	defer server.Close()
+
+	product, err := newTestProductClient(server.URL).GetProductByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Product 1", product.Name)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestProductClient_StopsRetryingAtDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestProductClient(server.URL)
+	client.Retry = RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetProductByID(ctx, 1)
+	assert.Error(t, err)
+	// The next retry would start after the deadline, so the client gives up instead of waiting for it
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestProductClient_DoesNotRetryNotFound(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestProductClient(server.URL).GetProductByID(context.Background(), 42)
+	assert.EqualError(t, err, "product with ID 42 not found")
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestProductClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newTestProductClient(server.URL).GetProductByID(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestProductClient_AbortsAtTimeout(t *testing.T) {
+	// The product service answers long after the client timeout
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		// Reading the body lets the server notice when the client gives up
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := newTestProductClient(server.URL)
+	client.HTTPClient.Timeout = 50 * time.Millisecond
+	client.Retry = RetryPolicy{MaxAttempts: 1}
+
+	t.Run("ClientTimeout", func(t *testing.T) {
+		start := time.Now()
+		product, err := client.GetProductByID(context.Background(), 1)
+
+		assert.Nil(t, product)
+		var timeoutErr *TimeoutError
+		if assert.ErrorAs(t, err, &timeoutErr) {
+			assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+		}
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("ContextDeadline", func(t *testing.T) {
+		// A caller deadline shorter than the client timeout wins, and is not retried
+		client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+		attempts.Store(0)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		products, err := client.GetProductsByIDs(ctx, []uint{1, 2})
+
+		assert.Nil(t, products)
+		var timeoutErr *TimeoutError
+		assert.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("CancelledContextIsNotATimeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.GetProductByID(ctx, 1)
+
+		var timeoutErr *TimeoutError
+		assert.Error(t, err)
+		assert.False(t, errors.As(err, &timeoutErr))
+	})
+}
+
+func TestProductClient_GetProductsByIDs(t *testing.T) {
+	var attempts atomic.Int32
+	var request struct {
+		IDs []string `json:"ids"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt fails so the request body must be sent again on retry
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/products/batch-get", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write([]byte(`{"success":true,"data":{"products":[{"id":"1","sku":"SKU-1","name":"Product 1"},{"id":"f47ac10b-58cc-4372-a567-0e02b2c3d479","name":"Other"}],"missing_ids":["2"]}}`))
+	}))
+	defer server.Close()
+
+	products, err := newTestProductClient(server.URL).GetProductsByIDs(context.Background(), []uint{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, request.IDs)
+	assert.Equal(t, int32(2), attempts.Load())
+	// Missing products and IDs that are not numeric are left out
+	require.Len(t, products, 1)
+	assert.Equal(t, "Product 1", products[1].Name)
+	assert.Equal(t, "SKU-1", products[1].SKU)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	for i := 0; i < 20; i++ {
+		first := policy.backoff(1)
+		assert.GreaterOrEqual(t, first, 50*time.Millisecond)
+		assert.LessOrEqual(t, first, 100*time.Millisecond)
+
+		// 400ms is capped at the 300ms max delay
+		third := policy.backoff(3)
+		assert.GreaterOrEqual(t, third, 150*time.Millisecond)
+		assert.LessOrEqual(t, third, 300*time.Millisecond)
+	}
+}
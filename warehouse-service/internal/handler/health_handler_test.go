@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"warehouse-service/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupHealthHandlerTest(t *testing.T) (sqlmock.Sqlmock, *fiber.App) {
+	mockDb, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDb.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	healthHandler := NewHealthHandler(db, logger)
+
+	app := fiber.New()
+	app.Get("/api/v1/health", healthHandler.Live)
+	app.Get("/api/v1/health/ready", healthHandler.Ready)
+
+	return mock, app
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	mock, app := setupHealthHandlerTest(t)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data model.HealthResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, model.HealthStatusOK, body.Data.Status)
+
+	// Liveness never pings the database
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name               string
+		pingErr            error
+		expectedStatusCode int
+		expectedStatus     string
+		expectedDatabase   string
+	}{
+		{
+			name:               "database up",
+			expectedStatusCode: http.StatusOK,
+			expectedStatus:     model.HealthStatusReady,
+			expectedDatabase:   model.HealthStatusUp,
+		},
+		{
+			name:               "database down",
+			pingErr:            errors.New("connection refused"),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     model.HealthStatusNotReady,
+			expectedDatabase:   model.HealthStatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, app := setupHealthHandlerTest(t)
+			mock.ExpectPing().WillReturnError(tt.pingErr)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health/ready", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+
+			var body struct {
+				Data model.HealthResponse `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expectedStatus, body.Data.Status)
+			assert.Equal(t, tt.expectedDatabase, body.Data.Dependencies["database"].Status)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
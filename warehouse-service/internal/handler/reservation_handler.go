@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"strconv"
+	"time"
 	"warehouse-service/internal/context"
 	"warehouse-service/internal/delivery/http/response"
 	appErrors "warehouse-service/internal/errors"
@@ -27,7 +28,7 @@ func NewReservationHandler(useCase usecase.ReservationUseCaseInterface, logger *
 
 // ReserveStock godoc
 // @Summary Reserve inventory stock
-// @Description Reserves stock for a product in a warehouse using database-level locking
+// @Description Reserves stock for a product in a warehouse using optimistic locking; returns 409 if the stock keeps changing concurrently. The quantity must be positive and at most the configured maximum per reservation. An optional reference identifies the reservation for later commits and cancellations; one is generated when it is omitted
 // @Tags Inventory
 // @Accept json
 // @Produce json
@@ -91,9 +92,85 @@ func (h *ReservationHandler) ReserveStock(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, reservationResponse)
 }
 
+// ReserveStockBatch godoc
+// @Summary Reserve inventory stock for several items
+// @Description Reserves stock for all items in a single transaction. If any item lacks stock, nothing is reserved and the per-item results show which items failed
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param reservation body model.ReserveStockBatchRequest true "Items to reserve"
+// @Success 200 {object} model.ReserveStockBatchResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} model.ReserveStockBatchResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/reserve/batch [post]
+func (h *ReservationHandler) ReserveStockBatch(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse request body
+	request := new(model.ReserveStockBatchRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), h.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to reserve all items
+	batchResponse, err := h.UseCase.ReserveStockBatch(timeoutCtx, request.Items)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"items":      len(request.Items),
+			"error":      err.Error(),
+		}).Warn("Failed to reserve stock batch")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, h.Log)
+		}
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
+		}
+
+		if err == fiber.ErrNotFound || errors.Is(err, appErrors.ErrResourceNotFound) {
+			return response.JSONError(ctx, appErrors.ErrResourceNotFound, h.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	// Nothing was reserved; return the per-item results so the caller can see which items lacked stock
+	if !batchResponse.Reserved {
+		return ctx.Status(appErrors.ErrBusinessRuleViolation.StatusCode).JSON(response.Response{
+			Success: false,
+			Data:    batchResponse,
+			Error: &response.ErrorInfo{
+				Code:    appErrors.ErrBusinessRuleViolation.Code,
+				Message: "Insufficient stock for one or more items",
+			},
+		})
+	}
+
+	return response.JSONSuccess(ctx, batchResponse)
+}
+
 // CancelReservation godoc
 // @Summary Cancel a stock reservation
-// @Description Cancels a previously made stock reservation
+// @Description Cancels the active stock reservation made under the reference. An unknown or already resolved reference returns 404, and a quantity above the reserved quantity returns 422
 // @Tags Inventory
 // @Accept json
 // @Produce json
@@ -103,6 +180,8 @@ func (h *ReservationHandler) ReserveStock(ctx *fiber.Ctx) error {
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /inventory/reserve/cancel [post]
@@ -159,7 +238,7 @@ func (h *ReservationHandler) CancelReservation(ctx *fiber.Ctx) error {
 
 // CommitReservation godoc
 // @Summary Commit a stock reservation
-// @Description Commits a previously made stock reservation, reducing actual stock
+// @Description Commits the active stock reservation made under the reference, reducing actual stock. Repeating a commit with the same reference succeeds without reducing stock again. A first commit of an unknown reference returns 404
 // @Tags Inventory
 // @Accept json
 // @Produce json
@@ -169,6 +248,8 @@ func (h *ReservationHandler) CancelReservation(ctx *fiber.Ctx) error {
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /inventory/reserve/commit [post]
@@ -223,6 +304,72 @@ func (h *ReservationHandler) CommitReservation(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, map[string]string{"message": "Reservation committed successfully"})
 }
 
+// RelocateReservation godoc
+// @Summary Move a stock reservation to another warehouse
+// @Description Moves the whole active reservation of a product from one warehouse to another, keeping its reference. The destination reserves the stock and the source releases it in one transaction; if the destination lacks stock nothing changes
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param relocation body model.RelocateReservationRequest true "Relocation details"
+// @Success 200 {object} model.RelocateReservationResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/reserve/relocate [post]
+func (h *ReservationHandler) RelocateReservation(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse request body
+	request := new(model.RelocateReservationRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), h.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to relocate the reservation
+	relocationResponse, err := h.UseCase.RelocateReservation(timeoutCtx, request.Reference,
+		request.FromWarehouseID, request.ToWarehouseID, request.ProductID, request.Quantity)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id":        requestID,
+			"from_warehouse_id": request.FromWarehouseID,
+			"to_warehouse_id":   request.ToWarehouseID,
+			"product_id":        request.ProductID,
+			"quantity":          request.Quantity,
+			"reference":         request.Reference,
+			"error":             err.Error(),
+		}).Warn("Failed to relocate reservation")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, h.Log)
+		}
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	return response.JSONSuccess(ctx, relocationResponse)
+}
+
 // GetReservationHistory godoc
 // @Summary Get reservation history
 // @Description Returns the reservation history for a product in a warehouse
@@ -231,7 +378,10 @@ func (h *ReservationHandler) CommitReservation(ctx *fiber.Ctx) error {
 // @Param warehouse_id path int true "Warehouse ID"
 // @Param product_id path int true "Product ID"
 // @Param page query int false "Page number (defaults to 1)"
-// @Param limit query int false "Items per page (defaults to 20, max 100)"
+// @Param limit query int false "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)"
+// @Param status query string false "Only return logs with this status" Enums(reserved, committed, cancelled, expired)
+// @Param from query string false "Only return logs created at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Only return logs created before this time (RFC3339), or on or before this day (YYYY-MM-DD)"
 // @Success 200 {object} model.ReservationHistoryResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
@@ -269,7 +419,7 @@ func (h *ReservationHandler) GetReservationHistory(ctx *fiber.Ctx) error {
 
 	// Parse pagination parameters
 	page := 1
-	limit := 20
+	limit := 0 // The use case applies the configured default and clamps to the maximum
 
 	// Parse page parameter
 	if pageStr := ctx.Query("page"); pageStr != "" {
@@ -288,23 +438,54 @@ func (h *ReservationHandler) GetReservationHistory(ctx *fiber.Ctx) error {
 	// Parse limit parameter
 	if limitStr := ctx.Query("limit"); limitStr != "" {
 		limitNum, err := strconv.Atoi(limitStr)
-		if err != nil || limitNum < 1 || limitNum > 100 {
+		if err != nil || limitNum < 1 {
 			h.Log.WithFields(logrus.Fields{
 				"request_id": requestID,
 				"limit":      limitStr,
 				"error":      "Invalid limit parameter",
 			}).Warn("Invalid limit parameter")
-			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter (1-100)"), h.Log)
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter, must be a positive number"), h.Log)
 		}
 		limit = limitNum
 	}
 
+	// Parse filter parameters; the status is validated by the use case
+	filter := &model.ReservationHistoryFilter{
+		Status: model.ReservationStatus(ctx.Query("status")),
+	}
+
+	if fromStr := ctx.Query("from"); fromStr != "" {
+		from, err := parseHistoryTime(fromStr, false)
+		if err != nil {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"from":       fromStr,
+				"error":      err.Error(),
+			}).Warn("Invalid from parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid from parameter (RFC3339 or YYYY-MM-DD)"), h.Log)
+		}
+		filter.From = &from
+	}
+
+	if toStr := ctx.Query("to"); toStr != "" {
+		to, err := parseHistoryTime(toStr, true)
+		if err != nil {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"to":         toStr,
+				"error":      err.Error(),
+			}).Warn("Invalid to parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid to parameter (RFC3339 or YYYY-MM-DD)"), h.Log)
+		}
+		filter.To = &to
+	}
+
 	// Add timeout to context
 	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
 	defer cancel()
 
 	// Call the use case to get reservation history
-	history, err := h.UseCase.GetReservationHistory(timeoutCtx, uint(warehouseID), uint(productID), page, limit)
+	history, err := h.UseCase.GetReservationHistory(timeoutCtx, uint(warehouseID), uint(productID), filter, page, limit)
 	if err != nil {
 		h.Log.WithFields(logrus.Fields{
 			"request_id":   requestID,
@@ -312,6 +493,7 @@ func (h *ReservationHandler) GetReservationHistory(ctx *fiber.Ctx) error {
 			"product_id":   productID,
 			"page":         page,
 			"limit":        limit,
+			"status":       filter.Status,
 			"error":        err.Error(),
 		}).Warn("Failed to get reservation history")
 
@@ -321,8 +503,245 @@ func (h *ReservationHandler) GetReservationHistory(ctx *fiber.Ctx) error {
 			return response.JSONError(ctx, appErr, h.Log)
 		}
 
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput,
+				"Invalid status (reserved, committed, cancelled, expired) or date range"), h.Log)
+		}
+
 		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
 	}
 
 	return response.JSONSuccess(ctx, history)
+}
+
+// GetReservationByReference godoc
+// @Summary Get a reservation by reference
+// @Description Returns the reserved quantity, status and expiry of an active reservation. Committed, cancelled and expired reservations are not found.
+// @Tags Inventory
+// @Produce json
+// @Param warehouse_id path int true "Warehouse ID"
+// @Param product_id path int true "Product ID"
+// @Param reference path string true "Reservation reference"
+// @Success 200 {object} model.ReservationDetailResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/warehouses/{warehouse_id}/products/{product_id}/reservations/{reference} [get]
+func (h *ReservationHandler) GetReservationByReference(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Get path parameters
+	warehouseIDParam := ctx.Params("warehouse_id")
+	warehouseID, err := strconv.ParseUint(warehouseIDParam, 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseIDParam,
+			"error":        err.Error(),
+		}).Warn("Invalid warehouse ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
+	}
+
+	productIDParam := ctx.Params("product_id")
+	productID, err := strconv.ParseUint(productIDParam, 10, 32)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": productIDParam,
+			"error":      err.Error(),
+		}).Warn("Invalid product ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
+	}
+
+	reference := ctx.Params("reference")
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	reservation, err := h.UseCase.GetReservationByReference(timeoutCtx, uint(warehouseID), uint(productID), reference)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+			"reference":    reference,
+			"error":        err.Error(),
+		}).Warn("Failed to get reservation")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, h.Log)
+		}
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "reference is required"), h.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	return response.JSONSuccess(ctx, reservation)
+}
+
+// GetReservationsByReference godoc
+// @Summary List reservations by reference
+// @Description Lists every reservation logged with the reference across warehouses, products and statuses, oldest first, for reconciliation with the service that made them. A reference without reservations returns an empty list.
+// @Tags Inventory
+// @Produce json
+// @Param reference path string true "Reservation reference, such as an order ID"
+// @Success 200 {object} model.ReservationsByReferenceResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/references/{reference}/reservations [get]
+func (h *ReservationHandler) GetReservationsByReference(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	reference := ctx.Params("reference")
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	reservations, err := h.UseCase.GetReservationsByReference(timeoutCtx, reference)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"reference":  reference,
+			"error":      err.Error(),
+		}).Warn("Failed to list reservations by reference")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, h.Log)
+		}
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "reference is required"), h.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	return response.JSONSuccess(ctx, reservations)
+}
+
+// GetAgedReservations godoc
+// @Summary Report aged reservations
+// @Description Lists active reservations held for longer than older_than without any commit, cancellation, expiry or other activity since, with totals per warehouse. Read-only diagnostics for stock still held for released orders; admins only.
+// @Tags Inventory
+// @Produce json
+// @Param older_than query string false "Minimum reservation age as a Go duration such as 2h or 90m (defaults to the reservation TTL)"
+// @Param page query int false "Page number (defaults to 1)"
+// @Param limit query int false "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)"
+// @Success 200 {object} model.AgedReservationReportResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/reservations/aged [get]
+func (h *ReservationHandler) GetAgedReservations(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse the age threshold; zero leaves the default to the use case
+	var olderThan time.Duration
+	if olderThanStr := ctx.Query("older_than"); olderThanStr != "" {
+		parsed, err := time.ParseDuration(olderThanStr)
+		if err != nil || parsed <= 0 {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"older_than": olderThanStr,
+			}).Warn("Invalid older_than parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid older_than parameter (positive duration such as 2h)"), h.Log)
+		}
+		olderThan = parsed
+	}
+
+	// Parse pagination parameters
+	page := 1
+	limit := 0 // The use case applies the configured default and clamps to the maximum
+
+	if pageStr := ctx.Query("page"); pageStr != "" {
+		pageNum, err := strconv.Atoi(pageStr)
+		if err != nil || pageNum < 1 {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"page":       pageStr,
+			}).Warn("Invalid page parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid page parameter"), h.Log)
+		}
+		page = pageNum
+	}
+
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limitNum, err := strconv.Atoi(limitStr)
+		if err != nil || limitNum < 1 {
+			h.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"limit":      limitStr,
+			}).Warn("Invalid limit parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter, must be a positive number"), h.Log)
+		}
+		limit = limitNum
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	report, err := h.UseCase.FindReservationsOlderThan(timeoutCtx, olderThan, page, limit)
+	if err != nil {
+		h.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"older_than": olderThan.String(),
+			"page":       page,
+			"limit":      limit,
+			"error":      err.Error(),
+		}).Warn("Failed to report aged reservations")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, h.Log)
+		}
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, h.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), h.Log)
+	}
+
+	return response.JSONSuccess(ctx, report)
+}
+
+// parseHistoryTime parses an RFC3339 timestamp or a YYYY-MM-DD date. A date used as the
+// exclusive end of a range is moved to the start of the next day so the whole day is included.
+func parseHistoryTime(value string, endOfRange bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfRange {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
 }
\ No newline at end of file
@@ -33,7 +33,7 @@ func NewStockHandler(useCase usecase.StockUseCaseInterface, logger *logrus.Logge
 // @Param warehouseId path string true "Warehouse ID"
 // @Param productId query string false "Product ID filter"
 // @Param page query int false "Page number (defaults to 1)"
-// @Param limit query int false "Items per page (defaults to 20, max 100)"
+// @Param limit query int false "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)"
 // @Success 200 {object} model.WarehouseStockListResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
@@ -76,7 +76,7 @@ func (c *StockHandler) GetWarehouseStock(ctx *fiber.Ctx) error {
 
 	// Parse pagination parameters
 	page := 1
-	limit := 20
+	limit := 0 // The use case applies the configured default and clamps to the maximum
 
 	// Parse page parameter
 	if pageStr := ctx.Query("page"); pageStr != "" {
@@ -95,13 +95,13 @@ func (c *StockHandler) GetWarehouseStock(ctx *fiber.Ctx) error {
 	// Parse limit parameter
 	if limitStr := ctx.Query("limit"); limitStr != "" {
 		limitVal, err := strconv.Atoi(limitStr)
-		if err != nil || limitVal < 1 || limitVal > 100 {
+		if err != nil || limitVal < 1 {
 			c.Log.WithFields(logrus.Fields{
 				"request_id": requestID,
 				"limit":      limitStr,
 				"error":      "Invalid limit parameter",
 			}).Warn("Invalid limit parameter")
-			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter (1-100)"), c.Log)
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter, must be a positive number"), c.Log)
 		}
 		limit = limitVal
 	}
@@ -151,6 +151,7 @@ func (c *StockHandler) GetWarehouseStock(ctx *fiber.Ctx) error {
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /warehouses/{warehouseId}/stock [post]
@@ -219,6 +220,72 @@ func (c *StockHandler) AddStock(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, stockResponse)
 }
 
+// AdjustStock godoc
+// @Summary Adjust stock
+// @Description Corrects the on-hand quantity of a product at a warehouse by a signed delta, for example after a physical count. The adjustment is recorded with the caller, the old and new quantity and the reason. Restricted to admins.
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param adjustment body model.AdjustStockRequest true "Stock adjustment"
+// @Success 200 {object} model.StockResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/adjust [post]
+func (c *StockHandler) AdjustStock(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse request body
+	request := new(model.AdjustStockRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to adjust stock
+	stockResponse, err := c.UseCase.AdjustStock(timeoutCtx, request.WarehouseID, request.ProductID, request.Delta, request.Reason)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":  requestID,
+			"warehouseId": request.WarehouseID,
+			"productId":   request.ProductID,
+			"delta":       request.Delta,
+			"error":       err.Error(),
+		}).Warn("Failed to adjust stock")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		}
+
+		if err == fiber.ErrNotFound {
+			return response.JSONError(ctx, appErrors.ErrResourceNotFound, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, stockResponse)
+}
+
 // TransferStock godoc
 // @Summary Transfer stock between warehouses
 // @Description Transfers stock from one warehouse to another for a specific product
@@ -231,6 +298,8 @@ func (c *StockHandler) AddStock(ctx *fiber.Ctx) error {
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 422 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /stock/transfer [post]
@@ -283,4 +352,302 @@ func (c *StockHandler) TransferStock(ctx *fiber.Ctx) error {
 	}
 
 	return response.JSONSuccess(ctx, transferResponse)
+}
+
+// GetProductAvailability godoc
+// @Summary Get product availability at a warehouse
+// @Description Returns the available, reserved and total quantity of a product at a warehouse. Quantities are zero when the product has no stock there.
+// @Tags Inventory
+// @Produce json
+// @Param warehouse_id path int true "Warehouse ID"
+// @Param product_id path int true "Product ID"
+// @Success 200 {object} model.ProductAvailabilityResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/warehouses/{warehouse_id}/products/{product_id}/availability [get]
+func (c *StockHandler) GetProductAvailability(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Get path parameters
+	warehouseIDParam := ctx.Params("warehouse_id")
+	warehouseID, err := strconv.ParseUint(warehouseIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseIDParam,
+			"error":        err.Error(),
+		}).Warn("Invalid warehouse ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	productIDParam := ctx.Params("product_id")
+	productID, err := strconv.ParseUint(productIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": productIDParam,
+			"error":      err.Error(),
+		}).Warn("Invalid product ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to get product availability
+	availability, err := c.UseCase.GetProductAvailabilityAt(timeoutCtx, uint(warehouseID), uint(productID))
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+			"error":        err.Error(),
+		}).Warn("Failed to get product availability")
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, availability)
+}
+
+// GetStockHistory godoc
+// @Summary Get stock history of a product at a warehouse
+// @Description Returns the stock movements of a product at a warehouse, oldest first: stock added, transfers in and out, reservations, their release and commit, and manual adjustments. Each movement carries the quantity on hand, reserved and available after it.
+// @Tags Inventory
+// @Produce json
+// @Param warehouse_id path int true "Warehouse ID"
+// @Param product_id path int true "Product ID"
+// @Param page query int false "Page number (defaults to 1)"
+// @Param limit query int false "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)"
+// @Success 200 {object} model.StockHistoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/warehouses/{warehouse_id}/products/{product_id}/movements [get]
+func (c *StockHandler) GetStockHistory(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Get path parameters
+	warehouseIDParam := ctx.Params("warehouse_id")
+	warehouseID, err := strconv.ParseUint(warehouseIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseIDParam,
+			"error":        err.Error(),
+		}).Warn("Invalid warehouse ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	productIDParam := ctx.Params("product_id")
+	productID, err := strconv.ParseUint(productIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": productIDParam,
+			"error":      err.Error(),
+		}).Warn("Invalid product ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	// Parse pagination parameters; the use case applies the configured default limit and clamps to the maximum
+	page := 1
+	if pageStr := ctx.Query("page"); pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid page parameter"), c.Log)
+		}
+	}
+
+	limit := 0
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter, must be a positive number"), c.Log)
+		}
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to build the ledger
+	history, err := c.UseCase.GetStockHistory(timeoutCtx, uint(warehouseID), uint(productID), page, limit)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+			"error":        err.Error(),
+		}).Warn("Failed to get stock history")
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		}
+		if err == fiber.ErrNotFound {
+			return response.JSONError(ctx, appErrors.ErrResourceNotFound, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, history)
+}
+
+// GetProductStockSummary godoc
+// @Summary Get stock of a product across warehouses
+// @Description Returns the quantities of a product at each active warehouse together with the totals across them. Warehouses without stock of the product are only listed when include_empty is true.
+// @Tags Inventory
+// @Produce json
+// @Param product_id path int true "Product ID"
+// @Param include_empty query bool false "Include warehouses with zero stock"
+// @Success 200 {object} model.ProductStockSummaryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /inventory/products/{product_id}/stock [get]
+func (c *StockHandler) GetProductStockSummary(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Get path parameters
+	productIDParam := ctx.Params("product_id")
+	productID, err := strconv.ParseUint(productIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": productIDParam,
+			"error":      err.Error(),
+		}).Warn("Invalid product ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	includeEmpty := false
+	if includeEmptyParam := ctx.Query("include_empty"); includeEmptyParam != "" {
+		includeEmpty, err = strconv.ParseBool(includeEmptyParam)
+		if err != nil {
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid include_empty parameter"), c.Log)
+		}
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to aggregate the stock
+	summary, err := c.UseCase.GetProductStockSummary(timeoutCtx, uint(productID), includeEmpty)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": productID,
+			"error":      err.Error(),
+		}).Warn("Failed to get product stock summary")
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, summary)
+}
+
+// UpdateReorderThreshold godoc
+// @Summary Update the reorder threshold of a stock record
+// @Description Sets the quantity below which a low-stock alert is raised for a product at a warehouse. Zero disables alerts.
+// @Tags Stock
+// @Accept json
+// @Produce json
+// @Param warehouseId path int true "Warehouse ID"
+// @Param productId path int true "Product ID"
+// @Param threshold body model.UpdateReorderThresholdRequest true "New reorder threshold"
+// @Success 200 {object} model.StockResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /warehouses/{warehouseId}/stock/{productId}/reorder-threshold [put]
+func (c *StockHandler) UpdateReorderThreshold(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Get path parameters
+	warehouseIDParam := ctx.Params("warehouseId")
+	warehouseID, err := strconv.ParseUint(warehouseIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseIDParam,
+			"error":        err.Error(),
+		}).Warn("Invalid warehouse ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	productIDParam := ctx.Params("productId")
+	productID, err := strconv.ParseUint(productIDParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"product_id": productIDParam,
+			"error":      err.Error(),
+		}).Warn("Invalid product ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	// Parse request body
+	request := new(model.UpdateReorderThresholdRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+		}).Warn("Failed to parse request body")
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInvalidInput, err), c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to update the threshold
+	stockResponse, err := c.UseCase.UpdateReorderThreshold(timeoutCtx, uint(warehouseID), uint(productID), request)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id":   requestID,
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+			"error":        err.Error(),
+		}).Warn("Failed to update reorder threshold")
+
+		if err == fiber.ErrBadRequest {
+			return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+		}
+
+		if err == fiber.ErrNotFound {
+			return response.JSONError(ctx, appErrors.ErrResourceNotFound, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, stockResponse)
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"strconv"
+	"strings"
 	"warehouse-service/internal/context"
 	"warehouse-service/internal/delivery/http/response"
 	appErrors "warehouse-service/internal/errors"
@@ -85,6 +86,69 @@ func (c *WarehouseHandler) GetWarehouse(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, warehouseResponse)
 }
 
+// GetWarehousesByIDs godoc
+// @Summary Get multiple warehouses by ID
+// @Description Returns up to 100 warehouses with statistics in one call, in the order requested. Unknown IDs are omitted from the result.
+// @Tags Warehouses
+// @Produce json
+// @Param ids query string true "Comma-separated warehouse IDs"
+// @Success 200 {object} model.WarehouseBatchResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /warehouses/batch [get]
+func (c *WarehouseHandler) GetWarehousesByIDs(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Parse the comma-separated warehouse IDs
+	idsParam := ctx.Query("ids")
+	var ids []uint
+	for _, idParam := range strings.Split(idsParam, ",") {
+		idParam = strings.TrimSpace(idParam)
+		if idParam == "" {
+			continue
+		}
+
+		id, err := strconv.ParseUint(idParam, 10, 32)
+		if err != nil {
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"ids":        idsParam,
+				"error":      err.Error(),
+			}).Warn("Invalid warehouse ID format")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid warehouse ID: "+idParam), c.Log)
+		}
+		ids = append(ids, uint(id))
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to get the warehouses
+	warehouses, err := c.UseCase.GetWarehousesByIDs(timeoutCtx, ids)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"ids":        idsParam,
+			"error":      err.Error(),
+		}).Warn("Failed to get warehouses")
+
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, warehouses)
+}
+
 // CreateWarehouse godoc
 // @Summary Create a new warehouse
 // @Description Creates a new warehouse
@@ -224,15 +288,17 @@ func (c *WarehouseHandler) UpdateWarehouse(ctx *fiber.Ctx) error {
 
 // DeleteWarehouse godoc
 // @Summary Delete a warehouse
-// @Description Deletes an existing warehouse by ID
+// @Description Soft-deletes an existing warehouse by ID. Its stock is kept and it can be restored. A warehouse that still holds stock is only deleted with force=true.
 // @Tags Warehouses
 // @Produce json
 // @Param id path string true "Warehouse ID"
+// @Param force query bool false "Delete the warehouse even if it still holds stock (defaults to false)"
 // @Success 200 {object} response.SuccessMessageResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
 // @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /warehouses/{id} [delete]
@@ -253,12 +319,26 @@ func (c *WarehouseHandler) DeleteWarehouse(ctx *fiber.Ctx) error {
 		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
 	}
 
+	// Parse the force flag
+	force := false
+	if forceStr := ctx.Query("force"); forceStr != "" {
+		force, err = strconv.ParseBool(forceStr)
+		if err != nil {
+			c.Log.WithFields(logrus.Fields{
+				"request_id": requestID,
+				"force":      forceStr,
+				"error":      "Invalid force parameter",
+			}).Warn("Invalid force parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid force parameter (true or false)"), c.Log)
+		}
+	}
+
 	// Add timeout to context
 	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
 	defer cancel()
 
 	// Call the use case to delete the warehouse
-	err = c.UseCase.DeleteWarehouse(timeoutCtx, uint(id))
+	err = c.UseCase.DeleteWarehouse(timeoutCtx, uint(id), force)
 	if err != nil {
 		c.Log.WithFields(logrus.Fields{
 			"request_id": requestID,
@@ -282,13 +362,76 @@ func (c *WarehouseHandler) DeleteWarehouse(ctx *fiber.Ctx) error {
 	return response.JSONSuccess(ctx, map[string]string{"message": "Warehouse deleted successfully"})
 }
 
+// RestoreWarehouse godoc
+// @Summary Restore a deleted warehouse
+// @Description Undoes the soft delete of a warehouse. Restoring a warehouse that is not deleted returns it unchanged.
+// @Tags Warehouses
+// @Produce json
+// @Param id path string true "Warehouse ID"
+// @Success 200 {object} model.WarehouseResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /warehouses/{id}/restore [post]
+func (c *WarehouseHandler) RestoreWarehouse(ctx *fiber.Ctx) error {
+	// Get request ID for tracking
+	requestID := ctx.Get("X-Request-ID")
+	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
+
+	// Get the warehouse ID from the URL
+	idParam := ctx.Params("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"id":         idParam,
+			"error":      err.Error(),
+		}).Warn("Invalid warehouse ID format")
+		return response.JSONError(ctx, appErrors.ErrInvalidInput, c.Log)
+	}
+
+	// Add timeout to context
+	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
+	defer cancel()
+
+	// Call the use case to restore the warehouse
+	warehouseResponse, err := c.UseCase.RestoreWarehouse(timeoutCtx, uint(id))
+	if err != nil {
+		c.Log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"id":         id,
+			"error":      err.Error(),
+		}).Warn("Failed to restore warehouse")
+
+		// Handle specific error types
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return response.JSONError(ctx, appErr, c.Log)
+		}
+
+		if err == fiber.ErrNotFound {
+			return response.JSONError(ctx, appErrors.ErrResourceNotFound, c.Log)
+		}
+
+		return response.JSONError(ctx, appErrors.WithError(appErrors.ErrInternalServer, err), c.Log)
+	}
+
+	return response.JSONSuccess(ctx, warehouseResponse)
+}
+
 // ListWarehouses godoc
 // @Summary List warehouses
-// @Description Returns a paginated list of warehouses
+// @Description Returns a paginated list of warehouses, optionally filtered by search term, location and active status
 // @Tags Warehouses
 // @Produce json
 // @Param page query int false "Page number (defaults to 1)"
-// @Param limit query int false "Items per page (defaults to 20, max 100)"
+// @Param limit query int false "Items per page (defaults to 20; larger values are clamped to the maximum, 100 by default)"
+// @Param search query string false "Matches part of the warehouse name or address"
+// @Param location query string false "Only return warehouses at this location"
+// @Param active_only query bool false "Only return active warehouses (defaults to false)"
 // @Success 200 {object} model.WarehouseListResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 401 {object} response.ErrorResponse
@@ -302,9 +445,9 @@ func (c *WarehouseHandler) ListWarehouses(ctx *fiber.Ctx) error {
 	userCtx := context.WithRequestID(ctx.UserContext(), requestID)
 
 	// Parse pagination parameters
+	// A missing limit is left at zero for the use case to apply the configured default
 	request := &model.ListWarehouseRequest{
-		Page:  1,
-		Limit: 20,
+		Page: 1,
 	}
 
 	// Parse page parameter
@@ -324,17 +467,37 @@ func (c *WarehouseHandler) ListWarehouses(ctx *fiber.Ctx) error {
 	// Parse limit parameter
 	if limitStr := ctx.Query("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit < 1 || limit > 100 {
+		if err != nil || limit < 1 {
 			c.Log.WithFields(logrus.Fields{
 				"request_id": requestID,
 				"limit":      limitStr,
 				"error":      "Invalid limit parameter",
 			}).Warn("Invalid limit parameter")
-			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter (1-100)"), c.Log)
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid limit parameter, must be a positive number"), c.Log)
 		}
 		request.Limit = limit
 	}
 
+	// Parse filter parameters
+	request.Search = ctx.Query("search")
+	request.Location = ctx.Query("location")
+	if len(request.Search) > 255 || len(request.Location) > 255 {
+		return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "search and location must be at most 255 characters"), c.Log)
+	}
+
+	if activeOnlyStr := ctx.Query("active_only"); activeOnlyStr != "" {
+		activeOnly, err := strconv.ParseBool(activeOnlyStr)
+		if err != nil {
+			c.Log.WithFields(logrus.Fields{
+				"request_id":  requestID,
+				"active_only": activeOnlyStr,
+				"error":       "Invalid active_only parameter",
+			}).Warn("Invalid active_only parameter")
+			return response.JSONError(ctx, appErrors.WithMessage(appErrors.ErrInvalidInput, "Invalid active_only parameter (true or false)"), c.Log)
+		}
+		request.ActiveOnly = activeOnly
+	}
+
 	// Add timeout to context
 	timeoutCtx, cancel := context.WithDefaultTimeout(userCtx)
 	defer cancel()
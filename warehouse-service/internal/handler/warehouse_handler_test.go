@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 	"warehouse-service/internal/entity"
+	appErrors "warehouse-service/internal/errors"
 	"warehouse-service/internal/model"
 	"warehouse-service/mocks/usecase"
 
@@ -96,6 +97,55 @@ func TestWarehouseHandler_GetWarehouse(t *testing.T) {
 	assert.Equal(t, float64(totalItemCount), stats["total_items"])
 }
 
+func TestWarehouseHandler_GetWarehousesByIDs(t *testing.T) {
+	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Get("/api/v1/warehouses/batch", handler.GetWarehousesByIDs)
+	
+	// Setup mock expectations
+	mockUsecase.EXPECT().GetWarehousesByIDs(gomock.Any(), []uint{3, 1}).Return(&model.WarehouseBatchResponse{
+		Warehouses: []model.WarehouseResponse{
+			{ID: 3, Name: "Warehouse 3"},
+			{ID: 1, Name: "Warehouse 1"},
+		},
+	}, nil)
+	
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/batch?ids=3,%201", nil)
+	
+	// Execute request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	
+	// Check response order
+	var result struct {
+		Data model.WarehouseBatchResponse `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Data.Warehouses, 2) {
+		assert.Equal(t, uint(3), result.Data.Warehouses[0].ID)
+		assert.Equal(t, uint(1), result.Data.Warehouses[1].ID)
+	}
+}
+
+func TestWarehouseHandler_GetWarehousesByIDs_InvalidID(t *testing.T) {
+	handler, _, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Get("/api/v1/warehouses/batch", handler.GetWarehousesByIDs)
+	
+	// Create request with a non-numeric ID; the usecase must not be called
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/batch?ids=1,abc", nil)
+	
+	// Execute request
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestWarehouseHandler_GetWarehouse_InvalidID(t *testing.T) {
 	handler, _, app := setupWarehouseHandlerTest(t)
 	
@@ -331,7 +381,7 @@ func TestWarehouseHandler_DeleteWarehouse(t *testing.T) {
 	warehouseID := uint(1)
 	
 	// Setup mock expectations
-	mockUsecase.EXPECT().DeleteWarehouse(gomock.Any(), warehouseID).Return(nil)
+	mockUsecase.EXPECT().DeleteWarehouse(gomock.Any(), warehouseID, false).Return(nil)
 	
 	// Create request
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1", nil)
@@ -353,6 +403,101 @@ func TestWarehouseHandler_DeleteWarehouse(t *testing.T) {
 	assert.Equal(t, "Warehouse deleted successfully", result["data"].(map[string]interface{})["message"])
 }
 
+func TestWarehouseHandler_DeleteWarehouse_NotEmpty(t *testing.T) {
+	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Delete("/api/v1/warehouses/:id", handler.DeleteWarehouse)
+	
+	// The warehouse still holds stock
+	mockUsecase.EXPECT().DeleteWarehouse(gomock.Any(), uint(1), false).Return(appErrors.ErrWarehouseNotEmpty)
+	
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, "WAREHOUSE_NOT_EMPTY", result["error"].(map[string]interface{})["code"])
+}
+
+func TestWarehouseHandler_DeleteWarehouse_Force(t *testing.T) {
+	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Delete("/api/v1/warehouses/:id", handler.DeleteWarehouse)
+	
+	// The force flag is passed through to the use case
+	mockUsecase.EXPECT().DeleteWarehouse(gomock.Any(), uint(1), true).Return(nil)
+	
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1?force=true", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestWarehouseHandler_DeleteWarehouse_InvalidForce(t *testing.T) {
+	handler, _, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Delete("/api/v1/warehouses/:id", handler.DeleteWarehouse)
+	
+	// The use case is never called with an unparseable flag
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1?force=always", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestWarehouseHandler_RestoreWarehouse(t *testing.T) {
+	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Post("/api/v1/warehouses/:id/restore", handler.RestoreWarehouse)
+	
+	mockUsecase.EXPECT().RestoreWarehouse(gomock.Any(), uint(1)).Return(&model.WarehouseResponse{
+		ID:       1,
+		Name:     "Warehouse 1",
+		IsActive: true,
+	}, nil)
+	
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses/1/restore", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), result["data"].(map[string]interface{})["id"])
+}
+
+func TestWarehouseHandler_RestoreWarehouse_NotFound(t *testing.T) {
+	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Post("/api/v1/warehouses/:id/restore", handler.RestoreWarehouse)
+	
+	mockUsecase.EXPECT().RestoreWarehouse(gomock.Any(), uint(99)).Return(nil, appErrors.ErrResourceNotFound)
+	
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses/99/restore", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestWarehouseHandler_ListWarehouses(t *testing.T) {
 	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
 	
@@ -448,4 +593,52 @@ func TestWarehouseHandler_ListWarehouses_InvalidParams(t *testing.T) {
 	// Verify error structure
 	assert.Equal(t, false, result["success"])
 	assert.NotNil(t, result["error"])
-}
\ No newline at end of file
+}
+
+func TestWarehouseHandler_ListWarehouses_Filters(t *testing.T) {
+	handler, mockUsecase, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Get("/api/v1/warehouses", handler.ListWarehouses)
+	
+	// The filters are passed through to the use case
+	expectedRequest := &model.ListWarehouseRequest{
+		Page:       1,
+		Search:     "north",
+		Location:   "Jakarta",
+		ActiveOnly: true,
+	}
+	mockUsecase.EXPECT().ListWarehouses(gomock.Any(), expectedRequest).Return(&model.WarehouseListResponse{
+		Warehouses: []model.WarehouseResponse{},
+		Total:      0,
+		Page:       1,
+		Limit:      20,
+	}, nil)
+	
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses?search=north&location=Jakarta&active_only=true", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestWarehouseHandler_ListWarehouses_InvalidActiveOnly(t *testing.T) {
+	handler, _, app := setupWarehouseHandlerTest(t)
+	
+	// Setup route
+	app.Get("/api/v1/warehouses", handler.ListWarehouses)
+	
+	// The use case is never called with an unparseable flag
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses?active_only=yes", nil)
+	req.Header.Set("Authorization", "Bearer admin_token_here")
+	
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, false, result["success"])
+}
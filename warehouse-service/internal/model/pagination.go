@@ -0,0 +1,32 @@
+package model
+
+const (
+	// DefaultPageLimit is the page size of list endpoints when pagination.default_limit is not configured
+	DefaultPageLimit = 20
+
+	// MaxPageLimit is the largest page size of list endpoints when pagination.max_limit is not configured
+	MaxPageLimit = 100
+)
+
+// Pagination bounds the page size of list endpoints; zero fields use DefaultPageLimit and MaxPageLimit
+type Pagination struct {
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Limit returns the page size to query for a requested limit. A limit below 1 gets the default
+// and a limit above the maximum is clamped to the maximum rather than rejected.
+func (p Pagination) Limit(limit int) int {
+	defaultLimit, maxLimit := p.DefaultLimit, p.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = MaxPageLimit
+	}
+	if defaultLimit <= 0 {
+		defaultLimit = min(DefaultPageLimit, maxLimit)
+	}
+
+	if limit < 1 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
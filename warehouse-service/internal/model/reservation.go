@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // ReservationStatus represents the status of a reservation
 type ReservationStatus string
 
@@ -7,6 +9,10 @@ const (
 	ReservationStatusPending   ReservationStatus = "pending"
 	ReservationStatusCommitted ReservationStatus = "committed"
 	ReservationStatusCancelled ReservationStatus = "cancelled"
+	ReservationStatusExpired   ReservationStatus = "expired"
+
+	// ReservationStatusReserved is accepted by the history filter as an alias for pending reservations
+	ReservationStatusReserved ReservationStatus = "reserved"
 )
 
 // ReserveStockRequest represents a request to reserve stock
@@ -14,6 +20,13 @@ type ReserveStockRequest struct {
 	WarehouseID uint `json:"warehouse_id" validate:"required"`
 	ProductID   uint `json:"product_id" validate:"required"`
 	Quantity    int  `json:"quantity" validate:"required,gt=0"`
+	// Reference identifies the reservation in later commits and cancellations; a reference is generated when empty
+	Reference string `json:"reference,omitempty" validate:"omitempty,max=100"`
+}
+
+// ReserveStockBatchRequest represents a request to reserve stock for several items in one transaction
+type ReserveStockBatchRequest struct {
+	Items []ReserveStockRequest `json:"items" validate:"required,min=1,dive"`
 }
 
 // CancelReservationRequest represents a request to cancel a reservation
@@ -32,6 +45,21 @@ type CommitReservationRequest struct {
 	Reference   string `json:"reference" validate:"required"`
 }
 
+// RelocateReservationRequest represents a request to move a reservation to another warehouse
+type RelocateReservationRequest struct {
+	Reference       string `json:"reference" validate:"required"`
+	FromWarehouseID uint   `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   uint   `json:"to_warehouse_id" validate:"required,nefield=FromWarehouseID"`
+	ProductID       uint   `json:"product_id" validate:"required"`
+	Quantity        int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// RelocateReservationResponse represents a reservation after it moved to another warehouse
+type RelocateReservationResponse struct {
+	FromWarehouseID uint                 `json:"from_warehouse_id"`
+	Reservation     *ReservationResponse `json:"reservation"`
+}
+
 // ReservationResponse represents a response to a stock reservation request
 type ReservationResponse struct {
 	WarehouseID        uint             `json:"warehouse_id"`
@@ -44,6 +72,32 @@ type ReservationResponse struct {
 	ReservationTime    string           `json:"reservation_time"`
 }
 
+// BatchItemStatus represents the outcome of a single item in a batch reservation
+type BatchItemStatus string
+
+const (
+	BatchItemStatusReserved          BatchItemStatus = "reserved"
+	BatchItemStatusInsufficientStock BatchItemStatus = "insufficient_stock"
+	BatchItemStatusRolledBack        BatchItemStatus = "rolled_back"
+)
+
+// ReserveStockBatchItemResult represents the result of reserving one item of a batch
+type ReserveStockBatchItemResult struct {
+	WarehouseID uint                 `json:"warehouse_id"`
+	ProductID   uint                 `json:"product_id"`
+	Quantity    int                  `json:"quantity"`
+	Status      BatchItemStatus      `json:"status"`
+	Reservation *ReservationResponse `json:"reservation,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+// ReserveStockBatchResponse represents a response to a batch reservation request.
+// Reserved is false when any item lacked stock, in which case nothing was reserved.
+type ReserveStockBatchResponse struct {
+	Reserved bool                          `json:"reserved"`
+	Results  []ReserveStockBatchItemResult `json:"results"`
+}
+
 // ReservationLogResponse represents a single reservation log entry in the history
 type ReservationLogResponse struct {
 	Quantity    int               `json:"quantity"`
@@ -52,6 +106,13 @@ type ReservationLogResponse struct {
 	CreatedAt   string            `json:"created_at"`
 }
 
+// ReservationHistoryFilter narrows a reservation history query. From is inclusive and To is exclusive.
+type ReservationHistoryFilter struct {
+	Status ReservationStatus `validate:"omitempty,oneof=reserved pending committed cancelled expired"`
+	From   *time.Time
+	To     *time.Time
+}
+
 // ReservationHistoryResponse represents a response to a reservation history request
 type ReservationHistoryResponse struct {
 	WarehouseID uint                    `json:"warehouse_id"`
@@ -60,4 +121,72 @@ type ReservationHistoryResponse struct {
 	Page        int                     `json:"page"`
 	Limit       int                     `json:"limit"`
 	Logs        []ReservationLogResponse `json:"logs"`
-}
\ No newline at end of file
+}
+
+// ReservationDetailResponse represents the current state of a single reservation
+type ReservationDetailResponse struct {
+	WarehouseID      uint              `json:"warehouse_id"`
+	ProductID        uint              `json:"product_id"`
+	Reference        string            `json:"reference"`
+	ReservedQuantity int               `json:"reserved_quantity"`
+	Status           ReservationStatus `json:"status"`
+	ReservedAt       string            `json:"reserved_at"`
+	ExpiresAt        string            `json:"expires_at"`
+}
+
+// ReferenceReservationResponse represents one reservation log written for a reference
+type ReferenceReservationResponse struct {
+	ID          uint              `json:"id"`
+	WarehouseID uint              `json:"warehouse_id"`
+	ProductID   uint              `json:"product_id"`
+	Quantity    int               `json:"quantity"`
+	Status      ReservationStatus `json:"status"`
+	CreatedAt   string            `json:"created_at"`
+}
+
+// ReservationsByReferenceResponse lists every reservation log sharing a reference, such as all items of an order
+type ReservationsByReferenceResponse struct {
+	Reference    string                         `json:"reference"`
+	Total        int                            `json:"total"`
+	Reservations []ReferenceReservationResponse `json:"reservations"`
+}
+
+// ReservationExpiryResult reports what a single reservation expiry sweep released
+type ReservationExpiryResult struct {
+	ExpiredReservations int `json:"expired_reservations"`
+	FailedReservations  int `json:"failed_reservations"`
+}
+
+// CommitPurgeResult reports how many commit records a single purge deleted
+type CommitPurgeResult struct {
+	DeletedRecords int64 `json:"deleted_records"`
+}
+
+// AgedReservationResponse represents an active reservation that has been held for longer than the report threshold
+type AgedReservationResponse struct {
+	WarehouseID uint   `json:"warehouse_id"`
+	ProductID   uint   `json:"product_id"`
+	Reference   string `json:"reference"`
+	Quantity    int    `json:"quantity"`
+	ReservedAt  string `json:"reserved_at"`
+	AgeSeconds  int64  `json:"age_seconds"`
+}
+
+// AgedReservationWarehouseTotal sums the aged reservations of one warehouse
+type AgedReservationWarehouseTotal struct {
+	WarehouseID  uint  `json:"warehouse_id"`
+	Reservations int64 `json:"reservations"`
+	Quantity     int64 `json:"quantity"`
+}
+
+// AgedReservationReportResponse lists a page of active reservations older than a threshold
+// together with totals over all of them per warehouse
+type AgedReservationReportResponse struct {
+	OlderThan    string                          `json:"older_than"`
+	Cutoff       string                          `json:"cutoff"`
+	Total        int64                           `json:"total"`
+	Page         int                             `json:"page"`
+	Limit        int                             `json:"limit"`
+	Reservations []AgedReservationResponse       `json:"reservations"`
+	Warehouses   []AgedReservationWarehouseTotal `json:"warehouses"`
+}
@@ -8,6 +8,22 @@ type AddStockRequest struct {
 	Quantity    int    `json:"quantity" validate:"required,gt=0"`
 	Reference   string `json:"reference" validate:"required"`
 	Notes       string `json:"notes"`
+
+	// ReorderThreshold optionally sets the quantity below which a low-stock alert is raised
+	ReorderThreshold *int `json:"reorder_threshold,omitempty" validate:"omitempty,gte=0"`
+}
+
+// AdjustStockRequest represents a manual correction of the stock of a product, such as after a physical count
+type AdjustStockRequest struct {
+	WarehouseID uint   `json:"warehouse_id" validate:"required"`
+	ProductID   uint   `json:"product_id" validate:"required"`
+	Delta       int    `json:"delta" validate:"required,ne=0"` // Signed change to the on-hand quantity
+	Reason      string `json:"reason" validate:"required,max=255"`
+}
+
+// UpdateReorderThresholdRequest represents a request to change the reorder threshold of a stock record
+type UpdateReorderThresholdRequest struct {
+	ReorderThreshold int `json:"reorder_threshold" validate:"gte=0"`
 }
 
 // StockResponse represents a response to a stock operation
@@ -19,9 +35,37 @@ type StockResponse struct {
 	Quantity          int    `json:"quantity"`
 	ReservedQuantity  int    `json:"reserved_quantity"`
 	AvailableQuantity int    `json:"available_quantity"`
+	ReorderThreshold  int    `json:"reorder_threshold"`
 	UpdatedAt         string `json:"updated_at"`
 }
 
+// ProductAvailabilityResponse represents the stock levels of a single product at a warehouse
+type ProductAvailabilityResponse struct {
+	WarehouseID       uint `json:"warehouse_id"`
+	ProductID         uint `json:"product_id"`
+	AvailableQuantity int  `json:"available_quantity"`
+	ReservedQuantity  int  `json:"reserved_quantity"`
+	TotalQuantity     int  `json:"total_quantity"`
+}
+
+// WarehouseStockSummary represents the stock of a product at one warehouse
+type WarehouseStockSummary struct {
+	WarehouseID       uint   `json:"warehouse_id"`
+	WarehouseName     string `json:"warehouse_name"`
+	Quantity          int    `json:"quantity"`
+	ReservedQuantity  int    `json:"reserved_quantity"`
+	AvailableQuantity int    `json:"available_quantity"`
+}
+
+// ProductStockSummaryResponse represents the stock of a product across all active warehouses
+type ProductStockSummaryResponse struct {
+	ProductID              uint                    `json:"product_id"`
+	TotalQuantity          int                     `json:"total_quantity"`
+	TotalReservedQuantity  int                     `json:"total_reserved_quantity"`
+	TotalAvailableQuantity int                     `json:"total_available_quantity"`
+	Warehouses             []WarehouseStockSummary `json:"warehouses"`
+}
+
 // StockItemResponse represents a single stock item in a list
 type StockItemResponse struct {
 	WarehouseID       uint   `json:"warehouse_id"`
@@ -31,6 +75,7 @@ type StockItemResponse struct {
 	Quantity          int    `json:"quantity"`
 	ReservedQuantity  int    `json:"reserved_quantity"`
 	AvailableQuantity int    `json:"available_quantity"`
+	ReorderThreshold  int    `json:"reorder_threshold"`
 	UpdatedAt         string `json:"updated_at"`
 }
 
@@ -47,7 +92,7 @@ type WarehouseStockListResponse struct {
 // StockTransferRequest represents a request to transfer stock between warehouses
 type StockTransferRequest struct {
 	SourceWarehouseID uint   `json:"source_warehouse_id" validate:"required"`
-	TargetWarehouseID uint   `json:"target_warehouse_id" validate:"required"`
+	TargetWarehouseID uint   `json:"target_warehouse_id" validate:"required,nefield=SourceWarehouseID"`
 	ProductID         uint   `json:"product_id" validate:"required"`
 	ProductSKU        string `json:"product_sku" validate:"required"`
 	Quantity          int    `json:"quantity" validate:"required,gt=0"`
@@ -65,4 +110,37 @@ type StockTransferResponse struct {
 	Status            string `json:"status"`
 	TransferReference string `json:"transfer_reference"`
 	CreatedAt         string `json:"created_at"`
+}
+
+// StockMovementResponse represents one entry of a stock history with the balances it left behind
+type StockMovementResponse struct {
+	ID               uint   `json:"id"`
+	MovementType     string `json:"movement_type"`
+	Quantity         int    `json:"quantity"`          // Units moved; the signed correction for adjustments
+	QuantityChange   int    `json:"quantity_change"`   // Change to the quantity on hand
+	ReservedChange   int    `json:"reserved_change"`   // Change to the reserved quantity
+	Balance          int    `json:"balance"`           // Quantity on hand after the movement
+	ReservedBalance  int    `json:"reserved_balance"`  // Reserved quantity after the movement
+	AvailableBalance int    `json:"available_balance"` // Quantity available after the movement
+	ReferenceType    string `json:"reference_type,omitempty"`
+	ReferenceID      string `json:"reference_id,omitempty"`
+	Notes            string `json:"notes,omitempty"`
+	CreatedAt        string `json:"created_at"`
+}
+
+// StockHistoryResponse represents a page of the stock movements of a product at a warehouse, oldest first.
+// The opening balances are what the movements before the page add up to. On the last page the final balances
+// match the current quantity and reserved quantity, unless the stock changed before movements were recorded.
+type StockHistoryResponse struct {
+	WarehouseID             uint                    `json:"warehouse_id"`
+	ProductID               uint                    `json:"product_id"`
+	OpeningBalance          int                     `json:"opening_balance"`
+	OpeningReservedBalance  int                     `json:"opening_reserved_balance"`
+	CurrentQuantity         int                     `json:"current_quantity"`
+	CurrentReservedQuantity int                     `json:"current_reserved_quantity"`
+	Total                   int64                   `json:"total"`
+	Page                    int                     `json:"page"`
+	Limit                   int                     `json:"limit"`
+	TotalPages              int64                   `json:"total_pages"`
+	Movements               []StockMovementResponse `json:"movements"`
 }
\ No newline at end of file
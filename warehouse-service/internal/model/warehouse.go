@@ -20,13 +20,18 @@ type UpdateWarehouseRequest struct {
 }
 
 type ListWarehouseRequest struct {
-	Page  int `json:"page" validate:"min=1"`
-	Limit int `json:"limit" validate:"min=1,max=100"`
+	Page       int    `json:"page" validate:"min=1"`
+	Limit      int    `json:"limit" validate:"min=0"`      // Zero uses the configured default; larger than the maximum is clamped
+	Search     string `json:"search" validate:"max=255"`   // Matches part of the name or address
+	Location   string `json:"location" validate:"max=255"` // Matches the location exactly
+	ActiveOnly bool   `json:"active_only"`
 }
 
 type WarehouseStatsDTO struct {
-	TotalProducts int64 `json:"total_products"`
-	TotalItems    int64 `json:"total_items"`
+	TotalProducts  int64 `json:"total_products"`
+	TotalItems     int64 `json:"total_items"`
+	TotalReserved  int64 `json:"total_reserved"`  // Items held by reservations
+	TotalAvailable int64 `json:"total_available"` // Items neither reserved nor sold, TotalItems less TotalReserved
 }
 
 type WarehouseResponse struct {
@@ -40,6 +45,14 @@ type WarehouseResponse struct {
 	UpdatedAt string           `json:"updated_at,omitempty"`
 }
 
+// MaxWarehouseBatchSize caps how many warehouses can be fetched in one batch lookup
+const MaxWarehouseBatchSize = 100
+
+// WarehouseBatchResponse holds the warehouses found by a batch lookup, in the order they were requested
+type WarehouseBatchResponse struct {
+	Warehouses []WarehouseResponse `json:"warehouses"`
+}
+
 type WarehouseListResponse struct {
 	Warehouses []WarehouseResponse `json:"warehouses"`
 	Total      int64               `json:"total"`
@@ -11,21 +11,57 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ReservationLogFilter narrows the reservation logs of a product; zero values match everything.
+// From is inclusive and To is exclusive.
+type ReservationLogFilter struct {
+	Status string
+	From   *time.Time
+	To     *time.Time
+}
+
+// ReservationTotal sums the reservations of a single warehouse
+type ReservationTotal struct {
+	WarehouseID  uint
+	Reservations int64
+	Quantity     int64
+}
+
 type ReservationRepositoryInterface interface {
-	// ReserveStock reserves stock with database locking to prevent race conditions
-	ReserveStock(tx *gorm.DB, warehouseID, productID uint, quantity int) (*entity.WarehouseStock, error)
+	// ReserveStock reserves stock with optimistic locking to prevent overselling under concurrency
+	ReserveStock(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error)
 	
 	// CancelReservation cancels a previously made reservation
-	CancelReservation(tx *gorm.DB, warehouseID, productID uint, quantity int) error
+	CancelReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) error
 	
-	// CommitReservation converts a reservation to a confirmed withdrawal
-	CommitReservation(tx *gorm.DB, warehouseID, productID uint, quantity int) error
+	// CommitReservation converts a reservation to a confirmed withdrawal and returns the updated stock
+	CommitReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error)
+	
+	// MarkReservationCommitted records the commit of a reference and reports whether it was not recorded before
+	MarkReservationCommitted(tx *gorm.DB, warehouseID, productID uint, reference string) (bool, error)
 	
 	// CreateReservationLog logs a reservation event
 	CreateReservationLog(tx *gorm.DB, warehouseID, productID uint, quantity int, status string, reference string) error
 	
-	// GetReservationLogs retrieves reservation logs for a product
-	GetReservationLogs(tx *gorm.DB, warehouseID, productID uint, limit, offset int) ([]entity.ReservationLog, int64, error)
+	// GetReservationLogs retrieves reservation logs for a product that match the filter
+	GetReservationLogs(tx *gorm.DB, warehouseID, productID uint, filter ReservationLogFilter, limit, offset int) ([]entity.ReservationLog, int64, error)
+	
+	// FindStaleReservations finds pending reservations created before cutoff that were never committed, cancelled or expired
+	FindStaleReservations(tx *gorm.DB, cutoff time.Time, limit int) ([]entity.ReservationLog, error)
+	
+	// FindActiveReservation finds the pending reservation of a product with the given reference that was never committed, cancelled or expired
+	FindActiveReservation(tx *gorm.DB, warehouseID, productID uint, reference string) (*entity.ReservationLog, error)
+	
+	// DeleteCommittedReservations deletes at most limit commit records created before cutoff and returns how many were deleted
+	DeleteCommittedReservations(tx *gorm.DB, cutoff time.Time, limit int) (int64, error)
+	
+	// FindReservationsByReference finds every reservation log with the given reference across warehouses and products
+	FindReservationsByReference(tx *gorm.DB, reference string) ([]entity.ReservationLog, error)
+	
+	// FindReservationsOlderThan finds a page of unresolved pending reservations created before cutoff without later activity
+	FindReservationsOlderThan(tx *gorm.DB, cutoff time.Time, limit, offset int) ([]entity.ReservationLog, int64, error)
+	
+	// SumReservationsOlderThan totals the reservations matched by FindReservationsOlderThan per warehouse
+	SumReservationsOlderThan(tx *gorm.DB, cutoff time.Time) ([]ReservationTotal, error)
 }
 
 type ReservationRepository struct {
@@ -40,12 +76,12 @@ func NewReservationRepository(log *logrus.Logger, db *gorm.DB) ReservationReposi
 	}
 }
 
-// ReserveStock locks and reserves stock with pessimistic locking to prevent race conditions
-func (r *ReservationRepository) ReserveStock(tx *gorm.DB, warehouseID, productID uint, quantity int) (*entity.WarehouseStock, error) {
-	// First, lock the stock record with FOR UPDATE to prevent concurrent modifications
+// ReserveStock reserves stock with optimistic locking; a concurrent change to the row yields ErrStockVersionConflict.
+// The reservation is recorded as a stock movement under the given reference.
+func (r *ReservationRepository) ReserveStock(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error) {
+	// Read the stock record; the version read here guards the update below
 	stock := new(entity.WarehouseStock)
-	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+	result := tx.Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
 		First(stock)
 
 	if result.Error != nil {
@@ -66,8 +102,12 @@ func (r *ReservationRepository) ReserveStock(tx *gorm.DB, warehouseID, productID
 	// Update the reserved quantity
 	stock.ReservedQuantity += quantity
 
-	// Save the updated stock
-	if err := tx.Save(stock).Error; err != nil {
+	// Save the updated stock if nobody else changed it in the meantime
+	if err := updateStockWithVersion(tx, stock); err != nil {
+		return nil, err
+	}
+
+	if err := logStockMovement(tx, warehouseID, productID, "", entity.MovementTypeReserve, quantity, "reservation", reference, ""); err != nil {
 		return nil, err
 	}
 
@@ -77,16 +117,17 @@ func (r *ReservationRepository) ReserveStock(tx *gorm.DB, warehouseID, productID
 	return stock, nil
 }
 
-// CancelReservation cancels a previously made reservation by decreasing the reserved quantity
-func (r *ReservationRepository) CancelReservation(tx *gorm.DB, warehouseID, productID uint, quantity int) error {
+// CancelReservation cancels a previously made reservation by decreasing the reserved quantity,
+// recording the release as a stock movement under the given reference
+func (r *ReservationRepository) CancelReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) error {
 	// Set a short timeout for the query to prevent long-running locks
 	queryTimeout := 10 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
 	defer cancel()
 	
-	// Lock the stock record with a timeout to prevent deadlocks
+	// Read the stock record; the version read here guards the update below
 	stock := new(entity.WarehouseStock)
-	result := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+	result := tx.WithContext(ctx).
 		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
 		First(stock)
 
@@ -94,7 +135,7 @@ func (r *ReservationRepository) CancelReservation(tx *gorm.DB, warehouseID, prod
 		r.Log.WithError(result.Error).WithFields(logrus.Fields{
 			"warehouse_id": warehouseID,
 			"product_id": productID,
-		}).Error("Failed to lock stock record for cancellation")
+		}).Error("Failed to read stock record for cancellation")
 		return result.Error
 	}
 
@@ -113,27 +154,30 @@ func (r *ReservationRepository) CancelReservation(tx *gorm.DB, warehouseID, prod
 	// Update the reserved quantity
 	stock.ReservedQuantity -= quantity
 
-	// Save the updated stock
-	err := tx.Save(stock).Error
+	// Save the updated stock if nobody else changed it in the meantime
+	err := updateStockWithVersion(tx, stock)
 	if err != nil {
 		r.Log.WithError(err).WithFields(logrus.Fields{
 			"warehouse_id": warehouseID,
 			"product_id": productID,
 		}).Error("Failed to save stock after cancellation")
+		return err
 	}
-	return err
+
+	return logStockMovement(tx, warehouseID, productID, "", entity.MovementTypeRelease, quantity, "reservation", reference, "")
 }
 
-// CommitReservation converts a reservation to a confirmed withdrawal by reducing both quantity and reserved quantity
-func (r *ReservationRepository) CommitReservation(tx *gorm.DB, warehouseID, productID uint, quantity int) error {
+// CommitReservation converts a reservation to a confirmed withdrawal by reducing both quantity and reserved quantity,
+// recording the withdrawal as a stock movement under the given reference
+func (r *ReservationRepository) CommitReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error) {
 	// Set a short timeout for the query to prevent long-running locks
 	queryTimeout := 10 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
 	defer cancel()
 	
-	// Lock the stock record
+	// Read the stock record; the version read here guards the update below
 	stock := new(entity.WarehouseStock)
-	result := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+	result := tx.WithContext(ctx).
 		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
 		First(stock)
 
@@ -141,8 +185,8 @@ func (r *ReservationRepository) CommitReservation(tx *gorm.DB, warehouseID, prod
 		r.Log.WithError(result.Error).WithFields(logrus.Fields{
 			"warehouse_id": warehouseID,
 			"product_id": productID,
-		}).Error("Failed to lock stock record for commit")
-		return result.Error
+		}).Error("Failed to read stock record for commit")
+		return nil, result.Error
 	}
 
 	// Check if there's enough reserved quantity to commit
@@ -153,7 +197,7 @@ func (r *ReservationRepository) CommitReservation(tx *gorm.DB, warehouseID, prod
 			"reserved": stock.ReservedQuantity,
 			"requested": quantity,
 		}).Warn("Cannot commit more than reserved")
-		return fmt.Errorf("cannot commit more than reserved: reserved %d, commit request %d",
+		return nil, fmt.Errorf("cannot commit more than reserved: reserved %d, commit request %d",
 			stock.ReservedQuantity, quantity)
 	}
 
@@ -161,15 +205,46 @@ func (r *ReservationRepository) CommitReservation(tx *gorm.DB, warehouseID, prod
 	stock.ReservedQuantity -= quantity
 	stock.Quantity -= quantity
 
-	// Save the updated stock
-	err := tx.Save(stock).Error
-	if err != nil {
+	// Save the updated stock if nobody else changed it in the meantime
+	if err := updateStockWithVersion(tx, stock); err != nil {
 		r.Log.WithError(err).WithFields(logrus.Fields{
 			"warehouse_id": warehouseID,
 			"product_id": productID,
 		}).Error("Failed to save stock after commit")
+		return nil, err
 	}
-	return err
+
+	if err := logStockMovement(tx, warehouseID, productID, "", entity.MovementTypeCommit, quantity, "reservation", reference, ""); err != nil {
+		return nil, err
+	}
+
+	stock.CalculateAvailableQuantity()
+	return stock, nil
+}
+
+// MarkReservationCommitted records that the reservation with the given reference was committed for a product.
+// It returns false without error when the reference was already recorded. The unique index on the reference
+// makes a concurrent commit of the same reference wait for this transaction and then see it as recorded.
+func (r *ReservationRepository) MarkReservationCommitted(tx *gorm.DB, warehouseID, productID uint, reference string) (bool, error) {
+	committed := entity.CommittedReservation{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Reference:   reference,
+		CreatedAt:   time.Now(),
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&committed)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteCommittedReservations deletes at most limit commit records created before cutoff in a single statement
+func (r *ReservationRepository) DeleteCommittedReservations(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	result := tx.Where("created_at < ?", cutoff).Limit(limit).Delete(&entity.CommittedReservation{})
+	return result.RowsAffected, result.Error
 }
 
 // CreateReservationLog logs a reservation event
@@ -186,21 +261,20 @@ func (r *ReservationRepository) CreateReservationLog(tx *gorm.DB, warehouseID, p
 	return tx.Create(&log).Error
 }
 
-// GetReservationLogs retrieves reservation logs for a product
-func (r *ReservationRepository) GetReservationLogs(tx *gorm.DB, warehouseID, productID uint, limit, offset int) ([]entity.ReservationLog, int64, error) {
+// GetReservationLogs retrieves reservation logs for a product that match the filter
+func (r *ReservationRepository) GetReservationLogs(tx *gorm.DB, warehouseID, productID uint, filter ReservationLogFilter, limit, offset int) ([]entity.ReservationLog, int64, error) {
 	var logs []entity.ReservationLog
 	var count int64
 
-	// Count the total records
-	err := tx.Model(&entity.ReservationLog{}).
-		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+	// Count the matching records so the total agrees with the filtered pages
+	err := filterReservationLogs(tx.Model(&entity.ReservationLog{}), warehouseID, productID, filter).
 		Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Query with pagination
-	query := tx.Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+	query := filterReservationLogs(tx, warehouseID, productID, filter).
 		Order("created_at DESC")
 
 	if limit > 0 {
@@ -214,4 +288,147 @@ func (r *ReservationRepository) GetReservationLogs(tx *gorm.DB, warehouseID, pro
 	}
 
 	return logs, count, nil
-}
\ No newline at end of file
+}
+
+// filterReservationLogs restricts query to the reservation logs of a product that match the filter
+func filterReservationLogs(query *gorm.DB, warehouseID, productID uint, filter ReservationLogFilter) *gorm.DB {
+	query = query.Where("warehouse_id = ? AND product_id = ?", warehouseID, productID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	return query
+}
+
+// FindStaleReservations finds pending reservations created before cutoff that no commit, cancellation or
+// expiry log of the same warehouse, product and reference has resolved, oldest first. Matching the warehouse
+// keeps a reservation relocated to another warehouse pending there after its source was released.
+func (r *ReservationRepository) FindStaleReservations(tx *gorm.DB, cutoff time.Time, limit int) ([]entity.ReservationLog, error) {
+	var logs []entity.ReservationLog
+
+	resolved := tx.Table("reservation_logs AS resolved").
+		Select("1").
+		Where("resolved.warehouse_id = reservation_logs.warehouse_id AND resolved.product_id = reservation_logs.product_id").
+		Where("resolved.reference = reservation_logs.reference AND resolved.status IN ?", []string{
+			string(entity.ReservationStatusCommitted),
+			string(entity.ReservationStatusCancelled),
+			string(entity.ReservationStatusExpired),
+		})
+
+	query := tx.Where("status = ? AND created_at < ?", string(entity.ReservationStatusPending), cutoff).
+		Where("NOT EXISTS (?)", resolved).
+		Order("id")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// FindActiveReservation finds the latest pending reservation of a product with the given reference that no
+// commit, cancellation or expiry log of the same product and reference has resolved.
+// It returns gorm.ErrRecordNotFound when there is none.
+func (r *ReservationRepository) FindActiveReservation(tx *gorm.DB, warehouseID, productID uint, reference string) (*entity.ReservationLog, error) {
+	var log entity.ReservationLog
+
+	resolved := tx.Table("reservation_logs AS resolved").
+		Select("1").
+		Where("resolved.warehouse_id = reservation_logs.warehouse_id AND resolved.product_id = reservation_logs.product_id").
+		Where("resolved.reference = reservation_logs.reference AND resolved.status IN ?", []string{
+			string(entity.ReservationStatusCommitted),
+			string(entity.ReservationStatusCancelled),
+			string(entity.ReservationStatusExpired),
+		})
+
+	err := tx.Where("warehouse_id = ? AND product_id = ? AND reference = ? AND status = ?",
+		warehouseID, productID, reference, string(entity.ReservationStatusPending)).
+		Where("NOT EXISTS (?)", resolved).
+		Order("id DESC").
+		Take(&log).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+// FindReservationsByReference finds every reservation log with the given reference, whatever its warehouse, product
+// or status, in the order they were written
+func (r *ReservationRepository) FindReservationsByReference(tx *gorm.DB, reference string) ([]entity.ReservationLog, error) {
+	var logs []entity.ReservationLog
+
+	if err := tx.Where("reference = ?", reference).Order("created_at, id").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// FindReservationsOlderThan finds pending reservations created before cutoff that no commit, cancellation or
+// expiry resolved and that saw no other log of the same product and reference since cutoff, oldest first.
+// It also returns how many reservations match in total.
+func (r *ReservationRepository) FindReservationsOlderThan(tx *gorm.DB, cutoff time.Time, limit, offset int) ([]entity.ReservationLog, int64, error) {
+	var logs []entity.ReservationLog
+	var count int64
+
+	if err := reservationsOlderThan(tx.Model(&entity.ReservationLog{}), tx, cutoff).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := reservationsOlderThan(tx, tx, cutoff).Order("created_at, id")
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
+
+// SumReservationsOlderThan counts the reservations matched by FindReservationsOlderThan and sums their
+// quantity per warehouse, ordered by warehouse
+func (r *ReservationRepository) SumReservationsOlderThan(tx *gorm.DB, cutoff time.Time) ([]ReservationTotal, error) {
+	var totals []ReservationTotal
+
+	err := reservationsOlderThan(tx.Model(&entity.ReservationLog{}), tx, cutoff).
+		Select("warehouse_id, COUNT(*) AS reservations, COALESCE(SUM(quantity), 0) AS quantity").
+		Group("warehouse_id").
+		Order("warehouse_id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// reservationsOlderThan restricts query to unresolved pending reservations created before cutoff whose
+// product and reference have no log since cutoff; tx builds the correlated subquery
+func reservationsOlderThan(query, tx *gorm.DB, cutoff time.Time) *gorm.DB {
+	activity := tx.Table("reservation_logs AS activity").
+		Select("1").
+		Where("activity.warehouse_id = reservation_logs.warehouse_id AND activity.product_id = reservation_logs.product_id").
+		Where("activity.reference = reservation_logs.reference").
+		Where("activity.status IN ? OR activity.created_at >= ?", []string{
+			string(entity.ReservationStatusCommitted),
+			string(entity.ReservationStatusCancelled),
+			string(entity.ReservationStatusExpired),
+		}, cutoff)
+
+	return query.Where("status = ? AND created_at < ?", string(entity.ReservationStatusPending), cutoff).
+		Where("NOT EXISTS (?)", activity)
+}
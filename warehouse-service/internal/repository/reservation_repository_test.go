@@ -2,6 +2,7 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/sirupsen/logrus"
@@ -97,6 +98,54 @@ func TestReservationRepository_CreateReservationLog(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestReservationRepository_MarkReservationCommitted(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		want         bool
+	}{
+		{name: "FirstCommit", rowsAffected: 1, want: true},
+		{name: "AlreadyCommitted", rowsAffected: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, db := setupReservationRepositoryTest()
+
+			// A duplicate reference leaves the existing row alone instead of failing
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO `committed_reservations` \\(`warehouse_id`,`product_id`,`reference`,`created_at`\\) VALUES \\(\\?,\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE `id`=`id`").
+				WithArgs(1, 2, "RSV-1-2-123456", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			firstCommit, err := repo.MarkReservationCommitted(db, 1, 2, "RSV-1-2-123456")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, firstCommit)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationRepository_DeleteCommittedReservations(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+	cutoff := time.Now().Add(-720 * time.Hour)
+
+	// A single bounded statement so a purge batch keeps its locks brief
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `committed_reservations` WHERE created_at < \\? LIMIT \\?").
+		WithArgs(cutoff, 500).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteCommittedReservations(db, cutoff, 500)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestReservationRepository_GetReservationLogs(t *testing.T) {
 	// Skip test due to complexity of mocking GORM query behavior
 	t.Skip("Skipping GetReservationLogs test due to GORM query complexity")
@@ -105,4 +154,166 @@ func TestReservationRepository_GetReservationLogs(t *testing.T) {
 func TestReservationRepository_GetReservationLogs_CountError(t *testing.T) {
 	// Skip test due to complexity of mocking GORM query behavior
 	t.Skip("Skipping GetReservationLogs_CountError test due to GORM query complexity")
+}
+
+func TestReservationRepository_FindStaleReservations(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	cutoff := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+	createdAt := cutoff.Add(-time.Hour)
+
+	// Only pending logs without a commit, cancellation or expiry for the same warehouse, product and reference are returned
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` WHERE \\(status = \\? AND created_at < \\?\\) AND NOT EXISTS \\(SELECT 1 FROM reservation_logs AS resolved WHERE \\(resolved.warehouse_id = reservation_logs.warehouse_id AND resolved.product_id = reservation_logs.product_id\\) AND \\(resolved.reference = reservation_logs.reference AND resolved.status IN \\(\\?,\\?,\\?\\)\\)\\) ORDER BY id LIMIT \\?").
+		WithArgs("pending", cutoff, "committed", "cancelled", "expired", 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference", "created_at"}).
+			AddRow(7, 1, 2, 3, "pending", "RSV-1-2-123456", createdAt))
+
+	logs, err := repo.FindStaleReservations(db, cutoff, 50)
+
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, uint(7), logs[0].ID)
+	assert.Equal(t, "RSV-1-2-123456", logs[0].Reference)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_FindActiveReservation(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	createdAt := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+
+	// Only a pending log without a commit, cancellation or expiry for the same product and reference is active
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` WHERE \\(warehouse_id = \\? AND product_id = \\? AND reference = \\? AND status = \\?\\) AND NOT EXISTS \\(SELECT 1 FROM reservation_logs AS resolved WHERE \\(resolved.warehouse_id = reservation_logs.warehouse_id AND resolved.product_id = reservation_logs.product_id\\) AND \\(resolved.reference = reservation_logs.reference AND resolved.status IN \\(\\?,\\?,\\?\\)\\)\\) ORDER BY id DESC LIMIT \\?").
+		WithArgs(1, 2, "RSV-1-2-123456", "pending", "committed", "cancelled", "expired", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference", "created_at"}).
+			AddRow(7, 1, 2, 3, "pending", "RSV-1-2-123456", createdAt))
+
+	reservation, err := repo.FindActiveReservation(db, 1, 2, "RSV-1-2-123456")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), reservation.ID)
+	assert.Equal(t, 3, reservation.Quantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_FindActiveReservation_NotFound(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	reservation, err := repo.FindActiveReservation(db, 1, 2, "RSV-unknown")
+
+	assert.Nil(t, reservation)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestReservationRepository_FindReservationsByReference(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	createdAt := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+
+	// Every log of the reference is returned, whatever its warehouse, product or status
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` WHERE reference = \\? ORDER BY created_at, id").
+		WithArgs("ORDER-42").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference", "created_at"}).
+			AddRow(7, 1, 2, 3, "pending", "ORDER-42", createdAt).
+			AddRow(8, 2, 5, 1, "pending", "ORDER-42", createdAt).
+			AddRow(9, 1, 2, 3, "committed", "ORDER-42", createdAt.Add(time.Minute)))
+
+	reservations, err := repo.FindReservationsByReference(db, "ORDER-42")
+
+	assert.NoError(t, err)
+	assert.Len(t, reservations, 3)
+	assert.Equal(t, uint(2), reservations[1].WarehouseID)
+	assert.Equal(t, "committed", reservations[2].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_FindReservationsOlderThan(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	cutoff := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+	createdAt := cutoff.Add(-2 * time.Hour)
+
+	// A reservation is aged only if nothing resolved it and its reference saw no activity since the cutoff
+	where := "WHERE \\(status = \\? AND created_at < \\?\\) AND NOT EXISTS \\(SELECT 1 FROM reservation_logs AS activity WHERE \\(activity.warehouse_id = reservation_logs.warehouse_id AND activity.product_id = reservation_logs.product_id\\) AND activity.reference = reservation_logs.reference AND \\(activity.status IN \\(\\?,\\?,\\?\\) OR activity.created_at >= \\?\\)\\)"
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `reservation_logs` " + where).
+		WithArgs("pending", cutoff, "committed", "cancelled", "expired", cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` " + where + " ORDER BY created_at, id LIMIT \\? OFFSET \\?").
+		WithArgs("pending", cutoff, "committed", "cancelled", "expired", cutoff, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference", "created_at"}).
+			AddRow(7, 1, 2, 3, "pending", "RSV-1-2-123456", createdAt))
+
+	logs, count, err := repo.FindReservationsOlderThan(db, cutoff, 2, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "RSV-1-2-123456", logs[0].Reference)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_SumReservationsOlderThan(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	cutoff := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT warehouse_id, COUNT\\(\\*\\) AS reservations, COALESCE\\(SUM\\(quantity\\), 0\\) AS quantity FROM `reservation_logs` WHERE .+ GROUP BY `warehouse_id` ORDER BY warehouse_id").
+		WithArgs("pending", cutoff, "committed", "cancelled", "expired", cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_id", "reservations", "quantity"}).
+			AddRow(1, 2, 7).
+			AddRow(3, 1, 4))
+
+	totals, err := repo.SumReservationsOlderThan(db, cutoff)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ReservationTotal{
+		{WarehouseID: 1, Reservations: 2, Quantity: 7},
+		{WarehouseID: 3, Reservations: 1, Quantity: 4},
+	}, totals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_GetReservationLogs_Filtered(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	filter := ReservationLogFilter{Status: "committed", From: &from, To: &to}
+
+	// The total is counted with the same filter as the page
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `reservation_logs` WHERE \\(warehouse_id = \\? AND product_id = \\?\\) AND status = \\? AND created_at >= \\? AND created_at < \\?").
+		WithArgs(1, 2, "committed", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` WHERE \\(warehouse_id = \\? AND product_id = \\?\\) AND status = \\? AND created_at >= \\? AND created_at < \\? ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
+		WithArgs(1, 2, "committed", from, to, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference", "created_at"}).
+			AddRow(9, 1, 2, 4, "committed", "RSV-1-2-123456", from.Add(time.Hour)))
+
+	logs, count, err := repo.GetReservationLogs(db, 1, 2, filter, 2, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "committed", logs[0].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_CommitReservation_RejectsNegativeQuantity(t *testing.T) {
+	repo, mock, db := setupReservationRepositoryTest()
+
+	// Only 2 units are left on hand although 5 are reserved, so committing 5 would drive the quantity to -3
+	mock.ExpectQuery("SELECT \\* FROM `warehouse_stock` WHERE warehouse_id = \\? AND product_id = \\?").
+		WithArgs(1, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "version"}).
+			AddRow(1, 1, 10, 2, 5, 0))
+
+	stock, err := repo.CommitReservation(db, 1, 10, 5, "RSV-1")
+
+	// The decrement is rejected rather than clamped to zero, and no UPDATE is issued
+	assert.ErrorIs(t, err, ErrNegativeStock)
+	assert.Nil(t, stock)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
\ No newline at end of file
@@ -1,7 +1,8 @@
 package repository
 
 import (
-	"fmt"
+	"errors"
+	"strconv"
 	"warehouse-service/internal/entity"
 
 	"github.com/sirupsen/logrus"
@@ -9,21 +10,69 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrStockVersionConflict is returned when a stock row was changed by another transaction
+// between being read and written. The whole transaction can be retried.
+var ErrStockVersionConflict = errors.New("stock version conflict")
+
+// ErrNegativeStock is returned instead of writing a stock row whose quantity or reserved quantity would be negative
+var ErrNegativeStock = errors.New("stock quantity cannot become negative")
+
+// ErrBelowReservedStock is returned when an adjustment would leave fewer units on hand than are reserved
+var ErrBelowReservedStock = errors.New("stock quantity cannot drop below the reserved quantity")
+
+// ErrInsufficientSourceStock is returned when the source warehouse cannot cover a transfer
+var ErrInsufficientSourceStock = errors.New("insufficient stock in source warehouse")
+
+// ErrSameWarehouseTransfer is returned when a transfer's source and target warehouse are the same
+var ErrSameWarehouseTransfer = errors.New("source and target warehouse must differ")
+
+// StockBalance is the quantity on hand and reserved quantity that a run of stock movements adds up to
+type StockBalance struct {
+	Quantity         int
+	ReservedQuantity int
+}
+
+// WarehouseProductStock is the stock of a single product aggregated per warehouse
+type WarehouseProductStock struct {
+	WarehouseID      uint
+	WarehouseName    string
+	Quantity         int
+	ReservedQuantity int
+}
+
 type StockRepositoryInterface interface {
 	// GetWarehouseStock retrieves stock in a warehouse with pagination
 	GetWarehouseStock(tx *gorm.DB, warehouseID uint, productID uint, limit, offset int) ([]entity.WarehouseStock, int64, error)
 	
+	// AdjustStock applies a signed correction to the stock of a product and records it as a StockAdjustment
+	AdjustStock(tx *gorm.DB, warehouseID, productID uint, delta int, reason, actor string) (*entity.WarehouseStock, *entity.StockAdjustment, error)
+	
 	// AddStock adds stock to a warehouse
 	AddStock(tx *gorm.DB, warehouseID, productID uint, productSKU string, quantity int, reference, notes string) (*entity.WarehouseStock, error)
 	
-	// TransferStock transfers stock between warehouses
+	// TransferStock transfers stock between warehouses; the caller's transaction must be rolled back on error
 	TransferStock(tx *gorm.DB, sourceWarehouseID, targetWarehouseID, productID uint, productSKU string, quantity int, reference string) (*entity.StockTransfer, error)
 	
 	// LogStockMovement records a stock movement
 	LogStockMovement(tx *gorm.DB, warehouseID, productID uint, productSKU string, movementType entity.MovementType, quantity int, referenceType, referenceID, notes string) error
 	
+	// GetStockMovements retrieves the stock movements of a product at a warehouse, oldest first, with pagination
+	GetStockMovements(tx *gorm.DB, warehouseID, productID uint, limit, offset int) ([]entity.StockMovement, int64, error)
+	
+	// SumStockMovementsBefore adds up the stock movements of a product at a warehouse recorded before the given movement
+	SumStockMovementsBefore(tx *gorm.DB, warehouseID, productID, movementID uint) (StockBalance, error)
+	
 	// GetStock gets a single stock record with locking if requested
 	GetStock(tx *gorm.DB, warehouseID, productID uint, forUpdate bool) (*entity.WarehouseStock, error)
+	
+	// GetProductAvailability gets the stock levels of a product at a warehouse, with zero quantities when there is no stock row
+	GetProductAvailability(tx *gorm.DB, warehouseID, productID uint) (*entity.WarehouseStock, error)
+	
+	// UpdateReorderThreshold sets the reorder threshold of a stock record
+	UpdateReorderThreshold(tx *gorm.DB, warehouseID, productID uint, threshold int) (*entity.WarehouseStock, error)
+	
+	// GetProductStockByWarehouse aggregates the stock of a product per active warehouse
+	GetProductStockByWarehouse(tx *gorm.DB, productID uint, includeEmpty bool) ([]WarehouseProductStock, error)
 }
 
 type StockRepository struct {
@@ -91,6 +140,9 @@ func (r *StockRepository) AddStock(tx *gorm.DB, warehouseID, productID uint, pro
 			Quantity:         quantity,
 			ReservedQuantity: 0,
 		}
+		if err := checkStockNotNegative(stock); err != nil {
+			return nil, err
+		}
 		
 		if err := tx.Create(stock).Error; err != nil {
 			return nil, err
@@ -98,7 +150,7 @@ func (r *StockRepository) AddStock(tx *gorm.DB, warehouseID, productID uint, pro
 	} else {
 		// Update existing stock
 		stock.Quantity += quantity
-		if err := tx.Save(stock).Error; err != nil {
+		if err := updateStockWithVersion(tx, stock); err != nil {
 			return nil, err
 		}
 	}
@@ -114,95 +166,111 @@ func (r *StockRepository) AddStock(tx *gorm.DB, warehouseID, productID uint, pro
 	return stock, nil
 }
 
-// TransferStock transfers stock between warehouses
-func (r *StockRepository) TransferStock(tx *gorm.DB, sourceWarehouseID, targetWarehouseID, productID uint, productSKU string, quantity int, reference string) (*entity.StockTransfer, error) {
-	// Create transfer record
-	transfer := &entity.StockTransfer{
-		SourceWarehouseID: sourceWarehouseID,
-		TargetWarehouseID: targetWarehouseID,
-		ProductID:         productID,
-		Quantity:          quantity,
-		Status:            entity.StatusPending,
-		TransferReference: reference,
+// AdjustStock applies a signed delta to the quantity of a product at a warehouse and records who made the
+// change, the quantities before and after and why. A product without a stock row counts as zero stock.
+// The adjustment is refused with ErrNegativeStock if the quantity would drop below zero, and with
+// ErrBelowReservedStock if it would drop below the reserved quantity.
+func (r *StockRepository) AdjustStock(tx *gorm.DB, warehouseID, productID uint, delta int, reason, actor string) (*entity.WarehouseStock, *entity.StockAdjustment, error) {
+	// Get the stock with locking
+	stock, err := r.GetStock(tx, warehouseID, productID, true)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, nil, err
 	}
-	
-	if err := tx.Create(transfer).Error; err != nil {
-		return nil, err
+	exists := err == nil
+	if !exists {
+		stock = &entity.WarehouseStock{
+			WarehouseID: warehouseID,
+			ProductID:   productID,
+		}
 	}
 	
-	// Lock both source and target stocks consistently (by warehouse ID to prevent deadlocks)
-	// Order the locks by warehouse ID to prevent deadlocks
-	isSourceFirst := sourceWarehouseID < targetWarehouseID
-	
-	if !isSourceFirst {
-		// First lock the warehouse with smaller ID to prevent deadlocks
-		// This is a common deadlock prevention technique
+	oldQuantity := stock.Quantity
+	stock.Quantity += delta
+	if err := checkStockNotNegative(stock); err != nil {
+		return nil, nil, err
 	}
-	
-	// Get first stock with lock
-	var firstStock, secondStock *entity.WarehouseStock
-	var err error
-	
-	if isSourceFirst {
-		firstStock, err = r.GetStock(tx, sourceWarehouseID, productID, true)
-	} else {
-		firstStock, err = r.GetStock(tx, targetWarehouseID, productID, true)
+	if stock.Quantity < stock.ReservedQuantity {
+		return nil, nil, ErrBelowReservedStock
 	}
 	
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return nil, err
+	if exists {
+		if err := updateStockWithVersion(tx, stock); err != nil {
+			return nil, nil, err
+		}
+	} else if err := tx.Create(stock).Error; err != nil {
+		return nil, nil, err
 	}
 	
-	// If first is source and not found or has insufficient stock
-	if isSourceFirst && (err == gorm.ErrRecordNotFound || firstStock.Quantity-firstStock.ReservedQuantity < quantity) {
-		transfer.Status = entity.StatusFailed
-		tx.Save(transfer)
-		return nil, fmt.Errorf("insufficient stock in source warehouse")
+	// Record the adjustment
+	adjustment := &entity.StockAdjustment{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Delta:       delta,
+		OldQuantity: oldQuantity,
+		NewQuantity: stock.Quantity,
+		Reason:      reason,
+		Actor:       actor,
+	}
+	if err := tx.Create(adjustment).Error; err != nil {
+		return nil, nil, err
 	}
 	
-	// Get second stock with lock
-	if isSourceFirst {
-		secondStock, err = r.GetStock(tx, targetWarehouseID, productID, true)
-	} else {
-		secondStock, err = r.GetStock(tx, sourceWarehouseID, productID, true)
+	// Log the stock movement with the signed correction
+	if err := logStockMovement(tx, warehouseID, productID, "", entity.MovementTypeAdjust, delta, "adjustment", strconv.FormatUint(uint64(adjustment.ID), 10), reason); err != nil {
+		return nil, nil, err
 	}
 	
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return nil, err
+	// Calculate available quantity
+	stock.CalculateAvailableQuantity()
+	
+	return stock, adjustment, nil
+}
+
+// TransferStock moves stock of a product from one warehouse to another within the caller's transaction.
+// Both stock rows are locked in ascending ID order to avoid deadlocks, and nothing is written before the
+// source is known to have enough available stock, so the caller can roll back the whole transfer on any error.
+func (r *StockRepository) TransferStock(tx *gorm.DB, sourceWarehouseID, targetWarehouseID, productID uint, productSKU string, quantity int, reference string) (*entity.StockTransfer, error) {
+	if sourceWarehouseID == targetWarehouseID {
+		return nil, ErrSameWarehouseTransfer
 	}
 	
-	// If second is source and not found or has insufficient stock
-	if !isSourceFirst && (err == gorm.ErrRecordNotFound || secondStock.Quantity-secondStock.ReservedQuantity < quantity) {
-		transfer.Status = entity.StatusFailed
-		tx.Save(transfer)
-		return nil, fmt.Errorf("insufficient stock in source warehouse")
+	// Find the stock rows of both warehouses, then lock them one by one in ascending ID order
+	var stockIDs []uint
+	err := tx.Model(&entity.WarehouseStock{}).
+		Where("product_id = ? AND warehouse_id IN ?", productID, []uint{sourceWarehouseID, targetWarehouseID}).
+		Order("id").
+		Pluck("id", &stockIDs).Error
+	if err != nil {
+		return nil, err
 	}
 	
-	// Source and target are now identified
 	var sourceStock, targetStock *entity.WarehouseStock
-	if isSourceFirst {
-		sourceStock = firstStock
-		targetStock = secondStock
-	} else {
-		sourceStock = secondStock
-		targetStock = firstStock
+	for _, id := range stockIDs {
+		stock := new(entity.WarehouseStock)
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(stock, id).Error; err != nil {
+			return nil, err
+		}
+		
+		if stock.WarehouseID == sourceWarehouseID {
+			sourceStock = stock
+		} else {
+			targetStock = stock
+		}
 	}
 	
-	// Ensure source has sufficient stock
-	if sourceStock.Quantity-sourceStock.ReservedQuantity < quantity {
-		transfer.Status = entity.StatusFailed
-		tx.Save(transfer)
-		return nil, fmt.Errorf("insufficient stock in source warehouse")
+	// Ensure source has sufficient stock before touching anything
+	if sourceStock == nil || sourceStock.Quantity-sourceStock.ReservedQuantity < quantity {
+		return nil, ErrInsufficientSourceStock
 	}
 	
 	// Decrease source stock
 	sourceStock.Quantity -= quantity
-	if err := tx.Save(sourceStock).Error; err != nil {
+	if err := updateStockWithVersion(tx, sourceStock); err != nil {
 		return nil, err
 	}
 	
 	// Create or update target stock
-	if targetStock == nil || err == gorm.ErrRecordNotFound {
+	if targetStock == nil {
 		targetStock = &entity.WarehouseStock{
 			WarehouseID:      targetWarehouseID,
 			ProductID:        productID,
@@ -214,7 +282,7 @@ func (r *StockRepository) TransferStock(tx *gorm.DB, sourceWarehouseID, targetWa
 		}
 	} else {
 		targetStock.Quantity += quantity
-		if err := tx.Save(targetStock).Error; err != nil {
+		if err := updateStockWithVersion(tx, targetStock); err != nil {
 			return nil, err
 		}
 	}
@@ -229,9 +297,16 @@ func (r *StockRepository) TransferStock(tx *gorm.DB, sourceWarehouseID, targetWa
 		return nil, err
 	}
 	
-	// Mark transfer as completed
-	transfer.Status = entity.StatusCompleted
-	if err := tx.Save(transfer).Error; err != nil {
+	// Record the completed transfer
+	transfer := &entity.StockTransfer{
+		SourceWarehouseID: sourceWarehouseID,
+		TargetWarehouseID: targetWarehouseID,
+		ProductID:         productID,
+		Quantity:          quantity,
+		Status:            entity.StatusCompleted,
+		TransferReference: reference,
+	}
+	if err := tx.Create(transfer).Error; err != nil {
 		return nil, err
 	}
 	
@@ -240,18 +315,49 @@ func (r *StockRepository) TransferStock(tx *gorm.DB, sourceWarehouseID, targetWa
 
 // LogStockMovement records a stock movement
 func (r *StockRepository) LogStockMovement(tx *gorm.DB, warehouseID, productID uint, productSKU string, movementType entity.MovementType, quantity int, referenceType, referenceID, notes string) error {
-	movement := &entity.StockMovement{
-		WarehouseID:   warehouseID,
-		ProductID:     productID,
-		ProductSKU:    productSKU,
-		MovementType:  movementType,
-		Quantity:      quantity,
-		ReferenceType: referenceType,
-		ReferenceID:   referenceID,
-		Notes:         notes,
+	return logStockMovement(tx, warehouseID, productID, productSKU, movementType, quantity, referenceType, referenceID, notes)
+}
+
+// GetStockMovements retrieves the stock movements of a product at a warehouse in the order they were recorded
+func (r *StockRepository) GetStockMovements(tx *gorm.DB, warehouseID, productID uint, limit, offset int) ([]entity.StockMovement, int64, error) {
+	var movements []entity.StockMovement
+	var count int64
+	
+	query := tx.Model(&entity.StockMovement{}).Where("warehouse_id = ? AND product_id = ?", warehouseID, productID)
+	
+	if err := query.Count(&count).Error; err != nil {
+		r.Log.WithError(err).Error("Failed to count stock movements")
+		return nil, 0, err
 	}
 	
-	return tx.Create(movement).Error
+	if err := query.Order("id").Limit(limit).Offset(offset).Find(&movements).Error; err != nil {
+		r.Log.WithError(err).Error("Failed to get stock movements")
+		return nil, 0, err
+	}
+	
+	return movements, count, nil
+}
+
+// SumStockMovementsBefore adds up how the stock movements of a product at a warehouse recorded before movementID
+// changed the quantity on hand and the reserved quantity, following StockMovement.QuantityChange and ReservedChange.
+func (r *StockRepository) SumStockMovementsBefore(tx *gorm.DB, warehouseID, productID, movementID uint) (StockBalance, error) {
+	var balance StockBalance
+	
+	err := tx.Model(&entity.StockMovement{}).
+		Select("COALESCE(SUM(CASE WHEN movement_type IN ? THEN -quantity WHEN movement_type IN ? THEN 0 ELSE quantity END), 0) AS quantity, "+
+			"COALESCE(SUM(CASE WHEN movement_type = ? THEN quantity WHEN movement_type IN ? THEN -quantity ELSE 0 END), 0) AS reserved_quantity",
+			[]entity.MovementType{entity.MovementTypeStockOut, entity.MovementTypeTransferOut, entity.MovementTypeCommit},
+			[]entity.MovementType{entity.MovementTypeReserve, entity.MovementTypeRelease},
+			entity.MovementTypeReserve,
+			[]entity.MovementType{entity.MovementTypeRelease, entity.MovementTypeCommit}).
+		Where("warehouse_id = ? AND product_id = ? AND id < ?", warehouseID, productID, movementID).
+		Scan(&balance).Error
+	if err != nil {
+		r.Log.WithError(err).Error("Failed to sum stock movements")
+		return StockBalance{}, err
+	}
+	
+	return balance, nil
 }
 
 // GetStock gets a single stock record with locking if requested
@@ -270,4 +376,119 @@ func (r *StockRepository) GetStock(tx *gorm.DB, warehouseID, productID uint, for
 	
 	stock.CalculateAvailableQuantity()
 	return stock, nil
+}
+
+// GetProductAvailability gets the stock levels of a product at a warehouse in a single query.
+// A product that has never been stocked at the warehouse is reported with zero quantities.
+func (r *StockRepository) GetProductAvailability(tx *gorm.DB, warehouseID, productID uint) (*entity.WarehouseStock, error) {
+	var stocks []entity.WarehouseStock
+	
+	result := tx.Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).Limit(1).Find(&stocks)
+	if result.Error != nil {
+		r.Log.WithError(result.Error).Error("Failed to get product availability")
+		return nil, result.Error
+	}
+	
+	if len(stocks) == 0 {
+		return &entity.WarehouseStock{
+			WarehouseID: warehouseID,
+			ProductID:   productID,
+		}, nil
+	}
+	
+	stock := &stocks[0]
+	stock.CalculateAvailableQuantity()
+	return stock, nil
+}
+
+// UpdateReorderThreshold sets the reorder threshold of a stock record.
+// Only the threshold column is written, so it never conflicts with versioned quantity updates.
+func (r *StockRepository) UpdateReorderThreshold(tx *gorm.DB, warehouseID, productID uint, threshold int) (*entity.WarehouseStock, error) {
+	stock, err := r.GetStock(tx, warehouseID, productID, false)
+	if err != nil {
+		return nil, err
+	}
+	
+	stock.ReorderThreshold = threshold
+	if err := tx.Model(stock).Update("reorder_threshold", threshold).Error; err != nil {
+		r.Log.WithError(err).Error("Failed to update reorder threshold")
+		return nil, err
+	}
+	
+	return stock, nil
+}
+
+// GetProductStockByWarehouse aggregates the stock of a product per active, non-deleted warehouse in a single query.
+// Warehouses without stock of the product are only returned when includeEmpty is set.
+func (r *StockRepository) GetProductStockByWarehouse(tx *gorm.DB, productID uint, includeEmpty bool) ([]WarehouseProductStock, error) {
+	var stocks []WarehouseProductStock
+	
+	query := tx.Table("warehouses").
+		Select("warehouses.id AS warehouse_id, warehouses.name AS warehouse_name, " +
+			"COALESCE(SUM(warehouse_stock.quantity), 0) AS quantity, " +
+			"COALESCE(SUM(warehouse_stock.reserved_quantity), 0) AS reserved_quantity").
+		Joins("LEFT JOIN warehouse_stock ON warehouse_stock.warehouse_id = warehouses.id AND warehouse_stock.product_id = ?", productID).
+		Where("warehouses.is_active = ? AND warehouses.deleted_at IS NULL", true).
+		Group("warehouses.id, warehouses.name").
+		Order("warehouses.id")
+	
+	if !includeEmpty {
+		query = query.Having("COALESCE(SUM(warehouse_stock.quantity), 0) > 0")
+	}
+	
+	if err := query.Scan(&stocks).Error; err != nil {
+		r.Log.WithError(err).Error("Failed to aggregate product stock by warehouse")
+		return nil, err
+	}
+	
+	return stocks, nil
+}
+
+// logStockMovement records a stock movement in the caller's transaction, so it is only kept if the stock change is
+func logStockMovement(tx *gorm.DB, warehouseID, productID uint, productSKU string, movementType entity.MovementType, quantity int, referenceType, referenceID, notes string) error {
+	movement := &entity.StockMovement{
+		WarehouseID:   warehouseID,
+		ProductID:     productID,
+		ProductSKU:    productSKU,
+		MovementType:  movementType,
+		Quantity:      quantity,
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		Notes:         notes,
+	}
+	
+	return tx.Create(movement).Error
+}
+
+// checkStockNotNegative rejects stock whose quantity or reserved quantity went below zero
+func checkStockNotNegative(stock *entity.WarehouseStock) error {
+	if stock.Quantity < 0 || stock.ReservedQuantity < 0 {
+		return ErrNegativeStock
+	}
+	return nil
+}
+
+// updateStockWithVersion writes the stock quantities only if the row still has the version that was read,
+// bumping the version on success. A concurrent writer makes the update match no rows and yields ErrStockVersionConflict.
+func updateStockWithVersion(tx *gorm.DB, stock *entity.WarehouseStock) error {
+	if err := checkStockNotNegative(stock); err != nil {
+		return err
+	}
+	
+	result := tx.Model(&entity.WarehouseStock{}).
+		Where("id = ? AND version = ?", stock.ID, stock.Version).
+		Updates(map[string]interface{}{
+			"quantity":          stock.Quantity,
+			"reserved_quantity": stock.ReservedQuantity,
+			"version":           gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStockVersionConflict
+	}
+	
+	stock.Version++
+	return nil
 }
\ No newline at end of file
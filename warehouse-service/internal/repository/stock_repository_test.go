@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"warehouse-service/internal/entity"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupStockRepositoryTest() (*StockRepository, sqlmock.Sqlmock, *gorm.DB) {
+	// Initialize mock database
+	mockDb, mock, _ := sqlmock.New()
+
+	// Add the expected query for SELECT VERSION()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	// Proceed with the GORM setup
+	dialector := mysql.New(mysql.Config{
+		Conn:       mockDb,
+		DriverName: "mysql",
+	})
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		logrus.Fatal("Error opening DB connection: ", err)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(logrus.StandardLogger().Out)
+
+	repo := &StockRepository{
+		DB:  db,
+		Log: logger,
+	}
+
+	return repo, mock, db
+}
+
+func TestStockRepository_GetProductAvailability_Stocked(t *testing.T) {
+	repo, mock, db := setupStockRepositoryTest()
+
+	warehouseID := uint(1)
+	productID := uint(10)
+
+	rows := sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "updated_at"}).
+		AddRow(1, warehouseID, productID, 50, 15, time.Now())
+
+	mock.ExpectQuery("SELECT (.+) FROM `warehouse_stock` WHERE").
+		WithArgs(warehouseID, productID, 1).
+		WillReturnRows(rows)
+
+	// Call the method
+	stock, err := repo.GetProductAvailability(db, warehouseID, productID)
+
+	// Assert results
+	assert.NoError(t, err)
+	assert.NotNil(t, stock)
+	assert.Equal(t, 50, stock.Quantity)
+	assert.Equal(t, 15, stock.ReservedQuantity)
+	assert.Equal(t, 35, stock.AvailableQuantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_GetProductAvailability_Unstocked(t *testing.T) {
+	repo, mock, db := setupStockRepositoryTest()
+
+	warehouseID := uint(1)
+	productID := uint(99)
+
+	// No stock row exists for the product at this warehouse
+	rows := sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "updated_at"})
+
+	mock.ExpectQuery("SELECT (.+) FROM `warehouse_stock` WHERE").
+		WithArgs(warehouseID, productID, 1).
+		WillReturnRows(rows)
+
+	// Call the method
+	stock, err := repo.GetProductAvailability(db, warehouseID, productID)
+
+	// Assert results
+	assert.NoError(t, err)
+	assert.NotNil(t, stock)
+	assert.Equal(t, warehouseID, stock.WarehouseID)
+	assert.Equal(t, productID, stock.ProductID)
+	assert.Equal(t, 0, stock.Quantity)
+	assert.Equal(t, 0, stock.ReservedQuantity)
+	assert.Equal(t, 0, stock.AvailableQuantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_UpdateStockWithVersion(t *testing.T) {
+	_, mock, db := setupStockRepositoryTest()
+
+	stock := &entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 50, ReservedQuantity: 20, Version: 3}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `warehouse_stock` SET (.+)`version`=version \\+ 1(.+)WHERE id = \\? AND version = \\?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := updateStockWithVersion(db, stock)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), stock.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_UpdateStockWithVersion_Conflict(t *testing.T) {
+	_, mock, db := setupStockRepositoryTest()
+
+	stock := &entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 50, ReservedQuantity: 20, Version: 3}
+
+	// Another transaction already bumped the version, so the update matches no rows
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `warehouse_stock` SET (.+)WHERE id = \\? AND version = \\?").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := updateStockWithVersion(db, stock)
+
+	assert.ErrorIs(t, err, ErrStockVersionConflict)
+	assert.Equal(t, uint(3), stock.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_UpdateStockWithVersion_RejectsNegativeStock(t *testing.T) {
+	_, mock, db := setupStockRepositoryTest()
+
+	tests := []struct {
+		name  string
+		stock *entity.WarehouseStock
+	}{
+		{"NegativeQuantity", &entity.WarehouseStock{ID: 1, Quantity: -1, ReservedQuantity: 0, Version: 3}},
+		{"NegativeReservedQuantity", &entity.WarehouseStock{ID: 1, Quantity: 5, ReservedQuantity: -2, Version: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := updateStockWithVersion(db, tt.stock)
+
+			// The write is refused outright; nothing reaches the database
+			assert.ErrorIs(t, err, ErrNegativeStock)
+			assert.Equal(t, uint(3), tt.stock.Version)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStockRepository_GetProductStockByWarehouse(t *testing.T) {
+	repo, mock, db := setupStockRepositoryTest()
+
+	productID := uint(10)
+
+	rows := sqlmock.NewRows([]string{"warehouse_id", "warehouse_name", "quantity", "reserved_quantity"}).
+		AddRow(1, "Jakarta", 50, 15).
+		AddRow(2, "Bandung", 20, 0)
+
+	mock.ExpectQuery("SELECT warehouses.id AS warehouse_id, (.+) FROM `warehouses` LEFT JOIN warehouse_stock (.+) WHERE warehouses.is_active = \\? AND warehouses.deleted_at IS NULL GROUP BY warehouses.id, warehouses.name HAVING (.+) > 0 ORDER BY warehouses.id").
+		WithArgs(productID, true).
+		WillReturnRows(rows)
+
+	stocks, err := repo.GetProductStockByWarehouse(db, productID, false)
+
+	assert.NoError(t, err)
+	assert.Len(t, stocks, 2)
+	assert.Equal(t, WarehouseProductStock{WarehouseID: 1, WarehouseName: "Jakarta", Quantity: 50, ReservedQuantity: 15}, stocks[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_GetProductStockByWarehouse_IncludeEmpty(t *testing.T) {
+	repo, mock, db := setupStockRepositoryTest()
+
+	productID := uint(10)
+
+	rows := sqlmock.NewRows([]string{"warehouse_id", "warehouse_name", "quantity", "reserved_quantity"}).
+		AddRow(1, "Jakarta", 50, 15).
+		AddRow(3, "Surabaya", 0, 0)
+
+	// Without the HAVING clause warehouses with no stock of the product are kept
+	mock.ExpectQuery("GROUP BY warehouses.id, warehouses.name ORDER BY warehouses.id").
+		WithArgs(productID, true).
+		WillReturnRows(rows)
+
+	stocks, err := repo.GetProductStockByWarehouse(db, productID, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, stocks, 2)
+	assert.Equal(t, 0, stocks[1].Quantity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStockRepository_SumStockMovementsBefore(t *testing.T) {
+	repo, mock, db := setupStockRepositoryTest()
+
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CASE (.+) FROM `stock_movements` WHERE warehouse_id = \\? AND product_id = \\? AND id < \\?").
+		WithArgs(
+			entity.MovementTypeStockOut, entity.MovementTypeTransferOut, entity.MovementTypeCommit,
+			entity.MovementTypeReserve, entity.MovementTypeRelease,
+			entity.MovementTypeReserve,
+			entity.MovementTypeRelease, entity.MovementTypeCommit,
+			1, 10, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "reserved_quantity"}).AddRow(11, 2))
+
+	balance, err := repo.SumStockMovementsBefore(db, 1, 10, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StockBalance{Quantity: 11, ReservedQuantity: 2}, balance)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,17 +7,37 @@ import (
 	"gorm.io/gorm"
 )
 
+// WarehouseFilter narrows a warehouse listing; zero values match everything.
+// Search matches part of the name or address and Location must match exactly.
+type WarehouseFilter struct {
+	Search     string
+	Location   string
+	ActiveOnly bool
+}
+
+// WarehouseStockTotal sums the stock rows of a single warehouse
+type WarehouseStockTotal struct {
+	WarehouseID   uint
+	TotalProducts int64
+	TotalItems    int64
+	TotalReserved int64
+}
+
 type WarehouseRepositoryInterface interface {
 	// Warehouse operations
 	FindByID(db *gorm.DB, id uint) (*entity.Warehouse, error)
+	FindByIDWithDeleted(db *gorm.DB, id uint) (*entity.Warehouse, error)
+	FindByIDs(db *gorm.DB, ids []uint) ([]entity.Warehouse, error)
 	Create(db *gorm.DB, warehouse *entity.Warehouse) error
 	Update(db *gorm.DB, warehouse *entity.Warehouse) error
 	Delete(db *gorm.DB, id uint) error
-	List(db *gorm.DB, limit, offset int) ([]entity.Warehouse, int64, error)
+	Restore(db *gorm.DB, id uint) error
+	List(db *gorm.DB, filter WarehouseFilter, limit, offset int) ([]entity.Warehouse, int64, error)
 	
 	// Stock operations
 	GetProductCount(db *gorm.DB, warehouseID uint) (int64, error)
 	GetTotalItemCount(db *gorm.DB, warehouseID uint) (int64, error)
+	SumStockByWarehouseIDs(db *gorm.DB, warehouseIDs []uint) ([]WarehouseStockTotal, error)
 	GetWarehouseStock(db *gorm.DB, warehouseID uint, productID uint) (*entity.WarehouseStock, error)
 	ListWarehouseStock(db *gorm.DB, warehouseID uint, limit, offset int) ([]entity.WarehouseStock, int64, error)
 	UpdateStock(db *gorm.DB, stock *entity.WarehouseStock) error
@@ -44,6 +64,20 @@ func (r *WarehouseRepository) FindByID(db *gorm.DB, id uint) (*entity.Warehouse,
 	return warehouse, nil
 }
 
+// FindByIDWithDeleted finds a warehouse by ID even if it has been soft-deleted
+func (r *WarehouseRepository) FindByIDWithDeleted(db *gorm.DB, id uint) (*entity.Warehouse, error) {
+	return r.FindByID(db.Unscoped(), id)
+}
+
+// FindByIDs finds the warehouses with the given IDs in a single query. Unknown IDs are skipped.
+func (r *WarehouseRepository) FindByIDs(db *gorm.DB, ids []uint) ([]entity.Warehouse, error) {
+	var warehouses []entity.Warehouse
+	if err := db.Where("id IN ?", ids).Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+	return warehouses, nil
+}
+
 // Create creates a new warehouse
 func (r *WarehouseRepository) Create(db *gorm.DB, warehouse *entity.Warehouse) error {
 	return db.Create(warehouse).Error
@@ -54,27 +88,33 @@ func (r *WarehouseRepository) Update(db *gorm.DB, warehouse *entity.Warehouse) e
 	return db.Save(warehouse).Error
 }
 
-// Delete deletes a warehouse by ID
+// Delete soft-deletes a warehouse by setting its deleted_at timestamp
 func (r *WarehouseRepository) Delete(db *gorm.DB, id uint) error {
 	return db.Delete(&entity.Warehouse{}, id).Error
 }
 
-// List retrieves warehouses with pagination
-func (r *WarehouseRepository) List(db *gorm.DB, limit, offset int) ([]entity.Warehouse, int64, error) {
+// Restore clears the deleted_at timestamp of a soft-deleted warehouse
+func (r *WarehouseRepository) Restore(db *gorm.DB, id uint) error {
+	return db.Unscoped().Model(&entity.Warehouse{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// List retrieves the warehouses that match the filter with pagination
+func (r *WarehouseRepository) List(db *gorm.DB, filter WarehouseFilter, limit, offset int) ([]entity.Warehouse, int64, error) {
 	var warehouses []entity.Warehouse
 	var count int64
 	
-	err := db.Model(&entity.Warehouse{}).Count(&count).Error
+	// Count the matching records so the total agrees with the filtered pages
+	err := filterWarehouses(db.Model(&entity.Warehouse{}), filter).Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 	
+	query := filterWarehouses(db, filter)
 	if limit > 0 {
-		err = db.Limit(limit).Offset(offset).Find(&warehouses).Error
-	} else {
-		err = db.Find(&warehouses).Error
+		query = query.Limit(limit).Offset(offset)
 	}
 	
+	err = query.Find(&warehouses).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -82,6 +122,24 @@ func (r *WarehouseRepository) List(db *gorm.DB, limit, offset int) ([]entity.War
 	return warehouses, count, nil
 }
 
+// filterWarehouses applies the warehouse filter conditions to a query
+func filterWarehouses(query *gorm.DB, filter WarehouseFilter) *gorm.DB {
+	if filter.Search != "" {
+		searchPattern := "%" + filter.Search + "%"
+		query = query.Where("name LIKE ? OR address LIKE ?", searchPattern, searchPattern)
+	}
+
+	if filter.Location != "" {
+		query = query.Where("location = ?", filter.Location)
+	}
+
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	return query
+}
+
 // GetProductCount returns the count of unique products in a warehouse
 func (r *WarehouseRepository) GetProductCount(db *gorm.DB, warehouseID uint) (int64, error) {
 	var count int64
@@ -101,6 +159,28 @@ func (r *WarehouseRepository) GetTotalItemCount(db *gorm.DB, warehouseID uint) (
 	return total, err
 }
 
+// SumStockByWarehouseIDs returns the product count, total item count and total reserved count of each warehouse
+// in one grouped query.
+// Warehouses without stock rows are left out.
+func (r *WarehouseRepository) SumStockByWarehouseIDs(db *gorm.DB, warehouseIDs []uint) ([]WarehouseStockTotal, error) {
+	var totals []WarehouseStockTotal
+	if len(warehouseIDs) == 0 {
+		return totals, nil
+	}
+
+	err := db.Model(&entity.WarehouseStock{}).
+		Select("warehouse_id, COUNT(*) AS total_products, COALESCE(SUM(quantity), 0) AS total_items, " +
+			"COALESCE(SUM(reserved_quantity), 0) AS total_reserved").
+		Where("warehouse_id IN ?", warehouseIDs).
+		Group("warehouse_id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
 // GetWarehouseStock retrieves stock for a specific product in a warehouse
 func (r *WarehouseRepository) GetWarehouseStock(db *gorm.DB, warehouseID uint, productID uint) (*entity.WarehouseStock, error) {
 	stock := new(entity.WarehouseStock)
@@ -143,7 +223,7 @@ func (r *WarehouseRepository) ListWarehouseStock(db *gorm.DB, warehouseID uint,
 	return stocks, count, nil
 }
 
-// UpdateStock updates warehouse stock
+// UpdateStock updates warehouse stock, failing with ErrStockVersionConflict if the row changed since it was read
 func (r *WarehouseRepository) UpdateStock(db *gorm.DB, stock *entity.WarehouseStock) error {
-	return db.Save(stock).Error
+	return updateStockWithVersion(db, stock)
 }
\ No newline at end of file
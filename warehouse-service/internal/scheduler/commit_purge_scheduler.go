@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"warehouse-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CommitPurgeScheduler periodically deletes reservation commit records older than their retention
+type CommitPurgeScheduler struct {
+	ReservationUseCase usecase.ReservationUseCaseInterface
+	Retention          time.Duration
+	BatchSize          int
+	Log                *logrus.Logger
+
+	runner *periodicRunner
+}
+
+// NewCommitPurgeScheduler creates a new commit record purge scheduler
+func NewCommitPurgeScheduler(reservationUseCase usecase.ReservationUseCaseInterface, retention time.Duration, batchSize int, interval time.Duration, log *logrus.Logger) *CommitPurgeScheduler {
+	s := &CommitPurgeScheduler{
+		ReservationUseCase: reservationUseCase,
+		Retention:          retention,
+		BatchSize:          batchSize,
+		Log:                log,
+	}
+	s.runner = newPeriodicRunner("Commit purge scheduler", interval, s.purge, log)
+	return s
+}
+
+// Start runs a purge every interval until ctx is cancelled
+func (s *CommitPurgeScheduler) Start(ctx context.Context) {
+	s.runner.Start(ctx)
+}
+
+// Purge deletes old commit records once. It returns false without purging
+// when the previous purge is still running.
+func (s *CommitPurgeScheduler) Purge(ctx context.Context) bool {
+	return s.runner.RunOnce(ctx)
+}
+
+// purge deletes old commit records and logs how many were deleted
+func (s *CommitPurgeScheduler) purge(ctx context.Context) {
+	start := time.Now()
+	result, err := s.ReservationUseCase.PurgeCommittedReservations(ctx, s.Retention, s.BatchSize)
+
+	fields := logrus.Fields{
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if result != nil {
+		fields["deleted_records"] = result.DeletedRecords
+	}
+	if err != nil {
+		s.Log.WithError(err).WithFields(fields).Error("Commit purge failed")
+		return
+	}
+
+	s.Log.WithFields(fields).Info("Commit purge completed")
+}
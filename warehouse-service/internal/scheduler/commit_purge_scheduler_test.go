@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"warehouse-service/internal/model"
+	mockUsecase "warehouse-service/mocks/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommitPurgeScheduler_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReservationUseCase := mockUsecase.NewMockReservationUseCaseInterface(ctrl)
+
+	s := NewCommitPurgeScheduler(mockReservationUseCase, 720*time.Hour, 500, time.Hour, logrus.New())
+
+	t.Run("Success", func(t *testing.T) {
+		mockReservationUseCase.EXPECT().
+			PurgeCommittedReservations(gomock.Any(), 720*time.Hour, 500).
+			Return(&model.CommitPurgeResult{DeletedRecords: 3}, nil)
+
+		assert.True(t, s.Purge(context.Background()))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockReservationUseCase.EXPECT().
+			PurgeCommittedReservations(gomock.Any(), 720*time.Hour, 500).
+			Return(&model.CommitPurgeResult{DeletedRecords: 1}, errors.New("database error"))
+
+		assert.True(t, s.Purge(context.Background()))
+	})
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"warehouse-service/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ReservationExpiryScheduler periodically releases stock reservations that outlived their TTL
+type ReservationExpiryScheduler struct {
+	ReservationUseCase usecase.ReservationUseCaseInterface
+	Log                *logrus.Logger
+
+	runner *periodicRunner
+}
+
+// NewReservationExpiryScheduler creates a new reservation expiry scheduler
+func NewReservationExpiryScheduler(reservationUseCase usecase.ReservationUseCaseInterface, interval time.Duration, log *logrus.Logger) *ReservationExpiryScheduler {
+	s := &ReservationExpiryScheduler{
+		ReservationUseCase: reservationUseCase,
+		Log:                log,
+	}
+	s.runner = newPeriodicRunner("Reservation expiry scheduler", interval, s.sweep, log)
+	return s
+}
+
+// Start runs a sweep every interval until ctx is cancelled
+func (s *ReservationExpiryScheduler) Start(ctx context.Context) {
+	s.runner.Start(ctx)
+}
+
+// Sweep expires stale reservations once. It returns false without sweeping
+// when the previous sweep is still running.
+func (s *ReservationExpiryScheduler) Sweep(ctx context.Context) bool {
+	return s.runner.RunOnce(ctx)
+}
+
+// sweep expires stale reservations and logs how many were released
+func (s *ReservationExpiryScheduler) sweep(ctx context.Context) {
+	start := time.Now()
+	result, err := s.ReservationUseCase.ExpireStaleReservations(ctx)
+	if err != nil {
+		s.Log.WithError(err).Error("Reservation expiry sweep failed")
+		return
+	}
+
+	s.Log.WithFields(logrus.Fields{
+		"expired_reservations": result.ExpiredReservations,
+		"failed_reservations":  result.FailedReservations,
+		"duration_ms":          time.Since(start).Milliseconds(),
+	}).Info("Reservation expiry sweep completed")
+}
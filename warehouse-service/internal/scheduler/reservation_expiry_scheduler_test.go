@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"warehouse-service/internal/model"
+	mockUsecase "warehouse-service/mocks/usecase"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReservationExpiryScheduler_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReservationUseCase := mockUsecase.NewMockReservationUseCaseInterface(ctrl)
+
+	s := NewReservationExpiryScheduler(mockReservationUseCase, time.Minute, logrus.New())
+
+	t.Run("Success", func(t *testing.T) {
+		mockReservationUseCase.EXPECT().
+			ExpireStaleReservations(gomock.Any()).
+			Return(&model.ReservationExpiryResult{ExpiredReservations: 2, FailedReservations: 1}, nil)
+
+		assert.True(t, s.Sweep(context.Background()))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockReservationUseCase.EXPECT().
+			ExpireStaleReservations(gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		assert.True(t, s.Sweep(context.Background()))
+	})
+}
@@ -4,29 +4,63 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"warehouse-service/internal/entity"
 	appErrors "warehouse-service/internal/errors"
+	"warehouse-service/internal/gateway/notification"
 	"warehouse-service/internal/model"
 	"warehouse-service/internal/repository"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+// maxStockUpdateAttempts bounds how many times a stock transaction is retried after a version conflict
+const maxStockUpdateAttempts = 3
+
+// staleReservationBatchSize bounds how many stale reservations a single expiry sweep releases
+const staleReservationBatchSize = 100
+
+// errBatchRolledBack aborts the batch reservation transaction when an item lacks stock
+var errBatchRolledBack = errors.New("batch reservation rolled back")
+
 type ReservationUseCaseInterface interface {
 	// ReserveStock reserves stock for a product in a warehouse
 	ReserveStock(ctx context.Context, request *model.ReserveStockRequest) (*model.ReservationResponse, error)
 	
+	// ReserveStockBatch reserves stock for several items atomically
+	ReserveStockBatch(ctx context.Context, requests []model.ReserveStockRequest) (*model.ReserveStockBatchResponse, error)
+	
 	// CancelReservation cancels a previous reservation
 	CancelReservation(ctx context.Context, request *model.CancelReservationRequest) error
 	
-	// CommitReservation confirms a reservation and removes stock
+	// CommitReservation confirms a reservation and removes stock; repeating it with the same reference is a no-op
 	CommitReservation(ctx context.Context, request *model.CommitReservationRequest) error
 	
-	// GetReservationHistory retrieves reservation history for a product
-	GetReservationHistory(ctx context.Context, warehouseID, productID uint, page, limit int) (*model.ReservationHistoryResponse, error)
+	// RelocateReservation moves a reservation from one warehouse to another in a single transaction
+	RelocateReservation(ctx context.Context, reference string, fromWarehouseID, toWarehouseID, productID uint, quantity int) (*model.RelocateReservationResponse, error)
+	
+	// GetReservationHistory retrieves reservation history for a product, optionally filtered by status and date range
+	GetReservationHistory(ctx context.Context, warehouseID, productID uint, filter *model.ReservationHistoryFilter, page, limit int) (*model.ReservationHistoryResponse, error)
+	
+	// ExpireStaleReservations releases pending reservations that outlived the reservation TTL
+	ExpireStaleReservations(ctx context.Context) (*model.ReservationExpiryResult, error)
+	
+	// PurgeCommittedReservations deletes the commit records older than retention in batches of batchSize
+	PurgeCommittedReservations(ctx context.Context, retention time.Duration, batchSize int) (*model.CommitPurgeResult, error)
+	
+	// GetReservationByReference returns the active reservation of a product with the given reference
+	GetReservationByReference(ctx context.Context, warehouseID, productID uint, reference string) (*model.ReservationDetailResponse, error)
+	
+	// GetReservationsByReference lists every reservation with the given reference across warehouses and products
+	GetReservationsByReference(ctx context.Context, reference string) (*model.ReservationsByReferenceResponse, error)
+	
+	// FindReservationsOlderThan reports active reservations held for longer than age, with totals by warehouse
+	FindReservationsOlderThan(ctx context.Context, age time.Duration, page, limit int) (*model.AgedReservationReportResponse, error)
 }
 
 type ReservationUseCase struct {
@@ -35,6 +69,12 @@ type ReservationUseCase struct {
 	Validate            *validator.Validate
 	ReservationRepo     repository.ReservationRepositoryInterface
 	WarehouseRepository repository.WarehouseRepositoryInterface
+	AlertNotifier       notification.StockAlertNotifier
+	ReservationTTL      time.Duration
+	// MaxQuantity caps the units a single reservation may hold; zero disables the cap
+	MaxQuantity int
+	// Pagination bounds the page size of history and aged reservation reports; zero fields use the defaults
+	Pagination model.Pagination
 }
 
 func NewReservationUseCase(
@@ -43,6 +83,10 @@ func NewReservationUseCase(
 	validate *validator.Validate,
 	reservationRepo repository.ReservationRepositoryInterface,
 	warehouseRepo repository.WarehouseRepositoryInterface,
+	alertNotifier notification.StockAlertNotifier,
+	reservationTTL time.Duration,
+	maxQuantity int,
+	pagination model.Pagination,
 ) ReservationUseCaseInterface {
 	return &ReservationUseCase{
 		DB:                  db,
@@ -50,167 +94,598 @@ func NewReservationUseCase(
 		Validate:            validate,
 		ReservationRepo:     reservationRepo,
 		WarehouseRepository: warehouseRepo,
+		AlertNotifier:       alertNotifier,
+		ReservationTTL:      reservationTTL,
+		MaxQuantity:         maxQuantity,
+		Pagination:          pagination,
 	}
 }
 
-// ReserveStock reserves stock for a product in a warehouse with database-level locking
+// ReserveStock reserves stock for a product in a warehouse under the caller's reference, or a generated one,
+// retrying on concurrent stock updates
 func (u *ReservationUseCase) ReserveStock(ctx context.Context, request *model.ReserveStockRequest) (*model.ReservationResponse, error) {
+	if err := u.validateReserveQuantity("quantity", request.Quantity); err != nil {
+		u.Log.WithField("quantity", request.Quantity).Warn("Invalid quantity for stock reservation")
+		return nil, err
+	}
+
 	// Validate request
 	if err := u.Validate.Struct(request); err != nil {
 		u.Log.WithError(err).Warn("Invalid request body for stock reservation")
 		return nil, fiber.ErrBadRequest
 	}
 
-	// Start a transaction with high isolation level for consistency
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	var stock *entity.WarehouseStock
+	var reference string
+
+	err := u.withStockRetry(ctx, func(tx *gorm.DB) error {
+		// Verify warehouse exists
+		warehouse, err := u.WarehouseRepository.FindByID(tx, request.WarehouseID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			u.Log.WithError(err).Error("Failed to find warehouse")
+			return fiber.ErrInternalServerError
+		}
 
-	// Verify warehouse exists
-	warehouse, err := u.WarehouseRepository.FindByID(tx, request.WarehouseID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, appErrors.ErrResourceNotFound
+		// Check if warehouse is active
+		if !warehouse.IsActive {
+			return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, "Warehouse is not active")
 		}
-		u.Log.WithError(err).Error("Failed to find warehouse")
-		return nil, fiber.ErrInternalServerError
-	}
 
-	// Check if warehouse is active
-	if !warehouse.IsActive {
-		return nil, appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, "Warehouse is not active")
-	}
+		reference = reservationReference(request)
 
-	// Call repository to reserve stock with locking
-	stock, err := u.ReservationRepo.ReserveStock(tx, request.WarehouseID, request.ProductID, request.Quantity)
-	if err != nil {
-		u.Log.WithError(err).Error("Failed to reserve stock")
-		
-		// Check for specific error conditions
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, appErrors.ErrResourceNotFound
+		// Call repository to reserve stock
+		stock, err = u.ReservationRepo.ReserveStock(tx, request.WarehouseID, request.ProductID, request.Quantity, reference)
+		if err != nil {
+			if errors.Is(err, repository.ErrStockVersionConflict) {
+				return err
+			}
+			if errors.Is(err, repository.ErrNegativeStock) {
+				return appErrors.ErrInsufficientStock
+			}
+
+			u.Log.WithError(err).Error("Failed to reserve stock")
+
+			// Check for specific error conditions
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+
+			// Check for insufficient stock
+			if strings.HasPrefix(err.Error(), "insufficient stock") {
+				return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+			}
+
+			return fiber.ErrInternalServerError
 		}
-		
-		// Check for insufficient stock
-		if err.Error()[0:16] == "insufficient stock" {
-			return nil, appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+
+		// Log the reservation
+		err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID,
+			request.Quantity, string(model.ReservationStatusPending), reference)
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to create reservation log")
+			return fiber.ErrInternalServerError
 		}
-		
-		return nil, fiber.ErrInternalServerError
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Create reservation reference
-	reference := fmt.Sprintf("RSV-%d-%d-%d", request.WarehouseID, request.ProductID, time.Now().Unix())
+	return toReservationResponse(stock, reference), nil
+}
 
-	// Log the reservation
-	err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID, 
-		request.Quantity, string(model.ReservationStatusPending), reference)
-	if err != nil {
-		u.Log.WithError(err).Error("Failed to create reservation log")
-		return nil, fiber.ErrInternalServerError
+// ReserveStockBatch reserves stock for all items in a single transaction. If any item lacks stock,
+// every reservation of the batch is rolled back and the per-item results explain which items failed.
+func (u *ReservationUseCase) ReserveStockBatch(ctx context.Context, requests []model.ReserveStockRequest) (*model.ReserveStockBatchResponse, error) {
+	for i, request := range requests {
+		if err := u.validateReserveQuantity(fmt.Sprintf("items[%d].quantity", i), request.Quantity); err != nil {
+			u.Log.WithField("quantity", request.Quantity).Warn("Invalid quantity for batch stock reservation")
+			return nil, err
+		}
 	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		u.Log.WithError(err).Error("Failed to commit transaction")
-		return nil, fiber.ErrInternalServerError
+	// Validate request
+	if err := u.Validate.Struct(&model.ReserveStockBatchRequest{Items: requests}); err != nil {
+		u.Log.WithError(err).Warn("Invalid request body for batch stock reservation")
+		return nil, fiber.ErrBadRequest
 	}
 
-	// Build response
-	response := &model.ReservationResponse{
-		WarehouseID:        stock.WarehouseID,
-		ProductID:          stock.ProductID,
-		ReservedQuantity:   stock.ReservedQuantity,
-		AvailableQuantity:  stock.AvailableQuantity,
-		TotalQuantity:      stock.Quantity,
-		Reference:          reference,
-		Status:             model.ReservationStatusPending,
-		ReservationTime:    time.Now().Format(time.RFC3339),
+	var response *model.ReserveStockBatchResponse
+
+	err := u.withStockRetry(ctx, func(tx *gorm.DB) error {
+		response = &model.ReserveStockBatchResponse{
+			Reserved: true,
+			Results:  make([]model.ReserveStockBatchItemResult, 0, len(requests)),
+		}
+		checkedWarehouses := make(map[uint]bool)
+
+		for _, request := range requests {
+			// Verify each warehouse exists and is active once per batch
+			if !checkedWarehouses[request.WarehouseID] {
+				warehouse, err := u.WarehouseRepository.FindByID(tx, request.WarehouseID)
+				if err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return appErrors.ErrResourceNotFound
+					}
+					u.Log.WithError(err).Error("Failed to find warehouse")
+					return fiber.ErrInternalServerError
+				}
+
+				if !warehouse.IsActive {
+					return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, "Warehouse is not active")
+				}
+				checkedWarehouses[request.WarehouseID] = true
+			}
+
+			result := model.ReserveStockBatchItemResult{
+				WarehouseID: request.WarehouseID,
+				ProductID:   request.ProductID,
+				Quantity:    request.Quantity,
+			}
+
+			reference := reservationReference(&request)
+
+			stock, err := u.ReservationRepo.ReserveStock(tx, request.WarehouseID, request.ProductID, request.Quantity, reference)
+			if err != nil {
+				if errors.Is(err, repository.ErrStockVersionConflict) {
+					return err
+				}
+				if errors.Is(err, repository.ErrNegativeStock) {
+					return appErrors.ErrInsufficientStock
+				}
+
+				// Keep checking the remaining items so the caller sees every shortage at once
+				if strings.HasPrefix(err.Error(), "insufficient stock") {
+					result.Status = model.BatchItemStatusInsufficientStock
+					result.Error = err.Error()
+					response.Reserved = false
+					response.Results = append(response.Results, result)
+					continue
+				}
+
+				u.Log.WithError(err).Error("Failed to reserve stock")
+
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return appErrors.ErrResourceNotFound
+				}
+
+				return fiber.ErrInternalServerError
+			}
+
+			err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID,
+				request.Quantity, string(model.ReservationStatusPending), reference)
+			if err != nil {
+				u.Log.WithError(err).Error("Failed to create reservation log")
+				return fiber.ErrInternalServerError
+			}
+
+			result.Status = model.BatchItemStatusReserved
+			result.Reservation = toReservationResponse(stock, reference)
+			response.Results = append(response.Results, result)
+		}
+
+		if !response.Reserved {
+			for i := range response.Results {
+				if response.Results[i].Status == model.BatchItemStatusReserved {
+					response.Results[i].Status = model.BatchItemStatusRolledBack
+					response.Results[i].Reservation = nil
+				}
+			}
+			return errBatchRolledBack
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchRolledBack) {
+		return nil, err
 	}
 
 	return response, nil
 }
 
-// CancelReservation cancels a previous reservation
+// CancelReservation cancels the active reservation with the request's reference, retrying on concurrent stock updates
 func (u *ReservationUseCase) CancelReservation(ctx context.Context, request *model.CancelReservationRequest) error {
+	if err := validatePositiveQuantity("quantity", request.Quantity); err != nil {
+		u.Log.WithField("quantity", request.Quantity).Warn("Invalid quantity for cancellation")
+		return err
+	}
+
 	// Validate request
 	if err := u.Validate.Struct(request); err != nil {
 		u.Log.WithError(err).Warn("Invalid request body for cancellation")
 		return fiber.ErrBadRequest
 	}
 
-	// Start a transaction
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	return u.withStockRetry(ctx, func(tx *gorm.DB) error {
+		// Only a reservation made under this reference may be released
+		if err := u.checkActiveReservation(tx, request.WarehouseID, request.ProductID, request.Quantity, request.Reference, "cancel"); err != nil {
+			return err
+		}
 
-	// Cancel the reservation
-	err := u.ReservationRepo.CancelReservation(tx, request.WarehouseID, request.ProductID, request.Quantity)
-	if err != nil {
-		u.Log.WithError(err).Error("Failed to cancel reservation")
-		
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return appErrors.ErrResourceNotFound
+		// Cancel the reservation
+		err := u.ReservationRepo.CancelReservation(tx, request.WarehouseID, request.ProductID, request.Quantity, request.Reference)
+		if err != nil {
+			if errors.Is(err, repository.ErrStockVersionConflict) {
+				return err
+			}
+			if errors.Is(err, repository.ErrNegativeStock) {
+				return appErrors.ErrInsufficientStock
+			}
+
+			u.Log.WithError(err).Error("Failed to cancel reservation")
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+
+			// Check for specific error message
+			if strings.HasPrefix(err.Error(), "cannot cancel more than") {
+				return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+			}
+
+			return fiber.ErrInternalServerError
 		}
-		
-		// Check for specific error message
-		if err.Error()[0:24] == "cannot cancel more than" {
-			return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+
+		// Log the cancellation
+		err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID,
+			request.Quantity, string(model.ReservationStatusCancelled), request.Reference)
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to create cancellation log")
+			return fiber.ErrInternalServerError
 		}
-		
-		return fiber.ErrInternalServerError
+
+		return nil
+	})
+}
+
+// CommitReservation confirms the active reservation with the request's reference and removes stock, retrying on
+// concurrent stock updates.
+// Committing a reference that was already committed for the product is a no-op that succeeds.
+func (u *ReservationUseCase) CommitReservation(ctx context.Context, request *model.CommitReservationRequest) error {
+	if err := validatePositiveQuantity("quantity", request.Quantity); err != nil {
+		u.Log.WithField("quantity", request.Quantity).Warn("Invalid quantity for commit")
+		return err
 	}
 
-	// Log the cancellation
-	err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID, 
-		request.Quantity, string(model.ReservationStatusCancelled), request.Reference)
+	// Validate request
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Warn("Invalid request body for commit")
+		return fiber.ErrBadRequest
+	}
+
+	var stock *entity.WarehouseStock
+	var alreadyCommitted bool
+
+	err := u.withStockRetry(ctx, func(tx *gorm.DB) error {
+		// Record the reference first so a retried commit of the same reservation deducts nothing
+		firstCommit, err := u.ReservationRepo.MarkReservationCommitted(tx, request.WarehouseID, request.ProductID, request.Reference)
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to record reservation commit")
+			return fiber.ErrInternalServerError
+		}
+		alreadyCommitted = !firstCommit
+		if alreadyCommitted {
+			return nil
+		}
+
+		// Only a reservation made under this reference may be committed
+		if err := u.checkActiveReservation(tx, request.WarehouseID, request.ProductID, request.Quantity, request.Reference, "commit"); err != nil {
+			return err
+		}
+
+		// Commit the reservation
+		stock, err = u.ReservationRepo.CommitReservation(tx, request.WarehouseID, request.ProductID, request.Quantity, request.Reference)
+		if err != nil {
+			if errors.Is(err, repository.ErrStockVersionConflict) {
+				return err
+			}
+			if errors.Is(err, repository.ErrNegativeStock) {
+				return appErrors.ErrInsufficientStock
+			}
+
+			u.Log.WithError(err).Error("Failed to commit reservation")
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+
+			// Check for specific error message
+			if strings.HasPrefix(err.Error(), "cannot commit more than") {
+				return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+			}
+
+			return fiber.ErrInternalServerError
+		}
+
+		// Log the commit
+		err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID,
+			request.Quantity, string(model.ReservationStatusCommitted), request.Reference)
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to create commit log")
+			return fiber.ErrInternalServerError
+		}
+
+		return nil
+	})
 	if err != nil {
-		u.Log.WithError(err).Error("Failed to create cancellation log")
-		return fiber.ErrInternalServerError
+		return err
 	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		u.Log.WithError(err).Error("Failed to commit transaction")
+	if alreadyCommitted {
+		u.Log.WithFields(logrus.Fields{
+			"warehouse_id": request.WarehouseID,
+			"product_id":   request.ProductID,
+			"reference":    request.Reference,
+		}).Info("Reservation already committed, skipping stock deduction")
+		return nil
+	}
+
+	notifyIfBelowReorderThreshold(ctx, u.Log, u.AlertNotifier, stock, request.Quantity)
+	return nil
+}
+
+// reservationReference returns the caller's reference for a reservation, or generates a unique one when it is empty
+func reservationReference(request *model.ReserveStockRequest) string {
+	if request.Reference != "" {
+		return request.Reference
+	}
+	return "RSV-" + uuid.NewString()
+}
+
+// checkActiveReservation verifies that the product has an active reservation with the reference holding at least
+// quantity, so a commit or cancellation never releases stock reserved under another reference
+func (u *ReservationUseCase) checkActiveReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference, action string) error {
+	reservation, err := u.ReservationRepo.FindActiveReservation(tx, warehouseID, productID, reference)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.WithMessage(appErrors.ErrResourceNotFound, "Active reservation not found")
+		}
+		u.Log.WithError(err).Error("Failed to find reservation")
 		return fiber.ErrInternalServerError
 	}
 
+	if quantity > reservation.Quantity {
+		return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation,
+			fmt.Sprintf("cannot %s more than reserved: reserved %d, %s request %d", action, reservation.Quantity, action, quantity))
+	}
+
 	return nil
 }
 
-// CommitReservation confirms a reservation and removes stock
-func (u *ReservationUseCase) CommitReservation(ctx context.Context, request *model.CommitReservationRequest) error {
+// RelocateReservation moves the active reservation of a product with the given reference from one warehouse to
+// another, keeping its reference, in a single transaction retried on concurrent stock updates. The destination
+// reserves the quantity before the source releases it, so when the destination lacks stock nothing changes and
+// the source reservation stays in place. The whole reservation must move; quantity must match it.
+func (u *ReservationUseCase) RelocateReservation(ctx context.Context, reference string, fromWarehouseID, toWarehouseID, productID uint, quantity int) (*model.RelocateReservationResponse, error) {
 	// Validate request
+	request := &model.RelocateReservationRequest{
+		Reference:       reference,
+		FromWarehouseID: fromWarehouseID,
+		ToWarehouseID:   toWarehouseID,
+		ProductID:       productID,
+		Quantity:        quantity,
+	}
 	if err := u.Validate.Struct(request); err != nil {
-		u.Log.WithError(err).Warn("Invalid request body for commit")
-		return fiber.ErrBadRequest
+		u.Log.WithError(err).Warn("Invalid request body for reservation relocation")
+		return nil, fiber.ErrBadRequest
 	}
 
-	// Start a transaction
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	var stock *entity.WarehouseStock
+
+	err := u.withStockRetry(ctx, func(tx *gorm.DB) error {
+		// Find the reservation being moved
+		reservation, err := u.ReservationRepo.FindActiveReservation(tx, fromWarehouseID, productID, reference)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.WithMessage(appErrors.ErrResourceNotFound, "Active reservation not found")
+			}
+			u.Log.WithError(err).Error("Failed to find reservation")
+			return fiber.ErrInternalServerError
+		}
 
-	// Commit the reservation
-	err := u.ReservationRepo.CommitReservation(tx, request.WarehouseID, request.ProductID, request.Quantity)
-	if err != nil {
-		u.Log.WithError(err).Error("Failed to commit reservation")
-		
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return appErrors.ErrResourceNotFound
+		if reservation.Quantity != quantity {
+			return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation,
+				fmt.Sprintf("the whole reservation must be relocated: reserved %d, relocate request %d", reservation.Quantity, quantity))
 		}
-		
-		// Check for specific error message
-		if err.Error()[0:24] == "cannot commit more than" {
-			return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+
+		// Verify the destination warehouse exists and is active
+		warehouse, err := u.WarehouseRepository.FindByID(tx, toWarehouseID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appErrors.WithMessage(appErrors.ErrResourceNotFound, "Destination warehouse not found")
+			}
+			u.Log.WithError(err).Error("Failed to find warehouse")
+			return fiber.ErrInternalServerError
 		}
-		
-		return fiber.ErrInternalServerError
+
+		if !warehouse.IsActive {
+			return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, "Destination warehouse is not active")
+		}
+
+		// Reserve at the destination first; a shortage there aborts before the source is touched
+		stock, err = u.ReservationRepo.ReserveStock(tx, toWarehouseID, productID, quantity, reference)
+		if err != nil {
+			if errors.Is(err, repository.ErrStockVersionConflict) {
+				return err
+			}
+			if errors.Is(err, repository.ErrNegativeStock) {
+				return appErrors.ErrInsufficientStock
+			}
+			if strings.HasPrefix(err.Error(), "insufficient stock") {
+				return appErrors.WithMessage(appErrors.ErrInsufficientStock, "Destination warehouse has "+err.Error())
+			}
+			if strings.HasPrefix(err.Error(), "stock not found") {
+				return appErrors.WithMessage(appErrors.ErrInsufficientStock, "Destination warehouse does not stock this product")
+			}
+
+			u.Log.WithError(err).Error("Failed to reserve stock at destination warehouse")
+			return fiber.ErrInternalServerError
+		}
+
+		// Release the source reservation
+		err = u.ReservationRepo.CancelReservation(tx, fromWarehouseID, productID, quantity, reference)
+		if err != nil {
+			if errors.Is(err, repository.ErrStockVersionConflict) {
+				return err
+			}
+			if errors.Is(err, repository.ErrNegativeStock) {
+				return appErrors.ErrInsufficientStock
+			}
+
+			u.Log.WithError(err).Error("Failed to release reservation at source warehouse")
+
+			if strings.HasPrefix(err.Error(), "cannot cancel more than") {
+				return appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+			}
+
+			return fiber.ErrInternalServerError
+		}
+
+		// Log the release at the source and the new reservation at the destination
+		err = u.ReservationRepo.CreateReservationLog(tx, fromWarehouseID, productID,
+			quantity, string(model.ReservationStatusCancelled), reference)
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to create cancellation log")
+			return fiber.ErrInternalServerError
+		}
+
+		err = u.ReservationRepo.CreateReservationLog(tx, toWarehouseID, productID,
+			quantity, string(model.ReservationStatusPending), reference)
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to create reservation log")
+			return fiber.ErrInternalServerError
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Log the commit
-	err = u.ReservationRepo.CreateReservationLog(tx, request.WarehouseID, request.ProductID, 
-		request.Quantity, string(model.ReservationStatusCommitted), request.Reference)
+	u.Log.WithFields(logrus.Fields{
+		"reference":         reference,
+		"product_id":        productID,
+		"quantity":          quantity,
+		"from_warehouse_id": fromWarehouseID,
+		"to_warehouse_id":   toWarehouseID,
+	}).Info("Reservation relocated")
+
+	return &model.RelocateReservationResponse{
+		FromWarehouseID: fromWarehouseID,
+		Reservation:     toReservationResponse(stock, reference),
+	}, nil
+}
+
+// ExpireStaleReservations releases the reserved quantity of pending reservations older than the
+// reservation TTL back to available stock and records each release as expired in the reservation history.
+// Every reservation is released in its own transaction, so one failure does not block the others.
+func (u *ReservationUseCase) ExpireStaleReservations(ctx context.Context) (*model.ReservationExpiryResult, error) {
+	cutoff := time.Now().Add(-u.ReservationTTL)
+
+	staleReservations, err := u.ReservationRepo.FindStaleReservations(u.DB.WithContext(ctx), cutoff, staleReservationBatchSize)
 	if err != nil {
-		u.Log.WithError(err).Error("Failed to create commit log")
-		return fiber.ErrInternalServerError
+		u.Log.WithError(err).Error("Failed to find stale reservations")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	result := &model.ReservationExpiryResult{}
+	for _, reservation := range staleReservations {
+		err := u.withStockRetry(ctx, func(tx *gorm.DB) error {
+			if err := u.ReservationRepo.CancelReservation(tx, reservation.WarehouseID, reservation.ProductID, reservation.Quantity, reservation.Reference); err != nil {
+				return err
+			}
+
+			return u.ReservationRepo.CreateReservationLog(tx, reservation.WarehouseID, reservation.ProductID,
+				reservation.Quantity, string(model.ReservationStatusExpired), reservation.Reference)
+		})
+		if err != nil {
+			u.Log.WithError(err).WithFields(logrus.Fields{
+				"warehouse_id": reservation.WarehouseID,
+				"product_id":   reservation.ProductID,
+				"reference":    reservation.Reference,
+			}).Error("Failed to expire stale reservation")
+			result.FailedReservations++
+			continue
+		}
+
+		result.ExpiredReservations++
+	}
+
+	return result, nil
+}
+
+// PurgeCommittedReservations deletes the commit records created more than retention ago. They only guard
+// against a repeated commit while the commit may still be retried, so retention has to outlast the callers'
+// retries. Every batch of batchSize rows is its own statement so no delete holds its locks for long, and
+// batches continue until one comes back short or ctx is cancelled. On failure the count deleted so far is
+// returned with the error.
+func (u *ReservationUseCase) PurgeCommittedReservations(ctx context.Context, retention time.Duration, batchSize int) (*model.CommitPurgeResult, error) {
+	cutoff := time.Now().Add(-retention)
+
+	result := &model.CommitPurgeResult{}
+	for {
+		deleted, err := u.ReservationRepo.DeleteCommittedReservations(u.DB.WithContext(ctx), cutoff, batchSize)
+		result.DeletedRecords += deleted
+		if err != nil {
+			u.Log.WithError(err).Error("Failed to delete committed reservations")
+			return result, fiber.ErrInternalServerError
+		}
+		if deleted < int64(batchSize) {
+			return result, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+}
+
+// validateReserveQuantity rejects a reservation quantity that is not positive or exceeds MaxQuantity
+func (u *ReservationUseCase) validateReserveQuantity(field string, quantity int) error {
+	if err := validatePositiveQuantity(field, quantity); err != nil {
+		return err
+	}
+	if u.MaxQuantity > 0 && quantity > u.MaxQuantity {
+		return appErrors.WithMessage(appErrors.ErrInvalidInput,
+			fmt.Sprintf("%s must not exceed %d per reservation", field, u.MaxQuantity))
+	}
+	return nil
+}
+
+// validatePositiveQuantity rejects a quantity of zero or less
+func validatePositiveQuantity(field string, quantity int) error {
+	if quantity <= 0 {
+		return appErrors.WithMessage(appErrors.ErrInvalidInput, fmt.Sprintf("%s must be greater than 0", field))
+	}
+	return nil
+}
+
+// withStockRetry runs fn in its own transaction and commits it. When fn fails with a stock version
+// conflict the transaction is rolled back and retried from scratch, up to maxStockUpdateAttempts times.
+func (u *ReservationUseCase) withStockRetry(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	for attempt := 1; attempt <= maxStockUpdateAttempts; attempt++ {
+		err := u.runStockTransaction(ctx, fn)
+		if !errors.Is(err, repository.ErrStockVersionConflict) {
+			return err
+		}
+
+		u.Log.WithField("attempt", attempt).Warn("Stock version conflict, retrying transaction")
+	}
+
+	u.Log.Warn("Giving up after repeated stock version conflicts")
+	return appErrors.ErrStockConflict
+}
+
+// runStockTransaction runs fn in a single transaction
+func (u *ReservationUseCase) runStockTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
 	}
 
 	// Commit transaction
@@ -222,16 +697,40 @@ func (u *ReservationUseCase) CommitReservation(ctx context.Context, request *mod
 	return nil
 }
 
-// GetReservationHistory retrieves reservation history for a product
-func (u *ReservationUseCase) GetReservationHistory(ctx context.Context, warehouseID, productID uint, page, limit int) (*model.ReservationHistoryResponse, error) {
+// GetReservationHistory retrieves reservation history for a product, optionally filtered by status and date range
+func (u *ReservationUseCase) GetReservationHistory(ctx context.Context, warehouseID, productID uint, filter *model.ReservationHistoryFilter, page, limit int) (*model.ReservationHistoryResponse, error) {
+	// Validate filter
+	if err := u.Validate.Struct(filter); err != nil {
+		u.Log.WithError(err).Warn("Invalid reservation history filter")
+		return nil, fiber.ErrBadRequest
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		u.Log.Warn("Reservation history date range is empty")
+		return nil, fiber.ErrBadRequest
+	}
+
+	logFilter := repository.ReservationLogFilter{
+		Status: string(filter.Status),
+		From:   filter.From,
+		To:     filter.To,
+	}
+	// Reservations are logged as pending while they hold stock
+	if filter.Status == model.ReservationStatusReserved {
+		logFilter.Status = string(model.ReservationStatusPending)
+	}
+
 	// Calculate offset
+	if page < 1 {
+		page = 1
+	}
+	limit = u.Pagination.Limit(limit)
 	offset := (page - 1) * limit
 
 	// Start a transaction (read-only)
 	tx := u.DB.WithContext(ctx)
 
 	// Get reservation logs
-	logs, count, err := u.ReservationRepo.GetReservationLogs(tx, warehouseID, productID, limit, offset)
+	logs, count, err := u.ReservationRepo.GetReservationLogs(tx, warehouseID, productID, logFilter, limit, offset)
 	if err != nil {
 		u.Log.WithError(err).Error("Failed to get reservation logs")
 		return nil, fiber.ErrInternalServerError
@@ -258,4 +757,140 @@ func (u *ReservationUseCase) GetReservationHistory(ctx context.Context, warehous
 	}
 
 	return response, nil
+}
+
+// GetReservationByReference returns the reserved quantity, status and expiry of the active reservation of a
+// product with the given reference. Committed, cancelled and expired reservations are not found.
+func (u *ReservationUseCase) GetReservationByReference(ctx context.Context, warehouseID, productID uint, reference string) (*model.ReservationDetailResponse, error) {
+	if reference == "" {
+		return nil, fiber.ErrBadRequest
+	}
+
+	reservation, err := u.ReservationRepo.FindActiveReservation(u.DB.WithContext(ctx), warehouseID, productID, reference)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.WithMessage(appErrors.ErrResourceNotFound, "Active reservation not found")
+		}
+		u.Log.WithError(err).Error("Failed to find reservation")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.ReservationDetailResponse{
+		WarehouseID:      reservation.WarehouseID,
+		ProductID:        reservation.ProductID,
+		Reference:        reservation.Reference,
+		ReservedQuantity: reservation.Quantity,
+		Status:           model.ReservationStatus(reservation.Status),
+		ReservedAt:       reservation.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:        reservation.CreatedAt.Add(u.ReservationTTL).Format(time.RFC3339),
+	}, nil
+}
+
+// GetReservationsByReference lists every reservation log written with the given reference, across warehouses,
+// products and statuses, so another service can reconcile its own records of an order against the warehouse.
+// A reference without reservations yields an empty list rather than an error.
+func (u *ReservationUseCase) GetReservationsByReference(ctx context.Context, reference string) (*model.ReservationsByReferenceResponse, error) {
+	if reference == "" {
+		return nil, fiber.ErrBadRequest
+	}
+
+	reservations, err := u.ReservationRepo.FindReservationsByReference(u.DB.WithContext(ctx), reference)
+	if err != nil {
+		u.Log.WithError(err).WithField("reference", reference).Error("Failed to find reservations by reference")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.ReservationsByReferenceResponse{
+		Reference:    reference,
+		Total:        len(reservations),
+		Reservations: make([]model.ReferenceReservationResponse, 0, len(reservations)),
+	}
+
+	for _, reservation := range reservations {
+		response.Reservations = append(response.Reservations, model.ReferenceReservationResponse{
+			ID:          reservation.ID,
+			WarehouseID: reservation.WarehouseID,
+			ProductID:   reservation.ProductID,
+			Quantity:    reservation.Quantity,
+			Status:      model.ReservationStatus(reservation.Status),
+			CreatedAt:   reservation.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
+
+// FindReservationsOlderThan reports the active reservations created more than age ago that saw no commit,
+// cancellation, expiry or other activity since, so operators can spot stock still held for orders that were
+// already released. A zero age defaults to the reservation TTL. The report is read-only; releasing stock is
+// left to the expiry sweeper.
+func (u *ReservationUseCase) FindReservationsOlderThan(ctx context.Context, age time.Duration, page, limit int) (*model.AgedReservationReportResponse, error) {
+	if age < 0 || page < 1 {
+		return nil, fiber.ErrBadRequest
+	}
+	limit = u.Pagination.Limit(limit)
+	if age == 0 {
+		age = u.ReservationTTL
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-age)
+	tx := u.DB.WithContext(ctx)
+
+	reservations, count, err := u.ReservationRepo.FindReservationsOlderThan(tx, cutoff, limit, (page-1)*limit)
+	if err != nil {
+		u.Log.WithError(err).Error("Failed to find aged reservations")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	totals, err := u.ReservationRepo.SumReservationsOlderThan(tx, cutoff)
+	if err != nil {
+		u.Log.WithError(err).Error("Failed to total aged reservations")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.AgedReservationReportResponse{
+		OlderThan:    age.String(),
+		Cutoff:       cutoff.Format(time.RFC3339),
+		Total:        count,
+		Page:         page,
+		Limit:        limit,
+		Reservations: make([]model.AgedReservationResponse, 0, len(reservations)),
+		Warehouses:   make([]model.AgedReservationWarehouseTotal, 0, len(totals)),
+	}
+
+	for _, reservation := range reservations {
+		response.Reservations = append(response.Reservations, model.AgedReservationResponse{
+			WarehouseID: reservation.WarehouseID,
+			ProductID:   reservation.ProductID,
+			Reference:   reservation.Reference,
+			Quantity:    reservation.Quantity,
+			ReservedAt:  reservation.CreatedAt.Format(time.RFC3339),
+			AgeSeconds:  int64(now.Sub(reservation.CreatedAt).Seconds()),
+		})
+	}
+
+	for _, total := range totals {
+		response.Warehouses = append(response.Warehouses, model.AgedReservationWarehouseTotal{
+			WarehouseID:  total.WarehouseID,
+			Reservations: total.Reservations,
+			Quantity:     total.Quantity,
+		})
+	}
+
+	return response, nil
+}
+
+// toReservationResponse builds the response for a freshly made reservation
+func toReservationResponse(stock *entity.WarehouseStock, reference string) *model.ReservationResponse {
+	return &model.ReservationResponse{
+		WarehouseID:       stock.WarehouseID,
+		ProductID:         stock.ProductID,
+		ReservedQuantity:  stock.ReservedQuantity,
+		AvailableQuantity: stock.AvailableQuantity,
+		TotalQuantity:     stock.Quantity,
+		Reference:         reference,
+		Status:            model.ReservationStatusPending,
+		ReservationTime:   time.Now().Format(time.RFC3339),
+	}
 }
\ No newline at end of file
@@ -0,0 +1,1103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"warehouse-service/internal/entity"
+	appErrors "warehouse-service/internal/errors"
+	"warehouse-service/internal/model"
+	"warehouse-service/internal/repository"
+	mockRepository "warehouse-service/mocks/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// versionedStockRepository keeps a single stock row in memory and rejects writes whose
+// version no longer matches, the same way the versioned UPDATE does in MySQL.
+type versionedStockRepository struct {
+	repository.ReservationRepositoryInterface
+
+	mu    sync.Mutex
+	stock entity.WarehouseStock
+
+	// readBarrier, when set, holds the first reads until all of them happened so they see the same version
+	readBarrier *sync.WaitGroup
+	readers     int32
+
+	// forcedConflicts makes that many writes fail as if another transaction got there first
+	forcedConflicts int32
+	writes          int32
+
+	// staleReservations is returned by FindStaleReservations, which records the cutoff it was asked for
+	staleReservations []entity.ReservationLog
+	staleCutoff       time.Time
+
+	// logStatuses records the status of every reservation log written
+	logStatuses []string
+
+	// committedReferences remembers references whose commit log was written, the last step of a commit
+	// transaction, so a transaction retried after a conflict is not mistaken for a repeated commit
+	committedReferences map[string]bool
+
+	// reservations holds the quantity of each active reservation by reference; a pending log adds one
+	// and any other log resolves it
+	reservations map[string]int
+}
+
+func (r *versionedStockRepository) read() entity.WarehouseStock {
+	r.mu.Lock()
+	snapshot := r.stock
+	r.mu.Unlock()
+
+	if r.readBarrier != nil && atomic.AddInt32(&r.readers, 1) <= 2 {
+		r.readBarrier.Done()
+		r.readBarrier.Wait()
+	}
+
+	return snapshot
+}
+
+func (r *versionedStockRepository) write(stock entity.WarehouseStock) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if atomic.AddInt32(&r.writes, 1) <= r.forcedConflicts || r.stock.Version != stock.Version {
+		return repository.ErrStockVersionConflict
+	}
+
+	stock.Version++
+	r.stock = stock
+	return nil
+}
+
+func (r *versionedStockRepository) ReserveStock(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error) {
+	stock := r.read()
+	stock.CalculateAvailableQuantity()
+	if stock.AvailableQuantity < quantity {
+		return nil, fmt.Errorf("insufficient stock: requested %d, available %d", quantity, stock.AvailableQuantity)
+	}
+
+	stock.ReservedQuantity += quantity
+	if err := r.write(stock); err != nil {
+		return nil, err
+	}
+
+	stock.CalculateAvailableQuantity()
+	return &stock, nil
+}
+
+func (r *versionedStockRepository) CommitReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error) {
+	stock := r.read()
+	if stock.ReservedQuantity < quantity {
+		return nil, fmt.Errorf("cannot commit more than reserved: reserved %d, commit request %d", stock.ReservedQuantity, quantity)
+	}
+
+	stock.ReservedQuantity -= quantity
+	stock.Quantity -= quantity
+	if err := r.write(stock); err != nil {
+		return nil, err
+	}
+
+	return &stock, nil
+}
+
+func (r *versionedStockRepository) CancelReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) error {
+	stock := r.read()
+	if stock.ReservedQuantity < quantity {
+		return fmt.Errorf("cannot cancel more than reserved: reserved %d, cancel request %d", stock.ReservedQuantity, quantity)
+	}
+
+	stock.ReservedQuantity -= quantity
+	return r.write(stock)
+}
+
+func (r *versionedStockRepository) CreateReservationLog(tx *gorm.DB, warehouseID, productID uint, quantity int, status string, reference string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logStatuses = append(r.logStatuses, reference+":"+status)
+	if r.reservations == nil {
+		r.reservations = make(map[string]int)
+	}
+	if status == string(model.ReservationStatusPending) {
+		r.reservations[reference] = quantity
+	} else {
+		delete(r.reservations, reference)
+	}
+	if status == string(model.ReservationStatusCommitted) {
+		if r.committedReferences == nil {
+			r.committedReferences = make(map[string]bool)
+		}
+		r.committedReferences[reference] = true
+	}
+	return nil
+}
+
+func (r *versionedStockRepository) MarkReservationCommitted(tx *gorm.DB, warehouseID, productID uint, reference string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return !r.committedReferences[reference], nil
+}
+
+func (r *versionedStockRepository) FindActiveReservation(tx *gorm.DB, warehouseID, productID uint, reference string) (*entity.ReservationLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	quantity, ok := r.reservations[reference]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &entity.ReservationLog{WarehouseID: warehouseID, ProductID: productID, Quantity: quantity, Status: "pending", Reference: reference}, nil
+}
+
+func (r *versionedStockRepository) FindStaleReservations(tx *gorm.DB, cutoff time.Time, limit int) ([]entity.ReservationLog, error) {
+	r.staleCutoff = cutoff
+	return r.staleReservations, nil
+}
+
+// recordingStockAlertNotifier remembers the low-stock alerts it receives
+type recordingStockAlertNotifier struct {
+	alerts []int
+}
+
+func (n *recordingStockAlertNotifier) NotifyLowStock(ctx context.Context, warehouseID, productID uint, remainingQuantity int) error {
+	n.alerts = append(n.alerts, remainingQuantity)
+	return nil
+}
+
+func setupReservationUsecaseTest(t *testing.T, stockRepo *versionedStockRepository) (*ReservationUseCase, sqlmock.Sqlmock) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard) // Suppress log output during tests
+
+	mockDb, mock, _ := sqlmock.New()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: mockDb, DriverName: "mysql"}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error opening DB connection: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	warehouseRepo := mockRepository.NewMockWarehouseRepositoryInterface(ctrl)
+	warehouseRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&entity.Warehouse{ID: 1, IsActive: true}, nil).AnyTimes()
+
+	return &ReservationUseCase{
+		DB:                  db,
+		Log:                 logger,
+		Validate:            validator.New(),
+		ReservationRepo:     stockRepo,
+		WarehouseRepository: warehouseRepo,
+	}, mock
+}
+
+// expectRetriedTransactions allows enough transactions for two requests that both retry up to the limit.
+// Transactions of concurrent requests interleave, so expectations are matched in any order.
+func expectRetriedTransactions(mock sqlmock.Sqlmock) {
+	mock.MatchExpectationsInOrder(false)
+	for i := 0; i < 2*maxStockUpdateAttempts; i++ {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+	}
+}
+
+func TestReservationUsecase_ReserveStock_Concurrent(t *testing.T) {
+	barrier := &sync.WaitGroup{}
+	barrier.Add(2)
+	stockRepo := &versionedStockRepository{
+		stock:       entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5},
+		readBarrier: barrier,
+	}
+	usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+	expectRetriedTransactions(mock)
+
+	// Both requests read the same version; only one of them fits in the available stock
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 3})
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		// The loser retries, sees the new stock level and is rejected
+		assert.ErrorIs(t, err, appErrors.ErrBusinessRuleViolation)
+	}
+
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, 3, stockRepo.stock.ReservedQuantity)
+	assert.LessOrEqual(t, stockRepo.stock.ReservedQuantity, stockRepo.stock.Quantity)
+}
+
+func TestReservationUsecase_ReserveStock_ConflictRetriesExhausted(t *testing.T) {
+	stockRepo := &versionedStockRepository{
+		stock:           entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5},
+		forcedConflicts: maxStockUpdateAttempts,
+	}
+	usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+	expectRetriedTransactions(mock)
+
+	_, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 1})
+
+	assert.ErrorIs(t, err, appErrors.ErrStockConflict)
+	assert.Equal(t, int32(maxStockUpdateAttempts), stockRepo.writes)
+	assert.Equal(t, 0, stockRepo.stock.ReservedQuantity)
+}
+
+func TestReservationUsecase_CommitReservation_RetriesOnConflict(t *testing.T) {
+	stockRepo := &versionedStockRepository{
+		stock:           entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5, ReservedQuantity: 2},
+		forcedConflicts: 1,
+		reservations:    map[string]int{"RSV-1": 2},
+	}
+	usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+	expectRetriedTransactions(mock)
+
+	err := usecase.CommitReservation(context.Background(), &model.CommitReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 2, Reference: "RSV-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), stockRepo.writes)
+	assert.Equal(t, 3, stockRepo.stock.Quantity)
+	assert.Equal(t, 0, stockRepo.stock.ReservedQuantity)
+	assert.Equal(t, uint(1), stockRepo.stock.Version)
+}
+
+func TestReservationUsecase_CallerReference(t *testing.T) {
+	setup := func(t *testing.T) (*ReservationUseCase, *versionedStockRepository) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+		expectRetriedTransactions(mock)
+
+		response, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 3, Reference: "ord-42"})
+		assert.NoError(t, err)
+		assert.Equal(t, "ord-42", response.Reference)
+		return usecase, stockRepo
+	}
+
+	t.Run("CommitsWithTheSameReference", func(t *testing.T) {
+		usecase, stockRepo := setup(t)
+
+		err := usecase.CommitReservation(context.Background(), &model.CommitReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 3, Reference: "ord-42"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, stockRepo.stock.Quantity)
+		assert.Equal(t, 0, stockRepo.stock.ReservedQuantity)
+		assert.Equal(t, []string{"ord-42:pending", "ord-42:committed"}, stockRepo.logStatuses)
+	})
+
+	t.Run("RejectsAnotherReference", func(t *testing.T) {
+		usecase, stockRepo := setup(t)
+
+		err := usecase.CommitReservation(context.Background(), &model.CommitReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 3, Reference: "res_42"})
+		assert.ErrorIs(t, err, appErrors.ErrResourceNotFound)
+
+		err = usecase.CancelReservation(context.Background(), &model.CancelReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 3, Reference: "res_42"})
+		assert.ErrorIs(t, err, appErrors.ErrResourceNotFound)
+
+		// The reservation stays in place for its own reference
+		assert.Equal(t, 10, stockRepo.stock.Quantity)
+		assert.Equal(t, 3, stockRepo.stock.ReservedQuantity)
+		assert.Equal(t, []string{"ord-42:pending"}, stockRepo.logStatuses)
+	})
+
+	t.Run("RejectsMoreThanReserved", func(t *testing.T) {
+		usecase, stockRepo := setup(t)
+
+		err := usecase.CancelReservation(context.Background(), &model.CancelReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 4, Reference: "ord-42"})
+
+		assert.ErrorIs(t, err, appErrors.ErrBusinessRuleViolation)
+		assert.Equal(t, 3, stockRepo.stock.ReservedQuantity)
+	})
+
+	t.Run("CancelledReservationCannotBeCancelledAgain", func(t *testing.T) {
+		usecase, stockRepo := setup(t)
+		request := &model.CancelReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 3, Reference: "ord-42"}
+
+		assert.NoError(t, usecase.CancelReservation(context.Background(), request))
+		assert.ErrorIs(t, usecase.CancelReservation(context.Background(), request), appErrors.ErrResourceNotFound)
+
+		assert.Equal(t, 0, stockRepo.stock.ReservedQuantity)
+	})
+
+	t.Run("GeneratesReferenceWhenEmpty", func(t *testing.T) {
+		usecase, mock := setupReservationUsecaseTest(t, &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10},
+		})
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		first, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 1})
+		assert.NoError(t, err)
+		second, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 1})
+		assert.NoError(t, err)
+
+		// References generated in the same second must not collide
+		assert.True(t, strings.HasPrefix(first.Reference, "RSV-"))
+		assert.NotEqual(t, first.Reference, second.Reference)
+	})
+}
+
+func TestReservationUsecase_CommitReservation_Idempotent(t *testing.T) {
+	stockRepo := &versionedStockRepository{
+		stock:        entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5, ReservedQuantity: 4},
+		reservations: map[string]int{"RSV-1": 2},
+	}
+	usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+	notifier := &recordingStockAlertNotifier{}
+	usecase.AlertNotifier = notifier
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	request := &model.CommitReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: 2, Reference: "RSV-1"}
+
+	// The order service retries the commit after a lost response; the second call must not deduct again
+	assert.NoError(t, usecase.CommitReservation(context.Background(), request))
+	assert.NoError(t, usecase.CommitReservation(context.Background(), request))
+
+	assert.Equal(t, 3, stockRepo.stock.Quantity)
+	assert.Equal(t, 2, stockRepo.stock.ReservedQuantity)
+	assert.Equal(t, int32(1), stockRepo.writes)
+	assert.Equal(t, []string{"RSV-1:committed"}, stockRepo.logStatuses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationUsecase_ReserveStockBatch(t *testing.T) {
+	t.Run("ReservesAllItems", func(t *testing.T) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		response, err := usecase.ReserveStockBatch(context.Background(), []model.ReserveStockRequest{
+			{WarehouseID: 1, ProductID: 10, Quantity: 2},
+			{WarehouseID: 1, ProductID: 10, Quantity: 3},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, response.Reserved)
+		assert.Len(t, response.Results, 2)
+		for _, result := range response.Results {
+			assert.Equal(t, model.BatchItemStatusReserved, result.Status)
+			assert.NotNil(t, result.Reservation)
+		}
+		assert.Equal(t, 5, stockRepo.stock.ReservedQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RollsBackWhenAnyItemLacksStock", func(t *testing.T) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+		// The transaction must be rolled back, never committed
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		response, err := usecase.ReserveStockBatch(context.Background(), []model.ReserveStockRequest{
+			{WarehouseID: 1, ProductID: 10, Quantity: 3},
+			{WarehouseID: 1, ProductID: 10, Quantity: 3},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, response.Reserved)
+		assert.Len(t, response.Results, 2)
+		assert.Equal(t, model.BatchItemStatusRolledBack, response.Results[0].Status)
+		assert.Nil(t, response.Results[0].Reservation)
+		assert.Equal(t, model.BatchItemStatusInsufficientStock, response.Results[1].Status)
+		assert.NotEmpty(t, response.Results[1].Error)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsEmptyBatch", func(t *testing.T) {
+		usecase, _ := setupReservationUsecaseTest(t, &versionedStockRepository{})
+
+		response, err := usecase.ReserveStockBatch(context.Background(), nil)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestReservationUsecase_CommitReservation_LowStockAlert(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		reserved int
+		commit   int
+		alerts   []int
+	}{
+		{name: "CrossesBelowThreshold", quantity: 12, reserved: 5, commit: 5, alerts: []int{7}},
+		{name: "StaysAboveThreshold", quantity: 20, reserved: 5, commit: 5, alerts: nil},
+		{name: "AlreadyBelowThreshold", quantity: 8, reserved: 5, commit: 5, alerts: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stockRepo := &versionedStockRepository{
+				stock:        entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: tt.quantity, ReservedQuantity: tt.reserved, ReorderThreshold: 10},
+				reservations: map[string]int{"RSV-1": tt.reserved},
+			}
+			usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+			notifier := &recordingStockAlertNotifier{}
+			usecase.AlertNotifier = notifier
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			err := usecase.CommitReservation(context.Background(), &model.CommitReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: tt.commit, Reference: "RSV-1"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.alerts, notifier.alerts)
+		})
+	}
+}
+
+func TestReservationUsecase_ExpireStaleReservations(t *testing.T) {
+	stockRepo := &versionedStockRepository{
+		stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10, ReservedQuantity: 5},
+		staleReservations: []entity.ReservationLog{
+			{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "pending", Reference: "RSV-1"},
+			// More than is still reserved once RSV-1 is released, so it cannot be expired
+			{ID: 2, WarehouseID: 1, ProductID: 10, Quantity: 4, Status: "pending", Reference: "RSV-2"},
+		},
+	}
+	usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+	usecase.ReservationTTL = time.Hour
+	expectRetriedTransactions(mock)
+
+	before := time.Now()
+	result, err := usecase.ExpireStaleReservations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ReservationExpiryResult{ExpiredReservations: 1, FailedReservations: 1}, result)
+	assert.WithinDuration(t, before.Add(-time.Hour), stockRepo.staleCutoff, time.Second)
+
+	// The expired reservation is released back to available stock and recorded in the history
+	assert.Equal(t, 2, stockRepo.stock.ReservedQuantity)
+	assert.Equal(t, 10, stockRepo.stock.Quantity)
+	assert.Equal(t, []string{"RSV-1:expired"}, stockRepo.logStatuses)
+}
+
+// purgeReservationRepository deletes the given number of commit records per call, then fails with err
+type purgeReservationRepository struct {
+	repository.ReservationRepositoryInterface
+
+	batches []int64
+	err     error
+	cutoffs []time.Time
+	limits  []int
+}
+
+func (r *purgeReservationRepository) DeleteCommittedReservations(tx *gorm.DB, cutoff time.Time, limit int) (int64, error) {
+	r.cutoffs = append(r.cutoffs, cutoff)
+	r.limits = append(r.limits, limit)
+	if len(r.batches) == 0 {
+		return 0, r.err
+	}
+
+	deleted := r.batches[0]
+	r.batches = r.batches[1:]
+	return deleted, nil
+}
+
+func TestReservationUsecase_PurgeCommittedReservations(t *testing.T) {
+	setup := func(t *testing.T, repo *purgeReservationRepository) *ReservationUseCase {
+		usecase, _ := setupReservationUsecaseTest(t, nil)
+		usecase.ReservationRepo = repo
+		return usecase
+	}
+
+	t.Run("DeletesInBatchesUntilOneComesBackShort", func(t *testing.T) {
+		repo := &purgeReservationRepository{batches: []int64{2, 2, 1}}
+		usecase := setup(t, repo)
+
+		before := time.Now()
+		result, err := usecase.PurgeCommittedReservations(context.Background(), time.Hour, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &model.CommitPurgeResult{DeletedRecords: 5}, result)
+		assert.Equal(t, []int{2, 2, 2}, repo.limits)
+
+		// Every batch uses the cutoff taken at the start of the purge
+		assert.WithinDuration(t, before.Add(-time.Hour), repo.cutoffs[0], time.Second)
+		assert.Equal(t, repo.cutoffs[0], repo.cutoffs[2])
+	})
+
+	t.Run("ReportsDeletedSoFarOnFailure", func(t *testing.T) {
+		repo := &purgeReservationRepository{batches: []int64{2}, err: fmt.Errorf("database error")}
+		usecase := setup(t, repo)
+
+		result, err := usecase.PurgeCommittedReservations(context.Background(), time.Hour, 2)
+
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		assert.Equal(t, &model.CommitPurgeResult{DeletedRecords: 2}, result)
+	})
+
+	t.Run("StopsBetweenBatchesWhenCancelled", func(t *testing.T) {
+		repo := &purgeReservationRepository{batches: []int64{2, 2}}
+		usecase := setup(t, repo)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result, err := usecase.PurgeCommittedReservations(ctx, time.Hour, 2)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, &model.CommitPurgeResult{DeletedRecords: 2}, result)
+		assert.Len(t, repo.limits, 1)
+	})
+}
+
+// historyReservationRepository records the filter it is queried with
+type historyReservationRepository struct {
+	repository.ReservationRepositoryInterface
+
+	filter *repository.ReservationLogFilter
+}
+
+func (r *historyReservationRepository) GetReservationLogs(tx *gorm.DB, warehouseID, productID uint, filter repository.ReservationLogFilter, limit, offset int) ([]entity.ReservationLog, int64, error) {
+	r.filter = &filter
+	return []entity.ReservationLog{{Quantity: 2, Status: "pending", Reference: "RSV-1"}}, 11, nil
+}
+
+func TestReservationUsecase_GetReservationHistory(t *testing.T) {
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*ReservationUseCase, *historyReservationRepository) {
+		usecase, _ := setupReservationUsecaseTest(t, nil)
+		repo := &historyReservationRepository{}
+		usecase.ReservationRepo = repo
+		return usecase, repo
+	}
+
+	t.Run("FiltersByReservedStatusAndDateRange", func(t *testing.T) {
+		usecase, repo := setup(t)
+
+		response, err := usecase.GetReservationHistory(context.Background(), 1, 10,
+			&model.ReservationHistoryFilter{Status: model.ReservationStatusReserved, From: &from, To: &to}, 2, 5)
+
+		assert.NoError(t, err)
+		// Reserved stock is logged as pending
+		assert.Equal(t, &repository.ReservationLogFilter{Status: "pending", From: &from, To: &to}, repo.filter)
+		assert.Equal(t, int64(11), response.Total)
+		assert.Len(t, response.Logs, 1)
+	})
+
+	t.Run("RejectsUnknownStatus", func(t *testing.T) {
+		usecase, repo := setup(t)
+
+		_, err := usecase.GetReservationHistory(context.Background(), 1, 10,
+			&model.ReservationHistoryFilter{Status: "shipped"}, 1, 20)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, repo.filter)
+	})
+
+	t.Run("RejectsEmptyDateRange", func(t *testing.T) {
+		usecase, repo := setup(t)
+
+		_, err := usecase.GetReservationHistory(context.Background(), 1, 10,
+			&model.ReservationHistoryFilter{From: &to, To: &from}, 1, 20)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, repo.filter)
+	})
+}
+
+// lookupReservationRepository returns the active reservation it holds for one reference
+type lookupReservationRepository struct {
+	repository.ReservationRepositoryInterface
+
+	reservation *entity.ReservationLog
+}
+
+func (r *lookupReservationRepository) FindActiveReservation(tx *gorm.DB, warehouseID, productID uint, reference string) (*entity.ReservationLog, error) {
+	if r.reservation == nil || r.reservation.Reference != reference {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.reservation, nil
+}
+
+func TestReservationUsecase_GetReservationByReference(t *testing.T) {
+	reservedAt := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) *ReservationUseCase {
+		usecase, _ := setupReservationUsecaseTest(t, nil)
+		usecase.ReservationTTL = 25 * time.Hour
+		usecase.ReservationRepo = &lookupReservationRepository{
+			reservation: &entity.ReservationLog{WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "pending", Reference: "RSV-1-10-1", CreatedAt: reservedAt},
+		}
+		return usecase
+	}
+
+	t.Run("ReturnsActiveReservation", func(t *testing.T) {
+		response, err := setup(t).GetReservationByReference(context.Background(), 1, 10, "RSV-1-10-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &model.ReservationDetailResponse{
+			WarehouseID:      1,
+			ProductID:        10,
+			Reference:        "RSV-1-10-1",
+			ReservedQuantity: 3,
+			Status:           model.ReservationStatusPending,
+			ReservedAt:       "2025-05-25T12:00:00Z",
+			ExpiresAt:        "2025-05-26T13:00:00Z",
+		}, response)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := setup(t).GetReservationByReference(context.Background(), 1, 10, "RSV-unknown")
+
+		assert.ErrorIs(t, err, appErrors.ErrResourceNotFound)
+	})
+
+	t.Run("RequiresReference", func(t *testing.T) {
+		_, err := setup(t).GetReservationByReference(context.Background(), 1, 10, "")
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	})
+}
+
+// referenceReservationRepository returns the reservation logs it holds for their reference
+type referenceReservationRepository struct {
+	repository.ReservationRepositoryInterface
+
+	reservations []entity.ReservationLog
+}
+
+func (r *referenceReservationRepository) FindReservationsByReference(tx *gorm.DB, reference string) ([]entity.ReservationLog, error) {
+	var logs []entity.ReservationLog
+	for _, reservation := range r.reservations {
+		if reservation.Reference == reference {
+			logs = append(logs, reservation)
+		}
+	}
+	return logs, nil
+}
+
+func TestReservationUsecase_GetReservationsByReference(t *testing.T) {
+	reservedAt := time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) *ReservationUseCase {
+		usecase, _ := setupReservationUsecaseTest(t, nil)
+		usecase.ReservationRepo = &referenceReservationRepository{
+			reservations: []entity.ReservationLog{
+				{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "pending", Reference: "ORDER-42", CreatedAt: reservedAt},
+				{ID: 2, WarehouseID: 2, ProductID: 11, Quantity: 1, Status: "pending", Reference: "ORDER-42", CreatedAt: reservedAt},
+				{ID: 3, WarehouseID: 1, ProductID: 10, Quantity: 2, Status: "pending", Reference: "ORDER-43", CreatedAt: reservedAt},
+				{ID: 4, WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "committed", Reference: "ORDER-42", CreatedAt: reservedAt.Add(time.Minute)},
+			},
+		}
+		return usecase
+	}
+
+	t.Run("ListsAcrossWarehousesAndProducts", func(t *testing.T) {
+		response, err := setup(t).GetReservationsByReference(context.Background(), "ORDER-42")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ORDER-42", response.Reference)
+		assert.Equal(t, 3, response.Total)
+		assert.Equal(t, model.ReferenceReservationResponse{
+			ID:          2,
+			WarehouseID: 2,
+			ProductID:   11,
+			Quantity:    1,
+			Status:      model.ReservationStatusPending,
+			CreatedAt:   "2025-05-25T12:00:00Z",
+		}, response.Reservations[1])
+		assert.Equal(t, model.ReservationStatusCommitted, response.Reservations[2].Status)
+	})
+
+	t.Run("NoneMatchIsEmpty", func(t *testing.T) {
+		response, err := setup(t).GetReservationsByReference(context.Background(), "ORDER-unknown")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, response.Total)
+		assert.NotNil(t, response.Reservations)
+		assert.Empty(t, response.Reservations)
+	})
+
+	t.Run("RequiresReference", func(t *testing.T) {
+		_, err := setup(t).GetReservationsByReference(context.Background(), "")
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	})
+}
+
+// agedReservationRepository returns fixed aged reservations and records the cutoff and page it is queried with
+type agedReservationRepository struct {
+	repository.ReservationRepositoryInterface
+
+	reservations []entity.ReservationLog
+	totals       []repository.ReservationTotal
+
+	cutoff time.Time
+	limit  int
+	offset int
+}
+
+func (r *agedReservationRepository) FindReservationsOlderThan(tx *gorm.DB, cutoff time.Time, limit, offset int) ([]entity.ReservationLog, int64, error) {
+	r.cutoff, r.limit, r.offset = cutoff, limit, offset
+	return r.reservations, 21, nil
+}
+
+func (r *agedReservationRepository) SumReservationsOlderThan(tx *gorm.DB, cutoff time.Time) ([]repository.ReservationTotal, error) {
+	return r.totals, nil
+}
+
+func TestReservationUsecase_FindReservationsOlderThan(t *testing.T) {
+	reservedAt := time.Now().Add(-3 * time.Hour)
+
+	setup := func(t *testing.T) (*ReservationUseCase, *agedReservationRepository) {
+		usecase, _ := setupReservationUsecaseTest(t, nil)
+		usecase.ReservationTTL = 30 * time.Minute
+		repo := &agedReservationRepository{
+			reservations: []entity.ReservationLog{{WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "pending", Reference: "RSV-1-10-1", CreatedAt: reservedAt}},
+			totals:       []repository.ReservationTotal{{WarehouseID: 1, Reservations: 21, Quantity: 40}},
+		}
+		usecase.ReservationRepo = repo
+		return usecase, repo
+	}
+
+	t.Run("ReportsPageAndWarehouseTotals", func(t *testing.T) {
+		usecase, repo := setup(t)
+		before := time.Now()
+
+		report, err := usecase.FindReservationsOlderThan(context.Background(), 2*time.Hour, 3, 10)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, before.Add(-2*time.Hour), repo.cutoff, time.Second)
+		assert.Equal(t, 10, repo.limit)
+		assert.Equal(t, 20, repo.offset)
+		assert.Equal(t, "2h0m0s", report.OlderThan)
+		assert.Equal(t, int64(21), report.Total)
+		assert.Len(t, report.Reservations, 1)
+		assert.Equal(t, "RSV-1-10-1", report.Reservations[0].Reference)
+		assert.InDelta(t, (3 * time.Hour).Seconds(), report.Reservations[0].AgeSeconds, 2)
+		assert.Equal(t, []model.AgedReservationWarehouseTotal{{WarehouseID: 1, Reservations: 21, Quantity: 40}}, report.Warehouses)
+	})
+
+	t.Run("DefaultsToReservationTTL", func(t *testing.T) {
+		usecase, repo := setup(t)
+		before := time.Now()
+
+		report, err := usecase.FindReservationsOlderThan(context.Background(), 0, 1, 20)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, before.Add(-30*time.Minute), repo.cutoff, time.Second)
+		assert.Equal(t, "30m0s", report.OlderThan)
+	})
+
+	t.Run("RejectsNegativeAge", func(t *testing.T) {
+		usecase, repo := setup(t)
+
+		report, err := usecase.FindReservationsOlderThan(context.Background(), -time.Hour, 1, 20)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, report)
+		assert.True(t, repo.cutoff.IsZero())
+	})
+}
+
+func TestReservationUsecase_CommitReservation_RejectsNegativeStock(t *testing.T) {
+	usecase, mock := setupReservationUsecaseTest(t, nil)
+	usecase.ReservationRepo = repository.NewReservationRepository(usecase.Log, usecase.DB)
+
+	// Committing 5 units with only 2 on hand must fail instead of leaving -3 in stock
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `committed_reservations`").
+		WithArgs(1, 10, "RSV-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` WHERE \\(warehouse_id = \\? AND product_id = \\? AND reference = \\? AND status = \\?\\)").
+		WithArgs(1, 10, "RSV-1", "pending", "committed", "cancelled", "expired", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference"}).
+			AddRow(7, 1, 10, 5, "pending", "RSV-1"))
+	mock.ExpectQuery("SELECT \\* FROM `warehouse_stock` WHERE warehouse_id = \\? AND product_id = \\?").
+		WithArgs(1, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "version"}).
+			AddRow(1, 1, 10, 2, 5, 0))
+	mock.ExpectRollback()
+
+	err := usecase.CommitReservation(context.Background(), &model.CommitReservationRequest{
+		WarehouseID: 1, ProductID: 10, Quantity: 5, Reference: "RSV-1",
+	})
+
+	assert.ErrorIs(t, err, appErrors.ErrInsufficientStock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// relocationReservationRepository keeps the stock of one product in several warehouses and a single active reservation
+type relocationReservationRepository struct {
+	repository.ReservationRepositoryInterface
+
+	stocks      map[uint]*entity.WarehouseStock
+	reservation *entity.ReservationLog
+
+	// logs records every reservation log written as warehouse:status
+	logs []string
+}
+
+func (r *relocationReservationRepository) FindActiveReservation(tx *gorm.DB, warehouseID, productID uint, reference string) (*entity.ReservationLog, error) {
+	if r.reservation == nil || r.reservation.WarehouseID != warehouseID || r.reservation.Reference != reference {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.reservation, nil
+}
+
+func (r *relocationReservationRepository) ReserveStock(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) (*entity.WarehouseStock, error) {
+	stock, ok := r.stocks[warehouseID]
+	if !ok {
+		return nil, fmt.Errorf("stock not found for warehouseID %d and productID %d", warehouseID, productID)
+	}
+	stock.CalculateAvailableQuantity()
+	if stock.AvailableQuantity < quantity {
+		return nil, fmt.Errorf("insufficient stock: requested %d, available %d", quantity, stock.AvailableQuantity)
+	}
+
+	stock.ReservedQuantity += quantity
+	stock.CalculateAvailableQuantity()
+	return stock, nil
+}
+
+func (r *relocationReservationRepository) CancelReservation(tx *gorm.DB, warehouseID, productID uint, quantity int, reference string) error {
+	stock := r.stocks[warehouseID]
+	if stock.ReservedQuantity < quantity {
+		return fmt.Errorf("cannot cancel more than reserved: reserved %d, cancel request %d", stock.ReservedQuantity, quantity)
+	}
+
+	stock.ReservedQuantity -= quantity
+	return nil
+}
+
+func (r *relocationReservationRepository) CreateReservationLog(tx *gorm.DB, warehouseID, productID uint, quantity int, status string, reference string) error {
+	r.logs = append(r.logs, fmt.Sprintf("%d:%s", warehouseID, status))
+	return nil
+}
+
+func TestReservationUsecase_RelocateReservation(t *testing.T) {
+	setup := func(t *testing.T) (*ReservationUseCase, *relocationReservationRepository, sqlmock.Sqlmock) {
+		usecase, mock := setupReservationUsecaseTest(t, nil)
+		repo := &relocationReservationRepository{
+			stocks: map[uint]*entity.WarehouseStock{
+				1: {ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10, ReservedQuantity: 3},
+				2: {ID: 2, WarehouseID: 2, ProductID: 10, Quantity: 5},
+			},
+			reservation: &entity.ReservationLog{WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "pending", Reference: "ORDER-42"},
+		}
+		usecase.ReservationRepo = repo
+		return usecase, repo, mock
+	}
+
+	t.Run("MovesReservation", func(t *testing.T) {
+		usecase, repo, mock := setup(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		response, err := usecase.RelocateReservation(context.Background(), "ORDER-42", 1, 2, 10, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), response.FromWarehouseID)
+		assert.Equal(t, uint(2), response.Reservation.WarehouseID)
+		assert.Equal(t, "ORDER-42", response.Reservation.Reference)
+		assert.Equal(t, 3, response.Reservation.ReservedQuantity)
+		assert.Equal(t, 2, response.Reservation.AvailableQuantity)
+		assert.Equal(t, 0, repo.stocks[1].ReservedQuantity)
+		assert.Equal(t, []string{"1:cancelled", "2:pending"}, repo.logs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DestinationWithoutStock", func(t *testing.T) {
+		usecase, repo, mock := setup(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		response, err := usecase.RelocateReservation(context.Background(), "ORDER-42", 1, 3, 10, 3)
+
+		assert.ErrorIs(t, err, appErrors.ErrInsufficientStock)
+		assert.Nil(t, response)
+		assert.Equal(t, 3, repo.stocks[1].ReservedQuantity)
+		assert.Empty(t, repo.logs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsPartialRelocation", func(t *testing.T) {
+		usecase, repo, _ := setup(t)
+
+		_, err := usecase.RelocateReservation(context.Background(), "ORDER-42", 1, 2, 10, 2)
+
+		assert.ErrorIs(t, err, appErrors.ErrBusinessRuleViolation)
+		assert.Equal(t, 3, repo.stocks[1].ReservedQuantity)
+		assert.Equal(t, 0, repo.stocks[2].ReservedQuantity)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		usecase, _, _ := setup(t)
+
+		_, err := usecase.RelocateReservation(context.Background(), "ORDER-43", 1, 2, 10, 3)
+
+		assert.ErrorIs(t, err, appErrors.ErrResourceNotFound)
+	})
+
+	t.Run("RejectsSameWarehouse", func(t *testing.T) {
+		usecase, repo, _ := setup(t)
+
+		_, err := usecase.RelocateReservation(context.Background(), "ORDER-42", 1, 1, 10, 3)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Empty(t, repo.logs)
+	})
+}
+
+func TestReservationUsecase_RelocateReservation_DestinationShortRollsBack(t *testing.T) {
+	usecase, mock := setupReservationUsecaseTest(t, nil)
+	usecase.ReservationRepo = repository.NewReservationRepository(usecase.Log, usecase.DB)
+
+	// Warehouse 2 has only 2 of the 3 units available, so the transaction is rolled back
+	// before the reservation in warehouse 1 is released; no UPDATE or INSERT may run
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `reservation_logs` WHERE \\(warehouse_id = \\? AND product_id = \\? AND reference = \\? AND status = \\?\\)").
+		WithArgs(1, 10, "ORDER-42", "pending", "committed", "cancelled", "expired", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "status", "reference"}).
+			AddRow(7, 1, 10, 3, "pending", "ORDER-42"))
+	mock.ExpectQuery("SELECT \\* FROM `warehouse_stock` WHERE warehouse_id = \\? AND product_id = \\?").
+		WithArgs(2, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "version"}).
+			AddRow(2, 2, 10, 4, 2, 0))
+	mock.ExpectRollback()
+
+	response, err := usecase.RelocateReservation(context.Background(), "ORDER-42", 1, 2, 10, 3)
+
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, appErrors.ErrInsufficientStock)
+	assert.Contains(t, err.Error(), "requested 3, available 2")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationUsecase_QuantityLimits(t *testing.T) {
+	t.Run("ReservesExactlyMaxQuantity", func(t *testing.T) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+		usecase.MaxQuantity = 5
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		response, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 5})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		assert.Equal(t, 5, stockRepo.stock.ReservedQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsReservationOverMaxQuantity", func(t *testing.T) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+		usecase.MaxQuantity = 5
+
+		response, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: 6})
+
+		var appErr *appErrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, appErrors.ErrInvalidInput.Code, appErr.Code)
+		assert.Equal(t, fiber.StatusBadRequest, appErr.StatusCode)
+		assert.Equal(t, "quantity must not exceed 5 per reservation", appErr.Message)
+		assert.Nil(t, response)
+		assert.Equal(t, int32(0), stockRepo.writes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsNonPositiveReservation", func(t *testing.T) {
+		usecase, _ := setupReservationUsecaseTest(t, &versionedStockRepository{})
+		usecase.MaxQuantity = 5
+
+		for _, quantity := range []int{0, -1} {
+			_, err := usecase.ReserveStock(context.Background(), &model.ReserveStockRequest{WarehouseID: 1, ProductID: 10, Quantity: quantity})
+
+			var appErr *appErrors.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, appErrors.ErrInvalidInput.Code, appErr.Code)
+			assert.Equal(t, "quantity must be greater than 0", appErr.Message)
+		}
+	})
+
+	t.Run("RejectsBatchItemOverMaxQuantity", func(t *testing.T) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 10},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+		usecase.MaxQuantity = 5
+
+		response, err := usecase.ReserveStockBatch(context.Background(), []model.ReserveStockRequest{
+			{WarehouseID: 1, ProductID: 10, Quantity: 5},
+			{WarehouseID: 1, ProductID: 10, Quantity: 6},
+		})
+
+		var appErr *appErrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, appErrors.ErrInvalidInput.Code, appErr.Code)
+		assert.Equal(t, "items[1].quantity must not exceed 5 per reservation", appErr.Message)
+		assert.Nil(t, response)
+		assert.Equal(t, 0, stockRepo.stock.ReservedQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsNonPositiveCancelAndCommit", func(t *testing.T) {
+		stockRepo := &versionedStockRepository{
+			stock: entity.WarehouseStock{ID: 1, WarehouseID: 1, ProductID: 10, Quantity: 5, ReservedQuantity: 2},
+		}
+		usecase, mock := setupReservationUsecaseTest(t, stockRepo)
+
+		for _, quantity := range []int{0, -2} {
+			err := usecase.CancelReservation(context.Background(), &model.CancelReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: quantity, Reference: "RSV-1"})
+
+			var appErr *appErrors.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, appErrors.ErrInvalidInput.Code, appErr.Code)
+			assert.Equal(t, "quantity must be greater than 0", appErr.Message)
+
+			err = usecase.CommitReservation(context.Background(), &model.CommitReservationRequest{WarehouseID: 1, ProductID: 10, Quantity: quantity, Reference: "RSV-1"})
+
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, appErrors.ErrInvalidInput.Code, appErr.Code)
+			assert.Equal(t, "quantity must be greater than 0", appErr.Message)
+		}
+
+		assert.Equal(t, int32(0), stockRepo.writes)
+		assert.Equal(t, 2, stockRepo.stock.ReservedQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
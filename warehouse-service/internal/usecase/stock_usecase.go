@@ -2,8 +2,14 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	appContext "warehouse-service/internal/context"
+	appErrors "warehouse-service/internal/errors"
+	"warehouse-service/internal/entity"
+	"warehouse-service/internal/gateway/notification"
 	"warehouse-service/internal/gateway/product"
 	"warehouse-service/internal/model"
 	"warehouse-service/internal/repository"
@@ -17,7 +23,12 @@ import (
 type StockUseCaseInterface interface {
 	GetWarehouseStock(ctx context.Context, warehouseID uint, productID uint, page, limit int) (*model.WarehouseStockListResponse, error)
 	AddStock(ctx context.Context, request *model.AddStockRequest) (*model.StockResponse, error)
+	AdjustStock(ctx context.Context, warehouseID, productID uint, delta int, reason string) (*model.StockResponse, error)
 	TransferStock(ctx context.Context, request *model.StockTransferRequest) (*model.StockTransferResponse, error)
+	GetProductAvailabilityAt(ctx context.Context, warehouseID, productID uint) (*model.ProductAvailabilityResponse, error)
+	UpdateReorderThreshold(ctx context.Context, warehouseID, productID uint, request *model.UpdateReorderThresholdRequest) (*model.StockResponse, error)
+	GetProductStockSummary(ctx context.Context, productID uint, includeEmpty bool) (*model.ProductStockSummaryResponse, error)
+	GetStockHistory(ctx context.Context, warehouseID, productID uint, page, limit int) (*model.StockHistoryResponse, error)
 }
 
 type StockUseCase struct {
@@ -27,12 +38,17 @@ type StockUseCase struct {
 	StockRepo     repository.StockRepositoryInterface
 	WarehouseRepo repository.WarehouseRepositoryInterface
 	ProductClient product.ProductClientInterface
+	AlertNotifier notification.StockAlertNotifier
+	// Pagination bounds the page size of GetWarehouseStock and GetStockHistory; zero fields use the defaults
+	Pagination model.Pagination
 }
 
 func NewStockUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, 
                     stockRepo repository.StockRepositoryInterface, 
                     warehouseRepo repository.WarehouseRepositoryInterface,
-                    productClient product.ProductClientInterface) StockUseCaseInterface {
+                    productClient product.ProductClientInterface,
+                    alertNotifier notification.StockAlertNotifier,
+                    pagination model.Pagination) StockUseCaseInterface {
 	return &StockUseCase{
 		DB:            db,
 		Log:           log,
@@ -40,6 +56,8 @@ func NewStockUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Valida
 		StockRepo:     stockRepo,
 		WarehouseRepo: warehouseRepo,
 		ProductClient: productClient,
+		AlertNotifier: alertNotifier,
+		Pagination:    pagination,
 	}
 }
 
@@ -63,6 +81,10 @@ func (u *StockUseCase) GetWarehouseStock(ctx context.Context, warehouseID uint,
 	}
 	
 	// Calculate offset
+	if page < 1 {
+		page = 1
+	}
+	limit = u.Pagination.Limit(limit)
 	offset := (page - 1) * limit
 	
 	// Get warehouse stock
@@ -72,17 +94,27 @@ func (u *StockUseCase) GetWarehouseStock(ctx context.Context, warehouseID uint,
 		return nil, fiber.ErrInternalServerError
 	}
 	
+	// Fetch the product info of the whole page from the product service in one call
+	productIDs := make([]uint, 0, len(stocks))
+	seen := make(map[uint]bool, len(stocks))
+	for _, stock := range stocks {
+		if !seen[stock.ProductID] {
+			seen[stock.ProductID] = true
+			productIDs = append(productIDs, stock.ProductID)
+		}
+	}
+	productInfos, err := u.ProductClient.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		u.Log.WithError(err).WithField("product_count", len(productIDs)).Warn("Failed to fetch product info after retries, will return with mock product details")
+	}
+	
 	// Map to response DTOs
 	stockDTOs := make([]model.StockItemResponse, len(stocks))
 	for i, stock := range stocks {
-		// Fetch product info from the product service
-		var productName, sku string
-		productInfo, err := u.ProductClient.GetProductByID(ctx, stock.ProductID)
-		if err != nil {
-			u.Log.WithError(err).WithField("product_id", stock.ProductID).Warn("Failed to fetch product info, will return with mock product details")
-			productName = fmt.Sprintf("Product %d", stock.ProductID)
-			sku = fmt.Sprintf("SKU-%d", stock.ProductID)
-		} else {
+		// Products the product service did not return get mock details
+		productName := fmt.Sprintf("Product %d", stock.ProductID)
+		sku := fmt.Sprintf("SKU-%d", stock.ProductID)
+		if productInfo, ok := productInfos[stock.ProductID]; ok {
 			productName = productInfo.Name
 			sku = productInfo.SKU
 		}
@@ -95,6 +127,7 @@ func (u *StockUseCase) GetWarehouseStock(ctx context.Context, warehouseID uint,
 			Quantity:          stock.Quantity,
 			ReservedQuantity:  stock.ReservedQuantity,
 			AvailableQuantity: stock.AvailableQuantity,
+			ReorderThreshold:  stock.ReorderThreshold,
 			UpdatedAt:         stock.UpdatedAt.Format(time.RFC3339),
 		}
 	}
@@ -118,6 +151,30 @@ func (u *StockUseCase) GetWarehouseStock(ctx context.Context, warehouseID uint,
 	return response, nil
 }
 
+// GetProductAvailabilityAt retrieves the available, reserved and total quantity of a product at a warehouse
+func (u *StockUseCase) GetProductAvailabilityAt(ctx context.Context, warehouseID, productID uint) (*model.ProductAvailabilityResponse, error) {
+	if warehouseID == 0 || productID == 0 {
+		return nil, fiber.ErrBadRequest
+	}
+	
+	stock, err := u.StockRepo.GetProductAvailability(u.DB.WithContext(ctx), warehouseID, productID)
+	if err != nil {
+		u.Log.WithError(err).WithFields(logrus.Fields{
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+		}).Error("Failed to get product availability")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	return &model.ProductAvailabilityResponse{
+		WarehouseID:       warehouseID,
+		ProductID:         productID,
+		AvailableQuantity: stock.AvailableQuantity,
+		ReservedQuantity:  stock.ReservedQuantity,
+		TotalQuantity:     stock.Quantity,
+	}, nil
+}
+
 // AddStock adds stock to a warehouse
 func (u *StockUseCase) AddStock(ctx context.Context, request *model.AddStockRequest) (*model.StockResponse, error) {
 	// Validate request
@@ -167,9 +224,24 @@ func (u *StockUseCase) AddStock(ctx context.Context, request *model.AddStockRequ
 	stock, err := u.StockRepo.AddStock(tx, request.WarehouseID, request.ProductID, request.ProductSKU, request.Quantity, request.Reference, request.Notes)
 	if err != nil {
 		u.Log.WithError(err).Error("Failed to add stock")
+		switch {
+		case errors.Is(err, repository.ErrStockVersionConflict):
+			return nil, appErrors.ErrStockConflict
+		case errors.Is(err, repository.ErrNegativeStock):
+			return nil, appErrors.ErrInsufficientStock
+		}
 		return nil, fiber.ErrInternalServerError
 	}
 	
+	// Set the reorder threshold if one was given
+	if request.ReorderThreshold != nil {
+		if _, err := u.StockRepo.UpdateReorderThreshold(tx, request.WarehouseID, request.ProductID, *request.ReorderThreshold); err != nil {
+			u.Log.WithError(err).Error("Failed to set reorder threshold")
+			return nil, fiber.ErrInternalServerError
+		}
+		stock.ReorderThreshold = *request.ReorderThreshold
+	}
+	
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		u.Log.WithError(err).Error("Failed to commit transaction")
@@ -185,12 +257,87 @@ func (u *StockUseCase) AddStock(ctx context.Context, request *model.AddStockRequ
 		Quantity:          stock.Quantity,
 		ReservedQuantity:  stock.ReservedQuantity,
 		AvailableQuantity: stock.AvailableQuantity,
+		ReorderThreshold:  stock.ReorderThreshold,
 		UpdatedAt:         stock.UpdatedAt.Format(time.RFC3339),
 	}
 	
 	return response, nil
 }
 
+// AdjustStock corrects the on-hand quantity of a product at a warehouse by a signed delta, for example after a
+// physical count. The change is recorded together with the caller, the old and new quantity and the reason.
+func (u *StockUseCase) AdjustStock(ctx context.Context, warehouseID, productID uint, delta int, reason string) (*model.StockResponse, error) {
+	// Validate request
+	request := &model.AdjustStockRequest{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Delta:       delta,
+		Reason:      strings.TrimSpace(reason),
+	}
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, fiber.ErrBadRequest
+	}
+	
+	// Adjustments are attributed to the authenticated caller
+	actor := appContext.GetUserID(ctx)
+	if actor == "" {
+		actor = "system"
+	}
+	
+	// Start a transaction
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+	
+	// Verify warehouse exists
+	if _, err := u.WarehouseRepo.FindByID(tx, warehouseID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.ErrNotFound
+		}
+		u.Log.WithError(err).Error("Failed to find warehouse")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	// Apply the adjustment
+	stock, adjustment, err := u.StockRepo.AdjustStock(tx, warehouseID, productID, delta, request.Reason, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrStockVersionConflict):
+			return nil, appErrors.ErrStockConflict
+		case errors.Is(err, repository.ErrNegativeStock):
+			return nil, appErrors.WithMessage(appErrors.ErrInsufficientStock, "Adjustment would take the stock below zero")
+		case errors.Is(err, repository.ErrBelowReservedStock):
+			return nil, appErrors.WithMessage(appErrors.ErrInsufficientStock, "Adjustment would take the stock below the reserved quantity")
+		}
+		u.Log.WithError(err).Error("Failed to adjust stock")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	u.Log.WithFields(logrus.Fields{
+		"warehouse_id": warehouseID,
+		"product_id":   productID,
+		"delta":        delta,
+		"old_quantity": adjustment.OldQuantity,
+		"new_quantity": adjustment.NewQuantity,
+		"actor":        actor,
+	}).Info("Stock adjusted")
+	
+	return &model.StockResponse{
+		WarehouseID:       stock.WarehouseID,
+		ProductID:         stock.ProductID,
+		Quantity:          stock.Quantity,
+		ReservedQuantity:  stock.ReservedQuantity,
+		AvailableQuantity: stock.AvailableQuantity,
+		ReorderThreshold:  stock.ReorderThreshold,
+		UpdatedAt:         stock.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
 // TransferStock transfers stock between warehouses
 func (u *StockUseCase) TransferStock(ctx context.Context, request *model.StockTransferRequest) (*model.StockTransferResponse, error) {
 	// Validate request
@@ -256,8 +403,26 @@ func (u *StockUseCase) TransferStock(ctx context.Context, request *model.StockTr
 	// Transfer stock
 	transfer, err := u.StockRepo.TransferStock(tx, request.SourceWarehouseID, request.TargetWarehouseID, request.ProductID, request.ProductSKU, request.Quantity, reference)
 	if err != nil {
+		// The deferred rollback discards both legs of the transfer
 		u.Log.WithError(err).Error("Failed to transfer stock")
-		return nil, err
+		switch {
+		case errors.Is(err, repository.ErrStockVersionConflict):
+			return nil, appErrors.ErrStockConflict
+		case errors.Is(err, repository.ErrNegativeStock):
+			return nil, appErrors.ErrInsufficientStock
+		case errors.Is(err, repository.ErrInsufficientSourceStock):
+			return nil, appErrors.WithMessage(appErrors.ErrBusinessRuleViolation, err.Error())
+		case errors.Is(err, repository.ErrSameWarehouseTransfer):
+			return nil, fiber.ErrBadRequest
+		}
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	// Read the source stock after the deduction to check it against its reorder threshold
+	sourceStock, err := u.StockRepo.GetStock(tx, request.SourceWarehouseID, request.ProductID, false)
+	if err != nil {
+		u.Log.WithError(err).Error("Failed to read source stock after transfer")
+		return nil, fiber.ErrInternalServerError
 	}
 	
 	// Commit transaction
@@ -266,6 +431,8 @@ func (u *StockUseCase) TransferStock(ctx context.Context, request *model.StockTr
 		return nil, fiber.ErrInternalServerError
 	}
 	
+	notifyIfBelowReorderThreshold(ctx, u.Log, u.AlertNotifier, sourceStock, request.Quantity)
+	
 	// Prepare response
 	response := &model.StockTransferResponse{
 		TransferID:        transfer.ID,
@@ -279,4 +446,186 @@ func (u *StockUseCase) TransferStock(ctx context.Context, request *model.StockTr
 	}
 	
 	return response, nil
+}
+
+// UpdateReorderThreshold sets the quantity below which a low-stock alert is raised for a product at a warehouse
+func (u *StockUseCase) UpdateReorderThreshold(ctx context.Context, warehouseID, productID uint, request *model.UpdateReorderThresholdRequest) (*model.StockResponse, error) {
+	// Validate request
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, fiber.ErrBadRequest
+	}
+	
+	stock, err := u.StockRepo.UpdateReorderThreshold(u.DB.WithContext(ctx), warehouseID, productID, request.ReorderThreshold)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		u.Log.WithError(err).Error("Failed to update reorder threshold")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	return &model.StockResponse{
+		WarehouseID:       stock.WarehouseID,
+		ProductID:         stock.ProductID,
+		Quantity:          stock.Quantity,
+		ReservedQuantity:  stock.ReservedQuantity,
+		AvailableQuantity: stock.AvailableQuantity,
+		ReorderThreshold:  stock.ReorderThreshold,
+		UpdatedAt:         stock.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// GetProductStockSummary returns the stock of a product at each active warehouse together with the totals across them
+func (u *StockUseCase) GetProductStockSummary(ctx context.Context, productID uint, includeEmpty bool) (*model.ProductStockSummaryResponse, error) {
+	if productID == 0 {
+		return nil, fiber.ErrBadRequest
+	}
+	
+	stocks, err := u.StockRepo.GetProductStockByWarehouse(u.DB.WithContext(ctx), productID, includeEmpty)
+	if err != nil {
+		u.Log.WithError(err).WithField("product_id", productID).Error("Failed to get product stock summary")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	response := &model.ProductStockSummaryResponse{
+		ProductID:  productID,
+		Warehouses: make([]model.WarehouseStockSummary, 0, len(stocks)),
+	}
+	
+	for _, stock := range stocks {
+		available := stock.Quantity - stock.ReservedQuantity
+		if available < 0 {
+			available = 0
+		}
+		
+		response.Warehouses = append(response.Warehouses, model.WarehouseStockSummary{
+			WarehouseID:       stock.WarehouseID,
+			WarehouseName:     stock.WarehouseName,
+			Quantity:          stock.Quantity,
+			ReservedQuantity:  stock.ReservedQuantity,
+			AvailableQuantity: available,
+		})
+		response.TotalQuantity += stock.Quantity
+		response.TotalReservedQuantity += stock.ReservedQuantity
+		response.TotalAvailableQuantity += available
+	}
+	
+	return response, nil
+}
+
+// GetStockHistory returns a page of the stock movements of a product at a warehouse in the order they happened,
+// each with the quantity on hand, reserved and available it left behind. The running balances start from the sum
+// of the movements before the page, so every page shows the same balances whatever the page size.
+func (u *StockUseCase) GetStockHistory(ctx context.Context, warehouseID, productID uint, page, limit int) (*model.StockHistoryResponse, error) {
+	if warehouseID == 0 || productID == 0 {
+		return nil, fiber.ErrBadRequest
+	}
+	
+	tx := u.DB.WithContext(ctx)
+	
+	// Verify warehouse exists
+	if _, err := u.WarehouseRepo.FindByID(tx, warehouseID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		u.Log.WithError(err).Error("Failed to find warehouse")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	// Calculate offset
+	if page < 1 {
+		page = 1
+	}
+	limit = u.Pagination.Limit(limit)
+	offset := (page - 1) * limit
+	
+	movements, count, err := u.StockRepo.GetStockMovements(tx, warehouseID, productID, limit, offset)
+	if err != nil {
+		u.Log.WithError(err).WithFields(logrus.Fields{
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+		}).Error("Failed to get stock movements")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	// Start the running balances from everything recorded before the first movement of the page
+	var opening repository.StockBalance
+	if len(movements) > 0 && offset > 0 {
+		opening, err = u.StockRepo.SumStockMovementsBefore(tx, warehouseID, productID, movements[0].ID)
+		if err != nil {
+			u.Log.WithError(err).WithFields(logrus.Fields{
+				"warehouse_id": warehouseID,
+				"product_id":   productID,
+			}).Error("Failed to sum earlier stock movements")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+	
+	current, err := u.StockRepo.GetProductAvailability(tx, warehouseID, productID)
+	if err != nil {
+		u.Log.WithError(err).WithFields(logrus.Fields{
+			"warehouse_id": warehouseID,
+			"product_id":   productID,
+		}).Error("Failed to get current stock")
+		return nil, fiber.ErrInternalServerError
+	}
+	
+	// Calculate total pages
+	totalPages := count / int64(limit)
+	if count%int64(limit) > 0 {
+		totalPages++
+	}
+	
+	response := &model.StockHistoryResponse{
+		WarehouseID:             warehouseID,
+		ProductID:               productID,
+		OpeningBalance:          opening.Quantity,
+		OpeningReservedBalance:  opening.ReservedQuantity,
+		CurrentQuantity:         current.Quantity,
+		CurrentReservedQuantity: current.ReservedQuantity,
+		Total:                   count,
+		Page:                    page,
+		Limit:                   limit,
+		TotalPages:              totalPages,
+		Movements:               make([]model.StockMovementResponse, 0, len(movements)),
+	}
+	
+	balance := opening
+	for _, movement := range movements {
+		balance.Quantity += movement.QuantityChange()
+		balance.ReservedQuantity += movement.ReservedChange()
+		
+		response.Movements = append(response.Movements, model.StockMovementResponse{
+			ID:               movement.ID,
+			MovementType:     string(movement.MovementType),
+			Quantity:         movement.Quantity,
+			QuantityChange:   movement.QuantityChange(),
+			ReservedChange:   movement.ReservedChange(),
+			Balance:          balance.Quantity,
+			ReservedBalance:  balance.ReservedQuantity,
+			AvailableBalance: balance.Quantity - balance.ReservedQuantity,
+			ReferenceType:    movement.ReferenceType,
+			ReferenceID:      movement.ReferenceID,
+			Notes:            movement.Notes,
+			CreatedAt:        movement.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	
+	return response, nil
+}
+
+// notifyIfBelowReorderThreshold raises a low-stock alert when a deduction took the stock below its reorder threshold.
+// The stock change is already committed, so a failing notifier is only logged.
+func notifyIfBelowReorderThreshold(ctx context.Context, log *logrus.Logger, notifier notification.StockAlertNotifier, stock *entity.WarehouseStock, deducted int) {
+	if notifier == nil || stock == nil || !stock.FellBelowReorderThreshold(deducted) {
+		return
+	}
+	
+	if err := notifier.NotifyLowStock(ctx, stock.WarehouseID, stock.ProductID, stock.Quantity); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"warehouse_id": stock.WarehouseID,
+			"product_id":   stock.ProductID,
+			"quantity":     stock.Quantity,
+		}).Warn("Failed to send low stock alert")
+	}
 }
\ No newline at end of file
@@ -0,0 +1,489 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	appContext "warehouse-service/internal/context"
+	"warehouse-service/internal/entity"
+	appErrors "warehouse-service/internal/errors"
+	"warehouse-service/internal/gateway/product"
+	"warehouse-service/internal/model"
+	"warehouse-service/internal/repository"
+	mockRepository "warehouse-service/mocks/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// stubStockRepository returns canned per-warehouse stock for a product
+type stubStockRepository struct {
+	repository.StockRepositoryInterface
+
+	stocks          []repository.WarehouseProductStock
+	includeEmpty    bool
+	warehouseStocks []entity.WarehouseStock
+}
+
+func (r *stubStockRepository) GetProductStockByWarehouse(tx *gorm.DB, productID uint, includeEmpty bool) ([]repository.WarehouseProductStock, error) {
+	r.includeEmpty = includeEmpty
+	return r.stocks, nil
+}
+
+func (r *stubStockRepository) GetWarehouseStock(tx *gorm.DB, warehouseID uint, productID uint, limit, offset int) ([]entity.WarehouseStock, int64, error) {
+	return r.warehouseStocks, int64(len(r.warehouseStocks)), nil
+}
+
+// unavailableProductClient behaves like a product service that cannot be reached
+type unavailableProductClient struct {
+	product.ProductClientInterface
+}
+
+func (c *unavailableProductClient) GetProductByID(ctx context.Context, productID uint) (*product.ProductInfo, error) {
+	return nil, errors.New("product service unavailable")
+}
+
+func (c *unavailableProductClient) GetProductsByIDs(ctx context.Context, productIDs []uint) (map[uint]*product.ProductInfo, error) {
+	return nil, errors.New("product service unavailable")
+}
+
+// batchProductClient knows a fixed set of products and records every batch lookup
+type batchProductClient struct {
+	product.ProductClientInterface
+
+	products map[uint]*product.ProductInfo
+	calls    [][]uint
+}
+
+func (c *batchProductClient) GetProductsByIDs(ctx context.Context, productIDs []uint) (map[uint]*product.ProductInfo, error) {
+	c.calls = append(c.calls, productIDs)
+	return c.products, nil
+}
+
+func setupStockUsecaseTest(t *testing.T, stockRepo repository.StockRepositoryInterface) *StockUseCase {
+	// The repository is stubbed, so the database only has to open
+	usecase, _ := setupStockUsecaseWithDB(t)
+	usecase.StockRepo = stockRepo
+	return usecase
+}
+
+func setupStockUsecaseWithDB(t *testing.T) (*StockUseCase, sqlmock.Sqlmock) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard) // Suppress log output during tests
+
+	mockDb, mock, _ := sqlmock.New()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: mockDb, DriverName: "mysql"}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error opening DB connection: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	warehouseRepo := mockRepository.NewMockWarehouseRepositoryInterface(ctrl)
+	warehouseRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).DoAndReturn(func(db *gorm.DB, id uint) (*entity.Warehouse, error) {
+		return &entity.Warehouse{ID: id, IsActive: true}, nil
+	}).AnyTimes()
+
+	return &StockUseCase{
+		DB:            db,
+		Log:           logger,
+		Validate:      validator.New(),
+		StockRepo:     repository.NewStockRepository(logger, db),
+		WarehouseRepo: warehouseRepo,
+		ProductClient: &unavailableProductClient{},
+	}, mock
+}
+
+func TestStockUsecase_GetProductStockSummary(t *testing.T) {
+	t.Run("SumsAcrossWarehouses", func(t *testing.T) {
+		stockRepo := &stubStockRepository{
+			stocks: []repository.WarehouseProductStock{
+				{WarehouseID: 1, WarehouseName: "Jakarta", Quantity: 50, ReservedQuantity: 15},
+				{WarehouseID: 2, WarehouseName: "Bandung", Quantity: 20, ReservedQuantity: 0},
+				// Over-reserved rows count as zero available
+				{WarehouseID: 3, WarehouseName: "Surabaya", Quantity: 5, ReservedQuantity: 8},
+			},
+		}
+		usecase := setupStockUsecaseTest(t, stockRepo)
+
+		summary, err := usecase.GetProductStockSummary(context.Background(), 10, true)
+
+		assert.NoError(t, err)
+		assert.True(t, stockRepo.includeEmpty)
+		assert.Equal(t, uint(10), summary.ProductID)
+		assert.Len(t, summary.Warehouses, 3)
+		assert.Equal(t, 35, summary.Warehouses[0].AvailableQuantity)
+		assert.Equal(t, 0, summary.Warehouses[2].AvailableQuantity)
+		assert.Equal(t, 75, summary.TotalQuantity)
+		assert.Equal(t, 23, summary.TotalReservedQuantity)
+		assert.Equal(t, 55, summary.TotalAvailableQuantity)
+	})
+
+	t.Run("NoStock", func(t *testing.T) {
+		usecase := setupStockUsecaseTest(t, &stubStockRepository{})
+
+		summary, err := usecase.GetProductStockSummary(context.Background(), 10, false)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, summary.Warehouses)
+		assert.Empty(t, summary.Warehouses)
+		assert.Equal(t, 0, summary.TotalQuantity)
+	})
+
+	t.Run("InvalidProductID", func(t *testing.T) {
+		usecase := setupStockUsecaseTest(t, &stubStockRepository{})
+
+		summary, err := usecase.GetProductStockSummary(context.Background(), 0, false)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, summary)
+	})
+}
+
+func TestStockUsecase_GetWarehouseStock(t *testing.T) {
+	stockRepo := &stubStockRepository{
+		warehouseStocks: []entity.WarehouseStock{
+			{WarehouseID: 1, ProductID: 10, Quantity: 5},
+			{WarehouseID: 1, ProductID: 11, Quantity: 7},
+			{WarehouseID: 1, ProductID: 10, Quantity: 3},
+		},
+	}
+
+	t.Run("EnrichesPageInOneCall", func(t *testing.T) {
+		usecase := setupStockUsecaseTest(t, stockRepo)
+		usecase.Pagination = model.Pagination{DefaultLimit: model.DefaultPageLimit, MaxLimit: model.MaxPageLimit}
+		productClient := &batchProductClient{
+			products: map[uint]*product.ProductInfo{10: {ID: 10, SKU: "SKU-A", Name: "Product A"}},
+		}
+		usecase.ProductClient = productClient
+
+		result, err := usecase.GetWarehouseStock(context.Background(), 1, 0, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]uint{{10, 11}}, productClient.calls)
+		assert.Len(t, result.Items, 3)
+		assert.Equal(t, "Product A", result.Items[0].ProductName)
+		assert.Equal(t, "SKU-A", result.Items[0].SKU)
+		// Products unknown to the product service get mock details
+		assert.Equal(t, "Product 11", result.Items[1].ProductName)
+		assert.Equal(t, "Product A", result.Items[2].ProductName)
+	})
+
+	t.Run("ProductServiceUnavailable", func(t *testing.T) {
+		usecase := setupStockUsecaseTest(t, stockRepo)
+		usecase.Pagination = model.Pagination{DefaultLimit: model.DefaultPageLimit, MaxLimit: model.MaxPageLimit}
+
+		result, err := usecase.GetWarehouseStock(context.Background(), 1, 0, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 3)
+		assert.Equal(t, "Product 10", result.Items[0].ProductName)
+		assert.Equal(t, "SKU-10", result.Items[0].SKU)
+	})
+}
+
+// expectLockedTransferStocks expects the transfer to look up and lock the source (ID 1) and target (ID 2) stock rows
+func expectLockedTransferStocks(mock sqlmock.Sqlmock, sourceQuantity, targetQuantity int) {
+	columns := []string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "version"}
+
+	mock.ExpectQuery("SELECT `id` FROM `warehouse_stock` WHERE product_id = \\? AND warehouse_id IN \\(\\?,\\?\\) ORDER BY id").
+		WithArgs(10, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery("SELECT \\* FROM `warehouse_stock` WHERE `warehouse_stock`.`id` = \\? (.+) FOR UPDATE").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 1, 10, sourceQuantity, 0, 0))
+	mock.ExpectQuery("SELECT \\* FROM `warehouse_stock` WHERE `warehouse_stock`.`id` = \\? (.+) FOR UPDATE").
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 2, 10, targetQuantity, 0, 0))
+}
+
+func TestStockUsecase_TransferStock(t *testing.T) {
+	request := &model.StockTransferRequest{
+		SourceWarehouseID: 1,
+		TargetWarehouseID: 2,
+		ProductID:         10,
+		ProductSKU:        "SKU-10",
+		Quantity:          10,
+		Reference:         "TRF-1",
+	}
+
+	t.Run("RollsBackWhenTargetIncrementFails", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		mock.ExpectBegin()
+		expectLockedTransferStocks(mock, 50, 5)
+		// The source leg succeeds...
+		mock.ExpectExec("UPDATE `warehouse_stock` SET (.+) WHERE id = \\? AND version = \\?").
+			WithArgs(40, 0, sqlmock.AnyArg(), 1, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		// ...but the target leg fails
+		mock.ExpectExec("UPDATE `warehouse_stock` SET (.+) WHERE id = \\? AND version = \\?").
+			WithArgs(15, 0, sqlmock.AnyArg(), 2, 0).
+			WillReturnError(errors.New("lock wait timeout exceeded"))
+		// The whole transaction is rolled back, so the source keeps its 50 units; no commit may happen
+		mock.ExpectRollback()
+
+		response, err := usecase.TransferStock(context.Background(), request)
+
+		assert.Equal(t, fiber.ErrInternalServerError, err)
+		assert.Nil(t, response)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsInsufficientSourceStock", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		mock.ExpectBegin()
+		expectLockedTransferStocks(mock, 4, 5)
+		// Nothing is written before the source is known to cover the transfer
+		mock.ExpectRollback()
+
+		response, err := usecase.TransferStock(context.Background(), request)
+
+		assert.ErrorIs(t, err, appErrors.ErrBusinessRuleViolation)
+		assert.Nil(t, response)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsSameWarehouse", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		sameWarehouse := *request
+		sameWarehouse.TargetWarehouseID = sameWarehouse.SourceWarehouseID
+
+		response, err := usecase.TransferStock(context.Background(), &sameWarehouse)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, response)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// expectLockedStock expects the stock row of product 10 at warehouse 1 to be read with a row lock
+func expectLockedStock(mock sqlmock.Sqlmock, quantity, reserved int) {
+	mock.ExpectQuery("SELECT \\* FROM `warehouse_stock` WHERE warehouse_id = \\? AND product_id = \\? (.+) FOR UPDATE").
+		WithArgs(1, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "warehouse_id", "product_id", "quantity", "reserved_quantity", "version"}).
+			AddRow(7, 1, 10, quantity, reserved, 3))
+}
+
+func TestStockUsecase_AdjustStock(t *testing.T) {
+	adminCtx := appContext.WithUserID(context.Background(), "admin-1")
+
+	t.Run("PositiveDelta", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		mock.ExpectBegin()
+		expectLockedStock(mock, 20, 5)
+		mock.ExpectExec("UPDATE `warehouse_stock` SET (.+) WHERE id = \\? AND version = \\?").
+			WithArgs(25, 5, sqlmock.AnyArg(), 7, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		// The audit row records who made the change, the old and new quantity and why
+		mock.ExpectExec("INSERT INTO `stock_adjustments`").
+			WithArgs(1, 10, 5, 20, 25, "Found extra units during count", "admin-1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		// The correction also lands in the stock movement ledger, referencing the audit row
+		mock.ExpectExec("INSERT INTO `stock_movements`").
+			WithArgs(1, 10, "", entity.MovementTypeAdjust, 5, "adjustment", "1", "Found extra units during count", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		response, err := usecase.AdjustStock(adminCtx, 1, 10, 5, "  Found extra units during count ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 25, response.Quantity)
+		assert.Equal(t, 5, response.ReservedQuantity)
+		assert.Equal(t, 20, response.AvailableQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NegativeDelta", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		mock.ExpectBegin()
+		expectLockedStock(mock, 20, 5)
+		mock.ExpectExec("UPDATE `warehouse_stock` SET (.+) WHERE id = \\? AND version = \\?").
+			WithArgs(12, 5, sqlmock.AnyArg(), 7, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `stock_adjustments`").
+			WithArgs(1, 10, -8, 20, 12, "Damaged", "admin-1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO `stock_movements`").
+			WithArgs(1, 10, "", entity.MovementTypeAdjust, -8, "adjustment", "1", "Damaged", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		response, err := usecase.AdjustStock(adminCtx, 1, 10, -8, "Damaged")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 12, response.Quantity)
+		assert.Equal(t, 7, response.AvailableQuantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsGoingBelowZero", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		mock.ExpectBegin()
+		expectLockedStock(mock, 3, 0)
+		// Nothing is written and no audit row is recorded
+		mock.ExpectRollback()
+
+		response, err := usecase.AdjustStock(adminCtx, 1, 10, -4, "Count mismatch")
+
+		assert.ErrorIs(t, err, appErrors.ErrInsufficientStock)
+		assert.Nil(t, response)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsGoingBelowReserved", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		mock.ExpectBegin()
+		expectLockedStock(mock, 10, 8)
+		mock.ExpectRollback()
+
+		response, err := usecase.AdjustStock(adminCtx, 1, 10, -3, "Count mismatch")
+
+		assert.ErrorIs(t, err, appErrors.ErrInsufficientStock)
+		assert.Nil(t, response)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RejectsInvalidInput", func(t *testing.T) {
+		usecase, mock := setupStockUsecaseWithDB(t)
+
+		// A zero delta or a blank reason never reaches the database
+		for _, tc := range []struct {
+			delta  int
+			reason string
+		}{{0, "Recount"}, {5, "   "}} {
+			response, err := usecase.AdjustStock(adminCtx, 1, 10, tc.delta, tc.reason)
+
+			assert.Equal(t, fiber.ErrBadRequest, err)
+			assert.Nil(t, response)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// ledgerStockRepository replays a fixed list of stock movements, applying them to the stock the way the repositories do
+type ledgerStockRepository struct {
+	repository.StockRepositoryInterface
+
+	movements []entity.StockMovement
+}
+
+func (r *ledgerStockRepository) GetStockMovements(tx *gorm.DB, warehouseID, productID uint, limit, offset int) ([]entity.StockMovement, int64, error) {
+	end := min(offset+limit, len(r.movements))
+	if offset >= end {
+		return nil, int64(len(r.movements)), nil
+	}
+	return r.movements[offset:end], int64(len(r.movements)), nil
+}
+
+func (r *ledgerStockRepository) SumStockMovementsBefore(tx *gorm.DB, warehouseID, productID, movementID uint) (repository.StockBalance, error) {
+	var balance repository.StockBalance
+	for _, movement := range r.movements {
+		if movement.ID < movementID {
+			balance.Quantity += movement.QuantityChange()
+			balance.ReservedQuantity += movement.ReservedChange()
+		}
+	}
+	return balance, nil
+}
+
+func (r *ledgerStockRepository) GetProductAvailability(tx *gorm.DB, warehouseID, productID uint) (*entity.WarehouseStock, error) {
+	stock := &entity.WarehouseStock{WarehouseID: warehouseID, ProductID: productID}
+	for _, movement := range r.movements {
+		switch movement.MovementType {
+		case entity.MovementTypeStockIn, entity.MovementTypeTransferIn, entity.MovementTypeAdjust:
+			stock.Quantity += movement.Quantity
+		case entity.MovementTypeTransferOut:
+			stock.Quantity -= movement.Quantity
+		case entity.MovementTypeReserve:
+			stock.ReservedQuantity += movement.Quantity
+		case entity.MovementTypeRelease:
+			stock.ReservedQuantity -= movement.Quantity
+		case entity.MovementTypeCommit:
+			stock.Quantity -= movement.Quantity
+			stock.ReservedQuantity -= movement.Quantity
+		}
+	}
+	stock.CalculateAvailableQuantity()
+	return stock, nil
+}
+
+func TestStockUsecase_GetStockHistory(t *testing.T) {
+	stockRepo := &ledgerStockRepository{movements: []entity.StockMovement{
+		{ID: 1, MovementType: entity.MovementTypeStockIn, Quantity: 20, ReferenceType: "manual"},
+		{ID: 2, MovementType: entity.MovementTypeReserve, Quantity: 5, ReferenceType: "reservation", ReferenceID: "RSV-1"},
+		{ID: 3, MovementType: entity.MovementTypeTransferOut, Quantity: 3, ReferenceType: "transfer"},
+		{ID: 4, MovementType: entity.MovementTypeCommit, Quantity: 2, ReferenceType: "reservation", ReferenceID: "RSV-1"},
+		{ID: 5, MovementType: entity.MovementTypeRelease, Quantity: 1, ReferenceType: "reservation", ReferenceID: "RSV-1"},
+		{ID: 6, MovementType: entity.MovementTypeAdjust, Quantity: -4, ReferenceType: "adjustment", Notes: "Damaged"},
+		{ID: 7, MovementType: entity.MovementTypeTransferIn, Quantity: 6, ReferenceType: "transfer"},
+	}}
+	usecase := setupStockUsecaseTest(t, stockRepo)
+
+	t.Run("RunningBalanceReconcilesToCurrentStock", func(t *testing.T) {
+		history, err := usecase.GetStockHistory(context.Background(), 1, 10, 1, 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), history.Total)
+		assert.Equal(t, 0, history.OpeningBalance)
+		if assert.Len(t, history.Movements, 7) {
+			// The reservation holds stock without changing what is on hand
+			assert.Equal(t, 20, history.Movements[1].Balance)
+			assert.Equal(t, 5, history.Movements[1].ReservedBalance)
+			assert.Equal(t, 15, history.Movements[1].AvailableBalance)
+
+			// Committing takes the units off both the quantity on hand and the reservation
+			assert.Equal(t, -2, history.Movements[3].QuantityChange)
+			assert.Equal(t, -2, history.Movements[3].ReservedChange)
+
+			// Adjustments keep their sign
+			assert.Equal(t, -4, history.Movements[5].QuantityChange)
+
+			last := history.Movements[6]
+			assert.Equal(t, history.CurrentQuantity, last.Balance)
+			assert.Equal(t, history.CurrentReservedQuantity, last.ReservedBalance)
+			assert.Equal(t, 17, last.Balance)
+			assert.Equal(t, 2, last.ReservedBalance)
+		}
+	})
+
+	t.Run("LaterPagesContinueTheBalance", func(t *testing.T) {
+		full, err := usecase.GetStockHistory(context.Background(), 1, 10, 1, 100)
+		assert.NoError(t, err)
+
+		// Walking the ledger in pages of three gives the same balances as reading it at once
+		var paged []model.StockMovementResponse
+		for page := 1; page <= 3; page++ {
+			history, err := usecase.GetStockHistory(context.Background(), 1, 10, page, 3)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3), history.TotalPages)
+			paged = append(paged, history.Movements...)
+
+			if page == 3 {
+				assert.Equal(t, 11, history.OpeningBalance)
+				assert.Equal(t, 2, history.OpeningReservedBalance)
+			}
+		}
+		assert.Equal(t, full.Movements, paged)
+	})
+
+	t.Run("RejectsInvalidIDs", func(t *testing.T) {
+		history, err := usecase.GetStockHistory(context.Background(), 0, 10, 1, 10)
+
+		assert.Equal(t, fiber.ErrBadRequest, err)
+		assert.Nil(t, history)
+	})
+}
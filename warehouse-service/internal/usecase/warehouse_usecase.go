@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"warehouse-service/internal/entity"
 	appErrors "warehouse-service/internal/errors"
 	"warehouse-service/internal/model"
 	"warehouse-service/internal/model/converter"
@@ -14,16 +17,13 @@ import (
 	"gorm.io/gorm"
 )
 
-const (
-	defaultPage  = 1
-	defaultLimit = 20
-)
-
 type WarehouseUseCaseInterface interface {
 	GetWarehouse(ctx context.Context, id uint) (*model.WarehouseResponse, error)
+	GetWarehousesByIDs(ctx context.Context, ids []uint) (*model.WarehouseBatchResponse, error)
 	CreateWarehouse(ctx context.Context, request *model.CreateWarehouseRequest) (*model.WarehouseResponse, error)
 	UpdateWarehouse(ctx context.Context, request *model.UpdateWarehouseRequest) (*model.WarehouseResponse, error)
-	DeleteWarehouse(ctx context.Context, id uint) error
+	DeleteWarehouse(ctx context.Context, id uint, force bool) error
+	RestoreWarehouse(ctx context.Context, id uint) (*model.WarehouseResponse, error)
 	ListWarehouses(ctx context.Context, request *model.ListWarehouseRequest) (*model.WarehouseListResponse, error)
 }
 
@@ -32,6 +32,8 @@ type WarehouseUseCase struct {
 	Log                *logrus.Logger
 	Validate           *validator.Validate
 	WarehouseRepository repository.WarehouseRepositoryInterface
+	// Pagination bounds the page size of ListWarehouses; zero fields use the defaults
+	Pagination model.Pagination
 }
 
 func NewWarehouseUseCase(
@@ -39,12 +41,14 @@ func NewWarehouseUseCase(
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	warehouseRepository repository.WarehouseRepositoryInterface,
+	pagination model.Pagination,
 ) WarehouseUseCaseInterface {
 	return &WarehouseUseCase{
 		DB:                 db,
 		Log:                logger,
 		Validate:           validate,
 		WarehouseRepository: warehouseRepository,
+		Pagination:         pagination,
 	}
 }
 
@@ -73,6 +77,52 @@ func (c *WarehouseUseCase) GetWarehouse(ctx context.Context, id uint) (*model.Wa
 	return converter.WarehouseToResponse(warehouse, stats), nil
 }
 
+// GetWarehousesByIDs retrieves several warehouses with their statistics in one call.
+// Warehouses are returned in the order of ids, duplicates are returned once and unknown IDs are skipped.
+func (c *WarehouseUseCase) GetWarehousesByIDs(ctx context.Context, ids []uint) (*model.WarehouseBatchResponse, error) {
+	if len(ids) == 0 || len(ids) > model.MaxWarehouseBatchSize {
+		return nil, appErrors.WithMessage(appErrors.ErrInvalidInput,
+			fmt.Sprintf("Between 1 and %d warehouse IDs are required", model.MaxWarehouseBatchSize))
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// Find the warehouses
+	warehouses, err := c.WarehouseRepository.FindByIDs(tx, ids)
+	if err != nil {
+		c.Log.WithError(err).Error("Failed to find warehouses")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	warehousesByID := make(map[uint]*entity.Warehouse, len(warehouses))
+	for i := range warehouses {
+		warehousesByID[warehouses[i].ID] = &warehouses[i]
+	}
+
+	// Build the response in request order
+	response := &model.WarehouseBatchResponse{
+		Warehouses: make([]model.WarehouseResponse, 0, len(warehouses)),
+	}
+	stats, err := c.getWarehouseStatsBatch(tx, warehouses)
+	if err != nil {
+		c.Log.WithError(err).Error("Failed to get warehouse statistics")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	for _, id := range ids {
+		warehouse, ok := warehousesByID[id]
+		if !ok {
+			continue
+		}
+		delete(warehousesByID, id)
+
+		response.Warehouses = append(response.Warehouses, *converter.WarehouseToResponse(warehouse, stats[id]))
+	}
+
+	return response, nil
+}
+
 // CreateWarehouse creates a new warehouse
 func (c *WarehouseUseCase) CreateWarehouse(ctx context.Context, request *model.CreateWarehouseRequest) (*model.WarehouseResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
@@ -152,8 +202,9 @@ func (c *WarehouseUseCase) UpdateWarehouse(ctx context.Context, request *model.U
 	return converter.WarehouseToResponse(warehouse, stats), nil
 }
 
-// DeleteWarehouse deletes a warehouse by ID
-func (c *WarehouseUseCase) DeleteWarehouse(ctx context.Context, id uint) error {
+// DeleteWarehouse soft-deletes a warehouse by ID. A warehouse that still holds stock is only
+// deleted when force is set; otherwise ErrWarehouseNotEmpty is returned. It can be brought back with RestoreWarehouse.
+func (c *WarehouseUseCase) DeleteWarehouse(ctx context.Context, id uint, force bool) error {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -167,6 +218,18 @@ func (c *WarehouseUseCase) DeleteWarehouse(ctx context.Context, id uint) error {
 		return fiber.ErrInternalServerError
 	}
 
+	// Refuse to delete a warehouse that still holds stock unless forced
+	if !force {
+		totalItems, err := c.WarehouseRepository.GetTotalItemCount(tx, id)
+		if err != nil {
+			c.Log.WithError(err).Error("Failed to get warehouse item count")
+			return fiber.ErrInternalServerError
+		}
+		if totalItems != 0 {
+			return appErrors.ErrWarehouseNotEmpty
+		}
+	}
+
 	// Delete the warehouse
 	if err := c.WarehouseRepository.Delete(tx, id); err != nil {
 		c.Log.WithError(err).Error("Failed to delete warehouse")
@@ -182,27 +245,71 @@ func (c *WarehouseUseCase) DeleteWarehouse(ctx context.Context, id uint) error {
 	return nil
 }
 
-// ListWarehouses lists warehouses with pagination
+// RestoreWarehouse undoes the soft delete of a warehouse. Restoring a warehouse that is not deleted is a no-op.
+func (c *WarehouseUseCase) RestoreWarehouse(ctx context.Context, id uint) (*model.WarehouseResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// Find the warehouse, including soft-deleted ones
+	warehouse, err := c.WarehouseRepository.FindByIDWithDeleted(tx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		c.Log.WithError(err).Error("Failed to find warehouse")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Restore the warehouse
+	if warehouse.DeletedAt.Valid {
+		if err := c.WarehouseRepository.Restore(tx, id); err != nil {
+			c.Log.WithError(err).Error("Failed to restore warehouse")
+			return nil, fiber.ErrInternalServerError
+		}
+		warehouse.DeletedAt = gorm.DeletedAt{}
+	}
+
+	// Get warehouse statistics
+	stats, err := c.getWarehouseStats(tx, warehouse.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("Failed to get warehouse statistics")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("Failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WarehouseToResponse(warehouse, stats), nil
+}
+
+// ListWarehouses lists warehouses with pagination, optionally filtered by search term, location and active status
 func (c *WarehouseUseCase) ListWarehouses(ctx context.Context, request *model.ListWarehouseRequest) (*model.WarehouseListResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	// Set default pagination values if not provided
-	page := defaultPage
-	limit := defaultLimit
+	// Set default pagination values if not provided; an over-large limit is clamped
+	page := 1
+	limit := c.Pagination.Limit(0)
+	var filter repository.WarehouseFilter
 	if request != nil {
 		if request.Page > 0 {
 			page = request.Page
 		}
-		if request.Limit > 0 && request.Limit <= 100 {
-			limit = request.Limit
+		limit = c.Pagination.Limit(request.Limit)
+		filter = repository.WarehouseFilter{
+			Search:     strings.TrimSpace(request.Search),
+			Location:   strings.TrimSpace(request.Location),
+			ActiveOnly: request.ActiveOnly,
 		}
 	}
 
 	offset := (page - 1) * limit
 
 	// Get warehouses
-	warehouses, total, err := c.WarehouseRepository.List(tx, limit, offset)
+	warehouses, total, err := c.WarehouseRepository.List(tx, filter, limit, offset)
 	if err != nil {
 		c.Log.WithError(err).Error("Failed to list warehouses")
 		return nil, fiber.ErrInternalServerError
@@ -216,15 +323,15 @@ func (c *WarehouseUseCase) ListWarehouses(ctx context.Context, request *model.Li
 		Limit:      limit,
 	}
 
-	// Get stats for each warehouse and build response
+	// Get the stats of the whole page in one query and build response
+	stats, err := c.getWarehouseStatsBatch(tx, warehouses)
+	if err != nil {
+		c.Log.WithError(err).Error("Failed to get warehouse statistics")
+		return nil, fiber.ErrInternalServerError
+	}
+
 	for _, warehouse := range warehouses {
-		stats, err := c.getWarehouseStats(tx, warehouse.ID)
-		if err != nil {
-			c.Log.WithError(err).Error("Failed to get warehouse statistics")
-			continue
-		}
-		
-		warehouseResponse := converter.WarehouseToResponse(&warehouse, stats)
+		warehouseResponse := converter.WarehouseToResponse(&warehouse, stats[warehouse.ID])
 		response.Warehouses = append(response.Warehouses, *warehouseResponse)
 	}
 
@@ -237,22 +344,39 @@ func (c *WarehouseUseCase) ListWarehouses(ctx context.Context, request *model.Li
 	return response, nil
 }
 
-// getWarehouseStats gets statistics for a warehouse
+// getWarehouseStats gets statistics for a warehouse, using the same aggregate query as getWarehouseStatsBatch
 func (c *WarehouseUseCase) getWarehouseStats(tx *gorm.DB, warehouseID uint) (*model.WarehouseStatsDTO, error) {
-	// Get product count
-	productCount, err := c.WarehouseRepository.GetProductCount(tx, warehouseID)
+	stats, err := c.getWarehouseStatsBatch(tx, []entity.Warehouse{{ID: warehouseID}})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get total item count
-	totalItems, err := c.WarehouseRepository.GetTotalItemCount(tx, warehouseID)
+	return stats[warehouseID], nil
+}
+
+// getWarehouseStatsBatch gets statistics for several warehouses with a single aggregate query.
+// Every warehouse gets an entry; those without stock have zero counts.
+func (c *WarehouseUseCase) getWarehouseStatsBatch(tx *gorm.DB, warehouses []entity.Warehouse) (map[uint]*model.WarehouseStatsDTO, error) {
+	ids := make([]uint, len(warehouses))
+	stats := make(map[uint]*model.WarehouseStatsDTO, len(warehouses))
+	for i, warehouse := range warehouses {
+		ids[i] = warehouse.ID
+		stats[warehouse.ID] = &model.WarehouseStatsDTO{}
+	}
+
+	totals, err := c.WarehouseRepository.SumStockByWarehouseIDs(tx, ids)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.WarehouseStatsDTO{
-		TotalProducts: productCount,
-		TotalItems:    totalItems,
-	}, nil
+	for _, total := range totals {
+		if warehouseStats, ok := stats[total.WarehouseID]; ok {
+			warehouseStats.TotalProducts = total.TotalProducts
+			warehouseStats.TotalItems = total.TotalItems
+			warehouseStats.TotalReserved = total.TotalReserved
+			warehouseStats.TotalAvailable = total.TotalItems - total.TotalReserved
+		}
+	}
+
+	return stats, nil
 }
\ No newline at end of file
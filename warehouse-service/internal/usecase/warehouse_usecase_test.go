@@ -1,13 +1,22 @@
 package usecase
 
 import (
+	"context"
 	"io"
 	"testing"
+	"time"
+	"warehouse-service/internal/entity"
+	appErrors "warehouse-service/internal/errors"
+	"warehouse-service/internal/model"
+	warehouseRepository "warehouse-service/internal/repository"
 	"warehouse-service/mocks/repository"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
@@ -31,6 +40,21 @@ func setupWarehouseUsecaseTest(t *testing.T) (*WarehouseUseCase, *repository.Moc
 	return usecase, mockRepo, db
 }
 
+// setupWarehouseUsecaseWithDB backs the use case with sqlmock so transactions can begin and commit
+func setupWarehouseUsecaseWithDB(t *testing.T) (*WarehouseUseCase, *repository.MockWarehouseRepositoryInterface, sqlmock.Sqlmock) {
+	usecase, mockRepo, _ := setupWarehouseUsecaseTest(t)
+
+	mockDb, mock, _ := sqlmock.New()
+	mock.ExpectQuery("SELECT VERSION()").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("8.0.28"))
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: mockDb, DriverName: "mysql"}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error opening DB connection: %v", err)
+	}
+	usecase.DB = db
+
+	return usecase, mockRepo, mock
+}
+
 func TestWarehouseUsecase_GetWarehouse(t *testing.T) {
 	// Skip this test for now as it requires a full DB mockup
 	t.Skip("Skipping test that requires DB transaction mockup")
@@ -45,4 +69,206 @@ func TestWarehouseUsecase_GetWarehouse_NotFound(t *testing.T) {
 	
 	// This test would require mocking GORM's transaction behavior
 	// which is complex and outside the scope of this fix
-}
\ No newline at end of file
+}
+
+func TestWarehouseUsecase_GetWarehousesByIDs_InvalidCount(t *testing.T) {
+	usecase, _, _ := setupWarehouseUsecaseTest(t)
+
+	tooMany := make([]uint, model.MaxWarehouseBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = uint(i + 1)
+	}
+
+	for _, ids := range [][]uint{nil, tooMany} {
+		response, err := usecase.GetWarehousesByIDs(context.Background(), ids)
+
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, appErrors.ErrInvalidInput)
+	}
+}
+
+func TestWarehouseUsecase_DeleteWarehouse_NotEmpty(t *testing.T) {
+	usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Warehouse{ID: 1, IsActive: true}, nil)
+	mockRepo.EXPECT().GetTotalItemCount(gomock.Any(), uint(1)).Return(int64(25), nil)
+
+	// The warehouse is not deleted while it still holds stock
+	err := usecase.DeleteWarehouse(context.Background(), 1, false)
+
+	assert.ErrorIs(t, err, appErrors.ErrWarehouseNotEmpty)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_DeleteWarehouse_Empty(t *testing.T) {
+	usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Warehouse{ID: 1, IsActive: true}, nil)
+	mockRepo.EXPECT().GetTotalItemCount(gomock.Any(), uint(1)).Return(int64(0), nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+	err := usecase.DeleteWarehouse(context.Background(), 1, false)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_DeleteWarehouse_Force(t *testing.T) {
+	usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mockRepo.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&entity.Warehouse{ID: 1, IsActive: true}, nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+	// Forcing skips the stock check entirely
+	err := usecase.DeleteWarehouse(context.Background(), 1, true)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_RestoreWarehouse(t *testing.T) {
+	usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+
+	deletedAt := gorm.DeletedAt{Time: time.Date(2025, 5, 27, 10, 0, 0, 0, time.UTC), Valid: true}
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mockRepo.EXPECT().FindByIDWithDeleted(gomock.Any(), uint(1)).Return(&entity.Warehouse{ID: 1, Name: "Warehouse 1", IsActive: true, DeletedAt: deletedAt}, nil)
+	mockRepo.EXPECT().Restore(gomock.Any(), uint(1)).Return(nil)
+	mockRepo.EXPECT().SumStockByWarehouseIDs(gomock.Any(), []uint{1}).
+		Return([]warehouseRepository.WarehouseStockTotal{{WarehouseID: 1, TotalProducts: 2, TotalItems: 30, TotalReserved: 5}}, nil)
+
+	response, err := usecase.RestoreWarehouse(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.ID)
+	assert.Equal(t, int64(30), response.Stats.TotalItems)
+	assert.Equal(t, int64(25), response.Stats.TotalAvailable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_RestoreWarehouse_NotDeleted(t *testing.T) {
+	usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mockRepo.EXPECT().FindByIDWithDeleted(gomock.Any(), uint(1)).Return(&entity.Warehouse{ID: 1, Name: "Warehouse 1", IsActive: true}, nil)
+	mockRepo.EXPECT().SumStockByWarehouseIDs(gomock.Any(), []uint{1}).Return(nil, nil)
+
+	// Restoring a warehouse that is not deleted leaves it unchanged
+	response, err := usecase.RestoreWarehouse(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_RestoreWarehouse_NotFound(t *testing.T) {
+	usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mockRepo.EXPECT().FindByIDWithDeleted(gomock.Any(), uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	response, err := usecase.RestoreWarehouse(context.Background(), 99)
+
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, appErrors.ErrResourceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_ListWarehouses_StatsInOneQuery(t *testing.T) {
+	usecase, _, mock := setupWarehouseUsecaseWithDB(t)
+	usecase.WarehouseRepository = warehouseRepository.NewWarehouseRepository(usecase.Log, usecase.DB)
+
+	// sqlmock fails on any query not listed here, so the page costs three queries however many warehouses it holds
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `warehouses`").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT \\* FROM `warehouses` WHERE `warehouses`.`deleted_at` IS NULL LIMIT \\?").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "location", "address", "is_active"}).
+			AddRow(1, "Warehouse 1", "Jakarta", "Address 1", true).
+			AddRow(2, "Warehouse 2", "Bandung", "Address 2", true).
+			AddRow(3, "Warehouse 3", "Surabaya", "Address 3", false))
+	mock.ExpectQuery("SELECT warehouse_id, COUNT\\(\\*\\) AS total_products, COALESCE\\(SUM\\(quantity\\), 0\\) AS total_items, COALESCE\\(SUM\\(reserved_quantity\\), 0\\) AS total_reserved FROM `warehouse_stock` WHERE warehouse_id IN \\(\\?,\\?,\\?\\) GROUP BY `warehouse_id`").
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_id", "total_products", "total_items", "total_reserved"}).
+			AddRow(1, 2, 30, 10).
+			AddRow(3, 4, 120, 0))
+	mock.ExpectCommit()
+
+	response, err := usecase.ListWarehouses(context.Background(), &model.ListWarehouseRequest{Page: 1, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), response.Total)
+	assert.Len(t, response.Warehouses, 3)
+	assert.Equal(t, &model.WarehouseStatsDTO{TotalProducts: 2, TotalItems: 30, TotalReserved: 10, TotalAvailable: 20}, response.Warehouses[0].Stats)
+	// A warehouse without stock rows reports zero rather than being dropped
+	assert.Equal(t, &model.WarehouseStatsDTO{}, response.Warehouses[1].Stats)
+	assert.Equal(t, &model.WarehouseStatsDTO{TotalProducts: 4, TotalItems: 120, TotalReserved: 0, TotalAvailable: 120}, response.Warehouses[2].Stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_GetWarehouse_ReservedSplit(t *testing.T) {
+	usecase, _, mock := setupWarehouseUsecaseWithDB(t)
+	usecase.WarehouseRepository = warehouseRepository.NewWarehouseRepository(usecase.Log, usecase.DB)
+
+	// Two products: 40 units with 15 reserved by pending reservations, and 10 units with 3 reserved
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `warehouses` WHERE id = \\?").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "location", "address", "is_active"}).
+			AddRow(1, "Warehouse 1", "Jakarta", "Address 1", true))
+	mock.ExpectQuery("SELECT warehouse_id, COUNT\\(\\*\\) AS total_products, COALESCE\\(SUM\\(quantity\\), 0\\) AS total_items, COALESCE\\(SUM\\(reserved_quantity\\), 0\\) AS total_reserved FROM `warehouse_stock` WHERE warehouse_id IN \\(\\?\\) GROUP BY `warehouse_id`").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_id", "total_products", "total_items", "total_reserved"}).
+			AddRow(1, 2, 40+10, 15+3))
+	mock.ExpectRollback()
+
+	response, err := usecase.GetWarehouse(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.WarehouseStatsDTO{TotalProducts: 2, TotalItems: 50, TotalReserved: 18, TotalAvailable: 32}, response.Stats)
+	assert.Equal(t, response.Stats.TotalItems, response.Stats.TotalReserved+response.Stats.TotalAvailable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWarehouseUsecase_ListWarehouses_Limit(t *testing.T) {
+	tests := []struct {
+		name          string
+		pagination    model.Pagination
+		limit         int
+		expectedLimit int
+	}{
+		{"DefaultWhenUnset", model.Pagination{}, 0, 20},
+		{"OverMaxIsClamped", model.Pagination{}, 500, 100},
+		{"ConfiguredDefault", model.Pagination{DefaultLimit: 5, MaxLimit: 50}, 0, 5},
+		{"ConfiguredMaxIsClamped", model.Pagination{DefaultLimit: 5, MaxLimit: 50}, 80, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase, mockRepo, mock := setupWarehouseUsecaseWithDB(t)
+			usecase.Pagination = tt.pagination
+
+			// The over-large limit is clamped rather than rejected, and the offset uses the clamped limit
+			mock.ExpectBegin()
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any(), tt.expectedLimit, tt.expectedLimit).Return([]entity.Warehouse{}, int64(0), nil)
+			mockRepo.EXPECT().SumStockByWarehouseIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			mock.ExpectCommit()
+
+			response, err := usecase.ListWarehouses(context.Background(), &model.ListWarehouseRequest{Page: 2, Limit: tt.limit})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, response.Limit)
+			assert.Equal(t, 2, response.Page)
+		})
+	}
+}
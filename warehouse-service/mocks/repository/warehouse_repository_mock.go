@@ -12,6 +12,7 @@ package repository
 import (
 	reflect "reflect"
 	entity "warehouse-service/internal/entity"
+	repository "warehouse-service/internal/repository"
 
 	gomock "go.uber.org/mock/gomock"
 	gorm "gorm.io/gorm"
@@ -84,6 +85,36 @@ func (mr *MockWarehouseRepositoryInterfaceMockRecorder) FindByID(db, id any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).FindByID), db, id)
 }
 
+// FindByIDWithDeleted mocks base method.
+func (m *MockWarehouseRepositoryInterface) FindByIDWithDeleted(db *gorm.DB, id uint) (*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDWithDeleted", db, id)
+	ret0, _ := ret[0].(*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDWithDeleted indicates an expected call of FindByIDWithDeleted.
+func (mr *MockWarehouseRepositoryInterfaceMockRecorder) FindByIDWithDeleted(db, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDWithDeleted", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).FindByIDWithDeleted), db, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockWarehouseRepositoryInterface) FindByIDs(db *gorm.DB, ids []uint) ([]entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", db, ids)
+	ret0, _ := ret[0].([]entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockWarehouseRepositoryInterfaceMockRecorder) FindByIDs(db, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).FindByIDs), db, ids)
+}
+
 // GetProductCount mocks base method.
 func (m *MockWarehouseRepositoryInterface) GetProductCount(db *gorm.DB, warehouseID uint) (int64, error) {
 	m.ctrl.T.Helper()
@@ -130,9 +161,9 @@ func (mr *MockWarehouseRepositoryInterfaceMockRecorder) GetWarehouseStock(db, wa
 }
 
 // List mocks base method.
-func (m *MockWarehouseRepositoryInterface) List(db *gorm.DB, limit, offset int) ([]entity.Warehouse, int64, error) {
+func (m *MockWarehouseRepositoryInterface) List(db *gorm.DB, filter repository.WarehouseFilter, limit, offset int) ([]entity.Warehouse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", db, limit, offset)
+	ret := m.ctrl.Call(m, "List", db, filter, limit, offset)
 	ret0, _ := ret[0].([]entity.Warehouse)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -140,9 +171,9 @@ func (m *MockWarehouseRepositoryInterface) List(db *gorm.DB, limit, offset int)
 }
 
 // List indicates an expected call of List.
-func (mr *MockWarehouseRepositoryInterfaceMockRecorder) List(db, limit, offset any) *gomock.Call {
+func (mr *MockWarehouseRepositoryInterfaceMockRecorder) List(db, filter, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).List), db, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).List), db, filter, limit, offset)
 }
 
 // ListWarehouseStock mocks base method.
@@ -161,6 +192,35 @@ func (mr *MockWarehouseRepositoryInterfaceMockRecorder) ListWarehouseStock(db, w
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWarehouseStock", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).ListWarehouseStock), db, warehouseID, limit, offset)
 }
 
+// Restore mocks base method.
+func (m *MockWarehouseRepositoryInterface) Restore(db *gorm.DB, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", db, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockWarehouseRepositoryInterfaceMockRecorder) Restore(db, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).Restore), db, id)
+}
+
+// SumStockByWarehouseIDs mocks base method.
+func (m *MockWarehouseRepositoryInterface) SumStockByWarehouseIDs(db *gorm.DB, warehouseIDs []uint) ([]repository.WarehouseStockTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumStockByWarehouseIDs", db, warehouseIDs)
+	ret0, _ := ret[0].([]repository.WarehouseStockTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumStockByWarehouseIDs indicates an expected call of SumStockByWarehouseIDs.
+func (mr *MockWarehouseRepositoryInterfaceMockRecorder) SumStockByWarehouseIDs(db, warehouseIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumStockByWarehouseIDs", reflect.TypeOf((*MockWarehouseRepositoryInterface)(nil).SumStockByWarehouseIDs), db, warehouseIDs)
+}
+
 // Update mocks base method.
 func (m *MockWarehouseRepositoryInterface) Update(db *gorm.DB, warehouse *entity.Warehouse) error {
 	m.ctrl.T.Helper()
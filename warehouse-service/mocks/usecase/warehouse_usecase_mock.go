@@ -57,17 +57,17 @@ func (mr *MockWarehouseUseCaseInterfaceMockRecorder) CreateWarehouse(ctx, reques
 }
 
 // DeleteWarehouse mocks base method.
-func (m *MockWarehouseUseCaseInterface) DeleteWarehouse(ctx context.Context, id uint) error {
+func (m *MockWarehouseUseCaseInterface) DeleteWarehouse(ctx context.Context, id uint, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWarehouse", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteWarehouse", ctx, id, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteWarehouse indicates an expected call of DeleteWarehouse.
-func (mr *MockWarehouseUseCaseInterfaceMockRecorder) DeleteWarehouse(ctx, id any) *gomock.Call {
+func (mr *MockWarehouseUseCaseInterfaceMockRecorder) DeleteWarehouse(ctx, id, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarehouse", reflect.TypeOf((*MockWarehouseUseCaseInterface)(nil).DeleteWarehouse), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarehouse", reflect.TypeOf((*MockWarehouseUseCaseInterface)(nil).DeleteWarehouse), ctx, id, force)
 }
 
 // GetWarehouse mocks base method.
@@ -85,6 +85,21 @@ func (mr *MockWarehouseUseCaseInterfaceMockRecorder) GetWarehouse(ctx, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouse", reflect.TypeOf((*MockWarehouseUseCaseInterface)(nil).GetWarehouse), ctx, id)
 }
 
+// GetWarehousesByIDs mocks base method.
+func (m *MockWarehouseUseCaseInterface) GetWarehousesByIDs(ctx context.Context, ids []uint) (*model.WarehouseBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarehousesByIDs", ctx, ids)
+	ret0, _ := ret[0].(*model.WarehouseBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarehousesByIDs indicates an expected call of GetWarehousesByIDs.
+func (mr *MockWarehouseUseCaseInterfaceMockRecorder) GetWarehousesByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehousesByIDs", reflect.TypeOf((*MockWarehouseUseCaseInterface)(nil).GetWarehousesByIDs), ctx, ids)
+}
+
 // ListWarehouses mocks base method.
 func (m *MockWarehouseUseCaseInterface) ListWarehouses(ctx context.Context, request *model.ListWarehouseRequest) (*model.WarehouseListResponse, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +115,21 @@ func (mr *MockWarehouseUseCaseInterfaceMockRecorder) ListWarehouses(ctx, request
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWarehouses", reflect.TypeOf((*MockWarehouseUseCaseInterface)(nil).ListWarehouses), ctx, request)
 }
 
+// RestoreWarehouse mocks base method.
+func (m *MockWarehouseUseCaseInterface) RestoreWarehouse(ctx context.Context, id uint) (*model.WarehouseResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWarehouse", ctx, id)
+	ret0, _ := ret[0].(*model.WarehouseResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreWarehouse indicates an expected call of RestoreWarehouse.
+func (mr *MockWarehouseUseCaseInterfaceMockRecorder) RestoreWarehouse(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWarehouse", reflect.TypeOf((*MockWarehouseUseCaseInterface)(nil).RestoreWarehouse), ctx, id)
+}
+
 // UpdateWarehouse mocks base method.
 func (m *MockWarehouseUseCaseInterface) UpdateWarehouse(ctx context.Context, request *model.UpdateWarehouseRequest) (*model.WarehouseResponse, error) {
 	m.ctrl.T.Helper()